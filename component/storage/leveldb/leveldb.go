@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package leveldb
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -218,22 +219,9 @@ type store struct {
 
 // Put stores the key and the record.
 func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
-	if key == "" {
-		return errors.New("key cannot be blank")
-	}
-
-	if value == nil {
-		return errors.New("value cannot be nil")
-	}
-
-	for _, tag := range tags {
-		if strings.Contains(tag.Name, ":") {
-			return fmt.Errorf(invalidTagName, tag.Name)
-		}
-
-		if strings.Contains(tag.Value, ":") {
-			return fmt.Errorf(invalidTagValue, tag.Value)
-		}
+	err := checkPutArgs(key, value, tags)
+	if err != nil {
+		return err
 	}
 
 	var newDBEntry dbEntry
@@ -242,7 +230,7 @@ func (s *store) Put(key string, value []byte, tags ...storage.Tag) error {
 	if len(tags) > 0 {
 		newDBEntry.Tags = tags
 
-		err := s.updateTagMap(key, tags)
+		err = s.updateTagMap(key, tags)
 		if err != nil {
 			return fmt.Errorf("failed to update tag map: %w", err)
 		}
@@ -311,37 +299,87 @@ func (s *store) Query(expression string, options ...storage.QueryOption) (storag
 		return nil, err
 	}
 
-	if expression == "" {
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	matchingDatabaseKeys, err := s.getDatabaseKeysMatchingExpression(expression)
+	if err != nil {
+		return nil, err
 	}
 
-	tagMap, err := s.getTagMap()
+	return &iterator{keys: matchingDatabaseKeys, totalItems: len(matchingDatabaseKeys), store: s}, nil
+}
+
+// QueryWithPagination returns at most pageSize entries that satisfy the expression, ordered by key, starting after
+// the entry the bookmark points to. See spi.PaginatedStore for more information.
+func (s *store) QueryWithPagination(expression string, pageSize int,
+	bookmark string) (storage.Iterator, string, error) {
+	if pageSize <= 0 {
+		return nil, "", errors.New("page size must be greater than 0")
+	}
+
+	lastKey, err := base64.RawURLEncoding.DecodeString(bookmark)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tag map: %w", err)
+		return nil, "", fmt.Errorf("invalid bookmark: %w", err)
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
+	tagName, tagValue, err := parseQueryExpression(expression)
+	if err != nil {
+		return nil, "", err
+	}
 
-		matchingDatabaseKeys := getDatabaseKeysMatchingTagName(tagMap, expressionTagName)
+	dbIterator := s.db.NewIterator(nil, nil)
+	defer dbIterator.Release()
 
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
+	// LevelDB keeps the keys in order: resume from the key following the last key of the previous page
+	ok := dbIterator.Seek(lastKey)
+	if ok && string(dbIterator.Key()) == string(lastKey) {
+		ok = dbIterator.Next()
+	}
 
-		matchingDatabaseKeys, err :=
-			s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
+	var (
+		pageKeys     []string
+		nextBookmark string
+	)
+
+	for ; ok; ok = dbIterator.Next() {
+		key := string(dbIterator.Key())
+		if key == tagMapKey || key == storeConfigKey {
+			continue
+		}
+
+		var entry dbEntry
+
+		err = json.Unmarshal(dbIterator.Value(), &entry)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+			return nil, "", fmt.Errorf("failed to unmarshal retrieved DB entry: %w", err)
 		}
 
-		return &iterator{keys: matchingDatabaseKeys, store: s}, nil
-	default:
-		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+		if !matchesTags(entry.Tags, tagName, tagValue) {
+			continue
+		}
+
+		// there is a next page only if another entry matches
+		if len(pageKeys) == pageSize {
+			nextBookmark = base64.RawURLEncoding.EncodeToString([]byte(pageKeys[len(pageKeys)-1]))
+
+			break
+		}
+
+		pageKeys = append(pageKeys, key)
+	}
+
+	err = dbIterator.Error()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to iterate over the database: %w", err)
 	}
+
+	return &iterator{
+		keys:  pageKeys,
+		store: s,
+		countItems: func() (int, error) {
+			matchingDatabaseKeys, err := s.getDatabaseKeysMatchingExpression(expression)
+
+			return len(matchingDatabaseKeys), err
+		},
+	}, nextBookmark, nil
 }
 
 // Delete will delete record with k key.
@@ -363,24 +401,46 @@ func (s *store) Delete(key string) error {
 	return nil
 }
 
-// TODO (#2605) proper bulk retrieval implementation. This is just a naive implementation that ensures this method
-//  can at least perform the operations as expected without failing. It doesn't take advantage of LevelDB features that
-//  may allow for faster batch operations.
+// Batch performs multiple Put and/or Delete operations in order. The operations are written atomically in a single
+// LevelDB write batch along with the resulting tag map: if any operation is invalid or the write fails, then none of
+// the operations are applied.
 func (s *store) Batch(operations []storage.Operation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	tagMap, err := s.getTagMap()
+	if err != nil {
+		return fmt.Errorf("failed to get tag map: %w", err)
+	}
+
+	batch := new(leveldb.Batch)
+
 	for _, operation := range operations {
-		if operation.Value == nil {
-			err := s.Delete(operation.Key)
-			if err != nil {
-				return fmt.Errorf("failed to delete value: %w", err)
-			}
-		} else {
-			err := s.Put(operation.Key, operation.Value, operation.Tags...)
-			if err != nil {
-				return fmt.Errorf("failed to put value: %w", err)
-			}
+		err = addToBatch(batch, tagMap, operation)
+		if err != nil {
+			return err
 		}
 	}
 
+	tagMapBytes, err := json.Marshal(tagMap)
+	if err != nil {
+		return fmt.Errorf("failed to marshal updated tag map: %w", err)
+	}
+
+	// the tag map is stored as any other entry, see updateTagMap
+	tagMapEntryBytes, err := json.Marshal(dbEntry{Value: tagMapBytes})
+	if err != nil {
+		return fmt.Errorf("failed to marshal updated tag map entry: %w", err)
+	}
+
+	batch.Put([]byte(tagMapKey), tagMapEntryBytes)
+
+	err = s.db.Write(batch, nil)
+	if err != nil {
+		return fmt.Errorf("failed to write batch to underlying database: %w", err)
+	}
+
 	return nil
 }
 
@@ -402,13 +462,7 @@ func (s *store) updateTagMap(key string, tags []storage.Tag) error {
 		return fmt.Errorf("failed to get tag map: %w", err)
 	}
 
-	for _, tag := range tags {
-		if tagMap[tag.Name] == nil {
-			tagMap[tag.Name] = make(map[string]struct{})
-		}
-
-		tagMap[tag.Name][key] = struct{}{}
-	}
+	addKeyToTagMap(tagMap, key, tags)
 
 	tagMapBytes, err := json.Marshal(tagMap)
 	if err != nil {
@@ -469,9 +523,7 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 		return fmt.Errorf("failed to get tag map: %w", err)
 	}
 
-	for _, tagNameToKeys := range tagMap {
-		delete(tagNameToKeys, keyToRemove)
-	}
+	removeKeyFromTagMap(tagMap, keyToRemove)
 
 	tagMapBytes, err := json.Marshal(tagMap)
 	if err != nil {
@@ -486,6 +538,66 @@ func (s *store) removeFromTagMap(keyToRemove string) error {
 	return nil
 }
 
+func (s *store) getDatabaseKeysMatchingExpression(expression string) ([]string, error) {
+	if expression == "" {
+		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+
+	tagMap, err := s.getTagMap()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag map: %w", err)
+	}
+
+	expressionSplit := strings.Split(expression, ":")
+	switch len(expressionSplit) {
+	case expressionTagNameOnlyLength:
+		expressionTagName := expressionSplit[0]
+
+		return getDatabaseKeysMatchingTagName(tagMap, expressionTagName), nil
+	case expressionTagNameAndValueLength:
+		expressionTagName := expressionSplit[0]
+		expressionTagValue := expressionSplit[1]
+
+		matchingDatabaseKeys, err :=
+			s.getDatabaseKeysMatchingTagNameAndValue(tagMap, expressionTagName, expressionTagValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database keys matching tag name and value: %w", err)
+		}
+
+		return matchingDatabaseKeys, nil
+	default:
+		return nil, fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+}
+
+// parseQueryExpression returns the tag name and value of the expression, the value is empty if not provided.
+func parseQueryExpression(expression string) (string, string, error) {
+	if expression == "" {
+		return "", "", fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+
+	expressionSplit := strings.Split(expression, ":")
+	switch len(expressionSplit) {
+	case expressionTagNameOnlyLength:
+		return expressionSplit[0], "", nil
+	case expressionTagNameAndValueLength:
+		return expressionSplit[0], expressionSplit[1], nil
+	default:
+		return "", "", fmt.Errorf(invalidQueryExpressionFormat, expression)
+	}
+}
+
+// matchesTags checks whether one of the tags has the given name and value, any value if tagValue is empty.
+func matchesTags(tags []storage.Tag, tagName, tagValue string) bool {
+	for _, tag := range tags {
+		if tag.Name == tagName && (tagValue == "" || tag.Value == tagValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
 	expressionTagName, expressionTagValue string) ([]string, error) {
 	var matchingDatabaseKeys []string
@@ -514,6 +626,73 @@ func (s *store) getDatabaseKeysMatchingTagNameAndValue(tagMap tagMapping,
 	return matchingDatabaseKeys, nil
 }
 
+// addToBatch adds operation to batch and updates tagMap accordingly.
+func addToBatch(batch *leveldb.Batch, tagMap tagMapping, operation storage.Operation) error {
+	if operation.Value == nil {
+		if operation.Key == "" {
+			return errors.New("failed to delete value: key cannot be blank")
+		}
+
+		batch.Delete([]byte(operation.Key))
+		removeKeyFromTagMap(tagMap, operation.Key)
+
+		return nil
+	}
+
+	err := checkPutArgs(operation.Key, operation.Value, operation.Tags)
+	if err != nil {
+		return fmt.Errorf("failed to put value: %w", err)
+	}
+
+	entryBytes, err := json.Marshal(dbEntry{Value: operation.Value, Tags: operation.Tags})
+	if err != nil {
+		return fmt.Errorf("failed to marshal new DB entry: %w", err)
+	}
+
+	batch.Put([]byte(operation.Key), entryBytes)
+	addKeyToTagMap(tagMap, operation.Key, operation.Tags)
+
+	return nil
+}
+
+func addKeyToTagMap(tagMap tagMapping, key string, tags []storage.Tag) {
+	for _, tag := range tags {
+		if tagMap[tag.Name] == nil {
+			tagMap[tag.Name] = make(map[string]struct{})
+		}
+
+		tagMap[tag.Name][key] = struct{}{}
+	}
+}
+
+func removeKeyFromTagMap(tagMap tagMapping, key string) {
+	for _, tagNameToKeys := range tagMap {
+		delete(tagNameToKeys, key)
+	}
+}
+
+func checkPutArgs(key string, value []byte, tags []storage.Tag) error {
+	if key == "" {
+		return errors.New("key cannot be blank")
+	}
+
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	for _, tag := range tags {
+		if strings.Contains(tag.Name, ":") {
+			return fmt.Errorf(invalidTagName, tag.Name)
+		}
+
+		if strings.Contains(tag.Value, ":") {
+			return fmt.Errorf(invalidTagValue, tag.Value)
+		}
+	}
+
+	return nil
+}
+
 func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string) []string {
 	var matchingDatabaseKeys []string
 
@@ -532,6 +711,8 @@ func getDatabaseKeysMatchingTagName(tagMap tagMapping, expressionTagName string)
 
 type iterator struct {
 	keys         []string
+	totalItems   int
+	countItems   func() (int, error) // counts the total items on demand for the pages of paginated queries
 	currentIndex int
 	currentKey   string
 	store        *store
@@ -574,7 +755,11 @@ func (i *iterator) Tags() ([]storage.Tag, error) {
 }
 
 func (i *iterator) TotalItems() (int, error) {
-	return len(i.keys), nil
+	if i.countItems != nil {
+		return i.countItems()
+	}
+
+	return i.totalItems, nil
 }
 
 func (i *iterator) Close() error {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package leveldb_test
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -20,6 +21,10 @@ import (
 	commontest "github.com/hyperledger/aries-framework-go/test/component/storage"
 )
 
+type paginatedStore interface {
+	QueryWithPagination(expression string, pageSize int, bookmark string) (storage.Iterator, string, error)
+}
+
 func setupLevelDB(t testing.TB) string {
 	dbPath, err := ioutil.TempDir("", "db")
 	if err != nil {
@@ -149,6 +154,234 @@ func TestStore_Query(t *testing.T) {
 	})
 }
 
+func TestStore_QueryWithPagination(t *testing.T) {
+	path := setupLevelDB(t)
+
+	provider := leveldb.NewProvider(path)
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err = testStore.Put(fmt.Sprintf("key%04d", i), []byte(fmt.Sprintf("value%d", i)),
+			storage.Tag{Name: "TagName", Value: fmt.Sprintf("TagValue%d", i%2)})
+		require.NoError(t, err)
+	}
+
+	pagedStore, ok := testStore.(paginatedStore)
+	require.True(t, ok)
+
+	t.Run("Page through all entries", func(t *testing.T) {
+		var (
+			bookmark string
+			pages    int
+		)
+
+		for {
+			itr, nextBookmark, err := pagedStore.QueryWithPagination("TagName", 100, bookmark)
+			require.NoError(t, err)
+
+			totalItems, err := itr.TotalItems()
+			require.NoError(t, err)
+			require.Equal(t, 1000, totalItems)
+
+			for i := 0; i < 100; i++ {
+				more, err := itr.Next()
+				require.NoError(t, err)
+				require.True(t, more)
+
+				key, err := itr.Key()
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("key%04d", pages*100+i), key)
+
+				value, err := itr.Value()
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("value%d", pages*100+i), string(value))
+			}
+
+			more, err := itr.Next()
+			require.NoError(t, err)
+			require.False(t, more)
+
+			pages++
+
+			if nextBookmark == "" {
+				break
+			}
+
+			// the same bookmark returns the same page.
+			_, sameBookmark, err := pagedStore.QueryWithPagination("TagName", 100, bookmark)
+			require.NoError(t, err)
+			require.Equal(t, nextBookmark, sameBookmark)
+
+			bookmark = nextBookmark
+		}
+
+		require.Equal(t, 10, pages)
+	})
+	t.Run("Bookmark stays valid after deleting the last entry of the page", func(t *testing.T) {
+		_, bookmark, err := pagedStore.QueryWithPagination("TagName:TagValue1", 100, "")
+		require.NoError(t, err)
+
+		err = testStore.Delete("key0199")
+		require.NoError(t, err)
+
+		itr, _, err := pagedStore.QueryWithPagination("TagName:TagValue1", 100, bookmark)
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0201", key)
+	})
+	t.Run("Invalid page size", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("TagName", 0, "")
+		require.EqualError(t, err, "page size must be greater than 0")
+		require.Nil(t, itr)
+	})
+	t.Run("Invalid bookmark", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("TagName", 100, "not a bookmark")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid bookmark")
+		require.Nil(t, itr)
+	})
+	t.Run("Entries put after the last entry of the page are in the next page", func(t *testing.T) {
+		itr, bookmark, err := pagedStore.QueryWithPagination("TagName:TagValue0", 10, "")
+		require.NoError(t, err)
+
+		totalItems, err := itr.TotalItems()
+		require.NoError(t, err)
+
+		require.NoError(t, testStore.Put("key0001a", []byte("value"), storage.Tag{Name: "TagName", Value: "TagValue0"}))
+		require.NoError(t, testStore.Put("key0019a", []byte("value"), storage.Tag{Name: "TagName", Value: "TagValue0"}))
+
+		// the total is counted when asked for
+		totalItemsAfterPut, err := itr.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, totalItems+2, totalItemsAfterPut)
+
+		itr, _, err = pagedStore.QueryWithPagination("TagName:TagValue0", 1, bookmark)
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0019a", key)
+
+		require.NoError(t, testStore.Delete("key0001a"))
+		require.NoError(t, testStore.Delete("key0019a"))
+
+		itr, _, err = pagedStore.QueryWithPagination("TagName:TagValue0", 1, bookmark)
+		require.NoError(t, err)
+
+		more, err = itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err = itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0020", key)
+	})
+	t.Run("Invalid expression", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("", 100, "")
+		require.EqualError(t, err, `"" is not in a valid expression format. `+
+			"it must be in the following format: TagName:TagValue")
+		require.Nil(t, itr)
+	})
+}
+
+func TestStore_Batch(t *testing.T) {
+	path := setupLevelDB(t)
+
+	provider := leveldb.NewProvider(path)
+
+	testStore, err := provider.OpenStore(randomStoreName())
+	require.NoError(t, err)
+
+	t.Run("Import 500 entries in one batch", func(t *testing.T) {
+		operations := make([]storage.Operation, 500)
+
+		for i := range operations {
+			operations[i] = storage.Operation{
+				Key:   fmt.Sprintf("credential%d", i),
+				Value: []byte(fmt.Sprintf("value%d", i)),
+				Tags:  []storage.Tag{{Name: "Type", Value: "Credential"}},
+			}
+		}
+
+		err := testStore.Batch(operations)
+		require.NoError(t, err)
+
+		for i := range operations {
+			value, errGet := testStore.Get(fmt.Sprintf("credential%d", i))
+			require.NoError(t, errGet)
+			require.Equal(t, fmt.Sprintf("value%d", i), string(value))
+		}
+
+		itr, err := testStore.Query("Type:Credential")
+		require.NoError(t, err)
+
+		totalItems, err := itr.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, 500, totalItems)
+	})
+	t.Run("Invalid operation in the middle of a batch rolls back the whole batch", func(t *testing.T) {
+		operations := make([]storage.Operation, 500)
+
+		for i := range operations {
+			operations[i] = storage.Operation{
+				Key:   fmt.Sprintf("backup%d", i),
+				Value: []byte(fmt.Sprintf("value%d", i)),
+				Tags:  []storage.Tag{{Name: "Type", Value: "Backup"}},
+			}
+		}
+
+		// Delete an entry imported by the previous batch.
+		operations[100] = storage.Operation{Key: "credential0"}
+		operations[250].Tags = []storage.Tag{{Name: "Type", Value: "Invalid:Value"}}
+
+		err := testStore.Batch(operations)
+		require.EqualError(t, err, `failed to put value: "Invalid:Value" is an invalid tag value since it `+
+			"contains one or more ':' characters")
+
+		for i := range operations {
+			_, errGet := testStore.Get(fmt.Sprintf("backup%d", i))
+			require.True(t, errors.Is(errGet, storage.ErrDataNotFound))
+		}
+
+		value, err := testStore.Get("credential0")
+		require.NoError(t, err)
+		require.Equal(t, "value0", string(value))
+
+		itr, err := testStore.Query("Type")
+		require.NoError(t, err)
+
+		totalItems, err := itr.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, 500, totalItems)
+	})
+	t.Run("Blank key in a delete operation", func(t *testing.T) {
+		err := testStore.Batch([]storage.Operation{{Key: ""}})
+		require.EqualError(t, err, "failed to delete value: key cannot be blank")
+	})
+	t.Run("Fail to write batch since the DB connection was closed", func(t *testing.T) {
+		closedStore, err := provider.OpenStore(randomStoreName())
+		require.NoError(t, err)
+
+		require.NoError(t, closedStore.Close())
+
+		err = closedStore.Batch([]storage.Operation{{Key: "key", Value: []byte("value")}})
+		require.EqualError(t, err, "failed to get tag map: failed to get tag map: failed to get DB entry: "+
+			"leveldb: closed")
+	})
+}
+
 func TestStore_Flush(t *testing.T) {
 	path := setupLevelDB(t)
 
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package mem
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -28,6 +30,7 @@ var (
 	errInvalidQueryExpressionFormat = errors.New("invalid expression format. " +
 		"it must be in the following format: TagName:TagValue")
 	errIteratorExhausted = errors.New("iterator is exhausted")
+	errInvalidPageSize   = errors.New("page size must be greater than 0")
 )
 
 // Provider represents an in-memory implementation of the spi.Provider interface.
@@ -150,6 +153,7 @@ type dbEntry struct {
 type memStore struct {
 	name   string
 	db     map[string]dbEntry
+	keys   []string // the keys of db in order, the cursor of paginated queries
 	config spi.StoreConfiguration
 	close  closer
 	sync.RWMutex
@@ -177,10 +181,10 @@ func (m *memStore) Put(key string, value []byte, tags ...spi.Tag) error {
 
 	m.Lock()
 	defer m.Unlock()
-	m.db[key] = dbEntry{
+	m.put(key, dbEntry{
 		value: value,
 		tags:  tags,
-	}
+	})
 
 	return nil
 }
@@ -262,34 +266,82 @@ func (m *memStore) Query(expression string, options ...spi.QueryOption) (spi.Ite
 		return nil, err
 	}
 
-	if expression == "" {
-		return nil, errInvalidQueryExpressionFormat
+	expressionTagName, expressionTagValue, err := parseQueryExpression(expression)
+	if err != nil {
+		return nil, err
 	}
 
-	expressionSplit := strings.Split(expression, ":")
-	switch len(expressionSplit) {
-	case expressionTagNameOnlyLength:
-		expressionTagName := expressionSplit[0]
+	m.RLock()
+	defer m.RUnlock()
 
-		m.RLock()
-		defer m.RUnlock()
+	keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
 
-		keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, "")
+	return &memIterator{keys: keys, dbEntries: dbEntries, totalItems: len(keys)}, nil
+}
 
-		return &memIterator{keys: keys, dbEntries: dbEntries}, nil
-	case expressionTagNameAndValueLength:
-		expressionTagName := expressionSplit[0]
-		expressionTagValue := expressionSplit[1]
+// QueryWithPagination returns at most pageSize entries that satisfy the expression, ordered by key, starting after
+// the entry the bookmark points to. See spi.PaginatedStore for more information.
+func (m *memStore) QueryWithPagination(expression string, pageSize int,
+	bookmark string) (spi.Iterator, string, error) {
+	if pageSize <= 0 {
+		return nil, "", errInvalidPageSize
+	}
 
-		m.RLock()
-		defer m.RUnlock()
+	lastKey, err := decodeBookmark(bookmark)
+	if err != nil {
+		return nil, "", err
+	}
 
-		keys, dbEntries := m.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
+	expressionTagName, expressionTagValue, err := parseQueryExpression(expression)
+	if err != nil {
+		return nil, "", err
+	}
 
-		return &memIterator{keys: keys, dbEntries: dbEntries}, nil
-	default:
-		return nil, errInvalidQueryExpressionFormat
+	m.RLock()
+	defer m.RUnlock()
+
+	// resume from the key following the last key of the previous page
+	i := sort.SearchStrings(m.keys, lastKey)
+	if i < len(m.keys) && m.keys[i] == lastKey {
+		i++
 	}
+
+	var (
+		pageKeys      []string
+		pageDBEntries []dbEntry
+		nextBookmark  string
+	)
+
+	for ; i < len(m.keys); i++ {
+		entry := m.db[m.keys[i]]
+
+		if !matchesTags(entry.tags, expressionTagName, expressionTagValue) {
+			continue
+		}
+
+		// there is a next page only if another entry matches
+		if len(pageKeys) == pageSize {
+			nextBookmark = base64.RawURLEncoding.EncodeToString([]byte(pageKeys[len(pageKeys)-1]))
+
+			break
+		}
+
+		pageKeys = append(pageKeys, m.keys[i])
+		pageDBEntries = append(pageDBEntries, entry)
+	}
+
+	return &memIterator{
+		keys:      pageKeys,
+		dbEntries: pageDBEntries,
+		countItems: func() int {
+			m.RLock()
+			defer m.RUnlock()
+
+			keys, _ := m.getMatchingKeysAndDBEntries(expressionTagName, expressionTagValue)
+
+			return len(keys)
+		},
+	}, nextBookmark, nil
 }
 
 // Delete deletes the key + value pair (and all tags) associated with key.
@@ -301,7 +353,7 @@ func (m *memStore) Delete(k string) error {
 
 	m.Lock()
 	defer m.Unlock()
-	delete(m.db, k)
+	m.delete(k)
 
 	return nil
 }
@@ -320,14 +372,14 @@ func (m *memStore) Batch(operations []spi.Operation) error {
 
 	for _, operation := range operations {
 		if operation.Value == nil {
-			delete(m.db, operation.Key)
+			m.delete(operation.Key)
 			continue
 		}
 
-		m.db[operation.Key] = dbEntry{
+		m.put(operation.Key, dbEntry{
 			value: operation.Value,
 			tags:  operation.Tags,
-		}
+		})
 	}
 
 	return nil
@@ -345,30 +397,57 @@ func (m *memStore) Flush() error {
 	return nil
 }
 
-func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]string, []dbEntry) {
-	var matchAnyValue bool
-	if tagValue == "" {
-		matchAnyValue = true
+// put stores the entry and keeps the ordered keys up to date. The store must be locked for writing.
+func (m *memStore) put(key string, entry dbEntry) {
+	if _, ok := m.db[key]; !ok {
+		i := sort.SearchStrings(m.keys, key)
+
+		m.keys = append(m.keys, "")
+		copy(m.keys[i+1:], m.keys[i:])
+		m.keys[i] = key
+	}
+
+	m.db[key] = entry
+}
+
+// delete deletes the entry and keeps the ordered keys up to date. The store must be locked for writing.
+func (m *memStore) delete(key string) {
+	if _, ok := m.db[key]; !ok {
+		return
 	}
 
+	i := sort.SearchStrings(m.keys, key)
+	m.keys = append(m.keys[:i], m.keys[i+1:]...)
+
+	delete(m.db, key)
+}
+
+func (m *memStore) getMatchingKeysAndDBEntries(tagName, tagValue string) ([]string, []dbEntry) {
 	var keys []string
 
 	var dbEntries []dbEntry
 
 	for key, dbEntry := range m.db {
-		for _, tag := range dbEntry.tags {
-			if tag.Name == tagName && (matchAnyValue || tag.Value == tagValue) {
-				keys = append(keys, key)
-				dbEntries = append(dbEntries, dbEntry)
-
-				break
-			}
+		if matchesTags(dbEntry.tags, tagName, tagValue) {
+			keys = append(keys, key)
+			dbEntries = append(dbEntries, dbEntry)
 		}
 	}
 
 	return keys, dbEntries
 }
 
+// matchesTags checks whether one of the tags has the given name and value, any value if tagValue is empty.
+func matchesTags(tags []spi.Tag, tagName, tagValue string) bool {
+	for _, tag := range tags {
+		if tag.Name == tagName && (tagValue == "" || tag.Value == tagValue) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // memIterator represents a snapshot of some set of entries in a memStore.
 type memIterator struct {
 	currentIndex   int
@@ -376,6 +455,8 @@ type memIterator struct {
 	currentDBEntry dbEntry
 	keys           []string
 	dbEntries      []dbEntry
+	totalItems     int
+	countItems     func() int // counts the total items on demand for the pages of paginated queries
 }
 
 // Next moves the pointer to the next entry in the iterator. It returns false if the iterator is exhausted.
@@ -420,7 +501,11 @@ func (m *memIterator) Tags() ([]spi.Tag, error) {
 }
 
 func (m *memIterator) TotalItems() (int, error) {
-	return len(m.keys), nil
+	if m.countItems != nil {
+		return m.countItems(), nil
+	}
+
+	return m.totalItems, nil
 }
 
 // Close is a no-op, since there's nothing to close for a memIterator.
@@ -428,6 +513,32 @@ func (m *memIterator) Close() error {
 	return nil
 }
 
+func parseQueryExpression(expression string) (string, string, error) {
+	if expression == "" {
+		return "", "", errInvalidQueryExpressionFormat
+	}
+
+	expressionSplit := strings.Split(expression, ":")
+	switch len(expressionSplit) {
+	case expressionTagNameOnlyLength:
+		return expressionSplit[0], "", nil
+	case expressionTagNameAndValueLength:
+		return expressionSplit[0], expressionSplit[1], nil
+	default:
+		return "", "", errInvalidQueryExpressionFormat
+	}
+}
+
+// decodeBookmark returns the last key of the page the bookmark was issued for.
+func decodeBookmark(bookmark string) (string, error) {
+	lastKey, err := base64.RawURLEncoding.DecodeString(bookmark)
+	if err != nil {
+		return "", fmt.Errorf("invalid bookmark: %w", err)
+	}
+
+	return string(lastKey), nil
+}
+
 func getQueryOptions(options []spi.QueryOption) spi.QueryOptions {
 	var queryOptions spi.QueryOptions
 
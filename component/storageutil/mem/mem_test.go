@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package mem_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,10 @@ import (
 	storagetest "github.com/hyperledger/aries-framework-go/test/component/storage"
 )
 
+type paginatedStore interface {
+	QueryWithPagination(expression string, pageSize int, bookmark string) (spi.Iterator, string, error)
+}
+
 func TestCommon(t *testing.T) {
 	provider := mem.NewProvider()
 
@@ -39,6 +44,147 @@ func TestQueryNotSupportedOptions(t *testing.T) {
 	require.Nil(t, iterator)
 }
 
+func TestQueryWithPagination(t *testing.T) {
+	provider := mem.NewProvider()
+
+	testStore, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		err = testStore.Put(fmt.Sprintf("key%04d", i), []byte(fmt.Sprintf("value%d", i)),
+			spi.Tag{Name: "TagName", Value: fmt.Sprintf("TagValue%d", i%2)})
+		require.NoError(t, err)
+	}
+
+	pagedStore, ok := testStore.(paginatedStore)
+	require.True(t, ok)
+
+	t.Run("Page through all entries", func(t *testing.T) {
+		var (
+			bookmark string
+			pages    int
+		)
+
+		for {
+			itr, nextBookmark, err := pagedStore.QueryWithPagination("TagName", 100, bookmark)
+			require.NoError(t, err)
+
+			totalItems, err := itr.TotalItems()
+			require.NoError(t, err)
+			require.Equal(t, 1000, totalItems)
+
+			for i := 0; i < 100; i++ {
+				more, err := itr.Next()
+				require.NoError(t, err)
+				require.True(t, more)
+
+				key, err := itr.Key()
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("key%04d", pages*100+i), key)
+
+				value, err := itr.Value()
+				require.NoError(t, err)
+				require.Equal(t, fmt.Sprintf("value%d", pages*100+i), string(value))
+			}
+
+			more, err := itr.Next()
+			require.NoError(t, err)
+			require.False(t, more)
+
+			pages++
+
+			if nextBookmark == "" {
+				break
+			}
+
+			// the same bookmark returns the same page.
+			_, sameBookmark, err := pagedStore.QueryWithPagination("TagName", 100, bookmark)
+			require.NoError(t, err)
+			require.Equal(t, nextBookmark, sameBookmark)
+
+			bookmark = nextBookmark
+		}
+
+		require.Equal(t, 10, pages)
+	})
+	t.Run("Bookmark stays valid after deleting the last entry of the page", func(t *testing.T) {
+		_, bookmark, err := pagedStore.QueryWithPagination("TagName:TagValue1", 100, "")
+		require.NoError(t, err)
+
+		err = testStore.Delete("key0199")
+		require.NoError(t, err)
+
+		itr, _, err := pagedStore.QueryWithPagination("TagName:TagValue1", 100, bookmark)
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0201", key)
+	})
+	t.Run("Invalid page size", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("TagName", 0, "")
+		require.EqualError(t, err, "page size must be greater than 0")
+		require.Nil(t, itr)
+	})
+	t.Run("Invalid bookmark", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("TagName", 100, "not a bookmark")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid bookmark")
+		require.Nil(t, itr)
+	})
+	t.Run("Entries put after the last entry of the page are in the next page", func(t *testing.T) {
+		itr, bookmark, err := pagedStore.QueryWithPagination("TagName:TagValue0", 10, "")
+		require.NoError(t, err)
+
+		totalItems, err := itr.TotalItems()
+		require.NoError(t, err)
+
+		require.NoError(t, testStore.Batch([]spi.Operation{
+			{Key: "key0001a", Value: []byte("value"), Tags: []spi.Tag{{Name: "TagName", Value: "TagValue0"}}},
+			{Key: "key0019a", Value: []byte("value"), Tags: []spi.Tag{{Name: "TagName", Value: "TagValue0"}}},
+		}))
+
+		// the total is counted when asked for
+		totalItemsAfterPut, err := itr.TotalItems()
+		require.NoError(t, err)
+		require.Equal(t, totalItems+2, totalItemsAfterPut)
+
+		itr, _, err = pagedStore.QueryWithPagination("TagName:TagValue0", 1, bookmark)
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0019a", key)
+
+		require.NoError(t, testStore.Delete("key0001a"))
+		require.NoError(t, testStore.Delete("key0019a"))
+
+		itr, _, err = pagedStore.QueryWithPagination("TagName:TagValue0", 1, bookmark)
+		require.NoError(t, err)
+
+		more, err = itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err = itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, "key0020", key)
+	})
+	t.Run("Invalid expression", func(t *testing.T) {
+		itr, _, err := pagedStore.QueryWithPagination("", 100, "")
+		require.EqualError(t, err, "invalid expression format. it must be in the following format: TagName:TagValue")
+		require.Nil(t, itr)
+	})
+}
+
 func TestMemIterator(t *testing.T) {
 	provider := mem.NewProvider()
 
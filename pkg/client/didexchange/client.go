@@ -293,6 +293,19 @@ func (c *Client) CreateImplicitInvitation(inviterLabel, inviterDID string, args
 		"", "", applyOptions(args...).routerConnections)
 }
 
+// AcceptImplicitInvitation starts a DID exchange with the owner of a public DID (implicit invitation): the DID is
+// resolved and an exchange request labeled myLabel is sent to its DIDComm service, with a new peer DID of ours.
+// It returns the ID of the new connection.
+func (c *Client) AcceptImplicitInvitation(theirDID, myLabel string, args ...Opt) (string, error) {
+	connectionID, err := c.didexchangeSvc.CreateImplicitInvitation("", theirDID, myLabel, "",
+		applyOptions(args...).routerConnections)
+	if err != nil {
+		return "", fmt.Errorf("did exchange client - accept implicit invitation: %w", err)
+	}
+
+	return connectionID, nil
+}
+
 // CreateImplicitInvitationWithDID enables invitee to create implicit invitation using inviter and invitee public DID.
 func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (string, error) {
 	if inviter == nil || invitee == nil {
@@ -303,10 +316,14 @@ func (c *Client) CreateImplicitInvitationWithDID(inviter, invitee *DIDInfo) (str
 }
 
 // QueryConnections queries connections matching given criteria(parameters).
-func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) { //nolint: gocyclo
+func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connection, error) {
 	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 - query all connections from all criteria and
 	//  also results needs to be paged.
-	records, err := c.connectionStore.QueryConnectionRecords()
+	records, err := c.connectionStore.QueryConnectionRecordsWithOpts(
+		connection.WithMyDID(request.MyDID),
+		connection.WithTheirDID(request.TheirDID),
+		connection.WithState(request.State),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed query connections: %w", err)
 	}
@@ -314,10 +331,6 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 	var result []*Connection
 
 	for _, record := range records {
-		if request.State != "" && request.State != record.State {
-			continue
-		}
-
 		if request.InvitationID != "" && request.InvitationID != record.InvitationID {
 			continue
 		}
@@ -326,14 +339,6 @@ func (c *Client) QueryConnections(request *QueryConnectionsParams) ([]*Connectio
 			continue
 		}
 
-		if request.MyDID != "" && request.MyDID != record.MyDID {
-			continue
-		}
-
-		if request.TheirDID != "" && request.TheirDID != record.TheirDID {
-			continue
-		}
-
 		result = append(result, &Connection{Record: record})
 	}
 
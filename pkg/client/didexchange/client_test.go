@@ -835,6 +835,49 @@ func TestClient_CreateImplicitInvitation(t *testing.T) {
 	})
 }
 
+func TestClient_AcceptImplicitInvitation(t *testing.T) {
+	ed25519KH, err := mockkms.CreateMockED25519KeyHandle()
+	require.NoError(t, err)
+
+	t.Run("test success", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{},
+				mediator.Coordination:   &mockroute.MockMediatorSvc{},
+			},
+			KMSValue:             &mockkms.KeyManager{CreateKeyValue: ed25519KH},
+			ServiceEndpointValue: "endpoint",
+		})
+		require.NoError(t, err)
+
+		connectionID, err := c.AcceptImplicitInvitation("did:example:verifier", "bob")
+		require.NoError(t, err)
+		require.NotEmpty(t, connectionID)
+	})
+
+	t.Run("test error from service", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				didexchange.DIDExchange: &mocksvc.MockDIDExchangeSvc{
+					ImplicitInvitationErr: errors.New("resolve error"),
+				},
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			KMSValue:             &mockkms.KeyManager{CreateKeyValue: ed25519KH},
+			ServiceEndpointValue: "endpoint",
+		})
+		require.NoError(t, err)
+
+		connectionID, err := c.AcceptImplicitInvitation("did:example:verifier", "bob")
+		require.EqualError(t, err, "did exchange client - accept implicit invitation: resolve error")
+		require.Empty(t, connectionID)
+	})
+}
+
 func TestClient_CreateImplicitInvitationWithDID(t *testing.T) {
 	inviter := &DIDInfo{Label: "alice", DID: "did:example:alice"}
 	invitee := &DIDInfo{Label: "bob", DID: "did:example:bob"}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+)
+
+type provider interface {
+	Service(id string) (interface{}, error)
+}
+
+type protocolService interface {
+	Query(connectionID string, queries ...discoverfeatures.Query) ([]discoverfeatures.Feature, error)
+	RegisterProtocol(piuri string, roles ...string)
+	RegisterGoalCode(goalCode string)
+}
+
+// Client enables access to the Discover Features 2.0 protocol:
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type Client struct {
+	discoverFeaturesSvc protocolService
+}
+
+// New returns a new instance of the discover features client.
+func New(ctx provider) (*Client, error) {
+	svc, err := ctx.Service(discoverfeatures.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up service %s : %w", discoverfeatures.Name, err)
+	}
+
+	discoverFeaturesSvc, ok := svc.(protocolService)
+	if !ok {
+		return nil, errors.New("cast service to discover features service failed")
+	}
+
+	return &Client{discoverFeaturesSvc: discoverFeaturesSvc}, nil
+}
+
+// QueryProtocols returns the protocols matching the given patterns supported by the other party of the connection.
+// A "*" in a pattern is a wildcard, e.g. "https://didcomm.org/tictactoe/1.*".
+func (c *Client) QueryProtocols(connectionID string, match ...string) ([]discoverfeatures.Feature, error) {
+	return c.Query(connectionID, queries(discoverfeatures.FeatureTypeProtocol, match)...)
+}
+
+// QueryGoalCodes returns the goal codes matching the given patterns supported by the other party of the connection.
+func (c *Client) QueryGoalCodes(connectionID string, match ...string) ([]discoverfeatures.Feature, error) {
+	return c.Query(connectionID, queries(discoverfeatures.FeatureTypeGoalCode, match)...)
+}
+
+// Query sends the queries to the other party of the connection and returns the features it disclosed.
+func (c *Client) Query(connectionID string, queries ...discoverfeatures.Query) ([]discoverfeatures.Feature, error) {
+	features, err := c.discoverFeaturesSvc.Query(connectionID, queries...)
+	if err != nil {
+		return nil, fmt.Errorf("discover features client - query: %w", err)
+	}
+
+	return features, nil
+}
+
+// RegisterProtocol registers a protocol disclosed to the other parties, with the roles this agent can play.
+func (c *Client) RegisterProtocol(piuri string, roles ...string) {
+	c.discoverFeaturesSvc.RegisterProtocol(piuri, roles...)
+}
+
+// RegisterGoalCode registers a goal code disclosed to the other parties.
+func (c *Client) RegisterGoalCode(goalCode string) {
+	c.discoverFeaturesSvc.RegisterGoalCode(goalCode)
+}
+
+func queries(featureType string, match []string) []discoverfeatures.Query {
+	result := make([]discoverfeatures.Query, len(match))
+
+	for i := range match {
+		result[i] = discoverfeatures.Query{FeatureType: featureType, Match: match[i]}
+	}
+
+	return result
+}
@@ -0,0 +1,130 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("test new client", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &stubService{}})
+		require.NoError(t, err)
+		require.NotNil(t, client)
+	})
+
+	t.Run("test error from get service from context", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceErr: errors.New("service error")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+
+	t.Run("test error from cast service", func(t *testing.T) {
+		_, err := New(&mockprovider.Provider{ServiceValue: nil})
+		require.EqualError(t, err, "cast service to discover features service failed")
+	})
+}
+
+func TestClient_Query(t *testing.T) {
+	t.Run("protocols", func(t *testing.T) {
+		svc := &stubService{features: []discoverfeatures.Feature{{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			ID:          "https://didcomm.org/tictactoe/1.0",
+			Roles:       []string{"player"},
+		}}}
+
+		client, err := New(&mockprovider.Provider{ServiceValue: svc})
+		require.NoError(t, err)
+
+		features, err := client.QueryProtocols("connID", "https://didcomm.org/tictactoe/1.*")
+		require.NoError(t, err)
+		require.Equal(t, svc.features, features)
+		require.Equal(t, "connID", svc.connectionID)
+		require.Equal(t, []discoverfeatures.Query{{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			Match:       "https://didcomm.org/tictactoe/1.*",
+		}}, svc.queries)
+	})
+
+	t.Run("goal codes", func(t *testing.T) {
+		svc := &stubService{}
+
+		client, err := New(&mockprovider.Provider{ServiceValue: svc})
+		require.NoError(t, err)
+
+		_, err = client.QueryGoalCodes("connID", "org.didcomm.*", "aries.*")
+		require.NoError(t, err)
+		require.Equal(t, []discoverfeatures.Query{
+			{FeatureType: discoverfeatures.FeatureTypeGoalCode, Match: "org.didcomm.*"},
+			{FeatureType: discoverfeatures.FeatureTypeGoalCode, Match: "aries.*"},
+		}, svc.queries)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{ServiceValue: &stubService{queryErr: errors.New("service error")}})
+		require.NoError(t, err)
+
+		_, err = client.QueryProtocols("connID", "*")
+		require.EqualError(t, err, "discover features client - query: service error")
+	})
+}
+
+func TestClient_Register(t *testing.T) {
+	svc := &stubService{}
+
+	client, err := New(&mockprovider.Provider{ServiceValue: svc})
+	require.NoError(t, err)
+
+	client.RegisterProtocol("https://didcomm.org/tictactoe/1.0", "player")
+	client.RegisterGoalCode("org.didcomm.sell.goods.consumer")
+
+	require.Equal(t, []discoverfeatures.Feature{
+		{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			ID:          "https://didcomm.org/tictactoe/1.0",
+			Roles:       []string{"player"},
+		},
+		{FeatureType: discoverfeatures.FeatureTypeGoalCode, ID: "org.didcomm.sell.goods.consumer"},
+	}, svc.registered)
+}
+
+type stubService struct {
+	features     []discoverfeatures.Feature
+	queryErr     error
+	connectionID string
+	queries      []discoverfeatures.Query
+	registered   []discoverfeatures.Feature
+}
+
+func (s *stubService) Query(connectionID string,
+	queries ...discoverfeatures.Query) ([]discoverfeatures.Feature, error) {
+	s.connectionID = connectionID
+	s.queries = queries
+
+	return s.features, s.queryErr
+}
+
+func (s *stubService) RegisterProtocol(piuri string, roles ...string) {
+	s.registered = append(s.registered, discoverfeatures.Feature{
+		FeatureType: discoverfeatures.FeatureTypeProtocol,
+		ID:          piuri,
+		Roles:       roles,
+	})
+}
+
+func (s *stubService) RegisterGoalCode(goalCode string) {
+	s.registered = append(s.registered, discoverfeatures.Feature{
+		FeatureType: discoverfeatures.FeatureTypeGoalCode,
+		ID:          goalCode,
+	})
+}
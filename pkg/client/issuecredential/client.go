@@ -36,6 +36,14 @@ type (
 	// IssueCredential contains as attached payload the credentials being issued and is
 	// sent in response to a valid Invitation Credential message.
 	IssueCredential issuecredential.IssueCredential
+	// OfferCredentialV3 is the issue-credential 3.0 (DIDComm V2) version of OfferCredential.
+	OfferCredentialV3 issuecredential.OfferCredentialV3
+	// ProposeCredentialV3 is the issue-credential 3.0 (DIDComm V2) version of ProposeCredential.
+	ProposeCredentialV3 issuecredential.ProposeCredentialV3
+	// RequestCredentialV3 is the issue-credential 3.0 (DIDComm V2) version of RequestCredential.
+	RequestCredentialV3 issuecredential.RequestCredentialV3
+	// IssueCredentialV3 is the issue-credential 3.0 (DIDComm V2) version of IssueCredential.
+	IssueCredentialV3 issuecredential.IssueCredentialV3
 	// Action contains helpful information about action.
 	Action issuecredential.Action
 )
@@ -125,12 +133,51 @@ func (c *Client) SendRequest(request *RequestCredential, myDID, theirDID string)
 	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
 }
 
+// SendOfferV3 is used by the Issuer to send an offer (issue-credential 3.0).
+func (c *Client) SendOfferV3(offer *OfferCredentialV3, myDID, theirDID string) (string, error) {
+	if offer == nil {
+		return "", errEmptyOffer
+	}
+
+	offer.Type = issuecredential.OfferCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(offer), myDID, theirDID)
+}
+
+// SendProposalV3 is used by the Holder to send a proposal (issue-credential 3.0).
+func (c *Client) SendProposalV3(proposal *ProposeCredentialV3, myDID, theirDID string) (string, error) {
+	if proposal == nil {
+		return "", errEmptyProposal
+	}
+
+	proposal.Type = issuecredential.ProposeCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(proposal), myDID, theirDID)
+}
+
+// SendRequestV3 is used by the Holder to send a request (issue-credential 3.0).
+func (c *Client) SendRequestV3(request *RequestCredentialV3, myDID, theirDID string) (string, error) {
+	if request == nil {
+		return "", errEmptyRequest
+	}
+
+	request.Type = issuecredential.RequestCredentialMsgTypeV3
+
+	return c.service.HandleOutbound(service.NewDIDCommMsgMap(request), myDID, theirDID)
+}
+
 // AcceptProposal is used when the Issuer is willing to accept the proposal.
 // NOTE: For async usage.
 func (c *Client) AcceptProposal(piID string, msg *OfferCredential) error {
 	return c.service.ActionContinue(piID, WithOfferCredential(msg))
 }
 
+// AcceptProposalV3 is used when the Issuer is willing to accept the proposal (issue-credential 3.0).
+// NOTE: For async usage.
+func (c *Client) AcceptProposalV3(piID string, msg *OfferCredentialV3) error {
+	return c.service.ActionContinue(piID, WithOfferCredentialV3(msg))
+}
+
 // DeclineProposal is used when the Issuer does not want to accept the proposal.
 // NOTE: For async usage.
 func (c *Client) DeclineProposal(piID, reason string) error {
@@ -154,12 +201,25 @@ func (c *Client) NegotiateProposal(piID string, msg *ProposeCredential) error {
 	return c.service.ActionContinue(piID, WithProposeCredential(msg))
 }
 
+// NegotiateProposalV3 is used when the Holder wants to negotiate about an offer he received (issue-credential 3.0).
+// NOTE: For async usage. This function can be used only after receiving OfferCredentialV3.
+func (c *Client) NegotiateProposalV3(piID string, msg *ProposeCredentialV3) error {
+	return c.service.ActionContinue(piID, WithProposeCredentialV3(msg))
+}
+
 // AcceptRequest is used when the Issuer is willing to accept the request.
 // NOTE: For async usage.
 func (c *Client) AcceptRequest(piID string, msg *IssueCredential) error {
 	return c.service.ActionContinue(piID, WithIssueCredential(msg))
 }
 
+// AcceptRequestV3 is used when the Issuer is willing to accept the request (issue-credential 3.0).
+// The credentials may be left out of msg when they are signed by the IssueCredentialsV3 middleware.
+// NOTE: For async usage.
+func (c *Client) AcceptRequestV3(piID string, msg *IssueCredentialV3) error {
+	return c.service.ActionContinue(piID, WithIssueCredentialV3(msg))
+}
+
 // DeclineRequest is used when the Issuer does not want to accept the request.
 // NOTE: For async usage.
 func (c *Client) DeclineRequest(piID, reason string) error {
@@ -215,6 +275,38 @@ func WithIssueCredential(msg *IssueCredential) issuecredential.Opt {
 	return issuecredential.WithIssueCredential(&origin)
 }
 
+// WithProposeCredentialV3 allows providing ProposeCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithProposeCredentialV3(msg *ProposeCredentialV3) issuecredential.Opt {
+	origin := issuecredential.ProposeCredentialV3(*msg)
+
+	return issuecredential.WithProposeCredentialV3(&origin)
+}
+
+// WithRequestCredentialV3 allows providing RequestCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithRequestCredentialV3(msg *RequestCredentialV3) issuecredential.Opt {
+	origin := issuecredential.RequestCredentialV3(*msg)
+
+	return issuecredential.WithRequestCredentialV3(&origin)
+}
+
+// WithOfferCredentialV3 allows providing OfferCredentialV3 message
+// USAGE: This message should be provided after receiving a ProposeCredentialV3 message.
+func WithOfferCredentialV3(msg *OfferCredentialV3) issuecredential.Opt {
+	origin := issuecredential.OfferCredentialV3(*msg)
+
+	return issuecredential.WithOfferCredentialV3(&origin)
+}
+
+// WithIssueCredentialV3 allows providing IssueCredentialV3 message
+// USAGE: This message should be provided after receiving a RequestCredentialV3 message.
+func WithIssueCredentialV3(msg *IssueCredentialV3) issuecredential.Opt {
+	origin := issuecredential.IssueCredentialV3(*msg)
+
+	return issuecredential.WithIssueCredentialV3(&origin)
+}
+
 // WithFriendlyNames allows providing names for the credentials.
 // USAGE: This function should be used when the Holder receives IssueCredential message.
 func WithFriendlyNames(names ...string) issuecredential.Opt {
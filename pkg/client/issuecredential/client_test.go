@@ -316,3 +316,84 @@ func TestClient_DeclineCredential(t *testing.T) {
 
 	require.NoError(t, client.DeclineCredential("PIID", "the reason"))
 }
+
+func TestClient_SendV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newClient := func(msgType string) *Client {
+		provider := mocks.NewMockProvider(ctrl)
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleOutbound(gomock.Any(), Alice, Bob).
+			DoAndReturn(func(msg service.DIDCommMsg, _, _ string) (string, error) {
+				require.Equal(t, msgType, msg.Type())
+				require.True(t, msg.(service.DIDCommMsgMap).IsDIDCommV2())
+
+				return expectedPiid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		return client
+	}
+
+	t.Run("Send Offer", func(t *testing.T) {
+		piid, err := newClient(issuecredential.OfferCredentialMsgTypeV3).
+			SendOfferV3(&OfferCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Send Proposal", func(t *testing.T) {
+		piid, err := newClient(issuecredential.ProposeCredentialMsgTypeV3).
+			SendProposalV3(&ProposeCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Send Request", func(t *testing.T) {
+		piid, err := newClient(issuecredential.RequestCredentialMsgTypeV3).
+			SendRequestV3(&RequestCredentialV3{}, Alice, Bob)
+		require.Equal(t, expectedPiid, piid)
+		require.NoError(t, err)
+	})
+
+	t.Run("Empty messages", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.SendOfferV3(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyOffer.Error())
+
+		_, err = client.SendProposalV3(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyProposal.Error())
+
+		_, err = client.SendRequestV3(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyRequest.Error())
+	})
+}
+
+func TestClient_ContinueV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil).Times(3)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptProposalV3("PIID", &OfferCredentialV3{}))
+	require.NoError(t, client.NegotiateProposalV3("PIID", &ProposeCredentialV3{}))
+	require.NoError(t, client.AcceptRequestV3("PIID", &IssueCredentialV3{}))
+	require.NotNil(t, WithRequestCredentialV3(&RequestCredentialV3{}))
+}
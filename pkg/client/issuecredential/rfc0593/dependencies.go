@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -67,6 +68,8 @@ type SignatureSuiteSpec struct {
 	SignatureRepresentation verifiable.SignatureRepresentation
 	Suite                   func(...suite.Opt) signer.SignatureSuite
 	Signer                  func(Provider, interface{}) Signer
+	// Context is the JSON-LD context defining the terms of the proof, it is added to the credential if missing.
+	Context string
 }
 
 // DefaultSignatureSuiteSpecs are the signature suites supported by default.
@@ -83,6 +86,18 @@ var DefaultSignatureSuiteSpecs = map[string]SignatureSuiteSpec{ // nolint:gochec
 			return suite.NewCryptoSigner(p.Crypto(), kh)
 		},
 	},
+	ed25519signature2020.SignatureType: {
+		KeyType:       kms.ED25519Type,
+		KeyMultiCodec: fingerprint.ED25519PubKeyMultiCodec,
+		Suite: func(opts ...suite.Opt) signer.SignatureSuite {
+			return ed25519signature2020.New(opts...)
+		},
+		SignatureRepresentation: verifiable.SignatureProofValue,
+		Signer: func(p Provider, kh interface{}) Signer {
+			return suite.NewCryptoSigner(p.Crypto(), kh)
+		},
+		Context: "https://w3id.org/security/suites/ed25519-2020/v1",
+	},
 	bbsblssignature2020.SignatureType: {
 		KeyType:       kms.BLS12381G2Type,
 		KeyMultiCodec: fingerprint.BLS12381g2PubKeyMultiCodec,
@@ -341,6 +341,8 @@ func CreateIssueCredentialMsg(p Provider, spec *CredentialSpec) (*issuecredentia
 		return nil, fmt.Errorf("failed to determine the LD context required to add a proof: %w", err)
 	}
 
+	addSuiteContext(vc, DefaultSignatureSuiteSpecs[spec.Options.ProofType].Context)
+
 	err = vc.AddLinkedDataProof(ctx, jsonld.WithDocumentLoader(p.JSONLDDocumentLoader()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to add LD proof: %w", err)
@@ -481,6 +483,21 @@ func ldProofContext(p Provider, options *CredentialSpecOptions) (*verifiable.Lin
 	return ctx, nil
 }
 
+// addSuiteContext adds the JSON-LD context of the signature suite to the credential, unless it is already there.
+func addSuiteContext(vc *verifiable.Credential, suiteContext string) {
+	if suiteContext == "" {
+		return
+	}
+
+	for _, c := range vc.Context {
+		if c == suiteContext {
+			return
+		}
+	}
+
+	vc.Context = append(vc.Context, suiteContext)
+}
+
 func signatureSuite(p Provider, proofType string) (signer.SignatureSuite, *SignatureSuiteSpec, string, error) {
 	spec, supported := DefaultSignatureSuiteSpecs[proofType]
 	if !supported {
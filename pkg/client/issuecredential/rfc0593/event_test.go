@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries"
@@ -619,6 +620,79 @@ func TestIssueCredential(t *testing.T) {
 	})
 }
 
+func TestNegotiation_Ed25519Signature2020(t *testing.T) {
+	holder := agent(t)
+	issuer := agent(t)
+
+	unverifiedCredential := newVC(t)
+	unverifiedCredential.Context = []string{verifiable.ContextURI}
+
+	proposal := service.NewDIDCommMsgMap(&issuecredential.ProposeCredential{
+		Type: issuecredential.ProposeCredentialMsgType,
+		Formats: []issuecredential.Format{{
+			AttachID: "123",
+			Format:   rfc0593.ProofVCDetailFormat,
+		}},
+		FiltersAttach: []decorator.Attachment{{
+			ID: "123",
+			Data: decorator.AttachmentData{
+				JSON: &rfc0593.CredentialSpec{
+					Template: marshal(t, unverifiedCredential),
+					Options: &rfc0593.CredentialSpecOptions{
+						ProofPurpose: "assertionMethod",
+						Created:      time.Now().Format(time.RFC3339),
+						Domain:       uuid.New().String(),
+						Challenge:    uuid.New().String(),
+						ProofType:    ed25519signature2020.SignatureType,
+					},
+				},
+			},
+		}},
+	})
+
+	// the issuer replays the proposal of the holder as an offer
+	arg, _, err := rfc0593.ReplayProposal(issuer, proposal)
+	require.NoError(t, err)
+
+	md := metadata(t, arg)
+	require.NotEmpty(t, md.OfferCredential())
+
+	// the holder replays the offer as a request
+	arg, options, err := rfc0593.ReplayOffer(holder, service.NewDIDCommMsgMap(md.OfferCredential()))
+	require.NoError(t, err)
+	require.Equal(t, ed25519signature2020.SignatureType, options.ProofType)
+
+	md = metadata(t, arg)
+	require.NotEmpty(t, md.RequestCredential())
+
+	// the issuer issues the credential with the requested proof type
+	arg, _, err = rfc0593.IssueCredential(issuer, service.NewDIDCommMsgMap(md.RequestCredential()))
+	require.NoError(t, err)
+
+	md = metadata(t, arg)
+	require.NotEmpty(t, md.IssueCredential())
+	require.Equal(t, rfc0593.ProofVCFormat, md.IssueCredential().Formats[0].Format)
+
+	raw, err := md.IssueCredential().CredentialsAttach[0].Data.Fetch()
+	require.NoError(t, err)
+
+	vc, err := verifiable.ParseCredential(raw, verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(holder.JSONLDDocumentLoader()))
+	require.NoError(t, err)
+	require.Contains(t, vc.Context, "https://w3id.org/security/suites/ed25519-2020/v1")
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, ed25519signature2020.SignatureType, vc.Proofs[0]["type"])
+	require.Contains(t, vc.Proofs[0], "proofValue")
+
+	// the holder verifies the credential against the requested options
+	issued := service.NewDIDCommMsgMap(md.IssueCredential())
+	require.NoError(t, issued.SetID(uuid.New().String()))
+
+	arg, err = rfc0593.VerifyCredential(holder, options, "my_vc", issued)
+	require.NoError(t, err)
+	require.Equal(t, []string{"my_vc"}, metadata(t, arg).CredentialNames())
+}
+
 func TestVerifyCredential(t *testing.T) {
 	t.Run("verifies the credential", func(t *testing.T) {
 		agent := agent(t)
@@ -680,6 +754,18 @@ func TestVerifyCredential(t *testing.T) {
 	})
 }
 
+func metadata(t *testing.T, arg interface{}) *issuecredential.MetaData {
+	t.Helper()
+
+	opt, ok := arg.(issuecredential.Opt)
+	require.True(t, ok)
+
+	md := &issuecredential.MetaData{}
+	opt(md)
+
+	return md
+}
+
 func marshal(t *testing.T, v interface{}) []byte {
 	t.Helper()
 
@@ -270,6 +270,22 @@ func (m *mockMetadata) RequestCredential() *issuecredential.RequestCredential {
 	panic("implement me")
 }
 
+func (m *mockMetadata) OfferCredentialV3() *issuecredential.OfferCredentialV3 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) ProposeCredentialV3() *issuecredential.ProposeCredentialV3 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) IssueCredentialV3() *issuecredential.IssueCredentialV3 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) RequestCredentialV3() *issuecredential.RequestCredentialV3 {
+	panic("implement me")
+}
+
 func (m *mockMetadata) CredentialNames() []string {
 	panic("implement me")
 }
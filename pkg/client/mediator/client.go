@@ -43,6 +43,9 @@ type protocolService interface {
 
 	// Config returns the router's configuration.
 	Config(connID string) (*mediator.Config, error)
+
+	// KeylistQuery returns the recipient keys registered with the router.
+	KeylistQuery(connID string, p *mediator.Paginate) (*mediator.Keylist, error)
 }
 
 // WithTimeout option is for definition timeout value waiting for responses received from the router.
@@ -52,6 +55,14 @@ func WithTimeout(t time.Duration) mediator.ClientOption {
 	}
 }
 
+// WithCoordinateMediationV2 option is for registering with the router using the coordinate-mediation/2.0
+// protocol instead of the route coordination 1.0 protocol.
+func WithCoordinateMediationV2() mediator.ClientOption {
+	return func(opts *mediator.ClientOptions) {
+		opts.ProtocolSpec = mediator.CoordinationSpecV2
+	}
+}
+
 // New return new instance of route client.
 func New(ctx provider, options ...mediator.ClientOption) (*Client, error) {
 	svc, err := ctx.Service(mediator.Coordination)
@@ -109,3 +120,19 @@ func (c *Client) GetConfig(connID string) (*mediator.Config, error) {
 
 	return conf, nil
 }
+
+// GetKeys returns the recipient keys the agent registered with the router.
+func (c *Client) GetKeys(connID string, paginate *mediator.Paginate) ([]string, error) {
+	keylist, err := c.routeSvc.KeylistQuery(connID, paginate)
+	if err != nil {
+		return nil, fmt.Errorf("keylist query : %w", err)
+	}
+
+	keys := make([]string, len(keylist.Keys))
+
+	for i, key := range keylist.Keys {
+		keys[i] = key.RecipientKey
+	}
+
+	return keys, nil
+}
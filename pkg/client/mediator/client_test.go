@@ -54,6 +54,13 @@ func TestNew(t *testing.T) {
 
 		require.Equal(t, timeout, opts.Timeout)
 	})
+
+	t.Run("test coordinate mediation 2.0 is applied to options", func(t *testing.T) {
+		opts := &mediator.ClientOptions{}
+		WithCoordinateMediationV2()(opts)
+
+		require.Equal(t, mediator.CoordinationSpecV2, opts.ProtocolSpec)
+	})
 }
 
 func TestRegister(t *testing.T) {
@@ -170,3 +177,34 @@ func TestClient_GetConfig(t *testing.T) {
 		require.True(t, errors.Is(err, expected))
 	})
 }
+
+func TestClient_GetKeys(t *testing.T) {
+	t.Run("returns keys", func(t *testing.T) {
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				Keylist: &mediator.Keylist{
+					Keys: []mediator.KeylistKey{{RecipientKey: "key1"}, {RecipientKey: "key2"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		keys, err := c.GetKeys("conn", nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"key1", "key2"}, keys)
+	})
+
+	t.Run("wraps keylist query error", func(t *testing.T) {
+		expected := errors.New("test")
+		c, err := New(&mockprovider.Provider{
+			ServiceValue: &mockroute.MockMediatorSvc{
+				KeylistQueryErr: expected,
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = c.GetKeys("conn", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, expected))
+	})
+}
@@ -17,6 +17,8 @@ const (
 	ProtocolName = mediator.Coordination
 	// RequestMsgType defines the route coordination request message type.
 	RequestMsgType = mediator.RequestMsgType
+	// RequestMsgTypeV2 defines the coordinate mediation 2.0 request message type.
+	RequestMsgTypeV2 = mediator.RequestMsgTypeV2
 )
 
 // Request is the route-request message of this protocol.
@@ -33,6 +33,12 @@ type protocolService interface {
 	BatchPickup(connectionID string, size int) (int, error)
 
 	Noop(connectionID string) error
+
+	StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error)
+
+	DeliveryRequest(connectionID string, limit int) (int, error)
+
+	LiveDeliveryChange(connectionID string, liveDelivery bool) error
 }
 
 // New return new instance of messagepickup client.
@@ -78,3 +84,33 @@ func (r *Client) BatchPickup(connectionID string, size int) (int, error) {
 func (r *Client) Noop(connectionID string) error {
 	return r.messagepickupSvc.Noop(connectionID)
 }
+
+// StatusRequestV2 request a message pickup 2.0 status message.
+func (r *Client) StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error) {
+	sts, err := r.messagepickupSvc.StatusRequestV2(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("message pickup client - status request: %w", err)
+	}
+
+	return sts, nil
+}
+
+// DeliveryRequest request the delivery of up to limit queued messages, the delivered messages are
+// acknowledged so that the mediator removes them from its queue.
+func (r *Client) DeliveryRequest(connectionID string, limit int) (int, error) {
+	count, err := r.messagepickupSvc.DeliveryRequest(connectionID, limit)
+	if err != nil {
+		return -1, fmt.Errorf("message pickup client - delivery request: %w", err)
+	}
+
+	return count, nil
+}
+
+// LiveDeliveryChange turns the live delivery of the queued messages on or off.
+func (r *Client) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	if err := r.messagepickupSvc.LiveDeliveryChange(connectionID, liveDelivery); err != nil {
+		return fmt.Errorf("message pickup client - live delivery change: %w", err)
+	}
+
+	return nil
+}
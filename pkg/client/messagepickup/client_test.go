@@ -113,3 +113,84 @@ func TestNoop(t *testing.T) {
 		require.Contains(t, err.Error(), "service error")
 	})
 }
+
+func TestStatusRequestV2(t *testing.T) {
+	t.Run("status request v2 - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		_, err = client.StatusRequestV2("connID")
+		require.NoError(t, err)
+	})
+
+	t.Run("status request v2 - status request error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				StatusRequestV2Err: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.StatusRequestV2("connID")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
+
+func TestDeliveryRequest(t *testing.T) {
+	t.Run("delivery request - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestFunc: func(connectionID string, limit int) (int, error) {
+					require.Equal(t, 5, limit)
+
+					return 3, nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		count, err := client.DeliveryRequest("connID", 5)
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("delivery request - delivery request error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				DeliveryRequestErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = client.DeliveryRequest("connID", 1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
+
+func TestLiveDeliveryChange(t *testing.T) {
+	t.Run("live delivery change - success", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, client.LiveDeliveryChange("connID", true))
+	})
+
+	t.Run("live delivery change - error", func(t *testing.T) {
+		client, err := New(&mockprovider.Provider{
+			ServiceValue: &mockpickup.MockMessagePickupSvc{
+				LiveDeliveryChangeErr: errors.New("service error"),
+			},
+		})
+		require.NoError(t, err)
+
+		err = client.LiveDeliveryChange("connID", true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service error")
+	})
+}
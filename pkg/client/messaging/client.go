@@ -230,21 +230,17 @@ func (c *Client) sendToConnection(msg service.DIDCommMsgMap, connectionID string
 }
 
 func (c *Client) sendToTheirDID(msg service.DIDCommMsgMap, theirDID string) (messageDispatcher, error) {
-	records, err := c.connectionLookup.QueryConnectionRecords()
+	records, err := c.connectionLookup.QueryConnectionRecordsWithOpts(
+		connection.WithTheirDID(theirDID),
+		connection.WithState(stateNameCompleted),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	var conn *connection.Record
+	if len(records) > 0 {
+		conn := records[0]
 
-	for _, record := range records {
-		if record.State == stateNameCompleted && record.TheirDID == theirDID {
-			conn = record
-			break
-		}
-	}
-
-	if conn != nil {
 		return func() error {
 			return c.ctx.Messenger().Send(msg, conn.MyDID, conn.TheirDID)
 		}, nil
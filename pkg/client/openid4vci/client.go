@@ -0,0 +1,447 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vci
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	issuerMetadataPath      = "/.well-known/openid-credential-issuer"
+	authServerMetadataPath  = "/.well-known/oauth-authorization-server"
+	credentialOfferParam    = "credential_offer"
+	credentialOfferURIParam = "credential_offer_uri"
+	authorizationDetailType = "openid_credential"
+)
+
+var logger = log.New("aries-framework/client/openid4vci")
+
+// Client fetches credentials from issuers implementing OpenID for Verifiable Credential Issuance.
+type Client struct {
+	httpClient     *http.Client
+	signer         jose.Signer
+	keyID          string
+	clientID       string
+	credentialOpts []verifiable.CredentialOpt
+}
+
+// Opt configures the Client.
+type Opt func(c *Client)
+
+// WithHTTPClient sets the HTTP client used to call the issuer. http.DefaultClient is used by default.
+func WithHTTPClient(httpClient *http.Client) Opt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithProofSigner sets the signer of the proof of possession JWTs and the key ID, e.g. a DID URL, set in their
+// "kid" header. Use jose.NewCryptoSigner to sign with a KMS key. It is required.
+func WithProofSigner(signer jose.Signer, kid string) Opt {
+	return func(c *Client) {
+		c.signer = signer
+		c.keyID = kid
+	}
+}
+
+// WithClientID sets the OAuth client ID of the wallet, required by the authorization code flow.
+func WithClientID(clientID string) Opt {
+	return func(c *Client) {
+		c.clientID = clientID
+	}
+}
+
+// WithCredentialOpts sets the options used to parse the issued credentials, e.g. the public key fetcher verifying
+// their proofs.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) Opt {
+	return func(c *Client) {
+		c.credentialOpts = opts
+	}
+}
+
+// New returns a new OpenID4VCI Client.
+func New(opts ...Opt) (*Client, error) {
+	c := &Client{httpClient: http.DefaultClient}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.signer == nil {
+		return nil, errors.New("proof signer is required")
+	}
+
+	return c, nil
+}
+
+// ResolveCredentialOffer reads the credential offer of a credential offer URI, passed either by value in the
+// credential_offer parameter or by reference in the credential_offer_uri parameter.
+func (c *Client) ResolveCredentialOffer(offerURI string) (*CredentialOffer, error) {
+	u, err := url.Parse(offerURI)
+	if err != nil {
+		return nil, fmt.Errorf("resolve credential offer: %w", err)
+	}
+
+	offer := &CredentialOffer{}
+
+	if v := u.Query().Get(credentialOfferParam); v != "" {
+		err = json.Unmarshal([]byte(v), offer)
+	} else if ref := u.Query().Get(credentialOfferURIParam); ref != "" {
+		err = c.getJSON(ref, offer)
+	} else {
+		return nil, errors.New("resolve credential offer: credential offer is missing")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("resolve credential offer: %w", err)
+	}
+
+	if offer.CredentialIssuer == "" {
+		return nil, errors.New("resolve credential offer: credential_issuer is missing")
+	}
+
+	return offer, nil
+}
+
+// RequestCredentialWithPreAuthorizedCode fetches the credentials of offer using the pre-authorized code flow.
+// userPIN is sent if the offer requires it.
+func (c *Client) RequestCredentialWithPreAuthorizedCode(offer *CredentialOffer,
+	userPIN string) ([]*verifiable.Credential, error) {
+	grant := offer.Grants.PreAuthorizedCode
+	if grant == nil {
+		return nil, errors.New("request credential: offer has no pre-authorized code grant")
+	}
+
+	if grant.UserPINRequired && userPIN == "" {
+		return nil, errors.New("request credential: user PIN is required")
+	}
+
+	form := url.Values{
+		"grant_type":          {PreAuthorizedCodeGrantType},
+		"pre-authorized_code": {grant.PreAuthorizedCode},
+	}
+
+	if userPIN != "" {
+		form.Set("user_pin", userPIN)
+	}
+
+	return c.requestCredentials(offer, form)
+}
+
+// AuthorizationURL returns the URL of the authorization request starting the authorization code flow for offer.
+// The holder is redirected to redirectURI with the authorization code and state once authorized, to be passed to
+// RequestCredentialWithAuthorizationCode.
+func (c *Client) AuthorizationURL(offer *CredentialOffer, redirectURI, state string) (string, error) {
+	if c.clientID == "" {
+		return "", errors.New("authorization URL: client ID is required")
+	}
+
+	md, err := c.issuerMetadata(offer.CredentialIssuer)
+	if err != nil {
+		return "", fmt.Errorf("authorization URL: %w", err)
+	}
+
+	asMetadata, err := c.authServerMetadata(md)
+	if err != nil {
+		return "", fmt.Errorf("authorization URL: %w", err)
+	}
+
+	details := make([]authorizationDetail, len(offer.Credentials))
+
+	for i, def := range offer.Credentials {
+		details[i] = authorizationDetail{Type: authorizationDetailType, Format: def.Format, Types: def.Types}
+	}
+
+	detailsBytes, err := json.Marshal(details)
+	if err != nil {
+		return "", fmt.Errorf("authorization URL: marshal authorization details: %w", err)
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.clientID},
+		"redirect_uri":          {redirectURI},
+		"state":                 {state},
+		"authorization_details": {string(detailsBytes)},
+	}
+
+	if grant := offer.Grants.AuthorizationCode; grant != nil && grant.IssuerState != "" {
+		query.Set("issuer_state", grant.IssuerState)
+	}
+
+	return asMetadata.AuthorizationEndpoint + "?" + query.Encode(), nil
+}
+
+// RequestCredentialWithAuthorizationCode fetches the credentials of offer using the authorization code returned to
+// redirectURI (see AuthorizationURL).
+func (c *Client) RequestCredentialWithAuthorizationCode(offer *CredentialOffer,
+	code, redirectURI string) ([]*verifiable.Credential, error) {
+	if c.clientID == "" {
+		return nil, errors.New("request credential: client ID is required")
+	}
+
+	return c.requestCredentials(offer, url.Values{
+		"grant_type":   {AuthorizationCodeGrantType},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+		"client_id":    {c.clientID},
+	})
+}
+
+// requestCredentials exchanges the grant in tokenForm for an access token, then requests each credential of offer
+// with a proof of possession bound to the latest c_nonce.
+func (c *Client) requestCredentials(offer *CredentialOffer, tokenForm url.Values) ([]*verifiable.Credential, error) {
+	md, err := c.issuerMetadata(offer.CredentialIssuer)
+	if err != nil {
+		return nil, fmt.Errorf("request credential: %w", err)
+	}
+
+	token, err := c.requestToken(md, tokenForm)
+	if err != nil {
+		return nil, fmt.Errorf("request credential: %w", err)
+	}
+
+	nonce := token.CNonce
+	credentials := make([]*verifiable.Credential, 0, len(offer.Credentials))
+
+	for _, def := range offer.Credentials {
+		resp, reqErr := c.requestCredential(md, token.AccessToken, nonce, def)
+		if reqErr != nil {
+			return nil, fmt.Errorf("request credential: %w", reqErr)
+		}
+
+		vc, parseErr := c.parseCredential(resp)
+		if parseErr != nil {
+			return nil, fmt.Errorf("request credential: %w", parseErr)
+		}
+
+		credentials = append(credentials, vc)
+
+		if resp.CNonce != "" {
+			nonce = resp.CNonce
+		}
+	}
+
+	return credentials, nil
+}
+
+func (c *Client) requestToken(md *IssuerMetadata, form url.Values) (*tokenResponse, error) {
+	tokenEndpoint := md.TokenEndpoint
+
+	if tokenEndpoint == "" {
+		asMetadata, err := c.authServerMetadata(md)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenEndpoint = asMetadata.TokenEndpoint
+	}
+
+	req, err := http.NewRequest(http.MethodPost, tokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token := &tokenResponse{}
+
+	err = c.doJSON(req, token)
+	if err != nil {
+		return nil, fmt.Errorf("token request: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("token request: access_token is missing")
+	}
+
+	return token, nil
+}
+
+func (c *Client) requestCredential(md *IssuerMetadata, accessToken, nonce string,
+	def CredentialDefinition) (*credentialResponse, error) {
+	proofJWT, err := c.createProof(md.CredentialIssuer, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBytes, err := json.Marshal(&credentialRequest{
+		Format: def.Format,
+		Types:  def.Types,
+		Proof:  &proof{ProofType: ProofTypeJWT, JWT: proofJWT},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, md.CredentialEndpoint, bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, fmt.Errorf("credential request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp := &credentialResponse{}
+
+	err = c.doJSON(req, resp)
+	if err != nil {
+		return nil, fmt.Errorf("credential request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// createProof creates the JWT proving possession of the holder's key, bound to the issuer and its c_nonce.
+func (c *Client) createProof(audience, nonce string) (string, error) {
+	claims := &proofClaims{
+		Issuer:   c.clientID,
+		Audience: audience,
+		IssuedAt: time.Now().Unix(),
+		Nonce:    nonce,
+	}
+
+	headers := jose.Headers{jose.HeaderType: ProofJWTType}
+	if c.keyID != "" {
+		headers[jose.HeaderKeyID] = c.keyID
+	}
+
+	token, err := jwt.NewSigned(claims, headers, c.signer)
+	if err != nil {
+		return "", fmt.Errorf("create proof JWT: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+type proofClaims struct {
+	Issuer   string `json:"iss,omitempty"`
+	Audience string `json:"aud"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+func (c *Client) parseCredential(resp *credentialResponse) (*verifiable.Credential, error) {
+	var vcBytes []byte
+
+	switch credential := resp.Credential.(type) {
+	case nil:
+		return nil, errors.New("credential is missing in credential response")
+	case string:
+		vcBytes = []byte(credential)
+	default:
+		var err error
+
+		vcBytes, err = json.Marshal(credential)
+		if err != nil {
+			return nil, fmt.Errorf("marshal credential: %w", err)
+		}
+	}
+
+	vc, err := verifiable.ParseCredential(vcBytes, c.credentialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	return vc, nil
+}
+
+func (c *Client) issuerMetadata(issuer string) (*IssuerMetadata, error) {
+	md := &IssuerMetadata{}
+
+	err := c.getJSON(strings.TrimSuffix(issuer, "/")+issuerMetadataPath, md)
+	if err != nil {
+		return nil, fmt.Errorf("issuer metadata: %w", err)
+	}
+
+	if md.CredentialEndpoint == "" {
+		return nil, errors.New("issuer metadata: credential_endpoint is missing")
+	}
+
+	// the metadata must be the one of the offer's issuer, not of another issuer served from its URL
+	if md.CredentialIssuer != issuer {
+		return nil, fmt.Errorf("issuer metadata: credential_issuer %q does not match the offer issuer %q",
+			md.CredentialIssuer, issuer)
+	}
+
+	return md, nil
+}
+
+func (c *Client) authServerMetadata(md *IssuerMetadata) (*authorizationServerMetadata, error) {
+	authServer := md.AuthorizationServer
+	if authServer == "" {
+		authServer = md.CredentialIssuer
+	}
+
+	asMetadata := &authorizationServerMetadata{}
+
+	err := c.getJSON(strings.TrimSuffix(authServer, "/")+authServerMetadataPath, asMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("authorization server metadata: %w", err)
+	}
+
+	return asMetadata, nil
+}
+
+func (c *Client) getJSON(u string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(req, v)
+}
+
+func (c *Client) doJSON(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := &errorResponse{}
+
+		if json.Unmarshal(body, errResp) == nil && errResp.Error != "" {
+			return fmt.Errorf("%s returned status code [%d]: %s %s", req.URL, resp.StatusCode,
+				errResp.Error, errResp.ErrorDescription)
+		}
+
+		return fmt.Errorf("%s returned status code [%d]", req.URL, resp.StatusCode)
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
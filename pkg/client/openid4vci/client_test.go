@@ -0,0 +1,373 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vci
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	holderKID         = "did:example:holder#key-1"
+	preAuthorizedCode = "pre-auth-code-123"
+	authorizationCode = "auth-code-456"
+	userPIN           = "1234"
+	accessToken       = "access-token-789"
+	clientID          = "wallet"
+	redirectURI       = "https://wallet.example.com/callback"
+)
+
+func TestClient_PreAuthorizedCodeFlow(t *testing.T) {
+	holderPubKey, signer := newHolderKey(t)
+	issuer := newMockIssuer(t, holderPubKey, true)
+
+	client, err := New(WithHTTPClient(issuer.server.Client()), WithProofSigner(signer, holderKID),
+		WithCredentialOpts(verifiable.WithDisabledProofCheck(), verifiable.WithJSONLDDocumentLoader(loader(t))))
+	require.NoError(t, err)
+
+	offer, err := client.ResolveCredentialOffer(issuer.offerURI(t))
+	require.NoError(t, err)
+	require.Equal(t, issuer.server.URL, offer.CredentialIssuer)
+	require.True(t, offer.Grants.PreAuthorizedCode.UserPINRequired)
+
+	t.Run("success", func(t *testing.T) {
+		vcs, err := client.RequestCredentialWithPreAuthorizedCode(offer, userPIN)
+		require.NoError(t, err)
+		require.Len(t, vcs, 2)
+		require.Equal(t, []string{verifiable.VCType, "UniversityDegreeCredential"}, vcs[0].Types)
+		require.Equal(t, []string{verifiable.VCType, "DriverLicenseCredential"}, vcs[1].Types)
+		require.Equal(t, []string{"nonce-0", "nonce-1"}, issuer.nonces)
+	})
+
+	t.Run("missing user PIN", func(t *testing.T) {
+		_, err := client.RequestCredentialWithPreAuthorizedCode(offer, "")
+		require.EqualError(t, err, "request credential: user PIN is required")
+	})
+
+	t.Run("wrong user PIN", func(t *testing.T) {
+		_, err := client.RequestCredentialWithPreAuthorizedCode(offer, "0000")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "token request")
+		require.Contains(t, err.Error(), "invalid_grant")
+	})
+
+	t.Run("no pre-authorized code grant", func(t *testing.T) {
+		_, err := client.RequestCredentialWithPreAuthorizedCode(&CredentialOffer{}, userPIN)
+		require.EqualError(t, err, "request credential: offer has no pre-authorized code grant")
+	})
+
+	t.Run("proof signed with another key", func(t *testing.T) {
+		_, otherSigner := newHolderKey(t)
+
+		otherClient, err := New(WithHTTPClient(issuer.server.Client()), WithProofSigner(otherSigner, holderKID))
+		require.NoError(t, err)
+
+		_, err = otherClient.RequestCredentialWithPreAuthorizedCode(offer, userPIN)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential request")
+		require.Contains(t, err.Error(), "invalid_proof")
+	})
+
+	t.Run("metadata of another issuer", func(t *testing.T) {
+		issuer.metadataIssuer = "https://other.example.com"
+		defer func() { issuer.metadataIssuer = "" }()
+
+		_, err := client.RequestCredentialWithPreAuthorizedCode(offer, userPIN)
+		require.EqualError(t, err, fmt.Sprintf("request credential: issuer metadata: credential_issuer "+
+			"\"https://other.example.com\" does not match the offer issuer %q", issuer.server.URL))
+	})
+}
+
+func TestClient_AuthorizationCodeFlow(t *testing.T) {
+	holderPubKey, signer := newHolderKey(t)
+	issuer := newMockIssuer(t, holderPubKey, false)
+
+	client, err := New(WithHTTPClient(issuer.server.Client()), WithProofSigner(signer, holderKID),
+		WithClientID(clientID),
+		WithCredentialOpts(verifiable.WithDisabledProofCheck(), verifiable.WithJSONLDDocumentLoader(loader(t))))
+	require.NoError(t, err)
+
+	offer, err := client.ResolveCredentialOffer(issuer.offerURI(t))
+	require.NoError(t, err)
+
+	authURL, err := client.AuthorizationURL(offer, redirectURI, "state-1")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, issuer.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	require.Equal(t, "code", u.Query().Get("response_type"))
+	require.Equal(t, clientID, u.Query().Get("client_id"))
+	require.Equal(t, "issuer-state-1", u.Query().Get("issuer_state"))
+	require.Contains(t, u.Query().Get("authorization_details"), "UniversityDegreeCredential")
+
+	vcs, err := client.RequestCredentialWithAuthorizationCode(offer, authorizationCode, redirectURI)
+	require.NoError(t, err)
+	require.Len(t, vcs, 2)
+
+	_, err = client.RequestCredentialWithAuthorizationCode(offer, "other code", redirectURI)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid_grant")
+
+	t.Run("client ID is required", func(t *testing.T) {
+		noIDClient, err := New(WithProofSigner(signer, holderKID))
+		require.NoError(t, err)
+
+		_, err = noIDClient.AuthorizationURL(offer, redirectURI, "state-1")
+		require.EqualError(t, err, "authorization URL: client ID is required")
+
+		_, err = noIDClient.RequestCredentialWithAuthorizationCode(offer, authorizationCode, redirectURI)
+		require.EqualError(t, err, "request credential: client ID is required")
+	})
+}
+
+func TestClient_ResolveCredentialOffer(t *testing.T) {
+	_, signer := newHolderKey(t)
+
+	client, err := New(WithProofSigner(signer, holderKID))
+	require.NoError(t, err)
+
+	t.Run("by value", func(t *testing.T) {
+		offer, err := client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=" +
+			url.QueryEscape(`{"credential_issuer":"https://issuer.example.com","credentials":[]}`))
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.example.com", offer.CredentialIssuer)
+	})
+
+	t.Run("missing offer", func(t *testing.T) {
+		_, err := client.ResolveCredentialOffer("openid-credential-offer://")
+		require.EqualError(t, err, "resolve credential offer: credential offer is missing")
+	})
+
+	t.Run("missing issuer", func(t *testing.T) {
+		_, err := client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=" +
+			url.QueryEscape(`{"credentials":[]}`))
+		require.EqualError(t, err, "resolve credential offer: credential_issuer is missing")
+	})
+
+	t.Run("invalid offer", func(t *testing.T) {
+		_, err := client.ResolveCredentialOffer("openid-credential-offer://?credential_offer=%7B")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve credential offer")
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	require.EqualError(t, err, "proof signer is required")
+}
+
+// mockIssuer is an OpenID4VCI issuer acting as its own authorization server.
+type mockIssuer struct {
+	t            *testing.T
+	server       *httptest.Server
+	holderPubKey *cryptoapi.PublicKey
+	preAuthorize bool
+	// metadataIssuer overrides the credential_issuer of the served metadata
+	metadataIssuer string
+	nonceCount     int
+	nonce          string
+	nonces         []string
+}
+
+func newMockIssuer(t *testing.T, holderPubKey *cryptoapi.PublicKey, preAuthorize bool) *mockIssuer {
+	t.Helper()
+
+	issuer := &mockIssuer{t: t, holderPubKey: holderPubKey, preAuthorize: preAuthorize}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(issuerMetadataPath, issuer.metadata)
+	mux.HandleFunc(authServerMetadataPath, issuer.authServerMetadata)
+	mux.HandleFunc("/offer", issuer.offer)
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/credential", issuer.credential)
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (m *mockIssuer) offerURI(t *testing.T) string {
+	t.Helper()
+
+	return "openid-credential-offer://?credential_offer_uri=" + url.QueryEscape(m.server.URL+"/offer")
+}
+
+func (m *mockIssuer) metadata(w http.ResponseWriter, _ *http.Request) {
+	credentialIssuer := m.server.URL
+	if m.metadataIssuer != "" {
+		credentialIssuer = m.metadataIssuer
+	}
+
+	m.writeJSON(w, http.StatusOK, &IssuerMetadata{
+		CredentialIssuer:   credentialIssuer,
+		CredentialEndpoint: m.server.URL + "/credential",
+	})
+}
+
+func (m *mockIssuer) authServerMetadata(w http.ResponseWriter, _ *http.Request) {
+	m.writeJSON(w, http.StatusOK, &authorizationServerMetadata{
+		AuthorizationEndpoint: m.server.URL + "/authorize",
+		TokenEndpoint:         m.server.URL + "/token",
+	})
+}
+
+func (m *mockIssuer) offer(w http.ResponseWriter, _ *http.Request) {
+	offer := &CredentialOffer{
+		CredentialIssuer: m.server.URL,
+		Credentials: []CredentialDefinition{
+			{Format: FormatJWTVCJSON, Types: []string{verifiable.VCType, "UniversityDegreeCredential"}},
+			{Format: FormatJWTVCJSON, Types: []string{verifiable.VCType, "DriverLicenseCredential"}},
+		},
+	}
+
+	if m.preAuthorize {
+		offer.Grants.PreAuthorizedCode = &PreAuthorizedCodeGrant{
+			PreAuthorizedCode: preAuthorizedCode,
+			UserPINRequired:   true,
+		}
+	} else {
+		offer.Grants.AuthorizationCode = &AuthorizationCodeGrant{IssuerState: "issuer-state-1"}
+	}
+
+	m.writeJSON(w, http.StatusOK, offer)
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(m.t, r.ParseForm())
+
+	var valid bool
+
+	switch r.PostForm.Get("grant_type") {
+	case PreAuthorizedCodeGrantType:
+		valid = r.PostForm.Get("pre-authorized_code") == preAuthorizedCode && r.PostForm.Get("user_pin") == userPIN
+	case AuthorizationCodeGrantType:
+		valid = r.PostForm.Get("code") == authorizationCode && r.PostForm.Get("client_id") == clientID &&
+			r.PostForm.Get("redirect_uri") == redirectURI
+	}
+
+	if !valid {
+		m.writeJSON(w, http.StatusBadRequest, &errorResponse{Error: "invalid_grant"})
+
+		return
+	}
+
+	m.nonces, m.nonceCount = nil, 0
+
+	m.writeJSON(w, http.StatusOK, &tokenResponse{AccessToken: accessToken, TokenType: "bearer", CNonce: m.newNonce()})
+}
+
+func (m *mockIssuer) credential(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+accessToken {
+		m.writeJSON(w, http.StatusUnauthorized, &errorResponse{Error: "invalid_token"})
+
+		return
+	}
+
+	req := &credentialRequest{}
+	require.NoError(m.t, json.NewDecoder(r.Body).Decode(req))
+	require.Equal(m.t, ProofTypeJWT, req.Proof.ProofType)
+
+	jws, err := jose.ParseJWS(req.Proof.JWT, jose.NewPublicKeyVerifier(m.holderPubKey))
+	if err != nil {
+		m.writeJSON(w, http.StatusBadRequest, &errorResponse{Error: "invalid_proof", ErrorDescription: err.Error()})
+
+		return
+	}
+
+	typ, _ := jws.ProtectedHeaders.Type()
+	require.Equal(m.t, ProofJWTType, typ)
+
+	kid, _ := jws.ProtectedHeaders.KeyID()
+	require.Equal(m.t, holderKID, kid)
+
+	claims := &proofClaims{}
+	require.NoError(m.t, json.Unmarshal(jws.Payload, claims))
+	require.Equal(m.t, m.server.URL, claims.Audience)
+	require.Equal(m.t, m.nonce, claims.Nonce)
+
+	m.nonces = append(m.nonces, claims.Nonce)
+
+	vc := &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   req.Types,
+		ID:      "http://example.edu/credentials/1872",
+		Issuer:  verifiable.Issuer{ID: m.server.URL},
+		Issued:  util.NewTime(time.Now()),
+		Subject: map[string]interface{}{"id": "did:example:holder"},
+	}
+
+	claimsJWT, err := vc.JWTClaims(false)
+	require.NoError(m.t, err)
+
+	vcJWT, err := claimsJWT.MarshalUnsecuredJWT()
+	require.NoError(m.t, err)
+
+	m.writeJSON(w, http.StatusOK, &credentialResponse{Format: req.Format, Credential: vcJWT, CNonce: m.newNonce()})
+}
+
+func (m *mockIssuer) newNonce() string {
+	m.nonce = fmt.Sprintf("nonce-%d", m.nonceCount)
+	m.nonceCount++
+
+	return m.nonce
+}
+
+func (m *mockIssuer) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(m.t, json.NewEncoder(w).Encode(v))
+}
+
+func newHolderKey(t *testing.T) (*cryptoapi.PublicKey, jose.Signer) {
+	t.Helper()
+
+	kmsSvc, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, kh, err := kmsSvc.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, err := kmsSvc.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	return &cryptoapi.PublicKey{X: pubKey}, jose.NewCryptoSigner(cryptoSvc, kh, jose.AlgEdDSA)
+}
+
+func loader(t *testing.T) *jsonld.DocumentLoader {
+	t.Helper()
+
+	l, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	return l
+}
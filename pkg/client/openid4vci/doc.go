@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package openid4vci provides a wallet client for OpenID for Verifiable Credential Issuance:
+// https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html.
+//
+// Create your client, signing proofs of possession with a KMS key:
+//
+//	signer := jose.NewCryptoSigner(crypto, keyHandle, jose.AlgEdDSA)
+//
+//	client, err := openid4vci.New(openid4vci.WithProofSigner(signer, "did:example:holder#key-1"))
+//	if err != nil {
+//		panic(err)
+//	}
+//
+// Resolve the credential offer received from the issuer with client.ResolveCredentialOffer(), then fetch the
+// offered credentials with client.RequestCredentialWithPreAuthorizedCode(), or with
+// client.RequestCredentialWithAuthorizationCode() once the holder has been authorized at client.AuthorizationURL().
+package openid4vci
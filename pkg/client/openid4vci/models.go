@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vci
+
+const (
+	// PreAuthorizedCodeGrantType is the grant type of the pre-authorized code flow.
+	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	// AuthorizationCodeGrantType is the grant type of the authorization code flow.
+	AuthorizationCodeGrantType = "authorization_code"
+
+	// ProofTypeJWT is the proof type of JWT proofs of possession.
+	ProofTypeJWT = "jwt"
+	// ProofJWTType is the "typ" header of JWT proofs of possession.
+	ProofJWTType = "openid4vci-proof+jwt"
+
+	// FormatJWTVCJSON is the format of credentials issued as JWT.
+	FormatJWTVCJSON = "jwt_vc_json"
+	// FormatLDPVC is the format of credentials secured by a linked data proof.
+	FormatLDPVC = "ldp_vc"
+)
+
+// CredentialOffer is a credential offer of an issuer
+// (https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-offer).
+type CredentialOffer struct {
+	CredentialIssuer string                 `json:"credential_issuer"`
+	Credentials      []CredentialDefinition `json:"credentials"`
+	Grants           Grants                 `json:"grants,omitempty"`
+}
+
+// CredentialDefinition identifies an offered credential by its format and types.
+type CredentialDefinition struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types"`
+}
+
+// Grants holds the grants of a credential offer.
+type Grants struct {
+	AuthorizationCode *AuthorizationCodeGrant `json:"authorization_code,omitempty"`
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// AuthorizationCodeGrant is the grant of the authorization code flow.
+type AuthorizationCodeGrant struct {
+	IssuerState string `json:"issuer_state,omitempty"`
+}
+
+// PreAuthorizedCodeGrant is the grant of the pre-authorized code flow.
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+	UserPINRequired   bool   `json:"user_pin_required,omitempty"`
+}
+
+// IssuerMetadata is the metadata of a credential issuer, served at /.well-known/openid-credential-issuer.
+type IssuerMetadata struct {
+	CredentialIssuer    string `json:"credential_issuer"`
+	AuthorizationServer string `json:"authorization_server,omitempty"`
+	CredentialEndpoint  string `json:"credential_endpoint"`
+	// TokenEndpoint is used by issuers acting as their own authorization server.
+	TokenEndpoint string `json:"token_endpoint,omitempty"`
+}
+
+// authorizationServerMetadata is the OAuth 2.0 authorization server metadata (RFC 8414).
+type authorizationServerMetadata struct {
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	CNonce      string `json:"c_nonce,omitempty"`
+}
+
+type credentialRequest struct {
+	Format string   `json:"format"`
+	Types  []string `json:"types"`
+	Proof  *proof   `json:"proof,omitempty"`
+}
+
+type proof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+type credentialResponse struct {
+	Format     string      `json:"format"`
+	Credential interface{} `json:"credential"`
+	CNonce     string      `json:"c_nonce,omitempty"`
+}
+
+type authorizationDetail struct {
+	Type   string   `json:"type"`
+	Format string   `json:"format"`
+	Types  []string `json:"types"`
+}
+
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
@@ -0,0 +1,281 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const presentationDefinitionParam = "presentation_definition"
+
+var logger = log.New("aries-framework/client/openid4vp")
+
+// Client answers OpenID4VP authorization requests of verifiers with the holder's credentials.
+type Client struct {
+	httpClient     *http.Client
+	signer         verifiable.Signer
+	alg            verifiable.JWSAlgorithm
+	keyID          string
+	holder         string
+	credentialOpts []verifiable.CredentialOpt
+}
+
+// Opt configures the Client.
+type Opt func(c *Client)
+
+// WithHTTPClient sets the HTTP client used to post responses. http.DefaultClient is used by default.
+func WithHTTPClient(httpClient *http.Client) Opt {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithSigner sets the signer of the JWT VP tokens, their signature algorithm and the key ID, e.g. a DID URL of the
+// holder, set in their "kid" header. It is required.
+func WithSigner(signer verifiable.Signer, alg verifiable.JWSAlgorithm, kid string) Opt {
+	return func(c *Client) {
+		c.signer = signer
+		c.alg = alg
+		c.keyID = kid
+	}
+}
+
+// WithHolder sets the holder of the presentations, e.g. the holder's DID.
+func WithHolder(holder string) Opt {
+	return func(c *Client) {
+		c.holder = holder
+	}
+}
+
+// WithCredentialOpts sets the options used by the presentation definition evaluation, e.g. to derive selectively
+// disclosed credentials.
+func WithCredentialOpts(opts ...verifiable.CredentialOpt) Opt {
+	return func(c *Client) {
+		c.credentialOpts = opts
+	}
+}
+
+// New returns a new OpenID4VP Client.
+func New(opts ...Opt) (*Client, error) {
+	c := &Client{httpClient: http.DefaultClient}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.signer == nil {
+		return nil, errors.New("signer is required")
+	}
+
+	return c, nil
+}
+
+// ParseAuthorizationRequest reads an authorization request passed by value in the query of requestURI,
+// e.g. openid4vp://?client_id=...&response_type=vp_token&response_mode=direct_post&presentation_definition=...
+func ParseAuthorizationRequest(requestURI string) (*AuthorizationRequest, error) {
+	u, err := url.Parse(requestURI)
+	if err != nil {
+		return nil, fmt.Errorf("parse authorization request: %w", err)
+	}
+
+	query := u.Query()
+
+	req := &AuthorizationRequest{
+		ClientID:     query.Get("client_id"),
+		ResponseType: query.Get("response_type"),
+		ResponseMode: query.Get("response_mode"),
+		ResponseURI:  query.Get("response_uri"),
+		Nonce:        query.Get("nonce"),
+		State:        query.Get("state"),
+	}
+
+	if pd := query.Get(presentationDefinitionParam); pd != "" {
+		req.PresentationDefinition = &presexch.PresentationDefinition{}
+
+		err = json.Unmarshal([]byte(pd), req.PresentationDefinition)
+		if err != nil {
+			return nil, fmt.Errorf("parse authorization request: presentation definition: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+// PresentCredentials selects the credentials satisfying the presentation definition of req, presents them to the
+// verifier in a JWT VP token bound to its client_id and nonce, and posts the VP token and the presentation
+// submission to the response_uri of req.
+func (c *Client) PresentCredentials(req *AuthorizationRequest,
+	credentials []*verifiable.Credential) (*Response, error) {
+	err := checkRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("present credentials: %w", err)
+	}
+
+	submission, selected, err := req.PresentationDefinition.Evaluate(credentials, c.credentialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("present credentials: %w", err)
+	}
+
+	vpToken, err := c.createVPToken(req, selected)
+	if err != nil {
+		return nil, fmt.Errorf("present credentials: %w", err)
+	}
+
+	submissionBytes, err := json.Marshal(toVPTokenSubmission(submission))
+	if err != nil {
+		return nil, fmt.Errorf("present credentials: marshal presentation submission: %w", err)
+	}
+
+	form := url.Values{
+		"vp_token":                {vpToken},
+		"presentation_submission": {string(submissionBytes)},
+	}
+
+	if req.State != "" {
+		form.Set("state", req.State)
+	}
+
+	resp, err := c.postResponse(req.ResponseURI, form)
+	if err != nil {
+		return nil, fmt.Errorf("present credentials: %w", err)
+	}
+
+	return resp, nil
+}
+
+func checkRequest(req *AuthorizationRequest) error {
+	if req.ResponseType != ResponseTypeVPToken {
+		return fmt.Errorf("unsupported response_type '%s'", req.ResponseType)
+	}
+
+	if req.ResponseMode != ResponseModeDirectPost {
+		return fmt.Errorf("unsupported response_mode '%s'", req.ResponseMode)
+	}
+
+	if req.ResponseURI == "" {
+		return errors.New("response_uri is missing")
+	}
+
+	// the VP token is bound to the verifier through its aud (client_id) and nonce claims.
+	if req.ClientID == "" {
+		return errors.New("client_id is missing")
+	}
+
+	if req.Nonce == "" {
+		return errors.New("nonce is missing")
+	}
+
+	if req.PresentationDefinition == nil {
+		return errors.New("presentation_definition is missing")
+	}
+
+	return nil
+}
+
+func (c *Client) createVPToken(req *AuthorizationRequest, credentials []*verifiable.Credential) (string, error) {
+	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(credentials...))
+	if err != nil {
+		return "", fmt.Errorf("create presentation: %w", err)
+	}
+
+	vp.Holder = c.holder
+
+	claims, err := vp.JWTClaims([]string{req.ClientID}, false)
+	if err != nil {
+		return "", fmt.Errorf("create presentation JWT claims: %w", err)
+	}
+
+	claims.Nonce = req.Nonce
+
+	vpToken, err := claims.MarshalJWS(c.alg, c.signer, c.keyID)
+	if err != nil {
+		return "", fmt.Errorf("sign presentation: %w", err)
+	}
+
+	return vpToken, nil
+}
+
+// toVPTokenSubmission maps the descriptors of submission, pointing to credentials of the presentation, to the VP
+// token itself with the credentials as nested paths. The presentation of a JWT VP token is in its "vp" claim, e.g.
+// $.verifiableCredential[0] becomes $.vp.verifiableCredential[0].
+func toVPTokenSubmission(submission *presexch.PresentationSubmission) *presexch.PresentationSubmission {
+	descriptors := make([]*presexch.InputDescriptorMapping, len(submission.DescriptorMap))
+
+	for i, d := range submission.DescriptorMap {
+		descriptors[i] = &presexch.InputDescriptorMapping{
+			ID:         d.ID,
+			Format:     FormatJWTVP,
+			Path:       "$",
+			PathNested: &presexch.InputDescriptorMapping{ID: d.ID, Format: d.Format, Path: vpClaimPath(d.Path)},
+		}
+	}
+
+	return &presexch.PresentationSubmission{
+		ID:            submission.ID,
+		DefinitionID:  submission.DefinitionID,
+		DescriptorMap: descriptors,
+	}
+}
+
+func vpClaimPath(path string) string {
+	return "$.vp" + strings.TrimPrefix(path, "$")
+}
+
+func (c *Client) postResponse(responseURI string, form url.Values) (*Response, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, responseURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("post response: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("post response: %w", err)
+	}
+
+	defer closeResponseBody(httpResp.Body)
+
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("post response: read response body: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("post response: %s returned status code [%d]: %s", responseURI,
+			httpResp.StatusCode, body)
+	}
+
+	resp := &Response{}
+
+	if len(body) > 0 {
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return nil, fmt.Errorf("post response: unmarshal verifier response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Errorf("Failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,262 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	holderDID         = "did:example:holder"
+	verifierClientID  = "did:example:verifier"
+	testNonce         = "nonce-123"
+	testState         = "state-456"
+	examplesContext   = "https://www.w3.org/2018/credentials/examples/v1"
+	verifierRedirect  = "https://verifier.example.com/done"
+	degreeDescriptor  = "university_degree"
+	degreeCredential  = "UniversityDegreeCredential"
+	licenseCredential = "DriverLicenseCredential"
+)
+
+func TestClient_PresentCredentials(t *testing.T) {
+	holderPubKey, signer := newHolderKey(t)
+	verifier := newMockVerifier(t, holderPubKey)
+
+	client, err := New(WithHTTPClient(verifier.server.Client()), WithHolder(holderDID),
+		WithSigner(signer, verifiable.EdDSA, holderDID+"#key-1"))
+	require.NoError(t, err)
+
+	pdBytes, err := json.Marshal(&presexch.PresentationDefinition{
+		ID: "pd-1",
+		InputDescriptors: []*presexch.InputDescriptor{{
+			ID:     degreeDescriptor,
+			Schema: []*presexch.Schema{{URI: examplesContext + "#" + degreeCredential}},
+		}},
+	})
+	require.NoError(t, err)
+
+	requestURI := "openid4vp://?" + url.Values{
+		"client_id":               {verifierClientID},
+		"response_type":           {ResponseTypeVPToken},
+		"response_mode":           {ResponseModeDirectPost},
+		"response_uri":            {verifier.server.URL + "/response"},
+		"nonce":                   {testNonce},
+		"state":                   {testState},
+		"presentation_definition": {string(pdBytes)},
+	}.Encode()
+
+	req, err := ParseAuthorizationRequest(requestURI)
+	require.NoError(t, err)
+	require.Equal(t, verifierClientID, req.ClientID)
+	require.Equal(t, "pd-1", req.PresentationDefinition.ID)
+
+	credentials := []*verifiable.Credential{newCredential(licenseCredential), newCredential(degreeCredential)}
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := client.PresentCredentials(req, credentials)
+		require.NoError(t, err)
+		require.Equal(t, verifierRedirect, resp.RedirectURI)
+
+		require.Len(t, verifier.presentation.Credentials(), 1)
+		require.Equal(t, holderDID, verifier.presentation.Holder)
+
+		require.Equal(t, "pd-1", verifier.submission.DefinitionID)
+		require.Len(t, verifier.submission.DescriptorMap, 1)
+		require.Equal(t, degreeDescriptor, verifier.submission.DescriptorMap[0].ID)
+		require.Equal(t, FormatJWTVP, verifier.submission.DescriptorMap[0].Format)
+		require.Equal(t, "$", verifier.submission.DescriptorMap[0].Path)
+		require.Equal(t, "$.vp.verifiableCredential[0]", verifier.submission.DescriptorMap[0].PathNested.Path)
+	})
+
+	t.Run("no matching credentials", func(t *testing.T) {
+		_, err := client.PresentCredentials(req, []*verifiable.Credential{newCredential(licenseCredential)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), presexch.ErrNoCredentials.Error())
+	})
+
+	t.Run("verifier rejects the response", func(t *testing.T) {
+		otherReq := *req
+		otherReq.Nonce = "other nonce"
+
+		_, err := client.PresentCredentials(&otherReq, credentials)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "returned status code [400]")
+	})
+
+	t.Run("missing client_id or nonce", func(t *testing.T) {
+		otherReq := *req
+		otherReq.ClientID = ""
+
+		_, err := client.PresentCredentials(&otherReq, credentials)
+		require.EqualError(t, err, "present credentials: client_id is missing")
+
+		otherReq = *req
+		otherReq.Nonce = ""
+
+		_, err = client.PresentCredentials(&otherReq, credentials)
+		require.EqualError(t, err, "present credentials: nonce is missing")
+	})
+
+	t.Run("unsupported response mode", func(t *testing.T) {
+		otherReq := *req
+		otherReq.ResponseMode = "fragment"
+
+		_, err := client.PresentCredentials(&otherReq, credentials)
+		require.EqualError(t, err, "present credentials: unsupported response_mode 'fragment'")
+	})
+
+	t.Run("unsupported response type", func(t *testing.T) {
+		otherReq := *req
+		otherReq.ResponseType = "id_token"
+
+		_, err := client.PresentCredentials(&otherReq, credentials)
+		require.EqualError(t, err, "present credentials: unsupported response_type 'id_token'")
+	})
+
+	t.Run("missing presentation definition", func(t *testing.T) {
+		otherReq := *req
+		otherReq.PresentationDefinition = nil
+
+		_, err := client.PresentCredentials(&otherReq, credentials)
+		require.EqualError(t, err, "present credentials: presentation_definition is missing")
+	})
+}
+
+func TestParseAuthorizationRequest(t *testing.T) {
+	_, err := ParseAuthorizationRequest("openid4vp://?presentation_definition=%7B")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "parse authorization request: presentation definition")
+
+	_, err = ParseAuthorizationRequest(":")
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	require.EqualError(t, err, "signer is required")
+}
+
+// mockVerifier is an OpenID4VP verifier receiving direct_post responses.
+type mockVerifier struct {
+	t            *testing.T
+	server       *httptest.Server
+	holderPubKey []byte
+	presentation *verifiable.Presentation
+	submission   *presexch.PresentationSubmission
+}
+
+func newMockVerifier(t *testing.T, holderPubKey []byte) *mockVerifier {
+	t.Helper()
+
+	verifier := &mockVerifier{t: t, holderPubKey: holderPubKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/response", verifier.response)
+
+	verifier.server = httptest.NewServer(mux)
+	t.Cleanup(verifier.server.Close)
+
+	return verifier
+}
+
+func (m *mockVerifier) response(w http.ResponseWriter, r *http.Request) {
+	require.NoError(m.t, r.ParseForm())
+	require.Equal(m.t, testState, r.PostForm.Get("state"))
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(m.t, err)
+
+	vpToken := r.PostForm.Get("vp_token")
+
+	vp, err := verifiable.ParsePresentation([]byte(vpToken),
+		verifiable.WithPresPublicKeyFetcher(verifiable.SingleKey(m.holderPubKey, kms.ED25519)),
+		verifiable.WithPresJSONLDDocumentLoader(loader))
+	require.NoError(m.t, err)
+
+	if payloadNonce(m.t, vpToken) != testNonce {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	submission := &presexch.PresentationSubmission{}
+	require.NoError(m.t, json.Unmarshal([]byte(r.PostForm.Get("presentation_submission")), submission))
+
+	m.presentation = vp
+	m.submission = submission
+
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(m.t, json.NewEncoder(w).Encode(&Response{RedirectURI: verifierRedirect}))
+}
+
+func payloadNonce(t *testing.T, vpToken string) string {
+	t.Helper()
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(vpToken, ".")[1])
+	require.NoError(t, err)
+
+	var claims struct {
+		Nonce string `json:"nonce"`
+		Aud   string `json:"aud"`
+	}
+
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	require.Equal(t, verifierClientID, claims.Aud)
+
+	return claims.Nonce
+}
+
+func newCredential(credentialType string) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{verifiable.ContextURI, examplesContext},
+		Types:   []string{verifiable.VCType, credentialType},
+		ID:      "http://example.edu/credentials/" + credentialType,
+		Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+		Issued:  util.NewTime(time.Now()),
+		Subject: map[string]interface{}{"id": holderDID},
+	}
+}
+
+func newHolderKey(t *testing.T) ([]byte, jose.Signer) {
+	t.Helper()
+
+	kmsSvc, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, kh, err := kmsSvc.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, err := kmsSvc.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	return pubKey, jose.NewCryptoSigner(cryptoSvc, kh, jose.AlgEdDSA)
+}
@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package openid4vp provides a wallet client answering OpenID for Verifiable Presentations authorization requests:
+// https://openid.net/specs/openid-4-verifiable-presentations-1_0.html.
+//
+// Create your client, signing VP tokens with a KMS key:
+//
+//	signer := jose.NewCryptoSigner(crypto, keyHandle, jose.AlgEdDSA)
+//
+//	client, err := openid4vp.New(openid4vp.WithSigner(signer, verifiable.EdDSA, "did:example:holder#key-1"),
+//		openid4vp.WithHolder("did:example:holder"))
+//	if err != nil {
+//		panic(err)
+//	}
+//
+// Parse the verifier's request with openid4vp.ParseAuthorizationRequest(), then answer it with
+// client.PresentCredentials(). Only the direct_post response mode is supported.
+package openid4vp
@@ -0,0 +1,39 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openid4vp
+
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+const (
+	// ResponseTypeVPToken is the response type of authorization requests asking for a VP token.
+	ResponseTypeVPToken = "vp_token"
+	// ResponseModeDirectPost is the response mode where the response is sent with an HTTP POST to the response_uri.
+	ResponseModeDirectPost = "direct_post"
+
+	// FormatJWTVP is the format of VP tokens signed as JWT.
+	FormatJWTVP = "jwt_vp"
+)
+
+// AuthorizationRequest is an OpenID4VP authorization request of a verifier
+// (https://openid.net/specs/openid-4-verifiable-presentations-1_0.html#name-authorization-request).
+type AuthorizationRequest struct {
+	ClientID               string                           `json:"client_id"`
+	ResponseType           string                           `json:"response_type"`
+	ResponseMode           string                           `json:"response_mode"`
+	ResponseURI            string                           `json:"response_uri"`
+	Nonce                  string                           `json:"nonce"`
+	State                  string                           `json:"state,omitempty"`
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition"`
+}
+
+// Response is the verifier's response to the authorization response.
+type Response struct {
+	// RedirectURI is the URI the holder is redirected to, if any.
+	RedirectURI string `json:"redirect_uri,omitempty"`
+}
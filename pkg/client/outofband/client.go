@@ -64,6 +64,8 @@ type EventOptions struct {
 	Connections []string
 	ReuseAny    bool
 	ReuseDID    string
+	// TransientConnection keeps the connection in memory until its time to live elapses.
+	TransientConnection bool
 }
 
 // RouterConnections return router connections.
@@ -86,6 +88,11 @@ func (e *EventOptions) ReuseConnection() string {
 	return e.ReuseDID
 }
 
+// Transient signals whether the connection is ephemeral and must not be written to the permanent store.
+func (e *EventOptions) Transient() bool {
+	return e.TransientConnection
+}
+
 // Event is a container of out-of-band protocol-specific properties for DIDCommActions and StateMsgs.
 type Event interface {
 	// ConnectionID of the connection record, once it's created.
@@ -99,16 +106,17 @@ type Event interface {
 type MessageOption func(*message)
 
 type message struct {
-	Label              string
-	Goal               string
-	GoalCode           string
-	RouterConnections  []string
-	Service            []interface{}
-	HandshakeProtocols []string
-	Attachments        []*decorator.Attachment
-	Accept             []string
-	ReuseAnyConnection bool
-	ReuseConnection    string
+	Label               string
+	Goal                string
+	GoalCode            string
+	RouterConnections   []string
+	Service             []interface{}
+	HandshakeProtocols  []string
+	Attachments         []*decorator.Attachment
+	Accept              []string
+	ReuseAnyConnection  bool
+	ReuseConnection     string
+	TransientConnection bool
 }
 
 func (m *message) RouterConnection() string {
@@ -240,10 +248,11 @@ func (c *Client) ActionContinue(piID, label string, opts ...MessageOption) error
 	}
 
 	return c.oobService.ActionContinue(piID, &EventOptions{
-		Label:       label,
-		Connections: msg.RouterConnections,
-		ReuseAny:    msg.ReuseAnyConnection,
-		ReuseDID:    msg.ReuseConnection,
+		Label:               label,
+		Connections:         msg.RouterConnections,
+		ReuseAny:            msg.ReuseAnyConnection,
+		ReuseDID:            msg.ReuseConnection,
+		TransientConnection: msg.TransientConnection,
 	})
 }
 
@@ -265,10 +274,11 @@ func (c *Client) AcceptInvitation(i *Invitation, myLabel string, opts ...Message
 	connID, err := c.oobService.AcceptInvitation(
 		&cast,
 		&EventOptions{
-			Label:       myLabel,
-			ReuseAny:    msg.ReuseAnyConnection,
-			ReuseDID:    msg.ReuseConnection,
-			Connections: msg.RouterConnections,
+			Label:               myLabel,
+			ReuseAny:            msg.ReuseAnyConnection,
+			ReuseDID:            msg.ReuseConnection,
+			Connections:         msg.RouterConnections,
+			TransientConnection: msg.TransientConnection,
 		},
 	)
 	if err != nil {
@@ -305,7 +315,8 @@ func WithRouterConnections(conn ...string) MessageOption {
 	}
 }
 
-// WithHandshakeProtocols allows you to customize the handshake_protocols to include in the Invitation.
+// WithHandshakeProtocols allows you to customize the handshake_protocols to include in the Invitation,
+// listed in order of preference: the invitee accepts the invitation with the first one it supports.
 func WithHandshakeProtocols(proto ...string) MessageOption {
 	return func(m *message) {
 		m.HandshakeProtocols = proto
@@ -347,6 +358,15 @@ func ReuseConnection(theirDID string) MessageOption {
 	}
 }
 
+// WithTransientConnection is used when accepting an invitation with either AcceptInvitation or ActionContinue.
+// The connection created by the subsequent did-exchange is ephemeral (e.g. for a one-shot exchange with a verifier
+// kiosk): it is kept in memory until its time to live elapses and it is never written to the permanent store.
+func WithTransientConnection() MessageOption {
+	return func(m *message) {
+		m.TransientConnection = true
+	}
+}
+
 func validateServices(svcs ...interface{}) error {
 	for i := range svcs {
 		switch svc := svcs[i].(type) {
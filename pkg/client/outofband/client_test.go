@@ -288,6 +288,22 @@ func TestAcceptInvitation(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, expected, result)
 	})
+	t.Run("with a transient connection", func(t *testing.T) {
+		provider := withTestProvider()
+		provider.ServiceMap = map[string]interface{}{
+			outofband.Name: &stubOOBService{
+				acceptInvFunc: func(_ *outofband.Invitation, options outofband.Options) (string, error) {
+					require.True(t, options.Transient())
+
+					return "123456", nil
+				},
+			},
+		}
+		c, err := New(provider)
+		require.NoError(t, err)
+		_, err = c.AcceptInvitation(&Invitation{}, "", WithTransientConnection())
+		require.NoError(t, err)
+	})
 	t.Run("wraps error from outofband service", func(t *testing.T) {
 		expected := errors.New("test")
 		provider := withTestProvider()
@@ -0,0 +1,164 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+)
+
+// Invitation is this protocol's `invitation` message.
+type Invitation outofbandv2.Invitation
+
+const (
+	// InvitationMsgType is the 'type' for the invitation message.
+	InvitationMsgType = outofbandv2.InvitationMsgType
+)
+
+// MessageOption allow you to customize the way out-of-band 2.0 invitations are built.
+type MessageOption func(*message)
+
+type message struct {
+	From        string
+	Goal        string
+	GoalCode    string
+	Accept      []string
+	Attachments []*decorator.AttachmentV2
+}
+
+// AcceptOption allow you to customize how an out-of-band 2.0 invitation is accepted.
+type AcceptOption func(*acceptOpts)
+
+type acceptOpts struct {
+	MyDID string
+}
+
+// OobService defines the outofbandv2 service.
+type OobService interface {
+	AcceptInvitation(*outofbandv2.Invitation, string) (string, error)
+	SaveInvitation(*outofbandv2.Invitation) error
+}
+
+// Provider provides the dependencies for the client.
+type Provider interface {
+	Service(id string) (interface{}, error)
+}
+
+// Client for the Out-Of-Band 2.0 protocol:
+// https://identity.foundation/didcomm-messaging/spec/#out-of-band-messages
+type Client struct {
+	oobService OobService
+}
+
+// New returns a new Client for the Out-Of-Band 2.0 protocol.
+func New(p Provider) (*Client, error) {
+	s, err := p.Service(outofbandv2.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up service %s : %w", outofbandv2.Name, err)
+	}
+
+	oobSvc, ok := s.(OobService)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast service %s as a dependency", outofbandv2.Name)
+	}
+
+	return &Client{
+		oobService: oobSvc,
+	}, nil
+}
+
+// CreateInvitation creates and saves an out-of-band 2.0 invitation.
+// If no `from` DID is given with WithFrom, a new did:peer:2 is created for the invitation.
+func (c *Client) CreateInvitation(opts ...MessageOption) (*Invitation, error) {
+	msg := &message{}
+
+	for _, opt := range opts {
+		opt(msg)
+	}
+
+	inv := &Invitation{
+		ID:   uuid.New().String(),
+		Type: InvitationMsgType,
+		From: msg.From,
+		Body: &outofbandv2.InvitationBody{
+			GoalCode: msg.GoalCode,
+			Goal:     msg.Goal,
+			Accept:   msg.Accept,
+		},
+		Requests: msg.Attachments,
+	}
+
+	cast := outofbandv2.Invitation(*inv)
+
+	err := c.oobService.SaveInvitation(&cast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save outofband 2.0 invitation : %w", err)
+	}
+
+	inv.From = cast.From
+
+	return inv, nil
+}
+
+// AcceptInvitation from another agent and return the ID of the new DIDComm V2 connection record.
+func (c *Client) AcceptInvitation(i *Invitation, opts ...AcceptOption) (string, error) {
+	options := &acceptOpts{}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cast := outofbandv2.Invitation(*i)
+
+	connID, err := c.oobService.AcceptInvitation(&cast, options.MyDID)
+	if err != nil {
+		return "", fmt.Errorf("out-of-band 2.0 service failed to accept invitation : %w", err)
+	}
+
+	return connID, nil
+}
+
+// WithFrom allows you to specify the `from` DID of the invitation.
+func WithFrom(from string) MessageOption {
+	return func(m *message) {
+		m.From = from
+	}
+}
+
+// WithGoal allows you to specify the `goal` and `goalCode` for the invitation.
+func WithGoal(goal, goalCode string) MessageOption {
+	return func(m *message) {
+		m.Goal = goal
+		m.GoalCode = goalCode
+	}
+}
+
+// WithAttachments allows you to include attachments in the invitation.
+func WithAttachments(a ...*decorator.AttachmentV2) MessageOption {
+	return func(m *message) {
+		m.Attachments = a
+	}
+}
+
+// WithAccept will set the given media type profiles in the invitation's `accept` property.
+func WithAccept(a ...string) MessageOption {
+	return func(m *message) {
+		m.Accept = a
+	}
+}
+
+// WithMyDID allows you to specify the DID used for the connection when accepting an invitation.
+// A new did:peer:2 is created if it isn't given.
+func WithMyDID(myDID string) AcceptOption {
+	return func(o *acceptOpts) {
+		o.MyDID = myDID
+	}
+}
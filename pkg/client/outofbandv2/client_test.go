@@ -0,0 +1,157 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("returns client", func(t *testing.T) {
+		c, err := New(withTestProvider(&stubOOBService{}))
+		require.NoError(t, err)
+		require.NotNil(t, c)
+	})
+
+	t.Run("fails to look up the service", func(t *testing.T) {
+		_, err := New(&provider.Provider{ServiceErr: errors.New("test")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to look up service")
+	})
+
+	t.Run("fails to cast the service", func(t *testing.T) {
+		_, err := New(withTestProvider("not a service"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to cast service")
+	})
+}
+
+func TestCreateInvitation(t *testing.T) {
+	t.Run("creates and saves the invitation", func(t *testing.T) {
+		attachment := &decorator.AttachmentV2{
+			ID:        "attachment",
+			MediaType: "application/json",
+			Data:      decorator.AttachmentData{JSON: map[string]interface{}{"id": "123"}},
+		}
+
+		var saved *outofbandv2.Invitation
+
+		c, err := New(withTestProvider(&stubOOBService{
+			saveFunc: func(i *outofbandv2.Invitation) error {
+				i.From = "did:peer:2.test"
+				saved = i
+
+				return nil
+			},
+		}))
+		require.NoError(t, err)
+
+		inv, err := c.CreateInvitation(
+			WithGoal("To issue a credential", "issue-vc"),
+			WithAccept("didcomm/v2"),
+			WithAttachments(attachment),
+		)
+		require.NoError(t, err)
+		require.NotEmpty(t, inv.ID)
+		require.Equal(t, InvitationMsgType, inv.Type)
+		require.Equal(t, "did:peer:2.test", inv.From)
+		require.Equal(t, "To issue a credential", inv.Body.Goal)
+		require.Equal(t, "issue-vc", inv.Body.GoalCode)
+		require.Equal(t, []string{"didcomm/v2"}, inv.Body.Accept)
+		require.Equal(t, []*decorator.AttachmentV2{attachment}, inv.Requests)
+		require.Equal(t, inv.ID, saved.ID)
+	})
+
+	t.Run("sets the given 'from' DID", func(t *testing.T) {
+		c, err := New(withTestProvider(&stubOOBService{}))
+		require.NoError(t, err)
+
+		inv, err := c.CreateInvitation(WithFrom("did:example:inviter"))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:inviter", inv.From)
+	})
+
+	t.Run("fails to save the invitation", func(t *testing.T) {
+		c, err := New(withTestProvider(&stubOOBService{
+			saveFunc: func(*outofbandv2.Invitation) error {
+				return errors.New("test")
+			},
+		}))
+		require.NoError(t, err)
+
+		_, err = c.CreateInvitation()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to save outofband 2.0 invitation")
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	t.Run("returns the connection ID", func(t *testing.T) {
+		c, err := New(withTestProvider(&stubOOBService{
+			acceptFunc: func(i *outofbandv2.Invitation, myDID string) (string, error) {
+				require.Equal(t, "invitation", i.ID)
+				require.Equal(t, "did:example:invitee", myDID)
+
+				return "connection", nil
+			},
+		}))
+		require.NoError(t, err)
+
+		connID, err := c.AcceptInvitation(&Invitation{ID: "invitation"}, WithMyDID("did:example:invitee"))
+		require.NoError(t, err)
+		require.Equal(t, "connection", connID)
+	})
+
+	t.Run("fails to accept the invitation", func(t *testing.T) {
+		c, err := New(withTestProvider(&stubOOBService{
+			acceptFunc: func(*outofbandv2.Invitation, string) (string, error) {
+				return "", errors.New("test")
+			},
+		}))
+		require.NoError(t, err)
+
+		_, err = c.AcceptInvitation(&Invitation{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to accept invitation")
+	})
+}
+
+func withTestProvider(svc interface{}) *provider.Provider {
+	return &provider.Provider{
+		ServiceMap: map[string]interface{}{
+			outofbandv2.Name: svc,
+		},
+	}
+}
+
+type stubOOBService struct {
+	acceptFunc func(*outofbandv2.Invitation, string) (string, error)
+	saveFunc   func(*outofbandv2.Invitation) error
+}
+
+func (s *stubOOBService) AcceptInvitation(i *outofbandv2.Invitation, myDID string) (string, error) {
+	if s.acceptFunc != nil {
+		return s.acceptFunc(i, myDID)
+	}
+
+	return "", nil
+}
+
+func (s *stubOOBService) SaveInvitation(i *outofbandv2.Invitation) error {
+	if s.saveFunc != nil {
+		return s.saveFunc(i)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package outofbandv2 provides support for the Out-of-Band 2.0 protocol:
+// https://identity.foundation/didcomm-messaging/spec/#out-of-band-messages.
+//
+// Create your client:
+//
+//	ctx := getFrameworkContext()
+//	client, err := outofbandv2.New(ctx)
+//	if err != nil {
+//		panic(err)
+//	}
+//
+// Create an invitation with client.CreateInvitation() and share it, eg. as a URL with
+// outofbandv2.EncodeInvitationURL().
+//
+// Accept an invitation received out of band with client.AcceptInvitation(). This returns the ID of the
+// newly-created DIDComm V2 connection record and dispatches the invitation's attached message to the
+// protocol service handling it.
+package outofbandv2
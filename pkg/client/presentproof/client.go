@@ -23,6 +23,12 @@ type (
 	// presentation process, or in response to a request-presentation message when the Prover wants to
 	// propose using a different presentation format.
 	ProposePresentation presentproof.ProposePresentation
+	// RequestPresentationV3 is the present-proof 3.0 (DIDComm V2) RequestPresentation.
+	RequestPresentationV3 presentproof.RequestPresentationV3
+	// PresentationV3 is the present-proof 3.0 (DIDComm V2) Presentation.
+	PresentationV3 presentproof.PresentationV3
+	// ProposePresentationV3 is the present-proof 3.0 (DIDComm V2) ProposePresentation.
+	ProposePresentationV3 presentproof.ProposePresentationV3
 	// Action contains helpful information about action.
 	Action presentproof.Action
 )
@@ -97,6 +103,18 @@ func (c *Client) SendRequestPresentation(msg *RequestPresentation, myDID, theirD
 	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
 }
 
+// SendRequestPresentationV3 is used by the Verifier to send a present-proof 3.0 request presentation
+// over a DIDComm V2 connection. It returns the threadID of the new instance of the protocol.
+func (c *Client) SendRequestPresentationV3(msg *RequestPresentationV3, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errEmptyRequestPresentation
+	}
+
+	msg.Type = presentproof.RequestPresentationMsgTypeV3
+
+	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
+}
+
 type addProof func(presentation *verifiable.Presentation) error
 
 // AcceptRequestPresentation is used by the Prover is to accept a presentation request.
@@ -104,11 +122,22 @@ func (c *Client) AcceptRequestPresentation(piID string, msg *Presentation, sign
 	return c.service.ActionContinue(piID, WithMultiOptions(WithPresentation(msg), WithAddProofFn(sign)))
 }
 
+// AcceptRequestPresentationV3 is used by the Prover is to accept a present-proof 3.0 presentation request.
+func (c *Client) AcceptRequestPresentationV3(piID string, msg *PresentationV3, sign addProof) error {
+	return c.service.ActionContinue(piID, WithMultiOptions(WithPresentationV3(msg), WithAddProofFn(sign)))
+}
+
 // NegotiateRequestPresentation is used by the Prover to counter a presentation request they received with a proposal.
 func (c *Client) NegotiateRequestPresentation(piID string, msg *ProposePresentation) error {
 	return c.service.ActionContinue(piID, WithProposePresentation(msg))
 }
 
+// NegotiateRequestPresentationV3 is used by the Prover to counter a present-proof 3.0 presentation request
+// they received with a proposal.
+func (c *Client) NegotiateRequestPresentationV3(piID string, msg *ProposePresentationV3) error {
+	return c.service.ActionContinue(piID, WithProposePresentationV3(msg))
+}
+
 // DeclineRequestPresentation is used when the Prover does not want to accept the request presentation.
 func (c *Client) DeclineRequestPresentation(piID, reason string) error {
 	return c.service.ActionStop(piID, errors.New(reason))
@@ -126,11 +155,29 @@ func (c *Client) SendProposePresentation(msg *ProposePresentation, myDID, theirD
 	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
 }
 
+// SendProposePresentationV3 is used by the Prover to send a present-proof 3.0 propose presentation
+// over a DIDComm V2 connection. It returns the threadID of the new instance of the protocol.
+func (c *Client) SendProposePresentationV3(msg *ProposePresentationV3, myDID, theirDID string) (string, error) {
+	if msg == nil {
+		return "", errEmptyProposePresentation
+	}
+
+	msg.Type = presentproof.ProposePresentationMsgTypeV3
+
+	return c.service.HandleInbound(service.NewDIDCommMsgMap(msg), service.NewDIDCommContext(myDID, theirDID, nil))
+}
+
 // AcceptProposePresentation is used when the Verifier is willing to accept the propose presentation.
 func (c *Client) AcceptProposePresentation(piID string, msg *RequestPresentation) error {
 	return c.service.ActionContinue(piID, WithRequestPresentation(msg))
 }
 
+// AcceptProposePresentationV3 is used when the Verifier is willing to accept the present-proof 3.0
+// propose presentation.
+func (c *Client) AcceptProposePresentationV3(piID string, msg *RequestPresentationV3) error {
+	return c.service.ActionContinue(piID, WithRequestPresentationV3(msg))
+}
+
 // DeclineProposePresentation is used when the Verifier does not want to accept the propose presentation.
 func (c *Client) DeclineProposePresentation(piID, reason string) error {
 	return c.service.ActionStop(piID, errors.New(reason))
@@ -186,6 +233,30 @@ func WithRequestPresentation(msg *RequestPresentation) presentproof.Opt {
 	return presentproof.WithRequestPresentation(&origin)
 }
 
+// WithPresentationV3 allows providing PresentationV3 message
+// Use this option to respond to RequestPresentationV3.
+func WithPresentationV3(msg *PresentationV3) presentproof.Opt {
+	origin := presentproof.PresentationV3(*msg)
+
+	return presentproof.WithPresentationV3(&origin)
+}
+
+// WithProposePresentationV3 allows providing ProposePresentationV3 message
+// Use this option to respond to RequestPresentationV3.
+func WithProposePresentationV3(msg *ProposePresentationV3) presentproof.Opt {
+	origin := presentproof.ProposePresentationV3(*msg)
+
+	return presentproof.WithProposePresentationV3(&origin)
+}
+
+// WithRequestPresentationV3 allows providing RequestPresentationV3 message
+// Use this option to respond to ProposePresentationV3.
+func WithRequestPresentationV3(msg *RequestPresentationV3) presentproof.Opt {
+	origin := presentproof.RequestPresentationV3(*msg)
+
+	return presentproof.WithRequestPresentationV3(&origin)
+}
+
 // WithFriendlyNames allows providing names for the presentations.
 func WithFriendlyNames(names ...string) presentproof.Opt {
 	return presentproof.WithFriendlyNames(names...)
@@ -246,3 +246,125 @@ func TestClient_NegotiateProposePresentation(t *testing.T) {
 
 	require.NoError(t, client.NegotiateRequestPresentation("PIID", &ProposePresentation{}))
 }
+
+func TestClient_SendRequestPresentationV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		thid := uuid.New().String()
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.NewDIDCommContext(Alice, Bob, nil)).
+			DoAndReturn(func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+				require.Equal(t, msg.Type(), presentproof.RequestPresentationMsgTypeV3)
+
+				return thid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		result, err := client.SendRequestPresentationV3(&RequestPresentationV3{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, thid, result)
+	})
+
+	t.Run("Empty Request Presentation", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.SendRequestPresentationV3(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyRequestPresentation.Error())
+	})
+}
+
+func TestClient_SendProposePresentationV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+		thid := uuid.New().String()
+
+		svc := mocks.NewMockProtocolService(ctrl)
+		svc.EXPECT().HandleInbound(gomock.Any(), service.NewDIDCommContext(Alice, Bob, nil)).
+			DoAndReturn(func(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+				require.Equal(t, msg.Type(), presentproof.ProposePresentationMsgTypeV3)
+
+				return thid, nil
+			})
+
+		provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		result, err := client.SendProposePresentationV3(&ProposePresentationV3{}, Alice, Bob)
+		require.NoError(t, err)
+		require.Equal(t, thid, result)
+	})
+
+	t.Run("Empty Propose Presentation", func(t *testing.T) {
+		provider := mocks.NewMockProvider(ctrl)
+
+		provider.EXPECT().Service(gomock.Any()).Return(mocks.NewMockProtocolService(ctrl), nil)
+		client, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = client.SendProposePresentationV3(nil, Alice, Bob)
+		require.EqualError(t, err, errEmptyProposePresentation.Error())
+	})
+}
+
+func TestClient_AcceptRequestPresentationV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptRequestPresentationV3("PIID", &PresentationV3{}, nil))
+}
+
+func TestClient_AcceptProposePresentationV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.AcceptProposePresentationV3("PIID", &RequestPresentationV3{}))
+}
+
+func TestClient_NegotiateRequestPresentationV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	provider := mocks.NewMockProvider(ctrl)
+
+	svc := mocks.NewMockProtocolService(ctrl)
+	svc.EXPECT().ActionContinue("PIID", gomock.Any()).Return(nil)
+
+	provider.EXPECT().Service(gomock.Any()).Return(svc, nil)
+	client, err := New(provider)
+	require.NoError(t, err)
+
+	require.NoError(t, client.NegotiateRequestPresentationV3("PIID", &ProposePresentationV3{}))
+}
@@ -0,0 +1,33 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock
+
+import "time"
+
+// Clock reads the current time for the time-based checks of the framework (credential expiry, JWT nbf and exp,
+// message ~timing), so that they can be controlled in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock is the default Clock, reading the system time.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// OrSystem returns the clock, or SystemClock if the clock is nil.
+func OrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock{}
+	}
+
+	return c
+}
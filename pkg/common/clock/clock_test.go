@@ -0,0 +1,35 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestSystemClock_Now(t *testing.T) {
+	before := time.Now()
+	now := SystemClock{}.Now()
+
+	require.False(t, now.Before(before))
+	require.False(t, now.After(time.Now()))
+}
+
+func TestOrSystem(t *testing.T) {
+	require.Equal(t, SystemClock{}, OrSystem(nil))
+
+	fixed := fixedClock(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, fixed, OrSystem(fixed))
+}
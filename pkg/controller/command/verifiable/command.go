@@ -858,6 +858,27 @@ func (o *Command) createAndSignPresentationByID(vc *verifiable.Credential,
 	return vp.MarshalJSON()
 }
 
+// jwsSigner returns the signer with the JWS algorithm of the key of the verification method, which the
+// JsonWebSignature2020 suite puts in the header of its proofs.
+func (o *Command) jwsSigner(s suite.Signer, verificationMethod string) (suite.Signer, error) {
+	idSplit := strings.Split(verificationMethod, "#")
+	if len(idSplit) != creatorParts {
+		return nil, fmt.Errorf("wrong id %s to resolve", verificationMethod)
+	}
+
+	pubKey, err := verifiable.NewVDRKeyResolver(o.ctx.VDRegistry()).PublicKeyFetcher()(idSplit[0], "#"+idSplit[1])
+	if err != nil {
+		return nil, fmt.Errorf("resolve verification method: %w", err)
+	}
+
+	alg, err := jsonwebsignature2020.VerificationMethodJWSAlgorithm(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("JWS algorithm of the verification method: %w", err)
+	}
+
+	return jsonwebsignature2020.NewSignerWithJWSAlgorithm(s, alg), nil
+}
+
 func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 	s, err := newKMSSigner(o.ctx.KMS(), o.ctx.Crypto(), getKID(opts))
 	if err != nil {
@@ -870,7 +891,12 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
 	case JSONWebSignature2020:
-		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+		jwsSigner, e := o.jwsSigner(s, opts.VerificationMethod)
+		if e != nil {
+			return e
+		}
+
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(jwsSigner))
 	case BbsBlsSignature2020:
 		s.bbs = true
 		signatureSuite = bbsblssignature2020.New(suite.WithSigner(s))
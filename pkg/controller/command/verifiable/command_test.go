@@ -1034,6 +1034,7 @@ func TestGeneratePresentation(t *testing.T) {
 		require.Equal(t, vp.Proofs[0]["proofPurpose"], "authentication")
 		require.Contains(t, vp.Proofs[0]["created"], strconv.Itoa(presReq.Created.Year()))
 		require.Contains(t, vp.Proofs[0]["type"], "JsonWebSignature2020")
+		require.Equal(t, "ES256K", jwsAlgorithm(t, vp.Proofs[0]))
 	})
 
 	t.Run("test generate presentation with proof options - success (ed25519 jsonwebsignature)", func(t *testing.T) {
@@ -1078,6 +1079,7 @@ func TestGeneratePresentation(t *testing.T) {
 		require.Equal(t, vp.Proofs[0]["proofPurpose"], "authentication")
 		require.Contains(t, vp.Proofs[0]["created"], strconv.Itoa(presReq.Created.Year()))
 		require.Contains(t, vp.Proofs[0]["type"], "JsonWebSignature2020")
+		require.Equal(t, "EdDSA", jwsAlgorithm(t, vp.Proofs[0]))
 	})
 
 	t.Run("test generate presentation with proof options - unsupported signature type", func(t *testing.T) {
@@ -2251,6 +2253,7 @@ func TestCommand_SignCredential(t *testing.T) {
 		require.Equal(t, vc.Proofs[0]["proofPurpose"], "assertionMethod")
 		require.Contains(t, vc.Proofs[0]["created"], strconv.Itoa(req.Created.Year()))
 		require.Contains(t, vc.Proofs[0]["type"], "JsonWebSignature2020")
+		require.Equal(t, "EdDSA", jwsAlgorithm(t, vc.Proofs[0]))
 	})
 
 	t.Run("test sign credential with proof options - unsupported signature type", func(t *testing.T) {
@@ -2795,3 +2798,22 @@ func createTestDocumentLoader(t *testing.T) *jld.DocumentLoader {
 
 	return loader
 }
+
+// jwsAlgorithm returns the alg of the detached JWS of the proof.
+func jwsAlgorithm(t *testing.T, proof verifiable.Proof) string {
+	t.Helper()
+
+	jws, ok := proof["jws"].(string)
+	require.True(t, ok)
+
+	header, err := base64.RawURLEncoding.DecodeString(strings.Split(jws, ".")[0])
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(header, &fields))
+
+	alg, ok := fields["alg"].(string)
+	require.True(t, ok)
+
+	return alg
+}
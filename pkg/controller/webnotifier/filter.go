@@ -0,0 +1,56 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"path"
+)
+
+// TopicFilter reports whether notifications of the given topic must be forwarded.
+type TopicFilter func(topic string) bool
+
+// AllowTopics returns a TopicFilter accepting topics matching any of the given patterns.
+// Patterns are either exact topic names (eg: "present-proof_states") or glob patterns (eg: "didexchange_*") as
+// supported by path.Match.
+func AllowTopics(patterns ...string) TopicFilter {
+	return func(topic string) bool {
+		for _, pattern := range patterns {
+			if pattern == topic {
+				return true
+			}
+
+			if ok, err := path.Match(pattern, topic); err == nil && ok {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FilteringNotifier is a Notifier forwarding to the wrapped notifier only the notifications accepted by its filter.
+type FilteringNotifier struct {
+	notifier Notifier
+	filter   TopicFilter
+}
+
+// NewFilteringNotifier returns a new instance of a FilteringNotifier.
+func NewFilteringNotifier(notifier Notifier, filter TopicFilter) *FilteringNotifier {
+	return &FilteringNotifier{notifier: notifier, filter: filter}
+}
+
+// Notify forwards the given message to the wrapped notifier if its topic is accepted by the filter,
+// otherwise the message is dropped silently.
+func (n *FilteringNotifier) Notify(topic string, message []byte) error {
+	if !n.filter(topic) {
+		logger.Debugf("notification for topic '%s' filtered out", topic)
+
+		return nil
+	}
+
+	return n.notifier.Notify(topic, message)
+}
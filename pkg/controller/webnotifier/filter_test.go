@@ -0,0 +1,85 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func TestAllowTopics(t *testing.T) {
+	filter := AllowTopics("present-proof_states", "issue-credential_states", "didexchange_*", "[")
+
+	require.True(t, filter("present-proof_states"))
+	require.True(t, filter("issue-credential_states"))
+	require.True(t, filter("didexchange_states"))
+	require.True(t, filter("didexchange_actions"))
+	require.True(t, filter("["))
+	require.False(t, filter("didexchange"))
+	require.False(t, filter("present-proof_actions"))
+	require.False(t, filter(""))
+
+	require.False(t, AllowTopics()("present-proof_states"))
+}
+
+func TestFilteringNotifier_Notify(t *testing.T) {
+	payload := []byte("payload")
+
+	t.Run("exact match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify("present-proof_states", payload).Return(nil).Times(1)
+
+		n := NewFilteringNotifier(notifier, AllowTopics("present-proof_states"))
+		require.NoError(t, n.Notify("present-proof_states", payload))
+	})
+
+	t.Run("glob match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		errNotify := errors.New("notify error")
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify("didexchange_states", payload).Return(errNotify).Times(1)
+
+		n := NewFilteringNotifier(notifier, AllowTopics("didexchange_*"))
+		require.True(t, errors.Is(n.Notify("didexchange_states", payload), errNotify))
+	})
+
+	t.Run("rejected topic", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(0)
+
+		n := NewFilteringNotifier(notifier, AllowTopics("present-proof_states", "didexchange_*"))
+		require.NoError(t, n.Notify("issue-credential_states", payload))
+	})
+
+	t.Run("custom predicate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify("topic", payload).Return(nil).Times(1)
+
+		n := NewFilteringNotifier(notifier, func(topic string) bool {
+			return topic == "topic"
+		})
+		require.NoError(t, n.Notify("topic", payload))
+		require.NoError(t, n.Notify("other", payload))
+	})
+}
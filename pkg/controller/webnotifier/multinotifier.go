@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+// MultiNotifier is a Notifier fanning out notifications to multiple notifiers (eg: WebSocket and HTTP Webhooks).
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+// NewMultiNotifier returns a new instance of a MultiNotifier dispatching to the given notifiers.
+func NewMultiNotifier(notifiers ...Notifier) *MultiNotifier {
+	return &MultiNotifier{notifiers: notifiers}
+}
+
+// Notify sends the given message to all of the notifiers. A failing notifier does not prevent the message from
+// being sent to the remaining ones, errors encountered are combined into the returned error.
+func (n *MultiNotifier) Notify(topic string, message []byte) error {
+	var allErrs error
+
+	for _, notifier := range n.notifiers {
+		if err := notifier.Notify(topic, message); err != nil {
+			allErrs = appendError(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
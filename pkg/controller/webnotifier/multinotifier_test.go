@@ -0,0 +1,57 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func TestMultiNotifier_Notify(t *testing.T) {
+	const topic = "test"
+
+	payload := []byte("payload")
+
+	t.Run("notify all notifiers", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := mocks.NewMockNotifier(ctrl)
+		first.EXPECT().Notify(topic, payload).Return(nil).Times(1)
+
+		second := mocks.NewMockNotifier(ctrl)
+		second.EXPECT().Notify(topic, payload).Return(nil).Times(1)
+
+		require.NoError(t, NewMultiNotifier(first, second).Notify(topic, payload))
+	})
+
+	t.Run("continue notifying on error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		first := mocks.NewMockNotifier(ctrl)
+		first.EXPECT().Notify(topic, payload).Return(errors.New("first error")).Times(1)
+
+		second := mocks.NewMockNotifier(ctrl)
+		second.EXPECT().Notify(topic, payload).Return(nil).Times(1)
+
+		third := mocks.NewMockNotifier(ctrl)
+		third.EXPECT().Notify(topic, payload).Return(errors.New("third error")).Times(1)
+
+		err := NewMultiNotifier(first, second, third).Notify(topic, payload)
+		require.EqualError(t, err, "first error;third error")
+	})
+
+	t.Run("no notifiers", func(t *testing.T) {
+		require.NoError(t, NewMultiNotifier().Notify(topic, payload))
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+const (
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 30 * time.Second
+	defaultRetryJitter      = 0.5
+	defaultRetryMaxAttempts = 5
+)
+
+// DeadLetterFunc is invoked with the notification and the last error received once all delivery attempts of a
+// RetryNotifier are exhausted.
+type DeadLetterFunc func(topic string, message []byte, err error)
+
+type retryOpts struct {
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	jitter      float64
+	maxAttempts int
+	deadLetter  DeadLetterFunc
+}
+
+// RetryOpt is a RetryNotifier option.
+type RetryOpt func(opts *retryOpts)
+
+// WithRetryBaseDelay sets the delay before the first retry. Subsequent delays grow exponentially.
+func WithRetryBaseDelay(delay time.Duration) RetryOpt {
+	return func(opts *retryOpts) {
+		opts.baseDelay = delay
+	}
+}
+
+// WithRetryMaxDelay caps the delay between two attempts.
+func WithRetryMaxDelay(delay time.Duration) RetryOpt {
+	return func(opts *retryOpts) {
+		opts.maxDelay = delay
+	}
+}
+
+// WithRetryJitter sets the randomization factor applied to each delay (eg: 0.5 randomizes a delay of 1s within
+// [0.5s, 1.5s]). A value of 0 disables jitter.
+func WithRetryJitter(jitter float64) RetryOpt {
+	return func(opts *retryOpts) {
+		opts.jitter = jitter
+	}
+}
+
+// WithRetryMaxAttempts sets the maximum number of delivery attempts, including the first one.
+func WithRetryMaxAttempts(attempts int) RetryOpt {
+	return func(opts *retryOpts) {
+		opts.maxAttempts = attempts
+	}
+}
+
+// WithDeadLetter sets a callback invoked with notifications that could not be delivered.
+func WithDeadLetter(deadLetter DeadLetterFunc) RetryOpt {
+	return func(opts *retryOpts) {
+		opts.deadLetter = deadLetter
+	}
+}
+
+// RetryNotifier is a Notifier retrying failed notifications with an exponential backoff.
+// Note: a wrapped notifier dispatching to multiple subscribers (eg: HTTPNotifier with multiple URLs) is retried as a
+// whole, wrap a notifier per subscriber if each one must be retried individually.
+type RetryNotifier struct {
+	notifier Notifier
+	opts     retryOpts
+}
+
+// NewRetryNotifier returns a new instance of a RetryNotifier wrapping the given notifier.
+func NewRetryNotifier(notifier Notifier, opts ...RetryOpt) *RetryNotifier {
+	options := retryOpts{
+		baseDelay:   defaultRetryBaseDelay,
+		maxDelay:    defaultRetryMaxDelay,
+		jitter:      defaultRetryJitter,
+		maxAttempts: defaultRetryMaxAttempts,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &RetryNotifier{notifier: notifier, opts: options}
+}
+
+// Notify sends the given message using the wrapped notifier, retrying until it succeeds or the max attempts are
+// reached. In the latter case, the dead letter callback (if any) is invoked and the last error is returned.
+func (n *RetryNotifier) Notify(topic string, message []byte) error {
+	attempts := 0
+
+	err := backoff.Retry(func() error {
+		attempts++
+
+		return n.notifier.Notify(topic, message)
+	}, n.backOff())
+	if err != nil {
+		if n.opts.deadLetter != nil {
+			n.opts.deadLetter(topic, message, err)
+		}
+
+		return fmt.Errorf("notification failed after %d attempt(s): %w", attempts, err)
+	}
+
+	return nil
+}
+
+func (n *RetryNotifier) backOff() backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = n.opts.baseDelay
+	b.MaxInterval = n.opts.maxDelay
+	b.RandomizationFactor = n.opts.jitter
+	// attempts are capped by maxAttempts only.
+	b.MaxElapsedTime = 0
+
+	retries := 0
+	if n.opts.maxAttempts > 1 {
+		retries = n.opts.maxAttempts - 1
+	}
+
+	return backoff.WithMaxRetries(b, uint64(retries))
+}
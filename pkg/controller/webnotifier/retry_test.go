@@ -0,0 +1,104 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webnotifier
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/controller/webnotifier"
+)
+
+func TestRetryNotifier_Notify(t *testing.T) {
+	t.Run("webhook succeeds on third attempt", func(t *testing.T) {
+		var calls int32
+
+		srv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				resp.WriteHeader(http.StatusServiceUnavailable)
+
+				return
+			}
+
+			resp.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		deadLetters := 0
+
+		n := NewRetryNotifier(NewHTTPNotifier([]string{srv.URL}),
+			WithRetryBaseDelay(time.Millisecond),
+			WithRetryMaxDelay(10*time.Millisecond),
+			WithRetryJitter(0),
+			WithRetryMaxAttempts(5),
+			WithDeadLetter(func(string, []byte, error) {
+				deadLetters++
+			}))
+
+		err := n.Notify(topic, getTestBasicMessageJSON())
+		require.NoError(t, err)
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+		require.Zero(t, deadLetters)
+	})
+
+	t.Run("dead letter after max attempts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		errNotify := errors.New("notify error")
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify(topic, []byte("payload")).Return(errNotify).Times(3)
+
+		var (
+			deadTopic string
+			deadMsg   []byte
+			deadErr   error
+		)
+
+		n := NewRetryNotifier(notifier,
+			WithRetryBaseDelay(time.Millisecond),
+			WithRetryMaxAttempts(3),
+			WithDeadLetter(func(topic string, message []byte, err error) {
+				deadTopic, deadMsg, deadErr = topic, message, err
+			}))
+
+		err := n.Notify(topic, []byte("payload"))
+		require.True(t, errors.Is(err, errNotify))
+		require.EqualError(t, err, "notification failed after 3 attempt(s): notify error")
+		require.Equal(t, topic, deadTopic)
+		require.Equal(t, []byte("payload"), deadMsg)
+		require.True(t, errors.Is(deadErr, errNotify))
+	})
+
+	t.Run("single attempt without dead letter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		notifier := mocks.NewMockNotifier(ctrl)
+		notifier.EXPECT().Notify(topic, []byte("payload")).Return(errors.New("notify error")).Times(1)
+
+		err := NewRetryNotifier(notifier, WithRetryMaxAttempts(1)).Notify(topic, []byte("payload"))
+		require.EqualError(t, err, "notification failed after 1 attempt(s): notify error")
+	})
+
+	t.Run("default options", func(t *testing.T) {
+		n := NewRetryNotifier(nil)
+		require.Equal(t, defaultRetryBaseDelay, n.opts.baseDelay)
+		require.Equal(t, defaultRetryMaxDelay, n.opts.maxDelay)
+		require.Equal(t, defaultRetryJitter, n.opts.jitter)
+		require.Equal(t, defaultRetryMaxAttempts, n.opts.maxAttempts)
+		require.Nil(t, n.opts.deadLetter)
+	})
+}
@@ -97,6 +97,9 @@ type RecipientWrappedKey struct {
 	Alg          string    `json:"alg,omitempty"`
 	APU          []byte    `json:"apu,omitempty"`
 	APV          []byte    `json:"apv,omitempty"`
+	// KDFInfo is the serialized Concat KDF input used to derive the key encryption key. It is only set by WrapKey()
+	// when the WithKDFTrace() option is used.
+	KDFInfo []byte `json:"kdfinfo,omitempty"`
 }
 
 // PublicKey mainly to exchange EPK in RecipientWrappedKey.
@@ -17,14 +17,19 @@ import (
 	"github.com/google/tink/go/aead"
 	aeadsubtle "github.com/google/tink/go/aead/subtle"
 	"github.com/google/tink/go/core/primitiveset"
+	"github.com/google/tink/go/daead"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/mac"
+	macsubtle "github.com/google/tink/go/mac/subtle"
 	"github.com/google/tink/go/signature"
+	"github.com/google/tink/go/tink"
 	"golang.org/x/crypto/chacha20poly1305"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/aead/subtle"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
+	// register secp256k1 key managers for Sign/Verify.
+	_ "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 const (
@@ -96,16 +101,17 @@ func (t *Crypto) Encrypt(msg, aad []byte, kh interface{}) ([]byte, []byte, error
 
 func nonceSize(ps *primitiveset.PrimitiveSet) int {
 	var ivSize int
-	// AESGCM and XChacha20Poly1305 nonce sizes supported only for now
+	// AESGCM, XChacha20Poly1305 and AES-CBC+HMAC-SHA2 nonce sizes supported only for now
 	switch ps.Primary.Primitive.(type) {
 	case *aeadsubtle.XChaCha20Poly1305:
 		ivSize = chacha20poly1305.NonceSizeX
 	case *aeadsubtle.AESGCM:
 		ivSize = aeadsubtle.AESGCMIVSize
-	case *aeadsubtle.EncryptThenAuthenticate:
+	case *aeadsubtle.EncryptThenAuthenticate, *subtle.AESCBCHMAC:
 		// AESCBC+HMACSHA Tink keys use Tink's EncryptThenAuthenticate AEAD primitive as per the CBC hmac key manager's
-		// Primitive() call.
-		ivSize = subtle.AES128Size
+		// Primitive() call. Its output is IV || ciphertext || tag (RFC 7518 section 5.2.2.1), only the IV is
+		// returned as the nonce, the authentication tag remains appended to the ciphertext.
+		ivSize = subtle.AESCBCIVSize
 	default:
 		ivSize = aeadsubtle.AESGCMIVSize
 	}
@@ -145,6 +151,94 @@ func (t *Crypto) Decrypt(cipher, aad, nonce []byte, kh interface{}) ([]byte, err
 	return pt, nil
 }
 
+// Seal will encrypt msg without aad using a matching AEAD primitive in kh key handle and return a single
+// self-contained blob: nonce || ciphertext. It is a convenience wrapper around Encrypt for callers that don't need
+// aad and would otherwise have to store the nonce separately.
+func (t *Crypto) Seal(msg []byte, kh interface{}) ([]byte, error) {
+	cipherText, nonce, err := t.Encrypt(msg, nil, kh)
+	if err != nil {
+		return nil, fmt.Errorf("seal: %w", err)
+	}
+
+	sealed := make([]byte, 0, len(nonce)+len(cipherText))
+	sealed = append(sealed, nonce...)
+	sealed = append(sealed, cipherText...)
+
+	return sealed, nil
+}
+
+// Open will decrypt a sealed blob created by Seal using a matching AEAD primitive in kh key handle. The nonce size
+// is detected from kh's primary primitive.
+func (t *Crypto) Open(sealed []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	ps, err := keyHandle.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("open: get primitives: %w", err)
+	}
+
+	ivSize := nonceSize(ps)
+	if len(sealed) < ivSize {
+		return nil, fmt.Errorf("open: sealed message size %d is shorter than nonce size %d", len(sealed), ivSize)
+	}
+
+	pt, err := t.Decrypt(sealed[ivSize:], nil, sealed[:ivSize], keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	return pt, nil
+}
+
+// EncryptDeterministic will deterministically encrypt plaintext and associatedData using a matching deterministic
+// AEAD primitive (eg AES-SIV as per RFC 5297) in kh key handle. Encrypting the same plaintext and associatedData with
+// the same key always returns the same ciphertext, which makes it suitable for deterministic lookup tokens.
+// returns:
+// 		ciphertext (including Tink's key prefix, if any) to be used by DecryptDeterministic
+//		error in case of errors
+func (t *Crypto) EncryptDeterministic(plaintext, associatedData []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	d, err := daead.New(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new deterministic aead: %w", err)
+	}
+
+	ct, err := d.EncryptDeterministically(plaintext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt deterministically: %w", err)
+	}
+
+	return ct, nil
+}
+
+// DecryptDeterministic will decrypt ciphertext created by EncryptDeterministic with associatedData using a matching
+// deterministic AEAD primitive in kh key handle.
+func (t *Crypto) DecryptDeterministic(ciphertext, associatedData []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	d, err := daead.New(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new deterministic aead: %w", err)
+	}
+
+	pt, err := d.DecryptDeterministically(ciphertext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt deterministically: %w", err)
+	}
+
+	return pt, nil
+}
+
 // Sign will sign msg using the implementation's corresponding signing key referenced by kh of a private key.
 func (t *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
 	keyHandle, ok := kh.(*keyset.Handle)
@@ -218,6 +312,40 @@ func (t *Crypto) VerifyMAC(macBytes, data []byte, kh interface{}) error {
 	return macPrimitive.VerifyMAC(macBytes, data)
 }
 
+// MACTagSize returns the size of the MAC computed by ComputeMAC using the primary MAC primitive in kh key handle.
+// The size includes the key prefix Tink adds to the tag for non RAW keys (eg 5 bytes for TINK keys). For example, a
+// RAW HMAC-SHA256 key with a full tag returns 32.
+func (t *Crypto) MACTagSize(kh interface{}) (int, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return 0, errBadKeyHandleFormat
+	}
+
+	ps, err := keyHandle.Primitives()
+	if err != nil {
+		return 0, fmt.Errorf("macTagSize: get primitives: %w", err)
+	}
+
+	var tagSize int
+
+	switch p := ps.Primary.Primitive.(type) {
+	case *macsubtle.HMAC:
+		tagSize = int(p.TagSize)
+	case tink.MAC:
+		// other MAC primitives (eg AES-CMAC) don't expose their tag size, compute a tag to read it.
+		tag, e := p.ComputeMAC(nil)
+		if e != nil {
+			return 0, fmt.Errorf("macTagSize: %w", e)
+		}
+
+		tagSize = len(tag)
+	default:
+		return 0, fmt.Errorf("macTagSize: primary primitive is not a MAC: %T", p)
+	}
+
+	return len(ps.Primary.Prefix) + tagSize, nil
+}
+
 // WrapKey will do ECDH (ES or 1PU) key wrapping of cek using apu, apv and recipient public key 'recPubKey'.
 // This function is used with the following parameters:
 //  - Key Wrapping: `ECDH-ES` (no options) or `ECDH-1PU` (using crypto.WithSender() option in wrapKeyOpts) over either:
@@ -233,6 +361,7 @@ func (t *Crypto) VerifyMAC(macBytes, data []byte, kh interface{}) error {
 //  - KDF (based on recPubKey.Curve): `Concat KDF` as per https://tools.ietf.org/html/rfc7518#section-4.6 (for recPubKey
 //    with NIST P curves) or `Curve25519`+`Concat KDF` as per https://tools.ietf.org/html/rfc7748#section-6.1 (for
 //    recPubKey with X25519 curve).
+//  - KDF input tracing: the crypto.WithKDFTrace() option sets the serialized Concat KDF input in the result's KDFInfo.
 // returns the resulting key wrapping info as *composite.RecipientWrappedKey or error in case of wrapping failure.
 func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
 	wrapKeyOpts ...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
@@ -252,9 +381,81 @@ func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *cryptoapi.PublicKey,
 		return nil, fmt.Errorf("wrapKey: %w", err)
 	}
 
+	if pOpts.KDFTrace() {
+		wk.KDFInfo = kdfTrace(wk, pOpts.Tag(), pOpts.SenderKey() != nil)
+	}
+
 	return wk, nil
 }
 
+// WrapKeyMulti will do ECDH (ES or 1PU) key wrapping of cek for each recipient public key in recipients using apu
+// and apv. It supports the same algorithms and wrapKeyOpts as WrapKey(). For ECDH-1PU, the sender ephemeral key is
+// generated once per recipient key type and curve (or set with the crypto.WithEPK() option) and is shared by all
+// recipients of that type. This means apu (if empty, it is set from the shared ephemeral key) will be consistent
+// across these recipients.
+// Recipients can mix NIST P and X25519 keys, note however that ECDH-1PU requires the sender key to match the
+// recipients' key type.
+// returns the list of key wrapping info as []*composite.RecipientWrappedKey (in the same order as recipients) or
+// error in case of wrapping failure.
+func (t *Crypto) WrapKeyMulti(cek, apu, apv []byte, recipients []*cryptoapi.PublicKey,
+	wrapKeyOpts ...cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, error) {
+	if len(recipients) == 0 {
+		return nil, errors.New("wrapKeyMulti: recipients public keys are required")
+	}
+
+	pOpts := cryptoapi.NewOpt()
+
+	for _, opt := range wrapKeyOpts {
+		opt(pOpts)
+	}
+
+	epks := map[string]*cryptoapi.PrivateKey{}
+
+	if pOpts.EPK() != nil {
+		epks[epkGroup(&pOpts.EPK().PublicKey)] = pOpts.EPK()
+	}
+
+	wrappedKeys := make([]*cryptoapi.RecipientWrappedKey, 0, len(recipients))
+
+	for i, recPubKey := range recipients {
+		if recPubKey == nil {
+			return nil, fmt.Errorf("wrapKeyMulti: recipient public key #%d is empty", i)
+		}
+
+		var epk *cryptoapi.PrivateKey
+
+		if pOpts.SenderKey() != nil { // ECDH-ES always uses a new EPK per recipient.
+			var ok bool
+
+			epk, ok = epks[epkGroup(recPubKey)]
+			if !ok {
+				var err error
+
+				epk, err = t.generateEPK(recPubKey)
+				if err != nil {
+					return nil, fmt.Errorf("wrapKeyMulti: recipient #%d: %w", i, err)
+				}
+
+				epks[epkGroup(recPubKey)] = epk
+			}
+		}
+
+		wk, err := t.deriveKEKAndWrap(cek, apu, apv, pOpts.Tag(), pOpts.SenderKey(), recPubKey, epk,
+			pOpts.UseXC20PKW())
+		if err != nil {
+			return nil, fmt.Errorf("wrapKeyMulti: recipient #%d: %w", i, err)
+		}
+
+		if pOpts.KDFTrace() {
+			wk.KDFInfo = kdfTrace(wk, pOpts.Tag(), pOpts.SenderKey() != nil)
+		}
+
+		wrappedKeys = append(wrappedKeys, wk)
+	}
+
+	return wrappedKeys, nil
+}
+
 // UnwrapKey unwraps a key in recWK using ECDH (ES or 1PU) with recipient private key kh.
 // This function is used with the following parameters:
 //  - Key Unwrapping: `ECDH-ES` (no options) or `ECDH-1PU` (using crypto.WithSender() option in wrapKeyOpts) over either
@@ -13,14 +13,20 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	tinkaead "github.com/google/tink/go/aead"
 	tinkaeadsubtle "github.com/google/tink/go/aead/subtle"
+	"github.com/google/tink/go/daead"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/mac"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	hmacpb "github.com/google/tink/go/proto/hmac_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/signature"
 	"github.com/google/tink/go/subtle/random"
+	"github.com/google/tink/go/testkeyset"
+	"github.com/google/tink/go/testutil"
 	"github.com/stretchr/testify/require"
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
@@ -30,7 +36,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
+	cbcpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/aes_cbc_go_proto"
+	aeadpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/aes_cbc_hmac_aead_go_proto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 )
 
 const testMessage = "test message"
@@ -151,6 +160,206 @@ func TestCrypto_EncryptDecrypt(t *testing.T) {
 	})
 }
 
+// nolint:lll
+func TestCrypto_DecryptAESCBCHMACTestVectors(t *testing.T) {
+	// Source: https://tools.ietf.org/html/rfc7518#appendix-B
+	plaintext := []byte("A cipher system must not be required to be secret, and it must be able to fall into the hands of the enemy without inconvenience")
+	aad := []byte("The second principle of Auguste Kerckhoffs")
+	iv := []byte{
+		0x1a, 0xf3, 0x8c, 0x2d, 0xc2, 0xb9, 0x6f, 0xfd, 0xd8, 0x66, 0x94, 0x09, 0x23, 0x41, 0xbc, 0x04,
+	}
+
+	key := make([]byte, 64)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	tests := []struct {
+		name       string
+		keySize    int
+		hashType   commonpb.HashType
+		cipherText []byte
+		tag        []byte
+	}{
+		{
+			name:     "A128CBC-HS256",
+			keySize:  subtle.AES128Size,
+			hashType: commonpb.HashType_SHA256,
+			cipherText: []byte{
+				0xc8, 0x0e, 0xdf, 0xa3, 0x2d, 0xdf, 0x39, 0xd5, 0xef, 0x00, 0xc0, 0xb4, 0x68, 0x83, 0x42, 0x79,
+				0xa2, 0xe4, 0x6a, 0x1b, 0x80, 0x49, 0xf7, 0x92, 0xf7, 0x6b, 0xfe, 0x54, 0xb9, 0x03, 0xa9, 0xc9,
+				0xa9, 0x4a, 0xc9, 0xb4, 0x7a, 0xd2, 0x65, 0x5c, 0x5f, 0x10, 0xf9, 0xae, 0xf7, 0x14, 0x27, 0xe2,
+				0xfc, 0x6f, 0x9b, 0x3f, 0x39, 0x9a, 0x22, 0x14, 0x89, 0xf1, 0x63, 0x62, 0xc7, 0x03, 0x23, 0x36,
+				0x09, 0xd4, 0x5a, 0xc6, 0x98, 0x64, 0xe3, 0x32, 0x1c, 0xf8, 0x29, 0x35, 0xac, 0x40, 0x96, 0xc8,
+				0x6e, 0x13, 0x33, 0x14, 0xc5, 0x40, 0x19, 0xe8, 0xca, 0x79, 0x80, 0xdf, 0xa4, 0xb9, 0xcf, 0x1b,
+				0x38, 0x4c, 0x48, 0x6f, 0x3a, 0x54, 0xc5, 0x10, 0x78, 0x15, 0x8e, 0xe5, 0xd7, 0x9d, 0xe5, 0x9f,
+				0xbd, 0x34, 0xd8, 0x48, 0xb3, 0xd6, 0x95, 0x50, 0xa6, 0x76, 0x46, 0x34, 0x44, 0x27, 0xad, 0xe5,
+				0x4b, 0x88, 0x51, 0xff, 0xb5, 0x98, 0xf7, 0xf8, 0x00, 0x74, 0xb9, 0x47, 0x3c, 0x82, 0xe2, 0xdb,
+			},
+			tag: []byte{
+				0x65, 0x2c, 0x3f, 0xa3, 0x6b, 0x0a, 0x7c, 0x5b, 0x32, 0x19, 0xfa, 0xb3, 0xa3, 0x0b, 0xc1, 0xc4,
+			},
+		},
+		{
+			name:     "A256CBC-HS512",
+			keySize:  subtle.AES256Size,
+			hashType: commonpb.HashType_SHA512,
+			cipherText: []byte{
+				0x4a, 0xff, 0xaa, 0xad, 0xb7, 0x8c, 0x31, 0xc5, 0xda, 0x4b, 0x1b, 0x59, 0x0d, 0x10, 0xff, 0xbd,
+				0x3d, 0xd8, 0xd5, 0xd3, 0x02, 0x42, 0x35, 0x26, 0x91, 0x2d, 0xa0, 0x37, 0xec, 0xbc, 0xc7, 0xbd,
+				0x82, 0x2c, 0x30, 0x1d, 0xd6, 0x7c, 0x37, 0x3b, 0xcc, 0xb5, 0x84, 0xad, 0x3e, 0x92, 0x79, 0xc2,
+				0xe6, 0xd1, 0x2a, 0x13, 0x74, 0xb7, 0x7f, 0x07, 0x75, 0x53, 0xdf, 0x82, 0x94, 0x10, 0x44, 0x6b,
+				0x36, 0xeb, 0xd9, 0x70, 0x66, 0x29, 0x6a, 0xe6, 0x42, 0x7e, 0xa7, 0x5c, 0x2e, 0x08, 0x46, 0xa1,
+				0x1a, 0x09, 0xcc, 0xf5, 0x37, 0x0d, 0xc8, 0x0b, 0xfe, 0xcb, 0xad, 0x28, 0xc7, 0x3f, 0x09, 0xb3,
+				0xa3, 0xb7, 0x5e, 0x66, 0x2a, 0x25, 0x94, 0x41, 0x0a, 0xe4, 0x96, 0xb2, 0xe2, 0xe6, 0x60, 0x9e,
+				0x31, 0xe6, 0xe0, 0x2c, 0xc8, 0x37, 0xf0, 0x53, 0xd2, 0x1f, 0x37, 0xff, 0x4f, 0x51, 0x95, 0x0b,
+				0xbe, 0x26, 0x38, 0xd0, 0x9d, 0xd7, 0xa4, 0x93, 0x09, 0x30, 0x80, 0x6d, 0x07, 0x03, 0xb1, 0xf6,
+			},
+			tag: []byte{
+				0x4d, 0xd3, 0xb4, 0xc0, 0x88, 0xa7, 0xf4, 0x5c, 0x21, 0x68, 0x39, 0x64, 0x5b, 0x20, 0x12, 0xbf,
+				0x2e, 0x62, 0x69, 0xa8, 0xc5, 0x6a, 0x81, 0x6d, 0xbc, 0x1b, 0x26, 0x77, 0x61, 0x95, 0x5b, 0xc5,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tc := test
+		t.Run(tc.name, func(t *testing.T) {
+			// RFC7518 splits K into MAC_KEY || ENC_KEY, each of keySize length.
+			cbcHMACKey := &aeadpb.AesCbcHmacAeadKey{
+				AesCbcKey: &cbcpb.AesCbcKey{
+					KeyValue: key[tc.keySize : 2*tc.keySize],
+				},
+				HmacKey: &hmacpb.HmacKey{
+					KeyValue: key[:tc.keySize],
+					Params: &hmacpb.HmacParams{
+						Hash:    tc.hashType,
+						TagSize: uint32(len(tc.tag)),
+					},
+				},
+			}
+
+			serializedKey, err := proto.Marshal(cbcHMACKey)
+			require.NoError(t, err)
+
+			keyData := testutil.NewKeyData("type.hyperledger.org/hyperledger.aries.crypto.tink.AesCbcHmacAeadKey",
+				serializedKey, tinkpb.KeyData_SYMMETRIC)
+			ks := testutil.NewTestKeyset(keyData, tinkpb.OutputPrefixType_RAW)
+
+			kh, err := testkeyset.NewHandle(ks)
+			require.NoError(t, err)
+
+			c := Crypto{}
+
+			// the tag is kept appended to the cipher text, the IV is passed in as the nonce.
+			ct := append(append([]byte{}, tc.cipherText...), tc.tag...)
+
+			pt, err := c.Decrypt(ct, aad, iv, kh)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, pt)
+
+			// round trip: output nonce is the IV and the cipher text includes the tag.
+			cipherText, nonce, err := c.Encrypt(plaintext, aad, kh)
+			require.NoError(t, err)
+			require.Len(t, nonce, subtle.AESCBCIVSize)
+			require.Len(t, cipherText, len(tc.cipherText)+len(tc.tag))
+
+			pt, err = c.Decrypt(cipherText, aad, nonce, kh)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, pt)
+		})
+	}
+}
+
+func TestCrypto_SealOpen(t *testing.T) {
+	c := Crypto{}
+	msg := []byte(testMessage)
+
+	for _, template := range []*tinkpb.KeyTemplate{
+		tinkaead.AES256GCMKeyTemplate(),
+		tinkaead.XChaCha20Poly1305KeyTemplate(),
+		aead.AES128CBCHMACSHA256KeyTemplate(),
+	} {
+		kh, err := keyset.NewHandle(template)
+		require.NoError(t, err)
+
+		sealed, err := c.Seal(msg, kh)
+		require.NoError(t, err)
+
+		pt, err := c.Open(sealed, kh)
+		require.NoError(t, err)
+		require.Equal(t, msg, pt)
+
+		// tampered blob - should fail
+		sealed[len(sealed)-1] ^= 0x01
+		_, err = c.Open(sealed, kh)
+		require.Error(t, err)
+
+		// blob shorter than nonce - should fail
+		ps, err := kh.Primitives()
+		require.NoError(t, err)
+
+		_, err = c.Open(sealed[:nonceSize(ps)-1], kh)
+		require.Contains(t, err.Error(), "is shorter than nonce size")
+	}
+
+	t.Run("fail with bad key handle", func(t *testing.T) {
+		_, err := c.Seal(msg, nil)
+		require.EqualError(t, err, "seal: "+errBadKeyHandleFormat.Error())
+
+		_, err = c.Open([]byte("sealed"), nil)
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+	})
+}
+
+func TestCrypto_EncryptDecryptDeterministic(t *testing.T) {
+	kh, err := keyset.NewHandle(daead.AESSIVKeyTemplate())
+	require.NoError(t, err)
+
+	c := Crypto{}
+	msg := []byte(testMessage)
+	ad := []byte("some associated data")
+
+	ct1, err := c.EncryptDeterministic(msg, ad, kh)
+	require.NoError(t, err)
+
+	// same plaintext and associated data with the same key must yield the same ciphertext
+	ct2, err := c.EncryptDeterministic(msg, ad, kh)
+	require.NoError(t, err)
+	require.Equal(t, ct1, ct2)
+
+	// different associated data must yield a different ciphertext
+	ct3, err := c.EncryptDeterministic(msg, []byte("other associated data"), kh)
+	require.NoError(t, err)
+	require.NotEqual(t, ct1, ct3)
+
+	pt, err := c.DecryptDeterministic(ct1, ad, kh)
+	require.NoError(t, err)
+	require.Equal(t, msg, pt)
+
+	_, err = c.DecryptDeterministic(ct1, []byte("bad ad"), kh)
+	require.Contains(t, err.Error(), "decrypt deterministically")
+
+	t.Run("fail with bad key handle", func(t *testing.T) {
+		_, err = c.EncryptDeterministic(msg, ad, nil)
+		require.Equal(t, errBadKeyHandleFormat, err)
+
+		_, err = c.DecryptDeterministic(ct1, ad, nil)
+		require.Equal(t, errBadKeyHandleFormat, err)
+	})
+
+	t.Run("fail with non deterministic aead key", func(t *testing.T) {
+		aeadKH, err := keyset.NewHandle(tinkaead.AES256GCMKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.EncryptDeterministic(msg, ad, aeadKH)
+		require.Contains(t, err.Error(), "create new deterministic aead")
+
+		_, err = c.DecryptDeterministic(ct1, ad, aeadKH)
+		require.Contains(t, err.Error(), "create new deterministic aead")
+	})
+}
+
 func TestCrypto_SignVerify(t *testing.T) {
 	t.Run("test with Ed25519 signature", func(t *testing.T) {
 		kh, err := keyset.NewHandle(signature.ED25519KeyTemplate())
@@ -209,6 +418,32 @@ func TestCrypto_SignVerify(t *testing.T) {
 		err = c.Verify(s, msg, badKH)
 		require.Error(t, err)
 	})
+
+	t.Run("test with secp256k1 signature", func(t *testing.T) {
+		for _, template := range []*tinkpb.KeyTemplate{
+			secp256k1.DERKeyTemplate(),
+			secp256k1.IEEEP1363KeyTemplate(),
+		} {
+			kh, err := keyset.NewHandle(template)
+			require.NoError(t, err)
+
+			c := Crypto{}
+			msg := []byte(testMessage)
+			s, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			// get corresponding public key handle to verify
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			err = c.Verify(s, msg, pubKH)
+			require.NoError(t, err)
+
+			// verify with a different message - should fail
+			err = c.Verify(s, []byte("other message"), pubKH)
+			require.Error(t, err)
+		}
+	})
 }
 
 func TestCrypto_ComputeMAC(t *testing.T) {
@@ -252,6 +487,61 @@ func TestCrypto_ComputeMAC(t *testing.T) {
 	})
 }
 
+func TestCrypto_MACTagSize(t *testing.T) {
+	c := Crypto{}
+
+	tests := []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		size     int
+	}{
+		{
+			name:     "HMAC-SHA256 with 256 bits tag",
+			template: mac.HMACSHA256Tag256KeyTemplate(),
+			size:     5 + 32,
+		},
+		{
+			name:     "HMAC-SHA512 with 256 bits tag",
+			template: mac.HMACSHA512Tag256KeyTemplate(),
+			size:     5 + 32,
+		},
+		{
+			name:     "AES-CMAC with 128 bits tag",
+			template: mac.AESCMACTag128KeyTemplate(),
+			size:     5 + 16,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			size, err := c.MACTagSize(kh)
+			require.NoError(t, err)
+			require.Equal(t, tc.size, size)
+
+			macBytes, err := c.ComputeMAC([]byte(testMessage), kh)
+			require.NoError(t, err)
+			require.Len(t, macBytes, size)
+		})
+	}
+
+	t.Run("invalid key handle", func(t *testing.T) {
+		_, err := c.MACTagSize(nil)
+		require.Equal(t, errBadKeyHandleFormat, err)
+	})
+
+	t.Run("fail - wrong key type", func(t *testing.T) {
+		kh, err := keyset.NewHandle(signature.ED25519KeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.MACTagSize(kh)
+		require.Contains(t, err.Error(), "macTagSize: primary primitive is not a MAC")
+	})
+}
+
 func TestCrypto_VerifyMAC(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		kh, err := keyset.NewHandle(mac.HMACSHA256Tag256KeyTemplate())
@@ -668,6 +958,161 @@ func TestCrypto_ECDH1PU_Wrap_Unwrap_Key_Using_CryptoPubKey_as_SenderKey(t *testi
 	require.EqualValues(t, cek, uCEK)
 }
 
+func TestCrypto_WrapKeyMulti(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	cek := random.GetRandomBytes(uint32(crypto.DefKeySize * 2))
+	apu := random.GetRandomBytes(uint32(10)) // or sender name
+	apv := random.GetRandomBytes(uint32(10)) // or recipient name
+
+	t.Run("test WrapKeyMulti with empty recipients", func(t *testing.T) {
+		_, err = c.WrapKeyMulti(cek, apu, apv, nil)
+		require.EqualError(t, err, "wrapKeyMulti: recipients public keys are required")
+
+		_, err = c.WrapKeyMulti(cek, apu, apv, []*crypto.PublicKey{nil})
+		require.EqualError(t, err, "wrapKeyMulti: recipient public key #0 is empty")
+	})
+
+	t.Run("test ECDH-1PU WrapKeyMulti shares EPK among recipients", func(t *testing.T) {
+		senderKH, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		senderPubKH, err := senderKH.Public()
+		require.NoError(t, err)
+
+		var (
+			recKHs  []*keyset.Handle
+			recKeys []*crypto.PublicKey
+		)
+
+		for i := 0; i < 3; i++ {
+			recKH, e := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+			require.NoError(t, e)
+
+			recKey, e := keyio.ExtractPrimaryPublicKey(recKH)
+			require.NoError(t, e)
+
+			recKHs = append(recKHs, recKH)
+			recKeys = append(recKeys, recKey)
+		}
+
+		wrappedKeys, err := c.WrapKeyMulti(cek, apu, apv, recKeys, crypto.WithSender(senderKH))
+		require.NoError(t, err)
+		require.Len(t, wrappedKeys, len(recKeys))
+
+		for i, wk := range wrappedKeys {
+			require.Equal(t, ECDH1PUA256KWAlg, wk.Alg)
+			require.Equal(t, recKeys[i].KID, wk.KID)
+			require.EqualValues(t, apu, wk.APU)
+			require.EqualValues(t, wrappedKeys[0].EPK, wk.EPK)
+
+			uCEK, e := c.UnwrapKey(wk, recKHs[i], crypto.WithSender(senderPubKH))
+			require.NoError(t, e)
+			require.EqualValues(t, cek, uCEK)
+		}
+	})
+
+	t.Run("test ECDH-ES WrapKeyMulti with mixed NIST P and X25519 recipients", func(t *testing.T) {
+		var (
+			recKHs  []*keyset.Handle
+			recKeys []*crypto.PublicKey
+		)
+
+		for _, tmpl := range []*tinkpb.KeyTemplate{
+			ecdh.NISTP256ECDHKWKeyTemplate(),
+			ecdh.X25519ECDHKWKeyTemplate(),
+			ecdh.NISTP384ECDHKWKeyTemplate(),
+		} {
+			recKH, e := keyset.NewHandle(tmpl)
+			require.NoError(t, e)
+
+			recKey, e := keyio.ExtractPrimaryPublicKey(recKH)
+			require.NoError(t, e)
+
+			recKHs = append(recKHs, recKH)
+			recKeys = append(recKeys, recKey)
+		}
+
+		wrappedKeys, err := c.WrapKeyMulti(cek, apu, apv, recKeys)
+		require.NoError(t, err)
+		require.Len(t, wrappedKeys, len(recKeys))
+
+		for i, wk := range wrappedKeys {
+			require.Equal(t, ECDHESA256KWAlg, wk.Alg)
+
+			uCEK, e := c.UnwrapKey(wk, recKHs[i])
+			require.NoError(t, e)
+			require.EqualValues(t, cek, uCEK)
+		}
+	})
+}
+
+func TestCrypto_WrapKey_WithKDFTrace(t *testing.T) {
+	recipientKeyHandle, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+	require.NoError(t, err)
+
+	recipientKey, err := keyio.ExtractPrimaryPublicKey(recipientKeyHandle)
+	require.NoError(t, err)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	apu := []byte("Alice")
+	apv := []byte("Bob")
+	keyBits := []byte{0, 0, 1, 0} // 256 bits KEK.
+
+	t.Run("ECDH-ES without KDF trace", func(t *testing.T) {
+		wrappedKey, err := c.WrapKey(random.GetRandomBytes(uint32(crypto.DefKeySize)), apu, apv, recipientKey)
+		require.NoError(t, err)
+		require.Empty(t, wrappedKey.KDFInfo)
+	})
+
+	t.Run("ECDH-ES with KDF trace", func(t *testing.T) {
+		cek := random.GetRandomBytes(uint32(crypto.DefKeySize))
+
+		wrappedKey, err := c.WrapKey(cek, apu, apv, recipientKey, crypto.WithKDFTrace())
+		require.NoError(t, err)
+
+		expected := append([]byte{0, 0, 0, 14}, []byte(ECDHESA256KWAlg)...)
+		expected = append(expected, 0, 0, 0, 5)
+		expected = append(expected, apu...)
+		expected = append(expected, 0, 0, 0, 3)
+		expected = append(expected, apv...)
+		expected = append(expected, keyBits...)
+
+		require.Equal(t, expected, wrappedKey.KDFInfo)
+
+		uCEK, err := c.UnwrapKey(wrappedKey, recipientKeyHandle)
+		require.NoError(t, err)
+		require.EqualValues(t, cek, uCEK)
+	})
+
+	t.Run("ECDH-1PU with KDF trace", func(t *testing.T) {
+		senderKH, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		cek := random.GetRandomBytes(uint32(crypto.DefKeySize * 2))
+		tag := []byte("tag")
+
+		wrappedKeys, err := c.WrapKeyMulti(cek, apu, apv, []*crypto.PublicKey{recipientKey},
+			crypto.WithSender(senderKH), crypto.WithTag(tag), crypto.WithKDFTrace())
+		require.NoError(t, err)
+		require.Len(t, wrappedKeys, 1)
+
+		expected := append([]byte{0, 0, 0, 15}, []byte(ECDH1PUA256KWAlg)...)
+		expected = append(expected, 0, 0, 0, 5)
+		expected = append(expected, apu...)
+		expected = append(expected, 0, 0, 0, 3)
+		expected = append(expected, apv...)
+		expected = append(expected, keyBits...)
+		expected = append(expected, 0, 0, 0, 3)
+		expected = append(expected, tag...)
+
+		require.Equal(t, expected, wrappedKeys[0].KDFInfo)
+	})
+}
+
 func TestBBSCrypto_SignVerify_DeriveProofVerifyProof(t *testing.T) {
 	c := Crypto{}
 	msg := [][]byte{
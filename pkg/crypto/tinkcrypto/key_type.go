@@ -0,0 +1,150 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	nistPECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPublicKey"
+	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
+)
+
+// KeyType returns the kms.KeyType of the primary key in kh. kh can either be a private or a public ECDH key
+// wrapping key handle (ie NISTPxxxECDHKW or X25519ECDHKW). This is useful to route WrapKey/UnwrapKey calls without
+// the need to deserialize the keyset.
+// returns:
+// 		the key type of the primary key in kh (eg kms.NISTP256ECDHKWType or kms.X25519ECDHKWType)
+//		error in case kh is not a valid ECDH key wrapping handle
+func (t *Crypto) KeyType(kh interface{}) (kms.KeyType, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return "", errBadKeyHandleFormat
+	}
+
+	typeURL, err := primaryKeyTypeURL(keyHandle)
+	if err != nil {
+		return "", fmt.Errorf("keyType: %w", err)
+	}
+
+	switch typeURL {
+	case x25519ECDHKWPrivateKeyTypeURL, x25519ECDHKWPublicKeyTypeURL:
+		return kms.X25519ECDHKWType, nil
+	case nistPECDHKWPrivateKeyTypeURL, nistPECDHKWPublicKeyTypeURL:
+		curve, e := nistPKWCurve(keyHandle, typeURL == nistPECDHKWPrivateKeyTypeURL)
+		if e != nil {
+			return "", fmt.Errorf("keyType: %w", e)
+		}
+
+		switch curve {
+		case commonpb.EllipticCurveType_NIST_P256:
+			return kms.NISTP256ECDHKWType, nil
+		case commonpb.EllipticCurveType_NIST_P384:
+			return kms.NISTP384ECDHKWType, nil
+		case commonpb.EllipticCurveType_NIST_P521:
+			return kms.NISTP521ECDHKWType, nil
+		}
+
+		return "", fmt.Errorf("keyType: unsupported curve '%s'", curve)
+	}
+
+	return "", fmt.Errorf("keyType: unsupported key type '%s'", typeURL)
+}
+
+func primaryKeyTypeURL(kh *keyset.Handle) (string, error) {
+	ksInfo := kh.KeysetInfo()
+
+	for _, ki := range ksInfo.KeyInfo {
+		if ki.KeyId == ksInfo.PrimaryKeyId {
+			return ki.TypeUrl, nil
+		}
+	}
+
+	return "", errors.New("primary key not found")
+}
+
+// nistPKWCurve reads the curve type of the primary key in kh. It writes kh using a noopAEAD for private keys or
+// without secrets for public keys in order to read the key's parameters.
+func nistPKWCurve(kh *keyset.Handle, isPrivate bool) (commonpb.EllipticCurveType, error) {
+	buf := new(bytes.Buffer)
+
+	var err error
+
+	if isPrivate {
+		err = kh.Write(&privKeyWriter{w: buf}, &noopAEAD{})
+	} else {
+		err = kh.WriteWithNoSecrets(&pubKeyWriter{w: buf})
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to read keyset: %w", err)
+	}
+
+	ks := new(tinkpb.Keyset)
+
+	err = proto.Unmarshal(buf.Bytes(), ks)
+	if err != nil {
+		return 0, errors.New("invalid keyset")
+	}
+
+	for _, key := range ks.Key {
+		if key.KeyId != ks.PrimaryKeyId {
+			continue
+		}
+
+		pubKey := new(ecdhpb.EcdhAeadPublicKey)
+
+		if isPrivate {
+			privKey := new(ecdhpb.EcdhAeadPrivateKey)
+
+			err = proto.Unmarshal(key.KeyData.Value, privKey)
+			pubKey = privKey.PublicKey
+		} else {
+			err = proto.Unmarshal(key.KeyData.Value, pubKey)
+		}
+
+		if err != nil || pubKey.GetParams().GetKwParams() == nil {
+			return 0, errors.New("invalid key in keyset")
+		}
+
+		return pubKey.Params.KwParams.CurveType, nil
+	}
+
+	return 0, errors.New("primary key not found")
+}
+
+type pubKeyWriter struct {
+	w *bytes.Buffer
+}
+
+// Write writes the public keyset to the underlying w.Writer.
+func (p *pubKeyWriter) Write(ks *tinkpb.Keyset) error {
+	b, err := proto.Marshal(ks)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.w.Write(b)
+
+	return err
+}
+
+// WriteEncrypted writes the encrypted keyset to the underlying w.Writer. It's not used in this implementation.
+func (p *pubKeyWriter) WriteEncrypted(_ *tinkpb.EncryptedKeyset) error {
+	return fmt.Errorf("pubKeyWriter: write encrypted function not supported")
+}
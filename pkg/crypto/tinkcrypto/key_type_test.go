@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCrypto_KeyType(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *tinkpb.KeyTemplate
+		keyType  kms.KeyType
+	}{
+		{
+			name:     "NIST P-256 ECDH KW key",
+			template: ecdh.NISTP256ECDHKWKeyTemplate(),
+			keyType:  kms.NISTP256ECDHKWType,
+		},
+		{
+			name:     "NIST P-384 ECDH KW key",
+			template: ecdh.NISTP384ECDHKWKeyTemplate(),
+			keyType:  kms.NISTP384ECDHKWType,
+		},
+		{
+			name:     "NIST P-521 ECDH KW key",
+			template: ecdh.NISTP521ECDHKWKeyTemplate(),
+			keyType:  kms.NISTP521ECDHKWType,
+		},
+		{
+			name:     "X25519 ECDH KW key",
+			template: ecdh.X25519ECDHKWKeyTemplate(),
+			keyType:  kms.X25519ECDHKWType,
+		},
+	}
+
+	c, err := New()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			kh, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			kt, err := c.KeyType(kh)
+			require.NoError(t, err)
+			require.Equal(t, tc.keyType, kt)
+
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			kt, err = c.KeyType(pubKH)
+			require.NoError(t, err)
+			require.Equal(t, tc.keyType, kt)
+		})
+	}
+
+	t.Run("bad key handle format", func(t *testing.T) {
+		_, err = c.KeyType("not a key handle")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.KeyType(kh)
+		require.EqualError(t, err, "keyType: unsupported key type "+
+			"'type.googleapis.com/google.crypto.tink.AesGcmKey'")
+	})
+}
@@ -91,6 +91,20 @@ func (t *Crypto) wrapRaw(kek, cek, apu, apv []byte, alg, kid string, epk *crypto
 	}, nil
 }
 
+// kdfTrace returns the serialized Concat KDF input (AlgorithmID || PartyUInfo || PartyVInfo || SuppPubInfo) that was
+// used to derive the KEK of wk. tag is only part of the input for ECDH-1PU (useTag set to true).
+func kdfTrace(wk *cryptoapi.RecipientWrappedKey, tag []byte, useTag bool) []byte {
+	algID, ptyUInfo, ptyVInfo, supPubInfo, _ := kdfOtherInfo(wk.Alg, wk.APU, wk.APV, tag, defKeySize, useTag)
+
+	info := make([]byte, 0, len(algID)+len(ptyUInfo)+len(ptyVInfo)+len(supPubInfo))
+	info = append(info, algID...)
+	info = append(info, ptyUInfo...)
+	info = append(info, ptyVInfo...)
+	info = append(info, supPubInfo...)
+
+	return info
+}
+
 // deriveKEKAndUnwrap is the entry point for Crypto.UnwrapKey().
 func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv, tag []byte, epk *cryptoapi.PublicKey, senderKH,
 	recKH interface{}) ([]byte, error) {
@@ -614,6 +628,57 @@ func (t *Crypto) generateOrGetEphemeralOKPKey(epkPrv *cryptoapi.PrivateKey) ([]b
 	return epkPrv.PublicKey.X, epkPrv.D, nil
 }
 
+// generateEPK creates a new ephemeral private key matching the type (and curve for EC keys) of recPubKey.
+func (t *Crypto) generateEPK(recPubKey *cryptoapi.PublicKey) (*cryptoapi.PrivateKey, error) {
+	switch recPubKey.Type {
+	case ecdhpb.KeyType_EC.String():
+		_, ephemeralPrivKey, err := t.convertRecKeyAndGenOrGetEPKEC(recPubKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("generateEPK: %w", err)
+		}
+
+		return &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     ephemeralPrivKey.PublicKey.X.Bytes(),
+				Y:     ephemeralPrivKey.PublicKey.Y.Bytes(),
+				Curve: ephemeralPrivKey.PublicKey.Curve.Params().Name,
+				Type:  recPubKey.Type,
+			},
+			D: ephemeralPrivKey.D.Bytes(),
+		}, nil
+	case ecdhpb.KeyType_OKP.String():
+		ephemeralPubKey, ephemeralPrivKey, err := t.generateOrGetEphemeralOKPKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("generateEPK: failed to generate ephemeral key: %w", err)
+		}
+
+		return &cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     ephemeralPubKey,
+				Curve: "X25519",
+				Type:  recPubKey.Type,
+			},
+			D: ephemeralPrivKey,
+		}, nil
+	default:
+		return nil, errors.New("generateEPK: invalid recipient key type")
+	}
+}
+
+// epkGroup returns the key used to share an ephemeral key among recipients of the same key type and curve.
+func epkGroup(pubKey *cryptoapi.PublicKey) string {
+	if pubKey.Type == ecdhpb.KeyType_OKP.String() {
+		return pubKey.Type
+	}
+
+	c, err := hybrid.GetCurve(pubKey.Curve)
+	if err != nil {
+		return pubKey.Type + ":" + pubKey.Curve
+	}
+
+	return pubKey.Type + ":" + c.Params().Name
+}
+
 func ksToPrivateECDSAKey(ks interface{}) (*ecdsa.PrivateKey, error) {
 	senderKH, ok := ks.(*keyset.Handle)
 	if !ok {
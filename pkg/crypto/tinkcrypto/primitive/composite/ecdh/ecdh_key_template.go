@@ -33,6 +33,8 @@ const (
 	AES256CBCHMACSHA384
 	// AES256CBCHMACSHA512 AEAD.
 	AES256CBCHMACSHA512
+	// AES128GCM AEAD.
+	AES128GCM
 )
 
 // EncryptionAlgLabel maps AEADAlg to its label.
@@ -43,6 +45,7 @@ var EncryptionAlgLabel = map[AEADAlg]string{ //nolint:gochecknoglobals
 	AES192CBCHMACSHA384: "AES192CBCHMACSHA384",
 	AES256CBCHMACSHA384: "AES256CBCHMACSHA384",
 	AES256CBCHMACSHA512: "AES256CBCHMACSHA512",
+	AES128GCM:           "AES128GCM",
 }
 
 // NISTP256ECDHKWKeyTemplate is a KeyTemplate that generates a key that accepts a CEK for JWE content
@@ -99,10 +102,11 @@ func X25519ECDHKWKeyTemplate() *tinkpb.KeyTemplate {
 // Keys from this template offer valid CompositeEncrypt primitive execution only and should not be stored in the KMS.
 // The key created from this template has no recipient key info linked to it. It is exclusively used for primitive
 // execution using content encryption. Available content encryption algorithms:
-//  - AES256GCM, XChacaha20Poly1305, AES128CBC+HMAC256, AES192CBC+HMAC384, AES256CBC+HMAC384, AES256CBC+HMAC512
+//  - AES128GCM, AES256GCM, XChacaha20Poly1305, AES128CBC+HMAC256, AES192CBC+HMAC384, AES256CBC+HMAC384, AES256CBC+HMAC512
 // It works with both key wrapping modes (executed outside of the key primitive created by this template):
 // NIST P kw or XC20P kw
 // cek should be of size:
+// - 16 bytes for AES128GCM.
 // - 32 bytes for AES256GCM, XChacaha20Poly1305, AES128CBC+HMAC256.
 // - 48 bytes for AES192CBC+HMAC384.
 // - 56 bytes for AES256CBC+HMAC384.
@@ -153,6 +157,8 @@ func getTypeParams(nistpKW bool, encAlg AEADAlg, cek []byte) (string, ecdhpb.Key
 	)
 
 	switch encAlg {
+	case AES128GCM:
+		keyTemplate = aead.AES128GCMKeyTemplate()
 	case AES256GCM:
 		keyTemplate = aead.AES256GCMKeyTemplate()
 	case AES128CBCHMACSHA256, AES192CBCHMACSHA384, AES256CBCHMACSHA384, AES256CBCHMACSHA512:
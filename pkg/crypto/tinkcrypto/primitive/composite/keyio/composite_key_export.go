@@ -399,6 +399,8 @@ func keyTemplateAndURL(cp commonpb.EllipticCurveType, aeadAlg ecdh.AEADAlg,
 
 	// set aeadAlg encryption primitive template.
 	switch aeadAlg {
+	case ecdh.AES128GCM:
+		encT = tinkaead.AES128GCMKeyTemplate()
 	case ecdh.AES256GCM:
 		encT = tinkaead.AES256GCMKeyTemplate()
 	case ecdh.XC20P:
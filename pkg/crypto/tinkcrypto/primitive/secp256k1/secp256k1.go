@@ -0,0 +1,79 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package secp256k1 provides implementations of ECDSA signatures on the secp256k1 curve (used by Bitcoin and
+// Ethereum) as Tink signature primitives. Tink does not support this curve natively.
+//
+// Signatures are deterministic (RFC6979) with a low S value and can be encoded either as DER or IEEE_P1363 (r||s).
+// DER is commonly used by blockchains while IEEE_P1363 is required by JOSE (ES256K) and EcdsaSecp256k1Signature2019
+// proofs.
+//
+// Example:
+//
+//  package main
+//
+//  import (
+//      "github.com/google/tink/go/keyset"
+//      "github.com/google/tink/go/signature"
+//
+//      "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
+//  )
+//
+//  func main() {
+//      kh, err := keyset.NewHandle(secp256k1.IEEEP1363KeyTemplate())
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      s, err := signature.NewSigner(kh)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      msg := []byte("this data needs to be signed")
+//
+//      sig, err := s.Sign(msg)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      pubKH, err := kh.Public()
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      v, err := signature.NewVerifier(pubKH)
+//      if err != nil {
+//          // handle error
+//      }
+//
+//      err = v.Verify(sig, msg)
+//      if err != nil {
+//          // handle error
+//      }
+//  }
+package secp256k1
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// TODO - find a better way to setup tink than init.
+// nolint: gochecknoinits
+func init() {
+	// TODO - avoid the tink registry singleton.
+	err := registry.RegisterKeyManager(newSecp256K1SignerKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+
+	err = registry.RegisterKeyManager(newSecp256K1VerifierKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("secp256k1.init() failed: %v", err))
+	}
+}
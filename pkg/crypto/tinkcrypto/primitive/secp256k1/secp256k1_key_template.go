@@ -0,0 +1,59 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+)
+
+// DERKeyTemplate is a KeyTemplate that generates a new secp256k1 ECDSA private key with the following parameters:
+//   - Hash function: SHA256
+//   - Curve: secp256k1
+//   - Signature encoding: DER
+//   - Output prefix type: RAW
+func DERKeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(commonpb.HashType_SHA256, secp256k1pb.BitcoinCurveType_SECP256K1,
+		secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER)
+}
+
+// IEEEP1363KeyTemplate is a KeyTemplate that generates a new secp256k1 ECDSA private key with the following
+// parameters:
+//   - Hash function: SHA256
+//   - Curve: secp256k1
+//   - Signature encoding: IEEE_P1363 (r||s), as required by JOSE (ES256K)
+//   - Output prefix type: RAW
+func IEEEP1363KeyTemplate() *tinkpb.KeyTemplate {
+	return createKeyTemplate(commonpb.HashType_SHA256, secp256k1pb.BitcoinCurveType_SECP256K1,
+		secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_IEEE_P1363)
+}
+
+// createKeyTemplate creates a KeyTemplate containing a Secp256k1KeyFormat with the given parameters.
+func createKeyTemplate(hashType commonpb.HashType, curve secp256k1pb.BitcoinCurveType,
+	encoding secp256k1pb.Secp256K1SignatureEncoding) *tinkpb.KeyTemplate {
+	format := &secp256k1pb.Secp256K1KeyFormat{
+		Params: &secp256k1pb.Secp256K1Params{
+			HashType: hashType,
+			Curve:    curve,
+			Encoding: encoding,
+		},
+	}
+
+	serializedFormat, err := proto.Marshal(format)
+	if err != nil {
+		panic("failed to marshal Secp256k1KeyFormat proto")
+	}
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          secp256k1SignerTypeURL,
+		Value:            serializedFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}
+}
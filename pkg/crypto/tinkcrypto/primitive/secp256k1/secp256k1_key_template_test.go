@@ -0,0 +1,60 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecp256k1KeyTemplates(t *testing.T) {
+	flagTests := []struct {
+		tcName   string
+		template *tinkpb.KeyTemplate
+	}{
+		{
+			tcName:   "create secp256k1 DER key",
+			template: DERKeyTemplate(),
+		},
+		{
+			tcName:   "create secp256k1 IEEE_P1363 key",
+			template: IEEEP1363KeyTemplate(),
+		},
+	}
+
+	for _, tt := range flagTests {
+		tc := tt
+		t.Run("Test "+tc.tcName, func(t *testing.T) {
+			kh, err := keyset.NewHandle(tc.template)
+			require.NoError(t, err)
+
+			signer, err := signature.NewSigner(kh)
+			require.NoError(t, err)
+
+			msg := []byte("hello world")
+
+			sig, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			verifier, err := signature.NewVerifier(pubKH)
+			require.NoError(t, err)
+
+			err = verifier.Verify(sig, msg)
+			require.NoError(t, err)
+
+			err = verifier.Verify(sig, []byte("bad message"))
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	secp256k1SignerKeyVersion = 0
+	secp256k1SignerTypeURL    = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
+)
+
+// common errors.
+var (
+	errInvalidSecp256K1SignKey       = errors.New("secp256k1_signer_key_manager: invalid key")
+	errInvalidSecp256K1SignKeyFormat = errors.New("secp256k1_signer_key_manager: invalid key format")
+)
+
+// secp256k1SignerKeyManager is an implementation of KeyManager interface for secp256k1 ECDSA signatures.
+// It generates new Secp256k1PrivateKeys and produces new instances of Secp256k1Signer subtle.
+type secp256k1SignerKeyManager struct{}
+
+// newSecp256K1SignerKeyManager creates a new secp256k1SignerKeyManager.
+func newSecp256K1SignerKeyManager() *secp256k1SignerKeyManager {
+	return new(secp256k1SignerKeyManager)
+}
+
+// Primitive creates a Secp256k1Signer subtle for the given serialized Secp256k1PrivateKey proto.
+func (km *secp256k1SignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSecp256K1SignKey
+	}
+
+	key := new(secp256k1pb.Secp256K1PrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidSecp256K1SignKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	params := key.PublicKey.Params
+
+	ret, err := subtle.NewSecp256K1Signer(params.HashType.String(), params.Curve.String(), params.Encoding.String(),
+		key.KeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: %w", err)
+	}
+
+	return ret, nil
+}
+
+// NewKey creates a new Secp256k1PrivateKey according to specification the given serialized Secp256k1KeyFormat.
+func (km *secp256k1SignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidSecp256K1SignKeyFormat
+	}
+
+	keyFormat := new(secp256k1pb.Secp256K1KeyFormat)
+
+	err := proto.Unmarshal(serializedKeyFormat, keyFormat)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256K1SignKeyFormat.Error()+": invalid proto: %w", err)
+	}
+
+	err = validateKeyFormat(keyFormat.Params)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidSecp256K1SignKeyFormat.Error()+": %w", err)
+	}
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer_key_manager: cannot generate secp256k1 key: %w", err)
+	}
+
+	return &secp256k1pb.Secp256K1PrivateKey{
+		Version: secp256k1SignerKeyVersion,
+		PublicKey: &secp256k1pb.Secp256K1PublicKey{
+			Version: secp256k1SignerKeyVersion,
+			Params:  keyFormat.Params,
+			X:       privKey.PublicKey.X.Bytes(),
+			Y:       privKey.PublicKey.Y.Bytes(),
+		},
+		KeyValue: privKey.D.Bytes(),
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given serialized Secp256k1KeyFormat.
+// It should be used solely by the key management API.
+func (km *secp256k1SignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidSecp256K1SignKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         secp256k1SignerTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData extracts the public key data from the private key.
+func (km *secp256k1SignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(secp256k1pb.Secp256K1PrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidSecp256K1SignKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidSecp256K1SignKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         secp256k1VerifierTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *secp256k1SignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == secp256k1SignerTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *secp256k1SignerKeyManager) TypeURL() string {
+	return secp256k1SignerTypeURL
+}
+
+// validateKey validates the given Secp256k1PrivateKey.
+func (km *secp256k1SignerKeyManager) validateKey(key *secp256k1pb.Secp256K1PrivateKey) error {
+	if err := keyset.ValidateKeyVersion(key.Version, secp256k1SignerKeyVersion); err != nil {
+		return fmt.Errorf("secp256k1_signer_key_manager: invalid key: %w", err)
+	}
+
+	if key.PublicKey == nil {
+		return errors.New("secp256k1_signer_key_manager: invalid key: missing public key")
+	}
+
+	if err := validateKeyFormat(key.PublicKey.Params); err != nil {
+		return fmt.Errorf("secp256k1_signer_key_manager: invalid key: %w", err)
+	}
+
+	return nil
+}
+
+// validateKeyFormat validates the given Secp256k1Params.
+func validateKeyFormat(params *secp256k1pb.Secp256K1Params) error {
+	if params == nil {
+		return errors.New("missing params")
+	}
+
+	return subtle.ValidateParams(params.HashType.String(), params.Curve.String(), params.Encoding.String())
+}
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+)
+
+func TestSecp256K1SignerKeyManager_Primitive(t *testing.T) {
+	km := newSecp256K1SignerKeyManager()
+
+	t.Run("Test signer key manager Primitive() with empty serialized key", func(t *testing.T) {
+		p, err := km.Primitive([]byte(""))
+		require.EqualError(t, err, errInvalidSecp256K1SignKey.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager Primitive() with bad serialized key", func(t *testing.T) {
+		p, err := km.Primitive([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256K1SignKey.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager Primitive() success", func(t *testing.T) {
+		key, err := km.NewKey(serializedKeyFormat(t, secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER))
+		require.NoError(t, err)
+
+		sKey, err := proto.Marshal(key)
+		require.NoError(t, err)
+
+		p, err := km.Primitive(sKey)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Test signer key manager Primitive() with bad key version", func(t *testing.T) {
+		key, err := km.NewKey(serializedKeyFormat(t, secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER))
+		require.NoError(t, err)
+
+		privKey, ok := key.(*secp256k1pb.Secp256K1PrivateKey)
+		require.True(t, ok)
+
+		privKey.Version = 9999
+
+		sKey, err := proto.Marshal(privKey)
+		require.NoError(t, err)
+
+		p, err := km.Primitive(sKey)
+		require.Contains(t, err.Error(), "secp256k1_signer_key_manager: invalid key")
+		require.Empty(t, p)
+	})
+}
+
+func TestSecp256K1SignerKeyManager_NewKey(t *testing.T) {
+	km := newSecp256K1SignerKeyManager()
+
+	t.Run("Test signer key manager NewKey() with empty key format", func(t *testing.T) {
+		p, err := km.NewKey(nil)
+		require.EqualError(t, err, errInvalidSecp256K1SignKeyFormat.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager NewKey() with bad key format", func(t *testing.T) {
+		p, err := km.NewKey([]byte("bad.data"))
+		require.Contains(t, err.Error(), errInvalidSecp256K1SignKeyFormat.Error())
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager NewKey() with unknown encoding", func(t *testing.T) {
+		p, err := km.NewKey(serializedKeyFormat(t, secp256k1pb.Secp256K1SignatureEncoding_UNKNOWN_BITCOIN_ENCODING))
+		require.EqualError(t, err, errInvalidSecp256K1SignKeyFormat.Error()+
+			": unsupported encoding: UNKNOWN_BITCOIN_ENCODING")
+		require.Empty(t, p)
+	})
+
+	t.Run("Test signer key manager NewKeyData() and PublicKeyData() success", func(t *testing.T) {
+		kd, err := km.NewKeyData(serializedKeyFormat(t, secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_IEEE_P1363))
+		require.NoError(t, err)
+		require.Equal(t, secp256k1SignerTypeURL, kd.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PRIVATE, kd.KeyMaterialType)
+
+		pubKD, err := km.PublicKeyData(kd.Value)
+		require.NoError(t, err)
+		require.Equal(t, secp256k1VerifierTypeURL, pubKD.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PUBLIC, pubKD.KeyMaterialType)
+
+		vkm := newSecp256K1VerifierKeyManager()
+
+		p, err := vkm.Primitive(pubKD.Value)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+	})
+
+	t.Run("Test signer key manager PublicKeyData() with bad key", func(t *testing.T) {
+		p, err := km.PublicKeyData([]byte("bad.data"))
+		require.EqualError(t, err, errInvalidSecp256K1SignKey.Error())
+		require.Empty(t, p)
+	})
+
+	require.True(t, km.DoesSupport(secp256k1SignerTypeURL))
+	require.Equal(t, secp256k1SignerTypeURL, km.TypeURL())
+}
+
+func TestSecp256K1VerifierKeyManager(t *testing.T) {
+	km := newSecp256K1VerifierKeyManager()
+
+	p, err := km.Primitive([]byte(""))
+	require.EqualError(t, err, errInvalidSecp256K1VerifierKey.Error())
+	require.Empty(t, p)
+
+	p, err = km.Primitive([]byte("bad.data"))
+	require.EqualError(t, err, errInvalidSecp256K1VerifierKey.Error())
+	require.Empty(t, p)
+
+	_, err = km.NewKey(nil)
+	require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKey not implemented")
+
+	_, err = km.NewKeyData(nil)
+	require.EqualError(t, err, "secp256k1_verifier_key_manager: NewKeyData not implemented")
+
+	require.True(t, km.DoesSupport(secp256k1VerifierTypeURL))
+	require.Equal(t, secp256k1VerifierTypeURL, km.TypeURL())
+}
+
+func serializedKeyFormat(t *testing.T, encoding secp256k1pb.Secp256K1SignatureEncoding) []byte {
+	t.Helper()
+
+	keyFormat := &secp256k1pb.Secp256K1KeyFormat{
+		Params: &secp256k1pb.Secp256K1Params{
+			HashType: commonpb.HashType_SHA256,
+			Curve:    secp256k1pb.BitcoinCurveType_SECP256K1,
+			Encoding: encoding,
+		},
+	}
+
+	b, err := proto.Marshal(keyFormat)
+	require.NoError(t, err)
+
+	return b
+}
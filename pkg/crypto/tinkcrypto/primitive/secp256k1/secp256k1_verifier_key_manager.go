@@ -0,0 +1,93 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+const (
+	secp256k1VerifierKeyVersion = 0
+	secp256k1VerifierTypeURL    = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
+)
+
+// common errors.
+var errInvalidSecp256K1VerifierKey = errors.New("secp256k1_verifier_key_manager: invalid key")
+
+// secp256k1VerifierKeyManager is an implementation of KeyManager interface for secp256k1 ECDSA signature
+// verification. It doesn't support key generation.
+type secp256k1VerifierKeyManager struct{}
+
+// newSecp256K1VerifierKeyManager creates a new secp256k1VerifierKeyManager.
+func newSecp256K1VerifierKeyManager() *secp256k1VerifierKeyManager {
+	return new(secp256k1VerifierKeyManager)
+}
+
+// Primitive creates a Secp256k1Verifier subtle for the given serialized Secp256k1PublicKey proto.
+func (km *secp256k1VerifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidSecp256K1VerifierKey
+	}
+
+	key := new(secp256k1pb.Secp256K1PublicKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidSecp256K1VerifierKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_verifier_key_manager: %w", err)
+	}
+
+	ret, err := subtle.NewSecp256K1Verifier(key.Params.HashType.String(), key.Params.Curve.String(),
+		key.Params.Encoding.String(), key.X, key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_verifier_key_manager: invalid key: %w", err)
+	}
+
+	return ret, nil
+}
+
+// NewKey is not implemented for public key manager.
+func (km *secp256k1VerifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *secp256k1VerifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("secp256k1_verifier_key_manager: NewKeyData not implemented")
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *secp256k1VerifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == secp256k1VerifierTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *secp256k1VerifierKeyManager) TypeURL() string {
+	return secp256k1VerifierTypeURL
+}
+
+// validateKey validates the given Secp256k1PublicKey.
+func (km *secp256k1VerifierKeyManager) validateKey(key *secp256k1pb.Secp256K1PublicKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, secp256k1VerifierKeyVersion)
+	if err != nil {
+		return fmt.Errorf("invalid key version: %w", err)
+	}
+
+	return validateKeyFormat(key.Params)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package subtle provides subtle implementations of the secp256k1 ECDSA signature primitives.
+package subtle
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/google/tink/go/subtle"
+)
+
+const (
+	// DER signature encoding.
+	DER = "Bitcoin_DER"
+	// IEEEP1363 signature encoding (r||s).
+	IEEEP1363 = "Bitcoin_IEEE_P1363"
+
+	secp256k1Curve = "SECP256K1"
+	secp256k1Size  = 32
+)
+
+// ValidateParams validates the hash function, curve and encoding of secp256k1 ECDSA keys. SHA256 is the only
+// supported hash function.
+func ValidateParams(hashAlg, curve, encoding string) error {
+	if curve != secp256k1Curve {
+		return fmt.Errorf("unsupported curve: %s", curve)
+	}
+
+	if hashAlg != "SHA256" {
+		return fmt.Errorf("unsupported hash function: %s", hashAlg)
+	}
+
+	switch encoding {
+	case DER, IEEEP1363:
+	default:
+		return fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+
+	return nil
+}
+
+// encodeSignature encodes (r, s) using encoding. s is normalized to its low value (s <= N/2) as required by
+// Bitcoin and Ethereum.
+func encodeSignature(r, s *big.Int, encoding string) ([]byte, error) {
+	halfOrder := new(big.Int).Rsh(btcec.S256().N, 1)
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(btcec.S256().N, s)
+	}
+
+	switch encoding {
+	case DER:
+		sig := &btcec.Signature{R: r, S: s}
+
+		return sig.Serialize(), nil
+	case IEEEP1363:
+		sig := make([]byte, 2*secp256k1Size)
+
+		r.FillBytes(sig[:secp256k1Size])
+		s.FillBytes(sig[secp256k1Size:])
+
+		return sig, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
+
+// decodeSignature decodes sig into (r, s) using encoding.
+func decodeSignature(sig []byte, encoding string) (*big.Int, *big.Int, error) {
+	switch encoding {
+	case DER:
+		s, err := btcec.ParseDERSignature(sig, btcec.S256())
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid DER signature: %w", err)
+		}
+
+		return s.R, s.S, nil
+	case IEEEP1363:
+		if len(sig) != 2*secp256k1Size {
+			return nil, nil, errors.New("invalid IEEE_P1363 signature size")
+		}
+
+		return new(big.Int).SetBytes(sig[:secp256k1Size]), new(big.Int).SetBytes(sig[secp256k1Size:]), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
+
+func computeHash(hashFunc func() hash.Hash, data []byte) []byte {
+	h := hashFunc()
+	// hash.Hash.Write never returns an error.
+	_, _ = h.Write(data) // nolint:errcheck
+
+	return h.Sum(nil)
+}
+
+func hashFunc(hashAlg string) (func() hash.Hash, error) {
+	hFunc := subtle.GetHashFunc(hashAlg)
+	if hFunc == nil {
+		return nil, fmt.Errorf("unsupported hash function: %s", hashAlg)
+	}
+
+	return hFunc, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// Secp256k1Signer is an implementation of Tink's Signer interface for ECDSA signatures on the secp256k1 curve.
+// Signatures are deterministic as per RFC6979 and always have a low S value.
+type Secp256k1Signer struct {
+	privateKey *btcec.PrivateKey
+	hashFunc   func() hash.Hash
+	encoding   string
+}
+
+// NewSecp256K1Signer creates a new instance of Secp256k1Signer for the given private key value.
+func NewSecp256K1Signer(hashAlg, curve, encoding string, keyValue []byte) (*Secp256k1Signer, error) {
+	if err := ValidateParams(hashAlg, curve, encoding); err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: %w", err)
+	}
+
+	d := new(big.Int).SetBytes(keyValue)
+	if d.Sign() == 0 || d.Cmp(btcec.S256().N) >= 0 {
+		return nil, errors.New("secp256k1_signer: invalid private key")
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), keyValue)
+
+	hFunc, err := hashFunc(hashAlg)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: %w", err)
+	}
+
+	return &Secp256k1Signer{
+		privateKey: privKey,
+		hashFunc:   hFunc,
+		encoding:   encoding,
+	}, nil
+}
+
+// Sign computes a signature for the given data.
+func (s *Secp256k1Signer) Sign(data []byte) ([]byte, error) {
+	hashed := computeHash(s.hashFunc, data)
+
+	sig, err := s.privateKey.Sign(hashed)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: signing failed: %w", err)
+	}
+
+	ret, err := encodeSignature(sig.R, sig.S, s.encoding)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_signer: %w", err)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1/subtle"
+)
+
+func TestSecp256k1SignVerify(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+
+	for _, encoding := range []string{subtle.DER, subtle.IEEEP1363} {
+		signer, err := subtle.NewSecp256K1Signer("SHA256", "SECP256K1", encoding, privKey.D.Bytes())
+		require.NoError(t, err)
+
+		verifier, err := subtle.NewSecp256K1Verifier("SHA256", "SECP256K1", encoding,
+			privKey.PublicKey.X.Bytes(), privKey.PublicKey.Y.Bytes())
+		require.NoError(t, err)
+
+		sig, err := signer.Sign(msg)
+		require.NoError(t, err)
+
+		if encoding == subtle.IEEEP1363 {
+			require.Len(t, sig, 64)
+		}
+
+		require.NoError(t, verifier.Verify(sig, msg))
+		require.Error(t, verifier.Verify(sig, []byte("other message")))
+
+		sig[len(sig)-1] ^= 0x01
+		require.Error(t, verifier.Verify(sig, msg))
+	}
+}
+
+func TestSecp256k1KnownAnswer(t *testing.T) {
+	// private key 1 signing "Satoshi Nakamoto", RFC6979 deterministic nonce and low S.
+	keyValue := []byte{1}
+	expected := "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" +
+		"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+
+	signer, err := subtle.NewSecp256K1Signer("SHA256", "SECP256K1", subtle.IEEEP1363, keyValue)
+	require.NoError(t, err)
+
+	sig, err := signer.Sign([]byte("Satoshi Nakamoto"))
+	require.NoError(t, err)
+	require.Equal(t, expected, hex.EncodeToString(sig))
+
+	_, pubKey := btcec.PrivKeyFromBytes(btcec.S256(), keyValue)
+
+	verifier, err := subtle.NewSecp256K1Verifier("SHA256", "SECP256K1", subtle.IEEEP1363,
+		pubKey.X.Bytes(), pubKey.Y.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(sig, []byte("Satoshi Nakamoto")))
+}
+
+func TestSecp256k1InvalidParams(t *testing.T) {
+	_, err := subtle.NewSecp256K1Signer("SHA512", "SECP256K1", subtle.DER, []byte{1})
+	require.EqualError(t, err, "secp256k1_signer: unsupported hash function: SHA512")
+
+	_, err = subtle.NewSecp256K1Signer("SHA256", "NIST_P256", subtle.DER, []byte{1})
+	require.EqualError(t, err, "secp256k1_signer: unsupported curve: NIST_P256")
+
+	_, err = subtle.NewSecp256K1Signer("SHA256", "SECP256K1", "BAD", []byte{1})
+	require.EqualError(t, err, "secp256k1_signer: unsupported encoding: BAD")
+
+	_, err = subtle.NewSecp256K1Signer("SHA256", "SECP256K1", subtle.DER, []byte{})
+	require.EqualError(t, err, "secp256k1_signer: invalid private key")
+
+	_, err = subtle.NewSecp256K1Verifier("SHA256", "SECP256K1", subtle.DER, []byte{1}, []byte{2})
+	require.Error(t, err)
+}
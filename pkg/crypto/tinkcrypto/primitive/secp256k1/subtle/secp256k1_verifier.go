@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+var errInvalidSecp256K1Signature = errors.New("secp256k1_verifier: invalid signature")
+
+// Secp256k1Verifier is an implementation of Tink's Verifier interface for ECDSA signatures on the secp256k1 curve.
+type Secp256k1Verifier struct {
+	publicKey *btcec.PublicKey
+	hashFunc  func() hash.Hash
+	encoding  string
+}
+
+// NewSecp256K1Verifier creates a new instance of Secp256k1Verifier for the given public key coordinates.
+func NewSecp256K1Verifier(hashAlg, curve, encoding string, x, y []byte) (*Secp256k1Verifier, error) {
+	if err := ValidateParams(hashAlg, curve, encoding); err != nil {
+		return nil, fmt.Errorf("secp256k1_verifier: %w", err)
+	}
+
+	pubKey := &btcec.PublicKey{
+		Curve: btcec.S256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !pubKey.Curve.IsOnCurve(pubKey.X, pubKey.Y) {
+		return nil, errors.New("secp256k1_verifier: invalid public key: point not on curve")
+	}
+
+	hFunc, err := hashFunc(hashAlg)
+	if err != nil {
+		return nil, fmt.Errorf("secp256k1_verifier: %w", err)
+	}
+
+	return &Secp256k1Verifier{
+		publicKey: pubKey,
+		hashFunc:  hFunc,
+		encoding:  encoding,
+	}, nil
+}
+
+// Verify verifies whether the given signature is valid for the given data. It returns an error if the signature is
+// not valid; nil otherwise.
+func (v *Secp256k1Verifier) Verify(signature, data []byte) error {
+	r, s, err := decodeSignature(signature, v.encoding)
+	if err != nil {
+		return fmt.Errorf("secp256k1_verifier: %w", err)
+	}
+
+	hashed := computeHash(v.hashFunc, data)
+
+	sig := &btcec.Signature{R: r, S: s}
+	if !sig.Verify(hashed, v.publicKey) {
+		return errInvalidSecp256K1Signature
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/streamingaead"
+)
+
+// EncryptStream will encrypt src and aad using a matching streaming AEAD primitive in kh key handle and write the
+// resulting ciphertext to dst. Unlike Encrypt, the plaintext is never fully loaded in memory which makes it suitable
+// for large payloads (eg DIDComm attachments).
+//
+// The ciphertext is split into segments whose size is set by the streaming AEAD key in kh, eg
+// streamingaead.AES256GCMHKDF1MBKeyTemplate() for 1 MB segments (recommended for large payloads) or
+// streamingaead.AES256GCMHKDF4KBKeyTemplate() for 4 KB segments. Each segment carries its own authentication tag and
+// is bound to its position in the stream, so decryption recovers segment boundaries from the key alone.
+//
+// Similar to Encrypt, the stream header (header length byte || salt || nonce prefix) is not written to dst, it is
+// returned as the nonce instead. It must be passed as is to DecryptStream.
+// returns:
+// 		nonce to be used by DecryptStream
+//		error in case of errors
+func (t *Crypto) EncryptStream(dst io.Writer, src io.Reader, aad []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	s, err := streamingaead.New(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	hw := &headerWriter{w: dst}
+
+	w, err := s.NewEncryptingWriter(hw, aad)
+	if err != nil {
+		return nil, fmt.Errorf("create encrypting writer: %w", err)
+	}
+
+	_, err = io.Copy(w, src)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt stream: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, fmt.Errorf("encrypt stream: %w", err)
+	}
+
+	if !hw.done {
+		return nil, errors.New("encrypt stream: missing stream header")
+	}
+
+	return hw.header, nil
+}
+
+// DecryptStream will decrypt src with aad and given nonce using a matching streaming AEAD primitive in kh key handle
+// and write the resulting plaintext to dst. nonce is the stream header returned by EncryptStream.
+//
+// Segments are authenticated as they are read, dst may therefore have received part of the plaintext when an error
+// is returned. Callers must discard dst's content if DecryptStream fails.
+// returns:
+//		error in case of errors
+func (t *Crypto) DecryptStream(dst io.Writer, src io.Reader, aad, nonce []byte, kh interface{}) error {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return errBadKeyHandleFormat
+	}
+
+	s, err := streamingaead.New(keyHandle)
+	if err != nil {
+		return fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	// since Tink expects the stream header as the ciphertext prefix, prepend it prior to reading the stream.
+	r, err := s.NewDecryptingReader(io.MultiReader(bytes.NewReader(nonce), src), aad)
+	if err != nil {
+		return fmt.Errorf("create decrypting reader: %w", err)
+	}
+
+	_, err = io.Copy(dst, r)
+	if err != nil {
+		return fmt.Errorf("decrypt stream: %w", err)
+	}
+
+	return nil
+}
+
+// headerWriter captures the streaming AEAD header written by Tink's encrypting writer and forwards the remaining
+// ciphertext segments to w. The first byte of the header is the header's length.
+type headerWriter struct {
+	w      io.Writer
+	header []byte
+	done   bool
+}
+
+// Write writes p to the underlying w.Writer after the stream header has been fully captured.
+func (h *headerWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if !h.done && len(p) > 0 {
+		if len(h.header) == 0 && p[0] == 0 {
+			return 0, errors.New("invalid stream header length")
+		}
+
+		headerLen := int(p[0])
+		if len(h.header) > 0 {
+			headerLen = int(h.header[0])
+		}
+
+		missing := headerLen - len(h.header)
+		if missing > len(p) {
+			missing = len(p)
+		}
+
+		h.header = append(h.header, p[:missing]...)
+		p = p[missing:]
+		h.done = len(h.header) == headerLen
+	}
+
+	if len(p) == 0 {
+		return n, nil
+	}
+
+	_, err := h.w.Write(p)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
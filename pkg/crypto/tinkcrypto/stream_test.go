@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"bytes"
+	"testing"
+
+	tinkaead "github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/streamingaead"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrypto_EncryptDecryptStream(t *testing.T) {
+	kh, err := keyset.NewHandle(streamingaead.AES256GCMHKDF4KBKeyTemplate())
+	require.NoError(t, err)
+
+	c := Crypto{}
+	aad := []byte("some aad")
+
+	t.Run("success", func(t *testing.T) {
+		// spans multiple 4 KB segments with a partial last segment.
+		plaintext := random.GetRandomBytes(3*4096 + 123)
+
+		ct := new(bytes.Buffer)
+
+		nonce, err := c.EncryptStream(ct, bytes.NewReader(plaintext), aad, kh)
+		require.NoError(t, err)
+		require.NotEmpty(t, nonce)
+		require.Equal(t, len(nonce), int(nonce[0]))
+		require.NotContains(t, ct.String(), string(nonce))
+
+		pt := new(bytes.Buffer)
+
+		err = c.DecryptStream(pt, bytes.NewReader(ct.Bytes()), aad, nonce, kh)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, pt.Bytes())
+	})
+
+	t.Run("success with empty plaintext", func(t *testing.T) {
+		ct := new(bytes.Buffer)
+
+		nonce, err := c.EncryptStream(ct, bytes.NewReader(nil), aad, kh)
+		require.NoError(t, err)
+
+		pt := new(bytes.Buffer)
+
+		err = c.DecryptStream(pt, bytes.NewReader(ct.Bytes()), aad, nonce, kh)
+		require.NoError(t, err)
+		require.Empty(t, pt.Bytes())
+	})
+
+	t.Run("fail to decrypt with bad aad or truncated stream", func(t *testing.T) {
+		plaintext := random.GetRandomBytes(2 * 4096)
+
+		ct := new(bytes.Buffer)
+
+		nonce, err := c.EncryptStream(ct, bytes.NewReader(plaintext), aad, kh)
+		require.NoError(t, err)
+
+		err = c.DecryptStream(new(bytes.Buffer), bytes.NewReader(ct.Bytes()), []byte("bad aad"), nonce, kh)
+		require.Error(t, err)
+
+		err = c.DecryptStream(new(bytes.Buffer), bytes.NewReader(ct.Bytes()[:ct.Len()-10]), aad, nonce, kh)
+		require.Error(t, err)
+	})
+
+	t.Run("fail with bad key handle", func(t *testing.T) {
+		_, err = c.EncryptStream(new(bytes.Buffer), bytes.NewReader([]byte("msg")), aad, "bad key handle")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		err = c.DecryptStream(new(bytes.Buffer), bytes.NewReader([]byte("ct")), aad, nil, "bad key handle")
+		require.EqualError(t, err, errBadKeyHandleFormat.Error())
+
+		aeadKH, err := keyset.NewHandle(tinkaead.AES256GCMKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.EncryptStream(new(bytes.Buffer), bytes.NewReader([]byte("msg")), aad, aeadKH)
+		require.Contains(t, err.Error(), "create new streaming aead")
+
+		err = c.DecryptStream(new(bytes.Buffer), bytes.NewReader([]byte("ct")), aad, nil, aeadKH)
+		require.Contains(t, err.Error(), "create new streaming aead")
+	})
+}
@@ -323,6 +323,21 @@ func kdf(kwAlg string, z, apu, apv []byte, keySize int) []byte {
 }
 
 func kdfWithTag(kwAlg string, z, apu, apv, tag []byte, keySize int, useTag bool) []byte {
+	algID, ptyUInfo, ptyVInfo, supPubInfo, kdfKeySize := kdfOtherInfo(kwAlg, apu, apv, tag, keySize, useTag)
+
+	reader := josecipher.NewConcatKDF(crypto.SHA256, z, algID, ptyUInfo, ptyVInfo, supPubInfo, []byte{})
+
+	kek := make([]byte, kdfKeySize)
+
+	_, _ = reader.Read(kek) // nolint:errcheck // ConcatKDF's Read() never returns an error
+
+	return kek
+}
+
+// kdfOtherInfo builds the Concat KDF OtherInfo fields (AlgorithmID, PartyUInfo, PartyVInfo and SuppPubInfo) for
+// kwAlg and returns them along with the size of the key to derive.
+func kdfOtherInfo(kwAlg string, apu, apv, tag []byte, keySize int, useTag bool) ([]byte, []byte, []byte, []byte,
+	int) {
 	algID := cryptoutil.LengthPrefix([]byte(kwAlg))
 	ptyUInfo := cryptoutil.LengthPrefix(apu)
 	ptyVInfo := cryptoutil.LengthPrefix(apv)
@@ -351,11 +366,5 @@ func kdfWithTag(kwAlg string, z, apu, apv, tag []byte, keySize int, useTag bool)
 		supPubInfo = append(supPubInfo, tagInfo...)
 	}
 
-	reader := josecipher.NewConcatKDF(crypto.SHA256, z, algID, ptyUInfo, ptyVInfo, supPubInfo, []byte{})
-
-	kek := make([]byte, kdfKeySize)
-
-	_, _ = reader.Read(kek) // nolint:errcheck // ConcatKDF's Read() never returns an error
-
-	return kek
+	return algID, ptyUInfo, ptyVInfo, supPubInfo, kdfKeySize
 }
@@ -11,6 +11,7 @@ type wrapKeyOpts struct {
 	useXC20PKW bool
 	tag        []byte
 	epk        *PrivateKey
+	kdfTrace   bool
 }
 
 // NewOpt creates a new empty wrap key option.
@@ -42,6 +43,11 @@ func (pk *wrapKeyOpts) EPK() *PrivateKey {
 	return pk.epk
 }
 
+// KDFTrace instructs to return the serialized KDF input used in key wrapping.
+func (pk *wrapKeyOpts) KDFTrace() bool {
+	return pk.kdfTrace
+}
+
 // WrapKeyOpts are the crypto.Wrap key options.
 type WrapKeyOpts func(opts *wrapKeyOpts)
 
@@ -62,6 +68,9 @@ func WithSender(senderKey interface{}) WrapKeyOpts {
 // encryption as key wrapping. The absence of this option (default) uses AES256-GCM encryption as key wrapping. The KDF
 // used in the crypto wrapping function is selected based on the type of recipient key argument of KeyWrap(), it is
 // independent of this option.
+// This option only selects the key wrapping algorithm, content encryption (eg JWE `enc`) is chosen separately by the
+// caller. XChacha20Poly1305 content encryption can therefore be used with the default AES256-GCM key wrapping (eg
+// JWE `enc` as `XC20P` with `alg` as `ECDH-ES+A256KW`) by omitting this option, even for X25519 recipient keys.
 func WithXC20PKW() WrapKeyOpts {
 	return func(opts *wrapKeyOpts) {
 		opts.useXC20PKW = true
@@ -86,3 +95,13 @@ func WithEPK(epk *PrivateKey) WrapKeyOpts {
 		opts.epk = epk
 	}
 }
+
+// WithKDFTrace option is a flag option for crypto wrapping. When used, the serialized Concat KDF input used to derive
+// the key encryption key (AlgorithmID || PartyUInfo || PartyVInfo || SuppPubInfo) is returned in the KDFInfo field of
+// the resulting RecipientWrappedKey. It is meant for debugging interoperability issues with other JOSE libraries. The
+// absence of this option (default) leaves KDFInfo empty.
+func WithKDFTrace() WrapKeyOpts {
+	return func(opts *wrapKeyOpts) {
+		opts.kdfTrace = true
+	}
+}
@@ -6,7 +6,30 @@ SPDX-License-Identifier: Apache-2.0
 
 package model
 
-import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	// AckMsgType is the type of the generic DIDComm V1 ack (RFC 0015).
+	AckMsgType = "https://didcomm.org/notification/1.0/ack"
+	// AckMsgTypeV2 is the type of the generic DIDComm V2 ack.
+	AckMsgTypeV2 = "https://didcomm.org/notification/2.0/ack"
+
+	// AckStatusOK acknowledges that the message was received or processed successfully.
+	AckStatusOK = "OK"
+	// AckStatusPending acknowledges that the message was received but is not processed yet.
+	AckStatusPending = "PENDING"
+	// AckStatusFail acknowledges that the message was received but could not be processed.
+	AckStatusFail = "FAIL"
+
+	pleaseAckDecorator = "~please_ack"
+)
 
 // Ack acknowledgement struct.
 type Ack struct {
@@ -15,3 +38,72 @@ type Ack struct {
 	Status string            `json:"status,omitempty"`
 	Thread *decorator.Thread `json:"~thread,omitempty"`
 }
+
+// AckV2 is the DIDComm V2 acknowledgement struct.
+type AckV2 struct {
+	ID   string    `json:"id,omitempty"`
+	Type string    `json:"type,omitempty"`
+	Body AckV2Body `json:"body,omitempty"`
+}
+
+// AckV2Body represents body for AckV2.
+type AckV2Body struct {
+	Status string `json:"status,omitempty"`
+}
+
+// RequestsAck returns true if msg carries a ~please_ack decorator requesting an ack on the given event
+// (decorator.AckOnReceipt or decorator.AckOnOutcome).
+func RequestsAck(msg service.DIDCommMsg, on string) bool {
+	h := struct {
+		PleaseAck *decorator.PleaseAck `json:"~please_ack,omitempty"`
+	}{}
+
+	if _, ok := msg.Clone()[pleaseAckDecorator]; !ok {
+		return false
+	}
+
+	if err := msg.Decode(&h); err != nil {
+		return false
+	}
+
+	if h.PleaseAck == nil {
+		h.PleaseAck = &decorator.PleaseAck{}
+	}
+
+	return h.PleaseAck.Requests(on)
+}
+
+// SendAck replies to msg with a generic ack of the given status on its thread. The ack is a DIDComm V2 message
+// if msg is one.
+func SendAck(messenger service.Messenger, msg service.DIDCommMsgMap, myDID, theirDID, status string) error {
+	ack := service.NewDIDCommMsgMap(&Ack{
+		Type:   AckMsgType,
+		ID:     uuid.New().String(),
+		Status: status,
+	})
+
+	if msg.IsDIDCommV2() {
+		ack = service.NewDIDCommMsgMap(&AckV2{
+			ID:   uuid.New().String(),
+			Type: AckMsgTypeV2,
+			Body: AckV2Body{Status: status},
+		})
+	}
+
+	if err := messenger.ReplyToMsg(msg, ack, myDID, theirDID); err != nil {
+		return fmt.Errorf("send ack: %w", err)
+	}
+
+	return nil
+}
+
+// SendOutcomeAck is the hook for application logic to acknowledge the outcome of processing msg: it sends an ack
+// of the given status if msg requested an OUTCOME ack, and does nothing otherwise.
+// RECEIPT acks are sent by the framework when the message is received.
+func SendOutcomeAck(messenger service.Messenger, msg service.DIDCommMsgMap, myDID, theirDID, status string) error {
+	if !RequestsAck(msg, decorator.AckOnOutcome) {
+		return nil
+	}
+
+	return SendAck(messenger, msg, myDID, theirDID, status)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+)
+
+func TestRequestsAck(t *testing.T) {
+	msg := service.DIDCommMsgMap{"@type": "type"}
+	require.False(t, RequestsAck(msg, decorator.AckOnReceipt))
+
+	msg["~please_ack"] = map[string]interface{}{}
+	require.True(t, RequestsAck(msg, decorator.AckOnReceipt))
+	require.False(t, RequestsAck(msg, decorator.AckOnOutcome))
+
+	msg["~please_ack"] = map[string]interface{}{"on": []interface{}{"OUTCOME"}}
+	require.False(t, RequestsAck(msg, decorator.AckOnReceipt))
+	require.True(t, RequestsAck(msg, decorator.AckOnOutcome))
+
+	msg["~please_ack"] = "invalid"
+	require.False(t, RequestsAck(msg, decorator.AckOnReceipt))
+}
+
+func TestSendOutcomeAck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	msg := service.DIDCommMsgMap{
+		"@id":         "request-1",
+		"@type":       "https://didcomm.org/issue-credential/2.0/request-credential",
+		"~please_ack": map[string]interface{}{"on": []interface{}{"RECEIPT", "OUTCOME"}},
+	}
+
+	t.Run("ack is sent", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(msg, gomock.Any(), "myDID", "theirDID").
+			DoAndReturn(func(_, out service.DIDCommMsgMap, _, _ string) error {
+				ack := &Ack{}
+				require.NoError(t, out.Decode(ack))
+				require.Equal(t, AckMsgType, ack.Type)
+				require.Equal(t, AckStatusFail, ack.Status)
+				require.NotEmpty(t, ack.ID)
+
+				return nil
+			})
+
+		require.NoError(t, SendOutcomeAck(messenger, msg, "myDID", "theirDID", AckStatusFail))
+	})
+
+	t.Run("DIDComm V2 ack is sent", func(t *testing.T) {
+		msgV2 := service.DIDCommMsgMap{
+			"id":          "request-2",
+			"type":        "https://didcomm.org/issue-credential/3.0/request-credential",
+			"~please_ack": map[string]interface{}{"on": []interface{}{"OUTCOME"}},
+		}
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(msgV2, gomock.Any(), "myDID", "theirDID").
+			DoAndReturn(func(_, out service.DIDCommMsgMap, _, _ string) error {
+				require.True(t, out.IsDIDCommV2())
+
+				ack := &AckV2{}
+				require.NoError(t, out.Decode(ack))
+				require.Equal(t, AckMsgTypeV2, ack.Type)
+				require.Equal(t, AckStatusOK, ack.Body.Status)
+				require.NotEmpty(t, ack.ID)
+
+				return nil
+			})
+
+		require.NoError(t, SendOutcomeAck(messenger, msgV2, "myDID", "theirDID", AckStatusOK))
+	})
+
+	t.Run("no ack requested", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+
+		require.NoError(t, SendOutcomeAck(messenger, service.DIDCommMsgMap{"@type": "type"},
+			"myDID", "theirDID", AckStatusOK))
+	})
+
+	t.Run("reply error", func(t *testing.T) {
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(errors.New("reply error"))
+
+		err := SendOutcomeAck(messenger, msg, "myDID", "theirDID", AckStatusOK)
+		require.EqualError(t, err, "send ack: reply error")
+	})
+}
@@ -6,15 +6,101 @@ SPDX-License-Identifier: Apache-2.0
 
 package model
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+const (
+	// ProblemCodePropKey is the event property holding the code of a received problem report.
+	ProblemCodePropKey = "problemCode"
+	// ProblemCommentPropKey is the event property holding the comment of a received problem report.
+	ProblemCommentPropKey = "problemComment"
+	// ProblemThreadIDPropKey is the event property holding the thread ID of a received problem report.
+	ProblemThreadIDPropKey = "problemThreadID"
+
+	// ProblemReportMsgType is the type of the generic DIDComm V1 problem report (RFC 0035).
+	ProblemReportMsgType = "https://didcomm.org/notification/1.0/problem-report"
+	// ProblemReportV2MsgType is the type of the generic DIDComm V2 problem report.
+	ProblemReportV2MsgType = "https://didcomm.org/report-problem/2.0/problem-report"
+
+	// MessageExpiredCode is the problem code reported for messages received after their expiry time.
+	MessageExpiredCode = "message-expired"
+)
+
 // ProblemReport problem report definition
 // TODO: need to provide full ProblemReport structure https://github.com/hyperledger/aries-framework-go/issues/912
 type ProblemReport struct {
 	Type        string `json:"@type"`
 	ID          string `json:"@id"`
 	Description Code   `json:"description"`
+	Comment     string `json:"comment,omitempty"`
 }
 
 // Code represents a problem report code.
 type Code struct {
 	Code string `json:"code"`
 }
+
+// ProblemReportV2 is the DIDComm V2 problem report definition.
+type ProblemReportV2 struct {
+	ID   string              `json:"id,omitempty"`
+	Type string              `json:"type,omitempty"`
+	Body ProblemReportV2Body `json:"body,omitempty"`
+}
+
+// ProblemReportV2Body represents body for ProblemReportV2.
+type ProblemReportV2Body struct {
+	Code    string `json:"code,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ProblemReportDetails is what a received problem report tells about the failure of a protocol exchange.
+type ProblemReportDetails struct {
+	Code     string
+	Comment  string
+	ThreadID string
+}
+
+// ParseProblemReport returns the details of the given DIDComm V1 or V2 problem-report message.
+func ParseProblemReport(msg service.DIDCommMsg) (*ProblemReportDetails, error) {
+	thID, err := msg.ThreadID()
+	if err != nil && !errors.Is(err, service.ErrThreadIDNotFound) {
+		return nil, fmt.Errorf("problem report thread ID: %w", err)
+	}
+
+	details := &ProblemReportDetails{ThreadID: thID}
+
+	if msg.Clone().IsDIDCommV2() {
+		report := ProblemReportV2{}
+
+		if err = msg.Decode(&report); err != nil {
+			return nil, fmt.Errorf("decode problem report: %w", err)
+		}
+
+		details.Code, details.Comment = report.Body.Code, report.Body.Comment
+
+		return details, nil
+	}
+
+	report := ProblemReport{}
+
+	if err = msg.Decode(&report); err != nil {
+		return nil, fmt.Errorf("decode problem report: %w", err)
+	}
+
+	details.Code, details.Comment = report.Description.Code, report.Comment
+
+	return details, nil
+}
+
+// Properties returns the details as event properties.
+func (d *ProblemReportDetails) Properties() map[string]interface{} {
+	return map[string]interface{}{
+		ProblemCodePropKey:     d.Code,
+		ProblemCommentPropKey:  d.Comment,
+		ProblemThreadIDPropKey: d.ThreadID,
+	}
+}
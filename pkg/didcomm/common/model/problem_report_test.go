@@ -0,0 +1,71 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+func TestParseProblemReport(t *testing.T) {
+	t.Run("DIDComm V1", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(&ProblemReport{
+			Type:        "https://didcomm.org/present-proof/2.0/problem-report",
+			ID:          "report-id",
+			Description: Code{Code: "rejected"},
+			Comment:     "presentation rejected",
+		})
+		msg["~thread"] = map[string]interface{}{"thid": "thread-id"}
+
+		details, err := ParseProblemReport(msg)
+		require.NoError(t, err)
+		require.Equal(t, &ProblemReportDetails{
+			Code:     "rejected",
+			Comment:  "presentation rejected",
+			ThreadID: "thread-id",
+		}, details)
+		require.Equal(t, map[string]interface{}{
+			ProblemCodePropKey:     "rejected",
+			ProblemCommentPropKey:  "presentation rejected",
+			ProblemThreadIDPropKey: "thread-id",
+		}, details.Properties())
+	})
+
+	t.Run("DIDComm V2", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(&ProblemReportV2{
+			ID:   "report-id",
+			Type: "https://didcomm.org/present-proof/3.0/problem-report",
+			Body: ProblemReportV2Body{Code: "internal", Comment: "internal error"},
+		})
+		msg["thid"] = "thread-id"
+
+		details, err := ParseProblemReport(msg)
+		require.NoError(t, err)
+		require.Equal(t, &ProblemReportDetails{
+			Code:     "internal",
+			Comment:  "internal error",
+			ThreadID: "thread-id",
+		}, details)
+	})
+
+	t.Run("no thread ID", func(t *testing.T) {
+		details, err := ParseProblemReport(service.NewDIDCommMsgMap(&ProblemReport{
+			Type:        "https://didcomm.org/issue-credential/2.0/problem-report",
+			Description: Code{Code: "rejected"},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, &ProblemReportDetails{Code: "rejected"}, details)
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		_, err := ParseProblemReport(service.DIDCommMsgMap(nil))
+		require.EqualError(t, err, "problem report thread ID: invalid message")
+	})
+}
@@ -24,6 +24,8 @@ type Destination struct {
 	RoutingKeys          []string
 	TransportReturnRoute string
 	MediaTypeProfiles    []string
+	// ThreadID is the thread of the message sent to the destination, set by the outbound dispatcher.
+	ThreadID string
 }
 
 const (
@@ -26,6 +26,10 @@ const (
 	jsonParentThreadID = "pthid"
 	jsonMetadata       = "_internal_metadata"
 
+	// DIDComm V2 plaintext messages use JSON names without the '@' prefix.
+	jsonIDV2   = "id"
+	jsonTypeV2 = "type"
+
 	basePIURI = "https://didcomm.org/"
 	oldPIURI  = "did:sov:BzCbsNYhMrjHiqZDTUASHg;spec/"
 )
@@ -80,7 +84,7 @@ func ParseDIDCommMsgMap(payload []byte) (DIDCommMsgMap, error) {
 	}
 
 	// Interop: accept old PIURI when it's used, as we handle backwards-compatibility at a more fine-grained level.
-	if typ := msg.Type(); typ != "" {
+	if typ, ok := msg[jsonType].(string); ok && typ != "" {
 		msg[jsonType] = strings.Replace(typ, oldPIURI, basePIURI, 1)
 	}
 
@@ -101,6 +105,7 @@ func NewDIDCommMsgMap(v interface{}) DIDCommMsgMap {
 
 // ThreadID returns msg ~thread.thid if there is no ~thread.thid returns msg @id
 // message is invalid if ~thread.thid exist and @id is absent.
+// DIDComm V2 messages carry the thread ID as a top-level 'thid' instead.
 func (m DIDCommMsgMap) ThreadID() (string, error) {
 	if m == nil {
 		return "", ErrInvalidMessage
@@ -109,6 +114,10 @@ func (m DIDCommMsgMap) ThreadID() (string, error) {
 	msgID := m.ID()
 	thread, ok := m[jsonThread].(map[string]interface{})
 
+	if m.IsDIDCommV2() {
+		thread, ok = m, true
+	}
+
 	if ok && thread[jsonThreadID] != nil {
 		var thID string
 		if v, ok := thread[jsonThreadID].(string); ok {
@@ -147,27 +156,24 @@ func (m DIDCommMsgMap) Metadata() map[string]interface{} {
 	return metadata
 }
 
-// Type returns the message type.
+// Type returns the message type ('@type', or 'type' for DIDComm V2 messages).
 func (m DIDCommMsgMap) Type() string {
-	if m == nil || m[jsonType] == nil {
-		return ""
-	}
+	return m.stringField(jsonType, jsonTypeV2)
+}
 
-	res, ok := m[jsonType].(string)
-	if !ok {
+// ParentThreadID returns the message parent threadID (top-level 'pthid' for DIDComm V2 messages).
+func (m DIDCommMsgMap) ParentThreadID() string {
+	if m == nil {
 		return ""
 	}
 
-	return res
-}
+	thread, ok := m[jsonThread].(map[string]interface{})
 
-// ParentThreadID returns the message parent threadID.
-func (m DIDCommMsgMap) ParentThreadID() string {
-	if m == nil || m[jsonThread] == nil {
-		return ""
+	if m.IsDIDCommV2() {
+		thread, ok = m, true
 	}
 
-	if thread, ok := m[jsonThread].(map[string]interface{}); ok && thread != nil {
+	if ok && thread != nil {
 		if pthID, ok := thread[jsonParentThreadID].(string); ok && pthID != "" {
 			return pthID
 		}
@@ -176,26 +182,49 @@ func (m DIDCommMsgMap) ParentThreadID() string {
 	return ""
 }
 
-// ID returns the message id.
+// IsDIDCommV2 returns true if the message is a DIDComm V2 plaintext message, ie. it has a 'type' but no '@type'.
+func (m DIDCommMsgMap) IsDIDCommV2() bool {
+	_, hasV1Type := m[jsonType]
+	_, hasV2Type := m[jsonTypeV2]
+
+	return !hasV1Type && hasV2Type
+}
+
+// ID returns the message id ('@id', or 'id' for DIDComm V2 messages).
 func (m DIDCommMsgMap) ID() string {
-	if m == nil || m[jsonID] == nil {
-		return ""
-	}
+	return m.stringField(jsonID, jsonIDV2)
+}
 
-	res, ok := m[jsonID].(string)
-	if !ok {
-		return ""
+// stringField returns the string value of the first of the given keys present in the message.
+func (m DIDCommMsgMap) stringField(keys ...string) string {
+	for _, key := range keys {
+		if m[key] == nil {
+			continue
+		}
+
+		res, ok := m[key].(string)
+		if !ok {
+			return ""
+		}
+
+		return res
 	}
 
-	return res
+	return ""
 }
 
-// SetID sets the message id.
+// SetID sets the message id ('@id', or 'id' for DIDComm V2 messages).
 func (m DIDCommMsgMap) SetID(id string) error {
 	if m == nil {
 		return ErrNilMessage
 	}
 
+	if m.IsDIDCommV2() {
+		m[jsonIDV2] = id
+
+		return nil
+	}
+
 	m[jsonID] = id
 
 	return nil
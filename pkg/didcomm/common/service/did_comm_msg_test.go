@@ -38,6 +38,11 @@ func TestDIDCommMsgMap_ID(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonID: "ID"},
 			expected: "ID",
 		},
+		{
+			name:     "Success (DIDComm V2)",
+			msg:      DIDCommMsgMap{jsonIDV2: "ID"},
+			expected: "ID",
+		},
 	}
 
 	for i := range tests {
@@ -58,6 +63,20 @@ func TestDIDCommMsgMap_SetID(t *testing.T) {
 
 	require.NoError(t, m.SetID(ID))
 	require.Equal(t, ID, m.ID())
+
+	v2 := DIDCommMsgMap{jsonTypeV2: "type"}
+
+	require.NoError(t, v2.SetID(ID))
+	require.Equal(t, ID, v2[jsonIDV2])
+	require.NotContains(t, v2, jsonID)
+}
+
+func TestDIDCommMsgMap_IsDIDCommV2(t *testing.T) {
+	require.False(t, DIDCommMsgMap(nil).IsDIDCommV2())
+	require.False(t, DIDCommMsgMap{}.IsDIDCommV2())
+	require.False(t, DIDCommMsgMap{jsonType: "type"}.IsDIDCommV2())
+	require.False(t, DIDCommMsgMap{jsonType: "type", jsonTypeV2: "type"}.IsDIDCommV2())
+	require.True(t, DIDCommMsgMap{jsonTypeV2: "type"}.IsDIDCommV2())
 }
 
 func TestDIDCommMsgMap_MetaData(t *testing.T) {
@@ -114,6 +133,16 @@ func TestDIDCommMsgMap_Type(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonType: "Type"},
 			expected: "Type",
 		},
+		{
+			name:     "Success (DIDComm V2)",
+			msg:      DIDCommMsgMap{jsonTypeV2: "Type"},
+			expected: "Type",
+		},
+		{
+			name:     "DIDComm V1 type takes precedence",
+			msg:      DIDCommMsgMap{jsonType: "Type", jsonTypeV2: "other"},
+			expected: "Type",
+		},
 	}
 
 	for i := range tests {
@@ -182,6 +211,11 @@ func TestDIDCommMsgMap_ParentThreadID(t *testing.T) {
 			msg:      DIDCommMsgMap{jsonThread: map[string]interface{}{jsonParentThreadID: "pthID"}},
 			expected: "pthID",
 		},
+		{
+			name:     "Success (DIDComm V2)",
+			msg:      DIDCommMsgMap{jsonTypeV2: "type", jsonParentThreadID: "pthID"},
+			expected: "pthID",
+		},
 	}
 
 	for i := range tests {
@@ -94,6 +94,15 @@ type Event interface {
 	UnregisterMsgEvent(ch chan<- StateMsg) error
 }
 
+// MsgEventReplayer is implemented by the services keeping their recent message events for the subscribers which
+// register late. Action events are not replayed: they carry one-shot Continue/Stop callbacks which belong to the
+// first consumer, so a replayed action could not be acted upon.
+type MsgEventReplayer interface {
+	// RegisterMsgEventWithReplay registers the channel like RegisterMsgEvent and replays to it the last n message
+	// events kept by the service.
+	RegisterMsgEventWithReplay(ch chan<- StateMsg, n int) error
+}
+
 // AutoExecuteActionEvent is a utility function to execute Action events automatically. The function requires
 // a channel to be passed-in to listen to dispatcher.DIDCommAction and triggers the Continue function on the
 // action event. This is a blocking function and use this function with a goroutine.
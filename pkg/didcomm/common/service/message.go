@@ -8,10 +8,21 @@ package service
 
 import "sync"
 
+// DefaultMsgEventsBufferSize is the number of recent message events kept for replay by default.
+const DefaultMsgEventsBufferSize = 10
+
 // Message thread-safe message register structure.
+// The recent message events are kept in a bounded ring buffer to be replayed to the channels registered later
+// with RegisterMsgEventWithReplay.
 type Message struct {
-	mu     sync.RWMutex
-	events []chan<- StateMsg
+	mu      sync.RWMutex
+	events  []chan<- StateMsg
+	replays map[chan<- StateMsg][]chan struct{} // stops the pending replays of the channel when it is unregistered
+
+	bufferSize    int
+	bufferSizeSet bool
+	recent        []StateMsg // ring buffer of the recent message events, the oldest one is recent[next]
+	next          int
 }
 
 // MsgEvents returns event message channels.
@@ -26,13 +37,46 @@ func (m *Message) MsgEvents() []chan<- StateMsg {
 // RegisterMsgEvent on protocol messages. The message events are triggered for incoming messages. Event
 // will not expect any callback on these events unlike Action events.
 func (m *Message) RegisterMsgEvent(ch chan<- StateMsg) error {
+	return m.RegisterMsgEventWithReplay(ch, 0)
+}
+
+// RegisterMsgEventWithReplay registers the channel like RegisterMsgEvent and replays to it the last n message
+// events, among the buffered ones. It lets the consumers which register late (e.g. a controller reconnecting)
+// recover the state changes they missed.
+// The events are replayed asynchronously, oldest first, new events may be sent to the channel in between. The
+// pending replay is stopped when the channel is unregistered.
+func (m *Message) RegisterMsgEventWithReplay(ch chan<- StateMsg, n int) error {
 	if ch == nil {
 		return ErrNilChannel
 	}
 
 	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.events = append(m.events, ch)
-	m.mu.Unlock()
+
+	replay := m.lastEvents(n)
+	if len(replay) == 0 {
+		return nil
+	}
+
+	stop := make(chan struct{})
+
+	if m.replays == nil {
+		m.replays = map[chan<- StateMsg][]chan struct{}{}
+	}
+
+	m.replays[ch] = append(m.replays[ch], stop)
+
+	go func() {
+		for _, msg := range replay {
+			select {
+			case ch <- copyStateMsg(msg):
+			case <-stop:
+				return
+			}
+		}
+	}()
 
 	return nil
 }
@@ -46,7 +90,118 @@ func (m *Message) UnregisterMsgEvent(ch chan<- StateMsg) error {
 			i--
 		}
 	}
+
+	for _, stop := range m.replays[ch] {
+		close(stop)
+	}
+
+	delete(m.replays, ch)
 	m.mu.Unlock()
 
 	return nil
 }
+
+// PublishMsgEvent keeps the message event for replay and sends it to the registered channels.
+func (m *Message) PublishMsgEvent(msg StateMsg) {
+	m.mu.Lock()
+	m.record(msg)
+	events := append(m.events[:0:0], m.events...)
+	m.mu.Unlock()
+
+	for _, handler := range events {
+		handler <- msg
+	}
+}
+
+// SetMsgEventsBufferSize sets the number of recent message events kept for replay (DefaultMsgEventsBufferSize by
+// default), zero disables the replay.
+func (m *Message) SetMsgEventsBufferSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.recent = m.lastEvents(size)
+	m.next = 0
+	m.bufferSize = size
+	m.bufferSizeSet = true
+}
+
+// record keeps a copy of the message event, the subscribers may modify the event they receive.
+func (m *Message) record(msg StateMsg) {
+	size := DefaultMsgEventsBufferSize
+	if m.bufferSizeSet {
+		size = m.bufferSize
+	}
+
+	if size == 0 {
+		return
+	}
+
+	msg = copyStateMsg(msg)
+
+	if len(m.recent) < size {
+		m.recent = append(m.recent, msg)
+
+		return
+	}
+
+	m.recent[m.next] = msg
+	m.next = (m.next + 1) % size
+}
+
+// lastEvents returns the last n buffered message events, oldest first.
+func (m *Message) lastEvents(n int) []StateMsg {
+	if n <= 0 {
+		return nil
+	}
+
+	events := make([]StateMsg, 0, len(m.recent))
+	events = append(events, m.recent[m.next:]...)
+	events = append(events, m.recent[:m.next]...)
+
+	if n < len(events) {
+		events = events[len(events)-n:]
+	}
+
+	return events
+}
+
+// copyStateMsg returns the message event with a deep copy of its DIDComm message, so that the subscribers do not
+// share the buffered one.
+func copyStateMsg(msg StateMsg) StateMsg {
+	if msg.Msg == nil {
+		return msg
+	}
+
+	if clone := msg.Msg.Clone(); clone != nil {
+		msg.Msg = DIDCommMsgMap(copyValue(map[string]interface{}(clone)).(map[string]interface{}))
+	}
+
+	return msg
+}
+
+func copyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(val))
+
+		for k, e := range val {
+			c[k] = copyValue(e)
+		}
+
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(val))
+
+		for i, e := range val {
+			c[i] = copyValue(e)
+		}
+
+		return c
+	default:
+		return v
+	}
+}
@@ -7,11 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+var _ MsgEventReplayer = (*Message)(nil)
+
 func TestAction_MsgEvents(t *testing.T) {
 	m := Message{}
 	require.Nil(t, m.MsgEvents())
@@ -51,3 +55,131 @@ func TestAction_UnregisterMsgEvent(t *testing.T) {
 	// no error if nothing to unregister
 	require.Nil(t, m.UnregisterMsgEvent(ch))
 }
+
+func TestMessage_PublishMsgEvent(t *testing.T) {
+	m := Message{}
+
+	ch := make(chan StateMsg, 1)
+	require.NoError(t, m.RegisterMsgEvent(ch))
+
+	m.PublishMsgEvent(StateMsg{StateID: "state"})
+	require.Equal(t, StateMsg{StateID: "state"}, <-ch)
+}
+
+func TestMessage_Replay(t *testing.T) {
+	stateIDs := func(ch <-chan StateMsg, n int) []string {
+		var ids []string
+
+		for i := 0; i < n; i++ {
+			select {
+			case msg := <-ch:
+				ids = append(ids, msg.StateID)
+			case <-time.After(time.Second):
+				require.Fail(t, "replayed event not received")
+			}
+		}
+
+		return ids
+	}
+
+	publish := func(m *Message, n int) {
+		for i := 1; i <= n; i++ {
+			m.PublishMsgEvent(StateMsg{StateID: fmt.Sprintf("state-%d", i)})
+		}
+	}
+
+	t.Run("replays the last events", func(t *testing.T) {
+		m := Message{}
+		publish(&m, 3)
+
+		ch := make(chan StateMsg)
+		require.NoError(t, m.RegisterMsgEventWithReplay(ch, 2))
+		require.Equal(t, []string{"state-2", "state-3"}, stateIDs(ch, 2))
+
+		all := make(chan StateMsg)
+		require.NoError(t, m.RegisterMsgEventWithReplay(all, 5))
+		require.Equal(t, []string{"state-1", "state-2", "state-3"}, stateIDs(all, 3))
+	})
+
+	t.Run("bounded buffer", func(t *testing.T) {
+		m := Message{}
+		publish(&m, DefaultMsgEventsBufferSize+3)
+
+		ch := make(chan StateMsg)
+		require.NoError(t, m.RegisterMsgEventWithReplay(ch, DefaultMsgEventsBufferSize+3))
+
+		ids := stateIDs(ch, DefaultMsgEventsBufferSize)
+		require.Equal(t, "state-4", ids[0])
+		require.Equal(t, fmt.Sprintf("state-%d", DefaultMsgEventsBufferSize+3), ids[len(ids)-1])
+	})
+
+	t.Run("buffer size", func(t *testing.T) {
+		m := Message{}
+		m.SetMsgEventsBufferSize(2)
+		publish(&m, 5)
+
+		ch := make(chan StateMsg)
+		require.NoError(t, m.RegisterMsgEventWithReplay(ch, 5))
+		require.Equal(t, []string{"state-4", "state-5"}, stateIDs(ch, 2))
+
+		// shrinking the buffer keeps the last events
+		m.SetMsgEventsBufferSize(1)
+		require.Equal(t, []string{"state-5"}, stateIDs(replay(t, &m, 5), 1))
+
+		disabled := Message{}
+		disabled.SetMsgEventsBufferSize(-1)
+		publish(&disabled, 1)
+		require.Empty(t, disabled.lastEvents(5))
+	})
+
+	t.Run("no replay by default", func(t *testing.T) {
+		m := Message{}
+		publish(&m, 1)
+
+		ch := make(chan StateMsg, 1)
+		require.NoError(t, m.RegisterMsgEvent(ch))
+
+		m.PublishMsgEvent(StateMsg{StateID: "live"})
+		require.Equal(t, "live", (<-ch).StateID)
+	})
+
+	t.Run("replay stops when the channel is unregistered", func(t *testing.T) {
+		m := Message{}
+		publish(&m, 3)
+
+		ch := make(chan StateMsg)
+		require.NoError(t, m.RegisterMsgEventWithReplay(ch, 3))
+		require.Equal(t, []string{"state-1"}, stateIDs(ch, 1))
+		require.NoError(t, m.UnregisterMsgEvent(ch))
+
+		select {
+		case msg := <-ch:
+			require.Fail(t, "unexpected replayed event", msg.StateID)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("subscribers get copies of the buffered events", func(t *testing.T) {
+		m := Message{}
+
+		msg := DIDCommMsgMap{"@id": "id", "attachment": map[string]interface{}{"credential": "vc"}}
+		m.PublishMsgEvent(StateMsg{StateID: "state", Msg: msg})
+		msg["attachment"].(map[string]interface{})["credential"] = "modified"
+
+		first := (<-replay(t, &m, 1)).Msg.(DIDCommMsgMap)
+		require.Equal(t, map[string]interface{}{"credential": "vc"}, first["attachment"])
+		first["attachment"].(map[string]interface{})["credential"] = "modified"
+
+		second := (<-replay(t, &m, 1)).Msg.(DIDCommMsgMap)
+		require.Equal(t, map[string]interface{}{"credential": "vc"}, second["attachment"])
+	})
+}
+
+func replay(t *testing.T, m *Message, n int) <-chan StateMsg {
+	t.Helper()
+
+	ch := make(chan StateMsg)
+	require.NoError(t, m.RegisterMsgEventWithReplay(ch, n))
+
+	return ch
+}
@@ -90,6 +90,14 @@ type Messenger interface {
 	ReplyToNested(msg DIDCommMsgMap, opts *NestedReplyOpts) error
 }
 
+// DestinationMessenger is implemented by the messengers able to reply to a message received without a connection.
+type DestinationMessenger interface {
+	// ReplyToMsgToDestination replies to the given message by sending the reply to the given destination,
+	// e.g. the route of the ~service decorator of a message received without a connection.
+	// Keeps threadID in the *decorator.Thread.
+	ReplyToMsgToDestination(in, out DIDCommMsgMap, sender string, destination *Destination) error
+}
+
 // MessengerHandler includes Messenger interface and Handle function to handle inbound messages.
 type MessengerHandler interface {
 	Messenger
@@ -206,6 +206,21 @@ func TestDIDCommMsg_ThreadID(t *testing.T) {
 		msg:  DIDCommMsgMap{},
 		val:  "",
 		err:  ErrThreadIDNotFound.Error(),
+	}, {
+		name: "DIDComm V2 ID without Thread ID",
+		msg:  DIDCommMsgMap{jsonTypeV2: "type", jsonIDV2: "ID"},
+		val:  "ID",
+		err:  "",
+	}, {
+		name: "DIDComm V2 Thread ID with ID",
+		msg:  DIDCommMsgMap{jsonTypeV2: "type", jsonIDV2: "ID", jsonThreadID: "thID"},
+		val:  "thID",
+		err:  "",
+	}, {
+		name: "DIDComm V2 Thread ID without ID",
+		msg:  DIDCommMsgMap{jsonTypeV2: "type", jsonThreadID: "thID"},
+		val:  "",
+		err:  ErrInvalidMessage.Error(),
 	}}
 
 	t.Parallel()
@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package threading provides helpers for the threading of DIDComm messages, handling both the DIDComm V1 shape
+// (the '~thread' decorator) and the DIDComm V2 shape (top-level 'thid' and 'pthid').
+package threading
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+const (
+	jsonThread         = "~thread"
+	jsonThreadID       = "thid"
+	jsonParentThreadID = "pthid"
+)
+
+// ThreadID returns the thread ID of the message: '~thread.thid' of DIDComm V1 messages or 'thid' of DIDComm V2
+// messages. The thread ID defaults to the message ID when the message does not set it, i.e. it starts a thread.
+func ThreadID(msg service.DIDCommMsg) (string, error) {
+	if msg == nil {
+		return "", service.ErrInvalidMessage
+	}
+
+	return msg.ThreadID()
+}
+
+// ParentThreadID returns the parent thread ID of the message ('~thread.pthid' or 'pthid'), empty if it has none.
+func ParentThreadID(msg service.DIDCommMsg) string {
+	if msg == nil {
+		return ""
+	}
+
+	return msg.ParentThreadID()
+}
+
+// Thread identifies the thread of a message.
+type Thread struct {
+	// ID is the thread ID.
+	ID string
+	// ParentID is the ID of the parent thread, empty if the thread is not nested.
+	ParentID string
+}
+
+// NewThread returns a new thread with a random ID, nested in the parent thread if parentThreadID is not empty.
+// The ID is meant to be the ID of the first message of the thread too.
+func NewThread(parentThreadID string) *Thread {
+	return &Thread{
+		ID:       uuid.New().String(),
+		ParentID: parentThreadID,
+	}
+}
+
+// Decorator returns the thread as the '~thread' decorator of DIDComm V1 messages.
+func (t *Thread) Decorator() *decorator.Thread {
+	return &decorator.Thread{
+		ID:  t.ID,
+		PID: t.ParentID,
+	}
+}
+
+// Apply sets the thread of the message: the '~thread' decorator of DIDComm V1 messages (keeping its other
+// properties) or the top-level 'thid' and 'pthid' of DIDComm V2 messages.
+func (t *Thread) Apply(msg service.DIDCommMsgMap) error {
+	if msg == nil {
+		return service.ErrNilMessage
+	}
+
+	if msg.IsDIDCommV2() {
+		setOrDelete(msg, jsonThreadID, t.ID)
+		setOrDelete(msg, jsonParentThreadID, t.ParentID)
+
+		return nil
+	}
+
+	thread := map[string]interface{}{}
+
+	if current, ok := msg[jsonThread].(map[string]interface{}); ok {
+		for k, v := range current {
+			thread[k] = v
+		}
+	}
+
+	setOrDelete(thread, jsonThreadID, t.ID)
+	setOrDelete(thread, jsonParentThreadID, t.ParentID)
+
+	msg[jsonThread] = thread
+
+	return nil
+}
+
+func setOrDelete(m map[string]interface{}, key, value string) {
+	if value == "" {
+		delete(m, key)
+
+		return
+	}
+
+	m[key] = value
+}
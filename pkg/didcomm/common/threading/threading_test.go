@@ -0,0 +1,126 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package threading
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
+
+func TestThreadID(t *testing.T) {
+	t.Run("DIDComm V1", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{
+			"@id":   "message-id",
+			"@type": "https://didcomm.org/basicmessage/1.0/message",
+			"~thread": map[string]interface{}{
+				"thid":  "thread-id",
+				"pthid": "parent-thread-id",
+			},
+		}
+
+		thID, err := ThreadID(msg)
+		require.NoError(t, err)
+		require.Equal(t, "thread-id", thID)
+		require.Equal(t, "parent-thread-id", ParentThreadID(msg))
+	})
+
+	t.Run("DIDComm V2", func(t *testing.T) {
+		msg := service.DIDCommMsgMap{
+			"id":    "message-id",
+			"type":  "https://didcomm.org/basicmessage/2.0/message",
+			"thid":  "thread-id",
+			"pthid": "parent-thread-id",
+			// ignored by DIDComm V2 messages
+			"~thread": map[string]interface{}{"thid": "v1-thread-id"},
+		}
+
+		thID, err := ThreadID(msg)
+		require.NoError(t, err)
+		require.Equal(t, "thread-id", thID)
+		require.Equal(t, "parent-thread-id", ParentThreadID(msg))
+	})
+
+	t.Run("defaults to the message ID", func(t *testing.T) {
+		for _, msg := range []service.DIDCommMsgMap{
+			{"@id": "message-id", "@type": "https://didcomm.org/basicmessage/1.0/message"},
+			{"@id": "message-id", "~thread": map[string]interface{}{"pthid": "parent-thread-id"}},
+			{"id": "message-id", "type": "https://didcomm.org/basicmessage/2.0/message"},
+		} {
+			thID, err := ThreadID(msg)
+			require.NoError(t, err)
+			require.Equal(t, "message-id", thID)
+		}
+	})
+
+	t.Run("invalid messages", func(t *testing.T) {
+		_, err := ThreadID(nil)
+		require.ErrorIs(t, err, service.ErrInvalidMessage)
+
+		_, err = ThreadID(service.DIDCommMsgMap{"~thread": map[string]interface{}{"thid": "thread-id"}})
+		require.ErrorIs(t, err, service.ErrInvalidMessage)
+
+		_, err = ThreadID(service.DIDCommMsgMap{"@type": "https://didcomm.org/basicmessage/1.0/message"})
+		require.ErrorIs(t, err, service.ErrThreadIDNotFound)
+
+		require.Empty(t, ParentThreadID(nil))
+	})
+}
+
+func TestNewThread(t *testing.T) {
+	t.Run("DIDComm V1", func(t *testing.T) {
+		thread := NewThread("parent-thread-id")
+		require.NotEmpty(t, thread.ID)
+		require.Equal(t, &decorator.Thread{ID: thread.ID, PID: "parent-thread-id"}, thread.Decorator())
+
+		msg := service.DIDCommMsgMap{
+			"@id":     "message-id",
+			"@type":   "https://didcomm.org/basicmessage/1.0/message",
+			"~thread": map[string]interface{}{"sender_order": 1},
+		}
+
+		require.NoError(t, thread.Apply(msg))
+		require.Equal(t, map[string]interface{}{
+			"thid":         thread.ID,
+			"pthid":        "parent-thread-id",
+			"sender_order": 1,
+		}, msg["~thread"])
+
+		thID, err := ThreadID(msg)
+		require.NoError(t, err)
+		require.Equal(t, thread.ID, thID)
+		require.Equal(t, "parent-thread-id", ParentThreadID(msg))
+	})
+
+	t.Run("DIDComm V2", func(t *testing.T) {
+		thread := NewThread("")
+		require.NotEqual(t, thread.ID, NewThread("").ID)
+
+		msg := service.DIDCommMsgMap{
+			"id":    "message-id",
+			"type":  "https://didcomm.org/basicmessage/2.0/message",
+			"pthid": "previous-parent-thread-id",
+		}
+
+		require.NoError(t, thread.Apply(msg))
+		require.Equal(t, thread.ID, msg["thid"])
+		require.NotContains(t, msg, "pthid")
+		require.NotContains(t, msg, "~thread")
+
+		thID, err := ThreadID(msg)
+		require.NoError(t, err)
+		require.Equal(t, thread.ID, thID)
+		require.Empty(t, ParentThreadID(msg))
+	})
+
+	t.Run("nil message", func(t *testing.T) {
+		require.ErrorIs(t, NewThread("").Apply(nil), service.ErrNilMessage)
+	})
+}
@@ -35,6 +35,11 @@ type provider interface {
 	StorageProvider() storage.Provider
 }
 
+// mediaTypeProfilesPackager is implemented by packagers reporting the media type profiles they can pack messages for.
+type mediaTypeProfilesPackager interface {
+	MediaTypeProfiles() []string
+}
+
 type connectionLookup interface {
 	GetConnectionIDByDIDs(myDID, theirDID string) (string, error)
 	GetConnectionRecord(string) (*connection.Record, error)
@@ -138,7 +143,7 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 		}
 
 		packedMsg, err := o.packager.PackMessage(&transport.Envelope{
-			MediaTypeProfile: mediaTypeProfile(des),
+			MediaTypeProfile: o.mediaTypeProfile(des),
 			Message:          req,
 			FromKey:          sender,
 			ToKeys:           des.RecipientKeys,
@@ -155,7 +160,7 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 			return fmt.Errorf("outboundDispatcher.Send: failed to create forward msg : %w", err)
 		}
 
-		_, err = v.Send(packedMsg, des)
+		_, err = v.Send(packedMsg, withThreadID(des, req))
 		if err != nil {
 			return fmt.Errorf("outboundDispatcher.Send: failed to send msg using outbound transport: %w", err)
 		}
@@ -166,6 +171,18 @@ func (o *OutboundDispatcher) Send(msg interface{}, senderVerKey string, des *ser
 	return fmt.Errorf("outboundDispatcher.Send: no transport found for destination: %+v", des)
 }
 
+// withThreadID returns a copy of the destination with the thread ID of the message sent to it, used by the transports
+// to return the message over the request of the same thread.
+func withThreadID(des *service.Destination, msg []byte) *service.Destination {
+	d := *des
+
+	if didCommMsg, err := service.ParseDIDCommMsgMap(msg); err == nil {
+		d.ThreadID, _ = didCommMsg.ThreadID()
+	}
+
+	return &d
+}
+
 // Forward forwards the message without packing to the destination.
 func (o *OutboundDispatcher) Forward(msg interface{}, des *service.Destination) error {
 	for _, v := range o.outboundTransports {
@@ -227,7 +244,7 @@ func (o *OutboundDispatcher) createForwardMessage(msg []byte, des *service.Desti
 	//  algorithm(auth/anon crypt) for Forward(router) message
 
 	packedMsg, err := o.packager.PackMessage(&transport.Envelope{
-		MediaTypeProfile: mediaTypeProfile(des),
+		MediaTypeProfile: o.mediaTypeProfile(des),
 		Message:          req,
 		FromKey:          senderVerKey,
 		ToKeys:           des.RoutingKeys,
@@ -266,12 +283,29 @@ func (o *OutboundDispatcher) addTransportRouteOptions(req []byte, des *service.D
 	return req, nil
 }
 
-// TODO - inject MediaTypeProfile selection strategy.
-func mediaTypeProfile(des *service.Destination) string {
-	mt := ""
-	if len(des.MediaTypeProfiles) > 0 {
-		mt = des.MediaTypeProfiles[0]
+// mediaTypeProfile returns the first media type profile of the recipient's accept list (listed in the recipient's
+// order of preference) the packager can pack messages for. An empty profile, packing with the primary packer of the
+// packager, is returned if there is none. Packagers not reporting their media type profiles get the recipient's
+// preferred profile.
+func (o *OutboundDispatcher) mediaTypeProfile(des *service.Destination) string {
+	p, ok := o.packager.(mediaTypeProfilesPackager)
+	if !ok {
+		if len(des.MediaTypeProfiles) > 0 {
+			return des.MediaTypeProfiles[0]
+		}
+
+		return ""
+	}
+
+	supported := p.MediaTypeProfiles()
+
+	for _, accepted := range des.MediaTypeProfiles {
+		for _, mtp := range supported {
+			if accepted == mtp {
+				return mtp
+			}
+		}
 	}
 
-	return mt
+	return ""
 }
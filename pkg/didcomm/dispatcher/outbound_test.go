@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,19 +16,31 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
+	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -269,6 +282,124 @@ func TestOutboundDispatcher_SendToDID(t *testing.T) {
 	})
 }
 
+func TestOutboundDispatcher_MediaTypeProfile(t *testing.T) {
+	o := &OutboundDispatcher{packager: &mediaTypeProfilesPackagerStub{
+		profiles: []string{transport.MediaTypeProfileDIDCommV2, transport.MediaTypeProfileAIP2RFC587},
+	}}
+
+	t.Run("first accepted profile the packager supports", func(t *testing.T) {
+		require.Equal(t, transport.MediaTypeProfileAIP2RFC587, o.mediaTypeProfile(&service.Destination{
+			MediaTypeProfiles: []string{
+				transport.MediaTypeProfileDIDCommAIP1,
+				transport.MediaTypeProfileAIP2RFC587,
+				transport.MediaTypeProfileDIDCommV2,
+			},
+		}))
+	})
+
+	t.Run("primary packer if no accepted profile is supported", func(t *testing.T) {
+		require.Empty(t, o.mediaTypeProfile(&service.Destination{
+			MediaTypeProfiles: []string{transport.MediaTypeProfileDIDCommAIP1},
+		}))
+		require.Empty(t, o.mediaTypeProfile(&service.Destination{}))
+	})
+
+	t.Run("preferred profile of the recipient if the packager does not report its profiles", func(t *testing.T) {
+		o := &OutboundDispatcher{packager: &mockPackager{}}
+
+		require.Equal(t, transport.MediaTypeProfileDIDCommAIP1, o.mediaTypeProfile(&service.Destination{
+			MediaTypeProfiles: []string{transport.MediaTypeProfileDIDCommAIP1, transport.MediaTypeProfileDIDCommV2},
+		}))
+		require.Empty(t, o.mediaTypeProfile(&service.Destination{}))
+	})
+}
+
+func TestOutboundDispatcher_SendToDIDAcceptingOnlyRFC19(t *testing.T) {
+	prov := &packagerProvider{storage: mockstore.NewMockStoreProvider()}
+
+	var err error
+
+	prov.kms, err = localkms.New("local-lock://test/key-uri/", prov)
+	require.NoError(t, err)
+
+	prov.crypto, err = tinkcrypto.New()
+	require.NoError(t, err)
+
+	authPacker, err := authcrypt.New(prov, jose.A256CBCHS512)
+	require.NoError(t, err)
+
+	// DIDComm V2 is the default, the legacy packer is used only for recipients that don't accept V2 envelopes.
+	prov.primaryPacker = authPacker
+	prov.packers = []packer.Packer{authPacker, legacy.New(prov)}
+
+	pkgr, err := packager.New(prov)
+	require.NoError(t, err)
+
+	docs := map[string]*did.Doc{}
+
+	for _, didID := range []string{"did:example:me", "did:example:them"} {
+		_, pubKey, e := prov.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+		require.NoError(t, e)
+
+		didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+		docs[didID] = &did.Doc{
+			ID: didID,
+			Service: []did.Service{{
+				ServiceEndpoint: "https://localhost:8090",
+				Type:            "did-communication",
+				RecipientKeys:   []string{didKey},
+			}},
+		}
+	}
+
+	outbound := &recordingOutboundTransport{}
+
+	o, err := NewOutbound(&mockProvider{
+		packagerValue: pkgr,
+		vdr: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: docs[didID]}, nil
+			},
+		},
+		outboundTransportsValue: []transport.OutboundTransport{outbound},
+		storageProvider:         mockstore.NewMockStoreProvider(),
+		protoStorageProvider:    mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	for _, accept := range [][]string{
+		{transport.MediaTypeProfileDIDCommAIP2RFC19},
+		// profiles the packager does not support are skipped.
+		{"didcomm/unsupported", transport.MediaTypeProfileDIDCommAIP2RFC19},
+	} {
+		o.connections = &mockConnectionLookup{
+			getConnectionRecordVal: &connection.Record{MediaTypeProfiles: accept},
+		}
+
+		ackID := uuid.New().String()
+
+		require.NoError(t, o.SendToDID(service.NewDIDCommMsgMap(&model.Ack{
+			Type: model.AckMsgType,
+			ID:   ackID,
+		}), "did:example:me", "did:example:them"))
+		require.Equal(t, ackID, outbound.destination.ThreadID)
+
+		env := struct {
+			Protected string `json:"protected"`
+		}{}
+		require.NoError(t, json.Unmarshal(outbound.sent, &env))
+
+		protected, err := base64.URLEncoding.DecodeString(env.Protected)
+		require.NoError(t, err)
+		require.Contains(t, string(protected), `"typ":"`+transport.MediaTypeRFC0019EncryptedEnvelope+`"`)
+
+		unpacked, err := pkgr.UnpackMessage(outbound.sent)
+		require.NoError(t, err)
+		require.Contains(t, string(unpacked.Message), model.AckMsgType)
+	}
+}
+
 func TestOutboundDispatcherTransportReturnRoute(t *testing.T) {
 	t.Run("transport route option - value set all", func(t *testing.T) {
 		transportReturnRoute := "all"
@@ -509,6 +640,71 @@ func (m *mockPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, er
 	return nil, nil
 }
 
+// mediaTypeProfilesPackagerStub is a packager reporting the media type profiles it supports.
+type mediaTypeProfilesPackagerStub struct {
+	mockPackager
+	profiles []string
+}
+
+func (m *mediaTypeProfilesPackagerStub) MediaTypeProfiles() []string {
+	return m.profiles
+}
+
+// recordingOutboundTransport records the last message sent and its destination.
+type recordingOutboundTransport struct {
+	mockdidcomm.MockOutboundTransport
+	sent        []byte
+	destination *service.Destination
+}
+
+func (o *recordingOutboundTransport) Send(data []byte, destination *service.Destination) (string, error) {
+	o.sent = data
+	o.destination = destination
+
+	return "", nil
+}
+
+func (o *recordingOutboundTransport) Accept(string) bool {
+	return true
+}
+
+// packagerProvider provides the dependencies of the KMS, the packers and the packager.
+type packagerProvider struct {
+	storage       storage.Provider
+	kms           kms.KeyManager
+	crypto        cryptoapi.Crypto
+	packers       []packer.Packer
+	primaryPacker packer.Packer
+}
+
+func (p *packagerProvider) StorageProvider() storage.Provider {
+	return p.storage
+}
+
+func (p *packagerProvider) SecretLock() secretlock.Service {
+	return &noop.NoLock{}
+}
+
+func (p *packagerProvider) KMS() kms.KeyManager {
+	return p.kms
+}
+
+func (p *packagerProvider) Crypto() cryptoapi.Crypto {
+	return p.crypto
+}
+
+func (p *packagerProvider) Packers() []packer.Packer {
+	return p.packers
+}
+
+func (p *packagerProvider) PrimaryPacker() packer.Packer {
+	return p.primaryPacker
+}
+
+func (p *packagerProvider) VDRegistry() vdrapi.Registry {
+	return nil
+}
+
 type mockConnectionLookup struct {
 	getConnectionByDIDsVal string
 	getConnectionByDIDsErr error
@@ -116,6 +116,92 @@ func TestMessageService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("test MessageService.HandleInbound() with localized content and attachments", func(t *testing.T) {
+		const jsonStr = `{
+			    "@id": "123456781",
+			    "@type": "https://didcomm.org/basicmessage/1.0/message",
+			    "~l10n": { "locale": "en" },
+			    "content": "Your hovercraft is full of eels.",
+			    "content~l10n": {
+			        "locale": "en",
+			        "es": "Mi aerodeslizador está lleno de anguilas.",
+			        "fr": "Mon aéroglisseur est plein d'anguilles."
+			    },
+			    "~attach": [
+			        {
+			            "@id": "eels",
+			            "mime-type": "text/plain",
+			            "filename": "eels.txt",
+			            "data": { "base64": "ZWVscw==" }
+			        },
+			        {
+			            "@id": "hovercraft",
+			            "mime-type": "image/png",
+			            "data": { "links": ["https://example.com/hovercraft.png"] }
+			        }
+			    ]
+			}`
+
+		var received Message
+
+		svc, err := NewMessageService("sample-name", func(message Message, _ service.DIDCommContext) error {
+			received = message
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(jsonStr))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		require.Equal(t, "en", received.ContentL10N.Locale())
+		require.Equal(t, []string{"es", "fr"}, received.ContentL10N.Locales())
+		require.Equal(t, "Mi aerodeslizador está lleno de anguilas.", received.LocalizedContent("es"))
+		require.Equal(t, "Mon aéroglisseur est plein d'anguilles.", received.LocalizedContent("fr"))
+		require.Equal(t, "Your hovercraft is full of eels.", received.LocalizedContent("en"))
+		require.Equal(t, "Your hovercraft is full of eels.", received.LocalizedContent("de"))
+		require.Equal(t, "Your hovercraft is full of eels.", received.LocalizedContent("locale"))
+
+		require.Len(t, received.Attachments, 2)
+		require.Equal(t, "eels.txt", received.Attachments[0].FileName)
+
+		data, err := received.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+		require.Equal(t, "eels", string(data))
+
+		require.Equal(t, "image/png", received.Attachments[1].MimeType)
+		require.Equal(t, []string{"https://example.com/hovercraft.png"}, received.Attachments[1].Data.Links)
+	})
+
+	t.Run("test MessageService.HandleInbound() plain message", func(t *testing.T) {
+		var received Message
+
+		svc, err := NewMessageService("sample-name", func(message Message, _ service.DIDCommContext) error {
+			received = message
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(`{
+			    "@id": "123456782",
+			    "@type": "https://didcomm.org/basicmessage/1.0/message",
+			    "content": "Your hovercraft is full of eels."
+			}`))
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+
+		require.Empty(t, received.ContentL10N.Locale())
+		require.Empty(t, received.ContentL10N.Locales())
+		require.Empty(t, received.Attachments)
+		require.Equal(t, "Your hovercraft is full of eels.", received.LocalizedContent("es"))
+	})
+
 	t.Run("test MessageService.HandleInbound() error", func(t *testing.T) {
 		const sampleErr = "sample-error"
 		svc, err := NewMessageService("sample-name", getMockMessageHandle())
@@ -9,7 +9,12 @@
 
 package basic
 
-import "time"
+import (
+	"sort"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+)
 
 // Message is message model for basic message protocol
 // Reference:
@@ -22,4 +27,46 @@ type Message struct {
 	} `json:"~l10n"`
 	SentTime time.Time `json:"sent_time"`
 	Content  string    `json:"content"`
+	// ContentL10N carries the localized variants of Content, see ContentL10N.
+	ContentL10N ContentL10N `json:"content~l10n,omitempty"`
+	// Attachments are the files attached to the message, inlined as base64 or referenced by links.
+	Attachments []decorator.Attachment `json:"~attach,omitempty"`
+}
+
+// ContentL10N is the field localization decorator of Message.Content. It maps locales to the localized content,
+// its "locale" entry is the locale of Content itself.
+// Reference:
+//  https://github.com/hyperledger/aries-rfcs/tree/master/features/0043-l10n#the-l10n-decorator-at-field-level
+type ContentL10N map[string]string
+
+const localeKey = "locale"
+
+// Locale returns the locale of Message.Content.
+func (c ContentL10N) Locale() string {
+	return c[localeKey]
+}
+
+// Locales returns the sorted locales of the localized variants of Message.Content.
+func (c ContentL10N) Locales() []string {
+	var locales []string
+
+	for locale := range c {
+		if locale != localeKey {
+			locales = append(locales, locale)
+		}
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// LocalizedContent returns the content in the given locale. Content is returned if the message has no variant
+// for the locale.
+func (m *Message) LocalizedContent(locale string) string {
+	if content, ok := m.ContentL10N[locale]; ok && locale != localeKey {
+		return content
+	}
+
+	return m.Content
 }
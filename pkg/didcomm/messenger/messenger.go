@@ -24,6 +24,7 @@ const (
 	MessengerStore = "messenger_store"
 
 	jsonID             = "@id"
+	jsonIDV2           = "id"
 	jsonThread         = "~thread"
 	jsonThreadID       = "thid"
 	jsonParentThreadID = "pthid"
@@ -95,6 +96,12 @@ func (m *Messenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) erro
 	// fills missing fields
 	fillIfMissing(msg)
 
+	if msg.IsDIDCommV2() {
+		setThreadV2(msg, msg.ID(), "")
+
+		return m.dispatcher.SendToDID(msg, myDID, theirDID)
+	}
+
 	msg[jsonThread] = map[string]interface{}{
 		jsonThreadID: msg.ID(),
 	}
@@ -112,6 +119,11 @@ func (m *Messenger) SendToDestination(msg service.DIDCommMsgMap, sender string,
 
 	delete(msg, jsonThread)
 
+	if msg.IsDIDCommV2() {
+		delete(msg, jsonThreadID)
+		delete(msg, jsonParentThreadID)
+	}
+
 	return m.dispatcher.Send(msg, sender, destination)
 }
 
@@ -127,6 +139,12 @@ func (m *Messenger) ReplyTo(msgID string, msg service.DIDCommMsgMap) error {
 		return fmt.Errorf("get record: %w", err)
 	}
 
+	if msg.IsDIDCommV2() {
+		setThreadV2(msg, rec.ThreadID, rec.ParentThreadID)
+
+		return m.dispatcher.SendToDID(msg, rec.MyDID, rec.TheirDID)
+	}
+
 	// sets threadID
 	thread := map[string]interface{}{
 		jsonThreadID: rec.ThreadID,
@@ -149,11 +167,42 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 	// fills missing fields
 	fillIfMissing(out)
 
+	if err := setReplyThread(in, out); err != nil {
+		return err
+	}
+
+	return m.dispatcher.SendToDID(out, myDID, theirDID)
+}
+
+// ReplyToMsgToDestination replies to the given message by sending the reply to the given destination,
+// e.g. the route of the ~service decorator of a message received without a connection.
+// The function adds ~thread decorator to the message according to the given message.
+// Do not provide a message with ~thread decorator. It will be rewritten.
+func (m *Messenger) ReplyToMsgToDestination(in, out service.DIDCommMsgMap, sender string,
+	destination *service.Destination) error {
+	// fills missing fields
+	fillIfMissing(out)
+
+	if err := setReplyThread(in, out); err != nil {
+		return err
+	}
+
+	return m.dispatcher.Send(out, sender, destination)
+}
+
+// setReplyThread sets the thread of the reply out to the thread of the message in.
+func setReplyThread(in, out service.DIDCommMsgMap) error {
 	thID, err := in.ThreadID()
 	if err != nil {
 		return fmt.Errorf("get threadID: %w", err)
 	}
 
+	if out.IsDIDCommV2() {
+		setThreadV2(out, thID, in.ParentThreadID())
+
+		return nil
+	}
+
 	// sets threadID
 	thread := map[string]interface{}{
 		jsonThreadID: thID,
@@ -166,7 +215,7 @@ func (m *Messenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID st
 
 	out[jsonThread] = thread
 
-	return m.dispatcher.SendToDID(out, myDID, theirDID)
+	return nil
 }
 
 // ReplyToNested sends the message by starting a new thread.
@@ -181,6 +230,12 @@ func (m *Messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.Neste
 		return fmt.Errorf("failed to prepare nested reply options: %w", err)
 	}
 
+	if msg.IsDIDCommV2() {
+		setThreadV2(msg, "", opts.ThreadID)
+
+		return m.dispatcher.SendToDID(msg, opts.MyDID, opts.TheirDID)
+	}
+
 	// sets parent threadID
 	msg[jsonThread] = map[string]interface{}{jsonParentThreadID: opts.ThreadID}
 
@@ -190,11 +245,27 @@ func (m *Messenger) ReplyToNested(msg service.DIDCommMsgMap, opts *service.Neste
 // fillIfMissing populates message with common fields such as ID.
 func fillIfMissing(msg service.DIDCommMsgMap) {
 	// if ID is empty we will create a new one
-	if msg.ID() == "" {
+	if msg.ID() == "" && msg.IsDIDCommV2() {
+		msg[jsonIDV2] = uuid.New().String()
+	} else if msg.ID() == "" {
 		msg[jsonID] = uuid.New().String()
 	}
 }
 
+// setThreadV2 sets the top-level thread IDs of a DIDComm V2 message, which has no ~thread decorator.
+func setThreadV2(msg service.DIDCommMsgMap, thID, pthID string) {
+	delete(msg, jsonThreadID)
+	delete(msg, jsonParentThreadID)
+
+	if thID != "" {
+		msg[jsonThreadID] = thID
+	}
+
+	if pthID != "" {
+		msg[jsonParentThreadID] = pthID
+	}
+}
+
 // getRecord returns message payload by msgID.
 func (m *Messenger) getRecord(msgID string) (*record, error) {
 	src, err := m.store.Get(msgID)
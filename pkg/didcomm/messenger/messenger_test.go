@@ -410,3 +410,175 @@ func TestMessenger_ReplyToMsg(t *testing.T) {
 		}, service.DIDCommMsgMap{}, "", ""), "get threadID: invalid message")
 	})
 }
+
+func TestMessenger_ReplyToMsgToDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		destination := &service.Destination{ServiceEndpoint: "http://example.com"}
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().Send(gomock.Any(), "did:key:sender", destination).
+			Do(func(msg interface{}, _ string, _ *service.Destination) error {
+				out, ok := msg.(service.DIDCommMsgMap)
+				require.True(t, ok)
+				require.NotEmpty(t, out[jsonID])
+				require.Equal(t, map[string]interface{}{
+					jsonThreadID: "thID", jsonParentThreadID: "pthID",
+				}, out[jsonThread])
+
+				return nil
+			})
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NotNil(t, msgr)
+
+		require.NoError(t, msgr.ReplyToMsgToDestination(service.DIDCommMsgMap{
+			jsonID:     "id",
+			jsonThread: map[string]interface{}{jsonThreadID: "thID", jsonParentThreadID: "pthID"},
+		}, service.DIDCommMsgMap{}, "did:key:sender", destination))
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+		require.NotNil(t, msgr)
+
+		require.EqualError(t, msgr.ReplyToMsgToDestination(service.DIDCommMsgMap{
+			jsonThread: map[string]interface{}{jsonThreadID: "thID"},
+		}, service.DIDCommMsgMap{}, "", &service.Destination{}), "get threadID: invalid message")
+	})
+}
+
+func sendToDIDCheckV2(t *testing.T, thID, pthID string) func(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	return func(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+		require.NotEmpty(t, msg[jsonIDV2])
+		require.NotContains(t, msg, jsonID)
+		require.NotContains(t, msg, jsonThread)
+
+		if thID == "" {
+			require.NotContains(t, msg, jsonThreadID)
+		} else {
+			require.Equal(t, thID, msg[jsonThreadID])
+		}
+
+		if pthID == "" {
+			require.NotContains(t, msg, jsonParentThreadID)
+		} else {
+			require.Equal(t, pthID, msg[jsonParentThreadID])
+		}
+
+		return nil
+	}
+}
+
+func TestMessenger_DIDCommV2(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const msgType = "https://didcomm.org/present-proof/3.0/request-presentation"
+
+	newMessenger := func(store *storageMocks.MockStore, outbound *dispatcherMocks.MockOutbound) *Messenger {
+		storageProvider := storageMocks.NewMockProvider(ctrl)
+		storageProvider.EXPECT().OpenStore(gomock.Any()).Return(store, nil)
+
+		provider := messengerMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(storageProvider)
+		provider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgr, err := NewMessenger(provider)
+		require.NoError(t, err)
+
+		return msgr
+	}
+
+	t.Run("send starts a thread", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).Do(sendToDIDCheckV2(t, ID, ""))
+
+		msgr := newMessenger(nil, outbound)
+		require.NoError(t, msgr.Send(service.DIDCommMsgMap{"type": msgType, jsonIDV2: ID}, myDID, theirDID))
+	})
+
+	t.Run("send fills a missing id", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
+			Do(func(msg service.DIDCommMsgMap, _, _ string) error {
+				require.NotEmpty(t, msg[jsonIDV2])
+				require.Equal(t, msg[jsonIDV2], msg[jsonThreadID])
+
+				return nil
+			})
+
+		msgr := newMessenger(nil, outbound)
+		require.NoError(t, msgr.Send(service.DIDCommMsgMap{"type": msgType}, myDID, theirDID))
+	})
+
+	t.Run("send to destination removes the thread", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ string, _ *service.Destination) error {
+				require.NotContains(t, msg, jsonThreadID)
+				require.NotContains(t, msg, jsonParentThreadID)
+
+				return nil
+			})
+
+		msgr := newMessenger(nil, outbound)
+		require.NoError(t, msgr.SendToDestination(service.DIDCommMsgMap{
+			"type": msgType, jsonThreadID: "thID", jsonParentThreadID: "pthID",
+		}, "", &service.Destination{}))
+	})
+
+	t.Run("reply to", func(t *testing.T) {
+		store := storageMocks.NewMockStore(ctrl)
+		store.EXPECT().Get(ID).Return([]byte(`{"thread_id":"thID","parent_thread_id":"pthID"}`), nil)
+
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(sendToDIDCheckV2(t, "thID", "pthID"))
+
+		msgr := newMessenger(store, outbound)
+		require.NoError(t, msgr.ReplyTo(ID, service.DIDCommMsgMap{"type": msgType}))
+	})
+
+	t.Run("reply to msg", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(sendToDIDCheckV2(t, "thID", "pthID"))
+
+		msgr := newMessenger(nil, outbound)
+		require.NoError(t, msgr.ReplyToMsg(service.DIDCommMsgMap{
+			"type": msgType, jsonIDV2: "id", jsonThreadID: "thID", jsonParentThreadID: "pthID",
+		}, service.DIDCommMsgMap{"type": msgType}, "", ""))
+	})
+
+	t.Run("reply to nested", func(t *testing.T) {
+		outbound := dispatcherMocks.NewMockOutbound(ctrl)
+		outbound.EXPECT().SendToDID(gomock.Any(), myDID, theirDID).
+			Do(sendToDIDCheckV2(t, "", "thID"))
+
+		msgr := newMessenger(nil, outbound)
+		require.NoError(t, msgr.ReplyToNested(service.DIDCommMsgMap{"type": msgType}, &service.NestedReplyOpts{
+			ThreadID: "thID", MyDID: myDID, TheirDID: theirDID,
+		}))
+	})
+}
@@ -8,8 +8,11 @@ package packager_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	. "github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
@@ -33,6 +37,230 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+func TestPackager_ReplayProtection(t *testing.T) {
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	customKMS, err := localkms.New("local-lock://test/key-uri/",
+		newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	thirdPartyKeyStore := make(map[string]mockstorage.DBEntry)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{Store: thirdPartyKeyStore}),
+		kms:     customKMS,
+		crypto:  cryptoSvc,
+	}
+
+	authPacker, err := authcrypt.New(mockedProviders, jose.A256CBCHS512)
+	require.NoError(t, err)
+
+	anonPacker, err := anoncrypt.New(mockedProviders, jose.A256GCM)
+	require.NoError(t, err)
+
+	mockedProviders.primaryPacker = authPacker
+	mockedProviders.packers = []packer.Packer{anonPacker}
+
+	fromKID, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	thirdPartyKeyStore[prefix.StorageKIDPrefix+fromKID] = mockstorage.DBEntry{Value: fromKey}
+
+	_, toKey, err := customKMS.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	toDIDKey, _ := fingerprint.CreateDIDKey(toKey)
+
+	pack := func(t *testing.T, packager *Packager, msg string) []byte {
+		t.Helper()
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeV1EncryptedEnvelope,
+			Message:          []byte(msg),
+			FromKey:          []byte(fromKID),
+			ToKeys:           []string{toDIDKey},
+		})
+		require.NoError(t, err)
+
+		return packMsg
+	}
+
+	t.Run("replayed envelope is rejected", func(t *testing.T) {
+		packager, err := New(mockedProviders, WithReplayStore(NewMemReplayStore(time.Minute)))
+		require.NoError(t, err)
+
+		packMsg := pack(t, packager, `{"@id":"1","@type":"https://didcomm.org/basicmessage/1.0/message"}`)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrReplayedMessage))
+
+		// the same message ID in another envelope is a replay too
+		_, err = packager.UnpackMessage(pack(t, packager,
+			`{"@id":"1","@type":"https://didcomm.org/basicmessage/1.0/message"}`))
+		require.True(t, errors.Is(err, ErrReplayedMessage))
+
+		_, err = packager.UnpackMessage(pack(t, packager,
+			`{"@id":"2","@type":"https://didcomm.org/basicmessage/1.0/message"}`))
+		require.NoError(t, err)
+	})
+
+	t.Run("replayed envelope of a message without ID is rejected", func(t *testing.T) {
+		packager, err := New(mockedProviders, WithReplayStore(NewMemReplayStore(time.Minute)))
+		require.NoError(t, err)
+
+		packMsg := pack(t, packager, "msg1")
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.True(t, errors.Is(err, ErrReplayedMessage))
+
+		// the envelopes of the same message have their own nonce
+		_, err = packager.UnpackMessage(pack(t, packager, "msg1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("anoncrypt envelopes are keyed by their nonce", func(t *testing.T) {
+		packager, err := New(mockedProviders, WithReplayStore(NewMemReplayStore(time.Minute)))
+		require.NoError(t, err)
+
+		anonPack := func(msg string) []byte {
+			packMsg, e := anonPacker.Pack(transport.MediaTypeV1PlaintextPayload, []byte(msg), nil, [][]byte{toKey})
+			require.NoError(t, e)
+
+			return packMsg
+		}
+
+		packMsg := anonPack(`{"@id":"1","@type":"https://didcomm.org/basicmessage/1.0/message"}`)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.True(t, errors.Is(err, ErrReplayedMessage))
+
+		// anyone can send an anoncrypt message with the same ID
+		_, err = packager.UnpackMessage(anonPack(`{"@id":"1","@type":"https://didcomm.org/basicmessage/1.0/message"}`))
+		require.NoError(t, err)
+	})
+
+	t.Run("no replay protection by default", func(t *testing.T) {
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		packMsg := pack(t, packager, `{"@id":"1"}`)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+	})
+
+	t.Run("replay store error", func(t *testing.T) {
+		packager, err := New(mockedProviders, WithReplayStore(&failingReplayStore{}))
+		require.NoError(t, err)
+
+		_, err = packager.UnpackMessage(pack(t, packager, `{"@id":"1"}`))
+		require.EqualError(t, err, "unpack: replay store: store failure")
+	})
+}
+
+func TestMemReplayStore(t *testing.T) {
+	store := NewMemReplayStore(50 * time.Millisecond)
+
+	replayed, err := store.CheckAndRecord("key1")
+	require.NoError(t, err)
+	require.False(t, replayed)
+
+	replayed, err = store.CheckAndRecord("key1")
+	require.NoError(t, err)
+	require.True(t, replayed)
+
+	time.Sleep(60 * time.Millisecond)
+
+	// the keys recorded out of the window are forgotten
+	replayed, err = store.CheckAndRecord("key2")
+	require.NoError(t, err)
+	require.False(t, replayed)
+
+	replayed, err = store.CheckAndRecord("key1")
+	require.NoError(t, err)
+	require.False(t, replayed)
+}
+
+func TestMemReplayStore_MaxKeys(t *testing.T) {
+	store := NewMemReplayStore(time.Minute, WithMaxReplayKeys(2))
+
+	for _, key := range []string{"key1", "key2", "key3"} {
+		replayed, err := store.CheckAndRecord(key)
+		require.NoError(t, err)
+		require.False(t, replayed)
+	}
+
+	replayed, err := store.CheckAndRecord("key3")
+	require.NoError(t, err)
+	require.True(t, replayed)
+
+	// the oldest key is forgotten to record the new one
+
+	replayed, err = store.CheckAndRecord("key1")
+	require.NoError(t, err)
+	require.False(t, replayed)
+}
+
+type failingReplayStore struct{}
+
+func (s *failingReplayStore) CheckAndRecord(string) (bool, error) {
+	return false, errors.New("store failure")
+}
+
+func TestPackager_MediaTypeProfiles(t *testing.T) {
+	customKMS, err := localkms.New("local-lock://test/key-uri/",
+		newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	mockedProviders := &mockProvider{
+		storage: mockstorage.NewMockStoreProvider(),
+		kms:     customKMS,
+		crypto:  cryptoSvc,
+	}
+
+	authPacker, err := authcrypt.New(mockedProviders, jose.A256CBCHS512)
+	require.NoError(t, err)
+
+	legacyPacker := legacy.New(mockedProviders)
+
+	mockedProviders.primaryPacker = legacyPacker
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		transport.MediaTypeProfileDIDCommAIP2RFC19,
+		transport.MediaTypeProfileDIDCommAIP1,
+	}, packager.MediaTypeProfiles())
+
+	mockedProviders.packers = []packer.Packer{authPacker}
+
+	packager, err = New(mockedProviders)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		transport.MediaTypeProfileDIDCommV2,
+		transport.MediaTypeProfileAIP2RFC587,
+		transport.MediaTypeProfileDIDCommAIP2RFC19,
+		transport.MediaTypeProfileDIDCommAIP1,
+	}, packager.MediaTypeProfiles())
+}
+
 func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 	localKeyURI := "local-lock://test/key-uri/"
 
@@ -344,6 +572,141 @@ func TestBaseKMSInPackager_UnpackMessage(t *testing.T) {
 		require.Equal(t, unpackedMsg.Message, []byte("msg2"))
 	})
 
+	t.Run("test success - legacy envelope for a recipient accepting only didcomm/aip2;env=rfc19", func(t *testing.T) {
+		customKMS, err := localkms.New(localKeyURI,
+			newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		mockedProviders := &mockProvider{
+			storage: mockstorage.NewMockStoreProvider(),
+			kms:     customKMS,
+			crypto:  cryptoSvc,
+		}
+
+		authPacker, err := authcrypt.New(mockedProviders, jose.A256CBCHS512)
+		require.NoError(t, err)
+
+		legacyPacker := legacy.New(mockedProviders)
+
+		// DIDComm V2 is the default, the legacy packer is used only for recipients that don't accept V2 envelopes.
+		mockedProviders.primaryPacker = authPacker
+		mockedProviders.packers = []packer.Packer{authPacker, legacyPacker}
+
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		_, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519)
+		require.NoError(t, err)
+
+		_, toKey, err := customKMS.CreateAndExportPubKeyBytes(kms.ED25519)
+		require.NoError(t, err)
+
+		didKey, _ := fingerprint.CreateDIDKey(toKey)
+
+		packMsg, err := packager.PackMessage(&transport.Envelope{
+			MediaTypeProfile: transport.MediaTypeProfileDIDCommAIP2RFC19,
+			Message:          []byte("msg1"),
+			FromKey:          fromKey,
+			ToKeys:           []string{didKey},
+		})
+		require.NoError(t, err)
+
+		env := struct {
+			Protected string `json:"protected"`
+		}{}
+		require.NoError(t, json.Unmarshal(packMsg, &env))
+
+		protected, err := base64.URLEncoding.DecodeString(env.Protected)
+		require.NoError(t, err)
+		require.Contains(t, string(protected), `"typ":"`+transport.MediaTypeRFC0019EncryptedEnvelope+`"`)
+
+		unpackedMsg, err := packager.UnpackMessage(packMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("msg1"), unpackedMsg.Message)
+	})
+
+	t.Run("test success - DIDComm V2 anoncrypt and authcrypt envelopes with multiple recipients", func(t *testing.T) {
+		customKMS, err := localkms.New(localKeyURI,
+			newMockKMSProvider(mockstorage.NewMockStoreProvider()))
+		require.NoError(t, err)
+
+		thirdPartyKeyStore := make(map[string]mockstorage.DBEntry)
+		mockedProviders := &mockProvider{
+			storage: mockstorage.NewCustomMockStoreProvider(&mockstorage.MockStore{
+				Store: thirdPartyKeyStore,
+			}),
+			kms:    customKMS,
+			crypto: cryptoSvc,
+		}
+
+		authPacker, err := authcrypt.New(mockedProviders, jose.A256CBCHS512)
+		require.NoError(t, err)
+
+		anonPacker, err := anoncrypt.New(mockedProviders, jose.A256GCM)
+		require.NoError(t, err)
+
+		mockedProviders.primaryPacker = authPacker
+		mockedProviders.packers = []packer.Packer{anonPacker, authPacker}
+
+		packager, err := New(mockedProviders)
+		require.NoError(t, err)
+
+		fromKID, fromKey, err := customKMS.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		// the recipient has received the sender's key to unpack authcrypt (ECDH-1PU) envelopes
+		thirdPartyKeyStore[prefix.StorageKIDPrefix+fromKID] = mockstorage.DBEntry{Value: fromKey}
+
+		var (
+			toDIDKeys  []string
+			recipients [][]byte
+		)
+
+		for i := 0; i < 2; i++ {
+			_, toKey, e := customKMS.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+			require.NoError(t, e)
+
+			didKey, _ := fingerprint.CreateDIDKey(toKey)
+			toDIDKeys = append(toDIDKeys, didKey)
+			recipients = append(recipients, toKey)
+		}
+
+		authMsg, err := packager.PackMessage(&transport.Envelope{
+			Message: []byte("authcrypt msg"),
+			FromKey: []byte(fromKID),
+			ToKeys:  toDIDKeys,
+		})
+		require.NoError(t, err)
+
+		anonMsg, err := anonPacker.Pack(transport.MediaTypeV1PlaintextPayload, []byte("anoncrypt msg"), nil, recipients)
+		require.NoError(t, err)
+
+		for _, msg := range [][]byte{authMsg, anonMsg} {
+			jwe, e := jose.Deserialize(string(msg))
+			require.NoError(t, e)
+			require.Len(t, jwe.Recipients, 2)
+
+			typ, ok := jwe.ProtectedHeaders.Type()
+			require.True(t, ok)
+			require.Equal(t, transport.MediaTypeV2EncryptedEnvelope, typ)
+		}
+
+		jwe, err := jose.Deserialize(string(authMsg))
+		require.NoError(t, err)
+
+		skid, ok := jwe.ProtectedHeaders.SenderKeyID()
+		require.True(t, ok)
+		require.Equal(t, fromKID, skid)
+
+		unpackedMsg, err := packager.UnpackMessage(authMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("authcrypt msg"), unpackedMsg.Message)
+
+		unpackedMsg, err = packager.UnpackMessage(anonMsg)
+		require.NoError(t, err)
+		require.Equal(t, []byte("anoncrypt msg"), unpackedMsg.Message)
+	})
+
 	t.Run("test success - dids not found", func(t *testing.T) {
 		customKMS, err := localkms.New(localKeyURI,
 			newMockKMSProvider(mockstorage.NewMockStoreProvider()))
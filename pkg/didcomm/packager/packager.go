@@ -23,6 +23,23 @@ import (
 
 const authSuffix = "-authcrypt"
 
+// envelopeEncodingTypes maps the media type profiles accepted by a recipient to the encoding type of the envelopes
+// they can read.
+var envelopeEncodingTypes = map[string]string{
+	transport.MediaTypeProfileDIDCommAIP1:      transport.MediaTypeRFC0019EncryptedEnvelope,
+	transport.MediaTypeProfileDIDCommAIP2RFC19: transport.MediaTypeRFC0019EncryptedEnvelope,
+	transport.MediaTypeProfileAIP2RFC587:       transport.MediaTypeV2EncryptedEnvelope,
+	transport.MediaTypeProfileDIDCommV2:        transport.MediaTypeV2EncryptedEnvelope,
+}
+
+// mediaTypeProfiles lists the media type profiles of envelopeEncodingTypes in order.
+var mediaTypeProfiles = []string{
+	transport.MediaTypeProfileDIDCommV2,
+	transport.MediaTypeProfileAIP2RFC587,
+	transport.MediaTypeProfileDIDCommAIP2RFC19,
+	transport.MediaTypeProfileDIDCommAIP1,
+}
+
 // Provider contains dependencies for the base packager and is typically created by using aries.Context().
 type Provider interface {
 	Packers() []packer.Packer
@@ -38,6 +55,7 @@ type Creator func(prov Provider) (transport.Packager, error)
 type Packager struct {
 	primaryPacker packer.Packer
 	packers       map[string]packer.Packer
+	replayStore   ReplayStore
 }
 
 // PackerCreator holds a creator function for a Packer and the name of the Packer's encoding method.
@@ -47,12 +65,16 @@ type PackerCreator struct {
 }
 
 // New return new instance of Packager implementation of transport.Packager.
-func New(ctx Provider) (*Packager, error) {
+func New(ctx Provider, opts ...Option) (*Packager, error) {
 	basePackager := Packager{
 		primaryPacker: nil,
 		packers:       map[string]packer.Packer{},
 	}
 
+	for _, opt := range opts {
+		opt(&basePackager)
+	}
+
 	for _, packerType := range ctx.Packers() {
 		basePackager.addPacker(packerType)
 	}
@@ -112,8 +134,8 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 
 	// TODO find a way to dynamically select a packer based on FromKey, recipients and their types.
 	//      https://github.com/hyperledger/aries-framework-go/issues/1112 Configurable packing
-	//      Use transport.Envelope.MediaTypeProfile for this.
-	bytes, err := bp.primaryPacker.Pack(cty, messageEnvelope.Message, messageEnvelope.FromKey, recipients)
+	bytes, err := bp.selectPacker(messageEnvelope.MediaTypeProfile).Pack(cty, messageEnvelope.Message,
+		messageEnvelope.FromKey, recipients)
 	if err != nil {
 		return nil, fmt.Errorf("packMessage: failed to pack: %w", err)
 	}
@@ -121,6 +143,40 @@ func (bp *Packager) PackMessage(messageEnvelope *transport.Envelope) ([]byte, er
 	return bytes, nil
 }
 
+// selectPacker returns the packer building envelopes of the format required by mediaTypeProfile. The primary
+// packer is preferred when it builds the required format, and is the fallback when the profile is unknown or no
+// registered packer builds the required format.
+func (bp *Packager) selectPacker(mediaTypeProfile string) packer.Packer {
+	encType, ok := envelopeEncodingTypes[mediaTypeProfile]
+	if !ok || bp.primaryPacker.EncodingType() == encType {
+		return bp.primaryPacker
+	}
+
+	// authcrypt is preferred over anoncrypt as it authenticates the sender.
+	for _, packerID := range []string{encType + authSuffix, encType} {
+		if p, found := bp.packers[packerID]; found {
+			return p
+		}
+	}
+
+	return bp.primaryPacker
+}
+
+// MediaTypeProfiles returns the media type profiles of the envelopes the registered packers build.
+func (bp *Packager) MediaTypeProfiles() []string {
+	var profiles []string
+
+	for _, mtp := range mediaTypeProfiles {
+		encType := envelopeEncodingTypes[mtp]
+
+		if bp.packers[encType] != nil || bp.packers[encType+authSuffix] != nil {
+			profiles = append(profiles, mtp)
+		}
+	}
+
+	return profiles
+}
+
 type envelopeStub struct {
 	Protected string `json:"protected,omitempty"`
 }
@@ -131,12 +187,29 @@ type headerStub struct {
 }
 
 func getEncodingType(encMessage []byte) (string, error) {
+	prot, err := getProtectedHeader(encMessage)
+	if err != nil {
+		return "", err
+	}
+
+	packerID := prot.Type
+
+	if prot.SKID != "" {
+		// since Type protected header is the same for authcrypt and anoncrypt, the differentiating factor is SKID.
+		// If it is present, then it's authcrypt.
+		packerID += authSuffix
+	}
+
+	return packerID, nil
+}
+
+func getProtectedHeader(encMessage []byte) (*headerStub, error) {
 	env := &envelopeStub{}
 
 	if strings.HasPrefix(string(encMessage), "{") { // full serialized
 		err := json.Unmarshal(encMessage, env)
 		if err != nil {
-			return "", fmt.Errorf("parse envelope: %w", err)
+			return nil, fmt.Errorf("parse envelope: %w", err)
 		}
 	} else { // compact serialized
 		env.Protected = strings.Split(string(encMessage), ".")[0]
@@ -153,25 +226,17 @@ func getEncodingType(encMessage []byte) (string, error) {
 	case err2 == nil:
 		protBytes = protBytes2
 	default:
-		return "", fmt.Errorf("decode header: %w", err1)
+		return nil, fmt.Errorf("decode header: %w", err1)
 	}
 
 	prot := &headerStub{}
 
 	err := json.Unmarshal(protBytes, prot)
 	if err != nil {
-		return "", fmt.Errorf("parse header: %w", err)
-	}
-
-	packerID := prot.Type
-
-	if prot.SKID != "" {
-		// since Type protected header is the same for authcrypt and anoncrypt, the differentiating factor is SKID.
-		// If it is present, then it's authcrypt.
-		packerID += authSuffix
+		return nil, fmt.Errorf("parse header: %w", err)
 	}
 
-	return packerID, nil
+	return prot, nil
 }
 
 // UnpackMessage Unpack a message.
@@ -191,5 +256,32 @@ func (bp *Packager) UnpackMessage(encMessage []byte) (*transport.Envelope, error
 		return nil, fmt.Errorf("unpack: %w", err)
 	}
 
+	if err = bp.checkReplay(encMessage, envelope); err != nil {
+		return nil, fmt.Errorf("unpack: %w", err)
+	}
+
 	return envelope, nil
 }
+
+// checkReplay records the unpacked envelope in the replay store, if any, and fails if it is replayed.
+func (bp *Packager) checkReplay(encMessage []byte, envelope *transport.Envelope) error {
+	if bp.replayStore == nil {
+		return nil
+	}
+
+	key := replayKey(encMessage, envelope)
+	if key == "" {
+		return nil
+	}
+
+	replayed, err := bp.replayStore.CheckAndRecord(key)
+	if err != nil {
+		return fmt.Errorf("replay store: %w", err)
+	}
+
+	if replayed {
+		return ErrReplayedMessage
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package packager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+)
+
+// compactJWEParts is the number of parts of a compact serialized JWE, the third one is its IV.
+const compactJWEParts = 5
+
+// ErrReplayedMessage is returned by UnpackMessage for an envelope which has already been unpacked.
+var ErrReplayedMessage = errors.New("replayed message")
+
+// ReplayStore records the envelopes unpacked by the packager to detect their replay.
+type ReplayStore interface {
+	// CheckAndRecord records the key of an unpacked envelope and reports whether the key has already been
+	// recorded (i.e. the envelope is replayed).
+	CheckAndRecord(key string) (bool, error)
+}
+
+// Option configures the packager.
+type Option func(*Packager)
+
+// WithReplayStore enables the replay protection of the unpacked envelopes: the envelopes with the same message ID
+// (or the same nonce if the message has no ID) from the same sender to the same recipient are rejected with
+// ErrReplayedMessage once recorded in the store. Anoncrypt envelopes have no sender, the ones with the same nonce
+// are rejected. There is no replay protection by default.
+func WithReplayStore(store ReplayStore) Option {
+	return func(p *Packager) {
+		p.replayStore = store
+	}
+}
+
+// DefaultMaxReplayKeys is the default maximum number of keys recorded by a MemReplayStore.
+const DefaultMaxReplayKeys = 100000
+
+// MemReplayStore is an in-memory ReplayStore keeping the recorded keys within a sliding time window.
+type MemReplayStore struct {
+	window  time.Duration
+	maxKeys int
+
+	mu    sync.Mutex
+	seen  map[string]time.Time
+	order []string // keys in the order they are recorded, to forget the oldest ones first
+}
+
+// MemReplayStoreOption configures a MemReplayStore.
+type MemReplayStoreOption func(*MemReplayStore)
+
+// WithMaxReplayKeys sets the maximum number of keys recorded by a MemReplayStore (DefaultMaxReplayKeys by default).
+// Once reached, the oldest keys are forgotten before the end of the window to record the new ones.
+func WithMaxReplayKeys(maxKeys int) MemReplayStoreOption {
+	return func(s *MemReplayStore) {
+		s.maxKeys = maxKeys
+	}
+}
+
+// NewMemReplayStore returns an in-memory ReplayStore detecting the replays within the given window,
+// the keys recorded before are forgotten.
+func NewMemReplayStore(window time.Duration, opts ...MemReplayStoreOption) *MemReplayStore {
+	s := &MemReplayStore{
+		window:  window,
+		maxKeys: DefaultMaxReplayKeys,
+		seen:    make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CheckAndRecord records the key and reports whether it has been recorded within the window.
+func (s *MemReplayStore) CheckAndRecord(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	for len(s.order) > 0 && now.Sub(s.seen[s.order[0]]) >= s.window {
+		s.forgetOldest()
+	}
+
+	if _, ok := s.seen[key]; ok {
+		return true, nil
+	}
+
+	for len(s.order) > 0 && len(s.order) >= s.maxKeys {
+		s.forgetOldest()
+	}
+
+	s.seen[key] = now
+	s.order = append(s.order, key)
+
+	return false, nil
+}
+
+func (s *MemReplayStore) forgetOldest() {
+	delete(s.seen, s.order[0])
+	s.order = s.order[1:]
+}
+
+type replayStub struct {
+	ID   string `json:"id,omitempty"`
+	V1ID string `json:"@id,omitempty"`
+	IV   string `json:"iv,omitempty"`
+}
+
+// replayKey returns the replay protection key of the unpacked envelope: the sender and recipient keys along with
+// either the ID of the message or the nonce of the envelope. It is empty if the envelope has neither of them.
+// Anoncrypt envelopes have no sender, so anyone can send a message with a given ID: they are keyed by their nonce
+// first, so that the messages of a sender cannot be rejected by another one reusing their IDs.
+func replayKey(encMessage []byte, envelope *transport.Envelope) string {
+	sender := replaySender(encMessage, envelope)
+	namespace := sender + ":" + base64.RawURLEncoding.EncodeToString(envelope.ToKey)
+
+	anoncrypt := sender == ""
+
+	if !anoncrypt {
+		if id := messageID(envelope.Message); id != "" {
+			return "id:" + namespace + ":" + id
+		}
+	}
+
+	if iv := envelopeIV(encMessage); iv != "" {
+		return "iv:" + namespace + ":" + iv
+	}
+
+	if anoncrypt {
+		if id := messageID(envelope.Message); id != "" {
+			return "id:" + namespace + ":" + id
+		}
+	}
+
+	return ""
+}
+
+// replaySender returns the authenticated sender of the envelope: its sender key, or the sender key ID (skid header)
+// of DIDComm V2 authcrypt envelopes. It is empty for anoncrypt envelopes.
+func replaySender(encMessage []byte, envelope *transport.Envelope) string {
+	if len(envelope.FromKey) > 0 {
+		return "key=" + base64.RawURLEncoding.EncodeToString(envelope.FromKey)
+	}
+
+	if prot, err := getProtectedHeader(encMessage); err == nil && prot.SKID != "" {
+		return "kid=" + prot.SKID
+	}
+
+	return ""
+}
+
+// messageID returns the ID of the DIDComm V1 or V2 message, if any.
+func messageID(message []byte) string {
+	msg := &replayStub{}
+
+	if json.Unmarshal(message, msg) != nil {
+		return ""
+	}
+
+	if msg.V1ID != "" {
+		return msg.V1ID
+	}
+
+	return msg.ID
+}
+
+// envelopeIV returns the IV of the full or compact serialized JWE envelope, if any.
+func envelopeIV(encMessage []byte) string {
+	env := &replayStub{}
+
+	if strings.HasPrefix(string(encMessage), "{") { // full serialized
+		if json.Unmarshal(encMessage, env) != nil {
+			return ""
+		}
+	} else if parts := strings.Split(string(encMessage), "."); len(parts) == compactJWEParts { // compact serialized
+		env.IV = parts[2]
+	}
+
+	return env.IV
+}
@@ -39,11 +39,23 @@ type Packer struct {
 	kms           kms.KeyManager
 	encAlg        jose.EncAlg
 	cryptoService cryptoapi.Crypto
+	serialization packer.JWESerialization
+}
+
+// Opt is an option of the Anoncrypt Packer.
+type Opt func(p *Packer)
+
+// WithJWESerialization sets the serialization syntax of the packed JWE envelopes. Defaults to
+// packer.DefaultJWESerialization.
+func WithJWESerialization(serialization packer.JWESerialization) Opt {
+	return func(p *Packer) {
+		p.serialization = serialization
+	}
 }
 
 // New will create an Packer instance to 'AnonCrypt' payloads for a given list of recipients.
 // The returned Packer contains all the information required to pack and unpack payloads.
-func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
+func New(ctx packer.Provider, encAlg jose.EncAlg, opts ...Opt) (*Packer, error) {
 	k := ctx.KMS()
 	if k == nil {
 		return nil, errors.New("anoncrypt: failed to create packer because KMS is empty")
@@ -54,11 +66,17 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		return nil, errors.New("anoncrypt: failed to create packer because crypto service is empty")
 	}
 
-	return &Packer{
+	p := &Packer{
 		kms:           k,
 		encAlg:        encAlg,
 		cryptoService: c,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
 }
 
 // Pack will encode the payload argument using the protocol defined by the Anoncrypt message of Aries RFC 0334.
@@ -92,14 +110,7 @@ func (p *Packer) Pack(contentType string, payload, _ []byte, recipientsPubKeys [
 
 	logger.Debugf("protected headers: %s", mPh)
 
-	var s string
-
-	if len(recipientsPubKeys) == 1 {
-		s, err = jwe.CompactSerialize(json.Marshal)
-	} else {
-		s, err = jwe.FullSerialize(json.Marshal)
-	}
-
+	s, err := packer.SerializeJWE(jwe, p.serialization)
 	if err != nil {
 		return nil, fmt.Errorf("anoncrypt Pack: failed to serialize JWE message: %w", err)
 	}
@@ -30,6 +30,7 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	afgjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -74,6 +75,24 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 			encAlg:  afgjose.A256GCM,
 			cty:     transport.MediaTypeV1PlaintextPayload,
 		},
+		{
+			name:    "anoncrypt using NISTP256ECDHKW and AES128-GCM",
+			keyType: kms.NISTP256ECDHKWType,
+			encAlg:  afgjose.A128GCM,
+			cty:     transport.MediaTypeV1PlaintextPayload,
+		},
+		{
+			name:    "anoncrypt using NISTP521ECDHKW and AES128-GCM",
+			keyType: kms.NISTP521ECDHKWType,
+			encAlg:  afgjose.A128GCM,
+			cty:     transport.MediaTypeV1PlaintextPayload,
+		},
+		{
+			name:    "anoncrypt using X25519ECDHKWType and AES128-GCM",
+			keyType: kms.X25519ECDHKWType,
+			encAlg:  afgjose.A128GCM,
+			cty:     transport.MediaTypeV1PlaintextPayload,
+		},
 		{
 			name:    "anoncrypt using NISTP256ECDHKW and XChacha20Poly1305",
 			keyType: kms.NISTP256ECDHKW,
@@ -161,6 +180,10 @@ func TestAnoncryptPackerSuccess(t *testing.T) {
 
 			verifyJWETypes(t, tc.cty, jweJSON.ProtectedHeaders)
 
+			encAlg, ok := jweJSON.ProtectedHeaders.Encryption()
+			require.True(t, ok)
+			require.Equal(t, string(tc.encAlg), encAlg)
+
 			// try with only 1 recipient
 			ct, err = anonPacker.Pack(tc.cty, origMsg, nil, [][]byte{recipientsKeys[0]})
 			require.NoError(t, err)
@@ -257,6 +280,64 @@ func TestAnoncryptPackerSuccessWithDifferentCurvesSuccess(t *testing.T) {
 	}, msg)
 }
 
+func TestAnoncryptPackerJWESerialization(t *testing.T) {
+	k := createKMS(t)
+	_, recipientsKeys, keyHandles := createRecipients(t, k, 2)
+
+	cty := transport.MediaTypeV1PlaintextPayload
+	origMsg := []byte("secret message")
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	recKey, err := exportPubKeyBytes(keyHandles[0])
+	require.NoError(t, err)
+
+	t.Run("flattened JSON serialization for a single recipient", func(t *testing.T) {
+		anonPacker, err := New(newMockProvider(k, cryptoSvc), afgjose.A256GCM,
+			WithJWESerialization(packer.FlattenedJWESerialization))
+		require.NoError(t, err)
+
+		ct, err := anonPacker.Pack(cty, origMsg, nil, [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+
+		rawJWE := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(ct, &rawJWE))
+		require.Contains(t, rawJWE, "encrypted_key")
+		require.NotContains(t, rawJWE, "recipients")
+
+		msg, err := anonPacker.Unpack(ct)
+		require.NoError(t, err)
+		require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+
+		// general JSON serialization is still used for multiple recipients
+		ct, err = anonPacker.Pack(cty, origMsg, nil, recipientsKeys)
+		require.NoError(t, err)
+
+		rawJWE = map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(ct, &rawJWE))
+		require.Contains(t, rawJWE, "recipients")
+	})
+
+	t.Run("compact serialization", func(t *testing.T) {
+		anonPacker, err := New(newMockProvider(k, cryptoSvc), afgjose.A256GCM,
+			WithJWESerialization(packer.CompactJWESerialization))
+		require.NoError(t, err)
+
+		ct, err := anonPacker.Pack(cty, origMsg, nil, [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+		require.Len(t, strings.Split(string(ct), "."), 5)
+
+		msg, err := anonPacker.Unpack(ct)
+		require.NoError(t, err)
+		require.EqualValues(t, &transport.Envelope{Message: origMsg, ToKey: recKey}, msg)
+
+		_, err = anonPacker.Pack(cty, origMsg, nil, recipientsKeys)
+		require.EqualError(t, err, "anoncrypt Pack: failed to serialize JWE message: unable to compact serialize: "+
+			"JWE compact serialization only supports JWE with exactly one single recipient")
+	})
+}
+
 func TestAnoncryptPackerFail(t *testing.T) {
 	cty := transport.MediaTypeV1PlaintextPayload
 
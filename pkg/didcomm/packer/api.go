@@ -7,8 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package packer
 
 import (
+	"encoding/json"
+	"fmt"
+
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -29,6 +33,39 @@ const (
 	ContentEncodingTypeV2 = "application/didcomm-plain+json"
 )
 
+// JWESerialization is the serialization syntax of the JWE envelopes built by JWE packers (authcrypt and anoncrypt).
+type JWESerialization int
+
+const (
+	// DefaultJWESerialization uses the compact serialization for a single recipient and the general JSON
+	// serialization for multiple recipients.
+	DefaultJWESerialization JWESerialization = iota
+	// FlattenedJWESerialization uses the flattened JSON serialization for a single recipient and the general JSON
+	// serialization for multiple recipients.
+	FlattenedJWESerialization
+	// CompactJWESerialization always uses the compact serialization, packing for multiple recipients fails.
+	CompactJWESerialization
+)
+
+// SerializeJWE serializes jwe using the given serialization syntax.
+func SerializeJWE(jwe *jose.JSONWebEncryption, serialization JWESerialization) (string, error) {
+	switch serialization {
+	case DefaultJWESerialization:
+		if len(jwe.Recipients) == 1 {
+			return jwe.CompactSerialize(json.Marshal)
+		}
+
+		return jwe.FullSerialize(json.Marshal)
+	case FlattenedJWESerialization:
+		// FullSerialize uses the flattened syntax when there is only one recipient.
+		return jwe.FullSerialize(json.Marshal)
+	case CompactJWESerialization:
+		return jwe.CompactSerialize(json.Marshal)
+	default:
+		return "", fmt.Errorf("unsupported JWE serialization: %d", serialization)
+	}
+}
+
 // Provider interface for Packer ctx.
 type Provider interface {
 	KMS() kms.KeyManager
@@ -47,6 +47,18 @@ type Packer struct {
 	encAlg        jose.EncAlg
 	thirdPartyKS  storage.Store
 	cryptoService cryptoapi.Crypto
+	serialization packer.JWESerialization
+}
+
+// Opt is an option of the Authcrypt Packer.
+type Opt func(p *Packer)
+
+// WithJWESerialization sets the serialization syntax of the packed JWE envelopes. Defaults to
+// packer.DefaultJWESerialization.
+func WithJWESerialization(serialization packer.JWESerialization) Opt {
+	return func(p *Packer) {
+		p.serialization = serialization
+	}
 }
 
 // New will create a Packer instance to 'AuthCrypt' payloads for a given sender and list of recipients keys using
@@ -55,7 +67,7 @@ type Packer struct {
 // pre-populated with the sender key required by a recipient to Unpack a JWE envelope. It is not needed by the sender
 // (as the sender packs the envelope with its own key).
 // The returned Packer contains all the information required to pack and unpack payloads.
-func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
+func New(ctx packer.Provider, encAlg jose.EncAlg, opts ...Opt) (*Packer, error) {
 	err := validateEncAlg(encAlg)
 	if err != nil {
 		return nil, fmt.Errorf("authcrypt: %w", err)
@@ -86,12 +98,18 @@ func New(ctx packer.Provider, encAlg jose.EncAlg) (*Packer, error) {
 		return nil, fmt.Errorf("authcrypt: failed to wrap key store: %w", err)
 	}
 
-	return &Packer{
+	p := &Packer{
 		kms:           k,
 		encAlg:        encAlg,
 		thirdPartyKS:  store,
 		cryptoService: c,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
 }
 
 func validateEncAlg(alg jose.EncAlg) error {
@@ -143,14 +161,7 @@ func (p *Packer) Pack(contentType string, payload, senderID []byte, recipientsPu
 
 	logger.Debugf("protected headers: %s", mPh)
 
-	var s string
-
-	if len(recipientsPubKeys) == 1 {
-		s, err = jwe.CompactSerialize(json.Marshal)
-	} else {
-		s, err = jwe.FullSerialize(json.Marshal)
-	}
-
+	s, err := packer.SerializeJWE(jwe, p.serialization)
 	if err != nil {
 		return nil, fmt.Errorf("authcrypt Pack: failed to serialize JWE message: %w", err)
 	}
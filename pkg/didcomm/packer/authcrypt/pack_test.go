@@ -30,6 +30,7 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	afgjose "github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -282,6 +283,62 @@ func TestAuthcryptPackerUsingKeysWithDifferentCurvesSuccess(t *testing.T) {
 	verifyJWETypes(t, cty, jweJSON.ProtectedHeaders)
 }
 
+func TestAuthcryptPackerJWESerialization(t *testing.T) {
+	k := createKMS(t)
+	_, recipientsKeys, keyHandles := createRecipients(t, k, 2)
+
+	cty := transport.MediaTypeV1PlaintextPayload
+	origMsg := []byte("secret message")
+
+	skid, senderKey, _ := createAndMarshalKey(t, k)
+
+	thirdPartyKeyStore := make(map[string]mockstorage.DBEntry)
+	mockStoreProvider := &mockstorage.MockStoreProvider{Store: &mockstorage.MockStore{
+		Store: thirdPartyKeyStore,
+	}}
+
+	thirdPartyKeyStore[prefix.StorageKIDPrefix+skid] = mockstorage.DBEntry{Value: senderKey}
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	recKey, err := exportPubKeyBytes(keyHandles[0])
+	require.NoError(t, err)
+
+	t.Run("flattened JSON serialization for a single recipient", func(t *testing.T) {
+		authPacker, err := New(newMockProvider(mockStoreProvider, k, cryptoSvc), afgjose.A256CBCHS512,
+			WithJWESerialization(packer.FlattenedJWESerialization))
+		require.NoError(t, err)
+
+		ct, err := authPacker.Pack(cty, origMsg, []byte(skid), [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+
+		rawJWE := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(ct, &rawJWE))
+		require.Contains(t, rawJWE, "encrypted_key")
+		require.NotContains(t, rawJWE, "recipients")
+
+		msg, err := authPacker.Unpack(ct)
+		require.NoError(t, err)
+		require.Equal(t, origMsg, msg.Message)
+		require.Equal(t, recKey, msg.ToKey)
+	})
+
+	t.Run("compact serialization", func(t *testing.T) {
+		authPacker, err := New(newMockProvider(mockStoreProvider, k, cryptoSvc), afgjose.A256CBCHS512,
+			WithJWESerialization(packer.CompactJWESerialization))
+		require.NoError(t, err)
+
+		ct, err := authPacker.Pack(cty, origMsg, []byte(skid), [][]byte{recipientsKeys[0]})
+		require.NoError(t, err)
+		require.Len(t, strings.Split(string(ct), "."), 5)
+
+		_, err = authPacker.Pack(cty, origMsg, []byte(skid), recipientsKeys)
+		require.EqualError(t, err, "authcrypt Pack: failed to serialize JWE message: unable to compact serialize: "+
+			"JWE compact serialization only supports JWE with exactly one single recipient")
+	})
+}
+
 func TestAuthcryptPackerFail(t *testing.T) {
 	cty := transport.MediaTypeV1PlaintextPayload
 
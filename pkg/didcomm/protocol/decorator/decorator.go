@@ -39,9 +39,56 @@ type Thread struct {
 	ReceivedOrders map[string]int `json:"received_orders,omitempty"`
 }
 
-// Timing keeps expiration time.
+// TimingPropKey is the DIDCommContext property holding the *Timing of an inbound message, if any.
+const TimingPropKey = "~timing"
+
+// Timing timing decorator (~timing)
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0032-message-timing
 type Timing struct {
+	// ExpiresTime is when the message expires. Messages received after it are rejected.
 	ExpiresTime time.Time `json:"expires_time,omitempty"`
+	// OutTime is when the message was sent.
+	OutTime *time.Time `json:"out_time,omitempty"`
+	// StaleTime is when the message becomes less relevant to the sender, without expiring.
+	StaleTime *time.Time `json:"stale_time,omitempty"`
+}
+
+// Expired returns true if the expires_time is set and is before now.
+func (t *Timing) Expired(now time.Time) bool {
+	return t != nil && !t.ExpiresTime.IsZero() && t.ExpiresTime.Before(now)
+}
+
+const (
+	// AckOnReceipt requests an ack as soon as the message is received.
+	AckOnReceipt = "RECEIPT"
+	// AckOnOutcome requests an ack once the message has been processed by the application.
+	AckOnOutcome = "OUTCOME"
+)
+
+// PleaseAck please ack decorator (~please_ack)
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0317-please-ack
+type PleaseAck struct {
+	// On lists when acks are requested: AckOnReceipt and/or AckOnOutcome. RECEIPT is implied if empty.
+	On []string `json:"on,omitempty"`
+}
+
+// Requests returns true if an ack is requested on the given event (AckOnReceipt or AckOnOutcome).
+func (p *PleaseAck) Requests(on string) bool {
+	if p == nil {
+		return false
+	}
+
+	if len(p.On) == 0 {
+		return on == AckOnReceipt
+	}
+
+	for _, v := range p.On {
+		if v == on {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Transport transport decorator
@@ -55,6 +102,14 @@ type ReturnRoute struct {
 	Value string `json:"~return_route,omitempty"`
 }
 
+// Service service decorator (~service), the route to reply to a message sent without a connection
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0056-service-decorator
+type Service struct {
+	RecipientKeys   []string `json:"recipientKeys"`
+	RoutingKeys     []string `json:"routingKeys,omitempty"`
+	ServiceEndpoint string   `json:"serviceEndpoint"`
+}
+
 // Attachment is intended to provide the possibility to include files, links or even JSON payload to the message.
 // To find out more please visit https://github.com/hyperledger/aries-rfcs/tree/master/concepts/0017-attachments
 type Attachment struct {
@@ -82,6 +137,27 @@ type Attachment struct {
 	Data AttachmentData `json:"data,omitempty"`
 }
 
+// AttachmentV2 is the DIDComm V2 attachment, which differs from Attachment in its JSON field names.
+// To find out more please visit https://identity.foundation/didcomm-messaging/spec/#attachments
+type AttachmentV2 struct {
+	// ID uniquely identifies attached content within the scope of a given message.
+	ID string `json:"id,omitempty"`
+	// Description is an optional human-readable description of the content.
+	Description string `json:"description,omitempty"`
+	// FileName is a hint about the name that might be used if this attachment is persisted as a file.
+	FileName string `json:"filename,omitempty"`
+	// MediaType describes the media type of the attached content. Optional but recommended.
+	MediaType string `json:"media_type,omitempty"`
+	// Format describes the format of the attachment if the media_type is not sufficient.
+	Format string `json:"format,omitempty"`
+	// LastModTime is a hint about when the content in this attachment was last modified.
+	LastModTime time.Time `json:"lastmod_time,omitempty"`
+	// ByteCount is an optional, and mostly relevant when content is included by reference instead of by value.
+	ByteCount int64 `json:"byte_count,omitempty"`
+	// Data is a JSON object that gives access to the actual content of the attachment.
+	Data AttachmentData `json:"data,omitempty"`
+}
+
 // AttachmentData contains attachment payload.
 type AttachmentData struct {
 	// Sha256 is a hash of the content. Optional. Used as an integrity check if content is inlined.
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 
 package didexchange
 
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+
 // Event properties related api. This can be used to cast Generic event properties to DID Exchange specific props.
 type Event interface {
 	// connection ID
@@ -62,3 +64,25 @@ func (ex *didExchangeEventError) All() map[string]interface{} {
 		"error":        ex.Error(),
 	}
 }
+
+// didExchangeProblemReportEvent for sending events when the other party abandoned the exchange with a problem report.
+type didExchangeProblemReportEvent struct {
+	didExchangeEvent
+	problemReport *model.ProblemReportDetails
+}
+
+// ProblemReport returns the details of the received problem report.
+func (ex *didExchangeProblemReportEvent) ProblemReport() *model.ProblemReportDetails {
+	return ex.problemReport
+}
+
+// All implements EventProperties interface.
+func (ex *didExchangeProblemReportEvent) All() map[string]interface{} {
+	props := ex.didExchangeEvent.All()
+
+	for k, v := range ex.problemReport.Properties() {
+		props[k] = v
+	}
+
+	return props
+}
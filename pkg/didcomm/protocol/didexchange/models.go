@@ -33,6 +33,9 @@ type OOBInvitation struct {
 	// MediaTypeProfiles are the message format profiles supported by the sender of this invitation
 	// as defined in RFC 0044.
 	MediaTypeProfiles []string
+	// Transient connections are kept in memory until their time to live elapses, they are never written to the
+	// permanent connection store.
+	Transient bool
 }
 
 // Invitation model
@@ -12,10 +12,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
@@ -27,6 +29,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -47,6 +50,11 @@ const (
 	AckMsgType = PIURI + "/ack"
 	// CompleteMsgType defines the did-exchange complete message type.
 	CompleteMsgType = PIURI + "/complete"
+	// ProblemReportMsgType defines the did-exchange problem report message type.
+	ProblemReportMsgType = PIURI + "/problem_report"
+	// SenderKeyPropKey is the DIDCommContext property holding the base58 encoded sender key of inbound problem
+	// reports, which must be a key of the counterparty of the exchange.
+	SenderKeyPropKey = "senderKey"
 	// oobMsgType is the internal message type for the oob invitation that the didexchange service receives.
 	oobMsgType             = "oob-invitation"
 	routerConnsMetadataKey = "routerConnections"
@@ -135,6 +143,11 @@ func New(prov provider) (*Service, error) {
 		return nil, fmt.Errorf("failed to initialize connection recorder: %w", err)
 	}
 
+	// resume the exchanges which were in progress when the agent stopped
+	if err = connRecorder.RestoreInFlightConnections(); err != nil {
+		return nil, fmt.Errorf("failed to restore in-flight connections: %w", err)
+	}
+
 	s, err := prov.Service(mediator.Coordination)
 	if err != nil {
 		return nil, err
@@ -200,6 +213,10 @@ func retrievingRouterConnections(msg service.DIDCommMsg) []string {
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("receive inbound message : %s", msg)
 
+	if msg.Type() == ProblemReportMsgType {
+		return s.handleProblemReport(msg, ctx)
+	}
+
 	// fetch the thread id
 	thID, err := msg.ThreadID()
 	if err != nil {
@@ -270,7 +287,8 @@ func (s *Service) Accept(msgType string) bool {
 		msgType == RequestMsgType ||
 		msgType == ResponseMsgType ||
 		msgType == AckMsgType ||
-		msgType == CompleteMsgType
+		msgType == CompleteMsgType ||
+		msgType == ProblemReportMsgType
 }
 
 // HandleOutbound handles outbound didexchange messages.
@@ -419,6 +437,16 @@ func createErrorEventProperties(connectionID, invitationID string, err error) *d
 	}
 }
 
+func createProblemReportEventProperties(connectionID, invitationID string,
+	report *model.ProblemReportDetails) *didExchangeProblemReportEvent {
+	props := createEventProperties(connectionID, invitationID)
+
+	return &didExchangeProblemReportEvent{
+		problemReport:    report,
+		didExchangeEvent: *props,
+	}
+}
+
 // sendActionEvent triggers the action event. This function stores the state of current processing and passes a callback
 // function in the event message.
 func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DIDCommAction) error {
@@ -464,11 +492,9 @@ func (s *Service) sendActionEvent(internalMsg *message, aEvent chan<- service.DI
 // sendEvent triggers the message events.
 func (s *Service) sendMsgEvents(msg *service.StateMsg) {
 	// trigger the message events
-	for _, handler := range s.MsgEvents() {
-		handler <- *msg
+	s.PublishMsgEvent(*msg)
 
-		logger.Debugf("sent msg event to handler: %+v", msg)
-	}
+	logger.Debugf("sent msg event to handlers: %+v", msg)
 }
 
 // startInternalListener listens to messages in gochannel for callback messages from clients.
@@ -605,6 +631,98 @@ func (s *Service) abandon(thID string, msg service.DIDCommMsg, processErr error)
 	return nil
 }
 
+// handleProblemReport abandons the exchange the problem report refers to and triggers an abandoned event
+// carrying the problem code and comment.
+func (s *Service) handleProblemReport(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	report, err := model.ParseProblemReport(msg)
+	if err != nil {
+		return "", fmt.Errorf("handle problem report: %w", err)
+	}
+
+	connRec, err := s.problemReportConnectionRecord(report.ThreadID)
+	if err != nil {
+		return "", fmt.Errorf("handle problem report: %w", err)
+	}
+
+	if !isCounterparty(connRec, ctx) {
+		return "", fmt.Errorf("handle problem report: sender is not the counterparty of connection %s",
+			connRec.ConnectionID)
+	}
+
+	if connRec.State == StateIDCompleted {
+		return "", fmt.Errorf("handle problem report: connection %s is already completed", connRec.ConnectionID)
+	}
+
+	connRec.State = StateIDAbandoned
+
+	err = s.connectionRecorder.SaveConnectionRecord(connRec)
+	if err != nil {
+		return "", fmt.Errorf("handle problem report: unable to update the state to abandoned: %w", err)
+	}
+
+	s.sendMsgEvents(&service.StateMsg{
+		ProtocolName: DIDExchange,
+		Type:         service.PostState,
+		Msg:          msg.Clone(),
+		StateID:      StateIDAbandoned,
+		Properties:   createProblemReportEventProperties(connRec.ConnectionID, connRec.InvitationID, report),
+	})
+
+	return connRec.ConnectionID, nil
+}
+
+// problemReportConnectionRecord returns the record of the exchange with the given thread ID, whichever party
+// started it.
+func (s *Service) problemReportConnectionRecord(thID string) (*connection.Record, error) {
+	for _, nsPrefix := range []string{myNSPrefix, theirNSPrefix} {
+		nsThID, err := connection.CreateNamespaceKey(nsPrefix, thID)
+		if err != nil {
+			return nil, err
+		}
+
+		connRec, err := s.connectionRecorder.GetConnectionRecordByNSThreadID(nsThID)
+		if err == nil {
+			return connRec, nil
+		}
+
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("no exchange found for thread ID %s: %w", thID, storage.ErrDataNotFound)
+}
+
+// isCounterparty checks whether the message of ctx was sent by the counterparty of the exchange: by their DID once
+// known, or else with one of the recipient keys of their invitation.
+func isCounterparty(connRec *connection.Record, ctx service.DIDCommContext) bool {
+	if connRec.TheirDID != "" && ctx.TheirDID() == connRec.TheirDID {
+		return true
+	}
+
+	senderKey, ok := ctx.All()[SenderKeyPropKey].(string)
+	if !ok || senderKey == "" {
+		return false
+	}
+
+	for _, key := range connRec.RecipientKeys {
+		if strings.HasPrefix(key, "did:key:") {
+			pubKey, err := fingerprint.PubKeyFromDIDKey(key)
+			if err != nil {
+				continue
+			}
+
+			key = base58.Encode(pubKey)
+		}
+
+		if key == senderKey {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Service) processCallback(msg *message) {
 	// pass the callback data to internal channel. This is created to unblock consumer go routine and wrap the callback
 	// channel internally.
@@ -713,6 +831,7 @@ func (s *Service) oobInvitationMsgRecord(msg service.DIDCommMsg) (*connection.Re
 		TheirLabel:        oobInvitation.TheirLabel,
 		Namespace:         findNamespace(msg.Type()),
 		MediaTypeProfiles: svc.Accept,
+		Transient:         oobInvitation.Transient,
 	}
 
 	publicDID, ok := oobInvitation.Target.(string)
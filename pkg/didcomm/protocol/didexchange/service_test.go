@@ -8,6 +8,7 @@ package didexchange
 
 import (
 	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -30,6 +31,7 @@ import (
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockroute "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/mediator"
 	mockdiddoc "github.com/hyperledger/aries-framework-go/pkg/mock/diddoc"
@@ -40,6 +42,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	didstore "github.com/hyperledger/aries-framework-go/pkg/store/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -218,6 +221,132 @@ func TestService_Handle_Inviter(t *testing.T) {
 	validateState(t, s, thid, findNamespace(AckMsgType), (&completed{}).Name())
 }
 
+// did-exchange flow with role Inviter, interrupted by a restart of the agent.
+func TestService_Handle_InviterAfterRestart(t *testing.T) {
+	mockStore := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+	storeProv := mockstorage.NewCustomMockStoreProvider(mockStore)
+	k := newKMS(t, storeProv)
+
+	// the permanent store survives the restart, the protocol state store does not
+	newProvider := func() *protocol.MockProvider {
+		return &protocol.MockProvider{
+			StoreProvider:              storeProv,
+			ProtocolStateStoreProvider: mockstorage.NewMockStoreProvider(),
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: &mockroute.MockMediatorSvc{},
+			},
+			CustomKMS:             k,
+			KeyTypeValue:          kms.ED25519Type,
+			KeyAgreementTypeValue: kms.X25519ECDHKWType,
+		}
+	}
+
+	prov := newProvider()
+
+	ctx := &context{
+		outboundDispatcher: prov.OutboundDispatcher(),
+		crypto:             &tinkcrypto.Crypto{},
+		kms:                k,
+		keyType:            kms.ED25519Type,
+		keyAgreementType:   kms.X25519ECDHKWType,
+	}
+
+	verPubKey, encPubKey := newSigningAndEncryptionDIDKeys(t, ctx)
+
+	ctx.vdRegistry = &mockvdr.MockVDRegistry{CreateValue: createDIDDocWithKey(verPubKey, encPubKey)}
+
+	connRec, err := connection.NewRecorder(prov)
+	require.NoError(t, err)
+
+	doc, err := ctx.vdRegistry.Create(testMethod, nil)
+	require.NoError(t, err)
+
+	start := func(prov *protocol.MockProvider) (*Service, chan struct{}, chan struct{}) {
+		s, err := New(prov)
+		require.NoError(t, err)
+
+		actionCh := make(chan service.DIDCommAction, 10)
+		require.NoError(t, s.RegisterActionEvent(actionCh))
+
+		statusCh := make(chan service.StateMsg, 10)
+		require.NoError(t, s.RegisterMsgEvent(statusCh))
+
+		completedFlag := make(chan struct{})
+		respondedFlag := make(chan struct{})
+
+		go msgEventListener(t, statusCh, respondedFlag, completedFlag)
+
+		go func() { service.AutoExecuteActionEvent(actionCh) }()
+
+		return s, respondedFlag, completedFlag
+	}
+
+	s, respondedFlag, _ := start(prov)
+
+	invitation := &Invitation{
+		Type:            InvitationMsgType,
+		ID:              randomString(),
+		Label:           "Bob",
+		RecipientKeys:   []string{verPubKey},
+		ServiceEndpoint: "http://alice.agent.example.com:8081",
+	}
+
+	require.NoError(t, connRec.SaveInvitation(invitation.ID, invitation))
+
+	thid := randomString()
+
+	payloadBytes, err := json.Marshal(
+		&Request{
+			Type:  RequestMsgType,
+			ID:    thid,
+			Label: "Bob",
+			Thread: &decorator.Thread{
+				PID: invitation.ID,
+			},
+			DID:       doc.DIDDocument.ID,
+			DocAttach: unsignedDocAttach(t, doc.DIDDocument),
+		})
+	require.NoError(t, err)
+	msg, err := service.ParseDIDCommMsgMap(payloadBytes)
+	require.NoError(t, err)
+	_, err = s.HandleInbound(msg, service.NewDIDCommContext(doc.DIDDocument.ID, "", nil))
+	require.NoError(t, err)
+
+	select {
+	case <-respondedFlag:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event responded")
+	}
+
+	// Alice's agent restarts before Bob replies with an ACK
+	s, _, completedFlag := start(newProvider())
+
+	validateState(t, s, thid, findNamespace(AckMsgType), (&responded{}).Name())
+
+	payloadBytes, err = json.Marshal(
+		&model.Ack{
+			Type:   AckMsgType,
+			ID:     randomString(),
+			Status: "OK",
+			Thread: &decorator.Thread{ID: thid},
+		})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(payloadBytes)
+	require.NoError(t, err)
+
+	_, err = s.HandleInbound(didMsg, service.NewDIDCommContext(doc.DIDDocument.ID, "", nil))
+	require.NoError(t, err)
+
+	select {
+	case <-completedFlag:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "didn't receive post event complete")
+	}
+
+	validateState(t, s, thid, findNamespace(AckMsgType), (&completed{}).Name())
+}
+
 func msgEventListener(t *testing.T, statusCh chan service.StateMsg, respondedFlag, completedFlag chan struct{}) {
 	for e := range statusCh {
 		require.Equal(t, DIDExchange, e.ProtocolName)
@@ -548,6 +677,7 @@ func TestService_Accept(t *testing.T) {
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/response"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/ack"))
 	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/complete"))
+	require.Equal(t, true, s.Accept("https://didcomm.org/didexchange/1.0/problem_report"))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -823,8 +953,9 @@ func (m *mockStore) GetBulk(keys ...string) ([][]byte, error) {
 	panic("implement me")
 }
 
+// Query finds no records.
 func (m *mockStore) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
-	panic("implement me")
+	return mockstorage.NewMockStoreProvider().Store.Query(expression, options...)
 }
 
 // Delete the record based on key.
@@ -1034,6 +1165,140 @@ func TestEventsUserError(t *testing.T) {
 	}
 }
 
+func TestHandleProblemReport(t *testing.T) {
+	svc, err := New(&protocol.MockProvider{
+		ServiceMap: map[string]interface{}{
+			mediator.Coordination: &mockroute.MockMediatorSvc{},
+		},
+	})
+	require.NoError(t, err)
+
+	statusCh := make(chan service.StateMsg, 10)
+	err = svc.RegisterMsgEvent(statusCh)
+	require.NoError(t, err)
+
+	newProblemReport := func(thID string) service.DIDCommMsgMap {
+		msg := service.NewDIDCommMsgMap(&model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			ID:          randomString(),
+			Description: model.Code{Code: "request_not_accepted"},
+			Comment:     "unknown invitation",
+		})
+		msg["~thread"] = map[string]interface{}{"thid": thID}
+
+		return msg
+	}
+
+	const theirDID = "did:example:their"
+
+	t.Run("abandons the exchange mid-protocol", func(t *testing.T) {
+		thID := randomString()
+		connRec := &connection.Record{
+			ConnectionID: randomString(), ThreadID: thID, InvitationID: randomString(),
+			Namespace: myNSPrefix, State: StateIDRequested, TheirDID: theirDID,
+		}
+
+		require.NoError(t, svc.connectionRecorder.SaveConnectionRecordWithMappings(connRec))
+
+		connID, err := svc.HandleInbound(newProblemReport(thID), service.NewDIDCommContext("", theirDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, connRec.ConnectionID, connID)
+
+		validateState(t, svc, thID, myNSPrefix, StateIDAbandoned)
+
+		select {
+		case e := <-statusCh:
+			require.Equal(t, service.PostState, e.Type)
+			require.Equal(t, StateIDAbandoned, e.StateID)
+
+			props, ok := e.Properties.(*didExchangeProblemReportEvent)
+			require.True(t, ok)
+			require.Equal(t, connRec.ConnectionID, props.ConnectionID())
+			require.Equal(t, connRec.InvitationID, props.InvitationID())
+			require.Equal(t, &model.ProblemReportDetails{
+				Code:     "request_not_accepted",
+				Comment:  "unknown invitation",
+				ThreadID: thID,
+			}, props.ProblemReport())
+			require.Equal(t, "request_not_accepted", props.All()[model.ProblemCodePropKey])
+			require.Equal(t, "unknown invitation", props.All()[model.ProblemCommentPropKey])
+			require.Equal(t, thID, props.All()[model.ProblemThreadIDPropKey])
+		case <-time.After(time.Second):
+			require.Fail(t, "abandoned event not received")
+		}
+	})
+
+	t.Run("sent with an invitation recipient key", func(t *testing.T) {
+		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		didKey, _ := fingerprint.CreateDIDKey(pubKey)
+
+		for _, recipientKey := range []string{didKey, base58.Encode(pubKey)} {
+			thID := randomString()
+			connRec := &connection.Record{
+				ConnectionID: randomString(), ThreadID: thID, InvitationID: randomString(),
+				Namespace: myNSPrefix, State: StateIDRequested, RecipientKeys: []string{recipientKey},
+			}
+
+			require.NoError(t, svc.connectionRecorder.SaveConnectionRecordWithMappings(connRec))
+
+			connID, err := svc.HandleInbound(newProblemReport(thID), service.NewDIDCommContext("", "",
+				map[string]interface{}{SenderKeyPropKey: base58.Encode(pubKey)}))
+			require.NoError(t, err)
+			require.Equal(t, connRec.ConnectionID, connID)
+
+			validateState(t, svc, thID, myNSPrefix, StateIDAbandoned)
+			<-statusCh
+		}
+	})
+
+	t.Run("not sent by the counterparty", func(t *testing.T) {
+		thID := randomString()
+		connRec := &connection.Record{
+			ConnectionID: randomString(), ThreadID: thID, InvitationID: randomString(),
+			Namespace: myNSPrefix, State: StateIDRequested, TheirDID: theirDID,
+			RecipientKeys: []string{base58.Encode([]byte("invitation key"))},
+		}
+
+		require.NoError(t, svc.connectionRecorder.SaveConnectionRecordWithMappings(connRec))
+
+		for _, ctx := range []service.DIDCommContext{
+			service.EmptyDIDCommContext(),
+			service.NewDIDCommContext("", "did:example:other", nil),
+			service.NewDIDCommContext("", "", map[string]interface{}{SenderKeyPropKey: base58.Encode([]byte("other"))}),
+		} {
+			_, err := svc.HandleInbound(newProblemReport(thID), ctx)
+			require.EqualError(t, err, fmt.Sprintf(
+				"handle problem report: sender is not the counterparty of connection %s", connRec.ConnectionID))
+		}
+
+		validateState(t, svc, thID, myNSPrefix, StateIDRequested)
+	})
+
+	t.Run("unknown exchange", func(t *testing.T) {
+		_, err := svc.HandleInbound(newProblemReport(randomString()), service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+
+	t.Run("completed exchange", func(t *testing.T) {
+		thID := randomString()
+		connRec := &connection.Record{
+			ConnectionID: randomString(), ThreadID: thID,
+			Namespace: theirNSPrefix, State: StateIDCompleted, TheirDID: theirDID,
+		}
+
+		require.NoError(t, svc.connectionRecorder.SaveConnectionRecordWithMappings(connRec))
+
+		_, err := svc.HandleInbound(newProblemReport(thID), service.NewDIDCommContext("", theirDID, nil))
+		require.EqualError(t, err, fmt.Sprintf("handle problem report: connection %s is already completed",
+			connRec.ConnectionID))
+
+		validateState(t, svc, thID, theirNSPrefix, StateIDCompleted)
+	})
+}
+
 func TestEventStoreError(t *testing.T) {
 	svc, err := New(&protocol.MockProvider{
 		ServiceMap: map[string]interface{}{
@@ -2048,6 +2313,73 @@ func TestService_CreateImplicitInvitation(t *testing.T) {
 		require.NotEmpty(t, connID)
 	})
 
+	t.Run("sends the exchange request to the DIDComm service of the DID", func(t *testing.T) {
+		routeSvc := &mockroute.MockMediatorSvc{}
+		prov := &protocol.MockProvider{
+			ServiceMap: map[string]interface{}{
+				mediator.Coordination: routeSvc,
+			},
+		}
+		sp := mockstorage.NewMockStoreProvider()
+		k := newKMS(t, sp)
+
+		sent := make(chan *Request, 1)
+		destinations := make(chan *service.Destination, 1)
+
+		ctx := &context{
+			kms: k,
+			outboundDispatcher: &mockdispatcher.MockOutbound{
+				ValidateSend: func(msg interface{}, _ string, des *service.Destination) error {
+					request, ok := msg.(*Request)
+					require.True(t, ok)
+
+					sent <- request
+					destinations <- des
+
+					return nil
+				},
+			},
+			routeSvc:         routeSvc,
+			keyType:          kms.ED25519Type,
+			keyAgreementType: kms.X25519ECDHKWType,
+		}
+
+		theirDIDDoc := createDIDDocWithKey(newSigningAndEncryptionDIDKeys(t, ctx))
+		myDIDDoc := createDIDDocWithKey(newSigningAndEncryptionDIDKeys(t, ctx))
+
+		connRec, err := connection.NewRecorder(prov)
+		require.NoError(t, err)
+
+		didConnStore, err := didstore.NewConnectionStore(prov)
+		require.NoError(t, err)
+
+		ctx.vdRegistry = &mockvdr.MockVDRegistry{ResolveValue: theirDIDDoc, CreateValue: myDIDDoc}
+		ctx.connectionRecorder = connRec
+		ctx.connectionStore = didConnStore
+
+		s, err := New(prov)
+		require.NoError(t, err)
+
+		s.ctx = ctx
+
+		connID, err := s.CreateImplicitInvitation("", theirDIDDoc.ID, "bob", "", nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, connID)
+
+		select {
+		case request := <-sent:
+			require.Equal(t, RequestMsgType, request.Type)
+			require.Equal(t, "bob", request.Label)
+			require.Equal(t, theirDIDDoc.ID, request.Thread.PID)
+
+			des := <-destinations
+			require.Equal(t, theirDIDDoc.Service[0].ServiceEndpoint, des.ServiceEndpoint)
+			require.Equal(t, theirDIDDoc.Service[0].RecipientKeys, des.RecipientKeys)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the exchange request")
+		}
+	})
+
 	t.Run("error during did resolution", func(t *testing.T) {
 		routeSvc := &mockroute.MockMediatorSvc{}
 		prov := &protocol.MockProvider{
@@ -2153,6 +2485,22 @@ func TestRespondTo(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, connID)
 	})
+	t.Run("responds to an invitation with a transient connection", func(t *testing.T) {
+		s, err := New(testProvider())
+		require.NoError(t, err)
+		inv := newInvitation(&did.Service{
+			ID:              uuid.New().String(),
+			Type:            "did-communication",
+			RecipientKeys:   []string{"did:key:1234567"},
+			ServiceEndpoint: "http://example.com",
+		})
+		inv.Transient = true
+		connID, err := s.RespondTo(inv, nil)
+		require.NoError(t, err)
+		record, err := s.connectionRecorder.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.True(t, record.Transient)
+	})
 	t.Run("responds to an implicit invitation", func(t *testing.T) {
 		publicDID := createDIDDoc(t, &context{
 			kms:              k,
@@ -0,0 +1,31 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didrotate
+
+// Rotate is sent by the party which rotates its DID to migrate an established connection to the new DID.
+// https://identity.foundation/didcomm-messaging/spec/#did-rotation
+type Rotate struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+	// FromPrior is a JWT signed by a key of the prior (old) DID, its "sub" claim is the new DID.
+	FromPrior string     `json:"from_prior,omitempty"`
+	Body      RotateBody `json:"body,omitempty"`
+}
+
+// RotateBody represents body for Rotate.
+type RotateBody struct {
+	ToDID string `json:"to_did,omitempty"`
+}
+
+// FromPriorClaims are the claims of the from_prior JWT.
+type FromPriorClaims struct {
+	// Subject is the new DID.
+	Subject string `json:"sub,omitempty"`
+	// Issuer is the prior DID.
+	Issuer   string `json:"iss,omitempty"`
+	IssuedAt int64  `json:"iat,omitempty"`
+}
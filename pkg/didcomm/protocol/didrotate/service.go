@@ -0,0 +1,412 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didrotate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// Name of this protocol service.
+	Name = "did-rotate"
+	// PIURI is the DID Rotate protocol's protocol instance URI.
+	PIURI = "https://didcomm.org/did-rotate/1.0"
+	// RotateMsgType is the 'type' for the rotate message.
+	RotateMsgType = PIURI + "/rotate"
+	// AckMsgType is the 'type' for the ack message.
+	AckMsgType = PIURI + "/ack"
+
+	didCommMessagingType = "DIDCommMessaging"
+	ackStatusOK          = "OK"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+
+	// fromPriorClockSkew is how far the iat of a from_prior JWT can be from the current time, so that a captured
+	// from_prior can't be replayed later on.
+	fromPriorClockSkew = 5 * time.Minute
+)
+
+var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
+
+type connectionRecorder interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+	GetConnectionIDByDIDs(string, string) (string, error)
+	SaveConnectionRecord(*connection.Record) error
+	RemoveDIDMapping(string, string) error
+}
+
+// Provider provides this service's dependencies.
+type Provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+}
+
+// Service implements the DID Rotate protocol.
+// The party rotating its DID sends a rotate message whose from_prior JWT is signed by an authentication key of the
+// prior DID, the other party verifies it and migrates the connection to the new DID. The connection keeps its ID
+// (and its history).
+type Service struct {
+	connections connectionRecorder
+	messenger   service.Messenger
+	vdrRegistry vdrapi.Registry
+	kms         kms.KeyManager
+	crypto      crypto.Crypto
+}
+
+// New creates a new instance of the DID Rotate service.
+func New(p Provider) (*Service, error) {
+	connectionRecorder, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a connection.Recorder : %w", err)
+	}
+
+	return &Service{
+		connections: connectionRecorder,
+		messenger:   p.Messenger(),
+		vdrRegistry: p.VDRegistry(),
+		kms:         p.KMS(),
+		crypto:      p.Crypto(),
+	}, nil
+}
+
+// Name is this service's name.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Accept determines whether this service can handle the given type of message.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == RotateMsgType || msgType == AckMsgType
+}
+
+// HandleInbound handles inbound messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	switch msg.Type() {
+	case RotateMsgType:
+		return "", s.handleRotate(msg, ctx)
+	case AckMsgType:
+		logger.Debugf("DID rotation acknowledged by %s", ctx.TheirDID())
+
+		return "", nil
+	}
+
+	return "", fmt.Errorf("unsupported message type %s", msg.Type())
+}
+
+// HandleOutbound handles outbound messages.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented: use RotateDID")
+}
+
+// RotateDID migrates the given connection to newDID. The other party is notified with a rotate message signed by
+// the current DID of the connection, newDID must be resolvable and is used as the connection's DID afterwards.
+func (s *Service) RotateDID(connectionID, newDID string) error {
+	record, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if record.State != connection.StateNameCompleted {
+		return fmt.Errorf("connection %s is not completed", connectionID)
+	}
+
+	if _, err = s.vdrRegistry.Resolve(newDID); err != nil {
+		return fmt.Errorf("resolve new DID %s: %w", newDID, err)
+	}
+
+	fromPrior, err := s.createFromPrior(record.MyDID, newDID)
+	if err != nil {
+		return fmt.Errorf("create from_prior: %w", err)
+	}
+
+	msg := service.NewDIDCommMsgMap(&Rotate{
+		ID:        uuid.New().String(),
+		Type:      RotateMsgType,
+		FromPrior: fromPrior,
+		Body:      RotateBody{ToDID: newDID},
+	})
+
+	// the rotate message is sent from the prior DID, the other party does not know the new one yet.
+	if err = s.messenger.Send(msg, record.MyDID, record.TheirDID); err != nil {
+		return fmt.Errorf("send rotate message: %w", err)
+	}
+
+	record.MyDID = newDID
+
+	if err = s.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	logger.Debugf("connection %s rotated to DID %s", connectionID, newDID)
+
+	return nil
+}
+
+func (s *Service) handleRotate(msg service.DIDCommMsg, ctx service.DIDCommContext) error {
+	rotate := Rotate{}
+
+	if err := msg.Decode(&rotate); err != nil {
+		return fmt.Errorf("decode rotate message: %w", err)
+	}
+
+	connectionID, err := s.connections.GetConnectionIDByDIDs(ctx.MyDID(), ctx.TheirDID())
+	if err != nil {
+		return fmt.Errorf("get connection ID: %w", err)
+	}
+
+	record, err := s.connections.GetConnectionRecord(connectionID)
+	if err != nil {
+		return fmt.Errorf("get connection record: %w", err)
+	}
+
+	if err = s.verifyFromPrior(rotate.FromPrior, record.TheirDID, rotate.Body.ToDID); err != nil {
+		return fmt.Errorf("verify from_prior: %w", err)
+	}
+
+	docResolution, err := s.vdrRegistry.Resolve(rotate.Body.ToDID)
+	if err != nil {
+		return fmt.Errorf("resolve new DID %s: %w", rotate.Body.ToDID, err)
+	}
+
+	priorDID := record.TheirDID
+	record.TheirDID = rotate.Body.ToDID
+
+	if record.DIDCommVersion == connection.DIDCommV2 {
+		updateDIDCommV2Destination(record, docResolution.DIDDocument)
+	}
+
+	if err = s.connections.SaveConnectionRecord(record); err != nil {
+		return fmt.Errorf("save connection record: %w", err)
+	}
+
+	// the messages from the prior DID are not handled over the connection anymore.
+	if priorDID != record.TheirDID {
+		if err = s.connections.RemoveDIDMapping(record.MyDID, priorDID); err != nil {
+			return fmt.Errorf("remove prior DID mapping: %w", err)
+		}
+	}
+
+	logger.Debugf("connection %s rotated to their DID %s", connectionID, record.TheirDID)
+
+	ack := service.NewDIDCommMsgMap(&model.AckV2{
+		ID:   uuid.New().String(),
+		Type: AckMsgType,
+		Body: model.AckV2Body{Status: ackStatusOK},
+	})
+
+	err = s.messenger.ReplyToMsg(msg.(service.DIDCommMsgMap), ack, record.MyDID, record.TheirDID)
+	if err != nil {
+		return fmt.Errorf("send ack: %w", err)
+	}
+
+	return nil
+}
+
+// createFromPrior creates the from_prior JWT signed by the first authentication key of priorDID.
+func (s *Service) createFromPrior(priorDID, newDID string) (string, error) {
+	return s.signFromPrior(priorDID, &FromPriorClaims{
+		Subject:  newDID,
+		Issuer:   priorDID,
+		IssuedAt: time.Now().Unix(),
+	})
+}
+
+func (s *Service) signFromPrior(priorDID string, claims *FromPriorClaims) (string, error) {
+	docResolution, err := s.vdrRegistry.Resolve(priorDID)
+	if err != nil {
+		return "", fmt.Errorf("resolve prior DID %s: %w", priorDID, err)
+	}
+
+	doc := docResolution.DIDDocument
+	if len(doc.Authentication) == 0 {
+		return "", fmt.Errorf("prior DID %s has no authentication key", priorDID)
+	}
+
+	vm := doc.Authentication[0].VerificationMethod
+
+	keyType, alg, err := signingKeyType(&vm)
+	if err != nil {
+		return "", err
+	}
+
+	kid, err := localkms.CreateKID(vm.Value, keyType)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate KID from public key: %w", err)
+	}
+
+	kh, err := s.kms.Get(kid)
+	if err != nil {
+		return "", fmt.Errorf("failed to get key handle: %w", err)
+	}
+
+	token, err := jwt.NewSigned(claims, nil, &signer{
+		crypto:    s.crypto,
+		keyHandle: kh,
+		keyID:     absoluteKeyID(doc.ID, vm.ID),
+		alg:       alg,
+	})
+	if err != nil {
+		return "", fmt.Errorf("sign JWT: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+// verifyFromPrior checks that the from_prior JWT is signed by an authentication key of priorDID and that it
+// rotates priorDID to newDID.
+func (s *Service) verifyFromPrior(fromPrior, priorDID, newDID string) error {
+	if fromPrior == "" {
+		return errors.New("from_prior is empty")
+	}
+
+	token, err := jwt.Parse(fromPrior, jwt.WithSignatureVerifier(jwt.NewVerifier(
+		jwt.KeyResolverFunc(s.resolveAuthenticationKey))))
+	if err != nil {
+		return fmt.Errorf("parse JWT: %w", err)
+	}
+
+	claims := FromPriorClaims{}
+
+	if err = token.DecodeClaims(&claims); err != nil {
+		return fmt.Errorf("decode claims: %w", err)
+	}
+
+	if claims.Issuer != priorDID {
+		return fmt.Errorf("issuer %s does not match the connection DID %s", claims.Issuer, priorDID)
+	}
+
+	if newDID == "" || claims.Subject != newDID {
+		return fmt.Errorf("subject %s does not match the new DID %s", claims.Subject, newDID)
+	}
+
+	if claims.IssuedAt == 0 {
+		return errors.New("iat is not defined")
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if age := time.Since(issuedAt); age > fromPriorClockSkew || age < -fromPriorClockSkew {
+		return fmt.Errorf("iat %s is not within %s of the current time", issuedAt.UTC().Format(time.RFC3339),
+			fromPriorClockSkew)
+	}
+
+	return nil
+}
+
+func (s *Service) resolveAuthenticationKey(issuer, keyID string) (*verifier.PublicKey, error) {
+	docResolution, err := s.vdrRegistry.Resolve(issuer)
+	if err != nil {
+		return nil, fmt.Errorf("resolve DID %s: %w", issuer, err)
+	}
+
+	doc := docResolution.DIDDocument
+
+	for _, auth := range doc.Authentication {
+		if absoluteKeyID(doc.ID, auth.VerificationMethod.ID) == keyID {
+			return &verifier.PublicKey{
+				Type:  auth.VerificationMethod.Type,
+				Value: auth.VerificationMethod.Value,
+				JWK:   auth.VerificationMethod.JSONWebKey(),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("authentication key %s is not found for DID %s", keyID, issuer)
+}
+
+// signingKeyType returns the KMS key type and the JWS algorithm of the authentication key vm.
+func signingKeyType(vm *did.VerificationMethod) (kms.KeyType, string, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return kms.ED25519Type, jose.AlgEdDSA, nil
+	}
+
+	if jwk := vm.JSONWebKey(); jwk != nil {
+		switch jwk.Crv {
+		case "Ed25519":
+			return kms.ED25519Type, jose.AlgEdDSA, nil
+		case "P-256":
+			return kms.ECDSAP256TypeIEEEP1363, jose.AlgES256, nil
+		case "P-384":
+			return kms.ECDSAP384TypeIEEEP1363, jose.AlgES384, nil
+		case "secp256k1":
+			return kms.ECDSASecp256k1TypeIEEEP1363, jose.AlgES256K, nil
+		}
+
+		return "", "", fmt.Errorf("unsupported key type %s with curve %s", vm.Type, jwk.Crv)
+	}
+
+	return "", "", fmt.Errorf("unsupported key type %s", vm.Type)
+}
+
+func updateDIDCommV2Destination(record *connection.Record, doc *did.Doc) {
+	record.RecipientKeys = nil
+
+	for i := range doc.KeyAgreement {
+		record.RecipientKeys = append(record.RecipientKeys,
+			absoluteKeyID(doc.ID, doc.KeyAgreement[i].VerificationMethod.ID))
+	}
+
+	for i := range doc.Service {
+		if doc.Service[i].Type == didCommMessagingType {
+			record.ServiceEndPoint = doc.Service[i].ServiceEndpoint
+			record.RoutingKeys = doc.Service[i].RoutingKeys
+
+			break
+		}
+	}
+}
+
+func absoluteKeyID(didID, keyID string) string {
+	if strings.HasPrefix(keyID, "#") {
+		return didID + keyID
+	}
+
+	return keyID
+}
+
+// signer signs the from_prior JWT with a KMS key.
+type signer struct {
+	crypto    crypto.Crypto
+	keyHandle interface{}
+	keyID     string
+	alg       string
+}
+
+func (s *signer) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.keyHandle)
+}
+
+func (s *signer) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm: s.alg,
+		jose.HeaderKeyID:     s.keyID,
+	}
+}
@@ -0,0 +1,700 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package didrotate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messaging/service/basic"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const serviceEndpoint = "https://agent.example.com"
+
+func TestNew(t *testing.T) {
+	t.Run("returns the service", func(t *testing.T) {
+		s, err := New(newTestNetwork(t).provider(t))
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		require.Equal(t, Name, s.Name())
+	})
+
+	t.Run("fails to open the connection store", func(t *testing.T) {
+		provider := newTestNetwork(t).provider(t)
+		provider.storeProvider = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("test")}
+
+		_, err := New(provider)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open a connection.Recorder")
+	})
+}
+
+func TestAccept(t *testing.T) {
+	s, err := New(newTestNetwork(t).provider(t))
+	require.NoError(t, err)
+
+	require.True(t, s.Accept(RotateMsgType))
+	require.True(t, s.Accept(AckMsgType))
+	require.False(t, s.Accept(basic.MessageRequestType))
+}
+
+func TestHandleOutbound(t *testing.T) {
+	s, err := New(newTestNetwork(t).provider(t))
+	require.NoError(t, err)
+
+	_, err = s.HandleOutbound(service.NewDIDCommMsgMap(&Rotate{Type: RotateMsgType}), "", "")
+	require.EqualError(t, err, "not implemented: use RotateDID")
+}
+
+func TestHandleInbound(t *testing.T) {
+	t.Run("unsupported message type", func(t *testing.T) {
+		s, err := New(newTestNetwork(t).provider(t))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Rotate{Type: "unknown"}), service.EmptyDIDCommContext())
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		s, err := New(newTestNetwork(t).provider(t))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Rotate{Type: AckMsgType}), service.EmptyDIDCommContext())
+		require.NoError(t, err)
+	})
+}
+
+func TestRotateDID(t *testing.T) {
+	t.Run("rotates and then sends a basic message over the rotated connection", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+		bob := network.newAgent(t)
+
+		aliceDID := alice.newDID(t)
+		bobDID := bob.newDID(t)
+		aliceConnID, bobConnID := connect(t, alice, aliceDID, bob, bobDID)
+
+		aliceNewDID := alice.newDID(t)
+		require.NoError(t, alice.svc.RotateDID(aliceConnID, aliceNewDID))
+
+		// Alice uses the new DID for the connection.
+		aliceRecord, err := alice.connections.GetConnectionRecord(aliceConnID)
+		require.NoError(t, err)
+		require.Equal(t, aliceNewDID, aliceRecord.MyDID)
+		require.Equal(t, bobDID, aliceRecord.TheirDID)
+
+		// Bob migrated the connection to Alice's new DID and acknowledged the rotation.
+		bobRecord, err := bob.connections.GetConnectionRecord(bobConnID)
+		require.NoError(t, err)
+		require.Equal(t, bobDID, bobRecord.MyDID)
+		require.Equal(t, aliceNewDID, bobRecord.TheirDID)
+		require.Len(t, bobRecord.RecipientKeys, 1)
+		require.Contains(t, bobRecord.RecipientKeys[0], aliceNewDID)
+		require.Equal(t, serviceEndpoint, bobRecord.ServiceEndPoint)
+		require.Equal(t, []string{RotateMsgType, AckMsgType}, network.sentTypes)
+
+		connID, err := bob.connections.GetConnectionIDByDIDs(bobDID, aliceNewDID)
+		require.NoError(t, err)
+		require.Equal(t, bobConnID, connID)
+
+		// the messages from Alice's prior DID don't map to the connection anymore.
+		_, err = bob.connections.GetConnectionIDByDIDs(bobDID, aliceDID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		fromPrior, err := alice.svc.createFromPrior(aliceDID, alice.newDID(t))
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(service.NewDIDCommMsgMap(&Rotate{
+			ID:        uuid.New().String(),
+			Type:      RotateMsgType,
+			FromPrior: fromPrior,
+			Body:      RotateBody{ToDID: alice.newDID(t)},
+		}), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection ID")
+
+		// Alice can't be reached at the prior DID anymore.
+		delete(network.agents, aliceDID)
+
+		msg := service.NewDIDCommMsgMap(&basic.Message{
+			ID:       uuid.New().String(),
+			Type:     basic.MessageRequestType,
+			SentTime: time.Now(),
+			Content:  "hello after rotation",
+		})
+		require.NoError(t, bob.messenger.Send(msg, bobRecord.MyDID, bobRecord.TheirDID))
+
+		select {
+		case received := <-alice.basicMessages:
+			require.Equal(t, "hello after rotation", received.msg.Content)
+			require.Equal(t, aliceNewDID, received.ctx.MyDID())
+			require.Equal(t, bobDID, received.ctx.TheirDID())
+		default:
+			t.Fatal("basic message was not received")
+		}
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+
+		err := alice.svc.RotateDID("unknown", alice.newDID(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("connection is not completed", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+
+		record := &connection.Record{
+			ConnectionID: uuid.New().String(),
+			State:        "requested",
+			MyDID:        alice.newDID(t),
+			TheirDID:     "did:example:bob",
+		}
+		require.NoError(t, alice.connections.SaveConnectionRecord(record))
+
+		err := alice.svc.RotateDID(record.ConnectionID, alice.newDID(t))
+		require.EqualError(t, err, fmt.Sprintf("connection %s is not completed", record.ConnectionID))
+	})
+
+	t.Run("new DID can't be resolved", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+		bob := network.newAgent(t)
+
+		aliceConnID, _ := connect(t, alice, alice.newDID(t), bob, bob.newDID(t))
+
+		err := alice.svc.RotateDID(aliceConnID, "did:example:unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve new DID did:example:unknown")
+	})
+
+	t.Run("prior DID key is not in the KMS", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+		bob := network.newAgent(t)
+
+		// the prior DID was created with Bob's KMS.
+		aliceConnID, _ := connect(t, alice, bob.newDID(t), bob, bob.newDID(t))
+
+		err := alice.svc.RotateDID(aliceConnID, alice.newDID(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create from_prior: failed to get key handle")
+	})
+
+	t.Run("fails to send the rotate message", func(t *testing.T) {
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+		bob := network.newAgent(t)
+
+		bobDID := bob.newDID(t)
+		aliceConnID, _ := connect(t, alice, alice.newDID(t), bob, bobDID)
+
+		delete(network.agents, bobDID)
+
+		err := alice.svc.RotateDID(aliceConnID, alice.newDID(t))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send rotate message")
+	})
+}
+
+func TestHandleRotate(t *testing.T) {
+	setup := func(t *testing.T) (*testNetwork, *testAgent, *testAgent, string, string) {
+		t.Helper()
+
+		network := newTestNetwork(t)
+		alice := network.newAgent(t)
+		bob := network.newAgent(t)
+
+		aliceDID := alice.newDID(t)
+		bobDID := bob.newDID(t)
+
+		connect(t, alice, aliceDID, bob, bobDID)
+
+		return network, alice, bob, aliceDID, bobDID
+	}
+
+	rotateMsg := func(fromPrior, toDID string) service.DIDCommMsgMap {
+		return service.NewDIDCommMsgMap(&Rotate{
+			ID:        uuid.New().String(),
+			Type:      RotateMsgType,
+			FromPrior: fromPrior,
+			Body:      RotateBody{ToDID: toDID},
+		})
+	}
+
+	t.Run("from_prior signed by another key", func(t *testing.T) {
+		network, _, bob, aliceDID, bobDID := setup(t)
+		mallory := network.newAgent(t)
+		newDID := mallory.newDID(t)
+
+		doc, err := network.vdr.Resolve(aliceDID)
+		require.NoError(t, err)
+
+		// Mallory claims Alice's authentication key but signs with her own one.
+		malloryDoc, err := network.vdr.Resolve(mallory.newDID(t))
+		require.NoError(t, err)
+
+		kid, err := localkms.CreateKID(malloryDoc.DIDDocument.Authentication[0].VerificationMethod.Value,
+			kms.ED25519Type)
+		require.NoError(t, err)
+
+		kh, err := mallory.provider.kms.Get(kid)
+		require.NoError(t, err)
+
+		token, err := jwt.NewSigned(&FromPriorClaims{Subject: newDID, Issuer: aliceDID}, nil, &signer{
+			crypto:    mallory.provider.crypto,
+			keyHandle: kh,
+			keyID:     absoluteKeyID(aliceDID, doc.DIDDocument.Authentication[0].VerificationMethod.ID),
+			alg:       jose.AlgEdDSA,
+		})
+		require.NoError(t, err)
+
+		fromPrior, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, newDID), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verify from_prior: parse JWT")
+
+		require.Empty(t, network.sentTypes)
+	})
+
+	t.Run("from_prior issued by another DID", func(t *testing.T) {
+		network, _, bob, aliceDID, bobDID := setup(t)
+		mallory := network.newAgent(t)
+		malloryDID := mallory.newDID(t)
+		newDID := mallory.newDID(t)
+
+		fromPrior, err := mallory.svc.createFromPrior(malloryDID, newDID)
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, newDID), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the connection DID")
+	})
+
+	t.Run("from_prior subject is not the new DID", func(t *testing.T) {
+		network, alice, bob, aliceDID, bobDID := setup(t)
+
+		fromPrior, err := alice.svc.createFromPrior(aliceDID, alice.newDID(t))
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, alice.newDID(t)),
+			service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the new DID")
+		require.Empty(t, network.sentTypes)
+	})
+
+	t.Run("from_prior without iat", func(t *testing.T) {
+		network, alice, bob, aliceDID, bobDID := setup(t)
+		newDID := alice.newDID(t)
+
+		fromPrior, err := alice.svc.signFromPrior(aliceDID, &FromPriorClaims{Subject: newDID, Issuer: aliceDID})
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, newDID), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.EqualError(t, err, "verify from_prior: iat is not defined")
+		require.Empty(t, network.sentTypes)
+	})
+
+	t.Run("stale from_prior", func(t *testing.T) {
+		network, alice, bob, aliceDID, bobDID := setup(t)
+		newDID := alice.newDID(t)
+
+		for _, issuedAt := range []time.Time{
+			time.Now().Add(-fromPriorClockSkew - time.Minute),
+			time.Now().Add(fromPriorClockSkew + time.Minute),
+		} {
+			fromPrior, err := alice.svc.signFromPrior(aliceDID, &FromPriorClaims{
+				Subject:  newDID,
+				Issuer:   aliceDID,
+				IssuedAt: issuedAt.Unix(),
+			})
+			require.NoError(t, err)
+
+			_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, newDID),
+				service.NewDIDCommContext(bobDID, aliceDID, nil))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "is not within 5m0s of the current time")
+		}
+
+		require.Empty(t, network.sentTypes)
+	})
+
+	t.Run("from_prior is empty", func(t *testing.T) {
+		_, alice, bob, aliceDID, bobDID := setup(t)
+
+		_, err := bob.svc.HandleInbound(rotateMsg("", alice.newDID(t)), service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.EqualError(t, err, "verify from_prior: from_prior is empty")
+	})
+
+	t.Run("new DID can't be resolved", func(t *testing.T) {
+		_, alice, bob, aliceDID, bobDID := setup(t)
+
+		fromPrior, err := alice.svc.createFromPrior(aliceDID, "did:example:unknown")
+		require.NoError(t, err)
+
+		_, err = bob.svc.HandleInbound(rotateMsg(fromPrior, "did:example:unknown"),
+			service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve new DID did:example:unknown")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		_, alice, bob, aliceDID, _ := setup(t)
+
+		_, err := bob.svc.HandleInbound(rotateMsg("", alice.newDID(t)),
+			service.NewDIDCommContext("did:example:other", aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection ID")
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		_, _, bob, aliceDID, bobDID := setup(t)
+
+		_, err := bob.svc.HandleInbound(service.DIDCommMsgMap{"type": RotateMsgType, "body": "invalid"},
+			service.NewDIDCommContext(bobDID, aliceDID, nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode rotate message")
+	})
+}
+
+func TestFromPriorKeyTypes(t *testing.T) {
+	network := newTestNetwork(t)
+	alice := network.newAgent(t)
+	bob := network.newAgent(t)
+	newDID := alice.newDID(t)
+
+	// newJWKDID registers a DID whose authentication key is the given JWK.
+	newJWKDID := func(t *testing.T, jwk *jose.JWK) string {
+		t.Helper()
+
+		didID := "did:example:" + uuid.New().String()
+
+		vm, err := did.NewVerificationMethodFromJWK(didID+"#key-1", "JsonWebKey2020", didID, jwk)
+		require.NoError(t, err)
+
+		network.docs[didID] = &did.Doc{
+			ID:                 didID,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		}
+
+		return didID
+	}
+
+	for keyType, alg := range map[kms.KeyType]string{
+		kms.ED25519Type:                 jose.AlgEdDSA,
+		kms.ECDSAP256TypeIEEEP1363:      jose.AlgES256,
+		kms.ECDSAP384TypeIEEEP1363:      jose.AlgES384,
+		kms.ECDSASecp256k1TypeIEEEP1363: jose.AlgES256K,
+	} {
+		t.Run(string(keyType), func(t *testing.T) {
+			// the prior DID key is a new key of Alice's KMS.
+			_, pubKey, err := alice.provider.kms.CreateAndExportPubKeyBytes(keyType)
+			require.NoError(t, err)
+
+			jwk, err := jose.PubKeyBytesToJWK(pubKey, keyType)
+			require.NoError(t, err)
+
+			priorDID := newJWKDID(t, jwk)
+
+			fromPrior, err := alice.svc.createFromPrior(priorDID, newDID)
+			require.NoError(t, err)
+
+			token, err := jwt.Parse(fromPrior, jwt.WithSignatureVerifier(
+				jwt.NewVerifier(jwt.KeyResolverFunc(bob.svc.resolveAuthenticationKey))))
+			require.NoError(t, err)
+
+			tokenAlg, ok := token.Headers.Algorithm()
+			require.True(t, ok)
+			require.Equal(t, alg, tokenAlg)
+
+			require.NoError(t, bob.svc.verifyFromPrior(fromPrior, priorDID, newDID))
+		})
+	}
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		jwk, err := jose.JWKFromX25519Key(make([]byte, 32))
+		require.NoError(t, err)
+
+		priorDID := newJWKDID(t, jwk)
+
+		_, err = alice.svc.createFromPrior(priorDID, newDID)
+		require.EqualError(t, err, "unsupported key type JsonWebKey2020 with curve X25519")
+
+		priorDID = "did:example:" + uuid.New().String()
+		vm := did.NewVerificationMethodFromBytes(priorDID+"#key-1", "X25519KeyAgreementKey2019", priorDID,
+			[]byte("key"))
+		network.docs[priorDID] = &did.Doc{
+			ID:             priorDID,
+			Authentication: []did.Verification{*did.NewEmbeddedVerification(vm, did.Authentication)},
+		}
+
+		_, err = alice.svc.createFromPrior(priorDID, newDID)
+		require.EqualError(t, err, "unsupported key type X25519KeyAgreementKey2019")
+	})
+}
+
+// connect creates a completed DIDComm V2 connection between the agents and returns its ID on both sides.
+func connect(t *testing.T, a *testAgent, aDID string, b *testAgent, bDID string) (string, string) {
+	t.Helper()
+
+	aRecord := &connection.Record{
+		ConnectionID:   uuid.New().String(),
+		State:          connection.StateNameCompleted,
+		MyDID:          aDID,
+		TheirDID:       bDID,
+		Namespace:      connection.MyNSPrefix,
+		DIDCommVersion: connection.DIDCommV2,
+	}
+	require.NoError(t, a.connections.SaveConnectionRecord(aRecord))
+
+	bRecord := &connection.Record{
+		ConnectionID:   uuid.New().String(),
+		State:          connection.StateNameCompleted,
+		MyDID:          bDID,
+		TheirDID:       aDID,
+		Namespace:      connection.TheirNSPrefix,
+		DIDCommVersion: connection.DIDCommV2,
+	}
+	require.NoError(t, b.connections.SaveConnectionRecord(bRecord))
+
+	return aRecord.ConnectionID, bRecord.ConnectionID
+}
+
+// testNetwork delivers messages between the agents by their DIDs.
+type testNetwork struct {
+	vdr       vdrapi.Registry
+	docs      map[string]*did.Doc // resolved before the did:peer DIDs
+	agents    map[string]*testAgent
+	sentTypes []string
+}
+
+func newTestNetwork(t *testing.T) *testNetwork {
+	t.Helper()
+
+	peerVDR, err := peer.New(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	n := &testNetwork{
+		docs:   map[string]*did.Doc{},
+		agents: map[string]*testAgent{},
+	}
+
+	n.vdr = &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			if doc, ok := n.docs[didID]; ok {
+				return &did.DocResolution{DIDDocument: doc}, nil
+			}
+
+			return peerVDR.Read(didID, opts...)
+		},
+	}
+
+	return n
+}
+
+func (n *testNetwork) provider(t *testing.T) *testProvider {
+	t.Helper()
+
+	km, err := localkms.New("local-lock://primary/test/",
+		mockkms.NewProviderForKMS(mockstore.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return &testProvider{
+		storeProvider:              mockstore.NewMockStoreProvider(),
+		protocolStateStoreProvider: mockstore.NewMockStoreProvider(),
+		vdr:                        n.vdr,
+		kms:                        km,
+		crypto:                     cr,
+	}
+}
+
+func (n *testNetwork) newAgent(t *testing.T) *testAgent {
+	t.Helper()
+
+	provider := n.provider(t)
+	provider.messenger = &testMessenger{network: n}
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	connections, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	a := &testAgent{
+		network:       n,
+		provider:      provider,
+		svc:           svc,
+		connections:   connections,
+		messenger:     provider.messenger,
+		basicMessages: make(chan basicMessage, 1),
+	}
+
+	a.basicMsgSvc, err = basic.NewMessageService("basic", func(msg basic.Message, ctx service.DIDCommContext) error {
+		a.basicMessages <- basicMessage{msg: msg, ctx: ctx}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return a
+}
+
+type basicMessage struct {
+	msg basic.Message
+	ctx service.DIDCommContext
+}
+
+type testAgent struct {
+	network       *testNetwork
+	provider      *testProvider
+	svc           *Service
+	basicMsgSvc   *basic.MessageService
+	connections   *connection.Recorder
+	messenger     service.Messenger
+	basicMessages chan basicMessage
+}
+
+// newDID creates a did:peer:2 with keys of the agent's KMS and registers it in the network.
+func (a *testAgent) newDID(t *testing.T) string {
+	t.Helper()
+
+	_, authKey, err := a.provider.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, kaKeyBytes, err := a.provider.kms.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	kaKey := &crypto.PublicKey{}
+	require.NoError(t, json.Unmarshal(kaKeyBytes, kaKey))
+
+	keys := []did.Verification{
+		*did.NewReferencedVerification(
+			did.NewVerificationMethodFromBytes("", "Ed25519VerificationKey2018", "", authKey), did.Authentication),
+		*did.NewReferencedVerification(
+			did.NewVerificationMethodFromBytes("", "X25519KeyAgreementKey2019", "", kaKey.X), did.KeyAgreement),
+	}
+
+	services := []did.Service{{
+		Type:            didCommMessagingType,
+		ServiceEndpoint: serviceEndpoint,
+	}}
+
+	didID, err := peer.NewNumAlgo2DID(keys, services)
+	require.NoError(t, err)
+
+	a.network.agents[didID] = a
+
+	return didID
+}
+
+func (a *testAgent) handleInbound(msg service.DIDCommMsgMap, ctx service.DIDCommContext) error {
+	if a.svc.Accept(msg.Type()) {
+		_, err := a.svc.HandleInbound(msg, ctx)
+
+		return err
+	}
+
+	_, err := a.basicMsgSvc.HandleInbound(msg, ctx)
+
+	return err
+}
+
+// testMessenger sends the messages through the testNetwork.
+type testMessenger struct {
+	service.Messenger
+	network *testNetwork
+}
+
+func (m *testMessenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	recipient, ok := m.network.agents[theirDID]
+	if !ok {
+		return fmt.Errorf("unknown DID %s", theirDID)
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	inbound, err := service.ParseDIDCommMsgMap(raw)
+	if err != nil {
+		return err
+	}
+
+	m.network.sentTypes = append(m.network.sentTypes, inbound.Type())
+
+	return recipient.handleInbound(inbound, service.NewDIDCommContext(theirDID, myDID, nil))
+}
+
+func (m *testMessenger) ReplyToMsg(_, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	return m.Send(out, myDID, theirDID)
+}
+
+type testProvider struct {
+	storeProvider              storage.Provider
+	protocolStateStoreProvider storage.Provider
+	messenger                  service.Messenger
+	vdr                        vdrapi.Registry
+	kms                        kms.KeyManager
+	crypto                     crypto.Crypto
+}
+
+func (p *testProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *testProvider) StorageProvider() storage.Provider {
+	return p.storeProvider
+}
+
+func (p *testProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStoreProvider
+}
+
+func (p *testProvider) VDRegistry() vdrapi.Registry {
+	return p.vdr
+}
+
+func (p *testProvider) KMS() kms.KeyManager {
+	return p.kms
+}
+
+func (p *testProvider) Crypto() crypto.Crypto {
+	return p.crypto
+}
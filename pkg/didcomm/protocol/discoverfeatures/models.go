@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+// Queries asks the other party which of the features matching the queries it supports.
+// https://identity.foundation/didcomm-messaging/spec/#discover-features-protocol-20
+type Queries struct {
+	ID   string      `json:"id,omitempty"`
+	Type string      `json:"type,omitempty"`
+	Body QueriesBody `json:"body,omitempty"`
+}
+
+// QueriesBody represents body for Queries.
+type QueriesBody struct {
+	Queries []Query `json:"queries,omitempty"`
+}
+
+// Query selects the features of the given type whose ID matches Match. A "*" in Match is a wildcard,
+// e.g. "https://didcomm.org/tictactoe/1.*".
+type Query struct {
+	FeatureType string `json:"feature-type,omitempty"`
+	Match       string `json:"match,omitempty"`
+}
+
+// Disclose is the answer to Queries, it lists the supported features matching the queries.
+type Disclose struct {
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	ThreadID string       `json:"thid,omitempty"`
+	Body     DiscloseBody `json:"body,omitempty"`
+}
+
+// DiscloseBody represents body for Disclose.
+type DiscloseBody struct {
+	Disclosures []Feature `json:"disclosures"`
+}
+
+// Feature is a feature supported by an agent, e.g. a protocol or a goal code.
+type Feature struct {
+	FeatureType string `json:"feature-type,omitempty"`
+	ID          string `json:"id,omitempty"`
+	// Roles the agent can play in a protocol.
+	Roles []string `json:"roles,omitempty"`
+}
@@ -0,0 +1,298 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// Name of this protocol service.
+	Name = "discover-features"
+	// PIURI is the Discover Features 2.0 protocol's protocol instance URI.
+	PIURI = "https://didcomm.org/discover-features/2.0"
+	// QueriesMsgType is the 'type' for the queries message.
+	QueriesMsgType = PIURI + "/queries"
+	// DiscloseMsgType is the 'type' for the disclose message.
+	DiscloseMsgType = PIURI + "/disclose"
+
+	// FeatureTypeProtocol is the feature type of protocols, their ID is the protocol's PIURI.
+	FeatureTypeProtocol = "protocol"
+	// FeatureTypeGoalCode is the feature type of goal codes.
+	FeatureTypeGoalCode = "goal-code"
+
+	// RoleRequester is the role of the party sending the queries.
+	RoleRequester = "requester"
+	// RoleResponder is the role of the party disclosing its features.
+	RoleResponder = "responder"
+
+	// DefaultQueryTimeout is the time Query waits for the disclose of the other party by default.
+	DefaultQueryTimeout = 50 * time.Second
+)
+
+var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
+
+type connections interface {
+	GetConnectionRecord(string) (*connection.Record, error)
+}
+
+// Provider provides this service's dependencies.
+type Provider interface {
+	Messenger() service.Messenger
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Service implements the Discover Features 2.0 protocol.
+// It discloses the protocols and goal codes registered with it to the other parties, and queries the features of
+// the other parties.
+type Service struct {
+	connectionLookup connections
+	messenger        service.Messenger
+	queryTimeout     time.Duration
+	features         []Feature
+	featuresLock     sync.RWMutex
+	discloseMap      map[string]chan Disclose
+	discloseMapLock  sync.RWMutex
+}
+
+// Opt is the discover features service option.
+type Opt func(s *Service)
+
+// WithQueryTimeout sets the time Query waits for the disclose of the other party (DefaultQueryTimeout by default).
+func WithQueryTimeout(timeout time.Duration) Opt {
+	return func(s *Service) {
+		s.queryTimeout = timeout
+	}
+}
+
+// New creates a new instance of the discover features service.
+func New(p Provider, opts ...Opt) (*Service, error) {
+	connectionLookup, err := connection.NewLookup(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a connection.Lookup : %w", err)
+	}
+
+	svc := &Service{
+		connectionLookup: connectionLookup,
+		messenger:        p.Messenger(),
+		queryTimeout:     DefaultQueryTimeout,
+		discloseMap:      make(map[string]chan Disclose),
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	svc.RegisterProtocol(PIURI, RoleRequester, RoleResponder)
+
+	return svc, nil
+}
+
+// Name is this service's name.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Accept determines whether this service can handle the given type of message.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == QueriesMsgType || msgType == DiscloseMsgType
+}
+
+// HandleInbound handles inbound messages.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	switch msg.Type() {
+	case QueriesMsgType:
+		return "", s.handleQueries(msg, ctx)
+	case DiscloseMsgType:
+		return "", s.handleDisclose(msg)
+	}
+
+	return "", fmt.Errorf("unsupported message type %s", msg.Type())
+}
+
+// HandleOutbound handles outbound messages.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	return "", errors.New("not implemented: use Query")
+}
+
+// RegisterProtocol registers a protocol supported by this agent, it is disclosed with the roles the agent can play.
+func (s *Service) RegisterProtocol(piuri string, roles ...string) {
+	s.register(Feature{FeatureType: FeatureTypeProtocol, ID: piuri, Roles: roles})
+}
+
+// RegisterGoalCode registers a goal code supported by this agent.
+func (s *Service) RegisterGoalCode(goalCode string) {
+	s.register(Feature{FeatureType: FeatureTypeGoalCode, ID: goalCode})
+}
+
+func (s *Service) register(feature Feature) {
+	s.featuresLock.Lock()
+	defer s.featuresLock.Unlock()
+
+	for i := range s.features {
+		if s.features[i].FeatureType == feature.FeatureType && s.features[i].ID == feature.ID {
+			s.features[i] = feature
+
+			return
+		}
+	}
+
+	s.features = append(s.features, feature)
+}
+
+// Query sends the queries over the given connection and returns the features disclosed by the other party.
+func (s *Service) Query(connectionID string, queries ...Query) ([]Feature, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("no queries")
+	}
+
+	record, err := s.connectionLookup.GetConnectionRecord(connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection record: %w", err)
+	}
+
+	msgID := uuid.New().String()
+
+	// register chan for callback processing, the disclose may be received before Send returns.
+	discloseCh := make(chan Disclose, 1)
+	s.setDiscloseCh(msgID, discloseCh)
+
+	defer s.setDiscloseCh(msgID, nil)
+
+	msg := service.NewDIDCommMsgMap(&Queries{
+		ID:   msgID,
+		Type: QueriesMsgType,
+		Body: QueriesBody{Queries: queries},
+	})
+
+	if err = s.messenger.Send(msg, record.MyDID, record.TheirDID); err != nil {
+		return nil, fmt.Errorf("send queries: %w", err)
+	}
+
+	select {
+	case disclose := <-discloseCh:
+		return disclose.Body.Disclosures, nil
+	case <-time.After(s.queryTimeout):
+		return nil, errors.New("timeout waiting for disclose")
+	}
+}
+
+// Disclose returns the registered features matching the queries. Queries of unknown feature types match nothing.
+func (s *Service) Disclose(queries ...Query) []Feature {
+	s.featuresLock.RLock()
+	defer s.featuresLock.RUnlock()
+
+	disclosures := []Feature{}
+
+	for i := range s.features {
+		for _, query := range queries {
+			if query.FeatureType == s.features[i].FeatureType && matches(query.Match, s.features[i].ID) {
+				disclosures = append(disclosures, s.features[i])
+
+				break
+			}
+		}
+	}
+
+	return disclosures
+}
+
+func (s *Service) handleQueries(msg service.DIDCommMsg, ctx service.DIDCommContext) error {
+	queries := Queries{}
+
+	if err := msg.Decode(&queries); err != nil {
+		return fmt.Errorf("decode queries message: %w", err)
+	}
+
+	disclose := service.NewDIDCommMsgMap(&Disclose{
+		ID:   uuid.New().String(),
+		Type: DiscloseMsgType,
+		Body: DiscloseBody{Disclosures: s.Disclose(queries.Body.Queries...)},
+	})
+
+	inbound, ok := msg.(service.DIDCommMsgMap)
+	if !ok {
+		return fmt.Errorf("unexpected queries message %T", msg)
+	}
+
+	if err := s.messenger.ReplyToMsg(inbound, disclose, ctx.MyDID(), ctx.TheirDID()); err != nil {
+		return fmt.Errorf("send disclose: %w", err)
+	}
+
+	logger.Debugf("disclosed features to %s", ctx.TheirDID())
+
+	return nil
+}
+
+func (s *Service) handleDisclose(msg service.DIDCommMsg) error {
+	disclose := Disclose{}
+
+	if err := msg.Decode(&disclose); err != nil {
+		return fmt.Errorf("decode disclose message: %w", err)
+	}
+
+	thID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("get threadID: %w", err)
+	}
+
+	// check if there are any channels registered for the thread ID
+	discloseCh := s.getDiscloseCh(thID)
+	if discloseCh == nil {
+		logger.Warnf("no query is waiting for the disclose of thread %s", thID)
+
+		return nil
+	}
+
+	select {
+	case discloseCh <- disclose:
+	default:
+		logger.Warnf("dropped a duplicate disclose of thread %s", thID)
+	}
+
+	return nil
+}
+
+func (s *Service) getDiscloseCh(thID string) chan Disclose {
+	s.discloseMapLock.RLock()
+	defer s.discloseMapLock.RUnlock()
+
+	return s.discloseMap[thID]
+}
+
+func (s *Service) setDiscloseCh(thID string, discloseCh chan Disclose) {
+	s.discloseMapLock.Lock()
+	defer s.discloseMapLock.Unlock()
+
+	if discloseCh == nil {
+		delete(s.discloseMap, thID)
+	} else {
+		s.discloseMap[thID] = discloseCh
+	}
+}
+
+// matches reports whether id matches the query pattern, where "*" matches any sequence of characters.
+func matches(pattern, id string) bool {
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+
+	matched, err := regexp.MatchString(expr, id)
+
+	return err == nil && matched
+}
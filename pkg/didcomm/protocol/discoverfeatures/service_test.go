@@ -0,0 +1,393 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discoverfeatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	tictactoe10 = "https://didcomm.org/tictactoe/1.0"
+	tictactoe11 = "https://didcomm.org/tictactoe/1.1"
+	tictactoe20 = "https://didcomm.org/tictactoe/2.0"
+	sellGoods   = "org.didcomm.sell.goods.consumer"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("returns the service", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+		require.Equal(t, Name, s.Name())
+		require.Equal(t, []Feature{{
+			FeatureType: FeatureTypeProtocol,
+			ID:          PIURI,
+			Roles:       []string{RoleRequester, RoleResponder},
+		}}, s.Disclose(Query{FeatureType: FeatureTypeProtocol, Match: "*"}))
+	})
+
+	t.Run("fails to open the connection store", func(t *testing.T) {
+		provider := newTestProvider(nil)
+		provider.storeProvider = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("test")}
+
+		_, err := New(provider)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open a connection.Lookup")
+	})
+}
+
+func TestAccept(t *testing.T) {
+	s, err := New(newTestProvider(nil))
+	require.NoError(t, err)
+
+	require.True(t, s.Accept(QueriesMsgType))
+	require.True(t, s.Accept(DiscloseMsgType))
+	require.False(t, s.Accept("https://didcomm.org/discover-features/1.0/query"))
+}
+
+func TestHandleOutbound(t *testing.T) {
+	s, err := New(newTestProvider(nil))
+	require.NoError(t, err)
+
+	_, err = s.HandleOutbound(service.NewDIDCommMsgMap(&Queries{Type: QueriesMsgType}), "", "")
+	require.EqualError(t, err, "not implemented: use Query")
+}
+
+func TestDisclose(t *testing.T) {
+	s, err := New(newTestProvider(nil))
+	require.NoError(t, err)
+
+	s.RegisterProtocol(tictactoe10, "player")
+	s.RegisterProtocol(tictactoe11, "player")
+	s.RegisterProtocol(tictactoe20, "player")
+	s.RegisterGoalCode(sellGoods)
+
+	t.Run("wildcard protocol query", func(t *testing.T) {
+		require.Equal(t, []Feature{
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe10, Roles: []string{"player"}},
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe11, Roles: []string{"player"}},
+		}, s.Disclose(Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/tictactoe/1.*"}))
+	})
+
+	t.Run("exact protocol query", func(t *testing.T) {
+		require.Equal(t, []Feature{
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe20, Roles: []string{"player"}},
+		}, s.Disclose(Query{FeatureType: FeatureTypeProtocol, Match: tictactoe20}))
+	})
+
+	t.Run("goal-code query", func(t *testing.T) {
+		require.Equal(t, []Feature{
+			{FeatureType: FeatureTypeGoalCode, ID: sellGoods},
+		}, s.Disclose(Query{FeatureType: FeatureTypeGoalCode, Match: "org.didcomm.*"}))
+	})
+
+	t.Run("match is not a regular expression", func(t *testing.T) {
+		require.Empty(t, s.Disclose(Query{FeatureType: FeatureTypeGoalCode, Match: "org.didcomm.sell.goods.+"}))
+	})
+
+	t.Run("unknown feature type", func(t *testing.T) {
+		require.Empty(t, s.Disclose(Query{FeatureType: "header", Match: "*"}))
+	})
+
+	t.Run("registering a feature again replaces it", func(t *testing.T) {
+		s.RegisterProtocol(tictactoe20, "player", "observer")
+
+		require.Equal(t, []Feature{
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe20, Roles: []string{"player", "observer"}},
+		}, s.Disclose(Query{FeatureType: FeatureTypeProtocol, Match: tictactoe20}))
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("wildcard queries over a connection", func(t *testing.T) {
+		network := &testNetwork{agents: map[string]*Service{}}
+		alice := network.newAgent(t, "did:example:alice")
+		bob := network.newAgent(t, "did:example:bob")
+
+		bob.svc.RegisterProtocol(tictactoe10, "player")
+		bob.svc.RegisterProtocol(tictactoe11, "player")
+		bob.svc.RegisterProtocol(tictactoe20, "player")
+		bob.svc.RegisterGoalCode(sellGoods)
+
+		connID := connect(t, alice, "did:example:alice", "did:example:bob")
+
+		features, err := alice.svc.Query(connID,
+			Query{FeatureType: FeatureTypeProtocol, Match: "https://didcomm.org/tictactoe/1.*"},
+			Query{FeatureType: FeatureTypeGoalCode, Match: "org.didcomm.*"},
+		)
+		require.NoError(t, err)
+		require.Equal(t, []Feature{
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe10, Roles: []string{"player"}},
+			{FeatureType: FeatureTypeProtocol, ID: tictactoe11, Roles: []string{"player"}},
+			{FeatureType: FeatureTypeGoalCode, ID: sellGoods},
+		}, features)
+
+		// the disclose was correlated with the queries by the thread ID.
+		require.Empty(t, alice.svc.discloseMap)
+	})
+
+	t.Run("nothing matches", func(t *testing.T) {
+		network := &testNetwork{agents: map[string]*Service{}}
+		alice := network.newAgent(t, "did:example:alice")
+		network.newAgent(t, "did:example:bob")
+
+		connID := connect(t, alice, "did:example:alice", "did:example:bob")
+
+		features, err := alice.svc.Query(connID, Query{FeatureType: FeatureTypeProtocol, Match: tictactoe10})
+		require.NoError(t, err)
+		require.Empty(t, features)
+	})
+
+	t.Run("no queries", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.Query("connection")
+		require.EqualError(t, err, "no queries")
+	})
+
+	t.Run("connection not found", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.Query("unknown", Query{FeatureType: FeatureTypeProtocol, Match: "*"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get connection record")
+	})
+
+	t.Run("fails to send the queries", func(t *testing.T) {
+		network := &testNetwork{agents: map[string]*Service{}}
+		alice := network.newAgent(t, "did:example:alice")
+
+		connID := connect(t, alice, "did:example:alice", "did:example:bob")
+
+		_, err := alice.svc.Query(connID, Query{FeatureType: FeatureTypeProtocol, Match: "*"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send queries")
+		require.Empty(t, alice.svc.discloseMap)
+	})
+
+	t.Run("no disclose before the query timeout", func(t *testing.T) {
+		provider := newTestProvider(&silentMessenger{})
+
+		s, err := New(provider, WithQueryTimeout(10*time.Millisecond))
+		require.NoError(t, err)
+
+		connections, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+
+		connID := connect(t, &testAgent{svc: s, connections: connections}, "did:example:alice", "did:example:bob")
+
+		_, err = s.Query(connID, Query{FeatureType: FeatureTypeProtocol, Match: "*"})
+		require.EqualError(t, err, "timeout waiting for disclose")
+		require.Empty(t, s.discloseMap)
+	})
+}
+
+func TestHandleInbound(t *testing.T) {
+	t.Run("unsupported message type", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Queries{Type: "unknown"}), service.EmptyDIDCommContext())
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+
+	t.Run("queries decode error", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.DIDCommMsgMap{"id": "1", "type": QueriesMsgType, "body": "invalid"},
+			service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode queries message")
+	})
+
+	t.Run("fails to send the disclose", func(t *testing.T) {
+		s, err := New(newTestProvider(&testMessenger{network: &testNetwork{agents: map[string]*Service{}}}))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Queries{ID: "1", Type: QueriesMsgType}),
+			service.NewDIDCommContext("did:example:bob", "did:example:alice", nil))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "send disclose")
+	})
+
+	t.Run("disclose decode error", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.DIDCommMsgMap{"id": "1", "type": DiscloseMsgType, "body": "invalid"},
+			service.EmptyDIDCommContext())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode disclose message")
+	})
+
+	t.Run("disclose without a query", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Disclose{
+			ID:       uuid.New().String(),
+			Type:     DiscloseMsgType,
+			ThreadID: uuid.New().String(),
+		}), service.EmptyDIDCommContext())
+		require.NoError(t, err)
+	})
+
+	t.Run("duplicate disclose", func(t *testing.T) {
+		s, err := New(newTestProvider(nil))
+		require.NoError(t, err)
+
+		thID := uuid.New().String()
+		discloseCh := make(chan Disclose, 1)
+		s.setDiscloseCh(thID, discloseCh)
+
+		for i := 0; i < 2; i++ {
+			_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Disclose{
+				ID:       uuid.New().String(),
+				Type:     DiscloseMsgType,
+				ThreadID: thID,
+			}), service.EmptyDIDCommContext())
+			require.NoError(t, err)
+		}
+
+		require.Len(t, discloseCh, 1)
+	})
+}
+
+// connect saves a completed connection in the agent's store and returns its ID.
+func connect(t *testing.T, a *testAgent, myDID, theirDID string) string {
+	t.Helper()
+
+	record := &connection.Record{
+		ConnectionID:   uuid.New().String(),
+		State:          connection.StateNameCompleted,
+		MyDID:          myDID,
+		TheirDID:       theirDID,
+		DIDCommVersion: connection.DIDCommV2,
+	}
+	require.NoError(t, a.connections.SaveConnectionRecord(record))
+
+	return record.ConnectionID
+}
+
+// testNetwork delivers messages between the agents by their DIDs.
+type testNetwork struct {
+	agents map[string]*Service
+}
+
+type testAgent struct {
+	svc         *Service
+	connections *connection.Recorder
+}
+
+func (n *testNetwork) newAgent(t *testing.T, didID string) *testAgent {
+	t.Helper()
+
+	provider := newTestProvider(&testMessenger{network: n})
+
+	svc, err := New(provider)
+	require.NoError(t, err)
+
+	connections, err := connection.NewRecorder(provider)
+	require.NoError(t, err)
+
+	n.agents[didID] = svc
+
+	return &testAgent{svc: svc, connections: connections}
+}
+
+// silentMessenger sends the messages nowhere.
+type silentMessenger struct {
+	service.Messenger
+}
+
+func (m *silentMessenger) Send(service.DIDCommMsgMap, string, string) error {
+	return nil
+}
+
+// testMessenger sends the messages through the testNetwork.
+type testMessenger struct {
+	service.Messenger
+	network *testNetwork
+}
+
+func (m *testMessenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	msg["thid"] = msg.ID()
+
+	return m.deliver(msg, myDID, theirDID)
+}
+
+func (m *testMessenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDID string) error {
+	thID, err := in.ThreadID()
+	if err != nil {
+		return err
+	}
+
+	out["thid"] = thID
+
+	return m.deliver(out, myDID, theirDID)
+}
+
+func (m *testMessenger) deliver(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+	recipient, ok := m.network.agents[theirDID]
+	if !ok {
+		return fmt.Errorf("unknown DID %s", theirDID)
+	}
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	inbound, err := service.ParseDIDCommMsgMap(raw)
+	if err != nil {
+		return err
+	}
+
+	_, err = recipient.HandleInbound(inbound, service.NewDIDCommContext(theirDID, myDID, nil))
+
+	return err
+}
+
+type testProvider struct {
+	storeProvider              storage.Provider
+	protocolStateStoreProvider storage.Provider
+	messenger                  service.Messenger
+}
+
+func newTestProvider(messenger service.Messenger) *testProvider {
+	return &testProvider{
+		storeProvider:              mockstore.NewMockStoreProvider(),
+		protocolStateStoreProvider: mockstore.NewMockStoreProvider(),
+		messenger:                  messenger,
+	}
+}
+
+func (p *testProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *testProvider) StorageProvider() storage.Provider {
+	return p.storeProvider
+}
+
+func (p *testProvider) ProtocolStateStorageProvider() storage.Provider {
+	return p.protocolStateStoreProvider
+}
@@ -329,15 +329,13 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
 	// trigger the message events
-	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Introduce,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
-	}
+	s.PublishMsgEvent(service.StateMsg{
+		ProtocolName: Introduce,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	})
 }
 
 // newDIDCommActionMsg creates new DIDCommAction message.
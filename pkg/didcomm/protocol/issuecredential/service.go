@@ -37,6 +37,28 @@ const (
 	ProblemReportMsgType = Spec + "problem-report"
 	// CredentialPreviewMsgType defines the protocol credential-preview inner object type.
 	CredentialPreviewMsgType = Spec + "credential-preview"
+
+	// SpecV3 defines the protocol spec V3 (DIDComm V2).
+	SpecV3 = "https://didcomm.org/issue-credential/3.0/"
+	// ProposeCredentialMsgTypeV3 defines the protocol propose-credential message type.
+	ProposeCredentialMsgTypeV3 = SpecV3 + "propose-credential"
+	// OfferCredentialMsgTypeV3 defines the protocol offer-credential message type.
+	OfferCredentialMsgTypeV3 = SpecV3 + "offer-credential"
+	// RequestCredentialMsgTypeV3 defines the protocol request-credential message type.
+	RequestCredentialMsgTypeV3 = SpecV3 + "request-credential"
+	// IssueCredentialMsgTypeV3 defines the protocol issue-credential message type.
+	IssueCredentialMsgTypeV3 = SpecV3 + "issue-credential"
+	// AckMsgTypeV3 defines the protocol ack message type.
+	AckMsgTypeV3 = SpecV3 + "ack"
+	// ProblemReportMsgTypeV3 defines the protocol problem-report message type.
+	ProblemReportMsgTypeV3 = SpecV3 + "problem-report"
+	// CredentialPreviewMsgTypeV3 defines the protocol credential-preview inner object type.
+	CredentialPreviewMsgTypeV3 = SpecV3 + "credential-preview"
+
+	// LDProofVCFormat is the attachment format of a JSON-LD credential secured with a Linked Data proof.
+	LDProofVCFormat = "aries/ld-proof-vc@v1.0"
+	// JWTVCFormat is the attachment format of a credential secured as a JWT.
+	JWTVCFormat = "jwt_vc"
 )
 
 const (
@@ -72,10 +94,14 @@ type MetaData struct {
 	credentialNames []string
 	// keeps offer credential payload,
 	// allows filling the message by providing an option function.
-	offerCredential   *OfferCredential
-	proposeCredential *ProposeCredential
-	requestCredential *RequestCredential
-	issueCredential   *IssueCredential
+	offerCredential     *OfferCredential
+	proposeCredential   *ProposeCredential
+	requestCredential   *RequestCredential
+	issueCredential     *IssueCredential
+	offerCredentialV3   *OfferCredentialV3
+	proposeCredentialV3 *ProposeCredentialV3
+	requestCredentialV3 *RequestCredentialV3
+	issueCredentialV3   *IssueCredentialV3
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function.
@@ -107,6 +133,26 @@ func (md *MetaData) IssueCredential() *IssueCredential {
 	return md.issueCredential
 }
 
+// OfferCredentialV3 didcomm message.
+func (md *MetaData) OfferCredentialV3() *OfferCredentialV3 {
+	return md.offerCredentialV3
+}
+
+// ProposeCredentialV3 didcomm message.
+func (md *MetaData) ProposeCredentialV3() *ProposeCredentialV3 {
+	return md.proposeCredentialV3
+}
+
+// RequestCredentialV3 didcomm message.
+func (md *MetaData) RequestCredentialV3() *RequestCredentialV3 {
+	return md.requestCredentialV3
+}
+
+// IssueCredentialV3 didcomm message.
+func (md *MetaData) IssueCredentialV3() *IssueCredentialV3 {
+	return md.issueCredentialV3
+}
+
 // CredentialNames are the names with which to save credentials with.
 func (md *MetaData) CredentialNames() []string {
 	return md.credentialNames
@@ -166,6 +212,38 @@ func WithIssueCredential(msg *IssueCredential) Opt {
 	}
 }
 
+// WithProposeCredentialV3 allows providing ProposeCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithProposeCredentialV3(msg *ProposeCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.proposeCredentialV3 = msg
+	}
+}
+
+// WithRequestCredentialV3 allows providing RequestCredentialV3 message
+// USAGE: This message should be provided after receiving an OfferCredentialV3 message.
+func WithRequestCredentialV3(msg *RequestCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.requestCredentialV3 = msg
+	}
+}
+
+// WithOfferCredentialV3 allows providing OfferCredentialV3 message
+// USAGE: This message should be provided after receiving a ProposeCredentialV3 message.
+func WithOfferCredentialV3(msg *OfferCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.offerCredentialV3 = msg
+	}
+}
+
+// WithIssueCredentialV3 allows providing IssueCredentialV3 message
+// USAGE: This message should be provided after receiving a RequestCredentialV3 message.
+func WithIssueCredentialV3(msg *IssueCredentialV3) Opt {
+	return func(md *MetaData) {
+		md.issueCredentialV3 = msg
+	}
+}
+
 // WithFriendlyNames allows providing names for the credentials.
 // USAGE: This function should be used when the Holder receives IssueCredential message.
 func WithFriendlyNames(names ...string) Opt {
@@ -460,29 +538,29 @@ func stateFromName(name string) state {
 
 func nextState(msg service.DIDCommMsg, outbound bool) (state, error) {
 	switch msg.Type() {
-	case ProposeCredentialMsgType:
+	case ProposeCredentialMsgType, ProposeCredentialMsgTypeV3:
 		if outbound {
 			return &proposalSent{}, nil
 		}
 
 		return &proposalReceived{}, nil
-	case OfferCredentialMsgType:
+	case OfferCredentialMsgType, OfferCredentialMsgTypeV3:
 		if outbound {
 			return &offerSent{}, nil
 		}
 
 		return &offerReceived{}, nil
-	case RequestCredentialMsgType:
+	case RequestCredentialMsgType, RequestCredentialMsgTypeV3:
 		if outbound {
 			return &requestSent{}, nil
 		}
 
 		return &requestReceived{}, nil
-	case IssueCredentialMsgType:
+	case IssueCredentialMsgType, IssueCredentialMsgTypeV3:
 		return &credentialReceived{}, nil
-	case ProblemReportMsgType:
+	case ProblemReportMsgType, ProblemReportMsgTypeV3:
 		return &abandoning{}, nil
-	case AckMsgType:
+	case AckMsgType, AckMsgTypeV3:
 		return &done{}, nil
 	default:
 		return nil, fmt.Errorf("unrecognized msgType: %s", msg.Type())
@@ -500,11 +578,15 @@ func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) e
 
 // canTriggerActionEvents checks if the incoming message can trigger an action event.
 func canTriggerActionEvents(msg service.DIDCommMsg) bool {
-	return msg.Type() == ProposeCredentialMsgType ||
-		msg.Type() == OfferCredentialMsgType ||
-		msg.Type() == IssueCredentialMsgType ||
-		msg.Type() == RequestCredentialMsgType ||
-		msg.Type() == ProblemReportMsgType
+	switch msg.Type() {
+	case ProposeCredentialMsgType, OfferCredentialMsgType, IssueCredentialMsgType,
+		RequestCredentialMsgType, ProblemReportMsgType,
+		ProposeCredentialMsgTypeV3, OfferCredentialMsgTypeV3, IssueCredentialMsgTypeV3,
+		RequestCredentialMsgTypeV3, ProblemReportMsgTypeV3:
+		return true
+	}
+
+	return false
 }
 
 func (s *Service) getTransitionalPayload(id string) (*transitionalPayload, error) {
@@ -685,15 +767,13 @@ func (s *Service) execute(next state, md *MetaData) (state, stateAction, error)
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *MetaData, stateID string, stateType service.StateMsgType) {
 	// trigger the message events
-	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Name,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
-	}
+	s.PublishMsgEvent(service.StateMsg{
+		ProtocolName: Name,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	})
 }
 
 // Name returns service name.
@@ -705,7 +785,9 @@ func (s *Service) Name() string {
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case ProposeCredentialMsgType, OfferCredentialMsgType, RequestCredentialMsgType,
-		IssueCredentialMsgType, AckMsgType, ProblemReportMsgType:
+		IssueCredentialMsgType, AckMsgType, ProblemReportMsgType,
+		ProposeCredentialMsgTypeV3, OfferCredentialMsgTypeV3, RequestCredentialMsgTypeV3,
+		IssueCredentialMsgTypeV3, AckMsgTypeV3, ProblemReportMsgTypeV3:
 		return true
 	}
 
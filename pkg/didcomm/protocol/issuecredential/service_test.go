@@ -60,6 +60,11 @@ func TestService_Use(t *testing.T) {
 			issueCredential:   &IssueCredential{Type: IssueCredentialMsgType},
 			requestCredential: &RequestCredential{Type: RequestCredentialMsgType},
 			credentialNames:   []string{"name"},
+
+			offerCredentialV3:   &OfferCredentialV3{Type: OfferCredentialMsgTypeV3},
+			proposeCredentialV3: &ProposeCredentialV3{Type: ProposeCredentialMsgTypeV3},
+			issueCredentialV3:   &IssueCredentialV3{Type: IssueCredentialMsgTypeV3},
+			requestCredentialV3: &RequestCredentialV3{Type: RequestCredentialMsgTypeV3},
 		}
 		var executed bool
 		svc.Use(func(next Handler) Handler {
@@ -72,6 +77,10 @@ func TestService_Use(t *testing.T) {
 				require.Equal(t, meta.requestCredential, metadata.RequestCredential())
 				require.Equal(t, meta.credentialNames, metadata.CredentialNames())
 				require.Equal(t, meta.state.Name(), metadata.StateName())
+				require.Equal(t, meta.offerCredentialV3, metadata.OfferCredentialV3())
+				require.Equal(t, meta.proposeCredentialV3, metadata.ProposeCredentialV3())
+				require.Equal(t, meta.issueCredentialV3, metadata.IssueCredentialV3())
+				require.Equal(t, meta.requestCredentialV3, metadata.RequestCredentialV3())
 
 				executed = true
 				return next.Handle(metadata)
@@ -887,6 +896,69 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Problem Report mid-protocol", func(t *testing.T) {
+		done := make(chan struct{})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			defer close(done)
+
+			require.Equal(t, "done", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		chState := make(chan service.StateMsg, 4)
+		require.NoError(t, svc.RegisterMsgEvent(chState))
+
+		msg := service.NewDIDCommMsgMap(model.ProblemReport{
+			Type:        ProblemReportMsgType,
+			Description: model.Code{Code: "issuance-abandoned"},
+			Comment:     "the credential can't be issued",
+		})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		properties, ok := action.Properties.(*eventProps)
+		require.True(t, ok)
+		require.Equal(t, &model.ProblemReportDetails{
+			Code:     "issuance-abandoned",
+			Comment:  "the credential can't be issued",
+			ThreadID: thID,
+		}, properties.ProblemReport())
+
+		action.Continue(nil)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		for _, stateID := range []string{stateNameAbandoning, stateNameAbandoning, stateNameDone, stateNameDone} {
+			stateMsg := <-chState
+			require.Equal(t, stateID, stateMsg.StateID)
+			require.Equal(t, "issuance-abandoned", stateMsg.Properties.All()[model.ProblemCodePropKey])
+			require.Equal(t, "the credential can't be issued", stateMsg.Properties.All()[model.ProblemCommentPropKey])
+			require.Equal(t, thID, stateMsg.Properties.All()[model.ProblemThreadIDPropKey])
+		}
+	})
+
 	t.Run("Receive Issue Credential Continue", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -1059,6 +1131,218 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Propose Credential Continue (V3)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &OfferCredentialV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, OfferCredentialMsgTypeV3, r.Type)
+				require.Equal(t, JWTVCFormat, r.Attachments[0].Format)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "offer-sent", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredentialV3{
+			ID:   uuid.New().String(),
+			Type: ProposeCredentialMsgTypeV3,
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		properties, ok := action.Properties.(*eventProps)
+		require.True(t, ok)
+		require.NotEmpty(t, properties.PIID())
+
+		action.Continue(WithOfferCredentialV3(&OfferCredentialV3{
+			Attachments: []decorator.AttachmentV2{{Format: JWTVCFormat}},
+		}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Propose Credential with unsupported format (V3)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReportV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeInternalError, r.Body.Code)
+				require.Equal(t, ProblemReportMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "done", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		svc.Use(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				if metadata.StateName() == stateNameProposalReceived {
+					return errors.New("unsupported credential format")
+				}
+
+				return next.Handle(metadata)
+			})
+		})
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(ProposeCredentialV3{
+			ID:          uuid.New().String(),
+			Type:        ProposeCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{Format: "hlindy/cred@v2.0"}},
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(WithOfferCredentialV3(&OfferCredentialV3{}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Request Credential Continue (V3)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &IssueCredentialV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, IssueCredentialMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("offer-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "credential-issued", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(RequestCredentialV3{
+			ID:   uuid.New().String(),
+			Type: RequestCredentialMsgTypeV3,
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(WithIssueCredentialV3(&IssueCredentialV3{}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Issue Credential Continue (V3)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &model.AckV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, AckMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return([]byte("request-sent"), nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, name []byte) error {
+			require.Equal(t, "done", string(name))
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(IssueCredentialV3{
+			ID:   uuid.New().String(),
+			Type: IssueCredentialMsgTypeV3,
+		})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+		action.Continue(nil)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
 	t.Run("Invalid state transition", func(t *testing.T) {
 		svc, err := New(provider)
 		require.NoError(t, err)
@@ -1461,6 +1745,44 @@ func Test_nextState(t *testing.T) {
 	require.Nil(t, next)
 }
 
+func Test_nextStateV3(t *testing.T) {
+	next, err := nextState(service.NewDIDCommMsgMap(ProposeCredentialV3{
+		Type: ProposeCredentialMsgTypeV3,
+	}), true)
+	require.NoError(t, err)
+	require.Equal(t, next, &proposalSent{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(OfferCredentialV3{
+		Type: OfferCredentialMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &offerReceived{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(RequestCredentialV3{
+		Type: RequestCredentialMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &requestReceived{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(IssueCredentialV3{
+		Type: IssueCredentialMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &credentialReceived{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(model.AckV2{
+		Type: AckMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &done{})
+
+	next, err = nextState(service.NewDIDCommMsgMap(model.ProblemReportV2{
+		Type: ProblemReportMsgTypeV3,
+	}), false)
+	require.NoError(t, err)
+	require.Equal(t, next, &abandoning{})
+}
+
 func TestService_Name(t *testing.T) {
 	require.Equal(t, (*Service).Name(nil), Name)
 }
@@ -1472,6 +1794,12 @@ func TestService_Accept(t *testing.T) {
 	require.True(t, (*Service).Accept(nil, IssueCredentialMsgType))
 	require.True(t, (*Service).Accept(nil, AckMsgType))
 	require.True(t, (*Service).Accept(nil, ProblemReportMsgType))
+	require.True(t, (*Service).Accept(nil, ProposeCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, OfferCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, RequestCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, IssueCredentialMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, AckMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, ProblemReportMsgTypeV3))
 	require.False(t, (*Service).Accept(nil, "unknown"))
 }
 
@@ -1492,5 +1820,25 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 		Type: RequestCredentialMsgType,
 	})))
 
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(ProposeCredentialV3{
+		Type: ProposeCredentialMsgTypeV3,
+	})))
+
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(OfferCredentialV3{
+		Type: OfferCredentialMsgTypeV3,
+	})))
+
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(IssueCredentialV3{
+		Type: IssueCredentialMsgTypeV3,
+	})))
+
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(RequestCredentialV3{
+		Type: RequestCredentialMsgTypeV3,
+	})))
+
+	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(model.AckV2{
+		Type: AckMsgTypeV3,
+	})))
+
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
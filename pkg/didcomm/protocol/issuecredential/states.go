@@ -9,6 +9,7 @@ package issuecredential
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
@@ -57,6 +58,18 @@ type state interface {
 // represents zero state's action.
 func zeroAction(service.Messenger) error { return nil }
 
+// isV3 checks whether the message belongs to the issue-credential 3.0 (DIDComm V2) protocol.
+func isV3(msg service.DIDCommMsg) bool {
+	return strings.HasPrefix(msg.Type(), SpecV3)
+}
+
+// replyAction creates the state's action which replies to the inbound message with the given one.
+func replyAction(md *MetaData, msg interface{}) stateAction {
+	return func(messenger service.Messenger) error {
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(msg), md.MyDID, md.TheirDID)
+	}
+}
+
 // noOp state.
 type noOp struct{}
 
@@ -120,7 +133,7 @@ func (s *abandoning) CanTransitionTo(st state) bool {
 func (s *abandoning) ExecuteInbound(md *MetaData) (state, stateAction, error) {
 	// if code is not provided it means we do not need to notify the another agent.
 	// if we received ProblemReport message no need to answer.
-	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType {
+	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType || md.Msg.Type() == ProblemReportMsgTypeV3 {
 		return &done{}, zeroAction, nil
 	}
 
@@ -136,11 +149,21 @@ func (s *abandoning) ExecuteInbound(md *MetaData) (state, stateAction, error) {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
 	}
 
+	problemReport := service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        ProblemReportMsgType,
+		Description: code,
+	})
+
+	if isV3(md.Msg) {
+		problemReport = service.NewDIDCommMsgMap(&model.ProblemReportV2{
+			Type: ProblemReportMsgTypeV3,
+			Body: model.ProblemReportV2Body{Code: code.Code},
+		})
+	}
+
 	return &done{}, func(messenger service.Messenger) error {
-		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
-			Type:        ProblemReportMsgType,
-			Description: code,
-		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+		return messenger.ReplyToNested(problemReport,
+			&service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
 	}, nil
 }
 
@@ -200,6 +223,16 @@ func (s *offerSent) CanTransitionTo(st state) bool {
 }
 
 func (s *offerSent) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		if md.offerCredentialV3 == nil {
+			return nil, nil, errors.New("offer credential was not provided")
+		}
+
+		md.offerCredentialV3.Type = OfferCredentialMsgTypeV3
+
+		return &noOp{}, replyAction(md, md.offerCredentialV3), nil
+	}
+
 	if md.offerCredential == nil {
 		return nil, nil, errors.New("offer credential was not provided")
 	}
@@ -235,6 +268,16 @@ func (s *requestReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *requestReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		if md.issueCredentialV3 == nil {
+			return nil, nil, errors.New("issue credential was not provided")
+		}
+
+		md.issueCredentialV3.Type = IssueCredentialMsgTypeV3
+
+		return &credentialIssued{}, replyAction(md, md.issueCredentialV3), nil
+	}
+
 	if md.issueCredential == nil {
 		return nil, nil, errors.New("issue credential was not provided")
 	}
@@ -284,6 +327,16 @@ func (s *proposalSent) CanTransitionTo(st state) bool {
 }
 
 func (s *proposalSent) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		if md.proposeCredentialV3 == nil {
+			return nil, nil, errors.New("propose credential was not provided")
+		}
+
+		md.proposeCredentialV3.Type = ProposeCredentialMsgTypeV3
+
+		return &noOp{}, replyAction(md, md.proposeCredentialV3), nil
+	}
+
 	if md.proposeCredential == nil {
 		return nil, nil, errors.New("propose credential was not provided")
 	}
@@ -321,6 +374,10 @@ func (s *offerReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *offerReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		return s.executeInboundV3(md)
+	}
+
 	// sends propose credential if it was provided
 	if md.proposeCredential != nil {
 		return &proposalSent{}, zeroAction, nil
@@ -350,6 +407,31 @@ func (s *offerReceived) ExecuteInbound(md *MetaData) (state, stateAction, error)
 	return &requestSent{}, action, nil
 }
 
+func (s *offerReceived) executeInboundV3(md *MetaData) (state, stateAction, error) {
+	// sends propose credential if it was provided
+	if md.proposeCredentialV3 != nil {
+		return &proposalSent{}, zeroAction, nil
+	}
+
+	offer := OfferCredentialV3{}
+	if err := md.Msg.Decode(&offer); err != nil {
+		return nil, nil, fmt.Errorf("decode: %w", err)
+	}
+
+	// requests the credentials in the offered formats unless the Holder provided its own request.
+	response := &RequestCredentialV3{
+		Type:        RequestCredentialMsgTypeV3,
+		Attachments: offer.Attachments,
+	}
+
+	if md.RequestCredentialV3() != nil {
+		response = md.RequestCredentialV3()
+		response.Type = RequestCredentialMsgTypeV3
+	}
+
+	return &requestSent{}, replyAction(md, response), nil
+}
+
 func (s *offerReceived) ExecuteOutbound(_ *MetaData) (state, stateAction, error) {
 	return nil, nil, fmt.Errorf("%s: ExecuteOutbound is not implemented yet", s.Name())
 }
@@ -390,6 +472,10 @@ func (s *credentialReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *credentialReceived) ExecuteInbound(md *MetaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		return &done{}, replyAction(md, model.AckV2{Type: AckMsgTypeV3}), nil
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
 		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(model.Ack{
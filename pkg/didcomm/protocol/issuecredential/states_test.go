@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 )
 
@@ -613,3 +614,282 @@ func TestCredentialReceived_ExecuteOutbound(t *testing.T) {
 	require.Nil(t, followup)
 	require.Nil(t, action)
 }
+
+func randomInboundMessageV3(t string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID       string `json:"id"`
+		ThreadID string `json:"thid"`
+		Type     string `json:"type"`
+	}{
+		ID:       uuid.New().String(),
+		ThreadID: uuid.New().String(),
+		Type:     t,
+	})
+}
+
+func TestAbandoning_ExecuteInboundV3(t *testing.T) {
+	t.Run("With code", func(t *testing.T) {
+		md := &MetaData{}
+		md.Msg = randomInboundMessageV3(RequestCredentialMsgTypeV3)
+
+		followup, action, err := (&abandoning{Code: codeInternalError}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReportV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeInternalError, r.Body.Code)
+				require.Equal(t, ProblemReportMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Problem report received", func(t *testing.T) {
+		md := &MetaData{}
+		md.Msg = randomInboundMessageV3(ProblemReportMsgTypeV3)
+
+		followup, action, err := (&abandoning{Code: codeInternalError}).ExecuteInbound(md)
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+		require.NoError(t, action(nil))
+	})
+}
+
+func TestOfferSent_ExecuteInboundV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&offerSent{}).ExecuteInbound(&MetaData{
+			offerCredentialV3: &OfferCredentialV3{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(ProposeCredentialMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, OfferCredentialMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("OfferCredential is absent", func(t *testing.T) {
+		followup, action, err := (&offerSent{}).ExecuteInbound(&MetaData{
+			offerCredential: &OfferCredential{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(ProposeCredentialMsgTypeV3),
+			}},
+		})
+		require.EqualError(t, err, "offer credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
+func TestRequestReceived_ExecuteInboundV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&requestReceived{}).ExecuteInbound(&MetaData{
+			issueCredentialV3: &IssueCredentialV3{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestCredentialMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &credentialIssued{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, IssueCredentialMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("IssueCredential is absent", func(t *testing.T) {
+		followup, action, err := (&requestReceived{}).ExecuteInbound(&MetaData{
+			issueCredential: &IssueCredential{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestCredentialMsgTypeV3),
+			}},
+		})
+		require.EqualError(t, err, "issue credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
+func TestProposalSent_ExecuteInboundV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&proposalSent{}).ExecuteInbound(&MetaData{
+			proposeCredentialV3: &ProposeCredentialV3{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(OfferCredentialMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, ProposeCredentialMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("ProposeCredential is absent", func(t *testing.T) {
+		followup, action, err := (&proposalSent{}).ExecuteInbound(&MetaData{
+			proposeCredential: &ProposeCredential{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(OfferCredentialMsgTypeV3),
+			}},
+		})
+		require.EqualError(t, err, "propose credential was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
+func TestOfferReceived_ExecuteInboundV3(t *testing.T) {
+	t.Run("With ProposeCredential", func(t *testing.T) {
+		followup, action, err := (&offerReceived{}).ExecuteInbound(&MetaData{
+			proposeCredentialV3: &ProposeCredentialV3{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(OfferCredentialMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &proposalSent{}, followup)
+		require.NoError(t, action(nil))
+	})
+
+	t.Run("Requests the offered credentials", func(t *testing.T) {
+		msg := service.NewDIDCommMsgMap(OfferCredentialV3{
+			ID:   uuid.New().String(),
+			Type: OfferCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				ID:     uuid.New().String(),
+				Format: JWTVCFormat,
+			}},
+		})
+
+		followup, action, err := (&offerReceived{}).ExecuteInbound(&MetaData{
+			transitionalPayload: transitionalPayload{Action: Action{Msg: msg}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &requestSent{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				request := RequestCredentialV3{}
+				require.NoError(t, msg.Decode(&request))
+				require.Equal(t, RequestCredentialMsgTypeV3, request.Type)
+				require.Len(t, request.Attachments, 1)
+				require.Equal(t, JWTVCFormat, request.Attachments[0].Format)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("With RequestCredential", func(t *testing.T) {
+		followup, action, err := (&offerReceived{}).ExecuteInbound(&MetaData{
+			requestCredentialV3: &RequestCredentialV3{Body: RequestCredentialV3Body{Comment: "comment"}},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(OfferCredentialMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &requestSent{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				request := RequestCredentialV3{}
+				require.NoError(t, msg.Decode(&request))
+				require.Equal(t, RequestCredentialMsgTypeV3, request.Type)
+				require.Equal(t, "comment", request.Body.Comment)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		followup, action, err := (&offerReceived{}).ExecuteInbound(&MetaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: service.DIDCommMsgMap{"type": OfferCredentialMsgTypeV3, "body": "invalid"},
+			}},
+		})
+		require.Contains(t, fmt.Sprintf("%v", err), "decode")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
+func TestCredentialReceived_ExecuteInboundV3(t *testing.T) {
+	followup, action, err := (&credentialReceived{}).ExecuteInbound(&MetaData{
+		transitionalPayload: transitionalPayload{Action: Action{
+			Msg: randomInboundMessageV3(IssueCredentialMsgTypeV3),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+	require.NotNil(t, action)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			require.Equal(t, AckMsgTypeV3, msg.Type())
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
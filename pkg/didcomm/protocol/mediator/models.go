@@ -55,3 +55,133 @@ type UpdateResponse struct {
 	Action       string `json:"action,omitempty"`
 	Result       string `json:"result,omitempty"`
 }
+
+// KeylistQuery route keylist query message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list-query
+type KeylistQuery struct {
+	Type     string    `json:"@type,omitempty"`
+	ID       string    `json:"@id,omitempty"`
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// Paginate key list query pagination.
+type Paginate struct {
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// Keylist route keylist message.
+// https://github.com/hyperledger/aries-rfcs/tree/master/features/0211-route-coordination#key-list
+type Keylist struct {
+	Type       string       `json:"@type,omitempty"`
+	ID         string       `json:"@id,omitempty"`
+	Keys       []KeylistKey `json:"keys,omitempty"`
+	Pagination *Pagination  `json:"pagination,omitempty"`
+}
+
+// KeylistKey route key entry of the key list message.
+type KeylistKey struct {
+	RecipientKey string `json:"recipient_key,omitempty"`
+}
+
+// Pagination key list pagination details.
+type Pagination struct {
+	Count     int `json:"count"`
+	Offset    int `json:"offset"`
+	Remaining int `json:"remaining"`
+}
+
+// RequestV2 coordinate mediation 2.0 mediate request message.
+// https://didcomm.org/coordinate-mediation/2.0/
+type RequestV2 struct {
+	Type        string        `json:"type,omitempty"`
+	ID          string        `json:"id,omitempty"`
+	ExpiresTime int64         `json:"expires_time,omitempty"`
+	Body        RequestV2Body `json:"body"`
+}
+
+// RequestV2Body represents body for RequestV2, the mediate request has no content.
+type RequestV2Body struct{}
+
+// GrantV2 coordinate mediation 2.0 mediate grant message.
+type GrantV2 struct {
+	Type     string      `json:"type,omitempty"`
+	ID       string      `json:"id,omitempty"`
+	ThreadID string      `json:"thid,omitempty"`
+	Body     GrantV2Body `json:"body"`
+}
+
+// GrantV2Body represents body for GrantV2.
+type GrantV2Body struct {
+	// RoutingDID DIDs the mediated agent uses as routing keys in its DIDComm services.
+	RoutingDID []string `json:"routing_did,omitempty"`
+}
+
+// KeylistUpdateV2 coordinate mediation 2.0 keylist update message.
+type KeylistUpdateV2 struct {
+	Type string              `json:"type,omitempty"`
+	ID   string              `json:"id,omitempty"`
+	Body KeylistUpdateV2Body `json:"body"`
+}
+
+// KeylistUpdateV2Body represents body for KeylistUpdateV2.
+type KeylistUpdateV2Body struct {
+	Updates []UpdateV2 `json:"updates,omitempty"`
+}
+
+// UpdateV2 coordinate mediation 2.0 recipient DID update.
+type UpdateV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+}
+
+// KeylistUpdateResponseV2 coordinate mediation 2.0 keylist update response message.
+type KeylistUpdateResponseV2 struct {
+	Type     string                      `json:"type,omitempty"`
+	ID       string                      `json:"id,omitempty"`
+	ThreadID string                      `json:"thid,omitempty"`
+	Body     KeylistUpdateResponseV2Body `json:"body"`
+}
+
+// KeylistUpdateResponseV2Body represents body for KeylistUpdateResponseV2.
+type KeylistUpdateResponseV2Body struct {
+	Updated []UpdateResponseV2 `json:"updated,omitempty"`
+}
+
+// UpdateResponseV2 coordinate mediation 2.0 recipient DID update response.
+type UpdateResponseV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Result       string `json:"result,omitempty"`
+}
+
+// KeylistQueryV2 coordinate mediation 2.0 keylist query message.
+type KeylistQueryV2 struct {
+	Type string             `json:"type,omitempty"`
+	ID   string             `json:"id,omitempty"`
+	Body KeylistQueryV2Body `json:"body"`
+}
+
+// KeylistQueryV2Body represents body for KeylistQueryV2.
+type KeylistQueryV2Body struct {
+	Paginate *Paginate `json:"paginate,omitempty"`
+}
+
+// KeylistV2 coordinate mediation 2.0 keylist message.
+type KeylistV2 struct {
+	Type     string        `json:"type,omitempty"`
+	ID       string        `json:"id,omitempty"`
+	ThreadID string        `json:"thid,omitempty"`
+	Body     KeylistV2Body `json:"body"`
+}
+
+// KeylistV2Body represents body for KeylistV2.
+type KeylistV2Body struct {
+	Keys       []KeylistKeyV2 `json:"keys"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+}
+
+// KeylistKeyV2 coordinate mediation 2.0 recipient DID entry of the keylist message.
+type KeylistKeyV2 struct {
+	RecipientDID string `json:"recipient_did,omitempty"`
+}
@@ -7,9 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package mediator
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/messagepickup"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -51,6 +55,36 @@ const (
 
 	// KeyListUpdateResponseMsgType defines the route coordination key list update message response type.
 	KeylistUpdateResponseMsgType = CoordinationSpec + "keylist_update_response"
+
+	// KeylistQueryMsgType defines the route coordination key list query message type.
+	KeylistQueryMsgType = CoordinationSpec + "keylist_query"
+
+	// KeylistMsgType defines the route coordination key list message type.
+	KeylistMsgType = CoordinationSpec + "keylist"
+)
+
+// constants for coordinate mediation 2.0 spec types.
+const (
+	// CoordinationSpecV2 defines the coordinate mediation 2.0 spec.
+	CoordinationSpecV2 = "https://didcomm.org/coordinate-mediation/2.0/"
+
+	// RequestMsgTypeV2 defines the coordinate mediation 2.0 request message type.
+	RequestMsgTypeV2 = CoordinationSpecV2 + "mediate-request"
+
+	// GrantMsgTypeV2 defines the coordinate mediation 2.0 grant message type.
+	GrantMsgTypeV2 = CoordinationSpecV2 + "mediate-grant"
+
+	// KeylistUpdateMsgTypeV2 defines the coordinate mediation 2.0 key list update message type.
+	KeylistUpdateMsgTypeV2 = CoordinationSpecV2 + "keylist-update"
+
+	// KeylistUpdateResponseMsgTypeV2 defines the coordinate mediation 2.0 key list update response message type.
+	KeylistUpdateResponseMsgTypeV2 = CoordinationSpecV2 + "keylist-update-response"
+
+	// KeylistQueryMsgTypeV2 defines the coordinate mediation 2.0 key list query message type.
+	KeylistQueryMsgTypeV2 = CoordinationSpecV2 + "keylist-query"
+
+	// KeylistMsgTypeV2 defines the coordinate mediation 2.0 key list message type.
+	KeylistMsgTypeV2 = CoordinationSpecV2 + "keylist"
 )
 
 // constants for key list update processing
@@ -77,6 +111,12 @@ const (
 	routeConfigDataKey = "route_config_%s"
 
 	routeGrantKey = "grant_%s"
+
+	// tag of the stored route keys, the value identifies the connection the key routes to.
+	routeKeyTag = "route_key"
+
+	didCommServiceType          = "did-communication"
+	didCommMessagingServiceType = "DIDCommMessaging"
 )
 
 const (
@@ -105,7 +145,8 @@ type ClientOption func(opts *ClientOptions)
 
 // ClientOptions holds options for the router client.
 type ClientOptions struct {
-	Timeout time.Duration
+	Timeout      time.Duration
+	ProtocolSpec string
 }
 
 // Options is a container for route protocol options.
@@ -140,6 +181,8 @@ type Service struct {
 	vdRegistry           vdr.Registry
 	keylistUpdateMap     map[string]chan *KeylistUpdateResponse
 	keylistUpdateMapLock sync.RWMutex
+	keylistMap           map[string]chan *Keylist
+	keylistMapLock       sync.RWMutex
 	callbacks            chan *callback
 	messagePickupSvc     messagepickup.ProtocolService
 }
@@ -152,7 +195,7 @@ func New(prov provider) (*Service, error) {
 	}
 
 	err = prov.StorageProvider().SetStoreConfig(Coordination,
-		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey}})
+		storage.StoreConfiguration{TagNames: []string{routeConnIDDataKey, routeKeyTag}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store configuration: %w", err)
 	}
@@ -180,6 +223,7 @@ func New(prov provider) (*Service, error) {
 		vdRegistry:       prov.VDRegistry(),
 		connectionLookup: connectionLookup,
 		keylistUpdateMap: make(map[string]chan *KeylistUpdateResponse),
+		keylistMap:       make(map[string]chan *Keylist),
 		callbacks:        make(chan *callback),
 		messagePickupSvc: messagePickupSvc,
 	}
@@ -202,7 +246,7 @@ func (s *Service) listenForCallbacks() {
 		}
 
 		switch c.msg.Type() {
-		case RequestMsgType:
+		case RequestMsgType, RequestMsgTypeV2:
 			err := s.handleInboundRequest(c)
 			if err != nil {
 				logger.Errorf("failed to handle inbound request: %+v : %w", c.msg, err)
@@ -218,7 +262,7 @@ func (s *Service) handleUserRejection(c *callback) {
 }
 
 func triggersActionEvent(msgType string) bool {
-	return msgType == RequestMsgType
+	return msgType == RequestMsgType || msgType == RequestMsgTypeV2
 }
 
 func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string) error {
@@ -263,6 +307,7 @@ func (s *Service) sendActionEvent(msg service.DIDCommMsg, myDID, theirDID string
 }
 
 // HandleInbound handles inbound route coordination messages.
+// nolint: gocyclo
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	logger.Debugf("service.HandleInbound() input: msg=%+v myDID=%s theirDID=%s", msg, ctx.MyDID(), ctx.TheirDID())
 
@@ -275,12 +320,16 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 		var err error
 
 		switch msg.Type() {
-		case GrantMsgType:
+		case GrantMsgType, GrantMsgTypeV2:
 			err = s.saveGrant(msg)
-		case KeylistUpdateMsgType:
+		case KeylistUpdateMsgType, KeylistUpdateMsgTypeV2:
 			err = s.handleKeylistUpdate(msg, ctx.MyDID(), ctx.TheirDID())
-		case KeylistUpdateResponseMsgType:
+		case KeylistUpdateResponseMsgType, KeylistUpdateResponseMsgTypeV2:
 			err = s.handleKeylistUpdateResponse(msg)
+		case KeylistQueryMsgType, KeylistQueryMsgTypeV2:
+			err = s.handleKeylistQuery(msg, ctx.MyDID(), ctx.TheirDID())
+		case KeylistMsgType, KeylistMsgTypeV2:
+			err = s.handleKeylist(msg)
 		case service.ForwardMsgType:
 			err = s.handleForward(msg)
 		}
@@ -322,7 +371,7 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 	}
 
 	switch msg.Type() {
-	case RequestMsgType:
+	case RequestMsgType, RequestMsgTypeV2:
 		return "", s.handleOutboundRequest(msg, myDID, theirDID)
 	default:
 		return "", fmt.Errorf("invalid or unsupported outbound message type %s", msg.Type())
@@ -332,7 +381,11 @@ func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string)
 // Accept checks whether the service can handle the message type.
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
-	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType, service.ForwardMsgType:
+	case RequestMsgType, GrantMsgType, KeylistUpdateMsgType, KeylistUpdateResponseMsgType,
+		KeylistQueryMsgType, KeylistMsgType, service.ForwardMsgType:
+		return true
+	case RequestMsgTypeV2, GrantMsgTypeV2, KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+		KeylistQueryMsgTypeV2, KeylistMsgTypeV2:
 		return true
 	}
 
@@ -351,13 +404,14 @@ func (s *Service) handleInboundRequest(c *callback) error {
 	// unmarshal the payload
 	request := &Request{}
 
-	err := c.msg.Decode(request)
+	err := decodeMsg(c.msg, request)
 	if err != nil {
 		return fmt.Errorf("handleInboundRequest: route request message unmarshal : %w", err)
 	}
 
 	grant, err := outboundGrant(
 		c.msg.ID(),
+		msgTypeForSpec(specOf(c.msg.Type()), GrantMsgType),
 		c.options,
 		s.endpoint,
 		func() (string, error) {
@@ -376,15 +430,15 @@ func (s *Service) handleInboundRequest(c *callback) error {
 		return fmt.Errorf("handleInboundRequest: failed to handle inbound request : %w", err)
 	}
 
-	return s.outbound.SendToDID(grant, c.myDID, c.theirDID)
+	return s.outbound.SendToDID(toSpec(specOf(c.msg.Type()), grant), c.myDID, c.theirDID)
 }
 
 func outboundGrant(
-	msgID string, opts *Options,
+	msgID, msgType string, opts *Options,
 	defaultEndpoint string, defaultKey func() (string, error)) (*Grant, error) {
 	grant := &Grant{
 		ID:          msgID,
-		Type:        GrantMsgType,
+		Type:        msgType,
 		Endpoint:    opts.ServiceEndpoint,
 		RoutingKeys: opts.RoutingKeys,
 	}
@@ -411,7 +465,7 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 	// unmarshal the payload
 	keyUpdate := &KeylistUpdate{}
 
-	err := msg.Decode(keyUpdate)
+	err := decodeMsg(msg, keyUpdate)
 	if err != nil {
 		return fmt.Errorf("route key list update message unmarshal : %w", err)
 	}
@@ -424,7 +478,7 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 			val := theirDID
 			result := success
 
-			err = s.routeStore.Put(dataKey(v.RecipientKey), []byte(val))
+			err = s.routeStore.Put(dataKey(v.RecipientKey), []byte(val), routeKeyTagOf(val))
 			if err != nil {
 				logger.Errorf("failed to add the route key to store : %s", err)
 
@@ -451,19 +505,19 @@ func (s *Service) handleKeylistUpdate(msg service.DIDCommMsg, myDID, theirDID st
 
 	// send the key update response
 	updateResponse := &KeylistUpdateResponse{
-		Type:    KeylistUpdateResponseMsgType,
+		Type:    msgTypeForSpec(specOf(msg.Type()), KeylistUpdateResponseMsgType),
 		ID:      msg.ID(),
 		Updated: updates,
 	}
 
-	return s.outbound.SendToDID(updateResponse, myDID, theirDID)
+	return s.outbound.SendToDID(toSpec(specOf(msg.Type()), updateResponse), myDID, theirDID)
 }
 
 func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	respMsg := &KeylistUpdateResponse{}
 
-	err := msg.Decode(respMsg)
+	err := decodeMsg(msg, respMsg)
 	if err != nil {
 		return fmt.Errorf("route keylist update response message unmarshal : %w", err)
 	}
@@ -479,6 +533,182 @@ func (s *Service) handleKeylistUpdateResponse(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleKeylistQuery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	query := &KeylistQuery{}
+
+	err := decodeMsg(msg, query)
+	if err != nil {
+		return fmt.Errorf("route keylist query message unmarshal : %w", err)
+	}
+
+	keys, err := s.getRouteKeys(theirDID)
+	if err != nil {
+		return fmt.Errorf("get route keys : %w", err)
+	}
+
+	keys, pagination := paginate(keys, query.Paginate)
+
+	keylist := &Keylist{
+		Type:       msgTypeForSpec(specOf(msg.Type()), KeylistMsgType),
+		ID:         msg.ID(),
+		Keys:       make([]KeylistKey, len(keys)),
+		Pagination: pagination,
+	}
+
+	for i, key := range keys {
+		keylist.Keys[i] = KeylistKey{RecipientKey: key}
+	}
+
+	return s.outbound.SendToDID(toSpec(specOf(msg.Type()), keylist), myDID, theirDID)
+}
+
+func (s *Service) handleKeylist(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	keylist := &Keylist{}
+
+	err := decodeMsg(msg, keylist)
+	if err != nil {
+		return fmt.Errorf("route keylist message unmarshal : %w", err)
+	}
+
+	// check if there are any channels registered for the message ID
+	keylistCh := s.getKeylistCh(keylist.ID)
+
+	if keylistCh != nil {
+		// invoke the channel for the incoming message
+		keylistCh <- keylist
+	}
+
+	return nil
+}
+
+// getRouteKeys returns the sorted recipient keys registered by the agent identified by theirDID. The keys are looked
+// up by the route key tag of the connection, the keys registered before the tag are indexed by handleForward.
+func (s *Service) getRouteKeys(theirDID string) ([]string, error) {
+	tag := routeKeyTagOf(theirDID)
+
+	records, err := s.routeStore.Query(tag.Name + ":" + tag.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route store: %w", err)
+	}
+
+	defer storage.Close(records, logger)
+
+	var keys []string
+
+	more, err := records.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next record: %w", err)
+	}
+
+	for more {
+		key, err := records.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key from records: %w", err)
+		}
+
+		keys = append(keys, strings.TrimPrefix(key, dataKey("")))
+
+		more, err = records.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next record: %w", err)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// indexRouteKey tags the route key with the connection it routes to if it was registered before the route keys were
+// tagged, so that the key is returned by the keylist queries of the connection.
+func (s *Service) indexRouteKey(recKey, theirDID string) error {
+	tags, err := s.routeStore.GetTags(dataKey(recKey))
+	if err != nil {
+		return fmt.Errorf("get route key tags: %w", err)
+	}
+
+	tag := routeKeyTagOf(theirDID)
+
+	for _, t := range tags {
+		if t == tag {
+			return nil
+		}
+	}
+
+	return s.routeStore.Put(dataKey(recKey), []byte(theirDID), tag)
+}
+
+// routeKeyTagOf returns the route key tag of the connection with theirDID, DIDs aren't valid tag values as they
+// contain ':'.
+func routeKeyTagOf(theirDID string) storage.Tag {
+	return storage.Tag{Name: routeKeyTag, Value: base64.RawURLEncoding.EncodeToString([]byte(theirDID))}
+}
+
+// routerEndpoint returns the endpoint of the first routing DID with a DIDComm service, the routing DIDs without
+// services are routed through the endpoint of the router connection.
+func (s *Service) routerEndpoint(routerDID string, routingDIDs []string) (string, error) {
+	for _, routingDID := range routingDIDs {
+		docResolution, err := s.vdRegistry.Resolve(strings.Split(routingDID, "#")[0])
+		if err != nil {
+			return "", fmt.Errorf("resolve routing DID %s: %w", routingDID, err)
+		}
+
+		if endpoint := didCommServiceEndpoint(docResolution.DIDDocument); endpoint != "" {
+			return endpoint, nil
+		}
+	}
+
+	docResolution, err := s.vdRegistry.Resolve(routerDID)
+	if err != nil {
+		return "", fmt.Errorf("resolve router DID %s: %w", routerDID, err)
+	}
+
+	endpoint := didCommServiceEndpoint(docResolution.DIDDocument)
+	if endpoint == "" {
+		return "", fmt.Errorf("router DID %s has no DIDComm service endpoint", routerDID)
+	}
+
+	return endpoint, nil
+}
+
+func didCommServiceEndpoint(doc *did.Doc) string {
+	for _, serviceType := range []string{didCommMessagingServiceType, didCommServiceType} {
+		if svc, ok := did.LookupService(doc, serviceType); ok && svc.ServiceEndpoint != "" {
+			return svc.ServiceEndpoint
+		}
+	}
+
+	return ""
+}
+
+func paginate(keys []string, p *Paginate) ([]string, *Pagination) {
+	if p == nil {
+		return keys, nil
+	}
+
+	offset := p.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	if offset > len(keys) {
+		offset = len(keys)
+	}
+
+	end := len(keys)
+	if p.Limit > 0 && offset+p.Limit < end {
+		end = offset + p.Limit
+	}
+
+	return keys[offset:end], &Pagination{
+		Count:     end - offset,
+		Offset:    offset,
+		Remaining: len(keys) - end,
+	}
+}
+
 func (s *Service) handleForward(msg service.DIDCommMsg) error {
 	// unmarshal the payload
 	forward := &model.Forward{}
@@ -495,6 +725,10 @@ func (s *Service) handleForward(msg service.DIDCommMsg) error {
 		return fmt.Errorf("route key fetch : %w", err)
 	}
 
+	if err = s.indexRouteKey(forward.To, string(theirDID)); err != nil {
+		logger.Warnf("failed to index the route key : %s", err)
+	}
+
 	dest, err := service.GetDestination(string(theirDID), s.vdRegistry)
 	if err != nil {
 		return fmt.Errorf("get destination : %w", err)
@@ -523,7 +757,7 @@ func (s *Service) Register(connectionID string, options ...ClientOption) error {
 	return s.doRegistration(
 		record,
 		&Request{
-			Type:   RequestMsgType,
+			Type:   msgTypeForSpec(opts.ProtocolSpec, RequestMsgType),
 			ID:     uuid.New().String(),
 			Timing: decorator.Timing{},
 		},
@@ -547,7 +781,7 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 	req.ExpiresTime = time.Now().UTC().Add(timeout)
 
 	// send message to the router
-	if err = s.outbound.SendToDID(req, record.MyDID, record.TheirDID); err != nil {
+	if err = s.outbound.SendToDID(toSpec(specOf(req.Type), req), record.MyDID, record.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
@@ -557,9 +791,18 @@ func (s *Service) doRegistration(record *connection.Record, req *Request, timeou
 		return fmt.Errorf("get grant: %w", err)
 	}
 
+	// the coordinate mediation 2.0 grant only has the routing DIDs, the endpoint is the one of their DIDComm service
+	if specOf(req.Type) == CoordinationSpecV2 {
+		grant.Endpoint, err = s.routerEndpoint(record.TheirDID, grant.RoutingKeys)
+		if err != nil {
+			return fmt.Errorf("get router endpoint: %w", err)
+		}
+	}
+
 	err = s.saveRouterConfig(record.ConnectionID, &config{
 		RouterEndpoint: grant.Endpoint,
 		RoutingKeys:    grant.RoutingKeys,
+		ProtocolSpec:   specOf(req.Type),
 	})
 	if err != nil {
 		return fmt.Errorf("save route config : %w", err)
@@ -597,13 +840,20 @@ func (s *Service) getGrant(id string, timeout time.Duration) (*Grant, error) {
 	return grant, nil
 }
 
-func (s *Service) saveGrant(grant service.DIDCommMsg) error {
+func (s *Service) saveGrant(msg service.DIDCommMsg) error {
+	grant := &Grant{}
+
+	err := decodeMsg(msg, grant)
+	if err != nil {
+		return fmt.Errorf("decode grant: %w", err)
+	}
+
 	src, err := json.Marshal(grant)
 	if err != nil {
 		return fmt.Errorf("marshal grant: %w", err)
 	}
 
-	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID()), src)
+	return s.routeStore.Put(fmt.Sprintf(routeGrantKey, grant.ID), src)
 }
 
 // Unregister unregisters the agent with the router.
@@ -672,6 +922,11 @@ func (s *Service) AddKey(connID, recKey string) error {
 		return fmt.Errorf("get connection: %w", err)
 	}
 
+	spec, err := s.getRouterSpec(connID)
+	if err != nil {
+		return fmt.Errorf("get router protocol spec: %w", err)
+	}
+
 	// generate message ID
 	msgID := uuid.New().String()
 
@@ -681,7 +936,7 @@ func (s *Service) AddKey(connID, recKey string) error {
 
 	keyUpdate := &KeylistUpdate{
 		ID:   msgID,
-		Type: KeylistUpdateMsgType,
+		Type: msgTypeForSpec(spec, KeylistUpdateMsgType),
 		Updates: []Update{
 			{
 				RecipientKey: recKey,
@@ -690,7 +945,7 @@ func (s *Service) AddKey(connID, recKey string) error {
 		},
 	}
 
-	if err := s.outbound.SendToDID(keyUpdate, conn.MyDID, conn.TheirDID); err != nil {
+	if err := s.outbound.SendToDID(toSpec(spec, keyUpdate), conn.MyDID, conn.TheirDID); err != nil {
 		return fmt.Errorf("send route request: %w", err)
 	}
 
@@ -709,6 +964,52 @@ func (s *Service) AddKey(connID, recKey string) error {
 	return nil
 }
 
+// KeylistQuery retrieves the recipient keys of the agent registered with the router. This method blocks
+// until a response is received from the router or it times out.
+func (s *Service) KeylistQuery(connID string, p *Paginate) (*Keylist, error) {
+	// check if router is already registered
+	if err := s.ensureConnectionExists(connID); err != nil {
+		return nil, fmt.Errorf("ensure connection exists: %w", err)
+	}
+
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	spec, err := s.getRouterSpec(connID)
+	if err != nil {
+		return nil, fmt.Errorf("get router protocol spec: %w", err)
+	}
+
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	keylistCh := make(chan *Keylist)
+	s.setKeylistCh(msgID, keylistCh)
+
+	// remove the channel once its been processed
+	defer s.setKeylistCh(msgID, nil)
+
+	query := &KeylistQuery{
+		ID:       msgID,
+		Type:     msgTypeForSpec(spec, KeylistQueryMsgType),
+		Paginate: p,
+	}
+
+	if err := s.outbound.SendToDID(toSpec(spec, query), conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send keylist query: %w", err)
+	}
+
+	select {
+	case keylist := <-keylistCh:
+		return keylist, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for keylist from the router")
+	}
+}
+
 // Config fetches the router config - endpoint and routingKeys.
 func (s *Service) Config(connID string) (*Config, error) {
 	// check if router is already registered
@@ -747,6 +1048,24 @@ func (s *Service) setKeyUpdateResponseCh(msgID string, keyUpdateCh chan *Keylist
 	}
 }
 
+func (s *Service) getKeylistCh(msgID string) chan *Keylist {
+	s.keylistMapLock.RLock()
+	defer s.keylistMapLock.RUnlock()
+
+	return s.keylistMap[msgID]
+}
+
+func (s *Service) setKeylistCh(msgID string, keylistCh chan *Keylist) {
+	s.keylistMapLock.Lock()
+	defer s.keylistMapLock.Unlock()
+
+	if keylistCh == nil {
+		delete(s.keylistMap, msgID)
+	} else {
+		s.keylistMap[msgID] = keylistCh
+	}
+}
+
 func (s *Service) ensureConnectionExists(connID string) error {
 	_, err := s.routeStore.Get(fmt.Sprintf(routeConnIDDataKey, connID))
 	if errors.Is(err, storage.ErrDataNotFound) {
@@ -767,9 +1086,19 @@ func (s *Service) saveRouterConnectionID(connID string) error {
 type config struct {
 	RouterEndpoint string
 	RoutingKeys    []string
+	ProtocolSpec   string `json:",omitempty"`
 }
 
 func (s *Service) getRouterConfig(connID string) (*Config, error) {
+	conf, err := s.getRouterConfigData(connID)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConfig(conf.RouterEndpoint, conf.RoutingKeys), nil
+}
+
+func (s *Service) getRouterConfigData(connID string) (*config, error) {
 	val, err := s.routeStore.Get(fmt.Sprintf(routeConfigDataKey, connID))
 	if err != nil {
 		return nil, fmt.Errorf("get router config data : %w", err)
@@ -782,7 +1111,26 @@ func (s *Service) getRouterConfig(connID string) (*Config, error) {
 		return nil, fmt.Errorf("unmarshal router config data : %w", err)
 	}
 
-	return NewConfig(conf.RouterEndpoint, conf.RoutingKeys), nil
+	return conf, nil
+}
+
+// getRouterSpec returns the coordinate mediation spec negotiated with the router, routers registered
+// without a saved config default to the route coordination 1.0 spec.
+func (s *Service) getRouterSpec(connID string) (string, error) {
+	conf, err := s.getRouterConfigData(connID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return CoordinationSpec, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if conf.ProtocolSpec == "" {
+		return CoordinationSpec, nil
+	}
+
+	return conf.ProtocolSpec, nil
 }
 
 func (s *Service) saveRouterConfig(connID string, conf *config) error {
@@ -810,7 +1158,7 @@ func (s *Service) getConnection(routerConnID string) (*connection.Record, error)
 func (s *Service) handleOutboundRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
 	req := &Request{}
 
-	err := msg.Decode(req)
+	err := decodeMsg(msg, req)
 	if err != nil {
 		return fmt.Errorf("failed to decode request : %w", err)
 	}
@@ -834,7 +1182,8 @@ func dataKey(id string) string {
 
 func parseClientOpts(options ...ClientOption) *ClientOptions {
 	opts := &ClientOptions{
-		Timeout: updateTimeout,
+		Timeout:      updateTimeout,
+		ProtocolSpec: CoordinationSpec,
 	}
 
 	// generate router config from options
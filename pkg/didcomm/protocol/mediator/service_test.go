@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -75,7 +76,15 @@ func TestServiceAccept(t *testing.T) {
 	require.Equal(t, true, s.Accept(GrantMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateMsgType))
 	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgType))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgType))
+	require.Equal(t, true, s.Accept(KeylistMsgType))
 	require.Equal(t, true, s.Accept(service.ForwardMsgType))
+	require.Equal(t, true, s.Accept(RequestMsgTypeV2))
+	require.Equal(t, true, s.Accept(GrantMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistUpdateMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistUpdateResponseMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistQueryMsgTypeV2))
+	require.Equal(t, true, s.Accept(KeylistMsgTypeV2))
 	require.Equal(t, false, s.Accept("unsupported msg type"))
 }
 
@@ -549,7 +558,7 @@ func TestServiceGrantMsg(t *testing.T) {
 		require.Equal(t, msgID, id)
 	})
 
-	t.Run("service handle grant msg - marshal error", func(t *testing.T) {
+	t.Run("service handle grant msg - decode error", func(t *testing.T) {
 		svc, err := New(&mockprovider.Provider{
 			ServiceMap: map[string]interface{}{
 				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
@@ -565,7 +574,7 @@ func TestServiceGrantMsg(t *testing.T) {
 
 		err = svc.saveGrant(msg)
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "marshal grant: json")
+		require.Contains(t, err.Error(), "decode grant")
 	})
 }
 
@@ -1430,6 +1439,291 @@ func TestGetConnections(t *testing.T) {
 	})
 }
 
+func TestKeylistQuery(t *testing.T) {
+	t.Run("test keylist query - router responds with the keys of the agent", func(t *testing.T) {
+		keylist := make(chan *Keylist)
+
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mem.NewProvider(),
+			ProtocolStateStorageProviderValue: mem.NewProvider(),
+			KMSValue:                          &mockkms.KeyManager{},
+			OutboundDispatcherValue: &mockdispatcher.MockOutbound{
+				ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+					if resp, ok := msg.(*Keylist); ok {
+						require.Equal(t, MYDID, myDID)
+						require.Equal(t, THEIRDID, theirDID)
+
+						keylist <- resp
+					}
+
+					return nil
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		updates := []Update{
+			{RecipientKey: "key-3", Action: add},
+			{RecipientKey: "key-1", Action: add},
+			{RecipientKey: "key-2", Action: add},
+		}
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(), updates),
+			MYDID, THEIRDID))
+		require.NoError(t, svc.handleKeylistUpdate(generateKeyUpdateListMsgPayload(t, randomID(),
+			[]Update{{RecipientKey: "other-key", Action: add}}), MYDID, "otherDID"))
+
+		go func() {
+			require.NoError(t, svc.handleKeylistQuery(generateKeylistQueryMsgPayload(t, "query-1", nil),
+				MYDID, THEIRDID))
+		}()
+
+		resp := <-keylist
+		require.Equal(t, KeylistMsgType, resp.Type)
+		require.Equal(t, "query-1", resp.ID)
+		require.Nil(t, resp.Pagination)
+		require.Equal(t, []KeylistKey{{RecipientKey: "key-1"}, {RecipientKey: "key-2"}, {RecipientKey: "key-3"}},
+			resp.Keys)
+
+		go func() {
+			require.NoError(t, svc.handleKeylistQuery(generateKeylistQueryMsgPayload(t, "query-2",
+				&Paginate{Limit: 1, Offset: 1}), MYDID, THEIRDID))
+		}()
+
+		resp = <-keylist
+		require.Equal(t, []KeylistKey{{RecipientKey: "key-2"}}, resp.Keys)
+		require.Equal(t, &Pagination{Count: 1, Offset: 1, Remaining: 1}, resp.Pagination)
+	})
+
+	t.Run("test keylist query - untagged route keys are indexed when forwarding", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+			VDRegistryValue:                   &mockvdr.MockVDRegistry{ResolveValue: mockdiddoc.GetMockDIDDoc(t)},
+		})
+		require.NoError(t, err)
+
+		// route key stored before the keys were tagged with their connection
+		require.NoError(t, svc.routeStore.Put(dataKey("legacy-key"), []byte(THEIRDID)))
+
+		keys, err := svc.getRouteKeys(THEIRDID)
+		require.NoError(t, err)
+		require.Empty(t, keys)
+
+		require.NoError(t, svc.handleForward(generateForwardMsgPayload(t, randomID(), "legacy-key", nil)))
+
+		keys, err = svc.getRouteKeys(THEIRDID)
+		require.NoError(t, err)
+		require.Equal(t, []string{"legacy-key"}, keys)
+
+		keys, err = svc.getRouteKeys("otherDID")
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("test keylist query - store query error", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue: &mockstore.MockStoreProvider{Store: &mockstore.MockStore{
+				Store:    make(map[string]mockstore.DBEntry),
+				ErrQuery: errors.New("query error"),
+			}},
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+			OutboundDispatcherValue:           &mockdispatcher.MockOutbound{},
+		})
+		require.NoError(t, err)
+
+		err = svc.handleKeylistQuery(generateKeylistQueryMsgPayload(t, randomID(), nil), MYDID, THEIRDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "query error")
+	})
+
+	t.Run("test keylist query - router not registered", func(t *testing.T) {
+		svc, err := New(&mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+			},
+			StorageProviderValue:              mockstore.NewMockStoreProvider(),
+			ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		_, err = svc.KeylistQuery("conn", nil)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrRouterNotRegistered))
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+
+	result, pagination := paginate(keys, nil)
+	require.Equal(t, keys, result)
+	require.Nil(t, pagination)
+
+	result, pagination = paginate(keys, &Paginate{Limit: 2})
+	require.Equal(t, []string{"key-1", "key-2"}, result)
+	require.Equal(t, &Pagination{Count: 2, Offset: 0, Remaining: 1}, pagination)
+
+	result, pagination = paginate(keys, &Paginate{Offset: 5})
+	require.Empty(t, result)
+	require.Equal(t, &Pagination{Count: 0, Offset: 3, Remaining: 0}, pagination)
+}
+
+// TestCoordinateMediationV2 drives the agent and router services against each other through an in-memory
+// transport: mediate-request -> mediate-grant -> keylist-update -> keylist-query.
+func TestCoordinateMediationV2(t *testing.T) {
+	const (
+		routerEndpoint = "http://mediator.example.com"
+		routingKey     = "did:key:z6MkqyYXcBQZ5hZ9BFHBiVnmrZ1C1HCpesgZQoTdgjLdU6Ah"
+		recKey         = "did:key:z6MkjtX1HHkB8mKQVTDhrA1Cr7kfJnbtXhWXRsBPyJjPvmBv"
+	)
+
+	var (
+		agentSvc, routerSvc *Service
+		wire                []string
+		wireMsgs            = map[string]service.DIDCommMsgMap{}
+		wireLock            sync.Mutex
+	)
+
+	// transport delivers the outbound message to the inbound handler of the other side of the connection
+	transport := func(to **Service) *mockdispatcher.MockOutbound {
+		return &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				src, err := json.Marshal(msg)
+				require.NoError(t, err)
+
+				didMsg, err := service.ParseDIDCommMsgMap(src)
+				require.NoError(t, err)
+
+				wireLock.Lock()
+				wire = append(wire, didMsg.Type())
+				wireMsgs[didMsg.Type()] = didMsg
+				wireLock.Unlock()
+
+				_, err = (*to).HandleInbound(didMsg, service.NewDIDCommContext(theirDID, myDID, nil))
+
+				return err
+			},
+		}
+	}
+
+	agentStore := make(map[string]mockstore.DBEntry)
+
+	agentSvc, err := New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              &mockstore.MockStoreProvider{Store: &mockstore.MockStore{Store: agentStore}},
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		KMSValue:                          &mockkms.KeyManager{},
+		OutboundDispatcherValue:           transport(&routerSvc),
+		// the routing DID has no service, the router endpoint is the one of the router connection
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				doc := &did.Doc{ID: didID}
+
+				if didID == THEIRDID {
+					doc.Service = []did.Service{{Type: "DIDCommMessaging", ServiceEndpoint: routerEndpoint}}
+				}
+
+				return &did.DocResolution{DIDDocument: doc}, nil
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	routerSvc, err = New(&mockprovider.Provider{
+		ServiceMap: map[string]interface{}{
+			messagepickup.MessagePickup: &mockmessagep.MockMessagePickupSvc{},
+		},
+		StorageProviderValue:              mem.NewProvider(),
+		ProtocolStateStorageProviderValue: mem.NewProvider(),
+		KMSValue:                          &mockkms.KeyManager{},
+		OutboundDispatcherValue:           transport(&agentSvc),
+	})
+	require.NoError(t, err)
+
+	// the router grants every mediation request
+	events := make(chan service.DIDCommAction)
+	require.NoError(t, routerSvc.RegisterActionEvent(events))
+
+	go func() {
+		for event := range events {
+			require.Equal(t, RequestMsgTypeV2, event.Message.Type())
+
+			event.Continue(Options{ServiceEndpoint: routerEndpoint, RoutingKeys: []string{routingKey}})
+		}
+	}()
+
+	connBytes, err := json.Marshal(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "complete",
+	})
+	require.NoError(t, err)
+
+	agentStore["conn_conn"] = mockstore.DBEntry{Value: connBytes}
+
+	// mediate-request -> mediate-grant
+	require.NoError(t, agentSvc.Register("conn", func(opts *ClientOptions) {
+		opts.ProtocolSpec = CoordinationSpecV2
+	}))
+
+	// the service endpoint of new connections is rewritten to the mediator's one with the routing key
+	endpoint, routingKeys, err := GetRouterConfig(agentSvc, "conn", "http://agent.example.com")
+	require.NoError(t, err)
+	require.Equal(t, routerEndpoint, endpoint)
+	require.Equal(t, []string{routingKey}, routingKeys)
+
+	// keylist-update -> keylist-update-response
+	require.NoError(t, AddKeyToRouter(agentSvc, "conn", recKey))
+
+	theirDID, err := routerSvc.routeStore.Get(dataKey(recKey))
+	require.NoError(t, err)
+	require.Equal(t, MYDID, string(theirDID))
+
+	// keylist-query -> keylist
+	keylist, err := agentSvc.KeylistQuery("conn", nil)
+	require.NoError(t, err)
+	require.Equal(t, []KeylistKey{{RecipientKey: recKey}}, keylist.Keys)
+
+	wireLock.Lock()
+	defer wireLock.Unlock()
+
+	require.Equal(t, []string{
+		RequestMsgTypeV2, GrantMsgTypeV2,
+		KeylistUpdateMsgTypeV2, KeylistUpdateResponseMsgTypeV2,
+		KeylistQueryMsgTypeV2, KeylistMsgTypeV2,
+	}, wire)
+
+	// the messages follow the coordinate mediation 2.0 schemas
+	grant := &GrantV2{}
+	require.NoError(t, wireMsgs[GrantMsgTypeV2].Decode(grant))
+	require.Equal(t, []string{routingKey}, grant.Body.RoutingDID)
+	require.Equal(t, wireMsgs[RequestMsgTypeV2].ID(), grant.ThreadID)
+
+	update := &KeylistUpdateV2{}
+	require.NoError(t, wireMsgs[KeylistUpdateMsgTypeV2].Decode(update))
+	require.Equal(t, []UpdateV2{{RecipientDID: recKey, Action: add}}, update.Body.Updates)
+
+	updateResp := &KeylistUpdateResponseV2{}
+	require.NoError(t, wireMsgs[KeylistUpdateResponseMsgTypeV2].Decode(updateResp))
+	require.Equal(t, []UpdateResponseV2{{RecipientDID: recKey, Action: add, Result: success}}, updateResp.Body.Updated)
+	require.Equal(t, update.ID, updateResp.ThreadID)
+
+	list := &KeylistV2{}
+	require.NoError(t, wireMsgs[KeylistMsgTypeV2].Decode(list))
+	require.Equal(t, []KeylistKeyV2{{RecipientDID: recKey}}, list.Body.Keys)
+	require.Equal(t, wireMsgs[KeylistQueryMsgTypeV2].ID(), list.ThreadID)
+}
+
 func generateRequestMsgPayload(t *testing.T, id string) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&Request{
 		Type: RequestMsgType,
@@ -1484,6 +1778,20 @@ func generateKeylistUpdateResponseMsgPayload(t *testing.T, id string, updates []
 	return didMsg
 }
 
+func generateKeylistQueryMsgPayload(t *testing.T, id string, paginate *Paginate) service.DIDCommMsg {
+	queryBytes, err := json.Marshal(&KeylistQuery{
+		Type:     KeylistQueryMsgType,
+		ID:       id,
+		Paginate: paginate,
+	})
+	require.NoError(t, err)
+
+	didMsg, err := service.ParseDIDCommMsgMap(queryBytes)
+	require.NoError(t, err)
+
+	return didMsg
+}
+
 func generateForwardMsgPayload(t *testing.T, id, to string, msg *model.Envelope) service.DIDCommMsg {
 	requestBytes, err := json.Marshal(&model.Forward{
 		Type: service.ForwardMsgType,
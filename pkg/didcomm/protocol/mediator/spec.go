@@ -0,0 +1,192 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mediator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
+
+// v2MsgTypes maps the route coordination 1.0 message types to the coordinate mediation 2.0 ones.
+// nolint:gochecknoglobals
+var v2MsgTypes = map[string]string{
+	RequestMsgType:               RequestMsgTypeV2,
+	GrantMsgType:                 GrantMsgTypeV2,
+	KeylistUpdateMsgType:         KeylistUpdateMsgTypeV2,
+	KeylistUpdateResponseMsgType: KeylistUpdateResponseMsgTypeV2,
+	KeylistQueryMsgType:          KeylistQueryMsgTypeV2,
+	KeylistMsgType:               KeylistMsgTypeV2,
+}
+
+// specOf returns the spec the message type belongs to.
+func specOf(msgType string) string {
+	if strings.HasPrefix(msgType, CoordinationSpecV2) {
+		return CoordinationSpecV2
+	}
+
+	return CoordinationSpec
+}
+
+// msgTypeForSpec converts the route coordination 1.0 message type to the given spec.
+func msgTypeForSpec(spec, msgType string) string {
+	if spec == CoordinationSpecV2 {
+		return v2MsgTypes[msgType]
+	}
+
+	return msgType
+}
+
+// toSpec converts the route coordination 1.0 message to the message of the given spec. The 1.0 responses reuse
+// the ID of the message they respond to, the 2.0 responses get a new ID and reference the request as their thread.
+// nolint:gocyclo,funlen
+func toSpec(spec string, msg interface{}) interface{} {
+	if spec != CoordinationSpecV2 {
+		return msg
+	}
+
+	switch m := msg.(type) {
+	case *Request:
+		return &RequestV2{
+			Type:        RequestMsgTypeV2,
+			ID:          m.ID,
+			ExpiresTime: m.ExpiresTime.Unix(),
+		}
+	case *Grant:
+		return &GrantV2{
+			Type:     GrantMsgTypeV2,
+			ID:       uuid.New().String(),
+			ThreadID: m.ID,
+			Body:     GrantV2Body{RoutingDID: m.RoutingKeys},
+		}
+	case *KeylistUpdate:
+		updates := make([]UpdateV2, len(m.Updates))
+
+		for i, u := range m.Updates {
+			updates[i] = UpdateV2{RecipientDID: u.RecipientKey, Action: u.Action}
+		}
+
+		return &KeylistUpdateV2{
+			Type: KeylistUpdateMsgTypeV2,
+			ID:   m.ID,
+			Body: KeylistUpdateV2Body{Updates: updates},
+		}
+	case *KeylistUpdateResponse:
+		updated := make([]UpdateResponseV2, len(m.Updated))
+
+		for i, u := range m.Updated {
+			updated[i] = UpdateResponseV2{RecipientDID: u.RecipientKey, Action: u.Action, Result: u.Result}
+		}
+
+		return &KeylistUpdateResponseV2{
+			Type:     KeylistUpdateResponseMsgTypeV2,
+			ID:       uuid.New().String(),
+			ThreadID: m.ID,
+			Body:     KeylistUpdateResponseV2Body{Updated: updated},
+		}
+	case *KeylistQuery:
+		return &KeylistQueryV2{
+			Type: KeylistQueryMsgTypeV2,
+			ID:   m.ID,
+			Body: KeylistQueryV2Body{Paginate: m.Paginate},
+		}
+	case *Keylist:
+		keys := make([]KeylistKeyV2, len(m.Keys))
+
+		for i, k := range m.Keys {
+			keys[i] = KeylistKeyV2{RecipientDID: k.RecipientKey}
+		}
+
+		return &KeylistV2{
+			Type:     KeylistMsgTypeV2,
+			ID:       uuid.New().String(),
+			ThreadID: m.ID,
+			Body:     KeylistV2Body{Keys: keys, Pagination: m.Pagination},
+		}
+	}
+
+	return msg
+}
+
+// decodeMsg decodes the message of either spec into the route coordination 1.0 message, the ID of the decoded
+// responses is the ID of the request they respond to.
+// nolint:gocyclo,funlen
+func decodeMsg(msg service.DIDCommMsg, v interface{}) error {
+	if specOf(msg.Type()) != CoordinationSpecV2 {
+		return msg.Decode(v)
+	}
+
+	threadID, err := msg.ThreadID()
+	if err != nil {
+		return fmt.Errorf("thread ID: %w", err)
+	}
+
+	switch m := v.(type) {
+	case *Request:
+		req := &RequestV2{}
+		if err = msg.Decode(req); err != nil {
+			return err
+		}
+
+		m.Type, m.ID = RequestMsgTypeV2, req.ID
+	case *Grant:
+		grant := &GrantV2{}
+		if err = msg.Decode(grant); err != nil {
+			return err
+		}
+
+		m.Type, m.ID, m.RoutingKeys = GrantMsgTypeV2, threadID, grant.Body.RoutingDID
+	case *KeylistUpdate:
+		update := &KeylistUpdateV2{}
+		if err = msg.Decode(update); err != nil {
+			return err
+		}
+
+		m.Type, m.ID, m.Updates = KeylistUpdateMsgTypeV2, update.ID, make([]Update, len(update.Body.Updates))
+
+		for i, u := range update.Body.Updates {
+			m.Updates[i] = Update{RecipientKey: u.RecipientDID, Action: u.Action}
+		}
+	case *KeylistUpdateResponse:
+		resp := &KeylistUpdateResponseV2{}
+		if err = msg.Decode(resp); err != nil {
+			return err
+		}
+
+		m.Type, m.ID, m.Updated = KeylistUpdateResponseMsgTypeV2, threadID, make([]UpdateResponse, len(resp.Body.Updated))
+
+		for i, u := range resp.Body.Updated {
+			m.Updated[i] = UpdateResponse{RecipientKey: u.RecipientDID, Action: u.Action, Result: u.Result}
+		}
+	case *KeylistQuery:
+		query := &KeylistQueryV2{}
+		if err = msg.Decode(query); err != nil {
+			return err
+		}
+
+		m.Type, m.ID, m.Paginate = KeylistQueryMsgTypeV2, query.ID, query.Body.Paginate
+	case *Keylist:
+		keylist := &KeylistV2{}
+		if err = msg.Decode(keylist); err != nil {
+			return err
+		}
+
+		m.Type, m.ID, m.Pagination = KeylistMsgTypeV2, threadID, keylist.Body.Pagination
+		m.Keys = make([]KeylistKey, len(keylist.Body.Keys))
+
+		for i, k := range keylist.Body.Keys {
+			m.Keys[i] = KeylistKey{RecipientKey: k.RecipientDID}
+		}
+	default:
+		return fmt.Errorf("unsupported coordinate mediation 2.0 message %T", v)
+	}
+
+	return nil
+}
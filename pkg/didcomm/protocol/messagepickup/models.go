@@ -66,3 +66,60 @@ type Noop struct {
 	Type string `json:"@type,omitempty"`
 	ID   string `json:"@id,omitempty"`
 }
+
+// StatusRequestV2 sent by the recipient to the mediator to request a status message.
+// https://didcomm.org/messagepickup/2.0/#status-request
+type StatusRequestV2 struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// StatusV2 details about the messages queued for the recipient.
+// https://didcomm.org/messagepickup/2.0/#status
+type StatusV2 struct {
+	Type                 string            `json:"@type,omitempty"`
+	ID                   string            `json:"@id,omitempty"`
+	MessageCount         int               `json:"message_count"`
+	LongestWaitedSeconds int               `json:"longest_waited_seconds,omitempty"`
+	NewestReceivedTime   time.Time         `json:"newest_received_time,omitempty"`
+	OldestReceivedTime   time.Time         `json:"oldest_received_time,omitempty"`
+	TotalBytes           int               `json:"total_bytes,omitempty"`
+	LiveDelivery         bool              `json:"live_delivery"`
+	Thread               *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// DeliveryRequest a request to have up to limit queued messages delivered.
+// https://didcomm.org/messagepickup/2.0/#delivery-request
+type DeliveryRequest struct {
+	Type   string            `json:"@type,omitempty"`
+	ID     string            `json:"@id,omitempty"`
+	Limit  int               `json:"limit"`
+	Thread *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// Delivery a batch of queued messages, each attachment ID is the ID of the queued message.
+// https://didcomm.org/messagepickup/2.0/#message-delivery
+type Delivery struct {
+	Type        string                 `json:"@type,omitempty"`
+	ID          string                 `json:"@id,omitempty"`
+	Attachments []decorator.Attachment `json:"~attach"`
+	Thread      *decorator.Thread      `json:"~thread,omitempty"`
+}
+
+// MessagesReceived acknowledges the delivered messages, which are then removed from the queue.
+// https://didcomm.org/messagepickup/2.0/#messages-received
+type MessagesReceived struct {
+	Type          string            `json:"@type,omitempty"`
+	ID            string            `json:"@id,omitempty"`
+	MessageIDList []string          `json:"message_id_list"`
+	Thread        *decorator.Thread `json:"~thread,omitempty"`
+}
+
+// LiveDeliveryChange turns live delivery of the queued messages on or off.
+// https://didcomm.org/messagepickup/2.0/#live-mode
+type LiveDeliveryChange struct {
+	Type         string `json:"@type,omitempty"`
+	ID           string `json:"@id,omitempty"`
+	LiveDelivery bool   `json:"live_delivery"`
+}
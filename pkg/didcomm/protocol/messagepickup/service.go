@@ -42,6 +42,23 @@ const (
 	NoopMsgType = Spec + "noop"
 )
 
+const (
+	// SpecV2 defines the message pickup 2.0 protocol spec.
+	SpecV2 = "https://didcomm.org/messagepickup/2.0/"
+	// StatusRequestMsgTypeV2 defines the message pickup 2.0 status-request message type.
+	StatusRequestMsgTypeV2 = SpecV2 + "status-request"
+	// StatusMsgTypeV2 defines the message pickup 2.0 status message type.
+	StatusMsgTypeV2 = SpecV2 + "status"
+	// DeliveryRequestMsgType defines the message pickup 2.0 delivery-request message type.
+	DeliveryRequestMsgType = SpecV2 + "delivery-request"
+	// DeliveryMsgType defines the message pickup 2.0 delivery message type.
+	DeliveryMsgType = SpecV2 + "delivery"
+	// MessagesReceivedMsgType defines the message pickup 2.0 messages-received message type.
+	MessagesReceivedMsgType = SpecV2 + "messages-received"
+	// LiveDeliveryChangeMsgType defines the message pickup 2.0 live-delivery-change message type.
+	LiveDeliveryChangeMsgType = SpecV2 + "live-delivery-change"
+)
+
 const (
 	updateTimeout = 50 * time.Second
 
@@ -78,6 +95,10 @@ type Service struct {
 	batchMapLock     sync.RWMutex
 	statusMap        map[string]chan Status
 	statusMapLock    sync.RWMutex
+	deliveryMap      map[string]chan Delivery
+	deliveryMapLock  sync.RWMutex
+	statusV2Map      map[string]chan StatusV2
+	statusV2MapLock  sync.RWMutex
 	inboxLock        sync.Mutex
 }
 
@@ -101,12 +122,15 @@ func New(prov provider, tp transport.Provider) (*Service, error) {
 		msgHandler:       tp.InboundMessageHandler(),
 		batchMap:         make(map[string]chan Batch),
 		statusMap:        make(map[string]chan Status),
+		deliveryMap:      make(map[string]chan Delivery),
+		statusV2Map:      make(map[string]chan StatusV2),
 	}
 
 	return svc, nil
 }
 
 // HandleInbound handles inbound message pick up messages.
+// nolint: gocyclo
 func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
 	// perform action asynchronously
 	go func() {
@@ -123,6 +147,18 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 			err = s.handleBatch(msg)
 		case NoopMsgType:
 			err = s.handleNoop(msg)
+		case StatusMsgTypeV2:
+			err = s.handleStatusV2(msg)
+		case StatusRequestMsgTypeV2:
+			err = s.handleStatusRequestV2(msg, ctx.MyDID(), ctx.TheirDID())
+		case DeliveryRequestMsgType:
+			err = s.handleDeliveryRequest(msg, ctx.MyDID(), ctx.TheirDID())
+		case DeliveryMsgType:
+			err = s.handleDelivery(msg, ctx.MyDID(), ctx.TheirDID())
+		case MessagesReceivedMsgType:
+			err = s.handleMessagesReceived(msg, ctx.MyDID(), ctx.TheirDID())
+		case LiveDeliveryChangeMsgType:
+			err = s.handleLiveDeliveryChange(msg, ctx.MyDID(), ctx.TheirDID())
 		}
 
 		if err != nil {
@@ -143,6 +179,9 @@ func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case BatchPickupMsgType, BatchMsgType, StatusRequestMsgType, StatusMsgType, NoopMsgType:
 		return true
+	case StatusRequestMsgTypeV2, StatusMsgTypeV2, DeliveryRequestMsgType, DeliveryMsgType,
+		MessagesReceivedMsgType, LiveDeliveryChangeMsgType:
+		return true
 	}
 
 	return false
@@ -291,8 +330,261 @@ func (s *Service) handleNoop(msg service.DIDCommMsg) error {
 	return nil
 }
 
+func (s *Service) handleStatusV2(msg service.DIDCommMsg) error {
+	// unmarshal the payload
+	statusMsg := &StatusV2{}
+
+	err := msg.Decode(statusMsg)
+	if err != nil {
+		return fmt.Errorf("status message unmarshal: %w", err)
+	}
+
+	// check if there are any channels registered for the message ID
+	statusCh := s.getStatusV2Ch(statusMsg.ID)
+	if statusCh != nil {
+		// invoke the channel for the incoming message
+		statusCh <- *statusMsg
+	}
+
+	return nil
+}
+
+func (s *Service) handleStatusRequestV2(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	// unmarshal the payload
+	request := &StatusRequestV2{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("status request message unmarshal: %w", err)
+	}
+
+	outbox, msgs, err := s.getInboxMessages(theirDID)
+	if err != nil {
+		return fmt.Errorf("status request get inbox: %w", err)
+	}
+
+	return s.outbound.SendToDID(newStatusV2(msg.ID(), outbox, msgs), myDID, theirDID)
+}
+
+func (s *Service) handleDeliveryRequest(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	// unmarshal the payload
+	request := &DeliveryRequest{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("delivery request message unmarshal: %w", err)
+	}
+
+	outbox, msgs, err := s.getInboxMessages(theirDID)
+	if err != nil {
+		return fmt.Errorf("delivery request get inbox: %w", err)
+	}
+
+	// nothing is queued, the mediator answers with a status
+	if len(msgs) == 0 {
+		return s.outbound.SendToDID(newStatusV2(msg.ID(), outbox, msgs), myDID, theirDID)
+	}
+
+	end := len(msgs)
+	if request.Limit > 0 && request.Limit < end {
+		end = request.Limit
+	}
+
+	// delivered messages are removed from the inbox only once the recipient acknowledged them
+	outbox.LastDeliveredTime = time.Now()
+
+	err = s.putInbox(theirDID, outbox)
+	if err != nil {
+		return fmt.Errorf("delivery request put inbox: %w", err)
+	}
+
+	return s.outbound.SendToDID(newDelivery(msg.ID(), msgs[:end]), myDID, theirDID)
+}
+
+func (s *Service) handleDelivery(msg service.DIDCommMsg, myDID, theirDID string) error {
+	// unmarshal the payload
+	delivery := &Delivery{}
+
+	err := msg.Decode(delivery)
+	if err != nil {
+		return fmt.Errorf("delivery message unmarshal: %w", err)
+	}
+
+	// check if there are any channels registered for the message ID
+	deliveryCh := s.getDeliveryCh(delivery.ID)
+	if deliveryCh != nil {
+		// invoke the channel for the incoming message
+		deliveryCh <- *delivery
+
+		return nil
+	}
+
+	// live delivery, the messages are processed and acknowledged right away
+	_, received := s.processDelivery(delivery)
+
+	return s.outbound.SendToDID(&MessagesReceived{
+		Type:          MessagesReceivedMsgType,
+		ID:            uuid.New().String(),
+		MessageIDList: received,
+	}, myDID, theirDID)
+}
+
+func (s *Service) handleMessagesReceived(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	// unmarshal the payload
+	request := &MessagesReceived{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("messages received message unmarshal: %w", err)
+	}
+
+	outbox, msgs, err := s.getInboxMessages(theirDID)
+	if err != nil {
+		return fmt.Errorf("messages received get inbox: %w", err)
+	}
+
+	received := make(map[string]struct{}, len(request.MessageIDList))
+	for _, id := range request.MessageIDList {
+		received[id] = struct{}{}
+	}
+
+	remaining := make([]*Message, 0, len(msgs))
+
+	for _, m := range msgs {
+		if _, ok := received[m.ID]; !ok {
+			remaining = append(remaining, m)
+		}
+	}
+
+	if len(remaining) != len(msgs) {
+		outbox.LastRemovedTime = time.Now()
+
+		err = outbox.EncodeMessages(remaining)
+		if err != nil {
+			return fmt.Errorf("messages received encode: %w", err)
+		}
+
+		err = s.putInbox(theirDID, outbox)
+		if err != nil {
+			return fmt.Errorf("messages received put inbox: %w", err)
+		}
+	}
+
+	return s.outbound.SendToDID(newStatusV2(msg.ID(), outbox, remaining), myDID, theirDID)
+}
+
+func (s *Service) handleLiveDeliveryChange(msg service.DIDCommMsg, myDID, theirDID string) error {
+	s.inboxLock.Lock()
+	defer s.inboxLock.Unlock()
+
+	// unmarshal the payload
+	request := &LiveDeliveryChange{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return fmt.Errorf("live delivery change message unmarshal: %w", err)
+	}
+
+	outbox, err := s.createInbox(theirDID)
+	if err != nil {
+		return fmt.Errorf("live delivery change get inbox: %w", err)
+	}
+
+	outbox.LiveDelivery = request.LiveDelivery
+	outbox.MyDID = myDID
+
+	err = s.putInbox(theirDID, outbox)
+	if err != nil {
+		return fmt.Errorf("live delivery change put inbox: %w", err)
+	}
+
+	if !outbox.LiveDelivery {
+		return nil
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return fmt.Errorf("live delivery change decode: %w", err)
+	}
+
+	// flush the messages queued before the live delivery was turned on
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return s.outbound.SendToDID(newDelivery(uuid.New().String(), msgs), myDID, theirDID)
+}
+
+// getInboxMessages returns the inbox of theirDID along with its messages, a missing inbox is an empty one.
+func (s *Service) getInboxMessages(theirDID string) (*inbox, []*Message, error) {
+	outbox, err := s.getInbox(theirDID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return &inbox{DID: theirDID}, nil, nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msgs, err := outbox.DecodeMessages()
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode messages: %w", err)
+	}
+
+	return outbox, msgs, nil
+}
+
+func newStatusV2(id string, outbox *inbox, msgs []*Message) *StatusV2 {
+	status := &StatusV2{
+		Type:         StatusMsgTypeV2,
+		ID:           id,
+		MessageCount: len(msgs),
+		LiveDelivery: outbox.LiveDelivery,
+	}
+
+	if len(msgs) > 0 {
+		status.TotalBytes = outbox.TotalSize
+		status.OldestReceivedTime = msgs[0].AddedTime
+		status.NewestReceivedTime = msgs[len(msgs)-1].AddedTime
+		status.LongestWaitedSeconds = int(time.Since(status.OldestReceivedTime).Seconds())
+	}
+
+	return status
+}
+
+func newDelivery(id string, msgs []*Message) *Delivery {
+	delivery := &Delivery{
+		Type:        DeliveryMsgType,
+		ID:          id,
+		Attachments: make([]decorator.Attachment, len(msgs)),
+	}
+
+	for i, m := range msgs {
+		delivery.Attachments[i] = decorator.Attachment{
+			ID:          m.ID,
+			LastModTime: m.AddedTime,
+			Data: decorator.AttachmentData{
+				JSON: m.Message,
+			},
+		}
+	}
+
+	return delivery
+}
+
 type inbox struct {
 	DID               string          `json:"DID"`
+	MyDID             string          `json:"myDID,omitempty"`
+	LiveDelivery      bool            `json:"live_delivery,omitempty"`
 	MessageCount      int             `json:"message_count"`
 	LastAddedTime     time.Time       `json:"last_added_time,omitempty"`
 	LastDeliveredTime time.Time       `json:"last_delivered_time,omitempty"`
@@ -364,6 +656,14 @@ func (s *Service) AddMessage(message *model.Envelope, theirDID string) error {
 		return fmt.Errorf("unable to put messages: %w", err)
 	}
 
+	if outbox.LiveDelivery {
+		// the message stays queued until the recipient acknowledges it with a messages-received
+		err = s.outbound.SendToDID(newDelivery(uuid.New().String(), []*Message{&m}), outbox.MyDID, theirDID)
+		if err != nil {
+			logger.Warnf("live delivery of message %s to %s failed: %s", m.ID, theirDID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -523,6 +823,150 @@ func (s *Service) Noop(connectionID string) error {
 	return nil
 }
 
+// StatusRequestV2 request a message pickup 2.0 status message.
+func (s *Service) StatusRequestV2(connectionID string) (*StatusV2, error) {
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate message ID
+	msgID := uuid.New().String()
+
+	// register chan for callback processing
+	statusCh := make(chan StatusV2)
+	s.setStatusV2Ch(msgID, statusCh)
+
+	defer s.setStatusV2Ch(msgID, nil)
+
+	req := &StatusRequestV2{
+		Type: StatusRequestMsgTypeV2,
+		ID:   msgID,
+	}
+
+	// send message to the router
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return nil, fmt.Errorf("send status request: %w", err)
+	}
+
+	select {
+	case sts := <-statusCh:
+		return &sts, nil
+	case <-time.After(updateTimeout):
+		return nil, errors.New("timeout waiting for status")
+	}
+}
+
+// DeliveryRequest requests up to limit queued messages from the mediator, processes the delivered messages
+// and acknowledges them with a messages-received, so that the mediator removes them from its queue.
+// Returns the number of successfully processed messages.
+func (s *Service) DeliveryRequest(connectionID string, limit int) (int, error) {
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return -1, err
+	}
+
+	// generate message ID
+	msgID := uuid.New().String()
+
+	// register chans for callback processing, the mediator answers with a status if nothing is queued
+	deliveryCh := make(chan Delivery)
+	s.setDeliveryCh(msgID, deliveryCh)
+
+	defer s.setDeliveryCh(msgID, nil)
+
+	statusCh := make(chan StatusV2)
+	s.setStatusV2Ch(msgID, statusCh)
+
+	defer s.setStatusV2Ch(msgID, nil)
+
+	req := &DeliveryRequest{
+		Type:  DeliveryRequestMsgType,
+		ID:    msgID,
+		Limit: limit,
+	}
+
+	// send message to the router
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return -1, fmt.Errorf("send delivery request: %w", err)
+	}
+
+	select {
+	case delivery := <-deliveryCh:
+		processed, received := s.processDelivery(&delivery)
+
+		ack := &MessagesReceived{
+			Type:          MessagesReceivedMsgType,
+			ID:            uuid.New().String(),
+			MessageIDList: received,
+		}
+
+		if err := s.outbound.SendToDID(ack, conn.MyDID, conn.TheirDID); err != nil {
+			return -1, fmt.Errorf("send messages received: %w", err)
+		}
+
+		return processed, nil
+	case <-statusCh:
+		return 0, nil
+	case <-time.After(updateTimeout):
+		return -1, errors.New("timeout waiting for delivery")
+	}
+}
+
+// LiveDeliveryChange turns the live delivery of the messages queued by the mediator on or off.
+func (s *Service) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	// get the connection record for the ID to fetch DID information
+	conn, err := s.getConnection(connectionID)
+	if err != nil {
+		return err
+	}
+
+	req := &LiveDeliveryChange{
+		Type:         LiveDeliveryChangeMsgType,
+		ID:           uuid.New().String(),
+		LiveDelivery: liveDelivery,
+	}
+
+	if err := s.outbound.SendToDID(req, conn.MyDID, conn.TheirDID); err != nil {
+		return fmt.Errorf("send live delivery change: %w", err)
+	}
+
+	return nil
+}
+
+// processDelivery handles the delivered messages and returns the number of successfully processed ones along
+// with the IDs of all the received messages.
+func (s *Service) processDelivery(delivery *Delivery) (int, []string) {
+	var processed int
+
+	received := make([]string, 0, len(delivery.Attachments))
+
+	for i := range delivery.Attachments {
+		a := delivery.Attachments[i]
+		received = append(received, a.ID)
+
+		d, err := a.Data.Fetch()
+		if err != nil {
+			logger.Errorf("error fetching delivered message %s: %w", a.ID, err)
+
+			continue
+		}
+
+		err = s.handleEnvelope(d)
+		if err != nil {
+			logger.Errorf("error handling delivered message %s: %w", a.ID, err)
+
+			continue
+		}
+
+		processed++
+	}
+
+	return processed, received
+}
+
 func (s *Service) getConnection(routerConnID string) (*connection.Record, error) {
 	conn, err := s.connectionLookup.GetConnectionRecord(routerConnID)
 	if err != nil {
@@ -572,12 +1016,52 @@ func (s *Service) setStatusCh(msgID string, statusCh chan Status) {
 	}
 }
 
+func (s *Service) getDeliveryCh(msgID string) chan Delivery {
+	s.deliveryMapLock.RLock()
+	defer s.deliveryMapLock.RUnlock()
+
+	return s.deliveryMap[msgID]
+}
+
+func (s *Service) setDeliveryCh(msgID string, deliveryCh chan Delivery) {
+	s.deliveryMapLock.Lock()
+	defer s.deliveryMapLock.Unlock()
+
+	if deliveryCh == nil {
+		delete(s.deliveryMap, msgID)
+	} else {
+		s.deliveryMap[msgID] = deliveryCh
+	}
+}
+
+func (s *Service) getStatusV2Ch(msgID string) chan StatusV2 {
+	s.statusV2MapLock.RLock()
+	defer s.statusV2MapLock.RUnlock()
+
+	return s.statusV2Map[msgID]
+}
+
+func (s *Service) setStatusV2Ch(msgID string, statusCh chan StatusV2) {
+	s.statusV2MapLock.Lock()
+	defer s.statusV2MapLock.Unlock()
+
+	if statusCh == nil {
+		delete(s.statusV2Map, msgID)
+	} else {
+		s.statusV2Map[msgID] = statusCh
+	}
+}
+
 func (s *Service) handle(msg *Message) error {
 	d, err := json.Marshal(msg.Message)
 	if err != nil {
 		return fmt.Errorf("failed to marshal msg: %w", err)
 	}
 
+	return s.handleEnvelope(d)
+}
+
+func (s *Service) handleEnvelope(d []byte) error {
 	unpackMsg, err := s.packager.UnpackMessage(d)
 	if err != nil {
 		return fmt.Errorf("failed to unpack msg: %w", err)
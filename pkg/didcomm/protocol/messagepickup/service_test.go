@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -445,6 +446,12 @@ func TestAccept(t *testing.T) {
 		require.True(t, svc.Accept(NoopMsgType))
 		require.True(t, svc.Accept(BatchMsgType))
 		require.True(t, svc.Accept(BatchPickupMsgType))
+		require.True(t, svc.Accept(StatusRequestMsgTypeV2))
+		require.True(t, svc.Accept(StatusMsgTypeV2))
+		require.True(t, svc.Accept(DeliveryRequestMsgType))
+		require.True(t, svc.Accept(DeliveryMsgType))
+		require.True(t, svc.Accept(MessagesReceivedMsgType))
+		require.True(t, svc.Accept(LiveDeliveryChangeMsgType))
 		require.False(t, svc.Accept("random-msg-type"))
 	})
 }
@@ -970,6 +977,210 @@ func TestGetConnection(t *testing.T) {
 	})
 }
 
+func TestMessagePickupV2(t *testing.T) {
+	envelope := &model.Envelope{
+		Protected: "eyJ0eXAiOiJwcnMuaHlwZXJsZWRnZXIuYXJpZXMtYXV0aC1t" +
+			"ZXNzYWdlIiwiYWxnIjoiRUNESC1TUytYQzIwUEtXIiwiZW5jIjoiWEMyMFAifQ",
+		IV:         "JS2FxjEKdndnt-J7QX5pEnVwyBTu0_3d",
+		CipherText: "qQyzvajdvCDJbwxM",
+		Tag:        "2FqZMMQuNPYfL0JsSkj8LQ",
+	}
+
+	t.Run("test message pickup 2.0 - enqueue, delivery request, delivery and messages received", func(t *testing.T) {
+		agent, mediator, wire := newPickupV2Pair(t)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, mediator.AddMessage(envelope, MYDID))
+		}
+
+		_, queued, err := mediator.getInboxMessages(MYDID)
+		require.NoError(t, err)
+		require.Len(t, queued, 3)
+
+		sts, err := agent.StatusRequestV2("conn")
+		require.NoError(t, err)
+		require.Equal(t, 3, sts.MessageCount)
+		require.False(t, sts.LiveDelivery)
+
+		// the batch size limits the delivered messages
+		processed, err := agent.DeliveryRequest("conn", 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, processed)
+
+		// acknowledged messages are removed from the queue
+		require.Eventually(t, func() bool {
+			_, msgs, e := mediator.getInboxMessages(MYDID)
+			require.NoError(t, e)
+
+			return len(msgs) == 1 && msgs[0].ID == queued[2].ID
+		}, time.Second, 10*time.Millisecond)
+
+		processed, err = agent.DeliveryRequest("conn", 2)
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+
+		require.Eventually(t, func() bool {
+			_, msgs, e := mediator.getInboxMessages(MYDID)
+			require.NoError(t, e)
+
+			return len(msgs) == 0
+		}, time.Second, 10*time.Millisecond)
+
+		// nothing is queued, the mediator answers with a status
+		processed, err = agent.DeliveryRequest("conn", 2)
+		require.NoError(t, err)
+		require.Equal(t, 0, processed)
+
+		// the status answering a messages-received may be sent after the next delivery-request
+		require.ElementsMatch(t, []string{
+			StatusRequestMsgTypeV2, StatusMsgTypeV2,
+			DeliveryRequestMsgType, DeliveryMsgType, MessagesReceivedMsgType, StatusMsgTypeV2,
+			DeliveryRequestMsgType, DeliveryMsgType, MessagesReceivedMsgType, StatusMsgTypeV2,
+			DeliveryRequestMsgType, StatusMsgTypeV2,
+		}, wire.types())
+	})
+
+	t.Run("test message pickup 2.0 - live delivery", func(t *testing.T) {
+		agent, mediator, _ := newPickupV2Pair(t)
+
+		// queued before the live delivery is turned on
+		require.NoError(t, mediator.AddMessage(envelope, MYDID))
+
+		require.NoError(t, agent.LiveDeliveryChange("conn", true))
+
+		require.Eventually(t, func() bool {
+			outbox, msgs, e := mediator.getInboxMessages(MYDID)
+			require.NoError(t, e)
+
+			return outbox.LiveDelivery && len(msgs) == 0
+		}, time.Second, 10*time.Millisecond)
+
+		// delivered as soon as it is queued
+		require.NoError(t, mediator.AddMessage(envelope, MYDID))
+
+		require.Eventually(t, func() bool {
+			_, msgs, e := mediator.getInboxMessages(MYDID)
+			require.NoError(t, e)
+
+			return len(msgs) == 0
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, agent.LiveDeliveryChange("conn", false))
+
+		require.Eventually(t, func() bool {
+			outbox, _, e := mediator.getInboxMessages(MYDID)
+			require.NoError(t, e)
+
+			return !outbox.LiveDelivery
+		}, time.Second, 10*time.Millisecond)
+
+		require.NoError(t, mediator.AddMessage(envelope, MYDID))
+
+		sts, err := agent.StatusRequestV2("conn")
+		require.NoError(t, err)
+		require.Equal(t, 1, sts.MessageCount)
+	})
+
+	t.Run("test message pickup 2.0 - messages received for unknown messages", func(t *testing.T) {
+		svc, err := getService()
+		require.NoError(t, err)
+
+		svc.outbound = &mockdispatcher.MockOutbound{}
+
+		require.NoError(t, svc.AddMessage(envelope, THEIRDID))
+
+		msg, err := service.ParseDIDCommMsgMap([]byte(fmt.Sprintf(`{"@id":"123","@type":%q,"message_id_list":["x"]}`,
+			MessagesReceivedMsgType)))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.handleMessagesReceived(msg, MYDID, THEIRDID))
+
+		_, msgs, err := svc.getInboxMessages(THEIRDID)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+	})
+
+	t.Run("test message pickup 2.0 - delivery request - connection error", func(t *testing.T) {
+		svc, err := getService()
+		require.NoError(t, err)
+
+		_, err = svc.DeliveryRequest("conn", 1)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrConnectionNotFound))
+	})
+}
+
+// wireRecorder records the types of the messages exchanged through the in-memory transport.
+type wireRecorder struct {
+	msgTypes []string
+	lock     sync.Mutex
+}
+
+func (w *wireRecorder) record(msgType string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.msgTypes = append(w.msgTypes, msgType)
+}
+
+func (w *wireRecorder) types() []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return append([]string(nil), w.msgTypes...)
+}
+
+// newPickupV2Pair creates an agent and a mediator service delivering their outbound messages to each other.
+func newPickupV2Pair(t *testing.T) (*Service, *Service, *wireRecorder) {
+	t.Helper()
+
+	var agent, mediator *Service
+
+	wire := &wireRecorder{}
+
+	transport := func(to **Service) *mockdispatcher.MockOutbound {
+		return &mockdispatcher.MockOutbound{
+			ValidateSendToDID: func(msg interface{}, myDID, theirDID string) error {
+				src, err := json.Marshal(msg)
+				require.NoError(t, err)
+
+				didMsg, err := service.ParseDIDCommMsgMap(src)
+				require.NoError(t, err)
+
+				wire.record(didMsg.Type())
+
+				_, err = (*to).HandleInbound(didMsg, service.NewDIDCommContext(theirDID, myDID, nil))
+
+				return err
+			},
+		}
+	}
+
+	agentProvider := &mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           transport(&mediator),
+	}
+
+	r, err := connection.NewRecorder(agentProvider)
+	require.NoError(t, err)
+	require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+		ConnectionID: "conn", MyDID: MYDID, TheirDID: THEIRDID, State: "completed",
+	}))
+
+	agent, err = New(agentProvider, &mockTransportProvider{packagerValue: &mockPackager{}})
+	require.NoError(t, err)
+
+	mediator, err = New(&mockprovider.Provider{
+		StorageProviderValue:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstore.NewMockStoreProvider(),
+		OutboundDispatcherValue:           transport(&agent),
+	}, &mockTransportProvider{packagerValue: &mockPackager{}})
+	require.NoError(t, err)
+
+	return agent, mediator, wire
+}
+
 func getService() (*Service, error) {
 	svc, err := New(&mockprovider.Provider{
 		StorageProviderValue:              mockstore.NewMockStoreProvider(),
@@ -7,21 +7,42 @@ SPDX-License-Identifier: Apache-2.0
 package issuecredential
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 const (
 	stateNameCredentialReceived = "credential-received"
+	stateNameProposalReceived   = "proposal-received"
+	stateNameRequestReceived    = "request-received"
 	myDIDKey                    = "myDID"
 	theirDIDKey                 = "theirDID"
 	namesKey                    = "names"
@@ -37,6 +58,14 @@ type Provider interface {
 	JSONLDDocumentLoader() ld.DocumentLoader
 }
 
+// IssuerProvider contains dependencies for the IssueCredentialsV3 middleware function.
+type IssuerProvider interface {
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	VDRegistry() vdrapi.Registry
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
 // SaveCredentials the helper function for the issue credential protocol which saves credentials.
 func SaveCredentials(p Provider) issuecredential.Middleware {
 	vdr := p.VDRegistry()
@@ -49,14 +78,12 @@ func SaveCredentials(p Provider) issuecredential.Middleware {
 				return next.Handle(metadata)
 			}
 
-			credential := issuecredential.IssueCredential{}
-
-			err := metadata.Message().Decode(&credential)
+			attachments, err := credentialAttachments(metadata.Message())
 			if err != nil {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			credentials, err := toVerifiableCredentials(vdr, credential.CredentialsAttach, documentLoader)
+			credentials, err := toVerifiableCredentials(vdr, attachments, documentLoader)
 			if err != nil {
 				return fmt.Errorf("to verifiable credentials: %w", err)
 			}
@@ -108,12 +135,42 @@ func getName(idx int, id string, metadata issuecredential.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachment,
+func credentialAttachments(msg service.DIDCommMsg) ([]decorator.AttachmentData, error) {
+	var result []decorator.AttachmentData
+
+	if msg.Type() == issuecredential.IssueCredentialMsgTypeV3 {
+		credential := issuecredential.IssueCredentialV3{}
+
+		if err := msg.Decode(&credential); err != nil {
+			return nil, err
+		}
+
+		for i := range credential.Attachments {
+			result = append(result, credential.Attachments[i].Data)
+		}
+
+		return result, nil
+	}
+
+	credential := issuecredential.IssueCredential{}
+
+	if err := msg.Decode(&credential); err != nil {
+		return nil, err
+	}
+
+	for i := range credential.CredentialsAttach {
+		result = append(result, credential.CredentialsAttach[i].Data)
+	}
+
+	return result, nil
+}
+
+func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.AttachmentData,
 	documentLoader ld.DocumentLoader) ([]*verifiable.Credential, error) {
 	var credentials []*verifiable.Credential
 
 	for i := range attachments {
-		rawVC, err := attachments[i].Data.Fetch()
+		rawVC, err := attachments[i].Fetch()
 		if err != nil {
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
@@ -130,3 +187,496 @@ func toVerifiableCredentials(v vdrapi.Registry, attachments []decorator.Attachme
 
 	return credentials, nil
 }
+
+// IssueCredentialsV3 the helper function for the issue credential 3.0 protocol which signs the credentials
+// requested by the holder. Credentials are signed by the KMS key of the given verification method
+// (e.g. did:example:123#key-1), which is resolved with the VDR, and issued in the format requested by the holder
+// (JSON-LD or JWT). JSON-LD credentials get a proof of the type (and cryptosuite, for Data Integrity proofs)
+// requested in the aries/ld-proof-vc-detail options, or else the default proof of the key type.
+// A proposal or request with an unsupported attachment format makes the protocol abandon with a problem-report.
+func IssueCredentialsV3(p IssuerProvider, verificationMethod string) issuecredential.Middleware {
+	keyManager := p.KMS()
+	ariesCrypto := p.Crypto()
+	vdr := p.VDRegistry()
+	documentLoader := p.JSONLDDocumentLoader()
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+			msg := metadata.Message()
+
+			if msg.Type() == issuecredential.ProposeCredentialMsgTypeV3 &&
+				metadata.StateName() == stateNameProposalReceived {
+				proposal := issuecredential.ProposeCredentialV3{}
+
+				if err := msg.Decode(&proposal); err != nil {
+					return fmt.Errorf("decode: %w", err)
+				}
+
+				if err := checkFormats(proposal.Attachments); err != nil {
+					return fmt.Errorf("proposal: %w", err)
+				}
+			}
+
+			if msg.Type() == issuecredential.RequestCredentialMsgTypeV3 &&
+				metadata.StateName() == stateNameRequestReceived {
+				c := &credentialIssuer{
+					keyManager:     keyManager,
+					crypto:         ariesCrypto,
+					vdr:            vdr,
+					vm:             verificationMethod,
+					documentLoader: documentLoader,
+				}
+
+				if err := c.handleRequest(msg, metadata.IssueCredentialV3()); err != nil {
+					return err
+				}
+			}
+
+			return next.Handle(metadata)
+		})
+	}
+}
+
+func checkFormats(attachments []decorator.AttachmentV2) error {
+	for i := range attachments {
+		if attachments[i].Format != issuecredential.LDProofVCFormat &&
+			attachments[i].Format != issuecredential.JWTVCFormat {
+			return fmt.Errorf("unsupported credential format %q", attachments[i].Format)
+		}
+	}
+
+	return nil
+}
+
+type credentialIssuer struct {
+	keyManager     kms.KeyManager
+	crypto         crypto.Crypto
+	vdr            vdrapi.Registry
+	vm             string
+	documentLoader ld.DocumentLoader
+}
+
+// handleRequest fills the issue-credential message (if it has no attachments yet) with the signed credentials
+// which were requested by the holder.
+func (c *credentialIssuer) handleRequest(msg service.DIDCommMsg, issue *issuecredential.IssueCredentialV3) error {
+	request := issuecredential.RequestCredentialV3{}
+
+	if err := msg.Decode(&request); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	if err := checkFormats(request.Attachments); err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+
+	if issue == nil || len(issue.Attachments) > 0 {
+		return nil
+	}
+
+	key, err := resolveIssuerKey(c.vdr, c.vm)
+	if err != nil {
+		return fmt.Errorf("issuer key: %w", err)
+	}
+
+	keySigner, err := newKMSSigner(c.keyManager, c.crypto, key)
+	if err != nil {
+		return fmt.Errorf("new signer: %w", err)
+	}
+
+	for i := range request.Attachments {
+		attachment, err := c.issue(keySigner, key, &request.Attachments[i])
+		if err != nil {
+			return fmt.Errorf("issue credential: %w", err)
+		}
+
+		issue.Attachments = append(issue.Attachments, *attachment)
+	}
+
+	return nil
+}
+
+func (c *credentialIssuer) issue(keySigner *kmsSigner, key *issuerKey,
+	attachment *decorator.AttachmentV2) (*decorator.AttachmentV2, error) {
+	raw, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetch: %w", err)
+	}
+
+	if attachment.Format == issuecredential.JWTVCFormat {
+		return c.issueJWT(keySigner, key, raw)
+	}
+
+	detail, err := parseLDProofVCDetail(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := ldProofSpecFor(detail.Options.ProofType, detail.Options.Cryptosuite, key.keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, err := c.credentialToSign(key, detail.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureSuite, err := spec.suite(keySigner, key)
+	if err != nil {
+		return nil, fmt.Errorf("signature suite: %w", err)
+	}
+
+	addContext(vc, spec.context)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           spec.proofType,
+		Suite:                   signatureSuite,
+		SignatureRepresentation: spec.representation,
+		VerificationMethod:      c.vm,
+	}, jsonld.WithDocumentLoader(c.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("add linked data proof: %w", err)
+	}
+
+	return &decorator.AttachmentV2{
+		ID:        uuid.New().String(),
+		MediaType: "application/ld+json",
+		Format:    issuecredential.LDProofVCFormat,
+		Data:      decorator.AttachmentData{JSON: vc},
+	}, nil
+}
+
+func (c *credentialIssuer) issueJWT(keySigner *kmsSigner, key *issuerKey,
+	raw []byte) (*decorator.AttachmentV2, error) {
+	alg, ok := jwtAlgorithms[key.keyType]
+	if !ok {
+		return nil, fmt.Errorf("no JWT algorithm for %s keys", key.keyType)
+	}
+
+	vc, err := c.credentialToSign(key, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := vc.JWTClaims(false)
+	if err != nil {
+		return nil, fmt.Errorf("jwt claims: %w", err)
+	}
+
+	jws, err := claims.MarshalJWS(alg, keySigner, c.vm)
+	if err != nil {
+		return nil, fmt.Errorf("marshal jws: %w", err)
+	}
+
+	return &decorator.AttachmentV2{
+		ID:        uuid.New().String(),
+		MediaType: "application/jwt",
+		Format:    issuecredential.JWTVCFormat,
+		Data:      decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(jws))},
+	}, nil
+}
+
+// credentialToSign rebuilds the credential requested by the holder from its claims: the issuance date and the
+// proofs are the issuer's, and the status, evidence, terms of use and refresh service the holder may have set are
+// dropped. The requested credential must name the issuer of the verification method.
+func (c *credentialIssuer) credentialToSign(key *issuerKey, raw []byte) (*verifiable.Credential, error) {
+	requested, err := verifiable.ParseCredential(raw,
+		verifiable.WithDisabledProofCheck(),
+		verifiable.WithJSONLDDocumentLoader(c.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("parse credential: %w", err)
+	}
+
+	if requested.Issuer.ID != key.issuerDID {
+		return nil, fmt.Errorf("credential issuer %q is not %q", requested.Issuer.ID, key.issuerDID)
+	}
+
+	return &verifiable.Credential{
+		Context:       requested.Context,
+		CustomContext: requested.CustomContext,
+		ID:            requested.ID,
+		Types:         requested.Types,
+		Subject:       requested.Subject,
+		Issuer:        verifiable.Issuer{ID: key.issuerDID},
+		Issued:        util.NewTime(time.Now().UTC()),
+		Expired:       requested.Expired,
+		Schemas:       requested.Schemas,
+		CustomFields:  requested.CustomFields,
+	}, nil
+}
+
+// ldProofVCDetail is the aries/ld-proof-vc-detail (RFC 0593) form of a request of a JSON-LD credential: the
+// credential with the options of its proof. A request may also hold the bare credential, which then gets the
+// default proof of the issuer's key.
+type ldProofVCDetail struct {
+	Credential json.RawMessage  `json:"credential"`
+	Options    ldProofVCOptions `json:"options"`
+}
+
+type ldProofVCOptions struct {
+	ProofType   string `json:"proofType,omitempty"`
+	Cryptosuite string `json:"cryptosuite,omitempty"`
+}
+
+func parseLDProofVCDetail(raw []byte) (*ldProofVCDetail, error) {
+	detail := &ldProofVCDetail{}
+
+	if err := json.Unmarshal(raw, detail); err != nil {
+		return nil, fmt.Errorf("unmarshal credential detail: %w", err)
+	}
+
+	if len(detail.Credential) == 0 {
+		return &ldProofVCDetail{Credential: raw}, nil
+	}
+
+	return detail, nil
+}
+
+// ldProofSpec specifies the linked data proofs of a proof type (and cryptosuite) the issuer creates.
+type ldProofSpec struct {
+	proofType      string
+	keyTypes       []kms.KeyType
+	representation verifiable.SignatureRepresentation
+	// context is the JSON-LD context defining the terms of the proof, it is added to the credential if missing.
+	context string
+	suite   func(s suite.Signer, key *issuerKey) (signer.SignatureSuite, error)
+}
+
+type ldProofKey struct {
+	proofType   string
+	cryptosuite string
+}
+
+const (
+	jsonWebSignature2020       = "JsonWebSignature2020"
+	jsonWebKey2020             = "JsonWebKey2020"
+	dataIntegrityContext       = "https://w3id.org/security/data-integrity/v2"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	assertionMethod            = "assertionMethod"
+)
+
+// nolint:gochecknoglobals
+var (
+	ldProofSpecs = map[ldProofKey]ldProofSpec{
+		{proofType: ed25519signature2018.SignatureType}: {
+			keyTypes:       []kms.KeyType{kms.ED25519Type},
+			representation: verifiable.SignatureJWS,
+			suite: func(s suite.Signer, _ *issuerKey) (signer.SignatureSuite, error) {
+				return ed25519signature2018.New(suite.WithSigner(s)), nil
+			},
+		},
+		{proofType: ed25519signature2020.SignatureType}: {
+			keyTypes:       []kms.KeyType{kms.ED25519Type},
+			representation: verifiable.SignatureProofValue,
+			context:        "https://w3id.org/security/suites/ed25519-2020/v1",
+			suite: func(s suite.Signer, _ *issuerKey) (signer.SignatureSuite, error) {
+				return ed25519signature2020.New(suite.WithSigner(s)), nil
+			},
+		},
+		{proofType: jsonWebSignature2020}: {
+			keyTypes: []kms.KeyType{
+				kms.ED25519Type, kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSASecp256k1TypeIEEEP1363,
+			},
+			representation: verifiable.SignatureJWS,
+			context:        "https://w3id.org/security/suites/jws-2020/v1",
+			suite: func(s suite.Signer, key *issuerKey) (signer.SignatureSuite, error) {
+				// the proofs are verified with JsonWebKey2020 verification methods only
+				if key.pubKey.Type != jsonWebKey2020 {
+					return nil, fmt.Errorf("verification method of type %q is not a %s", key.pubKey.Type, jsonWebKey2020)
+				}
+
+				alg, err := jsonwebsignature2020.VerificationMethodJWSAlgorithm(key.pubKey)
+				if err != nil {
+					return nil, err
+				}
+
+				jwsSigner := jsonwebsignature2020.NewSignerWithJWSAlgorithm(s, alg)
+
+				return jsonwebsignature2020.New(suite.WithSigner(jwsSigner)), nil
+			},
+		},
+		{proofType: proof.DataIntegrityProofType, cryptosuite: eddsardfc2022.CryptosuiteID}: {
+			keyTypes:       []kms.KeyType{kms.ED25519Type},
+			representation: verifiable.SignatureProofValue,
+			context:        dataIntegrityContext,
+			suite: func(s suite.Signer, _ *issuerKey) (signer.SignatureSuite, error) {
+				return eddsardfc2022.New(suite.WithSigner(s)), nil
+			},
+		},
+		{proofType: proof.DataIntegrityProofType, cryptosuite: ecdsardfc2019.CryptosuiteID}: {
+			keyTypes:       []kms.KeyType{kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363},
+			representation: verifiable.SignatureProofValue,
+			context:        dataIntegrityContext,
+			suite: func(s suite.Signer, key *issuerKey) (signer.SignatureSuite, error) {
+				if key.keyType == kms.ECDSAP384TypeIEEEP1363 {
+					return ecdsardfc2019.NewP384(suite.WithSigner(s)), nil
+				}
+
+				return ecdsardfc2019.New(suite.WithSigner(s)), nil
+			},
+		},
+	}
+
+	// defaultLDProofs are the proofs of the credentials requested without a proof type.
+	defaultLDProofs = map[kms.KeyType]ldProofKey{
+		kms.ED25519Type:                 {proofType: ed25519signature2018.SignatureType},
+		kms.ECDSAP256TypeIEEEP1363:      {proofType: jsonWebSignature2020},
+		kms.ECDSAP384TypeIEEEP1363:      {proofType: jsonWebSignature2020},
+		kms.ECDSASecp256k1TypeIEEEP1363: {proofType: jsonWebSignature2020},
+	}
+
+	// defaultCryptosuites are the cryptosuites of the Data Integrity proofs requested without a cryptosuite.
+	defaultCryptosuites = map[kms.KeyType]string{
+		kms.ED25519Type:            eddsardfc2022.CryptosuiteID,
+		kms.ECDSAP256TypeIEEEP1363: ecdsardfc2019.CryptosuiteID,
+		kms.ECDSAP384TypeIEEEP1363: ecdsardfc2019.CryptosuiteID,
+	}
+
+	jwtAlgorithms = map[kms.KeyType]verifiable.JWSAlgorithm{
+		kms.ED25519Type:                 verifiable.EdDSA,
+		kms.ECDSAP256TypeIEEEP1363:      verifiable.ES256,
+		kms.ECDSAP384TypeIEEEP1363:      verifiable.ES384,
+		kms.ECDSASecp256k1TypeIEEEP1363: verifiable.ES256K,
+	}
+)
+
+// ldProofSpecFor returns the spec of the requested proof type and cryptosuite, which must support the key type of
+// the issuer.
+func ldProofSpecFor(proofType, cryptosuite string, keyType kms.KeyType) (*ldProofSpec, error) {
+	key := ldProofKey{proofType: proofType, cryptosuite: cryptosuite}
+
+	if proofType == "" {
+		key = defaultLDProofs[keyType]
+	}
+
+	if key.proofType == proof.DataIntegrityProofType && key.cryptosuite == "" {
+		key.cryptosuite = defaultCryptosuites[keyType]
+	}
+
+	spec, ok := ldProofSpecs[key]
+	if !ok {
+		return nil, fmt.Errorf("unsupported proof type %q (cryptosuite %q) for %s keys",
+			key.proofType, key.cryptosuite, keyType)
+	}
+
+	for _, kt := range spec.keyTypes {
+		if kt == keyType {
+			spec.proofType = key.proofType
+
+			return &spec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("proof type %q (cryptosuite %q) does not support %s keys",
+		key.proofType, key.cryptosuite, keyType)
+}
+
+// addContext adds the context to the credential, unless it is already there.
+func addContext(vc *verifiable.Credential, context string) {
+	if context == "" {
+		return
+	}
+
+	for _, c := range vc.Context {
+		if c == context {
+			return
+		}
+	}
+
+	vc.Context = append(vc.Context, context)
+}
+
+// issuerKey is the key of the issuer's verification method.
+type issuerKey struct {
+	issuerDID string
+	pubKey    *sigverifier.PublicKey
+	keyType   kms.KeyType
+	// kid is the ID of the key in the KMS.
+	kid string
+}
+
+// resolveIssuerKey resolves the verification method, which must be an assertion method of the issuer. The KMS key
+// ID is derived from its public key as the KMS does it (see localkms.CreateKID), ECDSA keys are expected to be
+// IEEE P1363 keys.
+func resolveIssuerKey(vdr vdrapi.Registry, verificationMethod string) (*issuerKey, error) {
+	idx := strings.Index(verificationMethod, "#")
+	if idx == -1 {
+		return nil, fmt.Errorf("verification method %s has no fragment", verificationMethod)
+	}
+
+	issuerDID := verificationMethod[:idx]
+
+	pubKey, err := verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()(issuerDID, verificationMethod[idx:])
+	if err != nil {
+		return nil, fmt.Errorf("resolve verification method: %w", err)
+	}
+
+	if !containsString(pubKey.ProofPurposes, assertionMethod) {
+		return nil, fmt.Errorf("verification method %s is not an assertion method", verificationMethod)
+	}
+
+	keyType, keyBytes, err := publicKeyOf(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := jwkkid.CreateKID(keyBytes, keyType)
+	if err != nil {
+		return nil, fmt.Errorf("kms key ID: %w", err)
+	}
+
+	return &issuerKey{issuerDID: issuerDID, pubKey: pubKey, keyType: keyType, kid: kid}, nil
+}
+
+// publicKeyOf returns the kms key type and the public key bytes of the verification method.
+func publicKeyOf(pubKey *sigverifier.PublicKey) (kms.KeyType, []byte, error) {
+	if pubKey.JWK != nil {
+		keyType, err := pubKey.JWK.KeyType()
+		if err != nil {
+			return "", nil, fmt.Errorf("key type: %w", err)
+		}
+
+		keyBytes, err := pubKey.JWK.PublicKeyBytes()
+		if err != nil {
+			return "", nil, fmt.Errorf("public key bytes: %w", err)
+		}
+
+		return keyType, keyBytes, nil
+	}
+
+	switch pubKey.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return kms.ED25519Type, pubKey.Value, nil
+	default:
+		return "", nil, fmt.Errorf("unsupported verification method type %q", pubKey.Type)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type kmsSigner struct {
+	keyHandle interface{}
+	crypto    crypto.Crypto
+}
+
+func newKMSSigner(keyManager kms.KeyManager, c crypto.Crypto, key *issuerKey) (*kmsSigner, error) {
+	keyHandle, err := keyManager.Get(key.kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kmsSigner{keyHandle: keyHandle, crypto: c}, nil
+}
+
+func (s *kmsSigner) Sign(data []byte) ([]byte, error) {
+	return s.crypto.Sign(data, s.keyHandle)
+}
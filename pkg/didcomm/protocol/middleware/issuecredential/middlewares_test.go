@@ -16,16 +16,24 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/middleware/issuecredential"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/framework/aries/api/vdr"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 )
 
 func getCredential() *verifiable.Credential {
@@ -315,15 +323,18 @@ func TestSaveCredentials(t *testing.T) {
 		loader, err := jsonldtest.DocumentLoader()
 		require.NoError(t, err)
 
+		issuerKey := did.VerificationMethod{
+			ID: "#key1",
+			Value: []byte{
+				234, 100, 192, 93, 251, 181, 198, 73, 122, 220, 27, 48, 93, 73, 166,
+				33, 152, 140, 168, 36, 9, 205, 59, 161, 137, 7, 164, 9, 176, 252, 1, 171,
+			},
+		}
+
 		registry := mockvdr.NewMockRegistry(ctrl)
 		registry.EXPECT().Resolve("did:example:123456").Return(&did.DocResolution{DIDDocument: &did.Doc{
-			VerificationMethod: []did.VerificationMethod{{
-				ID: "#key1",
-				Value: []byte{
-					234, 100, 192, 93, 251, 181, 198, 73, 122, 220, 27, 48, 93, 73, 166,
-					33, 152, 140, 168, 36, 9, 205, 59, 161, 137, 7, 164, 9, 176, 252, 1, 171,
-				},
-			}},
+			VerificationMethod: []did.VerificationMethod{issuerKey},
+			AssertionMethod:    []did.Verification{*did.NewReferencedVerification(&issuerKey, did.AssertionMethod)},
 		}}, nil)
 
 		provider := mocks.NewMockProvider(ctrl)
@@ -335,3 +346,458 @@ func TestSaveCredentials(t *testing.T) {
 		require.Equal(t, props["names"], []string{vcName})
 	})
 }
+
+func TestIssueCredentialsV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	_, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519)
+	require.NoError(t, err)
+
+	const issuerDID = "did:example:issuer"
+
+	// the key is resolved from the verification method, its ID is not the KMS key ID
+	vm := issuerDID + "#key-1"
+	issuerKey := did.VerificationMethod{ID: vm, Type: "Ed25519VerificationKey2018", Value: pubKey}
+	authKey := did.VerificationMethod{ID: issuerDID + "#auth-key", Type: "Ed25519VerificationKey2018", Value: pubKey}
+	otherKey := did.VerificationMethod{
+		ID: issuerDID + "#other-key", Type: "Ed25519VerificationKey2018", Value: make([]byte, 32),
+	}
+
+	issuerRegistry := mockvdr.NewMockRegistry(ctrl)
+	issuerRegistry.EXPECT().Resolve(issuerDID).Return(&did.DocResolution{DIDDocument: &did.Doc{
+		VerificationMethod: []did.VerificationMethod{issuerKey, authKey, otherKey},
+		AssertionMethod: []did.Verification{
+			*did.NewReferencedVerification(&issuerKey, did.AssertionMethod),
+			*did.NewReferencedVerification(&otherKey, did.AssertionMethod),
+		},
+		Authentication: []did.Verification{*did.NewReferencedVerification(&authKey, did.Authentication)},
+	}}, nil).AnyTimes()
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	provider := mocks.NewMockIssuerProvider(ctrl)
+	provider.EXPECT().KMS().Return(km).AnyTimes()
+	provider.EXPECT().Crypto().Return(cr).AnyTimes()
+	provider.EXPECT().VDRegistry().Return(issuerRegistry).AnyTimes()
+	provider.EXPECT().JSONLDDocumentLoader().Return(loader).AnyTimes()
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	unsignedCredential := func() *verifiable.Credential {
+		cred := getCredential()
+		cred.Issuer.ID = issuerDID
+
+		return cred
+	}
+
+	requestMsg := func(formats ...string) service.DIDCommMsg {
+		var attachments []decorator.AttachmentV2
+
+		for _, format := range formats {
+			attachments = append(attachments, decorator.AttachmentV2{
+				Format: format,
+				Data:   decorator.AttachmentData{JSON: unsignedCredential()},
+			})
+		}
+
+		return service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type:        issuecredential.RequestCredentialMsgTypeV3,
+			Attachments: attachments,
+		})
+	}
+
+	t.Run("Ignores processing", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredential{
+			Type: issuecredential.RequestCredentialMsgType,
+		}))
+
+		require.NoError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata))
+	})
+
+	t.Run("Proposal with supported formats", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameProposalReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.ProposeCredentialV3{
+			Type: issuecredential.ProposeCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{
+				{Format: issuecredential.LDProofVCFormat},
+				{Format: issuecredential.JWTVCFormat},
+			},
+		}))
+
+		require.NoError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata))
+	})
+
+	t.Run("Proposal with unsupported format", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameProposalReceived)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.ProposeCredentialV3{
+			Type:        issuecredential.ProposeCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{Format: "hlindy/cred@v2.0"}},
+		}))
+
+		const errMsg = `proposal: unsupported credential format "hlindy/cred@v2.0"`
+		require.EqualError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata), errMsg)
+	})
+
+	t.Run("Proposal decode (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameProposalReceived)
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{
+			"type": issuecredential.ProposeCredentialMsgTypeV3,
+			"body": "invalid",
+		})
+
+		require.Contains(t, fmt.Sprintf("%v", IssueCredentialsV3(provider, vm)(next).Handle(metadata)), "decode")
+	})
+
+	t.Run("Request decode (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(service.DIDCommMsgMap{
+			"type": issuecredential.RequestCredentialMsgTypeV3,
+			"body": "invalid",
+		})
+
+		require.Contains(t, fmt.Sprintf("%v", IssueCredentialsV3(provider, vm)(next).Handle(metadata)), "decode")
+	})
+
+	t.Run("Request with unsupported format", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(requestMsg("hlindy/cred@v2.0"))
+
+		const errMsg = `request: unsupported credential format "hlindy/cred@v2.0"`
+		require.EqualError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata), errMsg)
+	})
+
+	t.Run("Ignores processing (credentials provided)", func(t *testing.T) {
+		issue := &issuecredential.IssueCredentialV3{Attachments: []decorator.AttachmentV2{{ID: "ID"}}}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(issue)
+		metadata.EXPECT().Message().Return(requestMsg(issuecredential.LDProofVCFormat))
+
+		require.NoError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata))
+		require.Len(t, issue.Attachments, 1)
+	})
+
+	t.Run("Unknown key (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(requestMsg(issuecredential.LDProofVCFormat))
+
+		err := IssueCredentialsV3(provider, issuerDID+"#unknown")(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issuer key: resolve verification method")
+	})
+
+	t.Run("Not an assertion method (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(requestMsg(issuecredential.LDProofVCFormat))
+
+		err := IssueCredentialsV3(provider, authKey.ID)(next).Handle(metadata)
+		require.EqualError(t, err, "issuer key: verification method "+authKey.ID+" is not an assertion method")
+	})
+
+	t.Run("Key not in the KMS (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(requestMsg(issuecredential.LDProofVCFormat))
+
+		err := IssueCredentialsV3(provider, otherKey.ID)(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "new signer")
+	})
+
+	t.Run("Credential of another issuer (error)", func(t *testing.T) {
+		cred := unsignedCredential()
+		cred.Issuer.ID = "did:example:other"
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type: issuecredential.RequestCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				Format: issuecredential.JWTVCFormat,
+				Data:   decorator.AttachmentData{JSON: cred},
+			}},
+		}))
+
+		err := IssueCredentialsV3(provider, vm)(next).Handle(metadata)
+		require.EqualError(t, err, `issue credential: credential issuer "did:example:other" is not "`+issuerDID+`"`)
+	})
+
+	t.Run("Unsupported proof type (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type: issuecredential.RequestCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				Format: issuecredential.LDProofVCFormat,
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{
+					"credential": unsignedCredential(),
+					"options":    map[string]interface{}{"proofType": "BbsBlsSignature2020"},
+				}},
+			}},
+		}))
+
+		err := IssueCredentialsV3(provider, vm)(next).Handle(metadata)
+		require.EqualError(t, err, `issue credential: unsupported proof type "BbsBlsSignature2020" `+
+			`(cryptosuite "") for ED25519 keys`)
+	})
+
+	t.Run("Invalid credential (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(&issuecredential.IssueCredentialV3{})
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type: issuecredential.RequestCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				Format: issuecredential.JWTVCFormat,
+				Data:   decorator.AttachmentData{JSON: map[string]interface{}{}},
+			}},
+		}))
+
+		err := IssueCredentialsV3(provider, vm)(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "issue credential: parse credential")
+	})
+
+	t.Run("Success (round trip)", func(t *testing.T) {
+		issue := &issuecredential.IssueCredentialV3{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(issue)
+		metadata.EXPECT().Message().Return(requestMsg(issuecredential.LDProofVCFormat, issuecredential.JWTVCFormat))
+
+		require.NoError(t, IssueCredentialsV3(provider, vm)(next).Handle(metadata))
+		require.Len(t, issue.Attachments, 2)
+		require.Equal(t, issuecredential.LDProofVCFormat, issue.Attachments[0].Format)
+		require.Equal(t, issuecredential.JWTVCFormat, issue.Attachments[1].Format)
+
+		issue.Type = issuecredential.IssueCredentialMsgTypeV3
+
+		// the holder verifies and saves the issued credentials
+		props := map[string]interface{}{
+			myDIDKey:    myDIDKey,
+			theirDIDKey: theirDIDKey,
+		}
+
+		received := mocks.NewMockMetadata(ctrl)
+		received.EXPECT().StateName().Return(stateNameCredentialReceived)
+		received.EXPECT().CredentialNames().Return([]string{}).Times(2)
+		received.EXPECT().Properties().Return(props)
+		received.EXPECT().Message().Return(service.NewDIDCommMsgMap(issue))
+
+		var saved []*verifiable.Credential
+
+		verifiableStore := mockstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SaveCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ string, vc *verifiable.Credential, _ ...storeverifiable.Opt) error {
+				saved = append(saved, vc)
+
+				return nil
+			}).Times(2)
+
+		saveProvider := mocks.NewMockProvider(ctrl)
+		saveProvider.EXPECT().VDRegistry().Return(issuerRegistry)
+		saveProvider.EXPECT().VerifiableStore().Return(verifiableStore)
+		saveProvider.EXPECT().JSONLDDocumentLoader().Return(loader)
+
+		require.NoError(t, SaveCredentials(saveProvider)(next).Handle(received))
+		require.Len(t, saved, 2)
+		require.Len(t, saved[0].Proofs, 1)
+		require.NotEmpty(t, saved[1].JWT)
+
+		for _, vc := range saved {
+			require.Equal(t, issuerDID, vc.Issuer.ID)
+			require.Equal(t, getCredential().ID, vc.ID)
+		}
+	})
+}
+
+func TestIssueCredentialsV3_ProofTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/",
+		mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	const issuerDID = "did:example:issuer"
+
+	_, edPubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519)
+	require.NoError(t, err)
+
+	_, ecPubKey, err := km.CreateAndExportPubKeyBytes(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	ecJWK, err := jwkkid.BuildJWK(ecPubKey, kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	edKey := did.NewVerificationMethodFromBytes(issuerDID+"#ed-key", "Ed25519VerificationKey2018", issuerDID, edPubKey)
+
+	ecKey, err := did.NewVerificationMethodFromJWK(issuerDID+"#ec-key", "JsonWebKey2020", issuerDID, ecJWK)
+	require.NoError(t, err)
+
+	registry := mockvdr.NewMockRegistry(ctrl)
+	registry.EXPECT().Resolve(issuerDID).Return(&did.DocResolution{DIDDocument: &did.Doc{
+		VerificationMethod: []did.VerificationMethod{*edKey, *ecKey},
+		AssertionMethod: []did.Verification{
+			*did.NewReferencedVerification(edKey, did.AssertionMethod),
+			*did.NewReferencedVerification(ecKey, did.AssertionMethod),
+		},
+	}}, nil).AnyTimes()
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	provider := mocks.NewMockIssuerProvider(ctrl)
+	provider.EXPECT().KMS().Return(km).AnyTimes()
+	provider.EXPECT().Crypto().Return(cr).AnyTimes()
+	provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+	provider.EXPECT().JSONLDDocumentLoader().Return(loader).AnyTimes()
+
+	next := issuecredential.HandlerFunc(func(metadata issuecredential.Metadata) error {
+		return nil
+	})
+
+	issue := func(vm, format string, options map[string]interface{}) (*verifiable.Credential, error) {
+		cred := getCredential()
+		cred.Issuer.ID = issuerDID
+		cred.Status = &verifiable.TypedID{ID: "https://holder.example.com/status/1", Type: "StatusList2021Entry"}
+
+		var data interface{} = cred
+		if options != nil {
+			data = map[string]interface{}{"credential": cred, "options": options}
+		}
+
+		issued := &issuecredential.IssueCredentialV3{}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().IssueCredentialV3().Return(issued)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(issuecredential.RequestCredentialV3{
+			Type:        issuecredential.RequestCredentialMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{Format: format, Data: decorator.AttachmentData{JSON: data}}},
+		}))
+
+		if err := IssueCredentialsV3(provider, vm)(next).Handle(metadata); err != nil {
+			return nil, err
+		}
+
+		raw, err := issued.Attachments[0].Data.Fetch()
+		require.NoError(t, err)
+
+		// the holder verifies the proof with the issuer's key
+		return verifiable.ParseCredential(raw,
+			verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(registry).PublicKeyFetcher()),
+			verifiable.WithJSONLDDocumentLoader(loader))
+	}
+
+	tests := []struct {
+		name        string
+		vm          string
+		options     map[string]interface{}
+		proofType   string
+		cryptosuite string
+	}{
+		{
+			name:      "Ed25519 key, default proof",
+			vm:        edKey.ID,
+			proofType: "Ed25519Signature2018",
+		},
+		{
+			name:      "Ed25519 key, Ed25519Signature2020",
+			vm:        edKey.ID,
+			options:   map[string]interface{}{"proofType": "Ed25519Signature2020"},
+			proofType: "Ed25519Signature2020",
+		},
+		{
+			name:        "Ed25519 key, Data Integrity",
+			vm:          edKey.ID,
+			options:     map[string]interface{}{"proofType": "DataIntegrityProof"},
+			proofType:   "DataIntegrityProof",
+			cryptosuite: "eddsa-rdfc-2022",
+		},
+		{
+			name:      "P-256 key, default proof",
+			vm:        ecKey.ID,
+			proofType: "JsonWebSignature2020",
+		},
+		{
+			name:        "P-256 key, Data Integrity",
+			vm:          ecKey.ID,
+			options:     map[string]interface{}{"proofType": "DataIntegrityProof", "cryptosuite": "ecdsa-rdfc-2019"},
+			proofType:   "DataIntegrityProof",
+			cryptosuite: "ecdsa-rdfc-2019",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			vc, err := issue(tc.vm, issuecredential.LDProofVCFormat, tc.options)
+			require.NoError(t, err)
+			require.Len(t, vc.Proofs, 1)
+			require.Equal(t, tc.proofType, vc.Proofs[0]["type"])
+			require.Equal(t, tc.vm, vc.Proofs[0]["verificationMethod"])
+
+			if tc.cryptosuite != "" {
+				require.Equal(t, tc.cryptosuite, vc.Proofs[0]["cryptosuite"])
+			}
+
+			// the credential is rebuilt by the issuer
+			require.Nil(t, vc.Status)
+			require.Equal(t, issuerDID, vc.Issuer.ID)
+		})
+	}
+
+	t.Run("P-256 key, JWT", func(t *testing.T) {
+		vc, err := issue(ecKey.ID, issuecredential.JWTVCFormat, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, vc.JWT)
+		require.Nil(t, vc.Status)
+	})
+
+	t.Run("Ed25519VerificationKey2018 key, JsonWebSignature2020 (error)", func(t *testing.T) {
+		_, err := issue(edKey.ID, issuecredential.LDProofVCFormat,
+			map[string]interface{}{"proofType": "JsonWebSignature2020"})
+		require.EqualError(t, err, `issue credential: signature suite: verification method of type `+
+			`"Ed25519VerificationKey2018" is not a JsonWebKey2020`)
+	})
+
+	t.Run("P-256 key, Ed25519Signature2018 (error)", func(t *testing.T) {
+		_, err := issue(ecKey.ID, issuecredential.LDProofVCFormat,
+			map[string]interface{}{"proofType": "Ed25519Signature2018"})
+		require.EqualError(t, err, `issue credential: proof type "Ed25519Signature2018" (cryptosuite "") `+
+			`does not support ECDSAP256IEEEP1363 keys`)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
@@ -66,12 +67,12 @@ func SavePresentation(p Provider) presentproof.Middleware {
 				return next.Handle(metadata)
 			}
 
-			presentation := presentproof.Presentation{}
-			if err := metadata.Message().Decode(&presentation); err != nil {
+			attachments, err := presentationAttachments(metadata.Message())
+			if err != nil {
 				return fmt.Errorf("decode: %w", err)
 			}
 
-			presentations, err := toVerifiablePresentation(vdr, presentation.PresentationsAttach, documentLoader)
+			presentations, err := toVerifiablePresentation(vdr, attachments, documentLoader)
 			if err != nil {
 				return fmt.Errorf("to verifiable presentation: %w", err)
 			}
@@ -110,6 +111,35 @@ func SavePresentation(p Provider) presentproof.Middleware {
 	}
 }
 
+// presentationAttachments returns the data of the presentation attachments of the given presentation message.
+func presentationAttachments(msg service.DIDCommMsg) ([]decorator.AttachmentData, error) {
+	var data []decorator.AttachmentData
+
+	if msg.Type() == presentproof.PresentationMsgTypeV3 {
+		presentation := presentproof.PresentationV3{}
+		if err := msg.Decode(&presentation); err != nil {
+			return nil, err
+		}
+
+		for i := range presentation.Attachments {
+			data = append(data, presentation.Attachments[i].Data)
+		}
+
+		return data, nil
+	}
+
+	presentation := presentproof.Presentation{}
+	if err := msg.Decode(&presentation); err != nil {
+		return nil, err
+	}
+
+	for i := range presentation.PresentationsAttach {
+		data = append(data, presentation.PresentationsAttach[i].Data)
+	}
+
+	return data, nil
+}
+
 type presentationExchangePayload struct {
 	Challenge              string                           `json:"challenge"`
 	Domain                 string                           `json:"domain"`
@@ -165,23 +195,37 @@ func AddBBSProofFn(p Provider) func(presentation *verifiable.Presentation) error
 // PresentationDefinition the helper function for the present proof protocol that creates VP based on credentials that
 // were provided in the attachments according to the requested presentation definition.
 func PresentationDefinition(p Provider, opts ...OptPD) presentproof.Middleware { // nolint: funlen,gocyclo
-	vdr := p.VDRegistry()
-	documentLoader := p.JSONLDDocumentLoader()
-
 	options := defaultPdOptions()
 
 	for i := range opts {
 		opts[i](options)
 	}
 
+	vp := &vpCreator{
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		addProof:       options.addProof,
+	}
+
 	return func(next presentproof.Handler) presentproof.Handler {
 		return presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
 			if metadata.StateName() != stateNameRequestReceived {
 				return next.Handle(metadata)
 			}
 
+			msg := metadata.Message()
+
+			if msg.Type() == presentproof.RequestPresentationMsgTypeV3 {
+				err := presentationDefinitionV3(msg, metadata, vp)
+				if err != nil {
+					return err
+				}
+
+				return next.Handle(metadata)
+			}
+
 			request := presentproof.RequestPresentation{}
-			if err := metadata.Message().Decode(&request); err != nil {
+			if err := msg.Decode(&request); err != nil {
 				return fmt.Errorf("decode: %w", err)
 			}
 
@@ -197,32 +241,17 @@ func PresentationDefinition(p Provider, opts ...OptPD) presentproof.Middleware {
 				return fmt.Errorf("get attachment by format: %w", err)
 			}
 
-			var payload *presentationExchangePayload
-
-			if err = json.Unmarshal(src, &payload); err != nil {
-				return fmt.Errorf("unmarshal definition: %w", err)
-			}
-
-			credentials, err := parseCredentials(vdr, metadata.Presentation().PresentationsAttach, documentLoader)
-			if err != nil {
-				return fmt.Errorf("parse credentials: %w", err)
-			}
+			var data []decorator.AttachmentData
 
-			presentation, err := payload.PresentationDefinition.CreateVP(credentials,
-				verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()),
-				verifiable.WithJSONLDDocumentLoader(documentLoader))
-			if err != nil {
-				return fmt.Errorf("create VP: %w", err)
-			}
-
-			singFn := metadata.GetAddProofFn()
-			if singFn == nil {
-				singFn = options.addProof
+			for i := range metadata.Presentation().PresentationsAttach {
+				if metadata.Presentation().PresentationsAttach[i].MimeType == mimeTypeApplicationLdJSON {
+					data = append(data, metadata.Presentation().PresentationsAttach[i].Data)
+				}
 			}
 
-			err = singFn(presentation)
+			presentation, err := vp.create(metadata, src, data)
 			if err != nil {
-				return fmt.Errorf("add proof: %w", err)
+				return err
 			}
 
 			metadata.Presentation().PresentationsAttach = []decorator.Attachment{{
@@ -236,6 +265,88 @@ func PresentationDefinition(p Provider, opts ...OptPD) presentproof.Middleware {
 	}
 }
 
+// presentationDefinitionV3 creates the VP for a present-proof 3.0 request. Requests and presentations describe
+// the format of their attachments in the attachment itself.
+func presentationDefinitionV3(msg service.DIDCommMsg, metadata presentproof.Metadata, vp *vpCreator) error {
+	request := presentproof.RequestPresentationV3{}
+	if err := msg.Decode(&request); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	if metadata.PresentationV3() == nil ||
+		!hasFormatV3(request.Attachments, peDefinitionFormat) ||
+		hasFormatV3(metadata.PresentationV3().Attachments, peSubmissionFormat) {
+		return nil
+	}
+
+	src, err := getAttachmentByFormatV3(request.Attachments, peDefinitionFormat)
+	if err != nil {
+		return fmt.Errorf("get attachment by format: %w", err)
+	}
+
+	var data []decorator.AttachmentData
+
+	for i := range metadata.PresentationV3().Attachments {
+		if metadata.PresentationV3().Attachments[i].MediaType == mimeTypeApplicationLdJSON {
+			data = append(data, metadata.PresentationV3().Attachments[i].Data)
+		}
+	}
+
+	presentation, err := vp.create(metadata, src, data)
+	if err != nil {
+		return err
+	}
+
+	metadata.PresentationV3().Attachments = []decorator.AttachmentV2{{
+		ID:        uuid.New().String(),
+		MediaType: mimeTypeApplicationLdJSON,
+		Format:    peSubmissionFormat,
+		Data:      decorator.AttachmentData{JSON: presentation},
+	}}
+
+	return nil
+}
+
+type vpCreator struct {
+	vdr            vdrapi.Registry
+	documentLoader ld.DocumentLoader
+	addProof       func(presentation *verifiable.Presentation) error
+}
+
+// create evaluates the presentation definition payload against the given credentials and returns the signed VP.
+func (c *vpCreator) create(metadata presentproof.Metadata, payloadSrc []byte,
+	data []decorator.AttachmentData) (*verifiable.Presentation, error) {
+	var payload *presentationExchangePayload
+
+	if err := json.Unmarshal(payloadSrc, &payload); err != nil {
+		return nil, fmt.Errorf("unmarshal definition: %w", err)
+	}
+
+	credentials, err := parseCredentials(c.vdr, data, c.documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+
+	presentation, err := payload.PresentationDefinition.CreateVP(credentials,
+		verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(c.documentLoader))
+	if err != nil {
+		return nil, fmt.Errorf("create VP: %w", err)
+	}
+
+	singFn := metadata.GetAddProofFn()
+	if singFn == nil {
+		singFn = c.addProof
+	}
+
+	err = singFn(presentation)
+	if err != nil {
+		return nil, fmt.Errorf("add proof: %w", err)
+	}
+
+	return presentation, nil
+}
+
 func contains(s []string, e string) bool {
 	for _, a := range s {
 		if a == e {
@@ -247,16 +358,12 @@ func contains(s []string, e string) bool {
 }
 
 // nolint: gocyclo
-func parseCredentials(vdr vdrapi.Registry, attachments []decorator.Attachment,
+func parseCredentials(vdr vdrapi.Registry, data []decorator.AttachmentData,
 	documentLoader ld.DocumentLoader) ([]*verifiable.Credential, error) {
 	var credentials []*verifiable.Credential
 
-	for i := range attachments {
-		if attachments[i].MimeType != mimeTypeApplicationLdJSON {
-			continue
-		}
-
-		src, err := attachments[i].Data.Fetch()
+	for i := range data {
+		src, err := data[i].Fetch()
 		if err != nil {
 			return nil, err
 		}
@@ -317,6 +424,26 @@ func getAttachmentByFormat(fms []presentproof.Format, attachments []decorator.At
 	return nil, errors.New("not found")
 }
 
+func getAttachmentByFormatV3(attachments []decorator.AttachmentV2, name string) ([]byte, error) {
+	for i := range attachments {
+		if attachments[i].Format == name {
+			return attachments[i].Data.Fetch()
+		}
+	}
+
+	return nil, errors.New("not found")
+}
+
+func hasFormatV3(attachments []decorator.AttachmentV2, format string) bool {
+	for i := range attachments {
+		if attachments[i].Format == format {
+			return true
+		}
+	}
+
+	return false
+}
+
 func hasFormat(formats []presentproof.Format, format string) bool {
 	for _, fm := range formats {
 		if fm.Format == format {
@@ -340,12 +467,12 @@ func getName(idx int, id string, metadata presentproof.Metadata) string {
 	return uuid.New().String()
 }
 
-func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.Attachment,
+func toVerifiablePresentation(vdr vdrapi.Registry, data []decorator.AttachmentData,
 	documentLoader ld.DocumentLoader) ([]*verifiable.Presentation, error) {
 	var presentations []*verifiable.Presentation
 
 	for i := range data {
-		raw, err := data[i].Data.Fetch()
+		raw, err := data[i].Fetch()
 		if err != nil {
 			return nil, fmt.Errorf("fetch: %w", err)
 		}
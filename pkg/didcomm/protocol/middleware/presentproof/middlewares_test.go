@@ -264,6 +264,45 @@ func TestSavePresentation(t *testing.T) {
 		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
 		require.Equal(t, props["names"], []string{vcName})
 	})
+
+	t.Run("Success (V3)", func(t *testing.T) {
+		const vcName = "vc-name"
+
+		props := map[string]interface{}{
+			myDIDKey:    myDIDKey,
+			theirDIDKey: theirDIDKey,
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNamePresentationReceived)
+		metadata.EXPECT().PresentationNames().Return([]string{vcName}).Times(2)
+		metadata.EXPECT().Properties().Return(props)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.PresentationV3{
+			Type: presentproof.PresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{
+				{Data: decorator.AttachmentData{Base64: base64.StdEncoding.EncodeToString([]byte(vpJWS))}},
+			},
+		}))
+
+		verifiableStore := mocksstore.NewMockStore(ctrl)
+		verifiableStore.EXPECT().SavePresentation(vcName, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		loader, err := jsonldtest.DocumentLoader()
+		require.NoError(t, err)
+
+		registry := mocksvdr.NewMockRegistry(ctrl)
+		registry.EXPECT().Resolve("did:example:ebfeb1f712ebc6f1c276e12ec21").Return(
+			&did.DocResolution{DIDDocument: &did.Doc{VerificationMethod: []did.VerificationMethod{pubKey}}}, nil)
+
+		provider := mocks.NewMockProvider(ctrl)
+		provider.EXPECT().VDRegistry().Return(registry).AnyTimes()
+		provider.EXPECT().VerifiableStore().Return(verifiableStore)
+		provider.EXPECT().JSONLDDocumentLoader().Return(loader)
+
+		require.NoError(t, SavePresentation(provider)(next).Handle(metadata))
+		require.Equal(t, props["names"], []string{vcName})
+	})
 }
 
 func TestPresentationDefinition(t *testing.T) {
@@ -563,3 +602,124 @@ func TestPresentationDefinition(t *testing.T) {
 		require.Nil(t, PresentationDefinition(provider, WithAddProofFn(AddBBSProofFn(provider)))(next).Handle(metadata))
 	})
 }
+
+func TestPresentationDefinitionV3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	provider := mocks.NewMockProvider(ctrl)
+	provider.EXPECT().VDRegistry().Return(nil).AnyTimes()
+	provider.EXPECT().JSONLDDocumentLoader().Return(loader).AnyTimes()
+
+	next := presentproof.HandlerFunc(func(metadata presentproof.Metadata) error {
+		return nil
+	})
+
+	definitionAttachment := decorator.AttachmentV2{
+		ID:        uuid.New().String(),
+		MediaType: "application/json",
+		Format:    peDefinitionFormat,
+		Data: decorator.AttachmentData{
+			JSON: map[string]interface{}{
+				"presentation_definition": &presexch.PresentationDefinition{
+					ID: uuid.New().String(),
+					InputDescriptors: []*presexch.InputDescriptor{{
+						ID: uuid.New().String(),
+						Schema: []*presexch.Schema{{
+							URI: fmt.Sprintf("%s#%s", verifiable.ContextURI, verifiable.VCType),
+						}},
+						Constraints: &presexch.Constraints{
+							Fields: []*presexch.Field{{
+								Path:   []string{"$.first_name"},
+								Filter: &presexch.Filter{Type: &strFilterType},
+							}},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	t.Run("Ignores processing (no presentation)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().PresentationV3().Return(nil)
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.RequestPresentationV3{
+			Type:        presentproof.RequestPresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{definitionAttachment},
+		}))
+
+		require.NoError(t, PresentationDefinition(provider)(next).Handle(metadata))
+	})
+
+	t.Run("Ignores processing (no definition)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().PresentationV3().Return(&presentproof.PresentationV3{}).AnyTimes()
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.RequestPresentationV3{
+			Type: presentproof.RequestPresentationMsgTypeV3,
+		}))
+
+		require.NoError(t, PresentationDefinition(provider)(next).Handle(metadata))
+	})
+
+	t.Run("Attachment fetch (error)", func(t *testing.T) {
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().PresentationV3().Return(&presentproof.PresentationV3{}).AnyTimes()
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.RequestPresentationV3{
+			Type:        presentproof.RequestPresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{Format: peDefinitionFormat}},
+		}))
+
+		err := PresentationDefinition(provider)(next).Handle(metadata)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get attachment by format")
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		presentation := &presentproof.PresentationV3{
+			Attachments: []decorator.AttachmentV2{{
+				MediaType: mimeTypeApplicationLdJSON,
+				Data: decorator.AttachmentData{
+					JSON: &verifiable.Credential{
+						ID:      "http://example.edu/credentials/1872",
+						Context: []string{verifiable.ContextURI},
+						Types:   []string{verifiable.VCType},
+						Subject: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+						Issued: &util.TimeWithTrailingZeroMsec{
+							Time: time.Now(),
+						},
+						Issuer: verifiable.Issuer{
+							ID: "did:example:76e12ec712ebc6f1c221ebfeb1f",
+						},
+						CustomFields: map[string]interface{}{
+							"first_name": "First name",
+						},
+					},
+				},
+			}},
+		}
+
+		metadata := mocks.NewMockMetadata(ctrl)
+		metadata.EXPECT().StateName().Return(stateNameRequestReceived)
+		metadata.EXPECT().GetAddProofFn().Return(nil)
+		metadata.EXPECT().PresentationV3().Return(presentation).AnyTimes()
+		metadata.EXPECT().Message().Return(service.NewDIDCommMsgMap(presentproof.RequestPresentationV3{
+			Type:        presentproof.RequestPresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{definitionAttachment},
+		}))
+
+		require.NoError(t, PresentationDefinition(provider)(next).Handle(metadata))
+		require.Len(t, presentation.Attachments, 1)
+		require.Equal(t, peSubmissionFormat, presentation.Attachments[0].Format)
+		require.Equal(t, mimeTypeApplicationLdJSON, presentation.Attachments[0].MediaType)
+
+		vp, ok := presentation.Attachments[0].Data.JSON.(*verifiable.Presentation)
+		require.True(t, ok)
+		require.Len(t, vp.Credentials(), 1)
+	})
+}
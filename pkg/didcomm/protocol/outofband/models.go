@@ -19,6 +19,7 @@ type Invitation struct {
 	Accept    []string                `json:"accept,omitempty"`
 	Protocols []string                `json:"handshake_protocols,omitempty"`
 	Requests  []*decorator.Attachment `json:"request~attach,omitempty"`
+	Timing    *decorator.Timing       `json:"~timing,omitempty"`
 }
 
 // HandshakeReuse is this protocol's 'handshake-reuse' message.
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mitchellh/mapstructure"
@@ -18,8 +19,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmsdidkey"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -49,18 +53,18 @@ const (
 const (
 	// MediaTypeProfileDIDCommAIP1 is the encryption envelope, signing mechanism, plaintext conventions,
 	// and routing algorithms embodied in Aries AIP 1.0, circa 2020. Defined in RFC 0044.
-	MediaTypeProfileDIDCommAIP1 = "didcomm/aip1"
+	MediaTypeProfileDIDCommAIP1 = transport.MediaTypeProfileDIDCommAIP1
 	// MediaTypeProfileDIDCommAIP2RFC19 is the signing mechanism, plaintext conventions, and routing
 	// algorithms embodied in Aries AIP 2.0, circa 2021 -- with the old-style encryption envelope from
 	// Aries RFC 0019. Defined in RFC 0044.
-	MediaTypeProfileDIDCommAIP2RFC19 = "didcomm/aip2;env=rfc19"
+	MediaTypeProfileDIDCommAIP2RFC19 = transport.MediaTypeProfileDIDCommAIP2RFC19
 	// MediaTypeProfileAIP2RFC587 is the signing mechanism, plaintext conventions, and routing algorithms
 	// embodied in Aries AIP 2.0, circa 2021 -- with the new-style encryption envelope from Aries RFC 0587.
 	// Defined in RFC 0044.
-	MediaTypeProfileAIP2RFC587 = "didcomm/aip2;env=rfc587"
+	MediaTypeProfileAIP2RFC587 = transport.MediaTypeProfileAIP2RFC587
 	// MediaTypeProfileDIDCommV2 is the encryption envelope, signing mechanism, plaintext conventions,
 	// and routing algorithms embodied in the DIDComm messaging spec. Defined in RFC 0044.
-	MediaTypeProfileDIDCommV2 = "didcomm/v2"
+	MediaTypeProfileDIDCommV2 = transport.MediaTypeProfileDIDCommV2
 )
 
 var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
@@ -74,6 +78,9 @@ type Options interface {
 	RouterConnections() []string
 	ReuseAnyConnection() bool
 	ReuseConnection() string
+	// Transient is true if the connection is ephemeral: it is kept in memory until its time to live elapses
+	// rather than written to the permanent connection store.
+	Transient() bool
 }
 
 type didExchSvc interface {
@@ -86,6 +93,7 @@ type connectionRecorder interface {
 	GetConnectionRecord(string) (*connection.Record, error)
 	GetConnectionIDByDIDs(string, string) (string, error)
 	QueryConnectionRecords() ([]*connection.Record, error)
+	GetConnectionRecordsByParentThreadID(string) ([]*connection.Record, error)
 }
 
 // Service implements the Out-Of-Band protocol.
@@ -102,6 +110,9 @@ type Service struct {
 	extractDIDCommMsgBytesFunc func(*decorator.Attachment) ([]byte, error)
 	listenerFunc               func()
 	messenger                  service.Messenger
+	kms                        kms.KeyManager
+	keyType                    kms.KeyType
+	keyAgreementType           kms.KeyType
 }
 
 type callback struct {
@@ -139,8 +150,10 @@ type context struct {
 	ConnectionID       string
 	Invitation         *Invitation
 	DIDExchangeInv     *didexchange.OOBInvitation
+	HandshakeProtocol  string
 	MyLabel            string
 	RouterConnections  []string
+	Transient          bool
 }
 
 // Provider provides this service's dependencies.
@@ -152,6 +165,14 @@ type Provider interface {
 	Messenger() service.Messenger
 }
 
+// keyProvider is implemented by the providers able to create the ephemeral keys the connection-less requests of an
+// invitation are replied with.
+type keyProvider interface {
+	KMS() kms.KeyManager
+	KeyType() kms.KeyType
+	KeyAgreementType() kms.KeyType
+}
+
 // New creates a new instance of the out-of-band service.
 func New(p Provider) (*Service, error) {
 	svc, err := p.Service(didexchange.DIDExchange)
@@ -192,6 +213,10 @@ func New(p Provider) (*Service, error) {
 		messenger:                  p.Messenger(),
 	}
 
+	if kp, ok := p.(keyProvider); ok {
+		s.kms, s.keyType, s.keyAgreementType = kp.KMS(), kp.KeyType(), kp.KeyAgreementType()
+	}
+
 	s.listenerFunc = listener(s.callbackChannel, s.didEvents, s.handleCallback, s.handleDIDEvent)
 
 	didEventsSvc, ok := didSvc.(service.Event)
@@ -262,9 +287,10 @@ func (s *Service) handleContext(ctx *context) error { // nolint:funlen
 
 	deps := &dependencies{
 		connections:           s.connections,
-		didSvc:                s.didSvc,
+		handshakeSvcs:         map[string]didExchSvc{didexchange.PIURI: s.didSvc},
 		saveAttchStateFunc:    s.save,
 		dispatchAttachmntFunc: s.dispatchInvitationAttachment,
+		ephemeralDIDFunc:      s.createEphemeralDID,
 	}
 
 	var (
@@ -361,6 +387,7 @@ func (s *Service) requestApproval(ctx *context, events chan<- service.DIDCommAct
 			ctx.ReuseAnyConnection = opts.ReuseAnyConnection()
 			ctx.RouterConnections = opts.RouterConnections()
 			ctx.MyLabel = opts.MyLabel()
+			ctx.Transient = opts.Transient()
 
 			s.callbackChannel <- &callback{
 				msg:      msg,
@@ -436,6 +463,7 @@ func (s *Service) ActionContinue(piID string, opts Options) error {
 	ctx.ReuseConnection = opts.ReuseConnection()
 	ctx.ReuseAnyConnection = opts.ReuseAnyConnection()
 	ctx.MyLabel = opts.MyLabel()
+	ctx.Transient = opts.Transient()
 
 	err = validateInvitationAcceptance(ctx.Msg, opts)
 	if err != nil {
@@ -505,9 +533,7 @@ func sendMsgEvent(
 
 	logger.Debugf("sending state msg: %+v\n", stateMsg)
 
-	for _, handler := range l.MsgEvents() {
-		handler <- stateMsg
-	}
+	l.PublishMsgEvent(stateMsg)
 }
 
 // HandleOutbound handles outbound messages.
@@ -541,6 +567,7 @@ func (s *Service) currentContext(msg service.DIDCommMsg, ctx service.DIDCommCont
 			myContext.ReuseConnection = opts.ReuseConnection()
 			myContext.ReuseAnyConnection = opts.ReuseAnyConnection()
 			myContext.MyLabel = opts.MyLabel()
+			myContext.Transient = opts.Transient()
 		}
 
 		return myContext, s.saveContext(msg.ID(), myContext)
@@ -667,6 +694,7 @@ func (s *Service) handleInvitationCallback(c *callback) (string, error) {
 		routerConnections: c.ctx.RouterConnections,
 		reuseAnyConn:      c.ctx.ReuseAnyConnection,
 		reuseConn:         c.ctx.ReuseConnection,
+		transient:         c.ctx.Transient,
 	})
 	if err != nil {
 		return "", fmt.Errorf("unable to handle invitation: %w", err)
@@ -723,6 +751,13 @@ func (s *Service) dispatchInvitationAttachment(invID, myDID, theirDID string) er
 		return fmt.Errorf("failed to load attachment handling state : %w", err)
 	}
 
+	// the attachment is dispatched once the connection is established, which may be well after the invitation
+	// was received, so its expiry is checked here too
+	if state.Invitation.Timing.Expired(time.Now()) {
+		return fmt.Errorf("invitation %s expired at %s", state.Invitation.ID,
+			state.Invitation.Timing.ExpiresTime.Format(time.RFC3339))
+	}
+
 	msg, err := s.extractDIDCommMsg(state)
 	if err != nil {
 		return fmt.Errorf("failed to extract DIDComm msg : %w", err)
@@ -737,6 +772,10 @@ func (s *Service) dispatchInvitationAttachment(invID, myDID, theirDID string) er
 		return fmt.Errorf("failed to update state : %w", err)
 	}
 
+	if theirDID == "" {
+		startConnectionlessThread(msg)
+	}
+
 	logger.Debugf("dispatching inbound message of type: %s", msg.Type())
 
 	_, err = s.inboundHandler().HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
@@ -747,6 +786,51 @@ func (s *Service) dispatchInvitationAttachment(invID, myDID, theirDID string) er
 	return nil
 }
 
+// startConnectionlessThread starts the thread of a request received without a connection with the request itself
+// (unless it already has one), so that the receiving protocol handles it as a received message to be replied to.
+func startConnectionlessThread(msg service.DIDCommMsgMap) {
+	if msg.IsDIDCommV2() {
+		if _, ok := msg["thid"]; !ok {
+			msg["thid"] = msg.ID()
+		}
+
+		return
+	}
+
+	if _, ok := msg["~thread"]; !ok {
+		msg["~thread"] = map[string]interface{}{"thid": msg.ID()}
+	}
+}
+
+// createEphemeralDID creates a did:key to reply to a connection-less request attached to the invitation.
+func (s *Service) createEphemeralDID(inv *Invitation) (string, error) {
+	if s.kms == nil {
+		return "", errors.New("the provider has no KMS to create ephemeral keys with")
+	}
+
+	keyType := s.keyType
+
+	for _, mtp := range inv.Accept {
+		if mtp == MediaTypeProfileDIDCommV2 {
+			keyType = s.keyAgreementType
+
+			break
+		}
+	}
+
+	_, pubKeyBytes, err := s.kms.CreateAndExportPubKeyBytes(keyType)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ephemeral key : %w", err)
+	}
+
+	didKey, err := kmsdidkey.BuildDIDKeyByKMSKeyType(pubKeyBytes, keyType)
+	if err != nil {
+		return "", fmt.Errorf("failed to build ephemeral did:key : %w", err)
+	}
+
+	return didKey, nil
+}
+
 func (s *Service) save(state *attachmentHandlingState) error {
 	bytes, err := json.Marshal(state)
 	if err != nil {
@@ -798,7 +882,7 @@ func extractDIDCommMsgBytes(a *decorator.Attachment) ([]byte, error) {
 	return bytes, nil
 }
 
-func (s *Service) extractDIDCommMsg(state *attachmentHandlingState) (service.DIDCommMsg, error) {
+func (s *Service) extractDIDCommMsg(state *attachmentHandlingState) (service.DIDCommMsgMap, error) {
 	req, err := s.chooseAttachmentFunc(state)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select an attachment: %w", err)
@@ -890,6 +974,7 @@ func decodeDIDInvitationAndOOBInvitation(c *callback) (*didexchange.OOBInvitatio
 		Target:            target,
 		MyLabel:           c.ctx.MyLabel,
 		MediaTypeProfiles: oobInv.Accept,
+		Transient:         c.ctx.Transient,
 	}
 
 	return didInv, oobInv, nil
@@ -944,6 +1029,7 @@ type userOptions struct {
 	routerConnections []string
 	reuseAnyConn      bool
 	reuseConn         string
+	transient         bool
 }
 
 func (e *userOptions) MyLabel() string {
@@ -962,6 +1048,10 @@ func (e *userOptions) ReuseConnection() string {
 	return e.reuseConn
 }
 
+func (e *userOptions) Transient() bool {
+	return e.transient
+}
+
 // All implements EventProperties interface.
 func (e *eventProps) All() map[string]interface{} {
 	return map[string]interface{}{
@@ -7,26 +7,37 @@ SPDX-License-Identifier: Apache-2.0
 package outofband
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
+	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -626,6 +637,53 @@ func TestHandleDIDEvent(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, expected))
 	})
+	t.Run("does not dispatch the attachment of an expired invitation", func(t *testing.T) {
+		pthid := uuid.New().String()
+		connID := uuid.New().String()
+
+		provider := testProvider()
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{
+				handleFunc: func(service.DIDCommMsg, service.DIDCommContext) (string, error) {
+					require.Fail(t, "the attachment of an expired invitation was dispatched")
+
+					return "", nil
+				},
+			}
+		}
+
+		// setup connection state
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+		err = r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   connID,
+			MyDID:          myDID,
+			TheirDID:       theirDID,
+			ParentThreadID: pthid,
+		})
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.Timing = &decorator.Timing{ExpiresTime: time.Now().Add(-time.Minute)}
+
+		s := newAutoService(t, provider,
+			withState(t, &attachmentHandlingState{
+				ID:           pthid,
+				ConnectionID: connID,
+				Invitation:   inv,
+				Done:         false,
+			},
+			))
+		err = s.handleDIDEvent(service.StateMsg{
+			ProtocolName: didexchange.DIDExchange,
+			Type:         service.PostState,
+			Msg:          service.NewDIDCommMsgMap(newAck(pthid)),
+			StateID:      didexchange.StateIDCompleted,
+			Properties:   &mockdidexchange.MockEventProperties{ConnID: connID},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expired")
+	})
 	t.Run("wraps error from store when saving state", func(t *testing.T) {
 		expected := errors.New("test")
 		pthid := uuid.New().String()
@@ -847,6 +905,211 @@ func TestAcceptInvitation(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, errors.Is(err, expected))
 	})
+	t.Run("requests a transient connection", func(t *testing.T) {
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(i *didexchange.OOBInvitation, _ []string) (string, error) {
+					require.True(t, i.Transient)
+
+					return "123456", nil
+				},
+			},
+		}
+		s := newAutoService(t, provider)
+		_, err := s.AcceptInvitation(newInvitation(), &userOptions{transient: true})
+		require.NoError(t, err)
+	})
+	t.Run("selects the supported handshake protocol", func(t *testing.T) {
+		expected := "123456"
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(_ *didexchange.OOBInvitation, _ []string) (string, error) {
+					return expected, nil
+				},
+			},
+		}
+		s := newAutoService(t, provider)
+		inv := newInvitation()
+		inv.Protocols = []string{"https://didcomm.org/connections/1.0", didexchange.PIURI}
+		result, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+
+		inv = newInvitation()
+		inv.Protocols = []string{"https://didcomm.org/connections/1.0"}
+		_, err = s.AcceptInvitation(inv, &userOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no supported handshake protocol")
+	})
+	t.Run("reuses the connection when the same invitation is accepted twice", func(t *testing.T) {
+		const connID = "123456"
+		inv := newInvitation()
+		inv.Requests = nil
+		provider := testProvider()
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+
+		handshakes := 0
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(didInv *didexchange.OOBInvitation, _ []string) (string, error) {
+					handshakes++
+
+					// the did-exchange completes the connection with the invitation as parent thread
+					invitationDID, ok := didInv.Target.(string)
+					require.True(t, ok)
+
+					return connID, r.SaveConnectionRecord(&connection.Record{
+						ConnectionID:   connID,
+						MyDID:          myDID,
+						TheirDID:       theirDID,
+						InvitationDID:  invitationDID,
+						ParentThreadID: didInv.ThreadID,
+						State:          didexchange.StateIDCompleted,
+					})
+				},
+			},
+		}
+
+		reused := false
+		provider.CustomMessenger = &mockservice.MockMessenger{
+			ReplyToMsgFunc: func(in, out service.DIDCommMsgMap, myDIDArg, theirDIDArg string) error {
+				require.Equal(t, HandshakeReuseMsgType, out.Type())
+				require.Equal(t, inv.ID, in.ID())
+				require.Equal(t, myDID, myDIDArg)
+				require.Equal(t, theirDID, theirDIDArg)
+
+				reused = true
+
+				return nil
+			},
+		}
+
+		s := newAutoService(t, provider)
+
+		first, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Equal(t, connID, first)
+		require.False(t, reused)
+
+		second, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Equal(t, connID, second)
+		require.True(t, reused)
+
+		require.Equal(t, 1, handshakes)
+
+		records, err := r.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	})
+	t.Run("does not reuse a connection established with another service", func(t *testing.T) {
+		inv := newInvitation()
+		inv.Requests = nil
+		provider := testProvider()
+
+		r, err := connection.NewRecorder(provider)
+		require.NoError(t, err)
+
+		// a completed connection claiming the invitation as parent thread, but not with its services
+		require.NoError(t, r.SaveConnectionRecord(&connection.Record{
+			ConnectionID:   "other",
+			MyDID:          myDID,
+			TheirDID:       "did:example:other",
+			InvitationDID:  "did:example:other",
+			RecipientKeys:  []string{"did:key:z6MkjtX1o8JKT6zBx5FHa9mHnzMUzqMwEiBcBhwqcmLMzbbh"},
+			ParentThreadID: inv.ID,
+			State:          didexchange.StateIDCompleted,
+		}))
+
+		handshakes := 0
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+					handshakes++
+
+					return "new", nil
+				},
+			},
+		}
+
+		s := newAutoService(t, provider)
+
+		connID, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "new", connID)
+		require.Equal(t, 1, handshakes)
+	})
+	t.Run("dispatches connection-less requests with an ephemeral did:key", func(t *testing.T) {
+		dispatched := make(chan service.DIDCommContext, 1)
+		provider := testProvider()
+		provider.ServiceMap = map[string]interface{}{
+			didexchange.DIDExchange: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(_ *didexchange.OOBInvitation, _ []string) (string, error) {
+					return "", errors.New("unexpected did-exchange")
+				},
+			},
+		}
+		provider.CustomKMS = &mockkms.KeyManager{CrAndExportPubKeyValue: make([]byte, ed25519.PublicKeySize)}
+		provider.KeyTypeValue = kms.ED25519Type
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+				require.Equal(t, "test-type", msg.Type())
+
+				// the request starts the thread it is replied in
+				thID, err := msg.ThreadID()
+				require.NoError(t, err)
+				require.Equal(t, msg.ID(), thID)
+				require.Contains(t, msg.(service.DIDCommMsgMap), "~thread")
+
+				dispatched <- ctx
+
+				return "", nil
+			}}
+		}
+		s := newAutoService(t, provider)
+		inv := newInvitation()
+		inv.Protocols = nil
+		connID, err := s.AcceptInvitation(inv, &userOptions{})
+		require.NoError(t, err)
+		require.Empty(t, connID)
+
+		select {
+		case ctx := <-dispatched:
+			require.True(t, strings.HasPrefix(ctx.MyDID(), "did:key:z6Mk"))
+			require.Empty(t, ctx.TheirDID())
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+	t.Run("wraps error while creating the ephemeral key", func(t *testing.T) {
+		expected := errors.New("test")
+		provider := testProvider()
+		provider.CustomKMS = &mockkms.KeyManager{CrAndExportPubKeyErr: expected}
+		s := newAutoService(t, provider)
+		inv := newInvitation()
+		inv.Protocols = nil
+		_, err := s.AcceptInvitation(inv, &userOptions{})
+		require.ErrorIs(t, err, expected)
+	})
+	t.Run("error if the provider has no KMS for the ephemeral key", func(t *testing.T) {
+		s, err := New(struct{ Provider }{testProvider()})
+		require.NoError(t, err)
+
+		events := make(chan service.DIDCommAction)
+		require.NoError(t, s.RegisterActionEvent(events))
+
+		go service.AutoExecuteActionEvent(events)
+
+		inv := newInvitation()
+		inv.Protocols = nil
+		_, err = s.AcceptInvitation(inv, &userOptions{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "the provider has no KMS to create ephemeral keys with")
+	})
 	t.Run("error if invitation has invalid accept values", func(t *testing.T) {
 		provider := testProvider()
 		s := newAutoService(t, provider)
@@ -858,6 +1121,121 @@ func TestAcceptInvitation(t *testing.T) {
 	})
 }
 
+func TestConnectionlessPresentProof(t *testing.T) {
+	const (
+		verifierKey    = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		verifierRouter = "https://verifier.example.com/didcomm"
+	)
+
+	verifierPubKey, err := fingerprint.PubKeyFromDIDKey(verifierKey)
+	require.NoError(t, err)
+
+	newPresentProof := func(outbound *mockdispatcher.MockOutbound) *presentproof.Service {
+		provider := &protocol.MockProvider{StoreProvider: mem.NewProvider(), CustomOutbound: outbound}
+
+		msgSvc, e := messenger.NewMessenger(provider)
+		require.NoError(t, e)
+
+		svc, e := presentproof.New(&presentProofProvider{MockProvider: provider, messenger: msgSvc})
+		require.NoError(t, e)
+
+		return svc
+	}
+
+	verifier := newPresentProof(&mockdispatcher.MockOutbound{
+		ValidateSend: func(interface{}, string, *service.Destination) error {
+			return errors.New("the verifier sends nothing")
+		},
+	})
+
+	verifierActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, verifier.RegisterActionEvent(verifierActions))
+
+	verifierStates := make(chan service.StateMsg, 10)
+	require.NoError(t, verifier.RegisterMsgEvent(verifierStates))
+
+	request := service.NewDIDCommMsgMap(presentproof.RequestPresentation{
+		Type:        presentproof.RequestPresentationMsgType,
+		WillConfirm: true,
+	})
+	request["~service"] = map[string]interface{}{
+		"recipientKeys":   []string{verifierKey},
+		"serviceEndpoint": verifierRouter,
+	}
+	require.NoError(t, request.SetID(uuid.New().String()))
+
+	// the verifier records the request it attaches to the invitation
+	piID, err := verifier.HandleInbound(request, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+
+	holder := newPresentProof(&mockdispatcher.MockOutbound{
+		ValidateSend: func(msg interface{}, _ string, dest *service.Destination) error {
+			require.Equal(t, []string{verifierKey}, dest.RecipientKeys)
+			require.Equal(t, verifierRouter, dest.ServiceEndpoint)
+
+			presentation, e := service.ParseDIDCommMsgMap(marshal(t, msg))
+			require.NoError(t, e)
+
+			// the transport delivers the presentation to the key of the request's service
+			_, e = verifier.HandleInbound(presentation, service.NewDIDCommContext("", "", map[string]interface{}{
+				presentproof.RecipientKeyPropKey: base58.Encode(verifierPubKey),
+			}))
+
+			return e
+		},
+	})
+
+	holderActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, holder.RegisterActionEvent(holderActions))
+
+	provider := testProvider()
+	provider.CustomKMS = &mockkms.KeyManager{CrAndExportPubKeyValue: make([]byte, ed25519.PublicKeySize)}
+	provider.KeyTypeValue = kms.ED25519Type
+	provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler { return holder }
+
+	inv := newInvitation()
+	inv.Protocols = nil
+	inv.Requests[0].Data = decorator.AttachmentData{JSON: request}
+
+	connID, err := newAutoService(t, provider).AcceptInvitation(inv, &userOptions{})
+	require.NoError(t, err)
+	require.Empty(t, connID)
+
+	select {
+	case action := <-holderActions:
+		require.Equal(t, presentproof.RequestPresentationMsgType, action.Message.Type())
+
+		action.Continue(presentproof.WithPresentation(&presentproof.Presentation{
+			PresentationsAttach: []decorator.Attachment{{
+				Data: decorator.AttachmentData{JSON: map[string]interface{}{}},
+			}},
+		}))
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	select {
+	case action := <-verifierActions:
+		require.Equal(t, presentproof.PresentationMsgType, action.Message.Type())
+		require.Equal(t, piID, action.Properties.All()["piid"])
+
+		action.Continue(nil)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	for {
+		select {
+		case msg := <-verifierStates:
+			if msg.Type == service.PostState && msg.StateID == "done" {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
+}
+
 func TestSaveInvitation(t *testing.T) {
 	t.Run("saves invitation", func(t *testing.T) {
 		savedInStore := false
@@ -1160,6 +1538,16 @@ func (i *inboundMsgHandler) HandleInbound(msg service.DIDCommMsg, ctx service.DI
 	return i.handleFunc(msg, ctx)
 }
 
+// presentProofProvider provides the present-proof service with a messenger.
+type presentProofProvider struct {
+	*protocol.MockProvider
+	messenger service.Messenger
+}
+
+func (p *presentProofProvider) Messenger() service.Messenger {
+	return p.messenger
+}
+
 func marshal(t *testing.T, v interface{}) []byte {
 	t.Helper()
 
@@ -14,6 +14,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
@@ -38,9 +39,10 @@ func noAction(service.Messenger) error {
 
 type dependencies struct {
 	connections           connectionRecorder
-	didSvc                didExchSvc
+	handshakeSvcs         map[string]didExchSvc // by the PIURI of the handshake protocol they implement
 	saveAttchStateFunc    func(*attachmentHandlingState) error
 	dispatchAttachmntFunc func(string, string, string) error
+	ephemeralDIDFunc      func(*Invitation) (string, error)
 }
 
 // The outofband protocol's state.
@@ -180,11 +182,33 @@ func (s *statePrepareResponse) Execute(ctx *context, deps *dependencies) (state,
 		return s.connectionReuse(ctx, deps)
 	}
 
+	if isConnectionless(ctx.Invitation) {
+		return s.connectionless(ctx, deps)
+	}
+
+	// the invitation may be accepted again (e.g. a multi-use invitation scanned twice): the connection
+	// established the first time is reused instead of creating a new one
+	record, err := findInvitationConnection(ctx.Invitation, deps)
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	if record != nil {
+		return s.reuse(ctx, deps, record)
+	}
+
+	handshakeProtocol, err := chooseHandshakeProtocol(ctx.Invitation.Protocols, deps.handshakeSvcs)
+	if err != nil {
+		return nil, nil, true, err
+	}
+
+	ctx.HandshakeProtocol = handshakeProtocol
+
 	logger.Debugf("creating new connection using context: %+v", ctx)
 
-	connID, err := deps.didSvc.RespondTo(ctx.DIDExchangeInv, ctx.RouterConnections)
+	connID, err := deps.handshakeSvcs[handshakeProtocol].RespondTo(ctx.DIDExchangeInv, ctx.RouterConnections)
 	if err != nil {
-		return nil, nil, true, fmt.Errorf("didexchange service failed to handle inbound invitation: %w", err)
+		return nil, nil, true, fmt.Errorf("%s failed to handle inbound invitation: %w", handshakeProtocol, err)
 	}
 
 	ctx.ConnectionID = connID
@@ -205,6 +229,37 @@ func (s *statePrepareResponse) Execute(ctx *context, deps *dependencies) (state,
 	return &stateDone{}, noAction, false, nil
 }
 
+// connectionless handles the requests attached to an invitation without handshake protocols: they are
+// dispatched without a connection, replies being routed with their ~service decorator.
+func (s *statePrepareResponse) connectionless(ctx *context, deps *dependencies) (state, finisher, bool, error) {
+	logger.Debugf("handling connection-less requests using context: %+v", ctx)
+
+	myDID, err := deps.ephemeralDIDFunc(ctx.Invitation)
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("connectionless: %w", err)
+	}
+
+	ctx.MyDID = myDID
+
+	err = deps.saveAttchStateFunc(&attachmentHandlingState{
+		ID:         ctx.Invitation.ID,
+		Invitation: ctx.Invitation,
+	})
+	if err != nil {
+		return nil, nil, true, fmt.Errorf("failed to save attachment handling state: %w", err)
+	}
+
+	go func() {
+		logger.Debugf("dispatching connection-less invitation attachment...")
+
+		if dispatchErr := deps.dispatchAttachmntFunc(ctx.Invitation.ID, myDID, ""); dispatchErr != nil {
+			logger.Errorf("failed to dispatch attachment: %s", dispatchErr.Error())
+		}
+	}()
+
+	return &stateDone{}, noAction, false, nil
+}
+
 func (s *statePrepareResponse) connectionReuse(ctx *context, deps *dependencies) (state, finisher, bool, error) {
 	logger.Debugf("reusing connection using context: %+v", ctx)
 
@@ -238,6 +293,12 @@ func (s *statePrepareResponse) connectionReuse(ctx *context, deps *dependencies)
 		return nil, nil, true, errors.New("connectionReuse: no existing connection record found for the invitation")
 	}
 
+	return s.reuse(ctx, deps, record)
+}
+
+// reuse sends a handshake-reuse message over the given existing connection instead of creating a new one.
+func (s *statePrepareResponse) reuse(ctx *context, deps *dependencies,
+	record *connection.Record) (state, finisher, bool, error) {
 	ctx.ConnectionID = record.ConnectionID
 	ctx.MyDID = record.MyDID
 	ctx.TheirDID = record.TheirDID
@@ -249,7 +310,7 @@ func (s *statePrepareResponse) connectionReuse(ctx *context, deps *dependencies)
 			Invitation:   ctx.Invitation,
 		}
 
-		err = deps.saveAttchStateFunc(callbackState)
+		err := deps.saveAttchStateFunc(callbackState)
 		if err != nil {
 			return nil, nil, true, fmt.Errorf("failed to save attachment handling state: %w", err)
 		}
@@ -278,6 +339,83 @@ func (s *stateDone) Execute(*context, *dependencies) (state, finisher, bool, err
 	return &stateDone{}, noAction, true, nil
 }
 
+// chooseHandshakeProtocol returns the first handshake protocol offered by the invitation, in the inviter's order
+// of preference, that one of the given services implements. Did-exchange is used if the invitation does not list any.
+func chooseHandshakeProtocol(protocols []string, supported map[string]didExchSvc) (string, error) {
+	if len(protocols) == 0 {
+		protocols = []string{didexchange.PIURI}
+	}
+
+	for _, protocol := range protocols {
+		if _, ok := supported[protocol]; ok {
+			return protocol, nil
+		}
+	}
+
+	return "", fmt.Errorf("no supported handshake protocol in %v", protocols)
+}
+
+// isConnectionless checks whether the invitation only carries requests, without any handshake protocol.
+func isConnectionless(inv *Invitation) bool {
+	return len(inv.Protocols) == 0 && len(inv.Requests) > 0
+}
+
+// findInvitationConnection returns the completed connection established by a previous acceptance of the
+// invitation, nil if the invitation was not accepted before. The connection must have been established with one of
+// the services of the invitation.
+func findInvitationConnection(inv *Invitation, deps *dependencies) (*connection.Record, error) {
+	if inv.ID == "" {
+		return nil, nil
+	}
+
+	records, err := deps.connections.GetConnectionRecordsByParentThreadID(inv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch connection records: %w", err)
+	}
+
+	for _, record := range records {
+		if record.State == didexchange.StateIDCompleted && isInvitationConnection(record, inv) {
+			return record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isInvitationConnection checks whether the connection was established with one of the services of inv: either with
+// the DID of a public DID service or with the recipient keys of an inline service.
+func isInvitationConnection(record *connection.Record, inv *Invitation) bool {
+	for _, svc := range inv.Services {
+		if theirDID, ok := svc.(string); ok {
+			if record.InvitationDID == theirDID || record.TheirDID == theirDID {
+				return true
+			}
+
+			continue
+		}
+
+		block, err := chooseTarget([]interface{}{svc})
+		if err != nil {
+			continue
+		}
+
+		didSvc, ok := block.(*did.Service)
+		if !ok {
+			continue
+		}
+
+		for _, key := range didSvc.RecipientKeys {
+			for _, recKey := range record.RecipientKeys {
+				if key == recKey {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
 func findConnectionRecord(records []*connection.Record, theirDID string) (*connection.Record, bool) {
 	for i := range records {
 		record := records[i]
@@ -9,6 +9,7 @@ package outofband
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -16,11 +17,78 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	mockdidexchange "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol/didexchange"
 	mockservice "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/service"
 	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
 )
 
+func TestChooseHandshakeProtocol(t *testing.T) {
+	const connections = "https://didcomm.org/connections/1.0"
+
+	supported := map[string]didExchSvc{didexchange.PIURI: &mockdidexchange.MockDIDExchangeSvc{}}
+
+	protocol, err := chooseHandshakeProtocol(nil, supported)
+	require.NoError(t, err)
+	require.Equal(t, didexchange.PIURI, protocol)
+
+	protocol, err = chooseHandshakeProtocol([]string{connections, didexchange.PIURI}, supported)
+	require.NoError(t, err)
+	require.Equal(t, didexchange.PIURI, protocol)
+
+	_, err = chooseHandshakeProtocol([]string{connections}, supported)
+	require.Error(t, err)
+
+	// the inviter's order of preference is honoured across the supported protocols
+	supported[connections] = &mockdidexchange.MockDIDExchangeSvc{}
+
+	protocol, err = chooseHandshakeProtocol([]string{connections, didexchange.PIURI}, supported)
+	require.NoError(t, err)
+	require.Equal(t, connections, protocol)
+
+	protocol, err = chooseHandshakeProtocol([]string{didexchange.PIURI, connections}, supported)
+	require.NoError(t, err)
+	require.Equal(t, didexchange.PIURI, protocol)
+
+	_, err = chooseHandshakeProtocol(nil, map[string]didExchSvc{connections: &mockdidexchange.MockDIDExchangeSvc{}})
+	require.Error(t, err)
+}
+
+func TestIsInvitationConnection(t *testing.T) {
+	const recipientKey = "did:key:z6MkjtX1o8JKT6zBx5FHa9mHnzMUzqMwEiBcBhwqcmLMzbbh"
+
+	record := &connection.Record{TheirDID: "did:example:their", RecipientKeys: []string{recipientKey}}
+
+	tests := []struct {
+		name     string
+		services []interface{}
+		expected bool
+	}{
+		{name: "their DID", services: []interface{}{"did:example:their"}, expected: true},
+		{name: "other DID", services: []interface{}{"did:example:other"}},
+		{
+			name:     "inline service",
+			services: []interface{}{&did.Service{RecipientKeys: []string{recipientKey}}},
+			expected: true,
+		},
+		{
+			name:     "inline service map",
+			services: []interface{}{map[string]interface{}{"recipientKeys": []interface{}{recipientKey}}},
+			expected: true,
+		},
+		{name: "inline service with other keys", services: []interface{}{&did.Service{RecipientKeys: []string{"k"}}}},
+		{name: "invalid service", services: []interface{}{42}},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, isInvitationConnection(record, &Invitation{Services: tc.services}))
+		})
+	}
+}
+
 func TestStateFromName(t *testing.T) {
 	t.Run("valid state names", func(t *testing.T) {
 		states := []state{
@@ -122,6 +190,7 @@ func TestStatePrepareResponse_Execute(t *testing.T) {
 		t.Run("error while saving attachment handling state", func(t *testing.T) {
 			expected := errors.New("test")
 			ctx := &context{Invitation: &Invitation{
+				Protocols: []string{didexchange.PIURI},
 				Requests: []*decorator.Attachment{{
 					ID: uuid.New().String(),
 					Data: decorator.AttachmentData{
@@ -130,8 +199,8 @@ func TestStatePrepareResponse_Execute(t *testing.T) {
 				}},
 			}}
 			deps := &dependencies{
-				connections: nil,
-				didSvc:      &mockdidexchange.MockDIDExchangeSvc{},
+				connections:   nil,
+				handshakeSvcs: map[string]didExchSvc{didexchange.PIURI: &mockdidexchange.MockDIDExchangeSvc{}},
 				saveAttchStateFunc: func(*attachmentHandlingState) error {
 					return expected
 				},
@@ -143,6 +212,151 @@ func TestStatePrepareResponse_Execute(t *testing.T) {
 		})
 	})
 
+	t.Run("selects the first supported handshake protocol", func(t *testing.T) {
+		ctx := &context{Invitation: &Invitation{
+			Protocols: []string{"https://didcomm.org/connections/1.0", didexchange.PIURI},
+		}}
+		deps := &dependencies{
+			handshakeSvcs: map[string]didExchSvc{didexchange.PIURI: &mockdidexchange.MockDIDExchangeSvc{
+				RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+					return "connection-id", nil
+				},
+			}},
+		}
+		s := &statePrepareResponse{}
+
+		next, _, _, err := s.Execute(ctx, deps)
+		require.NoError(t, err)
+		require.IsType(t, &stateDone{}, next)
+		require.Equal(t, didexchange.PIURI, ctx.HandshakeProtocol)
+		require.Equal(t, "connection-id", ctx.ConnectionID)
+	})
+
+	t.Run("responds with the service of the preferred handshake protocol", func(t *testing.T) {
+		const connections = "https://didcomm.org/connections/1.0"
+
+		ctx := &context{Invitation: &Invitation{
+			Protocols: []string{connections, didexchange.PIURI},
+		}}
+		deps := &dependencies{
+			handshakeSvcs: map[string]didExchSvc{
+				didexchange.PIURI: &mockdidexchange.MockDIDExchangeSvc{
+					RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+						return "", errors.New("unexpected did-exchange")
+					},
+				},
+				connections: &mockdidexchange.MockDIDExchangeSvc{
+					RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+						return "connection-id", nil
+					},
+				},
+			},
+		}
+		s := &statePrepareResponse{}
+
+		next, _, _, err := s.Execute(ctx, deps)
+		require.NoError(t, err)
+		require.IsType(t, &stateDone{}, next)
+		require.Equal(t, connections, ctx.HandshakeProtocol)
+		require.Equal(t, "connection-id", ctx.ConnectionID)
+	})
+
+	t.Run("error if no handshake protocol is supported", func(t *testing.T) {
+		ctx := &context{Invitation: &Invitation{
+			Protocols: []string{"https://didcomm.org/connections/1.0"},
+		}}
+		s := &statePrepareResponse{}
+
+		_, _, _, err := s.Execute(ctx, &dependencies{})
+		require.EqualError(t, err, "no supported handshake protocol in [https://didcomm.org/connections/1.0]")
+	})
+
+	t.Run("connection-less", func(t *testing.T) {
+		newContext := func() *context {
+			return &context{Invitation: &Invitation{
+				ID: uuid.New().String(),
+				Requests: []*decorator.Attachment{{
+					ID: uuid.New().String(),
+					Data: decorator.AttachmentData{
+						JSON: map[string]interface{}{},
+					},
+				}},
+			}}
+		}
+
+		t.Run("dispatches the attachment with an ephemeral DID", func(t *testing.T) {
+			const ephemeralDID = "did:key:z6MkjtX1h8ndjbBU8jRkXkdrZtxTgdP9tv6bTxvo1CzBZq6g"
+
+			ctx := newContext()
+			dispatched := make(chan []string, 1)
+			deps := &dependencies{
+				handshakeSvcs: map[string]didExchSvc{didexchange.PIURI: &mockdidexchange.MockDIDExchangeSvc{
+					RespondToFunc: func(*didexchange.OOBInvitation, []string) (string, error) {
+						return "", errors.New("unexpected did-exchange")
+					},
+				}},
+				saveAttchStateFunc: func(state *attachmentHandlingState) error {
+					require.Equal(t, ctx.Invitation.ID, state.ID)
+					require.Empty(t, state.ConnectionID)
+
+					return nil
+				},
+				dispatchAttachmntFunc: func(invID, myDID, theirDID string) error {
+					dispatched <- []string{invID, myDID, theirDID}
+
+					return nil
+				},
+				ephemeralDIDFunc: func(*Invitation) (string, error) {
+					return ephemeralDID, nil
+				},
+			}
+			s := &statePrepareResponse{}
+
+			next, finish, halt, err := s.Execute(ctx, deps)
+			require.NoError(t, err)
+			require.IsType(t, &stateDone{}, next)
+			require.False(t, halt)
+			require.NoError(t, finish(nil))
+			require.Equal(t, ephemeralDID, ctx.MyDID)
+
+			select {
+			case args := <-dispatched:
+				require.Equal(t, []string{ctx.Invitation.ID, ephemeralDID, ""}, args)
+			case <-time.After(time.Second):
+				t.Error("timeout")
+			}
+		})
+
+		t.Run("error while creating the ephemeral DID", func(t *testing.T) {
+			expected := errors.New("test")
+			deps := &dependencies{
+				ephemeralDIDFunc: func(*Invitation) (string, error) {
+					return "", expected
+				},
+			}
+			s := &statePrepareResponse{}
+
+			_, _, _, err := s.Execute(newContext(), deps)
+			require.ErrorIs(t, err, expected)
+		})
+
+		t.Run("error while saving attachment handling state", func(t *testing.T) {
+			expected := errors.New("test")
+			deps := &dependencies{
+				saveAttchStateFunc: func(*attachmentHandlingState) error {
+					return expected
+				},
+				ephemeralDIDFunc: func(*Invitation) (string, error) {
+					return "did:key:test", nil
+				},
+			}
+			s := &statePrepareResponse{}
+
+			_, _, _, err := s.Execute(newContext(), deps)
+			require.ErrorIs(t, err, expected)
+		})
+	})
+
 	t.Run("connection reuse", func(t *testing.T) {
 		t.Run("advances to next state and sends handshake-reuse", func(t *testing.T) {
 			savedAttachmentState := false
@@ -208,6 +422,21 @@ func TestStatePrepareResponse_Execute(t *testing.T) {
 			require.ErrorIs(t, err, expected)
 		})
 
+		t.Run("error if cannot query connection records of a previous acceptance", func(t *testing.T) {
+			expected := errors.New("test")
+			ctx := &context{
+				Inbound:    true,
+				Invitation: &Invitation{ID: uuid.New().String()},
+			}
+			deps := &dependencies{
+				connections: &mockConnRecorder{queryConnRecordsErr: expected},
+			}
+			s := &statePrepareResponse{}
+
+			_, _, _, err := s.Execute(ctx, deps)
+			require.ErrorIs(t, err, expected)
+		})
+
 		t.Run("error if cannot find matching connection record", func(t *testing.T) {
 			ctx := &context{
 				Inbound:            true,
@@ -283,3 +512,7 @@ func (m *mockConnRecorder) GetConnectionIDByDIDs(string, string) (string, error)
 func (m *mockConnRecorder) QueryConnectionRecords() ([]*connection.Record, error) {
 	return m.queryConnRecordsVal, m.queryConnRecordsErr
 }
+
+func (m *mockConnRecorder) GetConnectionRecordsByParentThreadID(string) ([]*connection.Record, error) {
+	return m.queryConnRecordsVal, m.queryConnRecordsErr
+}
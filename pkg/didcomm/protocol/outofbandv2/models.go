@@ -0,0 +1,25 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Invitation is this protocol's `invitation` message.
+type Invitation struct {
+	ID       string                    `json:"id"`
+	Type     string                    `json:"type"`
+	From     string                    `json:"from"`
+	Body     *InvitationBody           `json:"body"`
+	Requests []*decorator.AttachmentV2 `json:"attachments,omitempty"`
+}
+
+// InvitationBody is the body of an `invitation` message.
+type InvitationBody struct {
+	GoalCode string   `json:"goal_code,omitempty"`
+	Goal     string   `json:"goal,omitempty"`
+	Accept   []string `json:"accept,omitempty"`
+}
@@ -0,0 +1,294 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// Name of this protocol service.
+	Name = "out-of-band/2.0"
+	// PIURI is the Out-of-Band 2.0 protocol's protocol instance URI.
+	PIURI = "https://didcomm.org/out-of-band/2.0"
+	// InvitationMsgType is the 'type' for the invitation message.
+	InvitationMsgType = PIURI + "/invitation"
+
+	didCommMessagingType       = "DIDCommMessaging"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+)
+
+var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
+
+type connectionRecorder interface {
+	SaveInvitation(string, interface{}) error
+	SaveConnectionRecordWithMappings(*connection.Record) error
+	RemoveConnection(string) error
+}
+
+// Provider provides this service's dependencies.
+type Provider interface {
+	StorageProvider() storage.Provider
+	ProtocolStateStorageProvider() storage.Provider
+	InboundDIDCommMessageHandler() func() service.InboundHandler
+	VDRegistry() vdrapi.Registry
+	KMS() kms.KeyManager
+	ServiceEndpoint() string
+}
+
+// Service implements the Out-Of-Band 2.0 protocol.
+// Invitations are exchanged out of band: the inviter saves the invitation it shares and the invitee accepts it,
+// which hands the attached protocol message off to the service handling it.
+type Service struct {
+	connections     connectionRecorder
+	inboundHandler  func() service.InboundHandler
+	vdrRegistry     vdrapi.Registry
+	kms             kms.KeyManager
+	serviceEndpoint string
+}
+
+// New creates a new instance of the out-of-band 2.0 service.
+func New(p Provider) (*Service, error) {
+	connectionRecorder, err := connection.NewRecorder(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a connection.Recorder : %w", err)
+	}
+
+	return &Service{
+		connections:     connectionRecorder,
+		inboundHandler:  p.InboundDIDCommMessageHandler(),
+		vdrRegistry:     p.VDRegistry(),
+		kms:             p.KMS(),
+		serviceEndpoint: p.ServiceEndpoint(),
+	}, nil
+}
+
+// Name is this service's name.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Accept determines whether this service can handle the given type of message.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == InvitationMsgType
+}
+
+// HandleInbound handles inbound messages. Out-of-band 2.0 invitations are not received over DIDComm, they must be
+// accepted with AcceptInvitation.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, _ service.DIDCommContext) (string, error) {
+	return "", fmt.Errorf("unsupported inbound message type %s: invitations must be accepted out of band",
+		msg.Type())
+}
+
+// HandleOutbound handles outbound messages.
+func (s *Service) HandleOutbound(_ service.DIDCommMsg, _, _ string) (string, error) {
+	// TODO implement
+	return "", errors.New("not implemented")
+}
+
+// SaveInvitation created by the outofbandv2 client. If the invitation has no 'from' DID a new did:peer:2 is
+// created for it, reachable at this agent's service endpoint.
+func (s *Service) SaveInvitation(i *Invitation) error {
+	if i.From == "" {
+		from, err := s.newPeerDID()
+		if err != nil {
+			return fmt.Errorf("failed to create the invitation 'from' DID : %w", err)
+		}
+
+		i.From = from
+	}
+
+	err := s.connections.SaveInvitation(i.ID, i)
+	if err != nil {
+		return fmt.Errorf("failed to save oob v2 invitation : %w", err)
+	}
+
+	logger.Debugf("saved invitation: %+v", i)
+
+	return nil
+}
+
+// AcceptInvitation from another agent and return the ID of the new DIDComm V2 connection. myDID is the DID used
+// for the connection, a new did:peer:2 is created if it is empty. The first attachment of the invitation, if any,
+// is dispatched to the service handling its message type.
+func (s *Service) AcceptInvitation(i *Invitation, myDID string) (string, error) {
+	err := validateInvitation(i)
+	if err != nil {
+		return "", fmt.Errorf("unable to accept invitation: %w", err)
+	}
+
+	docResolution, err := s.vdrRegistry.Resolve(i.From)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve invitation 'from' DID %s : %w", i.From, err)
+	}
+
+	if myDID == "" {
+		myDID, err = s.newPeerDID()
+		if err != nil {
+			return "", fmt.Errorf("failed to create my DID : %w", err)
+		}
+	}
+
+	record := newConnectionRecord(i, myDID, docResolution.DIDDocument)
+
+	err = s.connections.SaveConnectionRecordWithMappings(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to save connection record : %w", err)
+	}
+
+	logger.Debugf("created DIDComm V2 connection %s for invitation %s", record.ConnectionID, i.ID)
+
+	if len(i.Requests) == 0 {
+		return record.ConnectionID, nil
+	}
+
+	err = s.dispatchInvitationAttachment(i, myDID)
+	if err != nil {
+		// the connection was established for the attached request, it is not kept if the request can't be handled
+		if removeErr := s.connections.RemoveConnection(record.ConnectionID); removeErr != nil {
+			logger.Errorf("failed to remove connection %s of invitation %s : %s", record.ConnectionID, i.ID,
+				removeErr.Error())
+		}
+
+		return "", fmt.Errorf("failed to dispatch invitation attachment : %w", err)
+	}
+
+	return record.ConnectionID, nil
+}
+
+func (s *Service) dispatchInvitationAttachment(i *Invitation, myDID string) error {
+	bytes, err := i.Requests[0].Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch attachment contents : %w", err)
+	}
+
+	msg, err := service.ParseDIDCommMsgMap(bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse attachment : %w", err)
+	}
+
+	logger.Debugf("dispatching inbound message of type: %s", msg.Type())
+
+	_, err = s.inboundHandler().HandleInbound(msg, service.NewDIDCommContext(myDID, i.From, nil))
+	if err != nil {
+		return fmt.Errorf("failed to dispatch message: %w", err)
+	}
+
+	return nil
+}
+
+// newPeerDID creates a did:peer:2 with a new authentication and key agreement key pair and a DIDCommMessaging
+// service at this agent's service endpoint.
+func (s *Service) newPeerDID() (string, error) {
+	_, authKey, err := s.kms.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	if err != nil {
+		return "", fmt.Errorf("create authentication key: %w", err)
+	}
+
+	_, kaKeyBytes, err := s.kms.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+	if err != nil {
+		return "", fmt.Errorf("create key agreement key: %w", err)
+	}
+
+	kaKey := &crypto.PublicKey{}
+
+	err = json.Unmarshal(kaKeyBytes, kaKey)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal key agreement key: %w", err)
+	}
+
+	keys := []did.Verification{
+		*did.NewReferencedVerification(
+			did.NewVerificationMethodFromBytes("", ed25519VerificationKey2018, "", authKey), did.Authentication),
+		*did.NewReferencedVerification(
+			did.NewVerificationMethodFromBytes("", x25519KeyAgreementKey2019, "", kaKey.X), did.KeyAgreement),
+	}
+
+	services := []did.Service{{
+		Type:            didCommMessagingType,
+		ServiceEndpoint: s.serviceEndpoint,
+		Accept:          []string{outofband.MediaTypeProfileDIDCommV2},
+	}}
+
+	return peer.NewNumAlgo2DID(keys, services)
+}
+
+func validateInvitation(i *Invitation) error {
+	if i.Type != InvitationMsgType {
+		return fmt.Errorf("unsupported invitation type %s", i.Type)
+	}
+
+	if i.ID == "" {
+		return errors.New("invitation id is empty")
+	}
+
+	if i.From == "" {
+		return errors.New("invitation 'from' DID is empty")
+	}
+
+	return nil
+}
+
+func newConnectionRecord(i *Invitation, myDID string, theirDoc *did.Doc) *connection.Record {
+	record := &connection.Record{
+		ConnectionID:   uuid.New().String(),
+		State:          connection.StateNameCompleted,
+		ThreadID:       i.ID,
+		ParentThreadID: i.ID,
+		TheirDID:       i.From,
+		MyDID:          myDID,
+		InvitationID:   i.ID,
+		InvitationDID:  i.From,
+		Namespace:      connection.MyNSPrefix,
+		DIDCommVersion: connection.DIDCommV2,
+	}
+
+	if i.Body != nil {
+		record.MediaTypeProfiles = i.Body.Accept
+	}
+
+	for j := range theirDoc.KeyAgreement {
+		record.RecipientKeys = append(record.RecipientKeys, absoluteKeyID(theirDoc.ID,
+			theirDoc.KeyAgreement[j].VerificationMethod.ID))
+	}
+
+	for j := range theirDoc.Service {
+		if theirDoc.Service[j].Type == didCommMessagingType {
+			record.ServiceEndPoint = theirDoc.Service[j].ServiceEndpoint
+			record.RoutingKeys = theirDoc.Service[j].RoutingKeys
+
+			break
+		}
+	}
+
+	return record
+}
+
+func absoluteKeyID(didID, keyID string) string {
+	if strings.HasPrefix(keyID, "#") {
+		return didID + keyID
+	}
+
+	return keyID
+}
@@ -0,0 +1,416 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/protocol"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/store/connection"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+const serviceEndpoint = "https://inviter.example.com"
+
+func TestNew(t *testing.T) {
+	t.Run("returns the service", func(t *testing.T) {
+		s, err := New(testProvider(t))
+		require.NoError(t, err)
+		require.NotNil(t, s)
+		require.Equal(t, Name, s.Name())
+	})
+
+	t.Run("fails to open the connection store", func(t *testing.T) {
+		provider := testProvider(t)
+		provider.StoreProvider = &mockstore.MockStoreProvider{ErrOpenStoreHandle: errors.New("test")}
+
+		_, err := New(provider)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to open a connection.Recorder")
+	})
+}
+
+func TestAccept(t *testing.T) {
+	s, err := New(testProvider(t))
+	require.NoError(t, err)
+
+	require.True(t, s.Accept(InvitationMsgType))
+	require.False(t, s.Accept("https://didcomm.org/out-of-band/1.0/invitation"))
+}
+
+func TestHandleInbound(t *testing.T) {
+	s, err := New(testProvider(t))
+	require.NoError(t, err)
+
+	_, err = s.HandleInbound(service.NewDIDCommMsgMap(newInvitation()), service.EmptyDIDCommContext())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invitations must be accepted out of band")
+}
+
+func TestSaveInvitation(t *testing.T) {
+	t.Run("creates a did:peer:2 'from' DID", func(t *testing.T) {
+		provider := testProvider(t)
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.From = ""
+
+		require.NoError(t, s.SaveInvitation(inv))
+		require.True(t, peer.IsNumAlgo2DID(inv.From))
+
+		doc, err := provider.VDRegistry().Resolve(inv.From)
+		require.NoError(t, err)
+		require.Len(t, doc.DIDDocument.Authentication, 1)
+		require.Len(t, doc.DIDDocument.KeyAgreement, 1)
+		require.Len(t, doc.DIDDocument.Service, 1)
+		require.Equal(t, serviceEndpoint, doc.DIDDocument.Service[0].ServiceEndpoint)
+
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		saved := &Invitation{}
+		require.NoError(t, lookup.GetInvitation(inv.ID, saved))
+		require.Equal(t, inv, saved)
+	})
+
+	t.Run("keeps a given 'from' DID", func(t *testing.T) {
+		s, err := New(testProvider(t))
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.From = "did:example:inviter"
+
+		require.NoError(t, s.SaveInvitation(inv))
+		require.Equal(t, "did:example:inviter", inv.From)
+	})
+
+	t.Run("fails to create the 'from' DID", func(t *testing.T) {
+		provider := testProvider(t)
+		provider.CustomKMS = &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test")}
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.From = ""
+
+		err = s.SaveInvitation(inv)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create the invitation 'from' DID")
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	t.Run("creates a DIDComm V2 connection and dispatches the attachment", func(t *testing.T) {
+		inviter, err := New(testProvider(t))
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.From = ""
+		require.NoError(t, inviter.SaveInvitation(inv))
+
+		dispatched := make(chan service.DIDCommMsg, 1)
+		contexts := make(chan service.DIDCommContext, 1)
+
+		provider := testProvider(t)
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+				dispatched <- msg
+				contexts <- ctx
+
+				return "", nil
+			}}
+		}
+
+		invitee, err := New(provider)
+		require.NoError(t, err)
+
+		connID, err := invitee.AcceptInvitation(inv, "")
+		require.NoError(t, err)
+
+		msg := <-dispatched
+		require.Equal(t, "https://didcomm.org/present-proof/3.0/request-presentation", msg.Type())
+		require.Equal(t, "123", msg.ID())
+
+		ctx := <-contexts
+		require.Equal(t, inv.From, ctx.TheirDID())
+		require.True(t, peer.IsNumAlgo2DID(ctx.MyDID()))
+
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		record, err := lookup.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.Equal(t, connection.DIDCommV2, record.DIDCommVersion)
+		require.Equal(t, connection.StateNameCompleted, record.State)
+		require.Equal(t, inv.ID, record.InvitationID)
+		require.Equal(t, inv.From, record.TheirDID)
+		require.Equal(t, ctx.MyDID(), record.MyDID)
+		require.Equal(t, serviceEndpoint, record.ServiceEndPoint)
+		require.Equal(t, []string{inv.From + "#key-2"}, record.RecipientKeys)
+		require.Equal(t, inv.Body.Accept, record.MediaTypeProfiles)
+
+		found, err := lookup.GetConnectionIDByDIDs(record.MyDID, record.TheirDID)
+		require.NoError(t, err)
+		require.Equal(t, connID, found)
+	})
+
+	t.Run("uses the given DID and skips dispatch without attachments", func(t *testing.T) {
+		inviter, err := New(testProvider(t))
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.From = ""
+		inv.Requests = nil
+		require.NoError(t, inviter.SaveInvitation(inv))
+
+		provider := testProvider(t)
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(service.DIDCommMsg, service.DIDCommContext) (string, error) {
+				return "", errors.New("unexpected dispatch")
+			}}
+		}
+
+		invitee, err := New(provider)
+		require.NoError(t, err)
+
+		connID, err := invitee.AcceptInvitation(inv, "did:example:invitee")
+		require.NoError(t, err)
+
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		record, err := lookup.GetConnectionRecord(connID)
+		require.NoError(t, err)
+		require.Equal(t, "did:example:invitee", record.MyDID)
+	})
+
+	t.Run("invalid invitation", func(t *testing.T) {
+		s, err := New(testProvider(t))
+		require.NoError(t, err)
+
+		inv := newInvitation()
+		inv.Type = "https://didcomm.org/out-of-band/1.0/invitation"
+
+		_, err = s.AcceptInvitation(inv, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported invitation type")
+
+		inv = newInvitation()
+		inv.ID = ""
+
+		_, err = s.AcceptInvitation(inv, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invitation id is empty")
+
+		inv = newInvitation()
+		inv.From = ""
+
+		_, err = s.AcceptInvitation(inv, "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invitation 'from' DID is empty")
+	})
+
+	t.Run("fails to resolve the 'from' DID", func(t *testing.T) {
+		provider := testProvider(t)
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveErr: errors.New("test")}
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.AcceptInvitation(newInvitation(), "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to resolve invitation 'from' DID")
+	})
+
+	t.Run("fails to create my DID", func(t *testing.T) {
+		provider := testProvider(t)
+		provider.CustomKMS = &mockkms.KeyManager{CrAndExportPubKeyErr: errors.New("test")}
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{ID: "did:example:inviter"}}
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.AcceptInvitation(newInvitation(), "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create my DID")
+	})
+
+	t.Run("fails to dispatch the attachment", func(t *testing.T) {
+		provider := testProvider(t)
+		provider.CustomVDR = &mockvdr.MockVDRegistry{ResolveValue: &did.Doc{ID: "did:example:inviter"}}
+		provider.InboundDIDCommMsgHandlerFunc = func() service.InboundHandler {
+			return &inboundMsgHandler{handleFunc: func(service.DIDCommMsg, service.DIDCommContext) (string, error) {
+				return "", errors.New("test")
+			}}
+		}
+
+		s, err := New(provider)
+		require.NoError(t, err)
+
+		_, err = s.AcceptInvitation(newInvitation(), "did:example:invitee")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to dispatch message")
+
+		// the connection created for the invitation is removed
+		lookup, err := connection.NewLookup(provider)
+		require.NoError(t, err)
+
+		records, err := lookup.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		_, err = lookup.GetConnectionIDByDIDs("did:example:invitee", "did:example:inviter")
+		require.Error(t, err)
+
+		s.connections = &failingRemoveRecorder{connectionRecorder: s.connections}
+
+		_, err = s.AcceptInvitation(newInvitation(), "did:example:invitee")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to dispatch message")
+
+		inv := newInvitation()
+		inv.Requests[0].Data = decorator.AttachmentData{}
+
+		_, err = s.AcceptInvitation(inv, "did:example:invitee")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to fetch attachment contents")
+	})
+}
+
+func TestInvitationURL(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		inv := newInvitation()
+
+		invURL, err := EncodeInvitationURL("https://example.com/path?foo=bar", inv)
+		require.NoError(t, err)
+		require.Contains(t, invURL, "https://example.com/path?")
+		require.Contains(t, invURL, "foo=bar")
+		require.Contains(t, invURL, InvitationURLParam+"=")
+
+		decoded, err := DecodeInvitationURL(invURL)
+		require.NoError(t, err)
+		require.Equal(t, inv, decoded)
+	})
+
+	t.Run("accepts a padded invitation", func(t *testing.T) {
+		// {"type":"https://didcomm.org/out-of-band/2.0/invitation","id":"1","from":"did:example:alice","body":{}}
+		const padded = "eyJ0eXBlIjoiaHR0cHM6Ly9kaWRjb21tLm9yZy9vdXQtb2YtYmFuZC8yLjAvaW52aXRhdGlvbiIsImlkIjoiMSIs" +
+			"ImZyb20iOiJkaWQ6ZXhhbXBsZTphbGljZSIsImJvZHkiOnt9fQ=="
+
+		inv, err := DecodeInvitationURL("https://example.com?_oob=" + padded)
+		require.NoError(t, err)
+		require.Equal(t, InvitationMsgType, inv.Type)
+		require.Equal(t, "1", inv.ID)
+		require.Equal(t, "did:example:alice", inv.From)
+	})
+
+	t.Run("invalid URLs", func(t *testing.T) {
+		_, err := EncodeInvitationURL("://invalid", newInvitation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse invitation base URL")
+
+		_, err = DecodeInvitationURL("://invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse invitation URL")
+
+		_, err = DecodeInvitationURL("https://example.com?foo=bar")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no _oob query parameter")
+
+		_, err = DecodeInvitationURL("https://example.com?_oob=!!!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode invitation")
+
+		_, err = DecodeInvitationURL("https://example.com?_oob=bm90IGpzb24")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal invitation")
+	})
+}
+
+func testProvider(t *testing.T) *protocol.MockProvider {
+	t.Helper()
+
+	kmsProv := &protocol.MockProvider{
+		StoreProvider: mockstore.NewMockStoreProvider(),
+		CustomLock:    &noop.NoLock{},
+	}
+
+	customKMS, err := localkms.New("local-lock://primary/test/", kmsProv)
+	require.NoError(t, err)
+
+	peerVDR, err := peer.New(mockstore.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	return &protocol.MockProvider{
+		StoreProvider:              mockstore.NewMockStoreProvider(),
+		ProtocolStateStoreProvider: mockstore.NewMockStoreProvider(),
+		CustomKMS:                  customKMS,
+		CustomVDR: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return peerVDR.Read(didID, opts...)
+			},
+		},
+		ServiceEndpointValue: serviceEndpoint,
+	}
+}
+
+func newInvitation() *Invitation {
+	return &Invitation{
+		ID:   uuid.New().String(),
+		Type: InvitationMsgType,
+		From: "did:example:inviter",
+		Body: &InvitationBody{
+			GoalCode: "issue-vc",
+			Goal:     "To issue a credential",
+			Accept:   []string{"didcomm/v2"},
+		},
+		Requests: []*decorator.AttachmentV2{
+			{
+				ID:        uuid.New().String(),
+				MediaType: "application/json",
+				Data: decorator.AttachmentData{
+					JSON: map[string]interface{}{
+						"id":   "123",
+						"type": "https://didcomm.org/present-proof/3.0/request-presentation",
+					},
+				},
+			},
+		},
+	}
+}
+
+type failingRemoveRecorder struct {
+	connectionRecorder
+}
+
+func (r *failingRemoveRecorder) RemoveConnection(string) error {
+	return errors.New("remove connection error")
+}
+
+type inboundMsgHandler struct {
+	handleFunc func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+}
+
+func (i *inboundMsgHandler) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	return i.handleFunc(msg, ctx)
+}
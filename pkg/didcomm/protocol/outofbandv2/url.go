@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package outofbandv2
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// InvitationURLParam is the URL query parameter carrying an encoded out-of-band 2.0 invitation.
+const InvitationURLParam = "_oob"
+
+// EncodeInvitationURL returns baseURL with the base64url encoded invitation set as its `_oob` query parameter.
+// Reference: https://identity.foundation/didcomm-messaging/spec/#standard-message-encoding
+func EncodeInvitationURL(baseURL string, i *Invitation) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("parse invitation base URL: %w", err)
+	}
+
+	bytes, err := json.Marshal(i)
+	if err != nil {
+		return "", fmt.Errorf("marshal invitation: %w", err)
+	}
+
+	query := u.Query()
+	query.Set(InvitationURLParam, base64.RawURLEncoding.EncodeToString(bytes))
+	u.RawQuery = query.Encode()
+
+	return u.String(), nil
+}
+
+// DecodeInvitationURL returns the invitation encoded in the `_oob` query parameter of invitationURL.
+func DecodeInvitationURL(invitationURL string) (*Invitation, error) {
+	u, err := url.Parse(invitationURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse invitation URL: %w", err)
+	}
+
+	encoded := u.Query().Get(InvitationURLParam)
+	if encoded == "" {
+		return nil, errors.New("invitation URL has no _oob query parameter")
+	}
+
+	// accept padded and unpadded base64url invitations.
+	bytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode invitation: %w", err)
+	}
+
+	i := &Invitation{}
+
+	err = json.Unmarshal(bytes, i)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal invitation: %w", err)
+	}
+
+	return i, nil
+}
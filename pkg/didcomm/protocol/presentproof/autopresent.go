@@ -0,0 +1,101 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const mimeTypeApplicationLdJSON = "application/ld+json"
+
+// ErrDeferPresentation is returned by an AutoPresentHandler to leave the decision to the user,
+// the request-presentation message is then delivered through the action event as usual.
+var ErrDeferPresentation = errors.New("presentation deferred to the user")
+
+// AutoPresentHandler is a policy invoked when a request-presentation message is received. It returns
+// the credentials to present, the presentation is then sent without waiting for the user to accept the request.
+// The credentials are attached as "application/ld+json" attachments, so the PresentationDefinition middleware
+// creates the VP from them if the request contains a presentation definition.
+// Returning ErrDeferPresentation defers the request to the user, any other error declines the request.
+type AutoPresentHandler func(request service.DIDCommMsg, myDID, theirDID string) ([]*verifiable.Credential, error)
+
+// SetAutoPresentHandler registers the policy that decides whether the received requests are presented
+// automatically. A nil handler removes the policy.
+func (s *Service) SetAutoPresentHandler(handler AutoPresentHandler) {
+	s.autoPresentMu.Lock()
+	defer s.autoPresentMu.Unlock()
+
+	s.autoPresent = handler
+}
+
+func (s *Service) autoPresentHandler() AutoPresentHandler {
+	s.autoPresentMu.RLock()
+	defer s.autoPresentMu.RUnlock()
+
+	return s.autoPresent
+}
+
+// tryAutoPresent runs the AutoPresentHandler for the received request-presentation message.
+// It returns false if the request must be delivered to the user through the action event.
+func (s *Service) tryAutoPresent(md *metaData) bool {
+	handler := s.autoPresentHandler()
+	if handler == nil || md.state.Name() != stateNameRequestReceived {
+		return false
+	}
+
+	credentials, err := handler(md.msgClone, md.MyDID, md.TheirDID)
+	if errors.Is(err, ErrDeferPresentation) {
+		return false
+	}
+
+	if err != nil {
+		md.err = customError{error: fmt.Errorf("auto present: %w", err)}
+	} else {
+		setPresentation(md, credentials)
+	}
+
+	s.processCallback(md)
+
+	return true
+}
+
+func setPresentation(md *metaData, credentials []*verifiable.Credential) {
+	if isV3(md.Msg) {
+		presentation := &PresentationV3{}
+
+		for _, credential := range credentials {
+			presentation.Attachments = append(presentation.Attachments, decorator.AttachmentV2{
+				ID:        uuid.New().String(),
+				MediaType: mimeTypeApplicationLdJSON,
+				Data:      decorator.AttachmentData{JSON: credential},
+			})
+		}
+
+		md.presentationV3 = presentation
+
+		return
+	}
+
+	presentation := &Presentation{}
+
+	for _, credential := range credentials {
+		presentation.PresentationsAttach = append(presentation.PresentationsAttach, decorator.Attachment{
+			ID:       uuid.New().String(),
+			MimeType: mimeTypeApplicationLdJSON,
+			Data:     decorator.AttachmentData{JSON: credential},
+		})
+	}
+
+	md.presentation = presentation
+}
@@ -0,0 +1,222 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presentproof
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
+)
+
+func TestService_AutoPresent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	credential := &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1"},
+		ID:      "http://example.edu/credentials/1872",
+		Types:   []string{"VerifiableCredential"},
+		Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		Issuer:  verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+	}
+
+	newService := func(messenger service.Messenger) *Service {
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(messenger)
+		provider.EXPECT().StorageProvider().Return(mem.NewProvider()).Times(2)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	t.Run("presents without manual acceptance", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &Presentation{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, PresentationMsgType, r.Type)
+				require.Len(t, r.PresentationsAttach, 1)
+				require.Equal(t, "application/ld+json", r.PresentationsAttach[0].MimeType)
+
+				src, err := r.PresentationsAttach[0].Data.Fetch()
+				require.NoError(t, err)
+
+				var presented map[string]interface{}
+
+				require.NoError(t, json.Unmarshal(src, &presented))
+				require.Equal(t, credential.ID, presented["id"])
+
+				return nil
+			})
+
+		svc := newService(messenger)
+
+		svc.SetAutoPresentHandler(func(request service.DIDCommMsg, myDID, theirDID string) (
+			[]*verifiable.Credential, error) {
+			require.Equal(t, RequestPresentationMsgType, request.Type())
+			require.Equal(t, Alice, myDID)
+			require.Equal(t, Bob, theirDID)
+
+			return []*verifiable.Credential{credential}, nil
+		})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		_, err := svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		require.Empty(t, actions)
+	})
+
+	t.Run("presents V3 without manual acceptance", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), Alice, Bob).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &PresentationV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, PresentationMsgTypeV3, r.Type)
+				require.Len(t, r.Attachments, 1)
+				require.Equal(t, "application/ld+json", r.Attachments[0].MediaType)
+
+				return nil
+			})
+
+		svc := newService(messenger)
+
+		svc.SetAutoPresentHandler(func(service.DIDCommMsg, string, string) ([]*verifiable.Credential, error) {
+			return []*verifiable.Credential{credential}, nil
+		})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		_, err := svc.HandleInbound(randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		require.Empty(t, actions)
+	})
+
+	t.Run("defers to the user", func(t *testing.T) {
+		svc := newService(serviceMocks.NewMockMessenger(ctrl))
+
+		svc.SetAutoPresentHandler(func(service.DIDCommMsg, string, string) ([]*verifiable.Credential, error) {
+			return nil, ErrDeferPresentation
+		})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		_, err := svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case action := <-actions:
+			require.Equal(t, RequestPresentationMsgType, action.Message.Type())
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	})
+
+	t.Run("handler error declines the request", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, _ *service.NestedReplyOpts) error {
+				defer close(done)
+
+				r := &model.ProblemReport{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, ProblemReportMsgType, r.Type)
+
+				return nil
+			})
+
+		svc := newService(messenger)
+
+		svc.SetAutoPresentHandler(func(service.DIDCommMsg, string, string) ([]*verifiable.Credential, error) {
+			return nil, errors.New("no matching credentials")
+		})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		_, err := svc.HandleInbound(randomInboundMessage(RequestPresentationMsgType),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+
+		require.Empty(t, actions)
+	})
+
+	t.Run("other messages are delivered to the user", func(t *testing.T) {
+		svc := newService(serviceMocks.NewMockMessenger(ctrl))
+
+		svc.SetAutoPresentHandler(func(service.DIDCommMsg, string, string) ([]*verifiable.Credential, error) {
+			t.Error("unexpected call")
+
+			return nil, nil
+		})
+
+		actions := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(actions))
+
+		_, err := svc.HandleInbound(randomInboundMessage(ProposePresentationMsgType),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case action := <-actions:
+			require.Equal(t, ProposePresentationMsgType, action.Message.Type())
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	})
+}
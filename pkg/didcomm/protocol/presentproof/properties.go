@@ -6,7 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package presentproof
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+)
 
 const (
 	myDIDPropKey    = "myDID"
@@ -16,11 +21,12 @@ const (
 )
 
 type eventProps struct {
-	properties map[string]interface{}
-	myDID      string
-	theirDID   string
-	piid       string
-	err        error
+	properties    map[string]interface{}
+	myDID         string
+	theirDID      string
+	piid          string
+	err           error
+	problemReport *model.ProblemReportDetails
 }
 
 func newEventProps(md *metaData) *eventProps {
@@ -30,14 +36,31 @@ func newEventProps(md *metaData) *eventProps {
 	}
 
 	return &eventProps{
-		properties: properties,
-		myDID:      md.MyDID,
-		theirDID:   md.TheirDID,
-		piid:       md.PIID,
-		err:        md.err,
+		properties:    properties,
+		myDID:         md.MyDID,
+		theirDID:      md.TheirDID,
+		piid:          md.PIID,
+		err:           md.err,
+		problemReport: problemReportDetails(md.msgClone),
 	}
 }
 
+// problemReportDetails returns the details of msg if it is a problem report, nil otherwise.
+func problemReportDetails(msg service.DIDCommMsg) *model.ProblemReportDetails {
+	if msg == nil || (msg.Type() != ProblemReportMsgType && msg.Type() != ProblemReportMsgTypeV3) {
+		return nil
+	}
+
+	details, err := model.ParseProblemReport(msg)
+	if err != nil {
+		logger.Warnf("failed to parse problem report: %s", err)
+
+		return nil
+	}
+
+	return details
+}
+
 func (e *eventProps) MyDID() string {
 	return e.myDID
 }
@@ -50,6 +73,11 @@ func (e *eventProps) PIID() string {
 	return e.piid
 }
 
+// ProblemReport returns the details of the received problem report that abandoned the protocol, if any.
+func (e *eventProps) ProblemReport() *model.ProblemReportDetails {
+	return e.problemReport
+}
+
 func (e eventProps) Err() error {
 	if errors.As(e.err, &customError{}) {
 		return nil
@@ -76,5 +104,11 @@ func (e eventProps) All() map[string]interface{} {
 		e.properties[errorPropKey] = e.Err()
 	}
 
+	if e.problemReport != nil {
+		for k, v := range e.problemReport.Properties() {
+			e.properties[k] = v
+		}
+	}
+
 	return e.properties
 }
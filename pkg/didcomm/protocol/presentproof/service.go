@@ -10,12 +10,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -36,6 +40,22 @@ const (
 	ProblemReportMsgType = Spec + "problem-report"
 	// PresentationPreviewMsgType defines the protocol presentation-preview inner object type.
 	PresentationPreviewMsgType = Spec + "presentation-preview"
+
+	// SpecV3 defines the protocol spec V3 (DIDComm V2).
+	SpecV3 = "https://didcomm.org/present-proof/3.0/"
+	// ProposePresentationMsgTypeV3 defines the protocol propose-presentation message type.
+	ProposePresentationMsgTypeV3 = SpecV3 + "propose-presentation"
+	// RequestPresentationMsgTypeV3 defines the protocol request-presentation message type.
+	RequestPresentationMsgTypeV3 = SpecV3 + "request-presentation"
+	// PresentationMsgTypeV3 defines the protocol presentation message type.
+	PresentationMsgTypeV3 = SpecV3 + "presentation"
+	// AckMsgTypeV3 defines the protocol ack message type.
+	AckMsgTypeV3 = SpecV3 + "ack"
+	// ProblemReportMsgTypeV3 defines the protocol problem-report message type.
+	ProblemReportMsgTypeV3 = SpecV3 + "problem-report"
+	// RecipientKeyPropKey is the DIDCommContext property holding the base58 encoded recipient key of inbound
+	// messages, which must be a key of the ~service decorator of a request sent without a connection.
+	RecipientKeyPropKey = "recipientKey"
 )
 
 const (
@@ -60,19 +80,24 @@ type transitionalPayload struct {
 	Action
 	StateName   string
 	AckRequired bool
+	// ServiceKeys are the recipient keys of the ~service decorator of a request sent without a connection.
+	ServiceKeys []string
 }
 
 // metaData type to store data for internal usage.
 type metaData struct {
 	transitionalPayload
-	state               state
-	presentationNames   []string
-	properties          map[string]interface{}
-	msgClone            service.DIDCommMsg
-	presentation        *Presentation
-	proposePresentation *ProposePresentation
-	request             *RequestPresentation
-	addProofFn          func(presentation *verifiable.Presentation) error
+	state                 state
+	presentationNames     []string
+	properties            map[string]interface{}
+	msgClone              service.DIDCommMsg
+	presentation          *Presentation
+	proposePresentation   *ProposePresentation
+	request               *RequestPresentation
+	presentationV3        *PresentationV3
+	proposePresentationV3 *ProposePresentationV3
+	requestV3             *RequestPresentationV3
+	addProofFn            func(presentation *verifiable.Presentation) error
 	// err is used to determine whether callback was stopped
 	// e.g the user received an action event and executes Stop(err) function
 	// in that case `err` is equal to `err` which was passing to Stop function
@@ -95,6 +120,18 @@ func (md *metaData) RequestPresentation() *RequestPresentation {
 	return md.request
 }
 
+func (md *metaData) PresentationV3() *PresentationV3 {
+	return md.presentationV3
+}
+
+func (md *metaData) ProposePresentationV3() *ProposePresentationV3 {
+	return md.proposePresentationV3
+}
+
+func (md *metaData) RequestPresentationV3() *RequestPresentationV3 {
+	return md.requestV3
+}
+
 func (md *metaData) PresentationNames() []string {
 	return md.presentationNames
 }
@@ -164,6 +201,30 @@ func WithRequestPresentation(msg *RequestPresentation) Opt {
 	}
 }
 
+// WithPresentationV3 allows providing PresentationV3 message
+// USAGE: This message can be provided after receiving a RequestPresentationV3 message.
+func WithPresentationV3(msg *PresentationV3) Opt {
+	return func(md *metaData) {
+		md.presentationV3 = msg
+	}
+}
+
+// WithProposePresentationV3 allows providing ProposePresentationV3 message
+// USAGE: This message can be provided after receiving a RequestPresentationV3 message.
+func WithProposePresentationV3(msg *ProposePresentationV3) Opt {
+	return func(md *metaData) {
+		md.proposePresentationV3 = msg
+	}
+}
+
+// WithRequestPresentationV3 allows providing RequestPresentationV3 message
+// USAGE: This message can be provided after receiving a ProposePresentationV3 message.
+func WithRequestPresentationV3(msg *RequestPresentationV3) Opt {
+	return func(md *metaData) {
+		md.requestV3 = msg
+	}
+}
+
 // WithFriendlyNames allows providing names for the presentations.
 func WithFriendlyNames(names ...string) Opt {
 	return func(md *metaData) {
@@ -185,6 +246,9 @@ type Service struct {
 	callbacks  chan *metaData
 	messenger  service.Messenger
 	middleware Handler
+
+	autoPresentMu sync.RWMutex
+	autoPresent   AutoPresentHandler
 }
 
 // New returns the presentproof service.
@@ -245,8 +309,16 @@ func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommConte
 	md.MyDID = ctx.MyDID()
 	md.TheirDID = ctx.TheirDID()
 
+	if !sentToServiceKeys(md.ServiceKeys, ctx) {
+		return "", errors.New("the message was not sent to the service of the request")
+	}
+
 	// trigger action event based on message type for inbound messages
 	if canReply && canTriggerActionEvents(msgMap) {
+		if s.tryAutoPresent(md) {
+			return "", nil
+		}
+
 		err = s.saveTransitionalPayload(md.PIID, md.transitionalPayload)
 		if err != nil {
 			return "", fmt.Errorf("save transitional payload: %w", err)
@@ -311,6 +383,7 @@ func (s *Service) doHandle(msg service.DIDCommMsgMap) (*metaData, error) {
 		transitionalPayload: transitionalPayload{
 			StateName:   next.Name(),
 			AckRequired: data.AckRequired,
+			ServiceKeys: data.ServiceKeys,
 			Action: Action{
 				Msg:  msg,
 				PIID: piID,
@@ -364,7 +437,7 @@ func (s *Service) handle(md *metaData) error {
 		}
 
 		// WARN: md.ackRequired is being modified by requestSent state
-		data := &internalData{StateName: current.Name(), AckRequired: md.AckRequired}
+		data := &internalData{StateName: current.Name(), AckRequired: md.AckRequired, ServiceKeys: md.ServiceKeys}
 		if err := s.saveInternalData(md.PIID, data); err != nil {
 			return fmt.Errorf("failed to persist state %s: %w", current.Name(), err)
 		}
@@ -390,6 +463,37 @@ func getPIID(msg service.DIDCommMsg) (string, error) {
 type internalData struct {
 	AckRequired bool
 	StateName   string
+	ServiceKeys []string
+}
+
+// sentToServiceKeys checks whether the message of ctx was sent to one of the recipient keys of the ~service
+// decorator of a request sent without a connection. Any message is accepted when there are no such keys.
+func sentToServiceKeys(serviceKeys []string, ctx service.DIDCommContext) bool {
+	if len(serviceKeys) == 0 {
+		return true
+	}
+
+	recipientKey, ok := ctx.All()[RecipientKeyPropKey].(string)
+	if !ok || recipientKey == "" {
+		return false
+	}
+
+	for _, key := range serviceKeys {
+		if strings.HasPrefix(key, "did:key:") {
+			pubKey, err := fingerprint.PubKeyFromDIDKey(key)
+			if err != nil {
+				continue
+			}
+
+			key = base58.Encode(pubKey)
+		}
+
+		if key == recipientKey {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (s *Service) saveInternalData(piID string, data *internalData) error {
@@ -449,23 +553,23 @@ func nextState(msg service.DIDCommMsgMap) (state, error) {
 	canReply := canReplyTo(msg)
 
 	switch msg.Type() {
-	case RequestPresentationMsgType:
+	case RequestPresentationMsgType, RequestPresentationMsgTypeV3:
 		if canReply {
 			return &requestReceived{}, nil
 		}
 
 		return &requestSent{}, nil
-	case ProposePresentationMsgType:
+	case ProposePresentationMsgType, ProposePresentationMsgTypeV3:
 		if canReply {
 			return &proposalReceived{}, nil
 		}
 
 		return &proposalSent{}, nil
-	case PresentationMsgType:
+	case PresentationMsgType, PresentationMsgTypeV3:
 		return &presentationReceived{}, nil
-	case ProblemReportMsgType:
+	case ProblemReportMsgType, ProblemReportMsgTypeV3:
 		return &abandoned{}, nil
-	case AckMsgType:
+	case AckMsgType, AckMsgTypeV3:
 		return &done{}, nil
 	default:
 		return nil, fmt.Errorf("unrecognized msgType: %s", msg.Type())
@@ -483,10 +587,13 @@ func (s *Service) saveTransitionalPayload(id string, data transitionalPayload) e
 
 // canTriggerActionEvents checks if the incoming message can trigger an action event.
 func canTriggerActionEvents(msg service.DIDCommMsg) bool {
-	return msg.Type() == PresentationMsgType ||
-		msg.Type() == ProposePresentationMsgType ||
-		msg.Type() == RequestPresentationMsgType ||
-		msg.Type() == ProblemReportMsgType
+	switch msg.Type() {
+	case PresentationMsgType, ProposePresentationMsgType, RequestPresentationMsgType, ProblemReportMsgType,
+		PresentationMsgTypeV3, ProposePresentationMsgTypeV3, RequestPresentationMsgTypeV3, ProblemReportMsgTypeV3:
+		return true
+	}
+
+	return false
 }
 
 func (s *Service) getTransitionalPayload(id string) (*transitionalPayload, error) {
@@ -660,15 +767,13 @@ func (s *Service) execute(next state, md *metaData) (state, stateAction, error)
 // sendMsgEvents triggers the message events.
 func (s *Service) sendMsgEvents(md *metaData, stateID string, stateType service.StateMsgType) {
 	// trigger the message events
-	for _, handler := range s.MsgEvents() {
-		handler <- service.StateMsg{
-			ProtocolName: Name,
-			Type:         stateType,
-			Msg:          md.msgClone,
-			StateID:      stateID,
-			Properties:   newEventProps(md),
-		}
-	}
+	s.PublishMsgEvent(service.StateMsg{
+		ProtocolName: Name,
+		Type:         stateType,
+		Msg:          md.msgClone,
+		StateID:      stateID,
+		Properties:   newEventProps(md),
+	})
 }
 
 // Name returns service name.
@@ -680,7 +785,9 @@ func (s *Service) Name() string {
 func (s *Service) Accept(msgType string) bool {
 	switch msgType {
 	case ProposePresentationMsgType, RequestPresentationMsgType,
-		PresentationMsgType, AckMsgType, ProblemReportMsgType:
+		PresentationMsgType, AckMsgType, ProblemReportMsgType,
+		ProposePresentationMsgTypeV3, RequestPresentationMsgTypeV3,
+		PresentationMsgTypeV3, AckMsgTypeV3, ProblemReportMsgTypeV3:
 		return true
 	}
 
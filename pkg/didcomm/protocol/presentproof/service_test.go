@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -21,10 +22,14 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/messenger"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	dispatcherMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/dispatcher"
+	messengerMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/messenger"
 	presentproofMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/protocol/presentproof"
 	storageMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/spi/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -90,10 +95,13 @@ func TestService_Use(t *testing.T) {
 			}{
 				Type: PresentationMsgType,
 			}),
-			presentation:        &Presentation{Type: PresentationMsgType},
-			proposePresentation: &ProposePresentation{Type: ProposePresentationMsgType},
-			request:             &RequestPresentation{Type: RequestPresentationMsgType},
-			presentationNames:   []string{"name"},
+			presentation:          &Presentation{Type: PresentationMsgType},
+			proposePresentation:   &ProposePresentation{Type: ProposePresentationMsgType},
+			request:               &RequestPresentation{Type: RequestPresentationMsgType},
+			presentationNames:     []string{"name"},
+			presentationV3:        &PresentationV3{Type: PresentationMsgTypeV3},
+			proposePresentationV3: &ProposePresentationV3{Type: ProposePresentationMsgTypeV3},
+			requestV3:             &RequestPresentationV3{Type: RequestPresentationMsgTypeV3},
 		}
 		var executed bool
 		svc.Use(func(next Handler) Handler {
@@ -104,6 +112,9 @@ func TestService_Use(t *testing.T) {
 				require.Equal(t, meta.proposePresentation, metadata.ProposePresentation())
 				require.Equal(t, meta.request, metadata.RequestPresentation())
 				require.Equal(t, meta.presentationNames, metadata.PresentationNames())
+				require.Equal(t, meta.presentationV3, metadata.PresentationV3())
+				require.Equal(t, meta.proposePresentationV3, metadata.ProposePresentationV3())
+				require.Equal(t, meta.requestV3, metadata.RequestPresentationV3())
 				require.Equal(t, meta.state.Name(), metadata.StateName())
 				require.Nil(t, metadata.GetAddProofFn())
 
@@ -779,6 +790,82 @@ func TestService_HandleInbound(t *testing.T) {
 		}
 	})
 
+	t.Run("Receive Problem Report mid-protocol", func(t *testing.T) {
+		done := make(chan struct{})
+
+		src, err := json.Marshal(&internalData{StateName: "presentation-sent"})
+		require.NoError(t, err)
+
+		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			defer close(done)
+
+			src, err = json.Marshal(&internalData{StateName: "abandoned"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		chState := make(chan service.StateMsg, 2)
+		require.NoError(t, svc.RegisterMsgEvent(chState))
+
+		msg := service.NewDIDCommMsgMap(struct {
+			model.ProblemReport
+			Thread decorator.Thread `json:"~thread"`
+		}{
+			ProblemReport: model.ProblemReport{
+				Type:        ProblemReportMsgType,
+				Description: model.Code{Code: "invalid-presentation"},
+				Comment:     "the presentation does not match the request",
+			},
+			Thread: decorator.Thread{ID: uuid.New().String()},
+		})
+		require.NoError(t, msg.SetID(uuid.New().String()))
+
+		thID, err := msg.ThreadID()
+		require.NoError(t, err)
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		properties, ok := action.Properties.(*eventProps)
+		require.True(t, ok)
+		require.Equal(t, &model.ProblemReportDetails{
+			Code:     "invalid-presentation",
+			Comment:  "the presentation does not match the request",
+			ThreadID: thID,
+		}, properties.ProblemReport())
+
+		action.Continue(nil)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+
+		for _, stateType := range []service.StateMsgType{service.PreState, service.PostState} {
+			stateMsg := <-chState
+			require.Equal(t, stateType, stateMsg.Type)
+			require.Equal(t, stateNameAbandoned, stateMsg.StateID)
+			require.Equal(t, "invalid-presentation", stateMsg.Properties.All()[model.ProblemCodePropKey])
+			require.Equal(t, "the presentation does not match the request",
+				stateMsg.Properties.All()[model.ProblemCommentPropKey])
+			require.Equal(t, thID, stateMsg.Properties.All()[model.ProblemThreadIDPropKey])
+		}
+	})
+
 	t.Run("Receive Propose Presentation (continue without request)", func(t *testing.T) {
 		done := make(chan struct{})
 
@@ -1060,6 +1147,399 @@ func TestService_HandleInbound(t *testing.T) {
 		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
 		require.Contains(t, fmt.Sprintf("%v", err), "action proposal-sent: "+errMsg)
 	})
+
+	t.Run("Receive Request Presentation V3 (continue with presentation)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &PresentationV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, PresentationMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "request-received"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "presentation-sent"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := randomInboundMessageV3(RequestPresentationMsgTypeV3)
+		msg["body"] = map[string]interface{}{"will_confirm": true}
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		properties, ok := action.Properties.(*eventProps)
+		require.True(t, ok)
+		require.NotEmpty(t, properties.PIID())
+		require.Equal(t, properties.MyDID(), Alice)
+		require.Equal(t, properties.TheirDID(), Bob)
+
+		action.Continue(WithPresentationV3(&PresentationV3{}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Propose Presentation V3 (continue)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				defer close(done)
+
+				r := &RequestPresentationV3{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, RequestPresentationMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		store.EXPECT().Get(gomock.Any()).Return(nil, storage.ErrDataNotFound)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "proposal-received"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{AckRequired: true, StateName: "request-sent"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err = svc.HandleInbound(
+			randomInboundMessageV3(ProposePresentationMsgTypeV3),
+			service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		action.Continue(WithRequestPresentationV3(&RequestPresentationV3{
+			Body: RequestPresentationV3Body{WillConfirm: true},
+		}))
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Presentation V3 (continue)", func(t *testing.T) {
+		done := make(chan struct{})
+
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				r := &model.AckV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, AckMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		src, err := json.Marshal(&internalData{AckRequired: true, StateName: "request-sent"})
+		require.NoError(t, err)
+
+		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().Delete(gomock.Any()).Return(nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "presentation-received"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		store.EXPECT().Put(gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			defer close(done)
+
+			src, err = json.Marshal(&internalData{AckRequired: true, StateName: "done"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		msg := service.NewDIDCommMsgMap(PresentationV3{
+			ID:   uuid.New().String(),
+			Type: PresentationMsgTypeV3,
+			Attachments: []decorator.AttachmentV2{{
+				Data: decorator.AttachmentData{
+					Base64: base64.StdEncoding.EncodeToString([]byte(`{}`)),
+				},
+			}},
+		})
+		msg["thid"] = uuid.New().String()
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		action := <-ch
+
+		action.Continue(nil)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second * 10):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Receive Ack V3", func(t *testing.T) {
+		done := make(chan struct{})
+
+		src, err := json.Marshal(&internalData{StateName: "presentation-sent"})
+		require.NoError(t, err)
+
+		store.EXPECT().Get(gomock.Any()).Return(src, nil)
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			defer close(done)
+
+			src, err = json.Marshal(&internalData{StateName: "done"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		ch := make(chan service.DIDCommAction, 1)
+		require.NoError(t, svc.RegisterActionEvent(ch))
+
+		_, err = svc.HandleInbound(randomInboundMessageV3(AckMsgTypeV3), service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+
+	t.Run("Send Request Presentation V3", func(t *testing.T) {
+		done := make(chan struct{})
+
+		store.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, data []byte) error {
+			src, err := json.Marshal(&internalData{StateName: "request-sent"})
+			require.NoError(t, err)
+			require.Equal(t, src, data)
+
+			return nil
+		})
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		msg := service.NewDIDCommMsgMap(RequestPresentationV3{
+			Type: RequestPresentationMsgTypeV3,
+		})
+
+		messenger.EXPECT().Send(gomock.Any(), Alice, Bob).
+			Do(func(msg service.DIDCommMsgMap, myDID, theirDID string) error {
+				defer close(done)
+
+				require.NotEmpty(t, msg.ID())
+				require.NotEmpty(t, msg["id"])
+				require.Equal(t, RequestPresentationMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		_, err = svc.HandleInbound(msg, service.NewDIDCommContext(Alice, Bob, nil))
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Second):
+			t.Error("timeout")
+		}
+	})
+}
+
+func TestService_Connectionless(t *testing.T) {
+	const (
+		holderDID      = "did:key:z6MkjtX1h8ndjbBU8jRkXkdrZtxTgdP9tv6bTxvo1CzBZq6g"
+		verifierKey    = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		verifierRouter = "https://verifier.example.com/didcomm"
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newService := func(outbound *dispatcherMocks.MockOutbound) *Service {
+		store := mem.NewProvider()
+
+		mProvider := messengerMocks.NewMockProvider(ctrl)
+		mProvider.EXPECT().StorageProvider().Return(store)
+		mProvider.EXPECT().OutboundDispatcher().Return(outbound)
+
+		msgSvc, err := messenger.NewMessenger(mProvider)
+		require.NoError(t, err)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().StorageProvider().Return(store).Times(2)
+		provider.EXPECT().Messenger().Return(msgSvc)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+
+		return svc
+	}
+
+	verifier := newService(dispatcherMocks.NewMockOutbound(ctrl))
+
+	verifierActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, verifier.RegisterActionEvent(verifierActions))
+
+	verifierStates := make(chan service.StateMsg, 10)
+	require.NoError(t, verifier.RegisterMsgEvent(verifierStates))
+
+	holderOutbound := dispatcherMocks.NewMockOutbound(ctrl)
+	holderOutbound.EXPECT().Send(gomock.Any(), holderDID, gomock.Any()).
+		DoAndReturn(func(msg interface{}, _ string, dest *service.Destination) error {
+			require.Equal(t, []string{verifierKey}, dest.RecipientKeys)
+			require.Equal(t, verifierRouter, dest.ServiceEndpoint)
+
+			src, err := json.Marshal(msg)
+			require.NoError(t, err)
+
+			presentation, err := service.ParseDIDCommMsgMap(src)
+			require.NoError(t, err)
+
+			// the presentation must be delivered to the service of the request
+			_, err = verifier.HandleInbound(presentation, service.NewDIDCommContext("", "", map[string]interface{}{
+				RecipientKeyPropKey: base58.Encode([]byte("unknown key")),
+			}))
+			require.EqualError(t, err, "the message was not sent to the service of the request")
+
+			verifierPubKey, err := fingerprint.PubKeyFromDIDKey(verifierKey)
+			require.NoError(t, err)
+
+			// the verifier does not know the ephemeral key of the holder
+			_, err = verifier.HandleInbound(presentation, service.NewDIDCommContext("", "", map[string]interface{}{
+				RecipientKeyPropKey: base58.Encode(verifierPubKey),
+			}))
+
+			return err
+		})
+
+	holder := newService(holderOutbound)
+
+	holderActions := make(chan service.DIDCommAction, 1)
+	require.NoError(t, holder.RegisterActionEvent(holderActions))
+
+	request := service.NewDIDCommMsgMap(RequestPresentation{
+		Type:        RequestPresentationMsgType,
+		WillConfirm: true,
+	})
+	request["~service"] = map[string]interface{}{
+		"recipientKeys":   []string{verifierKey},
+		"serviceEndpoint": verifierRouter,
+	}
+	require.NoError(t, request.SetID(uuid.New().String()))
+
+	// the verifier records the request without sending it, it travels in an out-of-band invitation
+	piID, err := verifier.HandleInbound(request, service.EmptyDIDCommContext())
+	require.NoError(t, err)
+	require.Equal(t, request.ID(), piID)
+
+	// the holder receives the request from the out-of-band invitation with an ephemeral DID,
+	// the out-of-band protocol starting the thread with the request
+	received := request.Clone()
+	received["~thread"] = map[string]interface{}{"thid": request.ID()}
+
+	_, err = holder.HandleInbound(received, service.NewDIDCommContext(holderDID, "", nil))
+	require.NoError(t, err)
+
+	select {
+	case action := <-holderActions:
+		action.Continue(WithPresentation(&Presentation{
+			PresentationsAttach: []decorator.Attachment{{
+				Data: decorator.AttachmentData{
+					Base64: base64.StdEncoding.EncodeToString([]byte(`{}`)),
+				},
+			}},
+		}))
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	select {
+	case action := <-verifierActions:
+		require.Equal(t, PresentationMsgType, action.Message.Type())
+		require.Equal(t, piID, action.Properties.All()["piid"])
+
+		action.Continue(nil)
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	for {
+		select {
+		case msg := <-verifierStates:
+			// the presentation of a connection-less request is not acknowledged
+			if msg.Type == service.PostState && msg.StateID == stateNameDone {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	}
 }
 
 func Test_stateFromName(t *testing.T) {
@@ -1085,6 +1565,11 @@ func TestService_Accept(t *testing.T) {
 	require.True(t, (*Service).Accept(nil, PresentationMsgType))
 	require.True(t, (*Service).Accept(nil, AckMsgType))
 	require.True(t, (*Service).Accept(nil, ProblemReportMsgType))
+	require.True(t, (*Service).Accept(nil, ProposePresentationMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, RequestPresentationMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, PresentationMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, AckMsgTypeV3))
+	require.True(t, (*Service).Accept(nil, ProblemReportMsgTypeV3))
 	require.False(t, (*Service).Accept(nil, "unknown"))
 }
 
@@ -1101,6 +1586,18 @@ func TestService_canTriggerActionEvents(t *testing.T) {
 		Type: PresentationMsgType,
 	})))
 
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(RequestPresentationV3{
+		Type: RequestPresentationMsgTypeV3,
+	})))
+
+	require.True(t, canTriggerActionEvents(service.NewDIDCommMsgMap(PresentationV3{
+		Type: PresentationMsgTypeV3,
+	})))
+
+	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(model.AckV2{
+		Type: AckMsgTypeV3,
+	})))
+
 	require.False(t, canTriggerActionEvents(service.NewDIDCommMsgMap(struct{}{})))
 }
 
@@ -1161,6 +1658,32 @@ func Test_nextState(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, next, &abandoned{})
 
+	next, err = nextState(service.NewDIDCommMsgMap(RequestPresentationV3{
+		Type: RequestPresentationMsgTypeV3,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, next, &requestSent{})
+
+	next, err = nextState(randomInboundMessageV3(RequestPresentationMsgTypeV3))
+	require.NoError(t, err)
+	require.Equal(t, next, &requestReceived{})
+
+	next, err = nextState(randomInboundMessageV3(ProposePresentationMsgTypeV3))
+	require.NoError(t, err)
+	require.Equal(t, next, &proposalReceived{})
+
+	next, err = nextState(randomInboundMessageV3(PresentationMsgTypeV3))
+	require.NoError(t, err)
+	require.Equal(t, next, &presentationReceived{})
+
+	next, err = nextState(randomInboundMessageV3(AckMsgTypeV3))
+	require.NoError(t, err)
+	require.Equal(t, next, &done{})
+
+	next, err = nextState(randomInboundMessageV3(ProblemReportMsgTypeV3))
+	require.NoError(t, err)
+	require.Equal(t, next, &abandoned{})
+
 	next, err = nextState(service.NewDIDCommMsgMap(struct{}{}))
 	require.Error(t, err)
 	require.Nil(t, next)
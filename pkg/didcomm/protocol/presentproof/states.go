@@ -9,9 +9,11 @@ package presentproof
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 )
 
 const (
@@ -37,7 +39,8 @@ const (
 	codeInternalError = "internal"
 	codeRejectedError = "rejected"
 
-	jsonThread = "~thread"
+	jsonThread   = "~thread"
+	jsonThreadID = "thid"
 )
 
 // state action for network call.
@@ -97,7 +100,7 @@ func (s *abandoned) CanTransitionTo(st state) bool {
 func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 	// if code is not provided it means we do not need to notify the another agent.
 	// if we received ProblemReport message no need to answer.
-	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType {
+	if s.Code == "" || md.Msg.Type() == ProblemReportMsgType || md.Msg.Type() == ProblemReportMsgTypeV3 {
 		return &noOp{}, zeroAction, nil
 	}
 
@@ -113,11 +116,21 @@ func (s *abandoned) Execute(md *metaData) (state, stateAction, error) {
 		return nil, nil, fmt.Errorf("threadID: %w", err)
 	}
 
+	problemReport := service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        ProblemReportMsgType,
+		Description: code,
+	})
+
+	if isV3(md.Msg) {
+		problemReport = service.NewDIDCommMsgMap(&model.ProblemReportV2{
+			Type: ProblemReportMsgTypeV3,
+			Body: model.ProblemReportV2Body{Code: code.Code},
+		})
+	}
+
 	return &noOp{}, func(messenger service.Messenger) error {
-		return messenger.ReplyToNested(service.NewDIDCommMsgMap(&model.ProblemReport{
-			Type:        ProblemReportMsgType,
-			Description: code,
-		}), &service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
+		return messenger.ReplyToNested(problemReport,
+			&service.NestedReplyOpts{ThreadID: thID, MyDID: md.MyDID, TheirDID: md.TheirDID})
 	}, nil
 }
 
@@ -165,6 +178,10 @@ func (s *requestReceived) CanTransitionTo(st state) bool {
 }
 
 func (s *requestReceived) Execute(md *metaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		return s.executeV3(md)
+	}
+
 	if md.presentation == nil {
 		return &proposalSent{}, zeroAction, nil
 	}
@@ -178,6 +195,20 @@ func (s *requestReceived) Execute(md *metaData) (state, stateAction, error) {
 	return &presentationSent{WillConfirm: req.WillConfirm}, zeroAction, nil
 }
 
+func (s *requestReceived) executeV3(md *metaData) (state, stateAction, error) {
+	if md.presentationV3 == nil {
+		return &proposalSent{}, zeroAction, nil
+	}
+
+	var req *RequestPresentationV3
+
+	if err := md.Msg.Decode(&req); err != nil {
+		return nil, nil, err
+	}
+
+	return &presentationSent{WillConfirm: req.Body.WillConfirm}, zeroAction, nil
+}
+
 // requestSent the Verifier's state.
 type requestSent struct{}
 
@@ -192,12 +223,63 @@ func (s *requestSent) CanTransitionTo(st state) bool {
 }
 
 func forwardInitial(md *metaData) stateAction {
+	if isConnectionless(md) {
+		// a connection-less message is delivered within an out-of-band invitation
+		return zeroAction
+	}
+
 	return func(messenger service.Messenger) error {
 		return messenger.Send(md.Msg, md.MyDID, md.TheirDID)
 	}
 }
 
+// connectionlessRoute returns the ~service decorator of a message exchanged without a connection,
+// nil if the message was exchanged over a connection or has no ~service decorator.
+func connectionlessRoute(md *metaData) *decorator.Service {
+	if md.TheirDID != "" {
+		return nil
+	}
+
+	var decorated struct {
+		Service *decorator.Service `json:"~service,omitempty"`
+	}
+
+	if err := md.Msg.Decode(&decorated); err != nil {
+		return nil
+	}
+
+	return decorated.Service
+}
+
+func isConnectionless(md *metaData) bool {
+	return connectionlessRoute(md) != nil
+}
+
+// replyTo replies to the message over the connection between MyDID and TheirDID. A message received
+// without a connection is replied to the route provided by its ~service decorator.
+func replyTo(messenger service.Messenger, md *metaData, out service.DIDCommMsgMap) error {
+	route := connectionlessRoute(md)
+	if route == nil {
+		return messenger.ReplyToMsg(md.Msg, out, md.MyDID, md.TheirDID)
+	}
+
+	destMessenger, ok := messenger.(service.DestinationMessenger)
+	if !ok {
+		return errors.New("the messenger cannot reply to a message received without a connection")
+	}
+
+	return destMessenger.ReplyToMsgToDestination(md.Msg, out, md.MyDID, &service.Destination{
+		RecipientKeys:   route.RecipientKeys,
+		RoutingKeys:     route.RoutingKeys,
+		ServiceEndpoint: route.ServiceEndpoint,
+	})
+}
+
 func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
+	if isV3(md.Msg) {
+		return s.executeV3(md)
+	}
+
 	if !canReplyTo(md.Msg) {
 		var req *RequestPresentation
 
@@ -205,7 +287,14 @@ func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
 			return nil, nil, err
 		}
 
-		md.AckRequired = req.WillConfirm
+		// the presentation of a connection-less request has no route to acknowledge it to,
+		// and must be sent to the route of the request
+		route := connectionlessRoute(md)
+		if route != nil {
+			md.ServiceKeys = route.RecipientKeys
+		}
+
+		md.AckRequired = req.WillConfirm && route == nil
 
 		return &noOp{}, forwardInitial(md), nil
 	}
@@ -222,6 +311,38 @@ func (s *requestSent) Execute(md *metaData) (state, stateAction, error) {
 	}, nil
 }
 
+func (s *requestSent) executeV3(md *metaData) (state, stateAction, error) {
+	if !canReplyTo(md.Msg) {
+		var req *RequestPresentationV3
+
+		if err := md.Msg.Decode(&req); err != nil {
+			return nil, nil, err
+		}
+
+		// the presentation of a connection-less request has no route to acknowledge it to,
+		// and must be sent to the route of the request
+		route := connectionlessRoute(md)
+		if route != nil {
+			md.ServiceKeys = route.RecipientKeys
+		}
+
+		md.AckRequired = req.Body.WillConfirm && route == nil
+
+		return &noOp{}, forwardInitial(md), nil
+	}
+
+	if md.requestV3 == nil {
+		return nil, nil, errors.New("request was not provided")
+	}
+
+	md.AckRequired = md.requestV3.Body.WillConfirm
+
+	return &noOp{}, func(messenger service.Messenger) error {
+		md.requestV3.Type = RequestPresentationMsgTypeV3
+		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.requestV3), md.MyDID, md.TheirDID)
+	}, nil
+}
+
 // presentationSent the Prover's state.
 type presentationSent struct {
 	WillConfirm bool
@@ -237,15 +358,9 @@ func (s *presentationSent) CanTransitionTo(st state) bool {
 }
 
 func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
-	if md.presentation == nil {
-		return nil, nil, errors.New("presentation was not provided")
-	}
-
-	// creates the state's action
-	action := func(messenger service.Messenger) error {
-		// sets message type
-		md.presentation.Type = PresentationMsgType
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.presentation), md.MyDID, md.TheirDID)
+	action, err := presentationAction(md)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if !s.WillConfirm {
@@ -255,6 +370,30 @@ func (s *presentationSent) Execute(md *metaData) (state, stateAction, error) {
 	return &noOp{}, action, nil
 }
 
+// presentationAction creates the presentationSent state's action.
+func presentationAction(md *metaData) (stateAction, error) {
+	if isV3(md.Msg) {
+		if md.presentationV3 == nil {
+			return nil, errors.New("presentation was not provided")
+		}
+
+		return func(messenger service.Messenger) error {
+			md.presentationV3.Type = PresentationMsgTypeV3
+			return replyTo(messenger, md, service.NewDIDCommMsgMap(md.presentationV3))
+		}, nil
+	}
+
+	if md.presentation == nil {
+		return nil, errors.New("presentation was not provided")
+	}
+
+	return func(messenger service.Messenger) error {
+		// sets message type
+		md.presentation.Type = PresentationMsgType
+		return replyTo(messenger, md, service.NewDIDCommMsgMap(md.presentation))
+	}, nil
+}
+
 // presentationReceived the Verifier's state.
 type presentationReceived struct{}
 
@@ -272,11 +411,19 @@ func (s *presentationReceived) Execute(md *metaData) (state, stateAction, error)
 		return &done{}, zeroAction, nil
 	}
 
+	ack := service.NewDIDCommMsgMap(model.Ack{
+		Type: AckMsgType,
+	})
+
+	if isV3(md.Msg) {
+		ack = service.NewDIDCommMsgMap(model.AckV2{
+			Type: AckMsgTypeV3,
+		})
+	}
+
 	// creates the state's action
 	action := func(messenger service.Messenger) error {
-		return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(model.Ack{
-			Type: AckMsgType,
-		}), md.MyDID, md.TheirDID)
+		return replyTo(messenger, md, ack)
 	}
 
 	return &done{}, action, nil
@@ -295,15 +442,37 @@ func (s *proposalSent) CanTransitionTo(st state) bool {
 }
 
 func canReplyTo(msg service.DIDCommMsgMap) bool {
+	if isV3(msg) {
+		_, ok := msg[jsonThreadID]
+		return ok
+	}
+
 	_, ok := msg[jsonThread]
+
 	return ok
 }
 
+// isV3 checks whether the message belongs to the present-proof 3.0 (DIDComm V2) protocol.
+func isV3(msg service.DIDCommMsg) bool {
+	return strings.HasPrefix(msg.Type(), SpecV3)
+}
+
 func (s *proposalSent) Execute(md *metaData) (state, stateAction, error) {
 	if !canReplyTo(md.Msg) {
 		return &noOp{}, forwardInitial(md), nil
 	}
 
+	if isV3(md.Msg) {
+		if md.proposePresentationV3 == nil {
+			return nil, nil, errors.New("propose-presentation was not provided")
+		}
+
+		return &noOp{}, func(messenger service.Messenger) error {
+			md.proposePresentationV3.Type = ProposePresentationMsgTypeV3
+			return messenger.ReplyToMsg(md.Msg, service.NewDIDCommMsgMap(md.proposePresentationV3), md.MyDID, md.TheirDID)
+		}, nil
+	}
+
 	if md.proposePresentation == nil {
 		return nil, nil, errors.New("propose-presentation was not provided")
 	}
@@ -132,6 +132,43 @@ func TestAbandoning_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Internal Error (V3)", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = randomInboundMessageV3(PresentationMsgTypeV3)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NotNil(t, action)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().
+			ReplyToNested(gomock.Any(), gomock.Any()).
+			Do(func(msg service.DIDCommMsgMap, opts *service.NestedReplyOpts) error {
+				r := &model.ProblemReportV2{}
+				require.NoError(t, msg.Decode(r))
+				require.Equal(t, codeInternalError, r.Body.Code)
+				require.Equal(t, ProblemReportMsgTypeV3, r.Type)
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Problem report received (V3)", func(t *testing.T) {
+		md := &metaData{}
+		md.Msg = randomInboundMessageV3(ProblemReportMsgTypeV3)
+
+		followup, action, err := (&abandoned{Code: codeInternalError}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.NoError(t, action(nil))
+	})
+
 	t.Run("No error code", func(t *testing.T) {
 		md := &metaData{}
 		md.Msg = service.NewDIDCommMsgMap(struct{}{})
@@ -237,6 +274,45 @@ func TestRequestReceived_Execute(t *testing.T) {
 	})
 }
 
+func TestRequestReceived_ExecuteV3(t *testing.T) {
+	t.Run("With presentation", func(t *testing.T) {
+		msg := randomInboundMessageV3(RequestPresentationMsgTypeV3)
+		msg["body"] = map[string]interface{}{"will_confirm": true}
+
+		followup, action, err := (&requestReceived{}).Execute(&metaData{
+			presentationV3:      &PresentationV3{},
+			transitionalPayload: transitionalPayload{Action: Action{Msg: msg}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &presentationSent{WillConfirm: true}, followup)
+		require.NoError(t, action(nil))
+	})
+
+	t.Run("Without presentation", func(t *testing.T) {
+		followup, action, err := (&requestReceived{}).Execute(&metaData{
+			presentation: &Presentation{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &proposalSent{}, followup)
+		require.NoError(t, action(nil))
+	})
+
+	t.Run("Message decode error", func(t *testing.T) {
+		followup, action, err := (&requestReceived{}).Execute(&metaData{
+			presentationV3: &PresentationV3{},
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: service.DIDCommMsgMap{"type": RequestPresentationMsgTypeV3, "body": "invalid"},
+			}},
+		})
+		require.Error(t, err)
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
 func TestRequestSent_CanTransitionTo(t *testing.T) {
 	st := &requestSent{}
 	require.Equal(t, stateNameRequestSent, st.Name())
@@ -267,6 +343,89 @@ func randomInboundMessage(t string) service.DIDCommMsgMap {
 	})
 }
 
+func randomInboundMessageV3(t string) service.DIDCommMsgMap {
+	return service.NewDIDCommMsgMap(struct {
+		ID       string `json:"id"`
+		ThreadID string `json:"thid"`
+		Type     string `json:"type"`
+	}{
+		ID:       uuid.New().String(),
+		ThreadID: uuid.New().String(),
+		Type:     t,
+	})
+}
+
+func TestRequestSent_ExecuteV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		md := &metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(ProposePresentationMsgTypeV3),
+			}},
+			requestV3: &RequestPresentationV3{Body: RequestPresentationV3Body{WillConfirm: true}},
+		}
+
+		followup, action, err := (&requestSent{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.True(t, md.AckRequired)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, RequestPresentationMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Request is absent", func(t *testing.T) {
+		followup, action, err := (&requestSent{}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(ProposePresentationMsgTypeV3),
+			}},
+		})
+		require.EqualError(t, err, "request was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+
+	t.Run("Success (outbound)", func(t *testing.T) {
+		md := &metaData{transitionalPayload: transitionalPayload{
+			Action: Action{Msg: service.NewDIDCommMsgMap(RequestPresentationV3{
+				Type: RequestPresentationMsgTypeV3,
+				Body: RequestPresentationV3Body{WillConfirm: true},
+			})},
+		}}
+
+		followup, action, err := (&requestSent{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.True(t, md.AckRequired)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any())
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Message decode error", func(t *testing.T) {
+		followup, action, err := (&requestSent{}).Execute(&metaData{transitionalPayload: transitionalPayload{
+			Action: Action{Msg: service.DIDCommMsgMap{"type": RequestPresentationMsgTypeV3, "body": "invalid"}},
+		}})
+		require.Error(t, err)
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
 func TestRequestSent_Execute(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		followup, action, err := (&requestSent{}).Execute(&metaData{
@@ -314,6 +473,23 @@ func TestRequestSent_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Success (connection-less)", func(t *testing.T) {
+		md := &metaData{transitionalPayload: transitionalPayload{
+			Action: Action{Msg: service.DIDCommMsgMap{
+				"will_confirm": true,
+				"~service":     map[string]interface{}{"serviceEndpoint": "https://verifier.example.com"},
+			}},
+		}}
+
+		followup, action, err := (&requestSent{}).Execute(md)
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+		require.False(t, md.AckRequired)
+
+		// the request is not sent, it is delivered within an out-of-band invitation
+		require.NoError(t, action(serviceMocks.NewMockMessenger(gomock.NewController(t))))
+	})
+
 	t.Run("Message decode error", func(t *testing.T) {
 		followup, action, err := (&requestSent{}).Execute(&metaData{transitionalPayload: transitionalPayload{
 			Action: Action{Msg: service.DIDCommMsgMap{"@type": []int{1}}},
@@ -375,6 +551,39 @@ func TestPresentationSent_Execute(t *testing.T) {
 		require.NoError(t, action(messenger))
 	})
 
+	t.Run("Success (connection-less)", func(t *testing.T) {
+		request := randomInboundMessage(RequestPresentationMsgType)
+		request["~service"] = map[string]interface{}{
+			"recipientKeys":   []string{"did:key:verifier"},
+			"routingKeys":     []string{"did:key:router"},
+			"serviceEndpoint": "https://verifier.example.com",
+		}
+
+		followup, action, err := (&presentationSent{}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{Msg: request, MyDID: "did:key:holder"}},
+			presentation:        &Presentation{},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &done{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		destMessenger := serviceMocks.NewMockDestinationMessenger(ctrl)
+		destMessenger.EXPECT().ReplyToMsgToDestination(request, gomock.Any(), "did:key:holder", &service.Destination{
+			RecipientKeys:   []string{"did:key:verifier"},
+			RoutingKeys:     []string{"did:key:router"},
+			ServiceEndpoint: "https://verifier.example.com",
+		})
+
+		require.NoError(t, action(struct {
+			service.Messenger
+			service.DestinationMessenger
+		}{serviceMocks.NewMockMessenger(ctrl), destMessenger}))
+		require.EqualError(t, action(serviceMocks.NewMockMessenger(ctrl)),
+			"the messenger cannot reply to a message received without a connection")
+	})
+
 	t.Run("Presentation is absent", func(t *testing.T) {
 		followup, action, err := (&presentationSent{}).Execute(&metaData{})
 		require.EqualError(t, err, "presentation was not provided")
@@ -383,6 +592,44 @@ func TestPresentationSent_Execute(t *testing.T) {
 	})
 }
 
+func TestPresentationSent_ExecuteV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&presentationSent{WillConfirm: true}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			}},
+			presentationV3: &PresentationV3{},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, PresentationMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Presentation is absent", func(t *testing.T) {
+		followup, action, err := (&presentationSent{}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			}},
+			presentation: &Presentation{},
+		})
+		require.EqualError(t, err, "presentation was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
 func TestPresentationReceived_CanTransitionTo(t *testing.T) {
 	st := &presentationReceived{}
 	require.Equal(t, stateNamePresentationReceived, st.Name())
@@ -432,6 +679,32 @@ func TestPresentationReceived_Execute(t *testing.T) {
 	})
 }
 
+func TestPresentationReceived_ExecuteV3(t *testing.T) {
+	followup, action, err := (&presentationReceived{}).Execute(&metaData{
+		transitionalPayload: transitionalPayload{
+			AckRequired: true,
+			Action:      Action{Msg: randomInboundMessageV3(PresentationMsgTypeV3)},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &done{}, followup)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messenger := serviceMocks.NewMockMessenger(ctrl)
+	messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+			r := &model.AckV2{}
+			require.NoError(t, msg.Decode(r))
+			require.Equal(t, AckMsgTypeV3, r.Type)
+
+			return nil
+		})
+
+	require.NoError(t, action(messenger))
+}
+
 func TestProposePresentationSent_CanTransitionTo(t *testing.T) {
 	st := &proposalSent{}
 	require.Equal(t, stateNameProposalSent, st.Name())
@@ -494,6 +767,44 @@ func TestProposePresentationSent_Execute(t *testing.T) {
 	})
 }
 
+func TestProposePresentationSent_ExecuteV3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		followup, action, err := (&proposalSent{}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			}},
+			proposePresentationV3: &ProposePresentationV3{},
+		})
+		require.NoError(t, err)
+		require.Equal(t, &noOp{}, followup)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_, msg service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, ProposePresentationMsgTypeV3, msg.Type())
+
+				return nil
+			})
+
+		require.NoError(t, action(messenger))
+	})
+
+	t.Run("Propose presentation is absent", func(t *testing.T) {
+		followup, action, err := (&proposalSent{}).Execute(&metaData{
+			transitionalPayload: transitionalPayload{Action: Action{
+				Msg: randomInboundMessageV3(RequestPresentationMsgTypeV3),
+			}},
+			proposePresentation: &ProposePresentation{},
+		})
+		require.EqualError(t, err, "propose-presentation was not provided")
+		require.Nil(t, followup)
+		require.Nil(t, action)
+	})
+}
+
 func TestProposePresentationReceived_CanTransitionTo(t *testing.T) {
 	st := &proposalReceived{}
 	require.Equal(t, stateNameProposalReceived, st.Name())
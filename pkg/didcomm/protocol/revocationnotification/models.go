@@ -0,0 +1,22 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+
+// Revoke is sent by the issuer to notify the holder that a credential was revoked.
+// https://github.com/hyperledger/aries-rfcs/tree/main/features/0721-revocation-notification-v2
+type Revoke struct {
+	ID   string `json:"@id,omitempty"`
+	Type string `json:"@type,omitempty"`
+	// RevocationFormat is the format of the revocation (e.g. "anoncreds"), it defines the format of CredentialID.
+	RevocationFormat string `json:"revocation_format,omitempty"`
+	// CredentialID identifies the revoked credential, i.e. the ID of the credential stored by the holder.
+	CredentialID string               `json:"credential_id,omitempty"`
+	Comment      string               `json:"comment,omitempty"`
+	PleaseAck    *decorator.PleaseAck `json:"~please_ack,omitempty"`
+}
@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	// Name of this protocol service.
+	Name = "revocation-notification"
+	// PIURI is the Revocation Notification protocol's protocol instance URI.
+	PIURI = "https://didcomm.org/revocation_notification/2.0"
+	// RevokeMsgType is the '@type' for the revoke message.
+	RevokeMsgType = PIURI + "/revoke"
+
+	// StateIDRevoked is the state of the message event triggered once the revoked credential is recorded.
+	StateIDRevoked = "revoked"
+)
+
+var logger = log.New(fmt.Sprintf("aries-framework/%s/service", Name))
+
+// Provider provides this service's dependencies.
+type Provider interface {
+	Messenger() service.Messenger
+	VerifiableStore() verifiable.Store
+}
+
+// Service implements the Revocation Notification protocol.
+// The issuer notifies the holder that a credential was revoked, the holder flags the stored credential as revoked
+// and triggers a message event (see RevokedEvent) for the application.
+type Service struct {
+	service.Message
+	messenger service.Messenger
+	store     verifiable.Store
+}
+
+// New creates a new instance of the Revocation Notification service.
+func New(p Provider) (*Service, error) {
+	store := p.VerifiableStore()
+	if store == nil {
+		return nil, errors.New("verifiable store is not provided")
+	}
+
+	return &Service{
+		messenger: p.Messenger(),
+		store:     store,
+	}, nil
+}
+
+// Name is this service's name.
+func (s *Service) Name() string {
+	return Name
+}
+
+// Accept determines whether this service can handle the given type of message.
+func (s *Service) Accept(msgType string) bool {
+	return msgType == RevokeMsgType
+}
+
+// HandleInbound records the revocation of the credential the revoke message refers to.
+func (s *Service) HandleInbound(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	if msg.Type() != RevokeMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	revoke := Revoke{}
+
+	if err := msg.Decode(&revoke); err != nil {
+		return "", fmt.Errorf("decode revoke message: %w", err)
+	}
+
+	if revoke.CredentialID == "" {
+		return "", errors.New("credential_id is empty")
+	}
+
+	revocations, ok := s.store.(verifiable.RevocationStore)
+	if !ok {
+		return "", errors.New("verifiable store does not support revocation")
+	}
+
+	if err := s.checkIssuer(revoke.CredentialID, ctx.TheirDID()); err != nil {
+		return "", err
+	}
+
+	if err := revocations.MarkCredentialRevoked(revoke.CredentialID); err != nil {
+		return "", fmt.Errorf("mark credential %s revoked: %w", revoke.CredentialID, err)
+	}
+
+	logger.Debugf("credential %s revoked by %s", revoke.CredentialID, ctx.TheirDID())
+
+	props := &RevokedEvent{
+		MyDID:            ctx.MyDID(),
+		TheirDID:         ctx.TheirDID(),
+		CredentialID:     revoke.CredentialID,
+		RevocationFormat: revoke.RevocationFormat,
+		Comment:          revoke.Comment,
+	}
+
+	s.PublishMsgEvent(service.StateMsg{
+		ProtocolName: Name,
+		Type:         service.PostState,
+		StateID:      StateIDRevoked,
+		Msg:          msg,
+		Properties:   props,
+	})
+
+	return msg.ID(), nil
+}
+
+// checkIssuer checks that the credential was issued over the connection with theirDID, only its issuer can
+// notify the holder that it is revoked.
+func (s *Service) checkIssuer(credentialID, theirDID string) error {
+	records, err := s.store.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("get credential records: %w", err)
+	}
+
+	found := false
+
+	for _, r := range records {
+		if r.ID != credentialID {
+			continue
+		}
+
+		if r.TheirDID == theirDID && theirDID != "" {
+			return nil
+		}
+
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("credential %s is not stored", credentialID)
+	}
+
+	return fmt.Errorf("credential %s was not issued by %s", credentialID, theirDID)
+}
+
+// HandleOutbound sends the revoke message to the holder of the revoked credential.
+func (s *Service) HandleOutbound(msg service.DIDCommMsg, myDID, theirDID string) (string, error) {
+	if msg.Type() != RevokeMsgType {
+		return "", fmt.Errorf("unsupported message type %s", msg.Type())
+	}
+
+	msgMap, ok := msg.(service.DIDCommMsgMap)
+	if !ok {
+		return "", errors.New("unsupported message: expected service.DIDCommMsgMap")
+	}
+
+	if err := s.messenger.Send(msgMap, myDID, theirDID); err != nil {
+		return "", fmt.Errorf("send revoke message: %w", err)
+	}
+
+	return msgMap.ID(), nil
+}
+
+// RevokedEvent are the properties of the message event triggered once the revoked credential is recorded.
+type RevokedEvent struct {
+	MyDID            string
+	TheirDID         string
+	CredentialID     string
+	RevocationFormat string
+	Comment          string
+}
+
+// All implements EventProperties interface.
+func (e *RevokedEvent) All() map[string]interface{} {
+	all := map[string]interface{}{
+		"myDID":        e.MyDID,
+		"theirDID":     e.TheirDID,
+		"credentialID": e.CredentialID,
+	}
+
+	if e.RevocationFormat != "" {
+		all["revocationFormat"] = e.RevocationFormat
+	}
+
+	if e.Comment != "" {
+		all["comment"] = e.Comment
+	}
+
+	return all
+}
@@ -0,0 +1,275 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revocationnotification
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
+	verifiableMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+)
+
+const (
+	credentialName = "driving-license"
+	credentialID   = "http://example.gov/credentials/3732"
+	myDID          = "did:example:holder"
+	theirDID       = "did:example:issuer"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("returns the service", func(t *testing.T) {
+		s, err := New(&provider{store: newStore(t)})
+		require.NoError(t, err)
+		require.Equal(t, Name, s.Name())
+		require.True(t, s.Accept(RevokeMsgType))
+		require.False(t, s.Accept(PIURI+"/ack"))
+	})
+
+	t.Run("verifiable store is not provided", func(t *testing.T) {
+		_, err := New(&provider{})
+		require.EqualError(t, err, "verifiable store is not provided")
+	})
+}
+
+func TestService_HandleInbound(t *testing.T) {
+	t.Run("marks the stored credential revoked", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.SaveCredential(credentialName, &verifiable.Credential{ID: credentialID},
+			storeverifiable.WithTheirDID(theirDID)))
+		require.NoError(t, store.SaveCredential("other", &verifiable.Credential{ID: "http://example.gov/credentials/1"},
+			storeverifiable.WithTheirDID(theirDID)))
+
+		s, err := New(&provider{store: store})
+		require.NoError(t, err)
+
+		events := make(chan service.StateMsg, 1)
+		require.NoError(t, s.RegisterMsgEvent(events))
+
+		msg := service.NewDIDCommMsgMap(&Revoke{
+			ID:               "revoke-1",
+			Type:             RevokeMsgType,
+			RevocationFormat: "w3c",
+			CredentialID:     credentialID,
+			Comment:          "issued by mistake",
+		})
+
+		id, err := s.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+		require.NoError(t, err)
+		require.Equal(t, "revoke-1", id)
+
+		records, err := store.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		for _, r := range records {
+			require.Equal(t, r.ID == credentialID, r.Revoked)
+		}
+
+		select {
+		case event := <-events:
+			require.Equal(t, Name, event.ProtocolName)
+			require.Equal(t, service.PostState, event.Type)
+			require.Equal(t, StateIDRevoked, event.StateID)
+			require.Equal(t, msg, event.Msg)
+			require.Equal(t, map[string]interface{}{
+				"myDID":            myDID,
+				"theirDID":         theirDID,
+				"credentialID":     credentialID,
+				"revocationFormat": "w3c",
+				"comment":          "issued by mistake",
+			}, event.Properties.All())
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the revoked event")
+		}
+	})
+
+	t.Run("credential is not stored", func(t *testing.T) {
+		s, err := New(&provider{store: newStore(t)})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "credential "+credentialID+" is not stored")
+	})
+
+	t.Run("not notified by the issuer", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.SaveCredential(credentialName, &verifiable.Credential{ID: credentialID},
+			storeverifiable.WithTheirDID(theirDID)))
+
+		s, err := New(&provider{store: store})
+		require.NoError(t, err)
+
+		for _, sender := range []string{"did:example:other", ""} {
+			_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+				Type:         RevokeMsgType,
+				CredentialID: credentialID,
+			}), service.NewDIDCommContext(myDID, sender, nil))
+			require.EqualError(t, err, "credential "+credentialID+" was not issued by "+sender)
+		}
+
+		records, err := store.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.False(t, records[0].Revoked)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, store.SaveCredential(credentialName, &verifiable.Credential{ID: credentialID},
+			storeverifiable.WithTheirDID(theirDID)))
+
+		s, err := New(&provider{store: &revocationStore{Store: store, markErr: errors.New("test")}})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "mark credential "+credentialID+" revoked: test")
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStore := verifiableMocks.NewMockStore(ctrl)
+		mockStore.EXPECT().GetCredentials().Return(nil, errors.New("test"))
+
+		s, err = New(&provider{store: &revocationStore{Store: mockStore}})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "get credential records: test")
+	})
+
+	t.Run("store does not support revocation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s, err := New(&provider{store: verifiableMocks.NewMockStore(ctrl)})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		}), service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "verifiable store does not support revocation")
+	})
+
+	t.Run("missing credential ID", func(t *testing.T) {
+		s, err := New(&provider{store: newStore(t)})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{Type: RevokeMsgType}),
+			service.NewDIDCommContext(myDID, theirDID, nil))
+		require.EqualError(t, err, "credential_id is empty")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		s, err := New(&provider{store: newStore(t)})
+		require.NoError(t, err)
+
+		_, err = s.HandleInbound(service.NewDIDCommMsgMap(&Revoke{Type: "unknown"}), service.EmptyDIDCommContext())
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+func TestService_HandleOutbound(t *testing.T) {
+	t.Run("sends the revoke message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		msg := service.NewDIDCommMsgMap(&Revoke{
+			ID:           "revoke-1",
+			Type:         RevokeMsgType,
+			CredentialID: credentialID,
+		})
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(msg, theirDID, myDID).Return(nil)
+
+		s, err := New(&provider{store: newStore(t), messenger: messenger})
+		require.NoError(t, err)
+
+		id, err := s.HandleOutbound(msg, theirDID, myDID)
+		require.NoError(t, err)
+		require.Equal(t, "revoke-1", id)
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		messenger := serviceMocks.NewMockMessenger(ctrl)
+		messenger.EXPECT().Send(gomock.Any(), theirDID, myDID).Return(errors.New("test"))
+
+		s, err := New(&provider{store: newStore(t), messenger: messenger})
+		require.NoError(t, err)
+
+		_, err = s.HandleOutbound(service.NewDIDCommMsgMap(&Revoke{Type: RevokeMsgType}), theirDID, myDID)
+		require.EqualError(t, err, "send revoke message: test")
+	})
+
+	t.Run("unsupported message type", func(t *testing.T) {
+		s, err := New(&provider{store: newStore(t)})
+		require.NoError(t, err)
+
+		_, err = s.HandleOutbound(service.NewDIDCommMsgMap(&Revoke{Type: "unknown"}), theirDID, myDID)
+		require.EqualError(t, err, "unsupported message type unknown")
+	})
+}
+
+type provider struct {
+	messenger service.Messenger
+	store     storeverifiable.Store
+}
+
+func (p *provider) Messenger() service.Messenger {
+	return p.messenger
+}
+
+func (p *provider) VerifiableStore() storeverifiable.Store {
+	return p.store
+}
+
+func newStore(t *testing.T) storeverifiable.Store {
+	t.Helper()
+
+	store, err := storeverifiable.New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+	})
+	require.NoError(t, err)
+
+	return store
+}
+
+type revocationStore struct {
+	storeverifiable.Store
+	markErr error
+}
+
+func (s *revocationStore) MarkCredentialRevoked(id string) error {
+	if s.markErr != nil {
+		return s.markErr
+	}
+
+	return s.Store.(storeverifiable.RevocationStore).MarkCredentialRevoked(id)
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/rs/cors"
 
@@ -21,7 +22,24 @@ import (
 
 var logger = log.New("aries-framework/http")
 
-// TODO https://github.com/hyperledger/aries-framework-go/issues/891 Support for Transport Return Route (Duplex)
+const defaultReturnRouteTimeout = 5 * time.Second
+
+// inboundCommHTTPOpts holds options for the HTTP inbound transport.
+type inboundCommHTTPOpts struct {
+	returnRouteTimeout time.Duration
+}
+
+// InboundHTTPOpt is an inbound HTTP transport option.
+type InboundHTTPOpt func(opts *inboundCommHTTPOpts)
+
+// WithReturnRouteTimeout option sets how long the response to a request with the return route option
+// ("~transport": {"return_route": "all"}) waits for a message to the sender, 5 seconds by default.
+// The response has no body (202 Accepted) if no message is sent in time.
+func WithReturnRouteTimeout(timeout time.Duration) InboundHTTPOpt {
+	return func(opts *inboundCommHTTPOpts) {
+		opts.returnRouteTimeout = timeout
+	}
+}
 
 // NewInboundHandler will create a new handler to enforce Did-Comm HTTP transport specs
 // then routes processing to the mandatory 'msgHandler' argument.
@@ -29,20 +47,32 @@ var logger = log.New("aries-framework/http")
 // Arguments:
 // * 'msgHandler' is the handler function that will be executed with the inbound request payload.
 //    Users of this library must manage the handling of all inbound payloads in this function.
-func NewInboundHandler(prov transport.Provider) (http.Handler, error) {
+//
+// The first message to the sender of a request with the return route option is written as the response body of the
+// request (instead of being sent to the sender's endpoint), provided it is sent by the HTTP outbound transport.
+func NewInboundHandler(prov transport.Provider, opts ...InboundHTTPOpt) (http.Handler, error) {
 	if prov == nil || prov.InboundMessageHandler() == nil {
 		logger.Errorf("Error creating a new inbound handler: message handler function is nil")
 		return nil, errors.New("creation of inbound handler failed")
 	}
 
+	inOpts := &inboundCommHTTPOpts{returnRouteTimeout: defaultReturnRouteTimeout}
+
+	for _, opt := range opts {
+		opt(inOpts)
+	}
+
+	pool := getReturnRoutePool(prov)
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		processPOSTRequest(w, r, prov)
+		processPOSTRequest(w, r, prov, pool, inOpts.returnRouteTimeout)
 	})
 
 	return cors.Default().Handler(handler), nil
 }
 
-func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider) {
+func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.Provider, pool *returnRoutePool,
+	returnRouteTimeout time.Duration) {
 	if valid := validateHTTPMethod(w, r); !valid {
 		return
 	}
@@ -67,6 +97,13 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		return
 	}
 
+	var response chan []byte
+
+	if key, ok := getReturnRouteKey(unpackMsg); ok {
+		response = pool.add(key)
+		defer pool.remove(key, response)
+	}
+
 	messageHandler := prov.InboundMessageHandler()
 
 	err = messageHandler(unpackMsg)
@@ -75,8 +112,33 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, prov transport.P
 		//  from service
 		logger.Errorf("incoming msg processing failed: %s", err)
 		w.WriteHeader(http.StatusInternalServerError)
-	} else {
+
+		return
+	}
+
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+
+		return
+	}
+
+	writeReturnRouteResponse(w, r, response, returnRouteTimeout)
+}
+
+// writeReturnRouteResponse writes the message to the sender of the request as the response body, if any is sent
+// before the timeout.
+func writeReturnRouteResponse(w http.ResponseWriter, r *http.Request, response chan []byte, timeout time.Duration) {
+	select {
+	case msg := <-response:
+		w.Header().Set("Content-Type", commContentType)
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := w.Write(msg); err != nil {
+			logger.Errorf("failed to write return route response: %s", err)
+		}
+	case <-time.After(timeout):
 		w.WriteHeader(http.StatusAccepted)
+	case <-r.Context().Done():
 	}
 }
 
@@ -112,10 +174,11 @@ type Inbound struct {
 	externalAddr      string
 	server            *http.Server
 	certFile, keyFile string
+	opts              []InboundHTTPOpt
 }
 
 // NewInbound creates a new HTTP inbound transport instance.
-func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound, error) {
+func NewInbound(internalAddr, externalAddr, certFile, keyFile string, opts ...InboundHTTPOpt) (*Inbound, error) {
 	if internalAddr == "" {
 		return nil, errors.New("http address is mandatory")
 	}
@@ -129,12 +192,13 @@ func NewInbound(internalAddr, externalAddr, certFile, keyFile string) (*Inbound,
 		keyFile:      keyFile,
 		externalAddr: externalAddr,
 		server:       &http.Server{Addr: internalAddr},
+		opts:         opts,
 	}, nil
 }
 
 // Start the http server.
 func (i *Inbound) Start(prov transport.Provider) error {
-	handler, err := NewInboundHandler(prov)
+	handler, err := NewInboundHandler(prov, i.opts...)
 	if err != nil {
 		return fmt.Errorf("HTTP server start failed: %w", err)
 	}
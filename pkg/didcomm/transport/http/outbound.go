@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
@@ -61,8 +62,13 @@ func WithOutboundTLSConfig(tlsConfig *tls.Config) OutboundHTTPOpt {
 }
 
 // OutboundHTTPClient represents the Outbound HTTP transport instance.
+// Once started, the messages of the thread of the inbound requests with the return route option to their senders are
+// written as the responses of the requests, and the messages returned in the responses to the requests sent with the return
+// route option are handed to the inbound message handler.
 type OutboundHTTPClient struct {
 	client *http.Client
+	prov   transport.Provider
+	pool   *returnRoutePool
 }
 
 // NewOutbound creates a new instance of Outbound HTTP transport to Post requests to other Agents.
@@ -87,11 +93,20 @@ func NewOutbound(opts ...OutboundHTTPOpt) (*OutboundHTTPClient, error) {
 
 // Start starts outbound transport.
 func (cs *OutboundHTTPClient) Start(prov transport.Provider) error {
+	cs.prov = prov
+	cs.pool = getReturnRoutePool(prov)
+
 	return nil
 }
 
 // Send sends a2a exchange data via HTTP (client side).
 func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination) (string, error) {
+	// messages to be forwarded by a mediator are sent to the mediator.
+	if cs.pool != nil && len(destination.RoutingKeys) == 0 &&
+		cs.pool.deliver(destination.RecipientKeys, destination.ThreadID, data) {
+		return "", nil
+	}
+
 	resp, err := cs.client.Post(destination.ServiceEndpoint, commContentType, bytes.NewBuffer(data))
 	if err != nil {
 		logger.Errorf("posting DID envelope to agent failed [%s, %v]", destination.ServiceEndpoint, err)
@@ -126,14 +141,40 @@ func (cs *OutboundHTTPClient) Send(data []byte, destination *service.Destination
 			return "", fmt.Errorf("received unsuccessful POST HTTP status from agent "+
 				"[%s, %v %s]", destination.ServiceEndpoint, resp.Status, respData)
 		}
+
+		if resp.StatusCode == http.StatusOK && buf.Len() > 0 &&
+			destination.TransportReturnRoute == decorator.TransportReturnRouteAll {
+			go cs.handleReturnRoute(buf.Bytes())
+		}
 	}
 
 	return respData, nil
 }
 
-// AcceptRecipient checks if there is a connection for the list of recipient keys.
-func (cs *OutboundHTTPClient) AcceptRecipient([]string) bool {
-	return false
+// handleReturnRoute hands the message returned in the response to the inbound message handler.
+func (cs *OutboundHTTPClient) handleReturnRoute(msg []byte) {
+	if cs.prov == nil {
+		logger.Warnf("return route response dropped: outbound transport is not started")
+
+		return
+	}
+
+	unpackMsg, err := cs.prov.Packager().UnpackMessage(msg)
+	if err != nil {
+		logger.Errorf("failed to unpack return route response: %v", err)
+
+		return
+	}
+
+	if err = cs.prov.InboundMessageHandler()(unpackMsg); err != nil {
+		logger.Errorf("return route response processing failed: %v", err)
+	}
+}
+
+// AcceptRecipient checks if there is a request with the return route option from one of the recipient keys
+// waiting for its response.
+func (cs *OutboundHTTPClient) AcceptRecipient(keys []string) bool {
+	return cs.pool != nil && cs.pool.accept(keys)
 }
 
 // Accept url.
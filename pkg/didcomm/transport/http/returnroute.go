@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// returnRoutePoolIdleTimeout is how long the return route pool of a framework is kept without being used once the
+// inbound and outbound transports of the framework got it.
+const returnRoutePoolIdleTimeout = 10 * time.Minute
+
+// returnRouteKey identifies the response to an inbound request with the return route option: it is waiting for a
+// message of the thread of the request to the sender of the request.
+type returnRouteKey struct {
+	senderKey string
+	threadID  string
+}
+
+// returnRoutePool holds the HTTP responses of the inbound requests with the return route option, waiting for a
+// message to the sender of the request.
+type returnRoutePool struct {
+	responses map[returnRouteKey]chan []byte
+	lastUsed  time.Time
+	sync.Mutex
+}
+
+// nolint: gochecknoglobals
+var (
+	returnRoutePools     = make(map[string]*returnRoutePool)
+	returnRoutePoolsLock sync.Mutex
+)
+
+// getReturnRoutePool returns the return route pool shared by the inbound and outbound transports of the framework.
+// The pools of the other frameworks idle for returnRoutePoolIdleTimeout are removed.
+func getReturnRoutePool(prov transport.Provider) *returnRoutePool {
+	returnRoutePoolsLock.Lock()
+	defer returnRoutePoolsLock.Unlock()
+
+	now := time.Now()
+
+	for id, pool := range returnRoutePools {
+		if pool.idleSince(now.Add(-returnRoutePoolIdleTimeout)) {
+			delete(returnRoutePools, id)
+		}
+	}
+
+	id := prov.AriesFrameworkID()
+
+	if _, ok := returnRoutePools[id]; !ok {
+		returnRoutePools[id] = &returnRoutePool{responses: make(map[returnRouteKey]chan []byte)}
+	}
+
+	returnRoutePools[id].touch()
+
+	return returnRoutePools[id]
+}
+
+// idleSince checks if the pool has no response waiting and was last used before t.
+func (p *returnRoutePool) idleSince(t time.Time) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	return len(p.responses) == 0 && p.lastUsed.Before(t)
+}
+
+func (p *returnRoutePool) touch() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.lastUsed = time.Now()
+}
+
+// add registers the response waiting for a message to the key.
+func (p *returnRoutePool) add(key returnRouteKey) chan []byte {
+	p.Lock()
+	defer p.Unlock()
+
+	p.lastUsed = time.Now()
+
+	response := make(chan []byte, 1)
+	p.responses[key] = response
+
+	return response
+}
+
+// remove unregisters the response if it is still waiting for a message to the key.
+func (p *returnRoutePool) remove(key returnRouteKey, response chan []byte) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.responses[key] == response {
+		delete(p.responses, key)
+	}
+}
+
+// accept checks if there is a response waiting for a message to one of the recipient keys.
+func (p *returnRoutePool) accept(recipientKeys []string) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	for key := range p.responses {
+		for _, recipientKey := range recipientKeys {
+			if key.senderKey == recipientKey {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// deliver writes the message of the thread to the response waiting for a message of the thread to one of the
+// recipient keys. The response is sent as soon as it gets the message, the next messages to the recipient are sent
+// to the destination endpoint.
+func (p *returnRoutePool) deliver(recipientKeys []string, threadID string, msg []byte) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	p.lastUsed = time.Now()
+
+	for _, recipientKey := range recipientKeys {
+		key := returnRouteKey{senderKey: recipientKey, threadID: threadID}
+
+		if response, ok := p.responses[key]; ok {
+			delete(p.responses, key)
+
+			response <- msg
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// getReturnRouteKey returns the key the response to the inbound message is to be delivered for, if the message asks
+// for the responses to be returned over the same request. Messages of anonymous senders can't be answered over the
+// request, as the messages to them can't be matched with their key.
+func getReturnRouteKey(envelope *transport.Envelope) (returnRouteKey, bool) {
+	if len(envelope.FromKey) == 0 {
+		return returnRouteKey{}, false
+	}
+
+	trans := &decorator.Transport{}
+
+	if err := json.Unmarshal(envelope.Message, trans); err != nil {
+		return returnRouteKey{}, false
+	}
+
+	if trans.ReturnRoute == nil || trans.ReturnRoute.Value != decorator.TransportReturnRouteAll {
+		return returnRouteKey{}, false
+	}
+
+	msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+	if err != nil {
+		return returnRouteKey{}, false
+	}
+
+	threadID, err := msg.ThreadID()
+	if err != nil {
+		return returnRouteKey{}, false
+	}
+
+	didKey, _ := fingerprint.CreateDIDKey(envelope.FromKey)
+
+	return returnRouteKey{senderKey: didKey, threadID: threadID}, true
+}
@@ -0,0 +1,289 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package http
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+func TestReturnRoute(t *testing.T) {
+	t.Run("did-exchange response returned over the request", func(t *testing.T) {
+		requesterKey := newSenderKey(t)
+		requesterDIDKey, _ := fingerprint.CreateDIDKey(requesterKey)
+		responderKey := newSenderKey(t)
+
+		// the responder replies to the request with the outbound transport, the requester has no endpoint.
+		responderOutbound, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}))
+		require.NoError(t, err)
+
+		responder := &returnRouteProvider{id: t.Name() + "-responder"}
+		responder.handler = func(envelope *transport.Envelope) error {
+			request := &didexchange.Request{}
+			require.NoError(t, json.Unmarshal(envelope.Message, request))
+			require.Equal(t, didexchange.RequestMsgType, request.Type)
+
+			response, e := json.Marshal(&didexchange.Response{
+				Type:   didexchange.ResponseMsgType,
+				ID:     "response-1",
+				Thread: &decorator.Thread{ID: request.ID},
+				DID:    "did:example:responder",
+			})
+			require.NoError(t, e)
+
+			packed, e := (&plainPackager{}).PackMessage(&transport.Envelope{Message: response, FromKey: responderKey})
+			require.NoError(t, e)
+
+			keys := []string{requesterDIDKey}
+			require.True(t, responderOutbound.AcceptRecipient(keys))
+
+			_, e = responderOutbound.Send(packed, &service.Destination{RecipientKeys: keys, ThreadID: request.ID})
+
+			return e
+		}
+
+		require.NoError(t, responderOutbound.Start(responder))
+
+		handler, err := NewInboundHandler(responder)
+		require.NoError(t, err)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		requesterOutbound, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}))
+		require.NoError(t, err)
+
+		received := make(chan *transport.Envelope, 1)
+		requester := &returnRouteProvider{id: t.Name() + "-requester", handler: func(envelope *transport.Envelope) error {
+			received <- envelope
+
+			return nil
+		}}
+
+		require.NoError(t, requesterOutbound.Start(requester))
+
+		_, err = requesterOutbound.Send(newRequest(t, requesterKey, "request-1", decorator.TransportReturnRouteAll),
+			&service.Destination{
+				ServiceEndpoint:      server.URL,
+				TransportReturnRoute: decorator.TransportReturnRouteAll,
+			})
+		require.NoError(t, err)
+
+		select {
+		case envelope := <-received:
+			response := &didexchange.Response{}
+			require.NoError(t, json.Unmarshal(envelope.Message, response))
+			require.Equal(t, didexchange.ResponseMsgType, response.Type)
+			require.Equal(t, "request-1", response.Thread.ID)
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for the did-exchange response")
+		}
+
+		// the response was delivered, the next messages to the requester are sent to its endpoint.
+		require.False(t, responderOutbound.AcceptRecipient([]string{requesterDIDKey}))
+	})
+
+	t.Run("messages of other threads or to be forwarded are not returned over the request", func(t *testing.T) {
+		senderKey := newSenderKey(t)
+		senderDIDKey, _ := fingerprint.CreateDIDKey(senderKey)
+
+		prov := &returnRouteProvider{id: t.Name()}
+
+		outbound, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}))
+		require.NoError(t, err)
+		require.NoError(t, outbound.Start(prov))
+
+		endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer endpoint.Close()
+
+		prov.handler = func(*transport.Envelope) error {
+			require.True(t, outbound.AcceptRecipient([]string{senderDIDKey}))
+
+			// sent to the endpoint of the sender, the request is still waiting for its response.
+			for _, dest := range []*service.Destination{
+				{RecipientKeys: []string{senderDIDKey}, ThreadID: "other-thread", ServiceEndpoint: endpoint.URL},
+				{
+					RecipientKeys: []string{senderDIDKey}, RoutingKeys: []string{"did:key:mediator"},
+					ThreadID: "request-1", ServiceEndpoint: endpoint.URL,
+				},
+			} {
+				_, err = outbound.Send([]byte("message"), dest)
+				require.NoError(t, err)
+				require.True(t, outbound.AcceptRecipient([]string{senderDIDKey}))
+			}
+
+			return nil
+		}
+
+		handler, err := NewInboundHandler(prov, WithReturnRouteTimeout(time.Millisecond))
+		require.NoError(t, err)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		resp := post(t, server.URL, newRequest(t, senderKey, "request-1", decorator.TransportReturnRouteAll))
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("requests of anonymous senders are not answered over the request", func(t *testing.T) {
+		_, ok := getReturnRouteKey(&transport.Envelope{
+			Message: []byte(`{"@id": "request-1", "@type": "type", "~transport": {"~return_route": "all"}}`),
+		})
+		require.False(t, ok)
+
+		key, ok := getReturnRouteKey(&transport.Envelope{
+			Message: []byte(`{"@id": "request-1", "@type": "type", "~transport": {"~return_route": "all"}}`),
+			FromKey: newSenderKey(t),
+		})
+		require.True(t, ok)
+		require.Equal(t, "request-1", key.threadID)
+	})
+
+	t.Run("idle pools are removed", func(t *testing.T) {
+		pool := getReturnRoutePool(&returnRouteProvider{id: t.Name() + "-idle"})
+		pool.lastUsed = time.Now().Add(-2 * returnRoutePoolIdleTimeout)
+
+		busy := getReturnRoutePool(&returnRouteProvider{id: t.Name() + "-busy"})
+		busy.add(returnRouteKey{senderKey: "did:key:sender", threadID: "request-1"})
+		busy.lastUsed = time.Now().Add(-2 * returnRoutePoolIdleTimeout)
+
+		getReturnRoutePool(&returnRouteProvider{id: t.Name()})
+
+		returnRoutePoolsLock.Lock()
+		defer returnRoutePoolsLock.Unlock()
+
+		require.NotContains(t, returnRoutePools, t.Name()+"-idle")
+		require.Contains(t, returnRoutePools, t.Name()+"-busy")
+	})
+
+	t.Run("no message sent before the timeout", func(t *testing.T) {
+		handler, err := NewInboundHandler(&returnRouteProvider{id: t.Name()}, WithReturnRouteTimeout(time.Millisecond))
+		require.NoError(t, err)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		resp := post(t, server.URL, newRequest(t, newSenderKey(t), "request-1", decorator.TransportReturnRouteAll))
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+
+	t.Run("request without return route", func(t *testing.T) {
+		senderKey := newSenderKey(t)
+		senderDIDKey, _ := fingerprint.CreateDIDKey(senderKey)
+
+		prov := &returnRouteProvider{id: t.Name()}
+
+		outbound, err := NewOutbound(WithOutboundHTTPClient(&http.Client{}))
+		require.NoError(t, err)
+		require.NoError(t, outbound.Start(prov))
+
+		prov.handler = func(*transport.Envelope) error {
+			require.False(t, outbound.AcceptRecipient([]string{senderDIDKey}))
+
+			return nil
+		}
+
+		handler, err := NewInboundHandler(prov)
+		require.NoError(t, err)
+
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		resp := post(t, server.URL, newRequest(t, senderKey, "request-1", decorator.TransportReturnRouteNone))
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	})
+}
+
+// returnRouteProvider is a transport provider whose packager sends the messages as plain JSON, prefixed with the
+// sender key.
+type returnRouteProvider struct {
+	id      string
+	handler transport.InboundMessageHandler
+}
+
+func (p *returnRouteProvider) InboundMessageHandler() transport.InboundMessageHandler {
+	if p.handler == nil {
+		return func(*transport.Envelope) error { return nil }
+	}
+
+	return p.handler
+}
+
+func (p *returnRouteProvider) Packager() transport.Packager {
+	return &plainPackager{}
+}
+
+func (p *returnRouteProvider) AriesFrameworkID() string {
+	return p.id
+}
+
+type plainPackager struct{}
+
+func (p *plainPackager) PackMessage(envelope *transport.Envelope) ([]byte, error) {
+	return append(append([]byte{}, envelope.FromKey...), envelope.Message...), nil
+}
+
+func (p *plainPackager) UnpackMessage(encMessage []byte) (*transport.Envelope, error) {
+	return &transport.Envelope{
+		FromKey: encMessage[:ed25519.PublicKeySize],
+		Message: encMessage[ed25519.PublicKeySize:],
+	}, nil
+}
+
+func newSenderKey(t *testing.T) []byte {
+	t.Helper()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return pubKey
+}
+
+func newRequest(t *testing.T, senderKey []byte, id, returnRoute string) []byte {
+	t.Helper()
+
+	request := service.NewDIDCommMsgMap(&didexchange.Request{
+		Type:  didexchange.RequestMsgType,
+		ID:    id,
+		Label: "requester",
+	})
+	request["~transport"] = &decorator.ReturnRoute{Value: returnRoute}
+
+	msg, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	packed, err := (&plainPackager{}).PackMessage(&transport.Envelope{Message: msg, FromKey: senderKey})
+	require.NoError(t, err)
+
+	return packed
+}
+
+func post(t *testing.T, url string, data []byte) *http.Response {
+	t.Helper()
+
+	resp, err := http.Post(url, commContentType, bytes.NewBuffer(data)) // nolint: noctx
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	return resp
+}
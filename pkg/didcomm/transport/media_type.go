@@ -21,3 +21,20 @@ const (
 	// V1 plaintext payload as per Aries RFC 0587.
 	MediaTypeV2EncryptedEnvelopeV1PlaintextPayload = MediaTypeV2EncryptedEnvelope + ";cty=" + MediaTypeV1PlaintextPayload
 )
+
+const (
+	// MediaTypeProfileDIDCommAIP1 is the encryption envelope, signing mechanism, plaintext conventions,
+	// and routing algorithms embodied in Aries AIP 1.0, circa 2020. Defined in RFC 0044.
+	MediaTypeProfileDIDCommAIP1 = "didcomm/aip1"
+	// MediaTypeProfileDIDCommAIP2RFC19 is the signing mechanism, plaintext conventions, and routing
+	// algorithms embodied in Aries AIP 2.0, circa 2021 -- with the old-style encryption envelope from
+	// Aries RFC 0019. Defined in RFC 0044.
+	MediaTypeProfileDIDCommAIP2RFC19 = "didcomm/aip2;env=rfc19"
+	// MediaTypeProfileAIP2RFC587 is the signing mechanism, plaintext conventions, and routing algorithms
+	// embodied in Aries AIP 2.0, circa 2021 -- with the new-style encryption envelope from Aries RFC 0587.
+	// Defined in RFC 0044.
+	MediaTypeProfileAIP2RFC587 = "didcomm/aip2;env=rfc587"
+	// MediaTypeProfileDIDCommV2 is the encryption envelope, signing mechanism, plaintext conventions,
+	// and routing algorithms embodied in the DIDComm messaging spec. Defined in RFC 0044.
+	MediaTypeProfileDIDCommV2 = "didcomm/v2"
+)
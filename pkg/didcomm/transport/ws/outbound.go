@@ -10,6 +10,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"nhooyr.io/websocket"
 
@@ -18,17 +20,67 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 )
 
-const webSocketScheme = "ws"
+const (
+	webSocketScheme = "ws"
+
+	defaultReconnectInitialDelay = time.Second
+	defaultReconnectMaxDelay     = time.Minute
+)
+
+// OutboundClientOpt configures the websocket outbound transport.
+type OutboundClientOpt func(*OutboundClient)
+
+// WithReconnectBackoff sets the delay before reconnecting to a destination whose connection dropped. The delay is
+// doubled after every failed attempt, up to maxDelay.
+func WithReconnectBackoff(initialDelay, maxDelay time.Duration) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.reconnectInitialDelay = initialDelay
+		cs.reconnectMaxDelay = maxDelay
+	}
+}
+
+// WithMaxReconnectAttempts sets how many times reconnecting to a destination is attempted before giving up,
+// 0 (the default) retries until the transport is stopped.
+func WithMaxReconnectAttempts(attempts int) OutboundClientOpt {
+	return func(cs *OutboundClient) {
+		cs.maxReconnectAttempts = attempts
+	}
+}
 
 // OutboundClient websocket outbound.
+// Once started, the client keeps a connection open per destination and reconnects with a backoff when it drops,
+// responses sent back over the connection (return route) are handed to the inbound message handler.
 type OutboundClient struct {
-	pool *connPool
-	prov transport.Provider
+	pool                  *connPool
+	prov                  transport.Provider
+	conns                 map[string]*destinationConn
+	connsLock             sync.Mutex
+	stop                  chan struct{}
+	reconnectInitialDelay time.Duration
+	reconnectMaxDelay     time.Duration
+	maxReconnectAttempts  int
+}
+
+// destinationConn is the connection kept open to a destination and the recipient keys it is the return route for.
+type destinationConn struct {
+	conn *websocket.Conn
+	keys []string
 }
 
 // NewOutbound creates a client for Outbound WS transport.
-func NewOutbound() *OutboundClient {
-	return &OutboundClient{}
+func NewOutbound(opts ...OutboundClientOpt) *OutboundClient {
+	cs := &OutboundClient{
+		conns:                 make(map[string]*destinationConn),
+		stop:                  make(chan struct{}),
+		reconnectInitialDelay: defaultReconnectInitialDelay,
+		reconnectMaxDelay:     defaultReconnectMaxDelay,
+	}
+
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	return cs
 }
 
 // Start starts the outbound transport.
@@ -39,6 +91,32 @@ func (cs *OutboundClient) Start(prov transport.Provider) error {
 	return nil
 }
 
+// Stop closes the connections kept open to the destinations and stops reconnecting.
+func (cs *OutboundClient) Stop() error {
+	cs.connsLock.Lock()
+	defer cs.connsLock.Unlock()
+
+	select {
+	case <-cs.stop:
+		return nil
+	default:
+		close(cs.stop)
+	}
+
+	for endpoint, dc := range cs.conns {
+		delete(cs.conns, endpoint)
+
+		cs.removeKeys(dc.keys)
+
+		err := dc.conn.Close(websocket.StatusNormalClosure, "closing the connection")
+		if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+			logger.Warnf("failed to close connection to %s: %v", endpoint, err)
+		}
+	}
+
+	return nil
+}
+
 // Send sends a2a data via WS.
 func (cs *OutboundClient) Send(data []byte, destination *service.Destination) (string, error) {
 	conn, cleanup, err := cs.getConnection(destination)
@@ -92,6 +170,13 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 		return conn, cleanup, nil
 	}
 
+	// keep the connection open to listen to the responses in case of return route option set
+	if destination.TransportReturnRoute == decorator.TransportReturnRouteAll {
+		conn, err := cs.persistentConnection(destination)
+
+		return conn, cleanup, err
+	}
+
 	var err error
 
 	conn, _, err = websocket.Dial(context.Background(), destination.ServiceEndpoint, nil)
@@ -99,17 +184,6 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 		return nil, cleanup, fmt.Errorf("websocket client : %w", err)
 	}
 
-	// keep the connection open to listen to the response in case of return route option set
-	if destination.TransportReturnRoute == decorator.TransportReturnRouteAll {
-		for _, v := range destination.RecipientKeys {
-			cs.pool.add(v, conn)
-		}
-
-		go cs.pool.listener(conn, true)
-
-		return conn, cleanup, nil
-	}
-
 	cleanup = func() {
 		err = conn.Close(websocket.StatusNormalClosure, "closing the connection")
 		if err != nil && websocket.CloseStatus(err) != websocket.StatusNormalClosure {
@@ -119,3 +193,155 @@ func (cs *OutboundClient) getConnection(destination *service.Destination) (*webs
 
 	return conn, cleanup, nil
 }
+
+// persistentConnection returns the connection kept open to the destination, dialing it if needed, and keeps it
+// for the recipients to listen to their responses.
+func (cs *OutboundClient) persistentConnection(destination *service.Destination) (*websocket.Conn, error) {
+	endpoint := destination.ServiceEndpoint
+
+	cs.connsLock.Lock()
+	dc, ok := cs.conns[endpoint]
+	cs.connsLock.Unlock()
+
+	if !ok {
+		conn, _, err := websocket.Dial(context.Background(), endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("websocket client : %w", err)
+		}
+
+		dc = cs.keep(endpoint, conn)
+	}
+
+	cs.connsLock.Lock()
+	defer cs.connsLock.Unlock()
+
+	for _, v := range destination.RecipientKeys {
+		cs.pool.add(v, dc.conn)
+
+		if !contains(dc.keys, v) {
+			dc.keys = append(dc.keys, v)
+		}
+	}
+
+	return dc.conn, nil
+}
+
+// keep keeps the dialed connection open to the endpoint, unless another one was dialed meanwhile.
+func (cs *OutboundClient) keep(endpoint string, conn *websocket.Conn) *destinationConn {
+	cs.connsLock.Lock()
+	defer cs.connsLock.Unlock()
+
+	if dc, ok := cs.conns[endpoint]; ok {
+		closeConn(endpoint, conn)
+
+		return dc
+	}
+
+	dc := &destinationConn{conn: conn}
+	cs.conns[endpoint] = dc
+
+	go cs.listen(endpoint, dc)
+
+	return dc
+}
+
+// listen reads the messages received over the connection until it drops, then reconnects to the endpoint.
+func (cs *OutboundClient) listen(endpoint string, dc *destinationConn) {
+	cs.pool.listener(dc.conn, true)
+
+	cs.connsLock.Lock()
+
+	// the connection was closed by Stop.
+	if cs.conns[endpoint] != dc {
+		cs.connsLock.Unlock()
+
+		return
+	}
+
+	delete(cs.conns, endpoint)
+	cs.removeKeys(dc.keys)
+
+	cs.connsLock.Unlock()
+
+	logger.Warnf("websocket connection to %s dropped, reconnecting", endpoint)
+
+	cs.reconnect(endpoint, dc.keys)
+}
+
+func (cs *OutboundClient) reconnect(endpoint string, keys []string) {
+	delay := cs.reconnectInitialDelay
+
+	for attempt := 1; cs.maxReconnectAttempts == 0 || attempt <= cs.maxReconnectAttempts; attempt++ {
+		select {
+		case <-cs.stop:
+			return
+		case <-time.After(delay):
+		}
+
+		conn, _, err := websocket.Dial(context.Background(), endpoint, nil)
+		if err == nil {
+			cs.restore(endpoint, conn, keys)
+
+			return
+		}
+
+		logger.Warnf("reconnect attempt %d to %s failed: %v", attempt, endpoint, err)
+
+		delay *= 2
+		if delay > cs.reconnectMaxDelay {
+			delay = cs.reconnectMaxDelay
+		}
+	}
+
+	logger.Errorf("gave up reconnecting to %s after %d attempts", endpoint, cs.maxReconnectAttempts)
+}
+
+// restore keeps the new connection open to the endpoint, unless a connection was dialed by Send meanwhile.
+func (cs *OutboundClient) restore(endpoint string, conn *websocket.Conn, keys []string) {
+	cs.connsLock.Lock()
+	defer cs.connsLock.Unlock()
+
+	select {
+	case <-cs.stop:
+	default:
+		if _, ok := cs.conns[endpoint]; !ok {
+			dc := &destinationConn{conn: conn, keys: keys}
+			cs.conns[endpoint] = dc
+
+			for _, v := range keys {
+				cs.pool.add(v, conn)
+			}
+
+			go cs.listen(endpoint, dc)
+
+			logger.Infof("reconnected to %s", endpoint)
+
+			return
+		}
+	}
+
+	closeConn(endpoint, conn)
+}
+
+func closeConn(endpoint string, conn *websocket.Conn) {
+	if err := conn.Close(websocket.StatusNormalClosure, "closing the connection"); err != nil &&
+		websocket.CloseStatus(err) != websocket.StatusNormalClosure {
+		logger.Warnf("failed to close connection to %s: %v", endpoint, err)
+	}
+}
+
+func (cs *OutboundClient) removeKeys(keys []string) {
+	for _, v := range keys {
+		cs.pool.remove(v)
+	}
+}
+
+func contains(keys []string, key string) bool {
+	for _, v := range keys {
+		if v == key {
+			return true
+		}
+	}
+
+	return false
+}
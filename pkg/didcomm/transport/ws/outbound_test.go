@@ -7,15 +7,23 @@ SPDX-License-Identifier: Apache-2.0
 package ws
 
 import (
+	"context"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"nhooyr.io/websocket"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/test/transportutil"
 	mockpackager "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/packager"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
 func TestClient(t *testing.T) {
@@ -163,3 +171,239 @@ func TestClient(t *testing.T) {
 		require.Equal(t, "", resp)
 	})
 }
+
+func TestClientLoopback(t *testing.T) {
+	clientVerKey := base58.Encode([]byte("client-verification-key-32-bytes"))
+
+	port := ":" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+	// the agent behind the inbound transport answers over the socket the request was received on.
+	serverReceived := make(chan *transport.Envelope, 1)
+	serverProv := &mockTransportProvider{
+		packagerValue: &mockPackager{verKey: clientVerKey},
+		frameworkID:   uuid.New().String(),
+		executeInbound: func(envelope *transport.Envelope) error {
+			serverReceived <- envelope
+
+			return nil
+		},
+	}
+
+	inbound, err := NewInbound(port, "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, inbound.Start(serverProv))
+
+	defer func() {
+		require.NoError(t, inbound.Stop())
+	}()
+
+	require.NoError(t, transportutil.VerifyListener("localhost"+port, time.Second))
+
+	serverOutbound := NewOutbound()
+	require.NoError(t, serverOutbound.Start(serverProv))
+
+	// the agent dialing out, e.g. a mobile agent which can't accept inbound connections.
+	clientReceived := make(chan *transport.Envelope, 1)
+	clientOutbound := NewOutbound()
+	require.NoError(t, clientOutbound.Start(&mockTransportProvider{
+		packagerValue: &mockPackager{verKey: "server"},
+		frameworkID:   uuid.New().String(),
+		executeInbound: func(envelope *transport.Envelope) error {
+			clientReceived <- envelope
+
+			return nil
+		},
+	}))
+
+	defer func() {
+		require.NoError(t, clientOutbound.Stop())
+	}()
+
+	request := createTransportDecRequest(t, decorator.TransportReturnRouteAll)
+
+	_, err = clientOutbound.Send(request,
+		prepareDestinationWithTransport("ws://localhost"+port, decorator.TransportReturnRouteAll, []string{"server"}))
+	require.NoError(t, err)
+
+	select {
+	case envelope := <-serverReceived:
+		require.Equal(t, request, envelope.Message)
+	case <-time.After(time.Second):
+		require.Fail(t, "the server did not receive the request")
+	}
+
+	// the server has no route to the client but the socket it received the request on.
+	clientDIDKey, _ := fingerprint.CreateDIDKey(base58.Decode(clientVerKey))
+	require.True(t, serverOutbound.AcceptRecipient([]string{clientDIDKey}))
+
+	_, err = serverOutbound.Send([]byte("response"), prepareDestination("ws://unreachable-client"))
+	require.Error(t, err)
+
+	_, err = serverOutbound.Send([]byte("response"),
+		prepareDestinationWithTransport("ws://unreachable-client", "", []string{clientDIDKey}))
+	require.NoError(t, err)
+
+	select {
+	case envelope := <-clientReceived:
+		require.Equal(t, "response", string(envelope.Message))
+	case <-time.After(time.Second):
+		require.Fail(t, "the client did not receive the response")
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	recKeys := []string{"XYZ"}
+
+	startDroppingServer := func(t *testing.T) (string, *int32, chan string) {
+		t.Helper()
+
+		var connections int32
+
+		received := make(chan string, 10)
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			c, err := Accept(w, r)
+			require.NoError(t, err)
+
+			n := atomic.AddInt32(&connections, 1)
+
+			for {
+				_, message, err := c.Read(context.Background())
+				if err != nil {
+					return
+				}
+
+				received <- string(message)
+
+				// the first connection drops after a message.
+				if n == 1 {
+					require.NoError(t, c.Close(websocket.StatusGoingAway, "dropping the connection"))
+
+					return
+				}
+			}
+		})
+
+		return "ws://" + addr, &connections, received
+	}
+
+	t.Run("reconnects to the destination when the connection drops", func(t *testing.T) {
+		endpoint, connections, received := startDroppingServer(t)
+
+		outbound := NewOutbound(WithReconnectBackoff(10*time.Millisecond, 50*time.Millisecond))
+		require.NoError(t, outbound.Start(&mockProvider{&mockPackager{}}))
+
+		defer func() {
+			require.NoError(t, outbound.Stop())
+		}()
+
+		destination := prepareDestinationWithTransport(endpoint, decorator.TransportReturnRouteAll, recKeys)
+
+		_, err := outbound.Send([]byte("first"), destination)
+		require.NoError(t, err)
+		require.Equal(t, "first", <-received)
+
+		firstConn := outbound.pool.fetch(recKeys[0])
+		require.NotNil(t, firstConn)
+
+		// the connection is dialed again and is the return route of the recipients again.
+		require.Eventually(t, func() bool {
+			c := outbound.pool.fetch(recKeys[0])
+
+			return c != nil && c != firstConn
+		}, time.Second, 10*time.Millisecond)
+
+		_, err = outbound.Send([]byte("second"), destination)
+		require.NoError(t, err)
+		require.Equal(t, "second", <-received)
+		require.Equal(t, int32(2), atomic.LoadInt32(connections))
+	})
+
+	t.Run("keeps the connection open per destination", func(t *testing.T) {
+		var connections int32
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&connections, 1)
+			echo(t, w, r)
+		})
+
+		outbound := NewOutbound()
+		require.NoError(t, outbound.Start(&mockProvider{&mockPackager{}}))
+
+		defer func() {
+			require.NoError(t, outbound.Stop())
+		}()
+
+		destination := prepareDestinationWithTransport("ws://"+addr, decorator.TransportReturnRouteAll, recKeys)
+
+		for i := 0; i < 3; i++ {
+			_, err := outbound.Send([]byte("hello"), destination)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, int32(1), atomic.LoadInt32(&connections))
+	})
+
+	t.Run("closes the connection without return route", func(t *testing.T) {
+		var connections int32
+
+		addr := startWebSocketServer(t, func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&connections, 1)
+			echo(t, w, r)
+		})
+
+		outbound := NewOutbound()
+		require.NoError(t, outbound.Start(&mockProvider{&mockPackager{}}))
+
+		defer func() {
+			require.NoError(t, outbound.Stop())
+		}()
+
+		for i := 0; i < 3; i++ {
+			_, err := outbound.Send([]byte("hello"), prepareDestination("ws://"+addr))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, int32(3), atomic.LoadInt32(&connections))
+		require.Empty(t, outbound.conns)
+	})
+
+	t.Run("gives up after the max reconnect attempts", func(t *testing.T) {
+		outbound := NewOutbound(WithReconnectBackoff(10*time.Millisecond, 20*time.Millisecond),
+			WithMaxReconnectAttempts(3))
+		require.NoError(t, outbound.Start(&mockProvider{&mockPackager{}}))
+
+		defer func() {
+			require.NoError(t, outbound.Stop())
+		}()
+
+		unreachable := "ws://localhost:" + strconv.Itoa(transportutil.GetRandomPort(5))
+
+		// returns once the attempts are exhausted.
+		outbound.reconnect(unreachable, recKeys)
+
+		require.Empty(t, outbound.conns)
+		require.Nil(t, outbound.pool.fetch(recKeys[0]))
+	})
+
+	t.Run("stops reconnecting once stopped", func(t *testing.T) {
+		endpoint, connections, received := startDroppingServer(t)
+
+		outbound := NewOutbound(WithReconnectBackoff(100*time.Millisecond, 100*time.Millisecond))
+		require.NoError(t, outbound.Start(&mockProvider{&mockPackager{}}))
+
+		destination := prepareDestinationWithTransport(endpoint, decorator.TransportReturnRouteAll, recKeys)
+
+		_, err := outbound.Send([]byte("first"), destination)
+		require.NoError(t, err)
+		require.Equal(t, "first", <-received)
+
+		require.NoError(t, outbound.Stop())
+		require.NoError(t, outbound.Stop())
+
+		time.Sleep(200 * time.Millisecond)
+
+		require.Equal(t, int32(1), atomic.LoadInt32(connections))
+		require.Empty(t, outbound.conns)
+	})
+}
@@ -85,6 +85,12 @@ func (d *connPool) listener(conn *websocket.Conn, outbound bool) {
 			break
 		}
 
+		if d.packager == nil {
+			logger.Errorf("failed to unpack msg: no packager")
+
+			continue
+		}
+
 		unpackMsg, err := d.packager.UnpackMessage(message)
 		if err != nil {
 			logger.Errorf("failed to unpack msg: %v", err)
@@ -53,19 +53,13 @@ func acceptRecipient(pool *connPool, keys []string) bool {
 func keepConnAlive(conn *websocket.Conn, outbound bool, frequency time.Duration) {
 	if outbound {
 		ticker := time.NewTicker(frequency)
-		done := make(chan struct{})
+		defer ticker.Stop()
 
-		for {
-			select {
-			case <-done:
-				return
-			case <-ticker.C:
-				if err := conn.Ping(context.Background()); err != nil {
-					logger.Errorf("websocket ping error : %v", err)
+		for range ticker.C {
+			if err := conn.Ping(context.Background()); err != nil {
+				logger.Errorf("websocket ping error : %v", err)
 
-					ticker.Stop()
-					done <- struct{}{}
-				}
+				return
 			}
 		}
 	}
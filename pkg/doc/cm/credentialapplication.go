@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cm
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	// CredentialApplicationJSONLDContextIRI is the JSON-LD context of credential applications.
+	CredentialApplicationJSONLDContextIRI = "https://identity.foundation/credential-manifest/application/v1"
+	// CredentialApplicationJSONLDType is the JSON-LD type of credential applications.
+	CredentialApplicationJSONLDType = "CredentialApplication"
+
+	credentialApplicationProperty = "credential_application"
+)
+
+// CredentialApplication is sent by the holder to the issuer to apply for the credentials of a credential manifest.
+// It travels in a verifiable presentation along with the presentation submission of the credentials satisfying
+// the presentation definition of the manifest.
+type CredentialApplication struct {
+	ID         string           `json:"id,omitempty"`          // mandatory
+	ManifestID string           `json:"manifest_id,omitempty"` // mandatory
+	Format     *presexch.Format `json:"format,omitempty"`
+}
+
+// CreateCredentialApplication creates the verifiable presentation holding the credential application for
+// the manifest, along with the holder's credentials satisfying the input requirements of the manifest.
+// It returns an error wrapping presexch.ErrNoCredentials if the credentials do not satisfy the requirements.
+// The presentation is to be signed by the holder before it is sent to the issuer.
+func (cm *CredentialManifest) CreateCredentialApplication(credentials []*verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
+	if err := cm.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		vp  *verifiable.Presentation
+		err error
+	)
+
+	if cm.PresentationDefinition != nil {
+		vp, err = cm.PresentationDefinition.CreateVP(credentials, opts...)
+	} else {
+		vp, err = verifiable.NewPresentation()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("create credential application: %w", err)
+	}
+
+	vp.Context = append(vp.Context, CredentialApplicationJSONLDContextIRI)
+	vp.Type = append(vp.Type, CredentialApplicationJSONLDType)
+
+	if vp.CustomFields == nil {
+		vp.CustomFields = verifiable.CustomFields{}
+	}
+
+	vp.CustomFields[credentialApplicationProperty] = &CredentialApplication{
+		ID:         uuid.New().String(),
+		ManifestID: cm.ID,
+		Format:     cm.Format,
+	}
+
+	return vp, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cm_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	ldcontext "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredentialManifest_CreateCredentialApplication(t *testing.T) {
+	t.Run("input requirements satisfied", func(t *testing.T) {
+		manifest := parseManifest(t)
+
+		identity := newCredential(verifiable.Subject{
+			ID:           "did:example:holder",
+			CustomFields: verifiable.CustomFields{"name": "Jesse Travis"},
+		})
+
+		vp, err := manifest.CreateCredentialApplication([]*verifiable.Credential{
+			newCredential(verifiable.Subject{ID: "did:example:holder"}),
+			identity,
+		})
+		require.NoError(t, err)
+		require.Contains(t, vp.Context, cm.CredentialApplicationJSONLDContextIRI)
+		require.Contains(t, vp.Type, cm.CredentialApplicationJSONLDType)
+		require.Contains(t, vp.Type, presexch.PresentationSubmissionJSONLDType)
+
+		application, ok := vp.CustomFields["credential_application"].(*cm.CredentialApplication)
+		require.True(t, ok)
+		require.NotEmpty(t, application.ID)
+		require.Equal(t, manifest.ID, application.ManifestID)
+
+		submission, ok := vp.CustomFields["presentation_submission"].(*presexch.PresentationSubmission)
+		require.True(t, ok)
+		require.Equal(t, manifest.PresentationDefinition.ID, submission.DefinitionID)
+		require.Len(t, submission.DescriptorMap, 1)
+		require.Equal(t, "identity_input", submission.DescriptorMap[0].ID)
+
+		credentials := vp.Credentials()
+		require.Len(t, credentials, 1)
+		require.Equal(t, identity, credentials[0])
+	})
+
+	t.Run("input requirements not satisfied", func(t *testing.T) {
+		manifest := parseManifest(t)
+
+		vp, err := manifest.CreateCredentialApplication([]*verifiable.Credential{
+			newCredential(verifiable.Subject{
+				ID:           "did:example:holder",
+				CustomFields: verifiable.CustomFields{"email": "jesse@example.com"},
+			}),
+		})
+		require.ErrorIs(t, err, presexch.ErrNoCredentials)
+		require.Nil(t, vp)
+	})
+
+	t.Run("manifest without input requirements", func(t *testing.T) {
+		manifest := parseManifest(t)
+		manifest.PresentationDefinition = nil
+
+		vp, err := manifest.CreateCredentialApplication(nil)
+		require.NoError(t, err)
+		require.Empty(t, vp.Credentials())
+		require.NotContains(t, vp.CustomFields, "presentation_submission")
+
+		application, ok := vp.CustomFields["credential_application"].(*cm.CredentialApplication)
+		require.True(t, ok)
+		require.Equal(t, manifest.ID, application.ManifestID)
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		manifest := parseManifest(t)
+		manifest.OutputDescriptors = nil
+
+		vp, err := manifest.CreateCredentialApplication(nil)
+		require.EqualError(t, err, "invalid credential manifest: at least one output descriptor is required")
+		require.Nil(t, vp)
+	})
+}
+
+func newCredential(subject verifiable.Subject) *verifiable.Credential {
+	return &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/" + uuid.New().String(),
+		Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+		Subject: []verifiable.Subject{subject},
+	}
+}
+
+func TestCredentialApplication_LinkedDataProof(t *testing.T) {
+	manifest := parseManifest(t)
+
+	vp, err := manifest.CreateCredentialApplication([]*verifiable.Credential{
+		newCredential(verifiable.Subject{
+			ID:           "did:example:holder",
+			CustomFields: verifiable.CustomFields{"name": "Jesse Travis"},
+		}),
+	})
+	require.NoError(t, err)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	// the loader has the embedded contexts only, nothing is fetched from the network
+	loader, err := ldcontext.NewDocumentLoader(mem.NewProvider())
+	require.NoError(t, err)
+
+	err = vp.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           ed25519signature2018.SignatureType,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signature.GetEd25519Signer(privKey, pubKey))),
+		SignatureRepresentation: verifiable.SignatureJWS,
+		VerificationMethod:      "did:example:holder#key-1",
+	}, jsonld.WithDocumentLoader(loader))
+	require.NoError(t, err)
+
+	signed, err := json.Marshal(vp)
+	require.NoError(t, err)
+
+	parse := func(raw []byte) (*verifiable.Presentation, error) {
+		return verifiable.ParsePresentation(raw,
+			verifiable.WithPresPublicKeyFetcher(verifiable.SingleKey(pubKey, kms.ED25519)),
+			verifiable.WithPresJSONLDDocumentLoader(loader))
+	}
+
+	parsed, err := parse(signed)
+	require.NoError(t, err)
+	require.Contains(t, parsed.Context, cm.CredentialApplicationJSONLDContextIRI)
+
+	// the credential application is defined by its context, so it is covered by the proof
+	var tampered map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(signed, &tampered))
+	tampered["credential_application"].(map[string]interface{})["manifest_id"] = "another-manifest"
+
+	raw, err := json.Marshal(tampered)
+	require.NoError(t, err)
+
+	_, err = parse(raw)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check embedded proof")
+}
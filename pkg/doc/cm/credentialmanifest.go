@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cm implements the DIF Credential Manifest (https://identity.foundation/credential-manifest/): issuers
+// advertise the credentials they issue and the inputs they require, holders apply with a credential application.
+package cm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+)
+
+// CredentialManifest describes the credentials an issuer is able to issue (the output descriptors) and the inputs
+// the issuer requires from the holder in order to issue them (the presentation definition).
+type CredentialManifest struct {
+	ID                     string                           `json:"id,omitempty"`
+	Version                string                           `json:"spec_version,omitempty"`
+	Issuer                 Issuer                           `json:"issuer,omitempty"`
+	OutputDescriptors      []*OutputDescriptor              `json:"output_descriptors,omitempty"`
+	Format                 *presexch.Format                 `json:"format,omitempty"`
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition,omitempty"`
+}
+
+// Issuer describes the issuer of the credentials of the manifest.
+type Issuer struct {
+	ID     string  `json:"id,omitempty"` // mandatory, must be a valid URI
+	Name   string  `json:"name,omitempty"`
+	Styles *Styles `json:"styles,omitempty"`
+}
+
+// Styles describes how the issuer or the credentials are to be rendered in a user interface.
+type Styles struct {
+	Thumbnail  *ImageURIWithAltText `json:"thumbnail,omitempty"`
+	Hero       *ImageURIWithAltText `json:"hero,omitempty"`
+	Background *Color               `json:"background,omitempty"`
+	Text       *Color               `json:"text,omitempty"`
+}
+
+// ImageURIWithAltText is an image with its alternative text.
+type ImageURIWithAltText struct {
+	URI string `json:"uri,omitempty"` // mandatory
+	Alt string `json:"alt,omitempty"`
+}
+
+// Color is a color in the HEX format (e.g. #000000).
+type Color struct {
+	Color string `json:"color,omitempty"`
+}
+
+// OutputDescriptor describes a credential the issuer will issue in response to a credential application.
+type OutputDescriptor struct {
+	ID          string                 `json:"id,omitempty"`     // mandatory, unique within the manifest
+	Schema      string                 `json:"schema,omitempty"` // mandatory, the schema of the issued credential
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Display     *DataDisplayDescriptor `json:"display,omitempty"`
+	Styles      *Styles                `json:"styles,omitempty"`
+}
+
+// DataDisplayDescriptor describes how the issued credential is to be displayed.
+type DataDisplayDescriptor struct {
+	Title       *DisplayMappingObject          `json:"title,omitempty"`
+	Subtitle    *DisplayMappingObject          `json:"subtitle,omitempty"`
+	Description *DisplayMappingObject          `json:"description,omitempty"`
+	Properties  []*LabeledDisplayMappingObject `json:"properties,omitempty"`
+}
+
+// DisplayMappingObject is either a fixed text or the value selected by JSONPath from the issued credential
+// (the fallback being displayed if none of the paths selects a value).
+type DisplayMappingObject struct {
+	Text     string   `json:"text,omitempty"`
+	Paths    []string `json:"path,omitempty"`
+	Fallback string   `json:"fallback,omitempty"`
+}
+
+// LabeledDisplayMappingObject is a display mapping object with a label.
+type LabeledDisplayMappingObject struct {
+	DisplayMappingObject
+	Label string `json:"label,omitempty"` // mandatory
+}
+
+// ParseCredentialManifest parses and validates the credential manifest.
+func ParseCredentialManifest(data []byte) (*CredentialManifest, error) {
+	var manifest CredentialManifest
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("unmarshal credential manifest: %w", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &manifest, nil
+}
+
+// Validate checks that the credential manifest has the mandatory fields and that its presentation definition,
+// if any, is valid.
+func (cm *CredentialManifest) Validate() error {
+	if cm.ID == "" {
+		return errors.New("invalid credential manifest: id is required")
+	}
+
+	if cm.Issuer.ID == "" {
+		return errors.New("invalid credential manifest: issuer id is required")
+	}
+
+	if len(cm.OutputDescriptors) == 0 {
+		return errors.New("invalid credential manifest: at least one output descriptor is required")
+	}
+
+	ids := make(map[string]struct{}, len(cm.OutputDescriptors))
+
+	for i, descriptor := range cm.OutputDescriptors {
+		if err := descriptor.validate(); err != nil {
+			return fmt.Errorf("invalid credential manifest: output descriptor at index %d: %w", i, err)
+		}
+
+		if _, ok := ids[descriptor.ID]; ok {
+			return fmt.Errorf("invalid credential manifest: duplicate output descriptor id %s", descriptor.ID)
+		}
+
+		ids[descriptor.ID] = struct{}{}
+	}
+
+	if cm.PresentationDefinition != nil {
+		if err := cm.PresentationDefinition.ValidateSchema(); err != nil {
+			return fmt.Errorf("invalid credential manifest: presentation definition: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (od *OutputDescriptor) validate() error {
+	if od == nil {
+		return errors.New("output descriptor is missing")
+	}
+
+	if od.ID == "" {
+		return errors.New("id is required")
+	}
+
+	if od.Schema == "" {
+		return errors.New("schema is required")
+	}
+
+	if od.Display == nil {
+		return nil
+	}
+
+	for i, property := range od.Display.Properties {
+		if property == nil || property.Label == "" {
+			return fmt.Errorf("display property at index %d: label is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cm_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+)
+
+const driversLicenseManifest = "testdata/credential_manifest_drivers_license.json"
+
+func TestParseCredentialManifest(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		manifest, err := cm.ParseCredentialManifest(readFile(t, driversLicenseManifest))
+		require.NoError(t, err)
+		require.Equal(t, "WA-DL-CLASS-A", manifest.ID)
+		require.Equal(t, "did:example:123?linked-domains=3", manifest.Issuer.ID)
+		require.Equal(t, "#ff0000", manifest.Issuer.Styles.Background.Color)
+		require.Len(t, manifest.OutputDescriptors, 1)
+		require.Equal(t, "driving_license_output", manifest.OutputDescriptors[0].ID)
+		require.Equal(t, []string{"$.name", "$.vc.name"}, manifest.OutputDescriptors[0].Display.Title.Paths)
+		require.Equal(t, "Organ Donor", manifest.OutputDescriptors[0].Display.Properties[0].Label)
+		require.Equal(t, "identity_input", manifest.PresentationDefinition.InputDescriptors[0].ID)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		manifest, err := cm.ParseCredentialManifest([]byte("not JSON"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal credential manifest")
+		require.Nil(t, manifest)
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		manifest, err := cm.ParseCredentialManifest([]byte(`{"id":"WA-DL-CLASS-A"}`))
+		require.EqualError(t, err, "invalid credential manifest: issuer id is required")
+		require.Nil(t, manifest)
+	})
+}
+
+func TestCredentialManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*cm.CredentialManifest)
+		err    string
+	}{{
+		name:   "missing id",
+		modify: func(m *cm.CredentialManifest) { m.ID = "" },
+		err:    "invalid credential manifest: id is required",
+	}, {
+		name:   "missing issuer id",
+		modify: func(m *cm.CredentialManifest) { m.Issuer.ID = "" },
+		err:    "invalid credential manifest: issuer id is required",
+	}, {
+		name:   "missing output descriptors",
+		modify: func(m *cm.CredentialManifest) { m.OutputDescriptors = nil },
+		err:    "invalid credential manifest: at least one output descriptor is required",
+	}, {
+		name:   "missing output descriptor",
+		modify: func(m *cm.CredentialManifest) { m.OutputDescriptors[0] = nil },
+		err:    "invalid credential manifest: output descriptor at index 0: output descriptor is missing",
+	}, {
+		name:   "missing output descriptor id",
+		modify: func(m *cm.CredentialManifest) { m.OutputDescriptors[0].ID = "" },
+		err:    "invalid credential manifest: output descriptor at index 0: id is required",
+	}, {
+		name:   "missing output descriptor schema",
+		modify: func(m *cm.CredentialManifest) { m.OutputDescriptors[0].Schema = "" },
+		err:    "invalid credential manifest: output descriptor at index 0: schema is required",
+	}, {
+		name:   "missing display property label",
+		modify: func(m *cm.CredentialManifest) { m.OutputDescriptors[0].Display.Properties[0].Label = "" },
+		err: "invalid credential manifest: output descriptor at index 0: " +
+			"display property at index 0: label is required",
+	}, {
+		name: "duplicate output descriptor id",
+		modify: func(m *cm.CredentialManifest) {
+			m.OutputDescriptors = append(m.OutputDescriptors, &cm.OutputDescriptor{
+				ID:     m.OutputDescriptors[0].ID,
+				Schema: "https://schema.org/EducationalOccupationalCredential",
+			})
+		},
+		err: "invalid credential manifest: duplicate output descriptor id driving_license_output",
+	}, {
+		name:   "invalid presentation definition",
+		modify: func(m *cm.CredentialManifest) { m.PresentationDefinition.InputDescriptors = nil },
+		err: "invalid credential manifest: presentation definition: " +
+			"presentation_definition: input_descriptors is required",
+	}}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			manifest := parseManifest(t)
+			tc.modify(manifest)
+
+			require.EqualError(t, manifest.Validate(), tc.err)
+		})
+	}
+
+	t.Run("without presentation definition", func(t *testing.T) {
+		manifest := parseManifest(t)
+		manifest.PresentationDefinition = nil
+
+		require.NoError(t, manifest.Validate())
+	})
+}
+
+func parseManifest(t *testing.T) *cm.CredentialManifest {
+	t.Helper()
+
+	var manifest cm.CredentialManifest
+
+	require.NoError(t, json.Unmarshal(readFile(t, driversLicenseManifest), &manifest))
+	require.NoError(t, manifest.Validate())
+
+	return &manifest
+}
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := ioutil.ReadFile(name) // nolint: gosec
+	require.NoError(t, err)
+
+	return data
+}
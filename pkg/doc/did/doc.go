@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/multiformats/go-multibase"
 	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
@@ -50,10 +51,11 @@ const (
 	jsonldProofPurpose   = "proofPurpose"
 
 	// various public key encodings.
-	jsonldPublicKeyBase58 = "publicKeyBase58"
-	jsonldPublicKeyHex    = "publicKeyHex"
-	jsonldPublicKeyPem    = "publicKeyPem"
-	jsonldPublicKeyjwk    = "publicKeyJwk"
+	jsonldPublicKeyBase58    = "publicKeyBase58"
+	jsonldPublicKeyHex       = "publicKeyHex"
+	jsonldPublicKeyPem       = "publicKeyPem"
+	jsonldPublicKeyjwk       = "publicKeyJwk"
+	jsonldPublicKeyMultibase = "publicKeyMultibase"
 )
 
 var (
@@ -105,9 +107,18 @@ func Parse(did string) (*DID, error) {
 
 // DocResolution did resolution.
 type DocResolution struct {
-	Context          []string
-	DIDDocument      *Doc
-	DocumentMetadata *DocumentMetadata
+	Context            []string
+	DIDDocument        *Doc
+	DocumentMetadata   *DocumentMetadata
+	ResolutionMetadata *ResolutionMetadata
+}
+
+// ResolutionMetadata did resolution metadata.
+type ResolutionMetadata struct {
+	// ContentType is the media type of the returned did document representation.
+	ContentType string `json:"contentType,omitempty"`
+	// Error is the error code of the resolution process.
+	Error string `json:"error,omitempty"`
 }
 
 // MethodMetadata method metadata.
@@ -122,6 +133,12 @@ type MethodMetadata struct {
 
 // DocumentMetadata document metadata.
 type DocumentMetadata struct {
+	// Created is the timestamp of the Create operation.
+	Created *time.Time `json:"created,omitempty"`
+	// Updated is the timestamp of the last Update operation.
+	Updated *time.Time `json:"updated,omitempty"`
+	// VersionID is the version of the last Update operation.
+	VersionID string `json:"versionId,omitempty"`
 	// Deactivated is deactivated flag key.
 	Deactivated bool `json:"deactivated,omitempty"`
 	// CanonicalID is canonical ID key.
@@ -133,9 +150,10 @@ type DocumentMetadata struct {
 }
 
 type rawDocResolution struct {
-	Context          interface{}     `json:"@context"`
-	DIDDocument      json.RawMessage `json:"didDocument,omitempty"`
-	DocumentMetadata json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	Context            interface{}     `json:"@context"`
+	DIDDocument        json.RawMessage `json:"didDocument,omitempty"`
+	DocumentMetadata   json.RawMessage `json:"didDocumentMetadata,omitempty"`
+	ResolutionMetadata json.RawMessage `json:"didResolutionMetadata,omitempty"`
 }
 
 // ParseDocumentResolution parse document resolution.
@@ -163,9 +181,24 @@ func ParseDocumentResolution(data []byte) (*DocResolution, error) {
 		}
 	}
 
+	var resMeta *ResolutionMetadata
+
+	if len(raw.ResolutionMetadata) != 0 {
+		resMeta = &ResolutionMetadata{}
+
+		if err := json.Unmarshal(raw.ResolutionMetadata, resMeta); err != nil {
+			return nil, err
+		}
+	}
+
 	context, _ := parseContext(raw.Context)
 
-	return &DocResolution{Context: context, DIDDocument: doc, DocumentMetadata: docMeta}, nil
+	return &DocResolution{
+		Context:            context,
+		DIDDocument:        doc,
+		DocumentMetadata:   docMeta,
+		ResolutionMetadata: resMeta,
+	}, nil
 }
 
 // Doc DID Document definition.
@@ -202,6 +235,8 @@ type VerificationMethod struct {
 
 	jsonWebKey  *jose.JWK
 	relativeURL bool
+	// multibase defines that Value was decoded from (and is encoded to) publicKeyMultibase.
+	multibase bool
 }
 
 // NewVerificationMethodFromBytes creates a new VerificationMethod based on raw public key bytes.
@@ -742,6 +777,18 @@ func decodeVM(vm *VerificationMethod, rawPK map[string]interface{}) error {
 		return nil
 	}
 
+	if stringEntry(rawPK[jsonldPublicKeyMultibase]) != "" {
+		_, value, err := multibase.Decode(stringEntry(rawPK[jsonldPublicKeyMultibase]))
+		if err != nil {
+			return fmt.Errorf("decode public key multibase failed: %w", err)
+		}
+
+		vm.Value = value
+		vm.multibase = true
+
+		return nil
+	}
+
 	if stringEntry(rawPK[jsonldPublicKeyHex]) != "" {
 		value, err := hex.DecodeString(stringEntry(rawPK[jsonldPublicKeyHex]))
 		if err != nil {
@@ -940,6 +987,13 @@ func (docResolution *DocResolution) JSONBytes() ([]byte, error) {
 		DocumentMetadata: documentMetadataBytes,
 	}
 
+	if docResolution.ResolutionMetadata != nil {
+		raw.ResolutionMetadata, err = json.Marshal(docResolution.ResolutionMetadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	byteDoc, err := json.Marshal(raw)
 	if err != nil {
 		return nil, fmt.Errorf("JSON marshalling of document failed: %w", err)
@@ -1225,6 +1279,13 @@ func populateRawVerificationMethod(context, didID, baseURI string,
 		}
 
 		rawVM[jsonldPublicKeyjwk] = json.RawMessage(jwkBytes)
+	} else if vm.multibase {
+		value, err := multibase.Encode(multibase.Base58BTC, vm.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		rawVM[jsonldPublicKeyMultibase] = value
 	} else if vm.Value != nil {
 		rawVM[jsonldPublicKeyBase58] = base58.Encode(vm.Value)
 	}
@@ -173,6 +173,10 @@ func TestDocResolution(t *testing.T) {
 		require.Equal(t, "did:example:21tDAKCERh95uGgKbJNHYp", d.DIDDocument.ID)
 		require.Equal(t, true, d.DocumentMetadata.Method.Published)
 		require.Equal(t, "did:ex:123333", d.DocumentMetadata.CanonicalID)
+		require.Equal(t, "2", d.DocumentMetadata.VersionID)
+		require.Equal(t, "2021-03-10T15:18:52Z", d.DocumentMetadata.Created.Format(time.RFC3339))
+		require.Equal(t, "2021-03-11T08:10:22Z", d.DocumentMetadata.Updated.Format(time.RFC3339))
+		require.Equal(t, "application/did+ld+json", d.ResolutionMetadata.ContentType)
 
 		bytes, err := d.JSONBytes()
 		require.NoError(t, err)
@@ -185,6 +189,25 @@ func TestDocResolution(t *testing.T) {
 		require.Equal(t, "did:example:21tDAKCERh95uGgKbJNHYp", d.DIDDocument.ID)
 		require.Equal(t, true, d.DocumentMetadata.Method.Published)
 		require.Equal(t, "did:ex:123333", d.DocumentMetadata.CanonicalID)
+		require.Equal(t, "2", d.DocumentMetadata.VersionID)
+		require.Equal(t, "2021-03-10T15:18:52Z", d.DocumentMetadata.Created.Format(time.RFC3339))
+		require.Equal(t, "2021-03-11T08:10:22Z", d.DocumentMetadata.Updated.Format(time.RFC3339))
+		require.Equal(t, "application/did+ld+json", d.ResolutionMetadata.ContentType)
+	})
+
+	t.Run("test doc resolution without resolution metadata", func(t *testing.T) {
+		d, err := ParseDocumentResolution([]byte(validDocResolution))
+		require.NoError(t, err)
+
+		d.ResolutionMetadata = nil
+
+		bytes, err := d.JSONBytes()
+		require.NoError(t, err)
+		require.NotContains(t, string(bytes), "didResolutionMetadata")
+
+		d, err = ParseDocumentResolution(bytes)
+		require.NoError(t, err)
+		require.Nil(t, d.ResolutionMetadata)
 	})
 
 	t.Run("test did doc not exists", func(t *testing.T) {
@@ -479,10 +502,10 @@ func TestPublicKeys(t *testing.T) {
 
 			if len(raw.PublicKey) != 0 {
 				delete(raw.PublicKey[1], jsonldPublicKeyPem)
-				raw.PublicKey[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.PublicKey[1]["publicKeyGpg"] = wrongDataMsg
 			} else {
 				delete(raw.VerificationMethod[1], jsonldPublicKeyPem)
-				raw.VerificationMethod[1]["publicKeyMultibase"] = wrongDataMsg
+				raw.VerificationMethod[1]["publicKeyGpg"] = wrongDataMsg
 			}
 
 			bytes, err := json.Marshal(raw)
@@ -492,6 +515,48 @@ func TestPublicKeys(t *testing.T) {
 			require.Contains(t, err.Error(), "public key encoding not supported")
 		}
 	})
+
+	t.Run("test public key multibase", func(t *testing.T) {
+		// Multikey encoded Ed25519 public key: base58btc of multicodec 0xed01 prefixed key bytes.
+		const multikey = "z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"
+
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
+
+		delete(raw.VerificationMethod[1], jsonldPublicKeyPem)
+		raw.VerificationMethod[1][jsonldType] = "Multikey"
+		raw.VerificationMethod[1][jsonldPublicKeyMultibase] = multikey
+
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		doc, err := ParseDocument(bytes)
+		require.NoError(t, err)
+
+		vm := doc.VerificationMethod[1]
+		require.Equal(t, "Multikey", vm.Type)
+		require.Len(t, vm.Value, 34)
+		require.Equal(t, []byte{0xed, 0x01}, vm.Value[:2])
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+		require.Contains(t, string(docBytes), `"publicKeyMultibase":"`+multikey+`"`)
+	})
+
+	t.Run("test failed to decode public key multibase", func(t *testing.T) {
+		raw := &rawDoc{}
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &raw))
+
+		delete(raw.VerificationMethod[1], jsonldPublicKeyPem)
+		raw.VerificationMethod[1][jsonldPublicKeyMultibase] = wrongDataMsg
+
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = ParseDocument(bytes)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode public key multibase failed")
+	})
 }
 
 func TestParseDocument(t *testing.T) {
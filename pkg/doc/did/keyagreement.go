@@ -0,0 +1,125 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+)
+
+const (
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	ed25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+
+	// ed25519MultiCodec is the varint encoded multicodec of Ed25519 public keys.
+	ed25519MultiCodec = "\xed\x01"
+	// x25519MultiCodec is the varint encoded multicodec of Curve25519 public keys.
+	x25519MultiCodec = "\xec\x01"
+)
+
+// X25519FromEd25519 converts an Ed25519 public key into its X25519 counterpart using the birational map from the
+// twisted Edwards curve to the Montgomery curve (u = (1 + y) / (1 - y), RFC 7748 section 4.1).
+func X25519FromEd25519(ed25519PubKey []byte) ([]byte, error) {
+	x25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(ed25519PubKey)
+	if err != nil {
+		return nil, fmt.Errorf("convert Ed25519 key to X25519: %w", err)
+	}
+
+	return x25519PubKey, nil
+}
+
+// AddKeyAgreementFromEd25519 derives the X25519 key agreement key of the Ed25519 verification method with the
+// given ID and adds it to the keyAgreement of the DID document as an embedded X25519KeyAgreementKey2019
+// verification method. As for did:key, the fragment of its ID is the multibase encoded multicodec fingerprint of
+// the X25519 key. The Ed25519 verification method can be an Ed25519VerificationKey2018/2020 key or an Ed25519 JWK.
+func (doc *Doc) AddKeyAgreementFromEd25519(vmID string) (*VerificationMethod, error) {
+	vm := doc.lookupVerificationMethod(vmID)
+	if vm == nil {
+		return nil, fmt.Errorf("verification method %s not found", vmID)
+	}
+
+	if !isEd25519(vm) {
+		return nil, fmt.Errorf("verification method %s is not an Ed25519 key: %s", vmID, vm.Type)
+	}
+
+	x25519PubKey, err := X25519FromEd25519(ed25519PublicKey(vm))
+	if err != nil {
+		return nil, err
+	}
+
+	controller := vm.Controller
+	if controller == "" {
+		controller = doc.ID
+	}
+
+	keyID := doc.ID + "#z" + base58.Encode(append([]byte(x25519MultiCodec), x25519PubKey...))
+
+	keyAgreement := NewVerificationMethodFromBytes(keyID, x25519KeyAgreementKey2019, controller, x25519PubKey)
+
+	for _, ka := range doc.KeyAgreement {
+		if ka.VerificationMethod.ID == keyID {
+			return nil, fmt.Errorf("key agreement %s already exists", keyID)
+		}
+	}
+
+	doc.KeyAgreement = append(doc.KeyAgreement, *NewEmbeddedVerification(keyAgreement, KeyAgreement))
+
+	return keyAgreement, nil
+}
+
+// lookupVerificationMethod returns the verification method with the given ID, the ID can be relative to the
+// DID of the document. The embedded verification methods of the verification relationships are looked up too.
+func (doc *Doc) lookupVerificationMethod(id string) *VerificationMethod {
+	matches := func(vmID string) bool {
+		return vmID == id || (strings.HasPrefix(vmID, "#") && doc.ID+vmID == id) ||
+			(strings.HasPrefix(id, "#") && doc.ID+id == vmID)
+	}
+
+	for i := range doc.VerificationMethod {
+		if matches(doc.VerificationMethod[i].ID) {
+			return &doc.VerificationMethod[i]
+		}
+	}
+
+	for _, verifications := range doc.VerificationMethods() {
+		for i := range verifications {
+			if verifications[i].Embedded && matches(verifications[i].VerificationMethod.ID) {
+				return &verifications[i].VerificationMethod
+			}
+		}
+	}
+
+	return nil
+}
+
+func isEd25519(vm *VerificationMethod) bool {
+	switch vm.Type {
+	case ed25519VerificationKey2018, ed25519VerificationKey2020:
+		return true
+	}
+
+	jwk := vm.JSONWebKey()
+
+	return jwk != nil && jwk.Kty == "OKP" && jwk.Crv == "Ed25519"
+}
+
+// ed25519PublicKey returns the raw Ed25519 public key of vm. The publicKeyMultibase of Ed25519VerificationKey2020
+// keys is the multicodec encoded key, its multicodec prefix is stripped.
+func ed25519PublicKey(vm *VerificationMethod) []byte {
+	if vm.Type == ed25519VerificationKey2020 && len(vm.Value) == len(ed25519MultiCodec)+ed25519.PublicKeySize &&
+		strings.HasPrefix(string(vm.Value), ed25519MultiCodec) {
+		return vm.Value[len(ed25519MultiCodec):]
+	}
+
+	return vm.Value
+}
@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package did
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+func TestX25519FromEd25519(t *testing.T) {
+	t.Run("libsodium ed25519_convert test vector", func(t *testing.T) {
+		// https://github.com/jedisct1/libsodium/blob/master/test/default/ed25519_convert.c
+		seed, err := hex.DecodeString("421151a459faeade3d247115f94aedae42318124095afabe4d1451a559faedee")
+		require.NoError(t, err)
+
+		pubKey := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+
+		x25519PubKey, err := X25519FromEd25519(pubKey)
+		require.NoError(t, err)
+		require.Equal(t, "f1814f0e8ff1043d8a44d25babff3cedcae6c22c3edaa48f857ae70de2baae50",
+			hex.EncodeToString(x25519PubKey))
+	})
+
+	t.Run("invalid key size", func(t *testing.T) {
+		_, err := X25519FromEd25519([]byte("short"))
+		require.EqualError(t, err, "convert Ed25519 key to X25519: 5-byte key size is invalid")
+	})
+}
+
+func TestDoc_AddKeyAgreementFromEd25519(t *testing.T) {
+	// did:key test vector: https://w3c-ccg.github.io/did-method-key/#example-2
+	const (
+		didKey       = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		ed25519KeyID = didKey + "#z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+		x25519KeyID  = didKey + "#z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc"
+	)
+
+	ed25519PubKey := base58.Decode("z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"[1:])[2:]
+
+	newDoc := func() *Doc {
+		vm := NewVerificationMethodFromBytes(ed25519KeyID, ed25519VerificationKey2018, didKey, ed25519PubKey)
+
+		return &Doc{
+			Context:            []string{ContextV1},
+			ID:                 didKey,
+			VerificationMethod: []VerificationMethod{*vm},
+			Authentication:     []Verification{*NewReferencedVerification(vm, Authentication)},
+		}
+	}
+
+	t.Run("did:key test vector", func(t *testing.T) {
+		doc := newDoc()
+
+		keyAgreement, err := doc.AddKeyAgreementFromEd25519(ed25519KeyID)
+		require.NoError(t, err)
+		require.Equal(t, x25519KeyID, keyAgreement.ID)
+		require.Equal(t, x25519KeyAgreementKey2019, keyAgreement.Type)
+		require.Equal(t, didKey, keyAgreement.Controller)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.True(t, doc.KeyAgreement[0].Embedded)
+		require.Equal(t, KeyAgreement, doc.KeyAgreement[0].Relationship)
+		require.Equal(t, *keyAgreement, doc.KeyAgreement[0].VerificationMethod)
+
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+		require.Len(t, parsed.KeyAgreement, 1)
+		require.Equal(t, x25519KeyID, parsed.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, keyAgreement.Value, parsed.KeyAgreement[0].VerificationMethod.Value)
+
+		_, err = doc.AddKeyAgreementFromEd25519(ed25519KeyID)
+		require.EqualError(t, err, "key agreement "+x25519KeyID+" already exists")
+	})
+
+	t.Run("relative ID", func(t *testing.T) {
+		keyAgreement, err := newDoc().AddKeyAgreementFromEd25519("#z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH")
+		require.NoError(t, err)
+		require.Equal(t, x25519KeyID, keyAgreement.ID)
+	})
+
+	t.Run("Ed25519VerificationKey2020", func(t *testing.T) {
+		docBytes := []byte(`{
+  "@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/ed25519-2020/v1"],
+  "id": "` + didKey + `",
+  "verificationMethod": [{
+    "id": "` + ed25519KeyID + `",
+    "type": "Ed25519VerificationKey2020",
+    "controller": "` + didKey + `",
+    "publicKeyMultibase": "z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+  }]
+}`)
+
+		doc, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+
+		keyAgreement, err := doc.AddKeyAgreementFromEd25519(ed25519KeyID)
+		require.NoError(t, err)
+		require.Equal(t, x25519KeyID, keyAgreement.ID)
+
+		// a raw Ed25519VerificationKey2020 key is used as is
+		vm := NewVerificationMethodFromBytes(ed25519KeyID, ed25519VerificationKey2020, didKey, ed25519PubKey)
+		doc = &Doc{ID: didKey, VerificationMethod: []VerificationMethod{*vm}}
+
+		keyAgreement, err = doc.AddKeyAgreementFromEd25519(ed25519KeyID)
+		require.NoError(t, err)
+		require.Equal(t, x25519KeyID, keyAgreement.ID)
+	})
+
+	t.Run("Ed25519 JWK", func(t *testing.T) {
+		jwk, err := jose.JWKFromKey(ed25519.PublicKey(ed25519PubKey))
+		require.NoError(t, err)
+
+		vm, err := NewVerificationMethodFromJWK(didKey+"#jwk", "JsonWebKey2020", didKey, jwk)
+		require.NoError(t, err)
+
+		doc := &Doc{ID: didKey, VerificationMethod: []VerificationMethod{*vm}}
+
+		keyAgreement, err := doc.AddKeyAgreementFromEd25519(didKey + "#jwk")
+		require.NoError(t, err)
+		require.Equal(t, x25519KeyID, keyAgreement.ID)
+	})
+
+	t.Run("verification method not found", func(t *testing.T) {
+		_, err := newDoc().AddKeyAgreementFromEd25519(didKey + "#unknown")
+		require.EqualError(t, err, "verification method "+didKey+"#unknown not found")
+	})
+
+	t.Run("not an Ed25519 key", func(t *testing.T) {
+		doc := newDoc()
+		doc.VerificationMethod[0].Type = "EcdsaSecp256k1VerificationKey2019"
+
+		_, err := doc.AddKeyAgreementFromEd25519(ed25519KeyID)
+		require.EqualError(t, err, "verification method "+ed25519KeyID+
+			" is not an Ed25519 key: EcdsaSecp256k1VerificationKey2019")
+	})
+}
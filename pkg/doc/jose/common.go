@@ -87,6 +87,8 @@ const (
 	// A256GCMALG is the default content encryption algorithm value as per
 	// the JWA specification: https://tools.ietf.org/html/rfc7518#section-5.1
 	A256GCMALG = "A256GCM"
+	// A128GCMALG represents AES128-GCM content encryption algorithm value.
+	A128GCMALG = "A128GCM"
 	// XC20PALG represents XChacha20Poly1305 content encryption algorithm value.
 	XC20PALG = "XC20P"
 	// A128CBCHS256ALG represents AES_128_CBC_HMAC_SHA_256 encryption algorithm value.
@@ -100,6 +102,7 @@ const (
 )
 
 var aeadAlg = map[EncAlg]ecdh.AEADAlg{ //nolint:gochecknoglobals
+	A128GCM:      ecdh.AES128GCM,
 	A256GCM:      ecdh.AES256GCM,
 	XC20P:        ecdh.XC20P,
 	A128CBCHS256: ecdh.AES128CBCHMACSHA256,
@@ -215,7 +215,7 @@ func (jd *JWEDecrypt) validateAndExtractProtectedHeaders(jwe *JSONWebEncryption)
 	}
 
 	switch encAlg {
-	case string(A256GCM), string(XC20P), string(A128CBCHS256),
+	case string(A128GCM), string(A256GCM), string(XC20P), string(A128CBCHS256),
 		string(A192CBCHS384), string(A256CBCHS384), string(A256CBCHS512):
 	default:
 		return "", fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
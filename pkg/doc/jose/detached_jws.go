@@ -0,0 +1,288 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	_ "crypto/sha256" // register the hash functions of the ECDSA algorithms.
+	_ "crypto/sha512"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"golang.org/x/crypto/ed25519"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+)
+
+const (
+	// AlgEdDSA is the JWS algorithm for Ed25519 signatures.
+	AlgEdDSA = "EdDSA"
+	// AlgES256 is the JWS algorithm for ECDSA signatures using P-256 and SHA-256.
+	AlgES256 = "ES256"
+	// AlgES384 is the JWS algorithm for ECDSA signatures using P-384 and SHA-384.
+	AlgES384 = "ES384"
+	// AlgES512 is the JWS algorithm for ECDSA signatures using P-521 and SHA-512.
+	AlgES512 = "ES512"
+	// AlgES256K is the JWS algorithm for ECDSA signatures using secp256k1 and SHA-256.
+	AlgES256K = "ES256K"
+)
+
+type ecdsaAlgorithm struct {
+	curve elliptic.Curve
+	hash  crypto.Hash
+}
+
+// ecdsaAlgorithms holds the curve and hash of the supported ECDSA JWS algorithms.
+var ecdsaAlgorithms = map[string]ecdsaAlgorithm{ //nolint:gochecknoglobals
+	AlgES256:  {curve: elliptic.P256(), hash: crypto.SHA256},
+	AlgES384:  {curve: elliptic.P384(), hash: crypto.SHA384},
+	AlgES512:  {curve: elliptic.P521(), hash: crypto.SHA512},
+	AlgES256K: {curve: btcec.S256(), hash: crypto.SHA256},
+}
+
+// curveAlgorithms maps the JWK curve names to their JWS algorithm.
+var curveAlgorithms = map[string]string{ //nolint:gochecknoglobals
+	"Ed25519":   AlgEdDSA,
+	"P-256":     AlgES256,
+	"P-384":     AlgES384,
+	"P-521":     AlgES512,
+	"secp256k1": AlgES256K,
+}
+
+// DetachedJWS creates and verifies JWS with a detached unencoded payload (https://tools.ietf.org/html/rfc7797), as
+// set in the "jws" property of Linked Data proofs (e.g. Ed25519Signature2018 and JsonWebSignature2020).
+// Signing is delegated to a Crypto service so that private keys never leave the KMS.
+type DetachedJWS struct {
+	crypto cryptoapi.Crypto
+}
+
+// NewDetachedJWS returns a new DetachedJWS signing with c.
+func NewDetachedJWS(c cryptoapi.Crypto) *DetachedJWS {
+	return &DetachedJWS{crypto: c}
+}
+
+// SignDetached signs payload with the private key in kh and returns the JWS compact serialization without the
+// payload. The header holds the given alg (one of EdDSA, ES256, ES384, ES512 or ES256K), "b64": false and
+// "crit": ["b64"]. ECDSA signatures of DER key types are converted to the IEEE-P1363 format required by JWS.
+func (d *DetachedJWS) SignDetached(payload []byte, kh interface{}, alg string) (string, error) {
+	if !isSupported(alg) {
+		return "", fmt.Errorf("sign detached JWS: unsupported algorithm '%s'", alg)
+	}
+
+	jws, err := NewJWS(Headers{
+		HeaderB64Payload: false,
+		HeaderCritical:   []string{HeaderB64Payload},
+	}, nil, payload, &cryptoSigner{crypto: d.crypto, kh: kh, alg: alg})
+	if err != nil {
+		return "", fmt.Errorf("sign detached JWS: %w", err)
+	}
+
+	return jws.SerializeCompact(true)
+}
+
+// VerifyDetached verifies the detached jws (as created by SignDetached) against payload with pubKey.
+// For Ed25519, pubKey.X holds the public key. For EC keys, pubKey.X and pubKey.Y hold the coordinates of the public
+// point. The algorithm is derived from the JWK curve name of pubKey.Curve if set, or else from the key itself; the
+// "alg" header must match it.
+func (d *DetachedJWS) VerifyDetached(jws string, payload []byte, pubKey *cryptoapi.PublicKey) error {
+	if pubKey == nil {
+		return errors.New("verify detached JWS: public key is required")
+	}
+
+	_, err := ParseJWS(jws, SignatureVerifierFunc(
+		func(joseHeaders Headers, _, signingInput, signature []byte) error {
+			alg, _ := joseHeaders.Algorithm()
+
+			return verifySignature(alg, signingInput, signature, pubKey)
+		}), WithJWSDetachedPayload(payload))
+	if err != nil {
+		return fmt.Errorf("verify detached JWS: %w", err)
+	}
+
+	return nil
+}
+
+// NewCryptoSigner returns a Signer creating JWS signatures with the private key in kh through c. The "alg" header
+// is set to alg (one of EdDSA, ES256, ES384, ES512 or ES256K).
+func NewCryptoSigner(c cryptoapi.Crypto, kh interface{}, alg string) Signer {
+	return &cryptoSigner{crypto: c, kh: kh, alg: alg}
+}
+
+// NewPublicKeyVerifier returns a SignatureVerifier checking JWS signatures with pubKey. As for VerifyDetached, the
+// algorithm is derived from pubKey and the "alg" header must match it.
+func NewPublicKeyVerifier(pubKey *cryptoapi.PublicKey) SignatureVerifier {
+	return SignatureVerifierFunc(func(joseHeaders Headers, _, signingInput, signature []byte) error {
+		if pubKey == nil {
+			return errors.New("public key is required")
+		}
+
+		alg, _ := joseHeaders.Algorithm()
+
+		return verifySignature(alg, signingInput, signature, pubKey)
+	})
+}
+
+// cryptoSigner is a Signer signing with a key handle through a Crypto service.
+type cryptoSigner struct {
+	crypto cryptoapi.Crypto
+	kh     interface{}
+	alg    string
+}
+
+func (s *cryptoSigner) Sign(data []byte) ([]byte, error) {
+	if !isSupported(s.alg) {
+		return nil, fmt.Errorf("unsupported algorithm '%s'", s.alg)
+	}
+
+	signature, err := s.crypto.Sign(data, s.kh)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.alg == AlgEdDSA {
+		return signature, nil
+	}
+
+	size := coordinateSize(ecdsaAlgorithms[s.alg].curve)
+	if len(signature) == 2*size {
+		return signature, nil
+	}
+
+	return derToIEEEP1363(signature, size)
+}
+
+func (s *cryptoSigner) Headers() Headers {
+	return Headers{HeaderAlgorithm: s.alg}
+}
+
+func derToIEEEP1363(signature []byte, size int) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil || len(rest) != 0 {
+		return nil, errors.New("signature is neither in IEEE-P1363 nor in DER format")
+	}
+
+	if sig.R.Sign() < 0 || sig.S.Sign() < 0 || sig.R.BitLen() > size*8 || sig.S.BitLen() > size*8 {
+		return nil, errors.New("signature does not match the key curve")
+	}
+
+	ieeeSignature := make([]byte, 2*size)
+
+	sig.R.FillBytes(ieeeSignature[:size])
+	sig.S.FillBytes(ieeeSignature[size:])
+
+	return ieeeSignature, nil
+}
+
+func isSupported(alg string) bool {
+	_, ok := ecdsaAlgorithms[alg]
+
+	return ok || alg == AlgEdDSA
+}
+
+func coordinateSize(curve elliptic.Curve) int {
+	return (curve.Params().BitSize + 7) / 8 //nolint:gomnd
+}
+
+// keyAlgorithm returns the JWS algorithm of pubKey.
+func keyAlgorithm(pubKey *cryptoapi.PublicKey) (string, error) {
+	if pubKey.Curve != "" {
+		alg, ok := curveAlgorithms[pubKey.Curve]
+		if !ok {
+			return "", fmt.Errorf("unsupported curve '%s'", pubKey.Curve)
+		}
+
+		return alg, nil
+	}
+
+	if len(pubKey.Y) == 0 {
+		return AlgEdDSA, nil
+	}
+
+	x, y := new(big.Int).SetBytes(pubKey.X), new(big.Int).SetBytes(pubKey.Y)
+
+	for _, alg := range []string{AlgES256, AlgES256K, AlgES384, AlgES512} {
+		curve := ecdsaAlgorithms[alg].curve
+
+		// btcec only accepts coordinates of its size.
+		if x.BitLen() > curve.Params().BitSize || y.BitLen() > curve.Params().BitSize {
+			continue
+		}
+
+		if curve.IsOnCurve(x, y) {
+			return alg, nil
+		}
+	}
+
+	return "", errors.New("public key is not on a supported curve")
+}
+
+// verifySignature verifies signature with pubKey. The alg of the (untrusted) JWS header is only checked against the
+// algorithm of pubKey.
+func verifySignature(alg string, signingInput, signature []byte, pubKey *cryptoapi.PublicKey) error {
+	if !isSupported(alg) {
+		return fmt.Errorf("unsupported algorithm '%s'", alg)
+	}
+
+	keyAlg, err := keyAlgorithm(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if alg != keyAlg {
+		return fmt.Errorf("algorithm '%s' does not match the public key algorithm '%s'", alg, keyAlg)
+	}
+
+	if keyAlg != AlgEdDSA {
+		return verifyECDSASignature(ecdsaAlgorithms[keyAlg], signingInput, signature, pubKey)
+	}
+
+	if len(pubKey.X) != ed25519.PublicKeySize {
+		return errors.New("invalid Ed25519 public key")
+	}
+
+	if !ed25519.Verify(pubKey.X, signingInput, signature) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+func verifyECDSASignature(alg ecdsaAlgorithm, signingInput, signature []byte, pubKey *cryptoapi.PublicKey) error {
+	x, y := new(big.Int).SetBytes(pubKey.X), new(big.Int).SetBytes(pubKey.Y)
+	bitSize := alg.curve.Params().BitSize
+
+	if x.BitLen() > bitSize || y.BitLen() > bitSize || !alg.curve.IsOnCurve(x, y) {
+		return errors.New("public key is not on the curve")
+	}
+
+	size := coordinateSize(alg.curve)
+
+	if len(signature) != 2*size {
+		return errors.New("invalid signature size")
+	}
+
+	hasher := alg.hash.New()
+	hasher.Write(signingInput) //nolint:errcheck // hash writes never fail.
+
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+
+	if !ecdsa.Verify(&ecdsa.PublicKey{Curve: alg.curve, X: x, Y: y}, hasher.Sum(nil), r, s) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestDetachedJWS(t *testing.T) {
+	kmsSvc, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	detachedJWS := jose.NewDetachedJWS(cryptoSvc)
+	payload := []byte("canonicalized proof options and document digests")
+
+	tests := []struct {
+		keyType kms.KeyType
+		alg     string
+	}{
+		{keyType: kms.ED25519Type, alg: jose.AlgEdDSA},
+		{keyType: kms.ECDSAP256TypeIEEEP1363, alg: jose.AlgES256},
+		{keyType: kms.ECDSAP256TypeDER, alg: jose.AlgES256},
+		{keyType: kms.ECDSAP384TypeIEEEP1363, alg: jose.AlgES384},
+		{keyType: kms.ECDSAP521TypeIEEEP1363, alg: jose.AlgES512},
+		{keyType: kms.ECDSAP521TypeDER, alg: jose.AlgES512},
+		{keyType: kms.ECDSASecp256k1TypeIEEEP1363, alg: jose.AlgES256K},
+		{keyType: kms.ECDSASecp256k1TypeDER, alg: jose.AlgES256K},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(string(tc.keyType), func(t *testing.T) {
+			kid, kh, err := kmsSvc.Create(tc.keyType)
+			require.NoError(t, err)
+
+			pubKeyBytes, err := kmsSvc.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+
+			pubKey := toPublicKey(t, tc.keyType, pubKeyBytes)
+
+			jws, err := detachedJWS.SignDetached(payload, kh, tc.alg)
+			require.NoError(t, err)
+
+			jwsParts := strings.Split(jws, ".")
+			require.Len(t, jwsParts, 3)
+			require.Empty(t, jwsParts[1])
+
+			headersBytes, err := base64.RawURLEncoding.DecodeString(jwsParts[0])
+			require.NoError(t, err)
+
+			var headers map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(headersBytes, &headers))
+			require.Equal(t, map[string]interface{}{
+				"alg":  tc.alg,
+				"b64":  false,
+				"crit": []interface{}{"b64"},
+			}, headers)
+
+			require.NoError(t, detachedJWS.VerifyDetached(jws, payload, pubKey))
+
+			err = detachedJWS.VerifyDetached(jws, []byte("other payload"), pubKey)
+			require.EqualError(t, err, "verify detached JWS: invalid signature")
+		})
+	}
+
+	t.Run("key and algorithm mismatch", func(t *testing.T) {
+		kid, kh, err := kmsSvc.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		pubKeyBytes, err := kmsSvc.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+
+		jws, err := detachedJWS.SignDetached(payload, kh, jose.AlgES256K)
+		require.NoError(t, err)
+
+		pubKey := toPublicKey(t, kms.ECDSAP256TypeIEEEP1363, pubKeyBytes)
+
+		err = detachedJWS.VerifyDetached(jws, payload, pubKey)
+		require.EqualError(t, err,
+			"verify detached JWS: algorithm 'ES256K' does not match the public key algorithm 'ES256'")
+
+		err = detachedJWS.VerifyDetached(jws, payload, &cryptoapi.PublicKey{X: []byte("not an Ed25519 key")})
+		require.EqualError(t, err,
+			"verify detached JWS: algorithm 'ES256K' does not match the public key algorithm 'EdDSA'")
+
+		// the alg is derived from the key, not from the header.
+		pubKey.Curve = "secp256k1"
+
+		err = detachedJWS.VerifyDetached(jws, payload, pubKey)
+		require.EqualError(t, err, "verify detached JWS: public key is not on the curve")
+
+		pubKey.Curve = "P-256"
+
+		jws, err = detachedJWS.SignDetached(payload, kh, jose.AlgES256)
+		require.NoError(t, err)
+		require.NoError(t, detachedJWS.VerifyDetached(jws, payload, pubKey))
+
+		pubKey.Curve = "X25519"
+
+		err = detachedJWS.VerifyDetached(jws, payload, pubKey)
+		require.EqualError(t, err, "verify detached JWS: unsupported curve 'X25519'")
+
+		err = detachedJWS.VerifyDetached(jws, payload, &cryptoapi.PublicKey{X: []byte{1}, Y: []byte{1}})
+		require.EqualError(t, err, "verify detached JWS: public key is not on a supported curve")
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		_, kh, err := kmsSvc.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = detachedJWS.SignDetached(payload, kh, "RS256")
+		require.EqualError(t, err, "sign detached JWS: unsupported algorithm 'RS256'")
+
+		jws, err := jose.NewJWS(jose.Headers{"b64": false, "crit": []string{"b64"}}, nil, payload,
+			&testSigner{alg: "RS256"})
+		require.NoError(t, err)
+
+		serializedJWS, err := jws.SerializeCompact(true)
+		require.NoError(t, err)
+
+		err = detachedJWS.VerifyDetached(serializedJWS, payload, &cryptoapi.PublicKey{})
+		require.EqualError(t, err, "verify detached JWS: unsupported algorithm 'RS256'")
+	})
+
+	t.Run("invalid keys and signatures", func(t *testing.T) {
+		err := detachedJWS.VerifyDetached("", payload, nil)
+		require.EqualError(t, err, "verify detached JWS: public key is required")
+
+		_, err = jose.NewDetachedJWS(&mockcrypto.Crypto{SignErr: errors.New("sign error")}).
+			SignDetached(payload, nil, jose.AlgEdDSA)
+		require.EqualError(t, err, "sign detached JWS: sign JWS: sign JWS verification data: sign error")
+
+		_, err = jose.NewCryptoSigner(&mockcrypto.Crypto{}, nil, "RS256").Sign(payload)
+		require.EqualError(t, err, "unsupported algorithm 'RS256'")
+
+		tooLongDER, err := asn1.Marshal(struct{ R, S *big.Int }{
+			R: new(big.Int).Lsh(big.NewInt(1), 300), S: big.NewInt(1),
+		})
+		require.NoError(t, err)
+
+		_, err = jose.NewDetachedJWS(&mockcrypto.Crypto{SignValue: tooLongDER}).
+			SignDetached(payload, nil, jose.AlgES256)
+		require.EqualError(t, err, "sign detached JWS: sign JWS: sign JWS verification data: "+
+			"signature does not match the key curve")
+
+		_, err = jose.NewDetachedJWS(&mockcrypto.Crypto{SignValue: []byte("not DER")}).
+			SignDetached(payload, nil, jose.AlgES256)
+		require.EqualError(t, err, "sign detached JWS: sign JWS: sign JWS verification data: "+
+			"signature is neither in IEEE-P1363 nor in DER format")
+
+		jws, err := jose.NewDetachedJWS(&mockcrypto.Crypto{SignValue: []byte("signature")}).
+			SignDetached(payload, nil, jose.AlgEdDSA)
+		require.NoError(t, err)
+
+		err = detachedJWS.VerifyDetached(jws, payload, &cryptoapi.PublicKey{X: []byte("short key")})
+		require.EqualError(t, err, "verify detached JWS: invalid Ed25519 public key")
+
+		jws, err = jose.NewDetachedJWS(&mockcrypto.Crypto{SignValue: make([]byte, 64)}).
+			SignDetached(payload, nil, jose.AlgES256)
+		require.NoError(t, err)
+
+		curveParams := elliptic.P256().Params()
+
+		err = detachedJWS.VerifyDetached(jws+"AA", payload,
+			&cryptoapi.PublicKey{X: curveParams.Gx.Bytes(), Y: curveParams.Gy.Bytes()})
+		require.EqualError(t, err, "verify detached JWS: invalid signature size")
+	})
+}
+
+func toPublicKey(t *testing.T, keyType kms.KeyType, pubKeyBytes []byte) *cryptoapi.PublicKey {
+	t.Helper()
+
+	switch keyType {
+	case kms.ED25519Type:
+		return &cryptoapi.PublicKey{X: pubKeyBytes}
+	case kms.ECDSAP256TypeIEEEP1363, kms.ECDSAP384TypeIEEEP1363, kms.ECDSAP521TypeIEEEP1363:
+		curves := map[kms.KeyType]elliptic.Curve{
+			kms.ECDSAP256TypeIEEEP1363: elliptic.P256(),
+			kms.ECDSAP384TypeIEEEP1363: elliptic.P384(),
+			kms.ECDSAP521TypeIEEEP1363: elliptic.P521(),
+		}
+
+		x, y := elliptic.Unmarshal(curves[keyType], pubKeyBytes)
+		require.NotNil(t, x)
+
+		return &cryptoapi.PublicKey{X: x.Bytes(), Y: y.Bytes()}
+	case kms.ECDSAP256TypeDER, kms.ECDSAP384TypeDER, kms.ECDSAP521TypeDER:
+		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+		require.NoError(t, err)
+
+		ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+		require.True(t, ok)
+
+		return &cryptoapi.PublicKey{X: ecPubKey.X.Bytes(), Y: ecPubKey.Y.Bytes()}
+	default:
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		require.NoError(t, err)
+
+		return &cryptoapi.PublicKey{X: pubKey.X.Bytes(), Y: pubKey.Y.Bytes()}
+	}
+}
+
+type testSigner struct {
+	alg string
+}
+
+func (s *testSigner) Sign([]byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+func (s *testSigner) Headers() jose.Headers {
+	return jose.Headers{jose.HeaderAlgorithm: s.alg}
+}
@@ -39,6 +39,8 @@ import (
 type EncAlg string
 
 const (
+	// A128GCM for AES128GCM content encryption.
+	A128GCM = EncAlg(A128GCMALG)
 	// A256GCM for AES256GCM content encryption.
 	A256GCM = EncAlg(A256GCMALG)
 	// XC20P for XChacha20Poly1305 content encryption.
@@ -72,18 +74,43 @@ type JWEEncrypt struct {
 	encTyp         string
 	cty            string
 	crypto         cryptoapi.Crypto
+	useA256KW      bool
+}
+
+// jweEncryptOpts holds options for the JWE encryption.
+type jweEncryptOpts struct {
+	useA256KW bool
+}
+
+// JWEEncryptOpt is the JWE Encrypter option.
+type JWEEncryptOpt func(opts *jweEncryptOpts)
+
+// WithA256KW option is for using `ECDH-ES+A256KW` key wrapping for all recipients. By default, recipients with OKP
+// (X25519) keys use `ECDH-ES+XC20PKW` key wrapping. Content encryption remains set by encAlg, this option allows
+// building a JWE with `enc` set to `XC20P` and `alg` set to `ECDH-ES+A256KW` regardless of the recipients' key type.
+// It is only supported for Anoncrypt (ECDH-ES).
+func WithA256KW() JWEEncryptOpt {
+	return func(opts *jweEncryptOpts) {
+		opts.useA256KW = true
+	}
 }
 
 // NewJWEEncrypt creates a new JWEEncrypt instance to build JWE with recipientsPubKeys
 // senderKID and senderKH are used for Authcrypt (to authenticate the sender), if not set JWEEncrypt assumes Anoncrypt.
 func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, senderKH *keyset.Handle,
-	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto, opts ...JWEEncryptOpt) (*JWEEncrypt, error) {
 	if len(recipientsPubKeys) == 0 {
 		return nil, fmt.Errorf("empty recipientsPubKeys list")
 	}
 
+	eOpts := &jweEncryptOpts{}
+
+	for _, opt := range opts {
+		opt(eOpts)
+	}
+
 	switch encAlg {
-	case A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512:
+	case A128GCM, A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512:
 	default:
 		return nil, fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
 	}
@@ -97,6 +124,10 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 		if senderKID == "" {
 			return nil, errors.New("senderKID is required with senderKH")
 		}
+
+		if eOpts.useA256KW {
+			return nil, errors.New("A256KW option is only supported for Anoncrypt (ECDH-ES)")
+		}
 	}
 
 	return &JWEEncrypt{
@@ -107,6 +138,7 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 		encTyp:         envelopMediaType,
 		cty:            cty,
 		crypto:         crypto,
+		useA256KW:      eOpts.useA256KW,
 	}, nil
 }
 
@@ -355,7 +387,7 @@ func (je *JWEEncrypt) encodeAPUAPV(kek *cryptoapi.RecipientWrappedKey) {
 func (je *JWEEncrypt) getWrapKeyOpts(tag []byte, epk *cryptoapi.PrivateKey) []cryptoapi.WrapKeyOpts {
 	var wrapOpts []cryptoapi.WrapKeyOpts
 
-	if je.recipientsKeys[0].Type == "OKP" {
+	if je.recipientsKeys[0].Type == "OKP" && !je.useA256KW {
 		wrapOpts = append(wrapOpts, cryptoapi.WithXC20PKW())
 	}
 
@@ -565,6 +597,8 @@ func (je *JWEEncrypt) newCEK() []byte {
 	defKeySize := 32
 
 	switch je.encAlg {
+	case A128GCM:
+		return random.GetRandomBytes(uint32(subtle.AES128Size)) // cek: 16 bytes.
 	case A256GCM, XC20P:
 		return random.GetRandomBytes(uint32(defKeySize))
 	case A128CBCHS256:
@@ -618,6 +618,61 @@ func TestJWEEncryptRoundTrip(t *testing.T) {
 	}
 }
 
+func TestJWEEncryptXC20PWithA256KW(t *testing.T) {
+	for _, nbRec := range []int{1, 2} {
+		recKeys, recKHs, _ := createRecipientsByKeyTemplate(t, nbRec, ecdh.X25519ECDHKWKeyTemplate(),
+			kms.X25519ECDHKWType)
+
+		cryptoSvc, kmsSvc := createCryptoAndKMSServices(t, recKHs)
+
+		jweEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.XC20P, EnvelopeEncodingType,
+			DIDCommContentEncodingType, "", nil, recKeys, cryptoSvc, ariesjose.WithA256KW())
+		require.NoError(t, err)
+
+		pt := []byte("secret message")
+
+		jwe, err := jweEncrypter.Encrypt(pt)
+		require.NoError(t, err)
+
+		serializedJWE, err := jwe.FullSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		enc, ok := localJWE.ProtectedHeaders.Encryption()
+		require.True(t, ok)
+		require.Equal(t, string(ariesjose.XC20P), enc)
+
+		if nbRec == 1 {
+			alg, ok := localJWE.ProtectedHeaders.Algorithm()
+			require.True(t, ok)
+			require.Equal(t, tinkcrypto.ECDHESA256KWAlg, alg)
+		} else {
+			for _, rec := range localJWE.Recipients {
+				require.Equal(t, tinkcrypto.ECDHESA256KWAlg, rec.Header.Alg)
+			}
+		}
+
+		jweDecrypter := ariesjose.NewJWEDecrypt(nil, cryptoSvc, kmsSvc)
+
+		msg, err := jweDecrypter.Decrypt(localJWE)
+		require.NoError(t, err)
+		require.EqualValues(t, pt, msg)
+	}
+
+	t.Run("A256KW option with authcrypt fails", func(t *testing.T) {
+		c, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		recipients, recsKH, kids := createRecipients(t, 1)
+
+		_, err = ariesjose.NewJWEEncrypt(ariesjose.XC20P, EnvelopeEncodingType, DIDCommContentEncodingType,
+			kids[0], recsKH[kids[0]], recipients, c, ariesjose.WithA256KW())
+		require.EqualError(t, err, "A256KW option is only supported for Anoncrypt (ECDH-ES)")
+	})
+}
+
 func TestInteropWithGoJoseEncryptAndLocalJoseDecryptUsingCompactSerialize(t *testing.T) {
 	recECKeys, recKHs, recKIDs := createRecipients(t, 1)
 	gjRecipients := convertToGoJoseRecipients(t, recECKeys, recKIDs)
@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func ExampleJWEEncrypt_compactSerialization() {
+	kmsSvc, cryptoSvc := newExampleServices()
+
+	// The recipient creates a key in its KMS and shares the public key.
+	recipientKey := newExampleRecipientKey(kmsSvc)
+
+	// Without a sender key, the JWE is built for Anoncrypt: the content is encrypted with A256GCM and the content
+	// encryption key is wrapped for the recipient with ECDH-ES+A256KW.
+	encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", "", "", nil,
+		[]*cryptoapi.PublicKey{recipientKey}, cryptoSvc)
+	if err != nil {
+		panic(fmt.Errorf("failed to create JWE encrypter: %w", err))
+	}
+
+	jwe, err := encrypter.Encrypt([]byte("secret message"))
+	if err != nil {
+		panic(fmt.Errorf("failed to encrypt: %w", err))
+	}
+
+	compactJWE, err := jwe.CompactSerialize(json.Marshal)
+	if err != nil {
+		panic(fmt.Errorf("failed to serialize JWE: %w", err))
+	}
+
+	// The recipient parses the JWE and decrypts it with the private key held by its KMS.
+	parsedJWE, err := jose.Deserialize(compactJWE)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse JWE: %w", err))
+	}
+
+	plaintext, err := jose.NewJWEDecrypt(nil, cryptoSvc, kmsSvc).Decrypt(parsedJWE)
+	if err != nil {
+		panic(fmt.Errorf("failed to decrypt: %w", err))
+	}
+
+	alg, _ := parsedJWE.ProtectedHeaders.Algorithm()
+	enc, _ := parsedJWE.ProtectedHeaders.Encryption()
+
+	fmt.Println(alg)
+	fmt.Println(enc)
+	fmt.Println(string(plaintext))
+
+	// Output:
+	// ECDH-ES+A256KW
+	// A256GCM
+	// secret message
+}
+
+func ExampleJWEEncrypt_generalJSONSerialization() {
+	kmsSvc, cryptoSvc := newExampleServices()
+
+	recipientKeys := []*cryptoapi.PublicKey{
+		newExampleRecipientKey(kmsSvc),
+		newExampleRecipientKey(kmsSvc),
+	}
+
+	encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", "", "", nil, recipientKeys, cryptoSvc)
+	if err != nil {
+		panic(fmt.Errorf("failed to create JWE encrypter: %w", err))
+	}
+
+	jwe, err := encrypter.Encrypt([]byte("secret message"))
+	if err != nil {
+		panic(fmt.Errorf("failed to encrypt: %w", err))
+	}
+
+	// A JWE with more than one recipient can only use the general JSON serialization.
+	jsonJWE, err := jwe.FullSerialize(json.Marshal)
+	if err != nil {
+		panic(fmt.Errorf("failed to serialize JWE: %w", err))
+	}
+
+	parsedJWE, err := jose.Deserialize(jsonJWE)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse JWE: %w", err))
+	}
+
+	plaintext, err := jose.NewJWEDecrypt(nil, cryptoSvc, kmsSvc).Decrypt(parsedJWE)
+	if err != nil {
+		panic(fmt.Errorf("failed to decrypt: %w", err))
+	}
+
+	fmt.Println(len(parsedJWE.Recipients))
+	fmt.Println(string(plaintext))
+
+	// Output:
+	// 2
+	// secret message
+}
+
+func newExampleServices() (*localkms.LocalKMS, *tinkcrypto.Crypto) {
+	kmsSvc, err := localkms.New("local-lock://example/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	if err != nil {
+		panic(fmt.Errorf("failed to create KMS: %w", err))
+	}
+
+	cryptoSvc, err := tinkcrypto.New()
+	if err != nil {
+		panic(fmt.Errorf("failed to create crypto: %w", err))
+	}
+
+	return kmsSvc, cryptoSvc
+}
+
+func newExampleRecipientKey(kmsSvc kms.KeyManager) *cryptoapi.PublicKey {
+	kid, pubKeyBytes, err := kmsSvc.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+	if err != nil {
+		panic(fmt.Errorf("failed to create recipient key: %w", err))
+	}
+
+	recipientKey := new(cryptoapi.PublicKey)
+
+	err = json.Unmarshal(pubKeyBytes, recipientKey)
+	if err != nil {
+		panic(fmt.Errorf("failed to unmarshal recipient key: %w", err))
+	}
+
+	recipientKey.KID = kid
+
+	return recipientKey
+}
@@ -129,6 +129,13 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*JWK, error) { // noli
 		}
 
 		return JWKFromKey(ecKey)
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(bytes, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(pubKey.ToECDSA())
 	case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
 		crv := getECDSACurve(keyType)
 		pubKey := &cryptoapi.PublicKey{}
@@ -881,6 +881,14 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 			name:    "P-521 DER test",
 			keyType: kms.ECDSAP521TypeDER,
 		},
+		{
+			name:    "secp256k1 DER test",
+			keyType: kms.ECDSASecp256k1TypeDER,
+		},
+		{
+			name:    "secp256k1 IEEE1363 test",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+		},
 		{
 			name:    "Ed25519 test",
 			keyType: kms.ED25519Type,
@@ -963,6 +971,23 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 				require.NotEmpty(t, jwk)
 				require.Equal(t, "EC", jwk.Kty)
 				require.Equal(t, crv.Params().Name, jwk.Crv)
+			case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+				privKey, err := btcec.NewPrivateKey(btcec.S256())
+				require.NoError(t, err)
+
+				keyBytes := privKey.PubKey().SerializeUncompressed()
+				if tc.keyType == kms.ECDSASecp256k1TypeDER {
+					keyBytes = privKey.PubKey().SerializeCompressed()
+				}
+
+				jwk, err := PubKeyBytesToJWK(keyBytes, tc.keyType)
+				require.NoError(t, err)
+				require.NotEmpty(t, jwk)
+				require.Equal(t, "EC", jwk.Kty)
+				require.Equal(t, secp256k1Crv, jwk.Crv)
+
+				_, err = PubKeyBytesToJWK([]byte("not a key"), tc.keyType)
+				require.Error(t, err)
 			case kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
 				crv := getECDSACurve(tc.keyType)
 				privKey, err := ecdsa.GenerateKey(crv, rand.Reader)
@@ -13,6 +13,10 @@ import _ "embed"
 var (
 	//go:embed contexts/third_party/w3.org/credentials_v1.jsonld
 	w3orgCredentials []byte
+	//go:embed contexts/third_party/w3.org/credentials_v2.jsonld
+	w3orgCredentialsV2 []byte
+	//go:embed contexts/third_party/w3.org/data-integrity_v2.jsonld
+	w3orgDataIntegrityV2 []byte
 	//go:embed contexts/third_party/w3.org/did_v1.jsonld
 	w3orgDID []byte
 	//go:embed contexts/third_party/w3c-ccg.github.io/did_v0.11.jsonld
@@ -31,10 +35,16 @@ var (
 	securityV2 []byte
 	//go:embed contexts/third_party/w3c-ccg.github.io/revocationList2020.jsonld
 	revocationList2020 []byte
+	//go:embed contexts/third_party/w3c-ccg.github.io/vc-status-list-2021_v1.jsonld
+	statusList2021 []byte
 	//go:embed contexts/third_party/digitalbazaar.github.io/ed25519-signature-2018-v1.jsonld
 	ed255192018 []byte
+	//go:embed contexts/third_party/digitalbazaar.github.io/ed25519-signature-2020-v1.jsonld
+	ed255192020 []byte
 	//go:embed contexts/third_party/identity.foundation/presentation-submission_v1.jsonld
 	presentationSubmission []byte
+	//go:embed contexts/third_party/identity.foundation/credential-application_v1.jsonld
+	credentialApplication []byte
 	//go:embed contexts/third_party/ns.did.ai/x25519-2019_v1.jsonld
 	x255192019 []byte
 	//go:embed contexts/third_party/ns.did.ai/secp256k1-2019_v1.jsonld
@@ -47,6 +57,16 @@ var embedContexts = []ContextDocument{ //nolint:gochecknoglobals
 		DocumentURL: "https://www.w3.org/2018/credentials/v1",
 		Content:     w3orgCredentials,
 	},
+	{
+		URL:         "https://www.w3.org/ns/credentials/v2",
+		DocumentURL: "https://www.w3.org/ns/credentials/v2",
+		Content:     w3orgCredentialsV2,
+	},
+	{
+		URL:         "https://w3id.org/security/data-integrity/v2",
+		DocumentURL: "https://w3c.github.io/vc-data-integrity/contexts/data-integrity/v2",
+		Content:     w3orgDataIntegrityV2,
+	},
 	{
 		URL:         "https://www.w3.org/ns/did/v1",
 		DocumentURL: "https://www.w3.org/ns/did/v1",
@@ -102,16 +122,31 @@ var embedContexts = []ContextDocument{ //nolint:gochecknoglobals
 		DocumentURL: "https://w3c-ccg.github.io/vc-status-rl-2020/contexts/vc-revocation-list-2020/v1.jsonld",
 		Content:     revocationList2020,
 	},
+	{
+		URL:         "https://w3id.org/vc/status-list/2021/v1",
+		DocumentURL: "https://w3c-ccg.github.io/vc-status-list-2021/contexts/v1.jsonld",
+		Content:     statusList2021,
+	},
 	{
 		URL:         "https://identity.foundation/presentation-exchange/submission/v1",
 		DocumentURL: "https://identity.foundation/presentation-exchange/submission/v1/",
 		Content:     presentationSubmission,
 	},
+	{
+		URL:         "https://identity.foundation/credential-manifest/application/v1",
+		DocumentURL: "https://identity.foundation/credential-manifest/application/v1",
+		Content:     credentialApplication,
+	},
 	{
 		URL:         "https://w3id.org/security/suites/ed25519-2018/v1",
 		DocumentURL: "https://digitalbazaar.github.io/ed25519-signature-2018-context/contexts/ed25519-signature-2018-v1.jsonld", //nolint:lll
 		Content:     ed255192018,
 	},
+	{
+		URL:         "https://w3id.org/security/suites/ed25519-2020/v1",
+		DocumentURL: "https://digitalbazaar.github.io/ed25519-signature-2020-context/contexts/ed25519-signature-2020-v1.jsonld", //nolint:lll
+		Content:     ed255192020,
+	},
 	{
 		URL:         "https://w3id.org/security/suites/x25519-2019/v1",
 		DocumentURL: "https://ns.did.ai/suites/x25519-2019/v1/",
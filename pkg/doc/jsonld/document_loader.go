@@ -11,22 +11,48 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 // ContextsDBName is a name of DB for storing JSON-LD contexts.
 const ContextsDBName = "jsonldContexts"
 
-// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
-var ErrContextNotFound = errors.New("context document not found")
+// DefaultMaxContextDepth is the default maximum depth of nested remote contexts referenced by a remote context
+// document.
+const DefaultMaxContextDepth = 10
+
+var (
+	// ErrContextNotFound is returned when JSON-LD context document is not found in the underlying storage.
+	ErrContextNotFound = errors.New("context document not found")
+	// ErrContextCycle is returned when a remote JSON-LD context document references itself, directly or through
+	// other remote contexts.
+	ErrContextCycle = errors.New("context document cycle detected")
+	// ErrContextDepthExceeded is returned when a remote JSON-LD context document references a chain of remote
+	// contexts deeper than the maximum allowed depth.
+	ErrContextDepthExceeded = errors.New("context document max depth exceeded")
+)
 
 // DocumentLoader is an implementation of ld.DocumentLoader backed by storage.
 type DocumentLoader struct {
 	store                storage.Store
 	remoteDocumentLoader ld.DocumentLoader
+	remoteURLAllowlist   map[string]struct{}
+	remoteDocumentTTL    time.Duration
+	maxContextDepth      int
+	clock                clock.Clock
+}
+
+// cachedDocument is a context document saved in the underlying storage. ExpiresAt is set for documents fetched from
+// a remote URL when a TTL is configured.
+type cachedDocument struct {
+	ld.RemoteDocument
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // NewDocumentLoader returns a new DocumentLoader instance.
@@ -35,9 +61,10 @@ type DocumentLoader struct {
 // Additional contexts can be set using WithExtraContexts() option.
 //
 // By default, missing contexts are not fetched from the remote URL. Use WithRemoteDocumentLoader() option
-// to specify a custom loader that can resolve context documents from the network.
+// to specify a custom loader that can resolve context documents from the network. Remote fetching can be restricted
+// with WithRemoteURLAllowlist() and fetched documents can be refreshed after a TTL set with WithRemoteDocumentTTL().
 func NewDocumentLoader(storageProvider storage.Provider, opts ...DocumentLoaderOpts) (*DocumentLoader, error) {
-	options := &documentLoaderOpts{}
+	options := &documentLoaderOpts{maxContextDepth: DefaultMaxContextDepth}
 
 	for i := range opts {
 		opts[i](options)
@@ -55,9 +82,23 @@ func NewDocumentLoader(storageProvider storage.Provider, opts ...DocumentLoaderO
 		return nil, fmt.Errorf("save context documents: %w", err)
 	}
 
+	var allowlist map[string]struct{}
+
+	if len(options.remoteURLAllowlist) > 0 {
+		allowlist = make(map[string]struct{}, len(options.remoteURLAllowlist))
+
+		for _, u := range options.remoteURLAllowlist {
+			allowlist[u] = struct{}{}
+		}
+	}
+
 	return &DocumentLoader{
 		store:                store,
 		remoteDocumentLoader: options.remoteDocumentLoader,
+		remoteURLAllowlist:   allowlist,
+		remoteDocumentTTL:    options.remoteDocumentTTL,
+		maxContextDepth:      options.maxContextDepth,
+		clock:                clock.OrSystem(options.clock),
 	}, nil
 }
 
@@ -91,8 +132,51 @@ func save(store storage.Store, docs []ContextDocument) error {
 	return nil
 }
 
+// AddDocuments saves a bundle of JSON-LD context documents (context URL to document content) into the underlying
+// storage in a single batch. It is useful for registering a vetted offline set of contexts in one call.
+// A context URL that already exists in the storage is rejected unless overwrite is set to true, in which case the
+// stored document is replaced.
+func (l *DocumentLoader) AddDocuments(docs map[string][]byte, overwrite bool) error {
+	urls := make([]string, 0, len(docs))
+
+	for u := range docs {
+		urls = append(urls, u)
+	}
+
+	// sort URLs for a deterministic processing order.
+	sort.Strings(urls)
+
+	contexts := make([]ContextDocument, 0, len(docs))
+
+	for _, u := range urls {
+		if !overwrite {
+			_, err := l.store.Get(u)
+			if err == nil {
+				return fmt.Errorf("add documents: context document '%s' already exists", u)
+			}
+
+			if !errors.Is(err, storage.ErrDataNotFound) {
+				return fmt.Errorf("add documents: get context from store: %w", err)
+			}
+		}
+
+		contexts = append(contexts, ContextDocument{
+			URL:         u,
+			DocumentURL: u,
+			Content:     docs[u],
+		})
+	}
+
+	if err := save(l.store, contexts); err != nil {
+		return fmt.Errorf("add documents: %w", err)
+	}
+
+	return nil
+}
+
 // LoadDocument resolves JSON-LD context document by document URL (u) either from storage or from remote URL.
 // If document is not found in the storage and remote DocumentLoader is not specified, ErrContextNotFound is returned.
+// Documents previously fetched from a remote URL are fetched again once their TTL (if set) has expired.
 func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
 	b, err := l.store.Get(u)
 	if err != nil {
@@ -107,22 +191,39 @@ func (l *DocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
 		return l.loadDocumentFromURL(u)
 	}
 
-	var rd ld.RemoteDocument
+	var doc cachedDocument
 
-	if err := json.Unmarshal(b, &rd); err != nil {
+	if err := json.Unmarshal(b, &doc); err != nil {
 		return nil, fmt.Errorf("unmarshal context document: %w", err)
 	}
 
-	return &rd, nil
+	if doc.ExpiresAt != nil && l.clock.Now().After(*doc.ExpiresAt) && l.remoteDocumentLoader != nil {
+		return l.loadDocumentFromURL(u)
+	}
+
+	return &doc.RemoteDocument, nil
 }
 
 func (l *DocumentLoader) loadDocumentFromURL(u string) (*ld.RemoteDocument, error) {
-	rd, err := l.remoteDocumentLoader.LoadDocument(u)
+	rd, err := l.fetchRemoteDocument(u)
 	if err != nil {
 		return nil, fmt.Errorf("load remote context document: %w", err)
 	}
 
-	b, err := json.Marshal(rd)
+	// validate nested remote contexts before caching the document.
+	err = l.checkRemoteContexts(rd.Document, map[string]struct{}{u: {}}, map[string]int{}, 1)
+	if err != nil {
+		return nil, fmt.Errorf("load remote context document '%s': %w", u, err)
+	}
+
+	doc := cachedDocument{RemoteDocument: *rd}
+
+	if l.remoteDocumentTTL > 0 {
+		expiresAt := l.clock.Now().Add(l.remoteDocumentTTL)
+		doc.ExpiresAt = &expiresAt
+	}
+
+	b, err := json.Marshal(doc)
 	if err != nil {
 		return nil, fmt.Errorf("marshal remote document: %w", err)
 	}
@@ -134,9 +235,108 @@ func (l *DocumentLoader) loadDocumentFromURL(u string) (*ld.RemoteDocument, erro
 	return rd, nil
 }
 
+func (l *DocumentLoader) fetchRemoteDocument(u string) (*ld.RemoteDocument, error) {
+	if l.remoteURLAllowlist != nil {
+		if _, ok := l.remoteURLAllowlist[u]; !ok {
+			return nil, fmt.Errorf("url '%s' is not allowed", u)
+		}
+	}
+
+	return l.remoteDocumentLoader.LoadDocument(u)
+}
+
+// checkRemoteContexts walks the remote contexts referenced by document (through `@context` and `@import`) that are
+// not yet in the underlying storage. path holds the URLs of the contexts being walked to detect cycles and depth is
+// the number of nested remote contexts walked so far. checked holds the contexts already walked during this load
+// with the depth they were walked at, so that a context referenced several times is fetched again only if it is
+// now reached at a lower depth (and its nested contexts could exceed the maximum depth).
+func (l *DocumentLoader) checkRemoteContexts(document interface{}, path map[string]struct{}, checked map[string]int,
+	depth int) error {
+	for _, ref := range contextRefs(document) {
+		if _, ok := path[ref]; ok {
+			return fmt.Errorf("%w: '%s'", ErrContextCycle, ref)
+		}
+
+		if checkedDepth, ok := checked[ref]; ok && checkedDepth <= depth {
+			continue
+		}
+
+		_, err := l.store.Get(ref)
+		if err == nil {
+			continue // stored contexts are either preloaded or were already checked.
+		}
+
+		if !errors.Is(err, storage.ErrDataNotFound) {
+			return fmt.Errorf("get context from store: %w", err)
+		}
+
+		if depth >= l.maxContextDepth {
+			return fmt.Errorf("%w: %d", ErrContextDepthExceeded, l.maxContextDepth)
+		}
+
+		rd, err := l.fetchRemoteDocument(ref)
+		if err != nil {
+			return fmt.Errorf("load nested context '%s': %w", ref, err)
+		}
+
+		path[ref] = struct{}{}
+
+		err = l.checkRemoteContexts(rd.Document, path, checked, depth+1)
+		if err != nil {
+			return err
+		}
+
+		delete(path, ref)
+
+		checked[ref] = depth
+	}
+
+	return nil
+}
+
+// contextRefs returns the URLs of the remote contexts referenced by document's `@context`, including `@import`
+// values and term scoped contexts.
+func contextRefs(document interface{}) []string {
+	m, ok := document.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	return contextValueRefs(m["@context"])
+}
+
+func contextValueRefs(ctx interface{}) []string {
+	var refs []string
+
+	switch c := ctx.(type) {
+	case string:
+		refs = append(refs, c)
+	case []interface{}:
+		for _, v := range c {
+			refs = append(refs, contextValueRefs(v)...)
+		}
+	case map[string]interface{}:
+		if imp, ok := c["@import"].(string); ok {
+			refs = append(refs, imp)
+		}
+
+		for _, v := range c {
+			if def, ok := v.(map[string]interface{}); ok {
+				refs = append(refs, contextValueRefs(def["@context"])...)
+			}
+		}
+	}
+
+	return refs
+}
+
 type documentLoaderOpts struct {
 	remoteDocumentLoader ld.DocumentLoader
 	extraContexts        []ContextDocument
+	remoteURLAllowlist   []string
+	remoteDocumentTTL    time.Duration
+	maxContextDepth      int
+	clock                clock.Clock
 }
 
 // DocumentLoaderOpts configures DocumentLoader during creation.
@@ -163,3 +363,38 @@ func WithRemoteDocumentLoader(loader ld.DocumentLoader) DocumentLoaderOpts {
 		opts.remoteDocumentLoader = loader
 	}
 }
+
+// WithRemoteURLAllowlist restricts fetching JSON-LD context documents with the remote DocumentLoader to the given
+// URLs. Any other URL missing from the underlying storage is rejected without hitting the network, which prevents
+// fetching arbitrary URLs found in untrusted documents. By default, all URLs are allowed.
+func WithRemoteURLAllowlist(urls ...string) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.remoteURLAllowlist = urls
+	}
+}
+
+// WithRemoteDocumentTTL sets how long JSON-LD context documents fetched from remote URLs are cached in the underlying
+// storage. Once expired, a document is fetched again on its next load. By default, fetched documents never expire.
+// Preloaded contexts are not affected by this option.
+func WithRemoteDocumentTTL(ttl time.Duration) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.remoteDocumentTTL = ttl
+	}
+}
+
+// WithClock sets the clock the expiry of the JSON-LD context documents fetched from remote URLs is checked with.
+// Defaults to clock.SystemClock.
+func WithClock(c clock.Clock) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.clock = c
+	}
+}
+
+// WithMaxContextDepth sets the maximum depth of nested remote contexts a remote JSON-LD context document can
+// reference. Remote context documents exceeding this depth or referencing themselves are rejected. Defaults to
+// DefaultMaxContextDepth.
+func WithMaxContextDepth(depth int) DocumentLoaderOpts {
+	return func(opts *documentLoaderOpts) {
+		opts.maxContextDepth = depth
+	}
+}
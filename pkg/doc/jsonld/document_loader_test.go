@@ -9,13 +9,16 @@ package jsonld_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -28,7 +31,7 @@ func TestNewDocumentLoader(t *testing.T) {
 
 		require.NotNil(t, loader)
 		require.NoError(t, err)
-		require.Equal(t, 16, len(storageProvider.Store.Store))
+		require.Equal(t, 21, len(storageProvider.Store.Store))
 	})
 
 	t.Run("Fail to open context DB store", func(t *testing.T) {
@@ -173,6 +176,264 @@ func TestLoadDocument(t *testing.T) {
 	})
 }
 
+func TestLoadDocumentWithAllowlistAndTTL(t *testing.T) {
+	const contextURL = "https://example.com/context.jsonld"
+
+	t.Run("Fetch remote context document from allowed URL", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader),
+			jsonld.WithRemoteURLAllowlist(contextURL))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+		require.Equal(t, 1, remoteLoader.loadCount)
+	})
+
+	t.Run("Reject remote context document from URL not in allowlist", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader),
+			jsonld.WithRemoteURLAllowlist(contextURL))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://attacker.example.com/context.jsonld")
+		require.Nil(t, rd)
+		require.EqualError(t, err, "load remote context document: url "+
+			"'https://attacker.example.com/context.jsonld' is not allowed")
+		require.Equal(t, 0, remoteLoader.loadCount)
+
+		// preloaded contexts are still served from the storage
+		rd, err = loader.LoadDocument("https://www.w3.org/2018/credentials/v1")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+	})
+
+	t.Run("Cache remote context document until TTL expires", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader),
+			jsonld.WithRemoteDocumentTTL(50*time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, contextURL, rd.DocumentURL)
+		require.Equal(t, 1, remoteLoader.loadCount)
+
+		time.Sleep(100 * time.Millisecond)
+
+		rd, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, contextURL, rd.DocumentURL)
+		require.Equal(t, 2, remoteLoader.loadCount)
+	})
+
+	t.Run("Remote context document TTL is checked with the clock", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+		c := mockclock.New(time.Now())
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader),
+			jsonld.WithRemoteDocumentTTL(time.Hour),
+			jsonld.WithClock(c))
+		require.NoError(t, err)
+
+		_, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+
+		c.Add(30 * time.Minute)
+
+		_, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, 1, remoteLoader.loadCount)
+
+		c.Add(time.Hour)
+
+		_, err = loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, 2, remoteLoader.loadCount)
+	})
+
+	t.Run("Remote context document without TTL never expires", func(t *testing.T) {
+		remoteLoader := &mockDocumentLoader{}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader))
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err = loader.LoadDocument(contextURL)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, remoteLoader.loadCount)
+	})
+}
+
+func TestLoadDocumentWithNestedRemoteContexts(t *testing.T) {
+	const contextURL = "https://example.com/context.jsonld"
+
+	t.Run("Reject self-referential remote context", func(t *testing.T) {
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(mapDocumentLoader{
+				contextURL: `{"@context": {"@import": "` + contextURL + `"}}`,
+			}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.Nil(t, rd)
+		require.True(t, errors.Is(err, jsonld.ErrContextCycle))
+	})
+
+	t.Run("Reject remote contexts cycle", func(t *testing.T) {
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(mapDocumentLoader{
+				"https://example.com/a.jsonld": `{"@context": ["https://example.com/b.jsonld"]}`,
+				"https://example.com/b.jsonld": `{"@context": {"@import": "https://example.com/a.jsonld"}}`,
+			}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://example.com/a.jsonld")
+		require.Nil(t, rd)
+		require.True(t, errors.Is(err, jsonld.ErrContextCycle))
+	})
+
+	t.Run("Reject unbounded chain of remote contexts", func(t *testing.T) {
+		docs := mapDocumentLoader{}
+
+		for i := 0; i < 1000; i++ {
+			docs[fmt.Sprintf("https://example.com/%d.jsonld", i)] = fmt.Sprintf(
+				`{"@context": "https://example.com/%d.jsonld"}`, i+1)
+		}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(docs), jsonld.WithMaxContextDepth(5))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://example.com/0.jsonld")
+		require.Nil(t, rd)
+		require.True(t, errors.Is(err, jsonld.ErrContextDepthExceeded))
+	})
+
+	t.Run("Load remote context referencing shared and stored contexts", func(t *testing.T) {
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(mapDocumentLoader{
+				"https://example.com/a.jsonld": `{"@context": ["https://www.w3.org/2018/credentials/v1",
+					"https://example.com/b.jsonld", {"term": {"@id": "https://example.com/term",
+					"@context": "https://example.com/c.jsonld"}}]}`,
+				"https://example.com/b.jsonld": `{"@context": "https://example.com/c.jsonld"}`,
+				"https://example.com/c.jsonld": sampleJSONLDContext,
+			}))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://example.com/a.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+	})
+
+	t.Run("Fetch each nested remote context once", func(t *testing.T) {
+		docs := mapDocumentLoader{"https://example.com/20.jsonld": sampleJSONLDContext}
+
+		// every context references the next one twice, walking each reference would take 2^20 fetches.
+		for i := 0; i < 20; i++ {
+			docs[fmt.Sprintf("https://example.com/%d.jsonld", i)] = fmt.Sprintf(
+				`{"@context": ["https://example.com/%[1]d.jsonld", {"@import": "https://example.com/%[1]d.jsonld"}]}`,
+				i+1)
+		}
+
+		remoteLoader := &countingDocumentLoader{loader: docs, loadCount: map[string]int{}}
+
+		loader, err := jsonld.NewDocumentLoader(mockstorage.NewMockStoreProvider(),
+			jsonld.WithRemoteDocumentLoader(remoteLoader), jsonld.WithMaxContextDepth(30))
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument("https://example.com/0.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+
+		require.Len(t, remoteLoader.loadCount, 21)
+
+		for u, count := range remoteLoader.loadCount {
+			require.Equal(t, 1, count, u)
+		}
+	})
+}
+
+func TestAddDocuments(t *testing.T) {
+	const contextURL = "https://example.com/context.jsonld"
+
+	t.Run("Add a bundle of context documents", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		err = loader.AddDocuments(map[string][]byte{
+			contextURL:                         []byte(sampleJSONLDContext),
+			"https://example.com/other.jsonld": []byte(sampleJSONLDContext),
+		}, false)
+		require.NoError(t, err)
+
+		rd, err := loader.LoadDocument(contextURL)
+		require.NoError(t, err)
+		require.Equal(t, contextURL, rd.DocumentURL)
+
+		rd, err = loader.LoadDocument("https://example.com/other.jsonld")
+		require.NoError(t, err)
+		require.NotNil(t, rd)
+	})
+
+	t.Run("Reject already stored context document unless overwrite is set", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		err = loader.AddDocuments(map[string][]byte{
+			"https://www.w3.org/2018/credentials/v1": []byte(sampleJSONLDContext),
+		}, false)
+		require.EqualError(t, err, "add documents: context document "+
+			"'https://www.w3.org/2018/credentials/v1' already exists")
+
+		err = loader.AddDocuments(map[string][]byte{
+			"https://www.w3.org/2018/credentials/v1": []byte(sampleJSONLDContext),
+		}, true)
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail to get context from store", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+		storageProvider.Store.ErrGet = errors.New("get error")
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		err = loader.AddDocuments(map[string][]byte{contextURL: []byte(sampleJSONLDContext)}, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "get context from store")
+	})
+
+	t.Run("Fail to read context document", func(t *testing.T) {
+		storageProvider := mockstorage.NewMockStoreProvider()
+
+		loader, err := jsonld.NewDocumentLoader(storageProvider)
+		require.NoError(t, err)
+
+		err = loader.AddDocuments(map[string][]byte{contextURL: []byte("invalid")}, false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "document from reader")
+	})
+}
+
 const sampleJSONLDContext = `
 {
   "@context": {
@@ -186,9 +447,12 @@ const sampleJSONLDContext = `
 
 type mockDocumentLoader struct {
 	ErrLoadDocument error
+	loadCount       int
 }
 
 func (m *mockDocumentLoader) LoadDocument(string) (*ld.RemoteDocument, error) {
+	m.loadCount++
+
 	if m.ErrLoadDocument != nil {
 		return nil, m.ErrLoadDocument
 	}
@@ -203,3 +467,35 @@ func (m *mockDocumentLoader) LoadDocument(string) (*ld.RemoteDocument, error) {
 		Document:    content,
 	}, nil
 }
+
+// countingDocumentLoader counts the documents loaded through loader by URL.
+type countingDocumentLoader struct {
+	loader    ld.DocumentLoader
+	loadCount map[string]int
+}
+
+func (c *countingDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	c.loadCount[u]++
+
+	return c.loader.LoadDocument(u)
+}
+
+// mapDocumentLoader is a remote document loader serving JSON-LD documents by URL.
+type mapDocumentLoader map[string]string
+
+func (m mapDocumentLoader) LoadDocument(u string) (*ld.RemoteDocument, error) {
+	doc, ok := m[u]
+	if !ok {
+		return nil, fmt.Errorf("document '%s' not found", u)
+	}
+
+	content, err := ld.DocumentFromReader(strings.NewReader(doc))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ld.RemoteDocument{
+		DocumentURL: u,
+		Document:    content,
+	}, nil
+}
@@ -13,10 +13,12 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/square/go-jose/v3/json"
 	"github.com/square/go-jose/v3/jwt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 )
 
@@ -35,6 +37,7 @@ type Claims jwt.Claims
 type parseOpts struct {
 	detachedPayload []byte
 	sigVerifier     jose.SignatureVerifier
+	validityClock   clock.Clock
 }
 
 // ParseOpt is the JWT Parser option.
@@ -54,6 +57,14 @@ func WithSignatureVerifier(signatureVerifier jose.SignatureVerifier) ParseOpt {
 	}
 }
 
+// WithValidityPeriodCheck option enables the check of the validity period of JWT: the current time read from
+// the clock (clock.SystemClock if nil) must not be before its "nbf" claim nor after its "exp" claim.
+func WithValidityPeriodCheck(c clock.Clock) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.validityClock = clock.OrSystem(c)
+	}
+}
+
 type signatureVerifierFunc func(joseHeaders jose.Headers, payload, signingInput, signature []byte) error
 
 func (v signatureVerifierFunc) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
@@ -162,7 +173,36 @@ func parseJWS(jwtSerialized string, opts *parseOpts) (*JSONWebToken, error) {
 		return nil, fmt.Errorf("parse JWT from compact JWS: %w", err)
 	}
 
-	return mapJWSToJWT(jws)
+	token, err := mapJWSToJWT(jws)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.validityClock != nil {
+		if err = checkValidityPeriod(token, opts.validityClock.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return token, nil
+}
+
+func checkValidityPeriod(token *JSONWebToken, now time.Time) error {
+	var claims Claims
+
+	if err := token.DecodeClaims(&claims); err != nil {
+		return fmt.Errorf("decode JWT claims: %w", err)
+	}
+
+	if claims.NotBefore != nil && now.Before(claims.NotBefore.Time()) {
+		return fmt.Errorf("JWT is not valid before %s", claims.NotBefore.Time().UTC().Format(time.RFC3339))
+	}
+
+	if claims.Expiry != nil && !now.Before(claims.Expiry.Time()) {
+		return fmt.Errorf("JWT expired at %s", claims.Expiry.Time().UTC().Format(time.RFC3339))
+	}
+
+	return nil
 }
 
 func mapJWSToJWT(jws *jose.JSONWebSignature) (*JSONWebToken, error) {
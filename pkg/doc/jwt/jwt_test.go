@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 )
 
 type CustomClaim struct {
@@ -201,6 +202,40 @@ func TestParse(t *testing.T) {
 	r.Nil(token)
 }
 
+func TestParseWithValidityPeriodCheck(t *testing.T) {
+	issued := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	token, err := NewUnsecured(&Claims{
+		Issuer:    "Albert",
+		NotBefore: jwt.NewNumericDate(issued),
+		Expiry:    jwt.NewNumericDate(issued.Add(time.Hour)),
+	}, nil)
+	require.NoError(t, err)
+
+	serialized, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	clock := mockclock.New(issued.Add(-time.Second))
+
+	_, err = Parse(serialized, WithSignatureVerifier(UnsecuredJWTVerifier()), WithValidityPeriodCheck(clock))
+	require.EqualError(t, err, "JWT is not valid before 2021-01-01T00:00:00Z")
+
+	clock.Add(time.Second)
+
+	parsed, err := Parse(serialized, WithSignatureVerifier(UnsecuredJWTVerifier()), WithValidityPeriodCheck(clock))
+	require.NoError(t, err)
+	require.Equal(t, "Albert", parsed.Payload["iss"])
+
+	clock.Add(time.Hour)
+
+	_, err = Parse(serialized, WithSignatureVerifier(UnsecuredJWTVerifier()), WithValidityPeriodCheck(clock))
+	require.EqualError(t, err, "JWT expired at 2021-01-01T01:00:00Z")
+
+	// without the check, the validity period is not checked
+	_, err = Parse(serialized, WithSignatureVerifier(UnsecuredJWTVerifier()))
+	require.NoError(t, err)
+}
+
 func buildJWS(signer jose.Signer, claims interface{}) (string, error) {
 	claimsBytes, err := json.Marshal(claims)
 	if err != nil {
@@ -8,6 +8,7 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
@@ -167,6 +168,47 @@ func verifyRS256(jws string, pubKey *rsa.PublicKey) error {
 	return nil
 }
 
+type ecdsaSigner struct {
+	privKey *ecdsa.PrivateKey
+	hash    crypto.Hash
+	headers map[string]interface{}
+}
+
+func newECDSASigner(privKey *ecdsa.PrivateKey, hash crypto.Hash, alg string) *ecdsaSigner {
+	return &ecdsaSigner{
+		privKey: privKey,
+		hash:    hash,
+		headers: prepareJWSHeaders(nil, alg),
+	}
+}
+
+func (s ecdsaSigner) Sign(data []byte) ([]byte, error) {
+	hasher := s.hash.New()
+
+	_, err := hasher.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ss, err := ecdsa.Sign(rand.Reader, s.privKey, hasher.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	keySize := (s.privKey.Curve.Params().BitSize + 7) / 8
+
+	// IEEE P1363 (r || s) signature format.
+	signature := make([]byte, 2*keySize)
+	r.FillBytes(signature[:keySize])
+	ss.FillBytes(signature[keySize:])
+
+	return signature, nil
+}
+
+func (s ecdsaSigner) Headers() jose.Headers {
+	return s.headers
+}
+
 func prepareJWSHeaders(headers map[string]interface{}, alg string) map[string]interface{} {
 	newHeaders := make(map[string]interface{})
 
@@ -22,10 +22,19 @@ import (
 
 const (
 	// signatureEdDSA defines EdDSA alg.
-	signatureEdDSA = "EdDSA"
+	signatureEdDSA = jose.AlgEdDSA
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signatureES256 defines ES256 alg.
+	signatureES256 = jose.AlgES256
+
+	// signatureES384 defines ES384 alg.
+	signatureES384 = jose.AlgES384
+
+	// signatureES256K defines ES256K alg.
+	signatureES256K = jose.AlgES256K
 )
 
 const issuerClaim = "iss"
@@ -64,9 +73,19 @@ func NewVerifier(resolver KeyResolver) *BasicVerifier {
 			Alg:      signatureRS256,
 			Verifier: getVerifier(resolver, VerifyRS256),
 		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES256,
+			Verifier: getVerifier(resolver, VerifyES256),
+		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES384,
+			Verifier: getVerifier(resolver, VerifyES384),
+		},
+		jose.AlgSignatureVerifier{
+			Alg:      signatureES256K,
+			Verifier: getVerifier(resolver, VerifyES256K),
+		},
 	)
-	// TODO ECDSA to support NIST P256 curve
-	//  https://github.com/hyperledger/aries-framework-go/issues/1266
 
 	return &BasicVerifier{resolver: resolver, compositeVerifier: compositeVerifier}
 }
@@ -144,6 +163,34 @@ func VerifyRS256(pubKey *verifier.PublicKey, message, signature []byte) error {
 	return rsa.VerifyPKCS1v15(pubKeyRsa, crypto.SHA256, hashed, signature)
 }
 
+// VerifyES256 verifies ES256 (ECDSA using P-256 and SHA-256) signature. The public key is taken from its JWK
+// if set, or else from its uncompressed point bytes.
+func VerifyES256(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifyECDSA(verifier.NewECDSAES256SignatureVerifier(), "P-256", pubKey, message, signature)
+}
+
+// VerifyES384 verifies ES384 (ECDSA using P-384 and SHA-384) signature. The public key is taken from its JWK
+// if set, or else from its uncompressed point bytes.
+func VerifyES384(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifyECDSA(verifier.NewECDSAES384SignatureVerifier(), "P-384", pubKey, message, signature)
+}
+
+// VerifyES256K verifies ES256K (ECDSA using secp256k1 and SHA-256) signature. The public key is taken from its JWK
+// if set, or else from its uncompressed point bytes.
+func VerifyES256K(pubKey *verifier.PublicKey, message, signature []byte) error {
+	return verifyECDSA(verifier.NewECDSASecp256k1SignatureVerifier(), "secp256k1", pubKey, message, signature)
+}
+
+func verifyECDSA(sigVerifier *verifier.ECDSASignatureVerifier, curve string,
+	pubKey *verifier.PublicKey, message, signature []byte) error {
+	// make sure the alg of the JWS matches the curve of the resolved key.
+	if pubKey.JWK != nil && pubKey.JWK.Crv != "" && pubKey.JWK.Crv != curve {
+		return fmt.Errorf("public key curve %s does not match expected curve %s", pubKey.JWK.Crv, curve)
+	}
+
+	return sigVerifier.Verify(pubKey, message, signature)
+}
+
 func getIssuerClaim(claims map[string]interface{}) (string, error) {
 	v, ok := claims[issuerClaim]
 	if !ok {
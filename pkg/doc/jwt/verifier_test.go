@@ -8,13 +8,16 @@ package jwt
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"errors"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/square/go-jose/v3/json"
 	"github.com/stretchr/testify/require"
 
@@ -75,6 +78,115 @@ func TestNewVerifier(t *testing.T) {
 	})
 }
 
+func TestNewVerifier_ECDSA(t *testing.T) {
+	tests := []struct {
+		name  string
+		alg   string
+		curve elliptic.Curve
+		hash  crypto.Hash
+	}{
+		{
+			name:  "Verify JWT signed by ES256",
+			alg:   signatureES256,
+			curve: elliptic.P256(),
+			hash:  crypto.SHA256,
+		},
+		{
+			name:  "Verify JWT signed by ES384",
+			alg:   signatureES384,
+			curve: elliptic.P384(),
+			hash:  crypto.SHA384,
+		},
+		{
+			name:  "Verify JWT signed by ES256K",
+			alg:   signatureES256K,
+			curve: btcec.S256(),
+			hash:  crypto.SHA256,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			privKey, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+			require.NoError(t, err)
+
+			token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, newECDSASigner(privKey, tc.hash, tc.alg))
+			require.NoError(t, err)
+			jws, err := token.Serialize(false)
+			require.NoError(t, err)
+
+			// public key as uncompressed point bytes.
+			v := NewVerifier(getTestKeyResolver(
+				&verifier.PublicKey{
+					Type:  "EcdsaSecp256k1VerificationKey2019",
+					Value: elliptic.Marshal(tc.curve, privKey.X, privKey.Y),
+				}, nil))
+			_, err = jose.ParseJWS(jws, v)
+			require.NoError(t, err)
+
+			// public key as JWK.
+			jwk, err := jose.JWKFromKey(&privKey.PublicKey)
+			require.NoError(t, err)
+
+			v = NewVerifier(getTestKeyResolver(
+				&verifier.PublicKey{
+					Type: "JsonWebKey2020",
+					JWK:  jwk,
+				}, nil))
+			_, err = jose.ParseJWS(jws, v)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("Verify JWT signed by ES256 with a P-384 key", func(t *testing.T) {
+		privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		token, err := NewSigned(&Claims{Issuer: "Mike"}, nil, newECDSASigner(privKey, crypto.SHA256, signatureES256))
+		require.NoError(t, err)
+		jws, err := token.Serialize(false)
+		require.NoError(t, err)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := jose.JWKFromKey(&otherKey.PublicKey)
+		require.NoError(t, err)
+
+		v := NewVerifier(getTestKeyResolver(
+			&verifier.PublicKey{
+				Type: "JsonWebKey2020",
+				JWK:  jwk,
+			}, nil))
+		_, err = jose.ParseJWS(jws, v)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key curve P-384 does not match expected curve P-256")
+	})
+}
+
+func TestVerifyES256(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signature, err := newECDSASigner(privKey, crypto.SHA256, signatureES256).Sign([]byte("test message"))
+	require.NoError(t, err)
+
+	pubKey := &verifier.PublicKey{
+		Type:  "JsonWebKey2020",
+		Value: elliptic.Marshal(elliptic.P256(), privKey.X, privKey.Y),
+	}
+
+	err = VerifyES256(pubKey, []byte("test message"), signature)
+	require.NoError(t, err)
+
+	err = VerifyES256(pubKey, []byte("another message"), signature)
+	require.EqualError(t, err, "ecdsa: invalid signature")
+
+	err = VerifyES384(pubKey, []byte("test message"), signature)
+	require.Error(t, err)
+}
+
 func TestBasicVerifier_Verify(t *testing.T) { // error corner cases
 	r := require.New(t)
 
@@ -116,6 +116,7 @@ type InputDescriptor struct {
 	Purpose     string                 `json:"purpose,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	Schema      []*Schema              `json:"schema,omitempty"`
+	Format      *Format                `json:"format,omitempty"`
 	Constraints *Constraints           `json:"constraints,omitempty"`
 }
 
@@ -144,6 +145,7 @@ type Field struct {
 	Path      []string    `json:"path,omitempty"`
 	ID        string      `json:"id,omitempty"`
 	Purpose   string      `json:"purpose,omitempty"`
+	Optional  bool        `json:"optional,omitempty"`
 	Filter    *Filter     `json:"filter,omitempty"`
 	Predicate *Preference `json:"predicate,omitempty"`
 }
@@ -162,6 +164,7 @@ type Filter struct {
 	Const            StrOrInt               `json:"const,omitempty"`
 	Enum             []StrOrInt             `json:"enum,omitempty"`
 	Not              map[string]interface{} `json:"not,omitempty"`
+	Contains         map[string]interface{} `json:"contains,omitempty"`
 }
 
 // ValidateSchema validates presentation definition.
@@ -297,22 +300,11 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 // CreateVP creates verifiable presentation.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
-	if err := pd.ValidateSchema(); err != nil {
-		return nil, err
-	}
-
-	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	submission, applicableCredentials, err := pd.Evaluate(credentials, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := applyRequirement(req, credentials, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	applicableCredentials, descriptors := merge(result)
-
 	vp, err := verifiable.NewPresentation(verifiable.WithCredentials(applicableCredentials...))
 	if err != nil {
 		return nil, err
@@ -322,16 +314,42 @@ func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	vp.Type = append(vp.Type, PresentationSubmissionJSONLDType)
 
 	vp.CustomFields = verifiable.CustomFields{
-		submissionProperty: &PresentationSubmission{
-			ID:            uuid.New().String(),
-			DefinitionID:  pd.ID,
-			DescriptorMap: descriptors,
-		},
+		submissionProperty: submission,
 	}
 
 	return vp, nil
 }
 
+// Evaluate selects the credentials which satisfy the input descriptors and submission requirements
+// of the presentation definition. It returns the presentation submission along with the selected
+// credentials, in the order referenced by the submission descriptor map.
+// Credentials matched by a descriptor with limit_disclosure required are replaced by a derived credential
+// (BBS+ selective disclosure when the credential is signed with BbsBlsSignature2020).
+func (pd *PresentationDefinition) Evaluate(credentials []*verifiable.Credential,
+	opts ...verifiable.CredentialOpt) (*PresentationSubmission, []*verifiable.Credential, error) {
+	if err := pd.ValidateSchema(); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := makeRequirement(pd.SubmissionRequirements, pd.InputDescriptors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := applyRequirement(req, credentials, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicableCredentials, descriptors := merge(result)
+
+	return &PresentationSubmission{
+		ID:            uuid.New().String(),
+		DefinitionID:  pd.ID,
+		DescriptorMap: descriptors,
+	}, applicableCredentials, nil
+}
+
 // ErrNoCredentials when any credentials do not satisfy requirements.
 var ErrNoCredentials = errors.New("credentials do not satisfy requirements")
 
@@ -528,6 +546,12 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential,
 
 		for i, field := range constraints.Fields {
 			err = filterField(field, credentialMap)
+			if errors.Is(err, errPathNotApplicable) && field.Optional {
+				applicable = true
+
+				continue
+			}
+
 			if errors.Is(err, errPathNotApplicable) {
 				applicable = false
 
@@ -877,6 +901,11 @@ func (a byID) Less(i, j int) bool { return a[i].ID < a[j].ID }
 func (a byID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
 func filterSchema(schemas []*Schema, credentials []*verifiable.Credential) []*verifiable.Credential {
+	// Presentation Exchange v2 descriptors have no schema, credentials are selected by constraints only.
+	if len(schemas) == 0 {
+		return credentials
+	}
+
 	var result []*verifiable.Credential
 
 	for _, credential := range credentials {
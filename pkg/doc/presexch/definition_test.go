@@ -1428,6 +1428,188 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_Evaluate(t *testing.T) {
+	newCredential := func(types ...string) *verifiable.Credential {
+		return &verifiable.Credential{
+			ID:      uuid.New().String(),
+			Context: []string{verifiable.ContextURI},
+			Types:   append([]string{verifiable.VCType}, types...),
+			Subject: []verifiable.Subject{{
+				ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+				CustomFields: map[string]interface{}{
+					"name": "Jayden Doe",
+				},
+			}},
+			Issuer: verifiable.Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Issued: &util.TimeWithTrailingZeroMsec{Time: time.Now()},
+		}
+	}
+
+	typeFilter := func(credentialType string) *Filter {
+		return &Filter{
+			Type:     &arrFilterType,
+			Contains: map[string]interface{}{"const": credentialType},
+		}
+	}
+
+	t.Run("Input descriptors without schema", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: "university_degree",
+				Format: &Format{
+					LdpVC: &LdpType{ProofType: []string{"Ed25519Signature2018"}},
+				},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.type"},
+						Filter: typeFilter("UniversityDegreeCredential"),
+					}},
+				},
+			}},
+		}
+
+		require.NoError(t, pd.ValidateSchema())
+
+		degree := newCredential("UniversityDegreeCredential")
+
+		submission, credentials, err := pd.Evaluate([]*verifiable.Credential{
+			newCredential("DriversLicenseCredential"), degree,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*verifiable.Credential{degree}, credentials)
+		require.NotEmpty(t, submission.ID)
+		require.Equal(t, pd.ID, submission.DefinitionID)
+		require.Len(t, submission.DescriptorMap, 1)
+		require.Equal(t, "university_degree", submission.DescriptorMap[0].ID)
+		require.Equal(t, "$.verifiableCredential[0]", submission.DescriptorMap[0].Path)
+	})
+
+	t.Run("Descriptor matches multiple credentials", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: "university_degree",
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.type"},
+						Filter: typeFilter("UniversityDegreeCredential"),
+					}},
+				},
+			}},
+		}
+
+		submission, credentials, err := pd.Evaluate([]*verifiable.Credential{
+			newCredential("UniversityDegreeCredential"),
+			newCredential("DriversLicenseCredential"),
+			newCredential("UniversityDegreeCredential"),
+		})
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Len(t, submission.DescriptorMap, 2)
+
+		for i, descriptor := range submission.DescriptorMap {
+			require.Equal(t, "university_degree", descriptor.ID)
+			require.Equal(t, fmt.Sprintf("$.verifiableCredential[%d]", i), descriptor.Path)
+		}
+	})
+
+	t.Run("Optional field", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path: []string{"$.credentialSubject.name"},
+					}, {
+						Path:     []string{"$.credentialSubject.email"},
+						Optional: true,
+						Filter:   &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		require.NoError(t, pd.ValidateSchema())
+
+		_, credentials, err := pd.Evaluate([]*verifiable.Credential{newCredential()})
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		pd.InputDescriptors[0].Constraints.Fields[1].Optional = false
+
+		_, credentials, err = pd.Evaluate([]*verifiable.Credential{newCredential()})
+		require.EqualError(t, err, errMsgSchema)
+		require.Nil(t, credentials)
+	})
+
+	t.Run("Submission requirements", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:    "degree",
+				Group: []string{"A"},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.type"},
+						Filter: typeFilter("UniversityDegreeCredential"),
+					}},
+				},
+			}, {
+				ID:    "drivers_license",
+				Group: []string{"A", "B"},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.type"},
+						Filter: typeFilter("DriversLicenseCredential"),
+					}},
+				},
+			}, {
+				ID:    "passport",
+				Group: []string{"B"},
+				Constraints: &Constraints{
+					Fields: []*Field{{
+						Path:   []string{"$.type"},
+						Filter: typeFilter("PassportCredential"),
+					}},
+				},
+			}},
+		}
+
+		pd.SubmissionRequirements = []*SubmissionRequirement{{Rule: All, From: "A"}}
+
+		submission, credentials, err := pd.Evaluate([]*verifiable.Credential{
+			newCredential("UniversityDegreeCredential"),
+			newCredential("PassportCredential"),
+			newCredential("DriversLicenseCredential"),
+		})
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+		require.Len(t, submission.DescriptorMap, 2)
+		require.Equal(t, "degree", submission.DescriptorMap[0].ID)
+		require.Equal(t, "drivers_license", submission.DescriptorMap[1].ID)
+
+		pd.SubmissionRequirements = []*SubmissionRequirement{{Rule: Pick, Count: 1, From: "B"}}
+
+		submission, credentials, err = pd.Evaluate([]*verifiable.Credential{
+			newCredential("UniversityDegreeCredential"),
+			newCredential("PassportCredential"),
+		})
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		require.Len(t, submission.DescriptorMap, 1)
+		require.Equal(t, "passport", submission.DescriptorMap[0].ID)
+	})
+
+	t.Run("Invalid definition", func(t *testing.T) {
+		submission, credentials, err := (&PresentationDefinition{ID: uuid.New().String()}).Evaluate(nil)
+		require.EqualError(t, err, "presentation_definition: input_descriptors is required")
+		require.Nil(t, submission)
+		require.Nil(t, credentials)
+	})
+}
+
 func checkSubmission(t *testing.T, vp *verifiable.Presentation, pd *PresentationDefinition) {
 	t.Helper()
 
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package presexch
 
 // DefinitionJSONSchema is the JSONSchema definition for PresentationDefinition.
+// It also accepts Presentation Exchange v2 definitions, where input descriptor schema is optional and
+// input descriptors and fields may declare format and optional respectively.
 // nolint:lll
 // https://github.com/decentralized-identity/presentation-exchange/blob/9a6abc6d2b0f08b6339c9116132fa94c4c834418/test/presentation-definition/schema.json
 const DefinitionJSONSchema = `
@@ -89,6 +91,10 @@ const DefinitionJSONSchema = `
             "not":{
                "type":"object",
                "minProperties":1
+            },
+            "contains":{
+               "type":"object",
+               "minProperties":1
             }
          },
          "required":[
@@ -242,6 +248,9 @@ const DefinitionJSONSchema = `
                   "$ref":"#/definitions/schema"
                }
             },
+            "format":{
+               "$ref":"#/definitions/format"
+            },
             "constraints":{
                "type":"object",
                "properties":{
@@ -366,8 +375,7 @@ const DefinitionJSONSchema = `
             }
          },
          "required":[
-            "id",
-            "schema"
+            "id"
          ],
          "additionalProperties":false
       },
@@ -388,6 +396,9 @@ const DefinitionJSONSchema = `
                   "purpose":{
                      "type":"string"
                   },
+                  "optional":{
+                     "type":"boolean"
+                  },
                   "filter":{
                      "$ref":"#/definitions/filter"
                   }
@@ -411,6 +422,9 @@ const DefinitionJSONSchema = `
                   "purpose":{
                      "type":"string"
                   },
+                  "optional":{
+                     "type":"boolean"
+                  },
                   "filter":{
                      "$ref":"#/definitions/filter"
                   },
@@ -0,0 +1,143 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+// BindingInfo holds the inputs of a key binding JWT.
+type BindingInfo struct {
+	// Nonce is the verifier's nonce, set in the "nonce" claim.
+	Nonce string
+	// Audience is the verifier, set in the "aud" claim.
+	Audience string
+	// IssuedAt is set in the "iat" claim. The current time read from Clock is used if zero.
+	IssuedAt time.Time
+	// Clock reads the current time, clock.SystemClock if nil.
+	Clock clock.Clock
+	// Signer signs with the holder's private key, matching the public key of the "cnf" claim of the SD-JWT.
+	Signer jose.Signer
+}
+
+// presentOpts holds options for the SD-JWT presentation.
+type presentOpts struct {
+	binding *BindingInfo
+}
+
+// PresentOpt is the SD-JWT presentation option.
+type PresentOpt func(opts *presentOpts)
+
+// WithHolderBinding appends a key binding JWT created from info to the presentation.
+func WithHolderBinding(info *BindingInfo) PresentOpt {
+	return func(opts *presentOpts) {
+		opts.binding = info
+	}
+}
+
+// GetDisclosures returns the disclosures of an SD-JWT in the combined format.
+func GetDisclosures(sdJWT string) ([]*Disclosure, error) {
+	cf, err := parseCombinedFormat(sdJWT)
+	if err != nil {
+		return nil, err
+	}
+
+	disclosures := make([]*Disclosure, len(cf.disclosures))
+
+	for i, encoded := range cf.disclosures {
+		disclosures[i], err = ParseDisclosure(encoded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return disclosures, nil
+}
+
+// CreatePresentation creates a presentation of the SD-JWT issued to the holder, revealing only the disclosures of
+// the claims named in claimNames.
+func CreatePresentation(sdJWT string, claimNames []string, opts ...PresentOpt) (string, error) {
+	pOpts := &presentOpts{}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	cf, err := parseCombinedFormat(sdJWT)
+	if err != nil {
+		return "", fmt.Errorf("create SD-JWT presentation: %w", err)
+	}
+
+	disclosures, err := GetDisclosures(sdJWT)
+	if err != nil {
+		return "", fmt.Errorf("create SD-JWT presentation: %w", err)
+	}
+
+	presentation := &combinedFormat{jwt: cf.jwt}
+
+	for _, name := range claimNames {
+		d := findDisclosure(disclosures, name)
+		if d == nil {
+			return "", fmt.Errorf("create SD-JWT presentation: no disclosure for claim '%s'", name)
+		}
+
+		presentation.disclosures = append(presentation.disclosures, d.Encoded)
+	}
+
+	if pOpts.binding != nil {
+		presentation.keyBinding, err = createKeyBinding(pOpts.binding, presentation.withoutKeyBinding())
+		if err != nil {
+			return "", fmt.Errorf("create SD-JWT presentation: %w", err)
+		}
+	}
+
+	return presentation.serialize(), nil
+}
+
+func findDisclosure(disclosures []*Disclosure, name string) *Disclosure {
+	for _, d := range disclosures {
+		if d.Name == name {
+			return d
+		}
+	}
+
+	return nil
+}
+
+func createKeyBinding(info *BindingInfo, presentation string) (string, error) {
+	if info.Signer == nil {
+		return "", errors.New("create key binding JWT: signer is required")
+	}
+
+	issuedAt := info.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = clock.OrSystem(info.Clock).Now()
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		claimNonce:  info.Nonce,
+		claimAud:    info.Audience,
+		claimIat:    issuedAt.Unix(),
+		claimSDHash: digest(presentation),
+	})
+	if err != nil {
+		return "", fmt.Errorf("create key binding JWT: %w", err)
+	}
+
+	jws, err := jose.NewJWS(jose.Headers{jose.HeaderType: TypeKeyBindingJWT}, nil, claims, info.Signer)
+	if err != nil {
+		return "", fmt.Errorf("create key binding JWT: %w", err)
+	}
+
+	return jws.SerializeCompact(false)
+}
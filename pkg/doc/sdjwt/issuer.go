@@ -0,0 +1,193 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const saltSize = 16
+
+// issueOpts holds options for the SD-JWT issuance.
+type issueOpts struct {
+	issuedAt        *time.Time
+	holderPublicKey *jose.JWK
+	keyID           string
+	saltFunc        func() (string, error)
+	alwaysDisclosed map[string]bool
+}
+
+// IssueOpt is the SD-JWT issuance option.
+type IssueOpt func(opts *issueOpts)
+
+// WithIssuedAt sets the "iat" claim of the SD-JWT.
+func WithIssuedAt(t time.Time) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.issuedAt = &t
+	}
+}
+
+// WithHolderPublicKey binds the SD-JWT to the holder's key, set as JWK in the "cnf" claim. Presentations of the
+// SD-JWT can then be bound to the holder by a key binding JWT.
+func WithHolderPublicKey(jwk *jose.JWK) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.holderPublicKey = jwk
+	}
+}
+
+// WithKeyID sets the "kid" header of the SD-JWT.
+func WithKeyID(kid string) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.keyID = kid
+	}
+}
+
+// WithAlwaysDisclosedClaims keeps the given claims in the clear in the JWT instead of making them selectively
+// disclosable, e.g. "exp", "nbf", "vct" or "sub" so that verifiers can always check them.
+func WithAlwaysDisclosedClaims(names ...string) IssueOpt {
+	return func(opts *issueOpts) {
+		for _, name := range names {
+			opts.alwaysDisclosed[name] = true
+		}
+	}
+}
+
+// WithSaltFunc overrides the generation of the disclosure salts.
+func WithSaltFunc(f func() (string, error)) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.saltFunc = f
+	}
+}
+
+// Issue issues claims as an SD-JWT signed by signer (see jose.NewCryptoSigner for a signer backed by the Crypto
+// service) and returns its combined format, holding the JWT followed by all disclosures.
+// Each of claims is selectively disclosable: it is replaced in the JWT by the digest of its disclosure, unless it is
+// always disclosed (see WithAlwaysDisclosedClaims). The issuer claim is always disclosed.
+func Issue(issuer string, claims map[string]interface{}, signer jose.Signer, opts ...IssueOpt) (string, error) {
+	iOpts := &issueOpts{saltFunc: generateSalt, alwaysDisclosed: map[string]bool{}}
+
+	for _, opt := range opts {
+		opt(iOpts)
+	}
+
+	payload := map[string]interface{}{
+		"iss":      issuer,
+		claimSDAlg: SDAlgorithm,
+	}
+
+	if iOpts.issuedAt != nil {
+		payload[claimIat] = iOpts.issuedAt.Unix()
+	}
+
+	if iOpts.holderPublicKey != nil {
+		payload[claimCnf] = map[string]interface{}{cnfJWK: iOpts.holderPublicKey}
+	}
+
+	sdClaims := make(map[string]interface{}, len(claims))
+
+	for name, value := range claims {
+		if !iOpts.alwaysDisclosed[name] {
+			sdClaims[name] = value
+
+			continue
+		}
+
+		if _, ok := payload[name]; ok {
+			return "", fmt.Errorf("issue SD-JWT: claim name '%s' is reserved", name)
+		}
+
+		payload[name] = value
+	}
+
+	disclosures, err := createDisclosures(sdClaims, iOpts.saltFunc)
+	if err != nil {
+		return "", fmt.Errorf("issue SD-JWT: %w", err)
+	}
+
+	digests := make([]string, len(disclosures))
+	encoded := make([]string, len(disclosures))
+
+	for i, d := range disclosures {
+		digests[i] = d.Digest()
+		encoded[i] = d.Encoded
+	}
+
+	// Sort the digests so that their order does not reveal the order of the claims.
+	sort.Strings(digests)
+
+	payload[claimSD] = digests
+
+	headers := jose.Headers{jose.HeaderType: jwt.TypeJWT}
+	if iOpts.keyID != "" {
+		headers[jose.HeaderKeyID] = iOpts.keyID
+	}
+
+	token, err := jwt.NewSigned(payload, headers, signer)
+	if err != nil {
+		return "", fmt.Errorf("issue SD-JWT: %w", err)
+	}
+
+	jws, err := token.Serialize(false)
+	if err != nil {
+		return "", fmt.Errorf("issue SD-JWT: %w", err)
+	}
+
+	return (&combinedFormat{jwt: jws, disclosures: encoded}).serialize(), nil
+}
+
+func createDisclosures(claims map[string]interface{}, saltFunc func() (string, error)) ([]*Disclosure, error) {
+	names := make([]string, 0, len(claims))
+
+	for name := range claims {
+		if name == claimSD || name == claimSDAlg {
+			return nil, fmt.Errorf("claim name '%s' is reserved", name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	disclosures := make([]*Disclosure, len(names))
+
+	for i, name := range names {
+		salt, err := saltFunc()
+		if err != nil {
+			return nil, fmt.Errorf("generate salt: %w", err)
+		}
+
+		if salt == "" {
+			return nil, errors.New("generate salt: empty salt")
+		}
+
+		disclosures[i], err = newDisclosure(salt, name, claims[name])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return disclosures, nil
+}
+
+func generateSalt() (string, error) {
+	salt := make([]byte, saltSize)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(salt), nil
+}
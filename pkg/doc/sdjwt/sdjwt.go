@@ -0,0 +1,152 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sdjwt implements Selective Disclosure JWTs (SD-JWT,
+// https://datatracker.ietf.org/doc/draft-ietf-oauth-selective-disclosure-jwt/).
+//
+// An issuer signs a JWT carrying the digests of the selectively disclosable claims in the "_sd" array, and hands
+// the JWT together with the disclosures to the holder. The holder presents the JWT with a subset of the
+// disclosures, optionally bound to its key by a key binding JWT, and the verifier reconstructs the disclosed claims.
+// Only top-level claims are selectively disclosable, the issuer can keep some of them always disclosed.
+package sdjwt
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SDAlgorithm is the hash algorithm used to compute disclosure digests, as set in the "_sd_alg" claim.
+	SDAlgorithm = "sha-256"
+
+	// TypeKeyBindingJWT is the "typ" header of key binding JWTs.
+	TypeKeyBindingJWT = "kb+jwt"
+
+	claimSD     = "_sd"
+	claimSDAlg  = "_sd_alg"
+	claimCnf    = "cnf"
+	claimNonce  = "nonce"
+	claimAud    = "aud"
+	claimIat    = "iat"
+	claimSDHash = "sd_hash"
+
+	cnfJWK = "jwk"
+
+	separator = "~"
+
+	disclosureElements = 3
+)
+
+// Disclosure is a selectively disclosable claim.
+type Disclosure struct {
+	Salt  string
+	Name  string
+	Value interface{}
+
+	// Encoded is the base64url encoded disclosure, as found in the combined format.
+	Encoded string
+}
+
+func newDisclosure(salt, name string, value interface{}) (*Disclosure, error) {
+	disclosureBytes, err := json.Marshal([]interface{}{salt, name, value})
+	if err != nil {
+		return nil, fmt.Errorf("marshal disclosure of claim '%s': %w", name, err)
+	}
+
+	return &Disclosure{
+		Salt:    salt,
+		Name:    name,
+		Value:   value,
+		Encoded: base64.RawURLEncoding.EncodeToString(disclosureBytes),
+	}, nil
+}
+
+// ParseDisclosure decodes a base64url encoded disclosure.
+func ParseDisclosure(encoded string) (*Disclosure, error) {
+	disclosureBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode disclosure: %w", err)
+	}
+
+	var elements []interface{}
+
+	err = json.Unmarshal(disclosureBytes, &elements)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal disclosure: %w", err)
+	}
+
+	if len(elements) != disclosureElements {
+		return nil, fmt.Errorf("disclosure must have %d elements", disclosureElements)
+	}
+
+	salt, ok := elements[0].(string)
+	if !ok {
+		return nil, errors.New("disclosure salt is not a string")
+	}
+
+	name, ok := elements[1].(string)
+	if !ok {
+		return nil, errors.New("disclosure claim name is not a string")
+	}
+
+	return &Disclosure{Salt: salt, Name: name, Value: elements[2], Encoded: encoded}, nil
+}
+
+// Digest returns the base64url encoded SHA-256 digest of the disclosure, as listed in the "_sd" claim.
+func (d *Disclosure) Digest() string {
+	return digest(d.Encoded)
+}
+
+func digest(s string) string {
+	h := sha256.Sum256([]byte(s))
+
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// combinedFormat is an SD-JWT in the combined format: <JWT>~<disclosure 1>~...~<disclosure N>~<key binding JWT>.
+// The key binding JWT is empty if the SD-JWT is not bound to the holder.
+type combinedFormat struct {
+	jwt         string
+	disclosures []string
+	keyBinding  string
+}
+
+func parseCombinedFormat(sdJWT string) (*combinedFormat, error) {
+	parts := strings.Split(sdJWT, separator)
+	if len(parts) < 2 {
+		return nil, errors.New("SD-JWT is not in the combined format")
+	}
+
+	return &combinedFormat{
+		jwt:         parts[0],
+		disclosures: parts[1 : len(parts)-1],
+		keyBinding:  parts[len(parts)-1],
+	}, nil
+}
+
+// withoutKeyBinding serializes the JWT and disclosures, ending with the separator. It is the input of the
+// "sd_hash" claim of key binding JWTs.
+func (c *combinedFormat) withoutKeyBinding() string {
+	var sb strings.Builder
+
+	sb.WriteString(c.jwt)
+	sb.WriteString(separator)
+
+	for _, d := range c.disclosures {
+		sb.WriteString(d)
+		sb.WriteString(separator)
+	}
+
+	return sb.String()
+}
+
+func (c *combinedFormat) serialize() string {
+	return c.withoutKeyBinding() + c.keyBinding
+}
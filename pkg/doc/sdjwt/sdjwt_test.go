@@ -0,0 +1,413 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+const (
+	testIssuer   = "did:example:issuer"
+	testNonce    = "nonce-123"
+	testAudience = "did:example:verifier"
+)
+
+func TestIssuePresentVerify(t *testing.T) {
+	kmsSvc, cryptoSvc := newKMSAndCrypto(t)
+
+	issuerKID, issuerKH, err := kmsSvc.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	issuerPubKey, err := kmsSvc.ExportPubKeyBytes(issuerKID)
+	require.NoError(t, err)
+
+	holderKID, holderKH, err := kmsSvc.Create(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	holderPubKey, err := kmsSvc.ExportPubKeyBytes(holderKID)
+	require.NoError(t, err)
+
+	holderJWK, err := jose.PubKeyBytesToJWK(holderPubKey, kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{
+		"given_name":  "Alice",
+		"family_name": "Smith",
+		"address":     map[string]interface{}{"country": "CA"},
+	}
+
+	sdJWT, err := Issue(testIssuer, claims, jose.NewCryptoSigner(cryptoSvc, issuerKH, jose.AlgEdDSA),
+		WithHolderPublicKey(holderJWK), WithIssuedAt(time.Now()), WithKeyID(issuerKID))
+	require.NoError(t, err)
+
+	disclosures, err := GetDisclosures(sdJWT)
+	require.NoError(t, err)
+	require.Len(t, disclosures, 3)
+
+	issuerVerifier := jose.NewPublicKeyVerifier(&cryptoapi.PublicKey{X: issuerPubKey})
+
+	t.Run("issued SD-JWT hides all claims", func(t *testing.T) {
+		verified, err := Verify(strings.Split(sdJWT, separator)[0]+separator, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Equal(t, testIssuer, verified["iss"])
+		require.NotContains(t, verified, "given_name")
+		require.NotContains(t, verified, claimSD)
+	})
+
+	t.Run("disclose subset with key binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			Signer:   jose.NewCryptoSigner(cryptoSvc, holderKH, jose.AlgES256),
+		}))
+		require.NoError(t, err)
+
+		verified, err := Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience))
+		require.NoError(t, err)
+		require.Equal(t, "Alice", verified["given_name"])
+		require.NotContains(t, verified, "family_name")
+		require.NotContains(t, verified, "address")
+		require.NotContains(t, verified, claimSDAlg)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding("other nonce", testAudience))
+		require.EqualError(t, err, "verify SD-JWT: key binding JWT nonce does not match")
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, "did:example:other"))
+		require.EqualError(t, err, "verify SD-JWT: key binding JWT aud does not match")
+	})
+
+	t.Run("key binding issued at the time of the clock", func(t *testing.T) {
+		issuedAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			Signer:   jose.NewCryptoSigner(cryptoSvc, holderKH, jose.AlgES256),
+			Clock:    mockclock.New(issuedAt),
+		}))
+		require.NoError(t, err)
+
+		cf, err := parseCombinedFormat(presentation)
+		require.NoError(t, err)
+
+		payload, err := base64.RawURLEncoding.DecodeString(strings.Split(cf.keyBinding, ".")[1])
+		require.NoError(t, err)
+
+		claims := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(payload, &claims))
+		require.EqualValues(t, issuedAt.Unix(), claims[claimIat])
+	})
+
+	t.Run("stale key binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			IssuedAt: time.Now().Add(-DefaultKeyBindingMaxAge - time.Minute),
+			Signer:   jose.NewCryptoSigner(cryptoSvc, holderKH, jose.AlgES256),
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT iat")
+		require.Contains(t, err.Error(), "is not within 5m0s of the current time")
+
+		verified, err := Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience), WithKeyBindingMaxAge(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, "Alice", verified["given_name"])
+	})
+
+	t.Run("disclose all without key binding", func(t *testing.T) {
+		presentation, err := CreatePresentation(sdJWT, []string{"address", "family_name", "given_name"})
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(presentation, separator))
+
+		verified, err := Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.NoError(t, err)
+		require.Equal(t, "Smith", verified["family_name"])
+		require.Equal(t, map[string]interface{}{"country": "CA"}, verified["address"])
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience))
+		require.EqualError(t, err, "verify SD-JWT: key binding JWT is missing")
+	})
+
+	t.Run("key binding signed with another key", func(t *testing.T) {
+		_, otherKH, err := kmsSvc.Create(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			Signer:   jose.NewCryptoSigner(cryptoSvc, otherKH, jose.AlgES256),
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse key binding JWT: invalid signature")
+	})
+
+	t.Run("key binding with another alg than the cnf key", func(t *testing.T) {
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			Signer:   jose.NewCryptoSigner(cryptoSvc, holderKH, jose.AlgES256K),
+		}))
+		require.NoError(t, err)
+
+		_, err = Verify(presentation, WithSignatureVerifier(issuerVerifier),
+			WithExpectedHolderBinding(testNonce, testAudience))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "key binding JWT alg 'ES256K' does not match the cnf key alg 'ES256'")
+	})
+
+	t.Run("key binding of another presentation", func(t *testing.T) {
+		presentation, err := CreatePresentation(sdJWT, []string{"given_name"}, WithHolderBinding(&BindingInfo{
+			Nonce:    testNonce,
+			Audience: testAudience,
+			Signer:   jose.NewCryptoSigner(cryptoSvc, holderKH, jose.AlgES256),
+		}))
+		require.NoError(t, err)
+
+		parts := strings.Split(presentation, separator)
+		tampered := parts[0] + separator + disclosures[0].Encoded + separator + parts[len(parts)-1]
+
+		_, err = Verify(tampered, WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err, "verify SD-JWT: key binding JWT sd_hash does not match the presentation")
+	})
+
+	t.Run("undisclosed digest", func(t *testing.T) {
+		d, err := newDisclosure("salt", "given_name", "Mallory")
+		require.NoError(t, err)
+
+		parts := strings.Split(sdJWT, separator)
+
+		_, err = Verify(parts[0]+separator+d.Encoded+separator, WithSignatureVerifier(issuerVerifier))
+		require.EqualError(t, err,
+			"verify SD-JWT: digest of disclosure of claim 'given_name' is not in _sd claim")
+	})
+
+	t.Run("same disclosure twice", func(t *testing.T) {
+		parts := strings.Split(sdJWT, separator)
+		presentation := parts[0] + separator + parts[1] + separator + parts[1] + separator
+
+		_, err := Verify(presentation, WithSignatureVerifier(issuerVerifier))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not in _sd claim")
+	})
+
+	t.Run("invalid issuer signature", func(t *testing.T) {
+		otherKID, _, err := kmsSvc.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		otherPubKey, err := kmsSvc.ExportPubKeyBytes(otherKID)
+		require.NoError(t, err)
+
+		_, err = Verify(sdJWT, WithSignatureVerifier(jose.NewPublicKeyVerifier(&cryptoapi.PublicKey{X: otherPubKey})))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("missing signature verifier", func(t *testing.T) {
+		_, err := Verify(sdJWT)
+		require.EqualError(t, err, "verify SD-JWT: signature verifier is required")
+	})
+
+	t.Run("unknown claim name", func(t *testing.T) {
+		_, err := CreatePresentation(sdJWT, []string{"birthdate"})
+		require.EqualError(t, err, "create SD-JWT presentation: no disclosure for claim 'birthdate'")
+	})
+
+	t.Run("key binding without signer", func(t *testing.T) {
+		_, err := CreatePresentation(sdJWT, nil, WithHolderBinding(&BindingInfo{}))
+		require.EqualError(t, err, "create SD-JWT presentation: create key binding JWT: signer is required")
+	})
+}
+
+func TestIssue(t *testing.T) {
+	kmsSvc, cryptoSvc := newKMSAndCrypto(t)
+
+	_, kh, err := kmsSvc.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	signer := jose.NewCryptoSigner(cryptoSvc, kh, jose.AlgEdDSA)
+
+	t.Run("reserved claim name", func(t *testing.T) {
+		_, err := Issue(testIssuer, map[string]interface{}{claimSD: "x"}, signer)
+		require.EqualError(t, err, "issue SD-JWT: claim name '_sd' is reserved")
+	})
+
+	t.Run("salt error", func(t *testing.T) {
+		_, err := Issue(testIssuer, map[string]interface{}{"name": "Alice"}, signer,
+			WithSaltFunc(func() (string, error) {
+				return "", errors.New("no entropy")
+			}))
+		require.EqualError(t, err, "issue SD-JWT: generate salt: no entropy")
+	})
+
+	t.Run("always disclosed claims", func(t *testing.T) {
+		expiry := time.Now().Add(time.Hour).Unix()
+
+		sdJWT, err := Issue(testIssuer, map[string]interface{}{
+			"name": "Alice",
+			"sub":  "did:example:holder",
+			"exp":  expiry,
+			"vct":  "https://credentials.example.com/identity_credential",
+		}, signer, WithAlwaysDisclosedClaims("sub", "exp", "vct"))
+		require.NoError(t, err)
+
+		disclosures, err := GetDisclosures(sdJWT)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+		require.Equal(t, "name", disclosures[0].Name)
+
+		cf, err := parseCombinedFormat(sdJWT)
+		require.NoError(t, err)
+
+		payloadBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(cf.jwt, ".")[1])
+		require.NoError(t, err)
+
+		payload := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(payloadBytes, &payload))
+		require.Equal(t, "did:example:holder", payload["sub"])
+		require.EqualValues(t, expiry, payload["exp"])
+		require.Equal(t, "https://credentials.example.com/identity_credential", payload["vct"])
+		require.NotContains(t, payload, "name")
+
+		// the always disclosed claims are verified without any disclosure.
+		presentation, err := CreatePresentation(sdJWT, nil)
+		require.NoError(t, err)
+
+		verified, err := Verify(presentation, WithSignatureVerifier(jose.SignatureVerifierFunc(
+			func(jose.Headers, []byte, []byte, []byte) error { return nil })))
+		require.NoError(t, err)
+		require.Equal(t, "did:example:holder", verified["sub"])
+		require.Equal(t, strconv.FormatInt(expiry, 10), fmt.Sprint(verified["exp"]))
+		require.NotContains(t, verified, "name")
+	})
+
+	t.Run("always disclosed claim set by the issuer", func(t *testing.T) {
+		_, err := Issue(testIssuer, map[string]interface{}{"iss": "did:example:other"}, signer,
+			WithAlwaysDisclosedClaims("iss"))
+		require.EqualError(t, err, "issue SD-JWT: claim name 'iss' is reserved")
+	})
+
+	t.Run("fixed salt", func(t *testing.T) {
+		sdJWT, err := Issue(testIssuer, map[string]interface{}{"name": "Alice"}, signer,
+			WithSaltFunc(func() (string, error) {
+				return "2GLC42sKQveCfGfryNRN9w", nil
+			}))
+		require.NoError(t, err)
+
+		disclosures, err := GetDisclosures(sdJWT)
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+		require.Equal(t, "2GLC42sKQveCfGfryNRN9w", disclosures[0].Salt)
+		require.Equal(t, "name", disclosures[0].Name)
+		require.Equal(t, "Alice", disclosures[0].Value)
+	})
+}
+
+func TestParseDisclosure(t *testing.T) {
+	_, err := ParseDisclosure("!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode disclosure")
+
+	_, err = ParseDisclosure("WyJzYWx0Il0") // ["salt"]
+	require.EqualError(t, err, "disclosure must have 3 elements")
+
+	_, err = ParseDisclosure("WzEsIm5hbWUiLCJ2YWx1ZSJd") // [1,"name","value"]
+	require.EqualError(t, err, "disclosure salt is not a string")
+
+	d, err := newDisclosure("salt", "name", "value")
+	require.NoError(t, err)
+
+	parsed, err := ParseDisclosure(d.Encoded)
+	require.NoError(t, err)
+	require.Equal(t, d, parsed)
+}
+
+func TestGetHolderPublicKey(t *testing.T) {
+	kmsSvc, _ := newKMSAndCrypto(t)
+
+	tests := []struct {
+		keyType        kms.KeyType
+		alg            string
+		coordinateSize int
+	}{
+		{keyType: kms.ECDSAP256TypeIEEEP1363, alg: "ES256", coordinateSize: 32},
+		{keyType: kms.ECDSAP384TypeIEEEP1363, alg: "ES384", coordinateSize: 48},
+		{keyType: kms.ECDSAP521TypeIEEEP1363, alg: "ES512", coordinateSize: 66},
+		{keyType: kms.ECDSASecp256k1TypeIEEEP1363, alg: "ES256K", coordinateSize: 32},
+		{keyType: kms.ED25519Type, alg: "EdDSA"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.keyType), func(t *testing.T) {
+			_, pubKeyBytes, err := kmsSvc.CreateAndExportPubKeyBytes(tc.keyType)
+			require.NoError(t, err)
+
+			holderJWK, err := jose.PubKeyBytesToJWK(pubKeyBytes, tc.keyType)
+			require.NoError(t, err)
+
+			pubKey, alg, err := getHolderPublicKey(map[string]interface{}{
+				claimCnf: map[string]interface{}{cnfJWK: holderJWK},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, alg)
+
+			if tc.coordinateSize > 0 {
+				require.Len(t, pubKey.X, tc.coordinateSize)
+				require.Len(t, pubKey.Y, tc.coordinateSize)
+			}
+		})
+	}
+
+	t.Run("missing cnf", func(t *testing.T) {
+		_, _, err := getHolderPublicKey(map[string]interface{}{})
+		require.EqualError(t, err, "cnf claim is missing")
+	})
+}
+
+func newKMSAndCrypto(t *testing.T) (kms.KeyManager, cryptoapi.Crypto) {
+	t.Helper()
+
+	kmsSvc, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return kmsSvc, cryptoSvc
+}
@@ -0,0 +1,288 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwt
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+// cnfKeyAlgs maps the curves of the EC holder keys to the JWS algorithm of their key binding JWTs.
+var cnfKeyAlgs = map[string]string{ //nolint:gochecknoglobals
+	"P-256":     jose.AlgES256,
+	"P-384":     jose.AlgES384,
+	"P-521":     jose.AlgES512,
+	"secp256k1": jose.AlgES256K,
+}
+
+// DefaultKeyBindingMaxAge is how old a key binding JWT can be by default, see WithKeyBindingMaxAge.
+const DefaultKeyBindingMaxAge = 5 * time.Minute
+
+// verifyOpts holds options for the SD-JWT verification.
+type verifyOpts struct {
+	sigVerifier         jose.SignatureVerifier
+	holderBindingNeeded bool
+	nonce               string
+	audience            string
+	keyBindingMaxAge    time.Duration
+}
+
+// VerifyOpt is the SD-JWT verification option.
+type VerifyOpt func(opts *verifyOpts)
+
+// WithSignatureVerifier sets the verifier of the issuer's signature, e.g. jose.NewPublicKeyVerifier or
+// jwt.NewVerifier. It is required.
+func WithSignatureVerifier(verifier jose.SignatureVerifier) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.sigVerifier = verifier
+	}
+}
+
+// WithExpectedHolderBinding requires a key binding JWT signed with the key of the "cnf" claim, holding the given
+// nonce and audience.
+func WithExpectedHolderBinding(nonce, audience string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.holderBindingNeeded = true
+		opts.nonce = nonce
+		opts.audience = audience
+	}
+}
+
+// WithKeyBindingMaxAge sets how far the "iat" of the key binding JWT can be from the current time
+// (DefaultKeyBindingMaxAge by default), so that a captured presentation can't be replayed later on.
+func WithKeyBindingMaxAge(maxAge time.Duration) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.keyBindingMaxAge = maxAge
+	}
+}
+
+// Verify verifies an SD-JWT presentation in the combined format and returns its claims, including the disclosed
+// ones. The key binding JWT, if any, is checked against the holder's key of the "cnf" claim.
+func Verify(sdJWT string, opts ...VerifyOpt) (map[string]interface{}, error) {
+	vOpts := &verifyOpts{keyBindingMaxAge: DefaultKeyBindingMaxAge}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	if vOpts.sigVerifier == nil {
+		return nil, errors.New("verify SD-JWT: signature verifier is required")
+	}
+
+	cf, err := parseCombinedFormat(sdJWT)
+	if err != nil {
+		return nil, fmt.Errorf("verify SD-JWT: %w", err)
+	}
+
+	token, err := jwt.Parse(cf.jwt, jwt.WithSignatureVerifier(vOpts.sigVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("verify SD-JWT: %w", err)
+	}
+
+	claims, err := discloseClaims(token.Payload, cf.disclosures)
+	if err != nil {
+		return nil, fmt.Errorf("verify SD-JWT: %w", err)
+	}
+
+	if cf.keyBinding == "" {
+		if vOpts.holderBindingNeeded {
+			return nil, errors.New("verify SD-JWT: key binding JWT is missing")
+		}
+
+		return claims, nil
+	}
+
+	err = verifyKeyBinding(cf, token.Payload, vOpts)
+	if err != nil {
+		return nil, fmt.Errorf("verify SD-JWT: %w", err)
+	}
+
+	return claims, nil
+}
+
+// discloseClaims recomputes the digests of the disclosures, checks that they are listed in the "_sd" claim and
+// returns the payload with the disclosed claims in place of the "_sd" and "_sd_alg" claims.
+func discloseClaims(payload map[string]interface{}, disclosures []string) (map[string]interface{}, error) {
+	if alg, ok := payload[claimSDAlg]; ok && alg != SDAlgorithm {
+		return nil, fmt.Errorf("unsupported _sd_alg '%v'", alg)
+	}
+
+	digests, err := getDigests(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]interface{}, len(payload))
+
+	for k, v := range payload {
+		if k != claimSD && k != claimSDAlg {
+			claims[k] = v
+		}
+	}
+
+	for _, encoded := range disclosures {
+		d, parseErr := ParseDisclosure(encoded)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		dgst := d.Digest()
+
+		if !digests[dgst] {
+			return nil, fmt.Errorf("digest of disclosure of claim '%s' is not in _sd claim", d.Name)
+		}
+
+		// Disclosing the same claim twice is not allowed.
+		delete(digests, dgst)
+
+		if _, ok := claims[d.Name]; ok {
+			return nil, fmt.Errorf("claim '%s' is already present", d.Name)
+		}
+
+		claims[d.Name] = d.Value
+	}
+
+	return claims, nil
+}
+
+func getDigests(payload map[string]interface{}) (map[string]bool, error) {
+	digests := make(map[string]bool)
+
+	sd, ok := payload[claimSD]
+	if !ok {
+		return digests, nil
+	}
+
+	sdArray, ok := sd.([]interface{})
+	if !ok {
+		return nil, errors.New("_sd claim is not an array")
+	}
+
+	for _, d := range sdArray {
+		dgst, isString := d.(string)
+		if !isString {
+			return nil, errors.New("_sd claim holds a non-string digest")
+		}
+
+		digests[dgst] = true
+	}
+
+	return digests, nil
+}
+
+func verifyKeyBinding(cf *combinedFormat, payload map[string]interface{}, opts *verifyOpts) error {
+	holderKey, alg, err := getHolderPublicKey(payload)
+	if err != nil {
+		return err
+	}
+
+	keyVerifier := jose.NewPublicKeyVerifier(holderKey)
+
+	jws, err := jose.ParseJWS(cf.keyBinding, jose.SignatureVerifierFunc(
+		func(joseHeaders jose.Headers, jwsPayload, signingInput, signature []byte) error {
+			// the alg of the key binding JWT is attacker-controlled, it must be the one of the cnf key.
+			if headerAlg, _ := joseHeaders.Algorithm(); headerAlg != alg {
+				return fmt.Errorf("key binding JWT alg '%s' does not match the cnf key alg '%s'", headerAlg, alg)
+			}
+
+			return keyVerifier.Verify(joseHeaders, jwsPayload, signingInput, signature)
+		}))
+	if err != nil {
+		return fmt.Errorf("parse key binding JWT: %w", err)
+	}
+
+	if typ, _ := jws.ProtectedHeaders.Type(); typ != TypeKeyBindingJWT {
+		return fmt.Errorf("key binding JWT typ '%s' is not '%s'", typ, TypeKeyBindingJWT)
+	}
+
+	var claims struct {
+		Nonce  string `json:"nonce"`
+		Aud    string `json:"aud"`
+		Iat    int64  `json:"iat"`
+		SDHash string `json:"sd_hash"`
+	}
+
+	err = json.Unmarshal(jws.Payload, &claims)
+	if err != nil {
+		return fmt.Errorf("unmarshal key binding JWT claims: %w", err)
+	}
+
+	if claims.SDHash != digest(cf.withoutKeyBinding()) {
+		return errors.New("key binding JWT sd_hash does not match the presentation")
+	}
+
+	if claims.Iat == 0 {
+		return errors.New("key binding JWT iat is missing")
+	}
+
+	issuedAt := time.Unix(claims.Iat, 0)
+	if age := time.Since(issuedAt); age > opts.keyBindingMaxAge || age < -opts.keyBindingMaxAge {
+		return fmt.Errorf("key binding JWT iat %s is not within %s of the current time",
+			issuedAt.UTC().Format(time.RFC3339), opts.keyBindingMaxAge)
+	}
+
+	if opts.holderBindingNeeded && claims.Nonce != opts.nonce {
+		return errors.New("key binding JWT nonce does not match")
+	}
+
+	if opts.holderBindingNeeded && claims.Aud != opts.audience {
+		return errors.New("key binding JWT aud does not match")
+	}
+
+	return nil
+}
+
+// getHolderPublicKey returns the holder public key of the cnf claim and the JWS algorithm expected for it.
+func getHolderPublicKey(payload map[string]interface{}) (*cryptoapi.PublicKey, string, error) {
+	cnf, ok := payload[claimCnf].(map[string]interface{})
+	if !ok {
+		return nil, "", errors.New("cnf claim is missing")
+	}
+
+	jwkBytes, err := json.Marshal(cnf[cnfJWK])
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal cnf jwk: %w", err)
+	}
+
+	var jwk jose.JWK
+
+	err = jwk.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("unmarshal cnf jwk: %w", err)
+	}
+
+	switch key := jwk.Key.(type) {
+	case ed25519.PublicKey:
+		return &cryptoapi.PublicKey{X: key, Type: jwk.Kty, Curve: jwk.Crv}, jose.AlgEdDSA, nil
+	case *ecdsa.PublicKey:
+		alg, ok := cnfKeyAlgs[jwk.Crv]
+		if !ok {
+			return nil, "", fmt.Errorf("unsupported cnf jwk curve '%s'", jwk.Crv)
+		}
+
+		coordinateSize := (key.Curve.Params().BitSize + 7) / 8 //nolint:gomnd
+
+		x := make([]byte, coordinateSize)
+		y := make([]byte, coordinateSize)
+
+		key.X.FillBytes(x)
+		key.Y.FillBytes(y)
+
+		return &cryptoapi.PublicKey{X: x, Y: y, Type: jwk.Kty, Curve: jwk.Crv}, alg, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported cnf jwk key type %T", jwk.Key)
+	}
+}
@@ -176,6 +176,23 @@ func (p *Processor) Compact(input, context map[string]interface{},
 	return ld.NewJsonLdProcessor().Compact(input, context, ldOptions)
 }
 
+// Expand expands given json ld object, the external contexts (if any) are appended to the input contexts.
+// The input object is not modified.
+func (p *Processor) Expand(input map[string]interface{}, opts ...ProcessorOpts) ([]interface{}, error) {
+	procOptions := prepareOpts(opts)
+
+	ldOptions := ld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = ld.JsonLd_1_1
+	ldOptions.DocumentLoader = procOptions.documentLoader
+
+	if len(procOptions.externalContexts) > 0 {
+		input = copyMap(input)
+		input["@context"] = AppendExternalContexts(input["@context"], procOptions.externalContexts...)
+	}
+
+	return ld.NewJsonLdProcessor().Expand(input, ldOptions)
+}
+
 // Frame makes a frame from the inputDoc using frameDoc.
 func (p *Processor) Frame(inputDoc map[string]interface{}, frameDoc map[string]interface{},
 	opts ...ProcessorOpts) (map[string]interface{}, error) {
@@ -259,17 +276,29 @@ func copyMap(m map[string]interface{}) map[string]interface{} {
 	cm := make(map[string]interface{})
 
 	for k, v := range m {
-		vm, ok := v.(map[string]interface{})
-		if ok {
-			cm[k] = copyMap(vm)
-		} else {
-			cm[k] = v
-		}
+		cm[k] = copyValue(v)
 	}
 
 	return cm
 }
 
+func copyValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return copyMap(vv)
+	case []interface{}:
+		ca := make([]interface{}, len(vv))
+
+		for i := range vv {
+			ca[i] = copyValue(vv[i])
+		}
+
+		return ca
+	default:
+		return v
+	}
+}
+
 func getDuplicatedIDs(doc map[string]interface{}, proc *ld.JsonLdProcessor, options *ld.JsonLdOptions) (
 	map[string]bool, error) {
 	expand, err := proc.Expand(doc, options)
@@ -476,22 +505,30 @@ func splitMessageIntoLines(msg string) []string {
 
 // TransformBlankNode replaces blank node identifiers in the RDF statements.
 // For example, transform from "_:c14n0" to "urn:bnid:_:c14n0".
+// All blank nodes of the statement are replaced, e.g. both subject and object of the statement
+// linking a blank node to the nested one.
 func TransformBlankNode(row string) string {
-	prefixIndex := strings.Index(row, "_:c14n")
-	if prefixIndex < 0 {
-		return row
-	}
+	var sb strings.Builder
 
-	sepIndex := strings.Index(row[prefixIndex:], " ")
-	if sepIndex < 0 {
-		sepIndex = len(row)
-	} else {
-		sepIndex += prefixIndex
+	for {
+		prefixIndex := strings.Index(row, "_:c14n")
+		if prefixIndex < 0 {
+			break
+		}
+
+		sepIndex := strings.Index(row[prefixIndex:], " ")
+		if sepIndex < 0 {
+			sepIndex = len(row)
+		} else {
+			sepIndex += prefixIndex
+		}
+
+		sb.WriteString(fmt.Sprintf("%s<urn:bnid:%s>", row[:prefixIndex], row[prefixIndex:sepIndex]))
+
+		row = row[sepIndex:]
 	}
 
-	prefix := row[:prefixIndex]
-	blankNode := row[prefixIndex:sepIndex]
-	suffix := row[sepIndex:]
+	sb.WriteString(row)
 
-	return fmt.Sprintf("%s<urn:bnid:%s>%s", prefix, blankNode, suffix)
+	return sb.String()
 }
@@ -229,6 +229,38 @@ func TestCompact(t *testing.T) {
 	})
 }
 
+func TestProcessor_Expand(t *testing.T) {
+	doc := map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/elements/1.1/",
+		},
+		"@id":       "http://example.org/test#book",
+		"dc:title":  "Title",
+		"undefined": "dropped",
+	}
+
+	expandedDoc, err := jsonld.Default().Expand(doc)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"@id": "http://example.org/test#book",
+			"http://purl.org/dc/elements/1.1/title": []interface{}{
+				map[string]interface{}{"@value": "Title"},
+			},
+		},
+	}, expandedDoc)
+
+	loader, err := jsonldtest.DocumentLoader()
+	require.NoError(t, err)
+
+	_, err = jsonld.Default().Expand(doc, jsonld.WithExternalContext("http://127.0.0.1?context=undefined"),
+		jsonld.WithDocumentLoader(loader))
+	require.Error(t, err)
+
+	// the input document is not modified
+	require.Equal(t, map[string]interface{}{"dc": "http://purl.org/dc/elements/1.1/"}, doc["@context"])
+}
+
 func TestProcessor_Frame(t *testing.T) {
 	processor := jsonld.Default()
 
@@ -320,6 +352,8 @@ func TestTransformBlankNodes(t *testing.T) {
 		fe = "abcd <urn:bnid:_:c14n> efgh"
 		g  = ""
 		ge = ""
+		h  = "_:c14n1 <https://example.org/examples#degree> _:c14n0 ."
+		he = "<urn:bnid:_:c14n1> <https://example.org/examples#degree> <urn:bnid:_:c14n0> ."
 	)
 
 	at := jsonld.TransformBlankNode(a)
@@ -342,6 +376,9 @@ func TestTransformBlankNodes(t *testing.T) {
 
 	gt := jsonld.TransformBlankNode(g)
 	require.Equal(t, ge, gt)
+
+	ht := jsonld.TransformBlankNode(h)
+	require.Equal(t, he, ht)
 }
 
 func BenchmarkGetCanonicalDocument(b *testing.B) {
@@ -73,7 +73,7 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 
 	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
 
-	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, opts...)
+	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, proofOptions, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +94,7 @@ func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]
 	proofOptionsCopy := make(map[string]interface{}, len(proofOptions))
 
 	for key, value := range proofOptions {
-		if excludedKeyFromString(key) == 0 {
+		if excludedKeyFromString(key) == 0 || isDataIntegrityProofID(proofOptions, key) {
 			proofOptionsCopy[key] = value
 		}
 	}
@@ -112,10 +112,19 @@ func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]
 	return suite.GetCanonicalDocument(proofOptionsCopy, opts...)
 }
 
-func prepareCanonicalDocument(suite signatureSuite, jsonldObject map[string]interface{},
+// isDataIntegrityProofID checks if the key is ID of Data Integrity proof, which (unlike ID of other proofs)
+// is secured by the proof.
+func isDataIntegrityProofID(proofOptions map[string]interface{}, key string) bool {
+	return key == jsonldID && proofOptions[jsonldType] == DataIntegrityProofType
+}
+
+func prepareCanonicalDocument(suite signatureSuite, jsonldObject, proofOptions map[string]interface{},
 	opts ...jsonld.ProcessorOpts) ([]byte, error) {
-	// copy document object without proof
-	docCopy := GetCopyWithoutProof(jsonldObject)
+	// copy document object without proof (but with previous proofs in case of a proof chain)
+	docCopy, err := getCopyWithPreviousProofs(jsonldObject, proofOptions[jsonldPreviousProof])
+	if err != nil {
+		return nil, err
+	}
 
 	// build canonical document
 	return suite.GetCanonicalDocument(docCopy, opts...)
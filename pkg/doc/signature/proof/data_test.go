@@ -47,7 +47,7 @@ func TestPrepareCanonicalDocument(t *testing.T) {
 	err := json.Unmarshal([]byte(test1), &doc)
 	require.NoError(t, err)
 
-	normalizedDoc, err := prepareCanonicalDocument(&mockSignatureSuite{}, doc)
+	normalizedDoc, err := prepareCanonicalDocument(&mockSignatureSuite{}, doc, map[string]interface{}{})
 	require.NoError(t, err)
 	require.NotEmpty(t, normalizedDoc)
 	require.Equal(t, test1Result, string(normalizedDoc))
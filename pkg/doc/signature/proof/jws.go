@@ -40,6 +40,11 @@ func CreateDetachedJWTHeader(p *Proof) string {
 		jwsAlg = p.Type
 	}
 
+	return CreateDetachedJWTHeaderWithAlg(jwsAlg)
+}
+
+// CreateDetachedJWTHeaderWithAlg creates detached JWT header with the given JWS algorithm (e.g. "ES256").
+func CreateDetachedJWTHeaderWithAlg(jwsAlg string) string {
 	jwtHeaderMap := map[string]interface{}{
 		"alg":  jwsAlg,
 		"b64":  false,
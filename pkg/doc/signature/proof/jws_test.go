@@ -112,6 +112,14 @@ func TestCreateDetachedJWTHeader(t *testing.T) {
 	require.Equal(t, "JsonWebSignature2020", jwtHeaderMap["alg"])
 	require.Equal(t, false, jwtHeaderMap["b64"])
 	require.Equal(t, []interface{}{"b64"}, jwtHeaderMap["crit"])
+
+	jwtHeader = CreateDetachedJWTHeaderWithAlg("ES384")
+	require.NotEmpty(t, jwtHeader)
+
+	jwtHeaderMap = getJwtHeaderMap(jwtHeader)
+	require.Equal(t, "ES384", jwtHeaderMap["alg"])
+	require.Equal(t, false, jwtHeaderMap["b64"])
+	require.Equal(t, []interface{}{"b64"}, jwtHeaderMap["crit"])
 }
 
 func TestGetJWTSignature(t *testing.T) {
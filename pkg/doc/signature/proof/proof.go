@@ -10,6 +10,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/multiformats/go-multibase"
+
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 )
 
@@ -20,6 +22,8 @@ const (
 	jsonldCreator = "creator"
 	// jsonldCreated is key for time proof created.
 	jsonldCreated = "created"
+	// jsonldExpires is key for time proof expires.
+	jsonldExpires = "expires"
 	// jsonldDomain is key for domain name.
 	jsonldDomain = "domain"
 	// jsonldNonce is key for nonce.
@@ -36,12 +40,29 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldCryptosuite is a key for cryptosuite of Data Integrity proof.
+	jsonldCryptosuite = "cryptosuite"
+	// jsonldID is a key for proof ID.
+	jsonldID = "id"
+	// jsonldPreviousProof is a key for ID(s) of the proof(s) preceding the proof in a proof chain.
+	jsonldPreviousProof = "previousProof"
 )
 
+// DataIntegrityProofType is the type of Data Integrity proof (https://www.w3.org/TR/vc-data-integrity/).
+// Data Integrity proofs are distinguished by their cryptosuite and keep proofValue encoded as multibase.
+const DataIntegrityProofType = "DataIntegrityProof"
+
+// ed25519Signature2020ProofType is the type of Ed25519Signature2020 proof, which keeps proofValue encoded as multibase
+// too.
+const ed25519Signature2020ProofType = "Ed25519Signature2020"
+
 // Proof is cryptographic proof of the integrity of the DID Document.
 type Proof struct {
+	ID                      string
 	Type                    string
+	Cryptosuite             string
 	Created                 *util.TimeWithTrailingZeroMsec
+	Expires                 *util.TimeWithTrailingZeroMsec
 	Creator                 string
 	VerificationMethod      string
 	ProofValue              []byte
@@ -53,6 +74,8 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// PreviousProof holds IDs of the proofs which are secured by this proof in a proof chain.
+	PreviousProof []string
 }
 
 // NewProof creates new proof.
@@ -64,14 +87,21 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		return nil, err
 	}
 
+	expires, err := decodeExpires(emap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode expires: %w", err)
+	}
+
 	var (
 		proofValue  []byte
 		proofHolder SignatureRepresentation
 		jws         string
 	)
 
+	proofType := stringEntry(emap[jsonldType])
+
 	if generalProof, ok := emap[jsonldProofValue]; ok {
-		proofValue, err = decodeBase64(stringEntry(generalProof))
+		proofValue, err = decodeProofValue(stringEntry(generalProof), proofType)
 		if err != nil {
 			return nil, err
 		}
@@ -96,9 +126,17 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		return nil, fmt.Errorf("failed to decode capabilityChain: %w", err)
 	}
 
+	previousProof, err := decodePreviousProof(emap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode previousProof: %w", err)
+	}
+
 	return &Proof{
-		Type:                    stringEntry(emap[jsonldType]),
+		ID:                      stringEntry(emap[jsonldID]),
+		Type:                    proofType,
+		Cryptosuite:             stringEntry(emap[jsonldCryptosuite]),
 		Created:                 timeValue,
+		Expires:                 expires,
 		Creator:                 stringEntry(emap[jsonldCreator]),
 		VerificationMethod:      stringEntry(emap[jsonldVerificationMethod]),
 		ProofValue:              proofValue,
@@ -109,9 +147,24 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		PreviousProof:           previousProof,
 	}, nil
 }
 
+func decodeExpires(proof map[string]interface{}) (*util.TimeWithTrailingZeroMsec, error) {
+	expires, ok := proof[jsonldExpires]
+	if !ok {
+		return nil, nil
+	}
+
+	expiresStr, ok := expires.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid format - must be a string: %+v", expires)
+	}
+
+	return util.ParseTimeWithTrailingZeroMsec(expiresStr)
+}
+
 func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error) {
 	var capabilityChain []interface{}
 
@@ -128,6 +181,59 @@ func decodeCapabilityChain(proof map[string]interface{}) ([]interface{}, error)
 	return capabilityChain, nil
 }
 
+func decodePreviousProof(proof map[string]interface{}) ([]string, error) {
+	switch previousProof := proof[jsonldPreviousProof].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{previousProof}, nil
+	case []interface{}:
+		ids := make([]string, len(previousProof))
+
+		for i, id := range previousProof {
+			idStr, ok := id.(string)
+			if !ok {
+				return nil, fmt.Errorf("proof ID must be a string: %+v", id)
+			}
+
+			ids[i] = idStr
+		}
+
+		return ids, nil
+	default:
+		return nil, fmt.Errorf("invalid format - must be a string or an array: %+v", previousProof)
+	}
+}
+
+// multibaseProofValue checks if proofValue of the proof type is encoded as multibase (base58btc) rather than base64.
+func multibaseProofValue(proofType string) bool {
+	return proofType == DataIntegrityProofType || proofType == ed25519Signature2020ProofType
+}
+
+func decodeProofValue(s, proofType string) ([]byte, error) {
+	if multibaseProofValue(proofType) {
+		_, value, err := multibase.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode multibase proofValue: %w", err)
+		}
+
+		return value, nil
+	}
+
+	return decodeBase64(s)
+}
+
+func encodeProofValue(proofValue []byte, proofType string) string {
+	if multibaseProofValue(proofType) {
+		// base58btc is the only supported encoding of multibase proofValue, hence error is not possible.
+		value, _ := multibase.Encode(multibase.Base58BTC, proofValue) //nolint:errcheck
+
+		return value
+	}
+
+	return base64.RawURLEncoding.EncodeToString(proofValue)
+}
+
 func decodeBase64(s string) ([]byte, error) {
 	allEncodings := []*base64.Encoding{
 		base64.RawURLEncoding, base64.StdEncoding,
@@ -157,6 +263,14 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	emap := make(map[string]interface{})
 	emap[jsonldType] = p.Type
 
+	if p.ID != "" {
+		emap[jsonldID] = p.ID
+	}
+
+	if p.Cryptosuite != "" {
+		emap[jsonldCryptosuite] = p.Cryptosuite
+	}
+
 	if p.Creator != "" {
 		emap[jsonldCreator] = p.Creator
 	}
@@ -169,8 +283,12 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCreated] = p.Created.Format(p.Created.GetFormat())
 	}
 
+	if p.Expires != nil {
+		emap[jsonldExpires] = p.Expires.Format(p.Expires.GetFormat())
+	}
+
 	if len(p.ProofValue) > 0 {
-		emap[jsonldProofValue] = base64.RawURLEncoding.EncodeToString(p.ProofValue)
+		emap[jsonldProofValue] = encodeProofValue(p.ProofValue, p.Type)
 	}
 
 	if len(p.JWS) > 0 {
@@ -197,6 +315,19 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
 
+	switch len(p.PreviousProof) {
+	case 0:
+	case 1:
+		emap[jsonldPreviousProof] = p.PreviousProof[0]
+	default:
+		previousProof := make([]interface{}, len(p.PreviousProof))
+		for i, id := range p.PreviousProof {
+			previousProof[i] = id
+		}
+
+		emap[jsonldPreviousProof] = previousProof
+	}
+
 	return emap
 }
 
@@ -122,6 +122,38 @@ func TestProof(t *testing.T) {
 			require.Contains(t, err.Error(), "invalid format for capabilityChain")
 		})
 	})
+
+	t.Run("expires", func(t *testing.T) {
+		p, err := NewProof(map[string]interface{}{
+			"type":               "type",
+			"verificationMethod": "did:example:123456#key1",
+			"created":            "2018-03-15T00:00:00Z",
+			"expires":            "2018-03-16T00:00:00.000Z",
+			"proofValue":         proofValueBase64,
+		})
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2018, 3, 16, 0, 0, 0, 0, time.UTC), p.Expires.Time)
+		require.Equal(t, "2018-03-16T00:00:00.000Z", p.JSONLdObject()["expires"])
+
+		p, err = NewProof(map[string]interface{}{
+			"type":               "type",
+			"verificationMethod": "did:example:123456#key1",
+			"created":            "2018-03-15T00:00:00Z",
+			"proofValue":         proofValueBase64,
+		})
+		require.NoError(t, err)
+		require.Nil(t, p.Expires)
+		require.NotContains(t, p.JSONLdObject(), "expires")
+
+		_, err = NewProof(map[string]interface{}{
+			"type":               "type",
+			"verificationMethod": "did:example:123456#key1",
+			"created":            "2018-03-15T00:00:00Z",
+			"expires":            1521072000,
+			"proofValue":         proofValueBase64,
+		})
+		require.EqualError(t, err, "failed to decode expires: invalid format - must be a string: 1521072000")
+	})
 }
 
 func TestInvalidProofValue(t *testing.T) {
@@ -253,6 +285,114 @@ func TestProof_JSONLdObject(t *testing.T) {
 	})
 }
 
+func TestDataIntegrityProof(t *testing.T) {
+	r := require.New(t)
+
+	// proofValueBase64 encoded as multibase base58btc
+	const proofValueMultibase = "z5gpJQZoaLUXevXk2mYYbQE9krfaJYBBwQcJhhAvX3zs6daJ2Eb6VJoU46WkUYN8R1vgX7o8ktuUkzpRJS5aJRQyh"
+
+	p, err := NewProof(map[string]interface{}{
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        "eddsa-rdfc-2022",
+		"created":            "2023-02-24T23:36:38Z",
+		"verificationMethod": "did:example:123#key-1",
+		"proofPurpose":       "assertionMethod",
+		"proofValue":         proofValueMultibase,
+	})
+	r.NoError(err)
+	r.Equal(DataIntegrityProofType, p.Type)
+	r.Equal("eddsa-rdfc-2022", p.Cryptosuite)
+	r.Equal(SignatureProofValue, p.SignatureRepresentation)
+
+	proofValueBytes, err := base64.RawURLEncoding.DecodeString(proofValueBase64)
+	r.NoError(err)
+	r.Equal(proofValueBytes, p.ProofValue)
+
+	pJSONLd := p.JSONLdObject()
+	r.Equal("eddsa-rdfc-2022", pJSONLd["cryptosuite"])
+	r.Equal(proofValueMultibase, pJSONLd["proofValue"])
+
+	t.Run("invalid multibase proofValue", func(t *testing.T) {
+		_, err := NewProof(map[string]interface{}{
+			"type":        "DataIntegrityProof",
+			"cryptosuite": "eddsa-rdfc-2022",
+			"created":     "2023-02-24T23:36:38Z",
+			"proofValue":  proofValueBase64,
+		})
+		r.Error(err)
+		r.Contains(err.Error(), "decode multibase proofValue")
+	})
+}
+
+func TestEd25519Signature2020Proof(t *testing.T) {
+	r := require.New(t)
+
+	// proofValueBase64 encoded as multibase base58btc
+	const proofValueMultibase = "z5gpJQZoaLUXevXk2mYYbQE9krfaJYBBwQcJhhAvX3zs6daJ2Eb6VJoU46WkUYN8R1vgX7o8ktuUkzpRJS5aJRQyh"
+
+	p, err := NewProof(map[string]interface{}{
+		"type":               "Ed25519Signature2020",
+		"created":            "2023-02-24T23:36:38Z",
+		"verificationMethod": "did:example:123#key-1",
+		"proofPurpose":       "assertionMethod",
+		"proofValue":         proofValueMultibase,
+	})
+	r.NoError(err)
+	r.Equal(SignatureProofValue, p.SignatureRepresentation)
+
+	proofValueBytes, err := base64.RawURLEncoding.DecodeString(proofValueBase64)
+	r.NoError(err)
+	r.Equal(proofValueBytes, p.ProofValue)
+	r.Equal(proofValueMultibase, p.JSONLdObject()["proofValue"])
+}
+
+func TestProofChain(t *testing.T) {
+	r := require.New(t)
+
+	p, err := NewProof(map[string]interface{}{
+		"id":            "urn:uuid:26329423-bec9-4b2e-88cb-a7c7d9dc4544",
+		"type":          "Ed25519Signature2018",
+		"created":       "2018-03-15T00:00:00Z",
+		"jws":           "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..signature",
+		"previousProof": "urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7",
+	})
+	r.NoError(err)
+	r.Equal("urn:uuid:26329423-bec9-4b2e-88cb-a7c7d9dc4544", p.ID)
+	r.Equal([]string{"urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7"}, p.PreviousProof)
+
+	pJSONLd := p.JSONLdObject()
+	r.Equal("urn:uuid:26329423-bec9-4b2e-88cb-a7c7d9dc4544", pJSONLd["id"])
+	r.Equal("urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7", pJSONLd["previousProof"])
+
+	p, err = NewProof(map[string]interface{}{
+		"type":          "Ed25519Signature2018",
+		"created":       "2018-03-15T00:00:00Z",
+		"jws":           "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..signature",
+		"previousProof": []interface{}{"urn:uuid:1", "urn:uuid:2"},
+	})
+	r.NoError(err)
+	r.Equal([]string{"urn:uuid:1", "urn:uuid:2"}, p.PreviousProof)
+	r.Equal([]interface{}{"urn:uuid:1", "urn:uuid:2"}, p.JSONLdObject()["previousProof"])
+
+	_, err = NewProof(map[string]interface{}{
+		"type":          "Ed25519Signature2018",
+		"created":       "2018-03-15T00:00:00Z",
+		"jws":           "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..signature",
+		"previousProof": []interface{}{1},
+	})
+	r.Error(err)
+	r.Contains(err.Error(), "failed to decode previousProof: proof ID must be a string")
+
+	_, err = NewProof(map[string]interface{}{
+		"type":          "Ed25519Signature2018",
+		"created":       "2018-03-15T00:00:00Z",
+		"jws":           "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..signature",
+		"previousProof": 1,
+	})
+	r.Error(err)
+	r.Contains(err.Error(), "failed to decode previousProof: invalid format - must be a string or an array")
+}
+
 func TestProof_PublicKeyID(t *testing.T) {
 	p := Proof{
 		Creator:            "creator",
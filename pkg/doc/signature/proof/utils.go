@@ -7,6 +7,7 @@ package proof
 
 import (
 	"errors"
+	"fmt"
 )
 
 const (
@@ -87,5 +88,59 @@ func GetCopyWithoutProof(jsonLdObject map[string]interface{}) map[string]interfa
 	return dest
 }
 
+// getCopyWithPreviousProofs gets copy of JSON LD Object without proofs except the previous proofs
+// (referred by ID) which are secured by a proof of a proof chain.
+func getCopyWithPreviousProofs(jsonLdObject map[string]interface{}, previousProof interface{}) (
+	map[string]interface{}, error) {
+	dest := GetCopyWithoutProof(jsonLdObject)
+
+	previousProofIDs, err := decodePreviousProof(map[string]interface{}{jsonldPreviousProof: previousProof})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode previousProof: %w", err)
+	}
+
+	if len(previousProofIDs) == 0 {
+		return dest, nil
+	}
+
+	var proofs []interface{}
+
+	switch p := jsonLdObject[jsonldProof].(type) {
+	case []interface{}:
+		proofs = p
+	case map[string]interface{}:
+		proofs = []interface{}{p}
+	}
+
+	previousProofs := make([]interface{}, 0, len(previousProofIDs))
+
+	for _, id := range previousProofIDs {
+		previous := findProof(proofs, id)
+		if previous == nil {
+			return nil, fmt.Errorf("previous proof %s not found", id)
+		}
+
+		previousProofs = append(previousProofs, previous)
+	}
+
+	if len(previousProofs) == 1 {
+		dest[jsonldProof] = previousProofs[0]
+	} else {
+		dest[jsonldProof] = previousProofs
+	}
+
+	return dest, nil
+}
+
+func findProof(proofs []interface{}, id string) map[string]interface{} {
+	for _, p := range proofs {
+		if proofMap, ok := p.(map[string]interface{}); ok && proofMap[jsonldID] == id {
+			return proofMap
+		}
+	}
+
+	return nil
+}
+
 // ErrProofNotFound is returned when proof is not found.
 var ErrProofNotFound = errors.New("proof not found")
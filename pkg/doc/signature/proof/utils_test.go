@@ -67,6 +67,34 @@ func TestGetCopyWithoutProof(t *testing.T) {
 	require.True(t, reflect.DeepEqual(docCopy, getDefaultDoc()))
 }
 
+func TestGetCopyWithPreviousProofs(t *testing.T) {
+	doc := getDefaultDoc()
+	doc[jsonldProof] = []interface{}{
+		map[string]interface{}{"id": "urn:uuid:1", "type": "DataIntegrityProof"},
+		map[string]interface{}{"id": "urn:uuid:2", "type": "DataIntegrityProof"},
+		map[string]interface{}{"id": "urn:uuid:3", "type": "DataIntegrityProof", "previousProof": "urn:uuid:2"},
+	}
+
+	docCopy, err := getCopyWithPreviousProofs(doc, nil)
+	require.NoError(t, err)
+	require.Equal(t, getDefaultDoc(), docCopy)
+
+	docCopy, err = getCopyWithPreviousProofs(doc, "urn:uuid:2")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"id": "urn:uuid:2", "type": "DataIntegrityProof"}, docCopy[jsonldProof])
+
+	docCopy, err = getCopyWithPreviousProofs(doc, []interface{}{"urn:uuid:1", "urn:uuid:2"})
+	require.NoError(t, err)
+	require.Len(t, docCopy[jsonldProof], 2)
+
+	_, err = getCopyWithPreviousProofs(doc, "urn:uuid:4")
+	require.EqualError(t, err, "previous proof urn:uuid:4 not found")
+
+	_, err = getCopyWithPreviousProofs(doc, 4)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to decode previousProof")
+}
+
 func TestAddSingleProof(t *testing.T) {
 	doc := map[string]interface{}{
 		"test": "test",
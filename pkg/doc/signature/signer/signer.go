@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
@@ -37,6 +38,20 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// dataIntegritySuite is implemented by Data Integrity signature suites which share
+// "DataIntegrityProof" proof type and are distinguished by the cryptosuite.
+type dataIntegritySuite interface {
+	// Cryptosuite returns the cryptosuite identifier (e.g. "eddsa-rdfc-2022")
+	Cryptosuite() string
+}
+
+// jwsAlgorithmSuite is implemented by signature suites which define the JWS algorithm of their detached JWS proofs
+// (e.g. JsonWebSignature2020 which derives it from the signing key), rather than the proof type.
+type jwsAlgorithmSuite interface {
+	// JWSAlgorithm returns the JWS algorithm, empty if it is unknown
+	JWSAlgorithm() (string, error)
+}
+
 // DocumentSigner implements signing of JSONLD documents.
 type DocumentSigner struct {
 	signatureSuites []SignatureSuite
@@ -44,16 +59,20 @@ type DocumentSigner struct {
 
 // Context holds signing options and private key.
 type Context struct {
+	ID                      string                        // optional
 	SignatureType           string                        // required
 	Creator                 string                        // required
 	SignatureRepresentation proof.SignatureRepresentation // optional
 	Created                 *time.Time                    // optional
+	Expires                 *time.Time                    // optional
 	Domain                  string                        // optional
 	Nonce                   []byte                        // optional
 	VerificationMethod      string                        // optional
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	PreviousProof           []string                      // optional
+	Clock                   clock.Clock                   // optional, reads the time of signing if Created is not set
 }
 
 // New returns new instance of document verifier.
@@ -97,11 +116,12 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 
 	created := context.Created
 	if created == nil {
-		now := time.Now()
+		now := clock.OrSystem(context.Clock).Now()
 		created = &now
 	}
 
 	p := &proof.Proof{
+		ID:                      context.ID,
 		Type:                    context.SignatureType,
 		SignatureRepresentation: context.SignatureRepresentation,
 		Creator:                 context.Creator,
@@ -112,6 +132,15 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Challenge:               context.Challenge,
 		ProofPurpose:            context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		PreviousProof:           context.PreviousProof,
+	}
+
+	if context.Expires != nil {
+		p.Expires = &util.TimeWithTrailingZeroMsec{Time: *context.Expires}
+	}
+
+	if diSuite, ok := suite.(dataIntegritySuite); ok {
+		p.Cryptosuite = diSuite.Cryptosuite()
 	}
 
 	// TODO support custom proof purpose
@@ -121,7 +150,12 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 	}
 
 	if context.SignatureRepresentation == proof.SignatureJWS {
-		p.JWS = proof.CreateDetachedJWTHeader(p) + ".."
+		p.JWS, err = createDetachedJWTHeader(suite, p)
+		if err != nil {
+			return err
+		}
+
+		p.JWS += ".."
 	}
 
 	message, err := proof.CreateVerifyData(suite, jsonLdObject, p, append(opts, jsonld.WithValidateRDF())...)
@@ -139,6 +173,21 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 	return proof.AddProof(jsonLdObject, p)
 }
 
+func createDetachedJWTHeader(suite SignatureSuite, p *proof.Proof) (string, error) {
+	if algSuite, ok := suite.(jwsAlgorithmSuite); ok {
+		jwsAlg, err := algSuite.JWSAlgorithm()
+		if err != nil {
+			return "", fmt.Errorf("get JWS algorithm: %w", err)
+		}
+
+		if jwsAlg != "" {
+			return proof.CreateDetachedJWTHeaderWithAlg(jwsAlg), nil
+		}
+	}
+
+	return proof.CreateDetachedJWTHeader(p), nil
+}
+
 func (signer *DocumentSigner) applySignatureValue(context *Context, p *proof.Proof, s []byte) {
 	switch context.SignatureRepresentation {
 	case proof.SignatureProofValue:
@@ -8,7 +8,9 @@ package signer
 import (
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
@@ -67,6 +70,60 @@ func TestDocumentSigner_Sign(t *testing.T) {
 	require.Contains(t, proofMap, "jws")
 }
 
+func TestDocumentSigner_SignCreatedAndExpires(t *testing.T) {
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	s := New(ed25519signature2018.New(suite.WithSigner(signer)))
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := created.Add(time.Hour)
+
+	context := getSignatureContext()
+	context.Created = &created
+	context.Expires = &expires
+
+	signedDoc, err := s.Sign(context, []byte(validDoc), jsonldtest.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signedDocMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signedDocMap))
+
+	proofs, err := proof.GetProofs(signedDocMap)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.Equal(t, created, proofs[0].Created.Time)
+	require.Equal(t, expires, proofs[0].Expires.Time)
+
+	// the same context and document produce the same proof
+	signedAgainDoc, err := s.Sign(context, []byte(validDoc), jsonldtest.WithDocumentLoader(t))
+	require.NoError(t, err)
+	require.Equal(t, signedDoc, signedAgainDoc)
+}
+
+func TestDocumentSigner_SignCreatedByClock(t *testing.T) {
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	s := New(ed25519signature2018.New(suite.WithSigner(signer)))
+
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	context := getSignatureContext()
+	context.Clock = mockclock.New(created)
+
+	signedDoc, err := s.Sign(context, []byte(validDoc), jsonldtest.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	var signedDocMap map[string]interface{}
+	require.NoError(t, json.Unmarshal(signedDoc, &signedDocMap))
+
+	proofs, err := proof.GetProofs(signedDocMap)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+	require.Equal(t, created, proofs[0].Created.Time)
+}
+
 func TestDocumentSigner_SignErrors(t *testing.T) {
 	context := getSignatureContext()
 	signer, err := newCryptoSigner(kmsapi.ED25519Type)
@@ -112,6 +169,23 @@ func TestDocumentSigner_SignErrors(t *testing.T) {
 	require.NotNil(t, err)
 	require.Nil(t, signedDoc)
 	require.Contains(t, err.Error(), "bad private key length")
+
+	// test JWS algorithm error
+	context = getSignatureContext()
+	context.SignatureRepresentation = proof.SignatureJWS
+	s = New(&jwsAlgorithmSuiteStub{SignatureSuite: ed25519signature2018.New(suite.WithSigner(signer))})
+	signedDoc, err = s.Sign(context, []byte(validDoc), jsonldtest.WithDocumentLoader(t))
+	require.NotNil(t, err)
+	require.Nil(t, signedDoc)
+	require.Contains(t, err.Error(), "get JWS algorithm: unsupported key")
+}
+
+type jwsAlgorithmSuiteStub struct {
+	SignatureSuite
+}
+
+func (s *jwsAlgorithmSuiteStub) JWSAlgorithm() (string, error) {
+	return "", errors.New("unsupported key")
 }
 
 func TestDocumentSigner_isValidContext(t *testing.T) {
@@ -0,0 +1,141 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsardfc2019
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+
+	"github.com/multiformats/go-multibase"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// MultikeyType is the type of verification method which keeps the public key
+// as multicodec prefixed value encoded into "publicKeyMultibase".
+const MultikeyType = "Multikey"
+
+type curve struct {
+	name string
+	ec   elliptic.Curve
+	hash crypto.Hash
+	// multicodec header of the compressed public key (varint of the code)
+	multicodecHeader []byte
+	verifier         func() *verifier.ECDSASignatureVerifier
+}
+
+//nolint:gochecknoglobals
+var (
+	p256 = &curve{
+		name:             "P-256",
+		ec:               elliptic.P256(),
+		hash:             crypto.SHA256,
+		multicodecHeader: []byte{0x80, 0x24},
+		verifier:         verifier.NewECDSAES256SignatureVerifier,
+	}
+	p384 = &curve{
+		name:             "P-384",
+		ec:               elliptic.P384(),
+		hash:             crypto.SHA384,
+		multicodecHeader: []byte{0x81, 0x24},
+		verifier:         verifier.NewECDSAES384SignatureVerifier,
+	}
+	curves = []*curve{p256, p384}
+)
+
+// PublicKeyVerifier verifies ECDSA P-256 and P-384 signatures in IEEE P1363 format.
+// The public key is accepted as JSON Web Key, as Multikey or as compressed or uncompressed point bytes.
+type PublicKeyVerifier struct{}
+
+// NewPublicKeyVerifier creates a signature verifier that verifies ECDSA P-256 and P-384 signatures.
+func NewPublicKeyVerifier() *PublicKeyVerifier {
+	return &PublicKeyVerifier{}
+}
+
+// Verify verifies the signature.
+func (v *PublicKeyVerifier) Verify(pubKey *verifier.PublicKey, msg, signature []byte) error {
+	c, value, err := decodePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	return c.verifier().Verify(&verifier.PublicKey{
+		Type:  pubKey.Type,
+		Value: value,
+		JWK:   pubKey.JWK,
+	}, msg, signature)
+}
+
+// decodePublicKey returns the curve and uncompressed point bytes of the public key.
+func decodePublicKey(pubKey *verifier.PublicKey) (*curve, []byte, error) {
+	if pubKey.JWK != nil {
+		for _, c := range curves {
+			if pubKey.JWK.Crv == c.name {
+				return c, pubKey.Value, nil
+			}
+		}
+
+		return nil, nil, fmt.Errorf("unsupported JWK curve: %s", pubKey.JWK.Crv)
+	}
+
+	if pubKey.Type == MultikeyType {
+		return decodeMultikey(pubKey.Value)
+	}
+
+	for _, c := range curves {
+		if value, ok := c.unmarshal(pubKey.Value); ok {
+			return c, value, nil
+		}
+	}
+
+	return nil, nil, errors.New("public key is neither P-256 nor P-384 key")
+}
+
+// decodeMultikey decodes P-256 or P-384 Multikey.
+// The key is accepted either as "publicKeyMultibase" value or as its multibase decoded bytes.
+func decodeMultikey(key []byte) (*curve, []byte, error) {
+	if len(key) > 0 && key[0] == byte(multibase.Base58BTC) {
+		_, decoded, err := multibase.Decode(string(key))
+		if err != nil {
+			return nil, nil, fmt.Errorf("decode multibase of Multikey: %w", err)
+		}
+
+		key = decoded
+	}
+
+	for _, c := range curves {
+		if !bytes.HasPrefix(key, c.multicodecHeader) {
+			continue
+		}
+
+		value, ok := c.unmarshal(key[len(c.multicodecHeader):])
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid %s Multikey", c.name)
+		}
+
+		return c, value, nil
+	}
+
+	return nil, nil, errors.New("multikey is neither P-256 nor P-384 public key")
+}
+
+// unmarshal returns uncompressed point bytes of compressed or uncompressed public key of the curve.
+func (c *curve) unmarshal(key []byte) ([]byte, bool) {
+	x, y := elliptic.UnmarshalCompressed(c.ec, key)
+	if x == nil {
+		x, y = elliptic.Unmarshal(c.ec, key)
+	}
+
+	if x == nil {
+		return nil, false
+	}
+
+	return elliptic.Marshal(c.ec, x, y), true
+}
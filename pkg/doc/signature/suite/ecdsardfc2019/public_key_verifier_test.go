@@ -0,0 +1,114 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsardfc2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	msg := []byte("test message")
+	v := NewPublicKeyVerifier()
+
+	for _, c := range curves {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			privKey, err := ecdsa.GenerateKey(c.ec, rand.Reader)
+			require.NoError(t, err)
+
+			signature := sign(t, c, privKey, msg)
+
+			multikey := newMultikey(c, &privKey.PublicKey)
+
+			multikeyMultibase, err := multibase.Encode(multibase.Base58BTC, multikey)
+			require.NoError(t, err)
+
+			jwk, err := jose.JWKFromKey(&privKey.PublicKey)
+			require.NoError(t, err)
+
+			for name, pubKey := range map[string]*verifier.PublicKey{
+				"uncompressed public key": {
+					Type:  "JsonWebKey2020",
+					Value: elliptic.Marshal(c.ec, privKey.X, privKey.Y),
+				},
+				"compressed public key": {
+					Type:  "JsonWebKey2020",
+					Value: elliptic.MarshalCompressed(c.ec, privKey.X, privKey.Y),
+				},
+				"JWK": {
+					Type: "JsonWebKey2020",
+					JWK:  jwk,
+				},
+				"decoded Multikey": {
+					Type:  MultikeyType,
+					Value: multikey,
+				},
+				"publicKeyMultibase of Multikey": {
+					Type:  MultikeyType,
+					Value: []byte(multikeyMultibase),
+				},
+			} {
+				require.NoError(t, v.Verify(pubKey, msg, signature), name)
+			}
+
+			err = v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: multikey}, []byte("other message"), signature)
+			require.EqualError(t, err, "ecdsa: invalid signature")
+		})
+	}
+
+	t.Run("unsupported keys", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: []byte{0xed, 0x01, 0x01}}, msg, nil)
+		require.EqualError(t, err, "multikey is neither P-256 nor P-384 public key")
+
+		err = v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: []byte{0x80, 0x24, 0x01}}, msg, nil)
+		require.EqualError(t, err, "invalid P-256 Multikey")
+
+		err = v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: []byte("z0OIl")}, msg, nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode multibase of Multikey")
+
+		err = v.Verify(&verifier.PublicKey{Type: "JsonWebKey2020", Value: []byte{0x01}}, msg, nil)
+		require.EqualError(t, err, "public key is neither P-256 nor P-384 key")
+
+		err = v.Verify(&verifier.PublicKey{Type: "JsonWebKey2020", JWK: &jose.JWK{Crv: "P-521"}}, msg, nil)
+		require.EqualError(t, err, "unsupported JWK curve: P-521")
+	})
+}
+
+func newMultikey(c *curve, pubKey *ecdsa.PublicKey) []byte {
+	return append(append([]byte{}, c.multicodecHeader...), elliptic.MarshalCompressed(c.ec, pubKey.X, pubKey.Y)...)
+}
+
+// sign creates ECDSA signature in IEEE P1363 format.
+func sign(t *testing.T, c *curve, privKey *ecdsa.PrivateKey, msg []byte) []byte {
+	t.Helper()
+
+	hasher := c.hash.New()
+	_, err := hasher.Write(msg)
+	require.NoError(t, err)
+
+	r, s, err := ecdsa.Sign(rand.Reader, privKey, hasher.Sum(nil))
+	require.NoError(t, err)
+
+	keySize := (c.ec.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*keySize)
+
+	r.FillBytes(signature[:keySize])
+	s.FillBytes(signature[keySize:])
+
+	return signature
+}
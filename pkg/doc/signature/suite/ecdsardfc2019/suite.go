@@ -0,0 +1,119 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ecdsardfc2019 implements the ecdsa-rdfc-2019 cryptosuite of the Data Integrity
+// specification (https://www.w3.org/TR/vc-di-ecdsa/#ecdsa-rdfc-2019).
+// Proofs of this cryptosuite have "DataIntegrityProof" type and "ecdsa-rdfc-2019" cryptosuite.
+// It uses the RDF Dataset Canonicalization Algorithm (RDFC-1.0, formerly URDNA2015)
+// to transform the input document into its canonical form.
+// The message digest algorithm is bound to the curve of the key: SHA-256 for P-256 and SHA-384 for P-384.
+// The signature is ECDSA in IEEE P1363 format encoded into "proofValue" as multibase (base58btc).
+package ecdsardfc2019
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Suite implements ecdsa-rdfc-2019 cryptosuite for a single curve.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+	curve           *curve
+}
+
+const (
+	// SignatureType is the type of Data Integrity proof.
+	SignatureType = proof.DataIntegrityProofType
+	// CryptosuiteID is the identifier of the cryptosuite.
+	CryptosuiteID = "ecdsa-rdfc-2019"
+
+	// RDFC-1.0 is the standardized URDNA2015.
+	rdfDataSetAlg       = "URDNA2015"
+	defaultProofPurpose = "assertionMethod"
+)
+
+// New an instance of ecdsa-rdfc-2019 cryptosuite for P-256 keys and SHA-256 digest.
+func New(opts ...suite.Opt) *Suite {
+	return newSuite(p256, opts...)
+}
+
+// NewP384 an instance of ecdsa-rdfc-2019 cryptosuite for P-384 keys and SHA-384 digest.
+func NewP384(opts ...suite.Opt) *Suite {
+	return newSuite(p384, opts...)
+}
+
+func newSuite(c *curve, opts ...suite.Opt) *Suite {
+	s := &Suite{
+		jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg),
+		curve:           c,
+	}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// ecdsa-rdfc-2019 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest using the hash bound to the curve of the suite.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	hasher := s.curve.hash.New()
+	hasher.Write(doc) //nolint:errcheck // hash.Hash never returns an error
+
+	return hasher.Sum(nil)
+}
+
+// Accept will accept only Data Integrity proof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns ecdsa-rdfc-2019 cryptosuite identifier.
+func (s *Suite) Cryptosuite() string {
+	return CryptosuiteID
+}
+
+// ProofPurpose returns the purpose a proof must have to be verified, "assertionMethod" by default.
+func (s *Suite) ProofPurpose() string {
+	if s.ExpectedProofPurpose != "" {
+		return s.ExpectedProofPurpose
+	}
+
+	return defaultProofPurpose
+}
+
+// CheckPublicKey checks that the curve of the public key is the one of the suite,
+// e.g. P-384 key is rejected by the suite using SHA-256 digest.
+func (s *Suite) CheckPublicKey(pubKey *verifier.PublicKey) error {
+	c, _, err := decodePublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	if c != s.curve {
+		return fmt.Errorf("%s public key cannot be used with %s digest of %s suite",
+			c.name, s.curve.hash, s.curve.name)
+	}
+
+	return nil
+}
+
+// Verify will verify a signature checking that the public key matches the curve of the suite.
+func (s *Suite) Verify(pubKey *verifier.PublicKey, doc, signature []byte) error {
+	if err := s.CheckPublicKey(pubKey); err != nil {
+		return err
+	}
+
+	return s.SignatureSuite.Verify(pubKey, doc, signature)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsardfc2019
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc := map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	}
+
+	canonicalDoc, err := New().GetCanonicalDocument(doc)
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n",
+		string(canonicalDoc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	sha256Digest := sha256.Sum256([]byte("test doc"))
+	require.Equal(t, sha256Digest[:], New().GetDigest([]byte("test doc")))
+
+	sha384Digest := sha512.Sum384([]byte("test doc"))
+	require.Equal(t, sha384Digest[:], NewP384().GetDigest([]byte("test doc")))
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("JsonWebSignature2020"))
+	require.Equal(t, "ecdsa-rdfc-2019", ss.Cryptosuite())
+	require.Equal(t, "assertionMethod", ss.ProofPurpose())
+	require.Equal(t, "authentication", New(suite.WithExpectedProofPurpose("authentication")).ProofPurpose())
+}
+
+func TestSignatureSuite_CheckPublicKey(t *testing.T) {
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	p256PubKey := &verifier.PublicKey{
+		Type:  MultikeyType,
+		Value: newMultikey(p256, &p256Key.PublicKey),
+	}
+	p384PubKey := &verifier.PublicKey{
+		Type:  MultikeyType,
+		Value: newMultikey(p384, &p384Key.PublicKey),
+	}
+
+	require.NoError(t, New().CheckPublicKey(p256PubKey))
+	require.NoError(t, NewP384().CheckPublicKey(p384PubKey))
+
+	err = New().CheckPublicKey(p384PubKey)
+	require.EqualError(t, err, "P-384 public key cannot be used with SHA-256 digest of P-256 suite")
+
+	err = NewP384().CheckPublicKey(p256PubKey)
+	require.EqualError(t, err, "P-256 public key cannot be used with SHA-384 digest of P-384 suite")
+
+	t.Run("P-384 key is rejected on verification by SHA-256 suite", func(t *testing.T) {
+		msg := []byte("test message")
+
+		ss := New(suite.WithVerifier(NewPublicKeyVerifier()))
+
+		err := ss.Verify(p384PubKey, msg, sign(t, p384, p384Key, msg))
+		require.EqualError(t, err, "P-384 public key cannot be used with SHA-256 digest of P-256 suite")
+
+		err = NewP384(suite.WithVerifier(NewPublicKeyVerifier())).Verify(p384PubKey, msg, sign(t, p384, p384Key, msg))
+		require.NoError(t, err)
+	})
+}
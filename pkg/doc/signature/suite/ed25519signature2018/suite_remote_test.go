@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ed25519signature2018
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const remoteKeyID = "did:example:hsm#key-1"
+
+//nolint:lll
+const remoteTestCredential = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1"],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "did:example:hsm",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {"type": "BachelorDegree", "name": "Bachelor of Science and Arts"}
+  }
+}`
+
+func TestSignatureSuite_RemoteSignerAndVerifier(t *testing.T) {
+	hsm := newFakeHSM(t)
+
+	server := httptest.NewServer(hsm)
+	defer server.Close()
+
+	remote := &remoteSignerVerifier{url: server.URL, keyID: remoteKeyID}
+
+	signedDoc, err := signer.New(New(suite.WithSigner(remote))).Sign(&signer.Context{
+		SignatureType:      SignatureType,
+		VerificationMethod: remoteKeyID,
+	}, []byte(remoteTestCredential), jsonldtest.WithDocumentLoader(t))
+	require.NoError(t, err)
+
+	resolver := &singleKeyResolver{publicKey: &sigverifier.PublicKey{Type: kms.ED25519, Value: hsm.publicKey}}
+
+	v, err := sigverifier.New(resolver, New(suite.WithVerifier(remote)))
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify(signedDoc, jsonldtest.WithDocumentLoader(t)))
+	require.Equal(t, 1, hsm.signed)
+	require.Equal(t, 1, hsm.verified)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(signedDoc, &doc))
+		doc["issuanceDate"] = "2011-01-01T19:23:24Z"
+
+		tamperedDoc, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		err = v.Verify(tamperedDoc, jsonldtest.WithDocumentLoader(t))
+		require.EqualError(t, err, "remote verify: invalid signature")
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := New(suite.WithSigner(&remoteSignerVerifier{url: server.URL, keyID: "unknown"})).Sign([]byte("doc"))
+		require.EqualError(t, err, "remote sign: key not found")
+	})
+}
+
+// remoteSignerVerifier is an example of suite.Signer and suite.Verifier forwarding the sign and verify calls to an
+// HSM reachable through an HTTP API only.
+type remoteSignerVerifier struct {
+	url   string
+	keyID string
+}
+
+type remoteRequest struct {
+	KeyID     string `json:"keyID,omitempty"`
+	PublicKey []byte `json:"publicKey,omitempty"`
+	Message   []byte `json:"message"`
+	Signature []byte `json:"signature,omitempty"`
+}
+
+type remoteResponse struct {
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func (r *remoteSignerVerifier) Sign(data []byte) ([]byte, error) {
+	resp, err := r.call("/sign", &remoteRequest{KeyID: r.keyID, Message: data})
+	if err != nil {
+		return nil, fmt.Errorf("remote sign: %w", err)
+	}
+
+	return resp.Signature, nil
+}
+
+func (r *remoteSignerVerifier) Verify(pubKey *sigverifier.PublicKey, doc, signature []byte) error {
+	_, err := r.call("/verify", &remoteRequest{PublicKey: pubKey.Value, Message: doc, Signature: signature})
+	if err != nil {
+		return fmt.Errorf("remote verify: %w", err)
+	}
+
+	return nil
+}
+
+func (r *remoteSignerVerifier) call(path string, req *remoteRequest) (*remoteResponse, error) {
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResp, err := http.Post(r.url+path, "application/json", bytes.NewReader(reqBytes)) //nolint:noctx,gosec
+	if err != nil {
+		return nil, err
+	}
+
+	defer httpResp.Body.Close() //nolint:errcheck
+
+	resp := &remoteResponse{}
+
+	if err = json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s", resp.Error)
+	}
+
+	return resp, nil
+}
+
+// fakeHSM holds an Ed25519 key and signs and verifies over HTTP.
+type fakeHSM struct {
+	publicKey  ed25519.PublicKey
+	privateKey ed25519.PrivateKey
+	signed     int
+	verified   int
+}
+
+func newFakeHSM(t *testing.T) *fakeHSM {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return &fakeHSM{publicKey: publicKey, privateKey: privateKey}
+}
+
+func (h *fakeHSM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &remoteRequest{}
+	resp := &remoteResponse{}
+
+	switch err := json.NewDecoder(r.Body).Decode(req); {
+	case err != nil:
+		resp.Error = err.Error()
+	case r.URL.Path == "/sign" && req.KeyID != remoteKeyID:
+		resp.Error = "key not found"
+	case r.URL.Path == "/sign":
+		h.signed++
+		resp.Signature = ed25519.Sign(h.privateKey, req.Message)
+	case r.URL.Path == "/verify" && !ed25519.Verify(req.PublicKey, req.Message, req.Signature):
+		resp.Error = "invalid signature"
+	case r.URL.Path == "/verify":
+		h.verified++
+	default:
+		resp.Error = "not found"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck
+}
+
+type singleKeyResolver struct {
+	publicKey *sigverifier.PublicKey
+}
+
+func (r *singleKeyResolver) Resolve(string) (*sigverifier.PublicKey, error) {
+	return r.publicKey, nil
+}
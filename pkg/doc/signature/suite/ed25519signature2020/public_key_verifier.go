@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ed25519signature2020
+
+import (
+	"crypto/ed25519"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// PublicKeyVerifier verifies Ed25519 signature taking Ed25519 public key bytes as input.
+// The multicodec prefixed public key of Ed25519VerificationKey2020 (or Multikey) verification method
+// is decoded to raw Ed25519 public key before the verification.
+type PublicKeyVerifier struct {
+	verifier *verifier.PublicKeyVerifier
+}
+
+// NewPublicKeyVerifier creates a signature verifier that verifies a Ed25519 signature
+// taking Ed25519 public key bytes or Ed25519 "publicKeyMultibase" as input.
+func NewPublicKeyVerifier() *PublicKeyVerifier {
+	return &PublicKeyVerifier{
+		verifier: verifier.NewPublicKeyVerifier(verifier.NewEd25519SignatureVerifier()),
+	}
+}
+
+// Verify verifies the signature.
+func (v *PublicKeyVerifier) Verify(pubKey *verifier.PublicKey, msg, signature []byte) error {
+	if pubKey.JWK == nil && len(pubKey.Value) != ed25519.PublicKeySize {
+		value, err := eddsardfc2022.DecodeMultikey(pubKey.Value)
+		if err != nil {
+			return err
+		}
+
+		pubKey = &verifier.PublicKey{
+			Type:  pubKey.Type,
+			Value: value,
+		}
+	}
+
+	return v.verifier.Verify(pubKey, msg, signature)
+}
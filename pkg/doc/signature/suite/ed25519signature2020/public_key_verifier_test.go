@@ -0,0 +1,65 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ed25519signature2020
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+	msgSig := ed25519.Sign(privKey, msg)
+
+	multicodecKey := append([]byte{0xed, 0x01}, pubKey...)
+
+	publicKeyMultibase, err := multibase.Encode(multibase.Base58BTC, multicodecKey)
+	require.NoError(t, err)
+
+	v := NewPublicKeyVerifier()
+
+	t.Run("raw public key", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: "Ed25519VerificationKey2018", Value: pubKey}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("decoded publicKeyMultibase", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: multicodecKey}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("publicKeyMultibase", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{
+			Type:  "Ed25519VerificationKey2020",
+			Value: []byte(publicKeyMultibase),
+		}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: multicodecKey},
+			[]byte("other message"), msgSig)
+		require.EqualError(t, err, "ed25519: invalid signature")
+	})
+
+	t.Run("not Ed25519 key", func(t *testing.T) {
+		// secp256k1-pub multicodec header
+		secp256k1Key := append([]byte{0xe7, 0x01}, pubKey...)
+
+		err := v.Verify(&verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: secp256k1Key}, msg, msgSig)
+		require.EqualError(t, err, "multikey is not Ed25519 public key")
+	})
+}
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsardfc2022
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/multiformats/go-multibase"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// MultikeyType is the type of verification method which keeps the public key
+// as multicodec prefixed value encoded into "publicKeyMultibase".
+const MultikeyType = "Multikey"
+
+// multicodec header of Ed25519 public key (varint of 0xed).
+var ed25519MulticodecHeader = []byte{0xed, 0x01} //nolint:gochecknoglobals
+
+// PublicKeyVerifier verifies Ed25519 signature taking Ed25519 public key bytes as input.
+// Multikey public key is decoded to raw Ed25519 public key before the verification.
+type PublicKeyVerifier struct {
+	verifier *verifier.PublicKeyVerifier
+}
+
+// NewPublicKeyVerifier creates a signature verifier that verifies a Ed25519 signature
+// taking Ed25519 public key bytes or Ed25519 Multikey as input.
+func NewPublicKeyVerifier() *PublicKeyVerifier {
+	return &PublicKeyVerifier{
+		verifier: verifier.NewPublicKeyVerifier(verifier.NewEd25519SignatureVerifier()),
+	}
+}
+
+// Verify verifies the signature.
+func (v *PublicKeyVerifier) Verify(pubKey *verifier.PublicKey, msg, signature []byte) error {
+	if pubKey.Type == MultikeyType && pubKey.JWK == nil {
+		value, err := DecodeMultikey(pubKey.Value)
+		if err != nil {
+			return err
+		}
+
+		pubKey = &verifier.PublicKey{
+			Type:  pubKey.Type,
+			Value: value,
+		}
+	}
+
+	return v.verifier.Verify(pubKey, msg, signature)
+}
+
+// DecodeMultikey decodes Ed25519 Multikey into raw Ed25519 public key.
+// The key is accepted either as "publicKeyMultibase" value or as its multibase decoded bytes.
+func DecodeMultikey(key []byte) ([]byte, error) {
+	if len(key) > 0 && key[0] == byte(multibase.Base58BTC) {
+		_, decoded, err := multibase.Decode(string(key))
+		if err != nil {
+			return nil, fmt.Errorf("decode multibase of Multikey: %w", err)
+		}
+
+		key = decoded
+	}
+
+	if !bytes.HasPrefix(key, ed25519MulticodecHeader) {
+		return nil, errors.New("multikey is not Ed25519 public key")
+	}
+
+	return key[len(ed25519MulticodecHeader):], nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsardfc2022
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+func TestPublicKeyVerifier_Verify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+	msgSig := ed25519.Sign(privKey, msg)
+
+	multikey := append([]byte{0xed, 0x01}, pubKey...)
+
+	multikeyMultibase, err := multibase.Encode(multibase.Base58BTC, multikey)
+	require.NoError(t, err)
+
+	v := NewPublicKeyVerifier()
+
+	t.Run("raw public key", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: "Ed25519VerificationKey2020", Value: pubKey}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("decoded Multikey", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: multikey}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("publicKeyMultibase of Multikey", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: []byte(multikeyMultibase)}, msg, msgSig)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: multikey}, []byte("other message"), msgSig)
+		require.EqualError(t, err, "ed25519: invalid signature")
+	})
+
+	t.Run("not Ed25519 Multikey", func(t *testing.T) {
+		// secp256k1-pub multicodec header
+		secp256k1Multikey := append([]byte{0xe7, 0x01}, pubKey...)
+
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: secp256k1Multikey}, msg, msgSig)
+		require.EqualError(t, err, "multikey is not Ed25519 public key")
+	})
+
+	t.Run("invalid multibase", func(t *testing.T) {
+		err := v.Verify(&verifier.PublicKey{Type: MultikeyType, Value: []byte("z0OIl")}, msg, msgSig)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode multibase of Multikey")
+	})
+}
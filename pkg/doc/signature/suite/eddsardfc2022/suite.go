@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eddsardfc2022 implements the eddsa-rdfc-2022 cryptosuite of the Data Integrity
+// specification (https://www.w3.org/TR/vc-di-eddsa/#eddsa-rdfc-2022).
+// Proofs of this cryptosuite have "DataIntegrityProof" type and "eddsa-rdfc-2022" cryptosuite.
+// It uses the RDF Dataset Canonicalization Algorithm (RDFC-1.0, formerly URDNA2015)
+// to transform the input document into its canonical form.
+// It uses SHA-256 [RFC6234] as the message digest algorithm and
+// Ed25519 [ED25519] as the signature algorithm. The signature is encoded into "proofValue" as multibase (base58btc).
+package eddsardfc2022
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+// Suite implements eddsa-rdfc-2022 cryptosuite.
+type Suite struct {
+	suite.SignatureSuite
+	jsonldProcessor *jsonld.Processor
+}
+
+const (
+	// SignatureType is the type of Data Integrity proof.
+	SignatureType = proof.DataIntegrityProofType
+	// CryptosuiteID is the identifier of the cryptosuite.
+	CryptosuiteID = "eddsa-rdfc-2022"
+
+	// RDFC-1.0 is the standardized URDNA2015.
+	rdfDataSetAlg       = "URDNA2015"
+	defaultProofPurpose = "assertionMethod"
+)
+
+// New an instance of eddsa-rdfc-2022 cryptosuite.
+func New(opts ...suite.Opt) *Suite {
+	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
+
+	suite.InitSuiteOptions(&s.SignatureSuite, opts...)
+
+	return s
+}
+
+// GetCanonicalDocument will return normalized/canonical version of the document.
+// eddsa-rdfc-2022 cryptosuite uses RDF Dataset Canonicalization as canonicalization algorithm.
+func (s *Suite) GetCanonicalDocument(doc map[string]interface{}, opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	return s.jsonldProcessor.GetCanonicalDocument(doc, opts...)
+}
+
+// GetDigest returns document digest.
+func (s *Suite) GetDigest(doc []byte) []byte {
+	digest := sha256.Sum256(doc)
+	return digest[:]
+}
+
+// Accept will accept only Data Integrity proof type.
+func (s *Suite) Accept(t string) bool {
+	return t == SignatureType
+}
+
+// Cryptosuite returns eddsa-rdfc-2022 cryptosuite identifier.
+func (s *Suite) Cryptosuite() string {
+	return CryptosuiteID
+}
+
+// ProofPurpose returns the purpose a proof must have to be verified, "assertionMethod" by default.
+func (s *Suite) ProofPurpose() string {
+	if s.ExpectedProofPurpose != "" {
+		return s.ExpectedProofPurpose
+	}
+
+	return defaultProofPurpose
+}
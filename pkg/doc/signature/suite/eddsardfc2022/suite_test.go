@@ -0,0 +1,48 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsardfc2022
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+)
+
+func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
+	doc := map[string]interface{}{
+		"@context": map[string]interface{}{
+			"dc": "http://purl.org/dc/terms/",
+		},
+		"@id":      "http://example.org/fact1",
+		"dc:title": "Hello World!",
+	}
+
+	canonicalDoc, err := New().GetCanonicalDocument(doc)
+	require.NoError(t, err)
+	require.Equal(t, "<http://example.org/fact1> <http://purl.org/dc/terms/title> \"Hello World!\" .\n",
+		string(canonicalDoc))
+}
+
+func TestSignatureSuite_GetDigest(t *testing.T) {
+	digest := New().GetDigest([]byte("test doc"))
+	expected := sha256.Sum256([]byte("test doc"))
+	require.Equal(t, expected[:], digest)
+}
+
+func TestSignatureSuite_Accept(t *testing.T) {
+	ss := New()
+	require.True(t, ss.Accept("DataIntegrityProof"))
+	require.False(t, ss.Accept("Ed25519Signature2018"))
+	require.Equal(t, "eddsa-rdfc-2022", ss.Cryptosuite())
+}
+
+func TestSignatureSuite_ProofPurpose(t *testing.T) {
+	require.Equal(t, "assertionMethod", New().ProofPurpose())
+	require.Equal(t, "authentication", New(suite.WithExpectedProofPurpose("authentication")).ProofPurpose())
+}
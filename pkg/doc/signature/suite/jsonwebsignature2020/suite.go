@@ -9,6 +9,9 @@ SPDX-License-Identifier: Apache-2.0
 // to transform the input document into its canonical form.
 // It uses SHA-256 [RFC6234] as the message digest algorithm.
 // Supported signature algorithms depend on the signer/verifier provided as options to the New().
+// The "alg" of the detached JWS proofs is derived from the public key of the signer (see JWSAlgorithm), the signers
+// which don't expose their public key (e.g. KMS signers) are given the algorithm of the key of the verification method
+// with NewSignerWithJWSAlgorithm.
 // According to the suite specification, signer/verifier must support the following algorithms:
 // kty | crvOrSize | alg
 // OKP | Ed25519   | EdDSA
@@ -21,9 +24,12 @@ package jsonwebsignature2020
 
 import (
 	"crypto/sha256"
+	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 // Suite implements jsonWebSignature2020 signature suite.
@@ -38,6 +44,63 @@ const (
 	rdfDataSetAlg = "URDNA2015"
 )
 
+// jwsAlgorithms maps the key type and curve (or "" for RSA) of a JWK to its JWS algorithm.
+var jwsAlgorithms = map[string]map[string]string{ // nolint:gochecknoglobals
+	"OKP": {"Ed25519": "EdDSA"},
+	"EC":  {"P-256": "ES256", "P-384": "ES384", "P-521": "ES512", "secp256k1": "ES256K"},
+	"RSA": {"": "PS256"},
+}
+
+// verificationMethodAlgorithms maps the verification method types of the keys without a JWK to their JWS algorithm.
+var verificationMethodAlgorithms = map[string]string{ // nolint:gochecknoglobals
+	"Ed25519VerificationKey2018":        "EdDSA",
+	"Ed25519VerificationKey2020":        "EdDSA",
+	"Secp256k1VerificationKey2018":      "ES256K",
+	"EcdsaSecp256k1VerificationKey2019": "ES256K",
+	"RsaVerificationKey2018":            "PS256",
+}
+
+// publicKeySigner is implemented by the signers which expose their public key.
+type publicKeySigner interface {
+	PublicKey() interface{}
+}
+
+// jwsAlgorithmSigner is implemented by the signers which know the JWS algorithm of their key.
+type jwsAlgorithmSigner interface {
+	JWSAlgorithm() string
+}
+
+// signerWithJWSAlgorithm is a signer knowing the JWS algorithm of the verification method it signs for.
+type signerWithJWSAlgorithm struct {
+	suite.Signer
+	alg string
+}
+
+func (s *signerWithJWSAlgorithm) JWSAlgorithm() string {
+	return s.alg
+}
+
+// NewSignerWithJWSAlgorithm returns the signer of the proofs with the JWS algorithm alg, for the signers which do not
+// expose their public key (e.g. KMS signers). See VerificationMethodJWSAlgorithm.
+func NewSignerWithJWSAlgorithm(s suite.Signer, alg string) suite.Signer {
+	return &signerWithJWSAlgorithm{Signer: s, alg: alg}
+}
+
+// VerificationMethodJWSAlgorithm returns the JWS algorithm of the JsonWebSignature2020 signatures made with the key of
+// a verification method, derived from its JWK or else from its type.
+func VerificationMethodJWSAlgorithm(pubKey *verifier.PublicKey) (string, error) {
+	if pubKey.JWK != nil {
+		return JWSAlgorithm(pubKey.JWK)
+	}
+
+	alg, ok := verificationMethodAlgorithms[pubKey.Type]
+	if !ok {
+		return "", fmt.Errorf("unsupported verification method type %s", pubKey.Type)
+	}
+
+	return alg, nil
+}
+
 // New an instance of Linked Data Signatures for JWS suite.
 func New(opts ...suite.Opt) *Suite {
 	s := &Suite{jsonldProcessor: jsonld.NewProcessor(rdfDataSetAlg)}
@@ -63,3 +126,39 @@ func (s *Suite) GetDigest(doc []byte) []byte {
 func (s *Suite) Accept(t string) bool {
 	return t == signatureType
 }
+
+// JWSAlgorithm returns the JWS algorithm of the detached JWS proofs created by the suite, derived from the public key
+// of the signer. It is empty if the signer does not expose its public key nor the algorithm.
+func (s *Suite) JWSAlgorithm() (string, error) {
+	if algSigner, ok := s.Signer.(jwsAlgorithmSigner); ok {
+		return algSigner.JWSAlgorithm(), nil
+	}
+
+	signer, ok := s.Signer.(publicKeySigner)
+	if !ok {
+		return "", nil
+	}
+
+	jwk, ok := signer.PublicKey().(*jose.JWK)
+	if !ok {
+		var err error
+
+		jwk, err = jose.JWKFromKey(signer.PublicKey())
+		if err != nil {
+			return "", fmt.Errorf("public key of the signer: %w", err)
+		}
+	}
+
+	return JWSAlgorithm(jwk)
+}
+
+// JWSAlgorithm returns the JWS algorithm of the JsonWebSignature2020 signatures made with the key (e.g. "ES384" for
+// a P-384 key).
+func JWSAlgorithm(jwk *jose.JWK) (string, error) {
+	alg, ok := jwsAlgorithms[jwk.Kty][jwk.Crv]
+	if !ok {
+		return "", fmt.Errorf("unsupported JWK: kty=%s crv=%s", jwk.Kty, jwk.Crv)
+	}
+
+	return alg, nil
+}
@@ -7,11 +7,33 @@ SPDX-License-Identifier: Apache-2.0
 package jsonwebsignature2020
 
 import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
+	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+//go:embed testdata/credential.jsonld
+var credential []byte //nolint:gochecknoglobals
+
+// testVectors are credentials signed with a key of each supported curve along with the public key, the Ed25519 key
+// is the one of RFC 8037 (A.1) and the P-256 key the one of RFC 7515 (A.3).
+//
+//go:embed testdata/test_vectors.json
+var testVectors []byte //nolint:gochecknoglobals
+
 func TestSignatureSuite_GetCanonicalDocument(t *testing.T) {
 	doc, err := New().GetCanonicalDocument(getDefaultDoc())
 	require.NoError(t, err)
@@ -33,6 +55,211 @@ func TestSignatureSuite_Accept(t *testing.T) {
 	require.False(t, accepted)
 }
 
+func TestJWSAlgorithm(t *testing.T) {
+	tests := []struct {
+		keyType kmsapi.KeyType
+		alg     string
+	}{
+		{kmsapi.ED25519Type, "EdDSA"},
+		{kmsapi.ECDSAP256TypeIEEEP1363, "ES256"},
+		{kmsapi.ECDSAP384TypeIEEEP1363, "ES384"},
+		{kmsapi.ECDSAP521TypeIEEEP1363, "ES512"},
+		{kmsapi.ECDSASecp256k1TypeIEEEP1363, "ES256K"},
+		{kmsapi.RSAPS256Type, "PS256"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.keyType), func(t *testing.T) {
+			s, err := signature.NewSigner(tc.keyType)
+			require.NoError(t, err)
+
+			alg, err := New(suite.WithSigner(s)).JWSAlgorithm()
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, alg)
+
+			jwk, err := jose.JWKFromKey(s.PublicKey())
+			require.NoError(t, err)
+
+			alg, err = JWSAlgorithm(jwk)
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, alg)
+		})
+	}
+
+	t.Run("signer without public key", func(t *testing.T) {
+		alg, err := New(suite.WithSigner(&struct{ suite.Signer }{})).JWSAlgorithm()
+		require.NoError(t, err)
+		require.Empty(t, alg)
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		jwk, err := jose.JWKFromX25519Key(make([]byte, 32))
+		require.NoError(t, err)
+
+		_, err = JWSAlgorithm(jwk)
+		require.EqualError(t, err, "unsupported JWK: kty=OKP crv=X25519")
+
+		_, err = New(suite.WithSigner(&publicKeySignerStub{pubKey: jwk})).JWSAlgorithm()
+		require.EqualError(t, err, "unsupported JWK: kty=OKP crv=X25519")
+
+		_, err = New(suite.WithSigner(&publicKeySignerStub{pubKey: "not a key"})).JWSAlgorithm()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key of the signer")
+	})
+}
+
+func TestVerificationMethodJWSAlgorithm(t *testing.T) {
+	s, err := signature.NewSigner(kmsapi.ECDSAP384TypeIEEEP1363)
+	require.NoError(t, err)
+
+	jwk, err := jose.JWKFromKey(s.PublicKey())
+	require.NoError(t, err)
+
+	alg, err := VerificationMethodJWSAlgorithm(&sigverifier.PublicKey{Type: jwkType, JWK: jwk})
+	require.NoError(t, err)
+	require.Equal(t, "ES384", alg)
+
+	alg, err = VerificationMethodJWSAlgorithm(&sigverifier.PublicKey{Type: "Ed25519VerificationKey2018"})
+	require.NoError(t, err)
+	require.Equal(t, "EdDSA", alg)
+
+	_, err = VerificationMethodJWSAlgorithm(&sigverifier.PublicKey{Type: "X25519KeyAgreementKey2019"})
+	require.EqualError(t, err, "unsupported verification method type X25519KeyAgreementKey2019")
+
+	t.Run("signer with the JWS algorithm of the verification method", func(t *testing.T) {
+		signedDoc, err := signer.New(New(suite.WithSigner(NewSignerWithJWSAlgorithm(&struct{ suite.Signer }{s}, alg)))).
+			Sign(&signer.Context{
+				SignatureType:           signatureType,
+				SignatureRepresentation: proof.SignatureJWS,
+				VerificationMethod:      "did:example:123#key-1",
+			}, credential, jsonldtest.WithDocumentLoader(t))
+		require.NoError(t, err)
+
+		// the P-384 key of the signer is hidden, the alg of the verification method is used
+		require.Equal(t, "EdDSA", jwsHeader(t, signedDoc)["alg"])
+	})
+}
+
+func TestSignAndVerify(t *testing.T) {
+	tests := []struct {
+		keyType kmsapi.KeyType
+		alg     string
+	}{
+		{kmsapi.ED25519Type, "EdDSA"},
+		{kmsapi.ECDSAP256TypeIEEEP1363, "ES256"},
+		{kmsapi.ECDSAP384TypeIEEEP1363, "ES384"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.keyType), func(t *testing.T) {
+			s, err := signature.NewSigner(tc.keyType)
+			require.NoError(t, err)
+
+			signedDoc, err := signer.New(New(suite.WithSigner(s))).Sign(&signer.Context{
+				SignatureType:           signatureType,
+				SignatureRepresentation: proof.SignatureJWS,
+				VerificationMethod:      "did:example:123#key-1",
+			}, credential, jsonldtest.WithDocumentLoader(t))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.alg, jwsHeader(t, signedDoc)["alg"])
+
+			jwk, err := jose.JWKFromKey(s.PublicKey())
+			require.NoError(t, err)
+
+			require.NoError(t, verify(t, jwk, signedDoc))
+		})
+	}
+}
+
+func TestVerify_TestVectors(t *testing.T) {
+	var vectors map[string]struct {
+		PublicKeyJwk json.RawMessage        `json:"publicKeyJwk"`
+		Document     map[string]interface{} `json:"document"`
+	}
+
+	require.NoError(t, json.Unmarshal(testVectors, &vectors))
+
+	algs := map[string]string{"Ed25519": "EdDSA", "P-256": "ES256", "P-384": "ES384"}
+
+	for crv, alg := range algs {
+		vector, ok := vectors[crv]
+		require.True(t, ok, crv)
+
+		t.Run(crv, func(t *testing.T) {
+			jwk := &jose.JWK{}
+			require.NoError(t, jwk.UnmarshalJSON(vector.PublicKeyJwk))
+			require.Equal(t, crv, jwk.Crv)
+
+			signedDoc, err := json.Marshal(vector.Document)
+			require.NoError(t, err)
+
+			require.Equal(t, alg, jwsHeader(t, signedDoc)["alg"])
+			require.NoError(t, verify(t, jwk, signedDoc))
+
+			tampered := strings.Replace(string(signedDoc), "did:example:456", "did:example:789", 1)
+			require.Error(t, verify(t, jwk, []byte(tampered)))
+
+			for otherCrv := range algs {
+				if otherCrv == crv {
+					continue
+				}
+
+				otherJWK := &jose.JWK{}
+				require.NoError(t, otherJWK.UnmarshalJSON(vectors[otherCrv].PublicKeyJwk))
+				require.Error(t, verify(t, otherJWK, signedDoc))
+			}
+		})
+	}
+}
+
+func verify(t *testing.T, jwk *jose.JWK, signedDoc []byte) error {
+	t.Helper()
+
+	v, err := sigverifier.New(&jwkResolver{jwk: jwk}, New(suite.WithVerifier(NewPublicKeyVerifier())))
+	require.NoError(t, err)
+
+	return v.Verify(signedDoc, jsonldtest.WithDocumentLoader(t))
+}
+
+func jwsHeader(t *testing.T, signedDoc []byte) map[string]interface{} {
+	t.Helper()
+
+	var doc struct {
+		Proof []struct {
+			JWS string `json:"jws"`
+		} `json:"proof"`
+	}
+
+	require.NoError(t, json.Unmarshal(signedDoc, &doc))
+	require.Len(t, doc.Proof, 1)
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(strings.Split(doc.Proof[0].JWS, ".")[0])
+	require.NoError(t, err)
+
+	var header map[string]interface{}
+	require.NoError(t, json.Unmarshal(headerBytes, &header))
+
+	return header
+}
+
+type jwkResolver struct {
+	jwk *jose.JWK
+}
+
+func (r *jwkResolver) Resolve(string) (*sigverifier.PublicKey, error) {
+	return &sigverifier.PublicKey{Type: jwkType, JWK: r.jwk}, nil
+}
+
+type publicKeySignerStub struct {
+	suite.Signer
+	pubKey interface{}
+}
+
+func (s *publicKeySignerStub) PublicKey() interface{} {
+	return s.pubKey
+}
+
 func getDefaultDoc() map[string]interface{} {
 	// this JSON-LD document was taken from http://json-ld.org/test-suite/tests/toRdf-0028-in.jsonld
 	doc := map[string]interface{}{
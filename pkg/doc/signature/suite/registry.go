@@ -0,0 +1,97 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+// Registry holds signature suites by the proof type (and the cryptosuite of Data Integrity proofs) they verify.
+// Suites are usually registered at framework init and looked up by the document verifier,
+// see verifier.NewWithRegistry.
+type Registry struct {
+	mu     sync.RWMutex
+	suites map[registryKey][]sigverifier.SignatureSuite
+}
+
+type registryKey struct {
+	proofType   string
+	cryptosuite string
+}
+
+// dataIntegritySuite is implemented by Data Integrity signature suites distinguished by the cryptosuite.
+type dataIntegritySuite interface {
+	Cryptosuite() string
+}
+
+// publicKeySuite is implemented by signature suites which support only certain public keys.
+type publicKeySuite interface {
+	CheckPublicKey(pubKey *sigverifier.PublicKey) error
+}
+
+// NewRegistry returns an empty signature suite registry.
+func NewRegistry() *Registry {
+	return &Registry{suites: make(map[registryKey][]sigverifier.SignatureSuite)}
+}
+
+// Register registers the signature suite for proofs of the given type and cryptosuite (empty if the proof is not
+// a Data Integrity proof).
+// Several suites can be registered for the same proof type and cryptosuite only if all of them support certain
+// public keys (e.g. the curves of ECDSA), any other registration for an already registered proof type and
+// cryptosuite conflicts and fails.
+func (r *Registry) Register(proofType, cryptosuite string, s sigverifier.SignatureSuite) error {
+	if s == nil {
+		return errors.New("signature suite is not defined")
+	}
+
+	if !s.Accept(proofType) {
+		return fmt.Errorf("signature suite does not accept proof type %s", proofType)
+	}
+
+	diSuite, ok := s.(dataIntegritySuite)
+	if ok && diSuite.Cryptosuite() != cryptosuite {
+		return fmt.Errorf("signature suite cryptosuite %s does not match %s", diSuite.Cryptosuite(), cryptosuite)
+	}
+
+	if !ok && cryptosuite != "" {
+		return fmt.Errorf("signature suite does not support cryptosuite %s", cryptosuite)
+	}
+
+	key := registryKey{proofType: proofType, cryptosuite: cryptosuite}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, registered := range r.suites[key] {
+		if !supportsCertainKeys(registered) || !supportsCertainKeys(s) {
+			return fmt.Errorf("conflicting signature suite registration for proof type %s and cryptosuite %q",
+				proofType, cryptosuite)
+		}
+	}
+
+	r.suites[key] = append(r.suites[key], s)
+
+	return nil
+}
+
+// Suites returns the signature suites registered for proofs of the given type and cryptosuite.
+func (r *Registry) Suites(proofType, cryptosuite string) []sigverifier.SignatureSuite {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]sigverifier.SignatureSuite(nil), r.suites[registryKey{proofType, cryptosuite}]...)
+}
+
+func supportsCertainKeys(s sigverifier.SignatureSuite) bool {
+	_, ok := s.(publicKeySuite)
+
+	return ok
+}
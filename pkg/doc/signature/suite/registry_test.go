@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package suite
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+)
+
+const fakeSignedDoc = `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "http://example.edu/credentials/1872",
+  "proof": {
+    "type": "FakeSignature2023",
+    "created": "2023-01-01T00:00:00Z",
+    "proofPurpose": "assertionMethod",
+    "verificationMethod": "did:example:issuer#key-1",
+    "proofValue": "ZmFrZSBzaWduYXR1cmU"
+  }
+}`
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		r := NewRegistry()
+
+		fake := &fakeSuite{proofType: "FakeSignature2023"}
+		require.NoError(t, r.Register("FakeSignature2023", "", fake))
+		require.Equal(t, []sigverifier.SignatureSuite{fake}, r.Suites("FakeSignature2023", ""))
+		require.Empty(t, r.Suites("FakeSignature2023", "fake-2023"))
+		require.Empty(t, r.Suites("Ed25519Signature2018", ""))
+	})
+
+	t.Run("success - several suites supporting certain public keys", func(t *testing.T) {
+		r := NewRegistry()
+
+		p256 := &fakeKeySuite{fakeSuite{proofType: "DataIntegrityProof", cryptosuite: "fake-2023"}}
+		p384 := &fakeKeySuite{fakeSuite{proofType: "DataIntegrityProof", cryptosuite: "fake-2023"}}
+
+		require.NoError(t, r.Register("DataIntegrityProof", "fake-2023", p256))
+		require.NoError(t, r.Register("DataIntegrityProof", "fake-2023", p384))
+		require.Len(t, r.Suites("DataIntegrityProof", "fake-2023"), 2)
+	})
+
+	t.Run("error - conflicting registration", func(t *testing.T) {
+		r := NewRegistry()
+
+		require.NoError(t, r.Register("FakeSignature2023", "", &fakeSuite{proofType: "FakeSignature2023"}))
+
+		err := r.Register("FakeSignature2023", "", &fakeSuite{proofType: "FakeSignature2023"})
+		require.EqualError(t, err, `conflicting signature suite registration for proof type FakeSignature2023 `+
+			`and cryptosuite ""`)
+
+		err = r.Register("FakeSignature2023", "", &fakeKeySuite{fakeSuite{proofType: "FakeSignature2023"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "conflicting signature suite registration")
+	})
+
+	t.Run("error - invalid suite", func(t *testing.T) {
+		r := NewRegistry()
+
+		require.EqualError(t, r.Register("FakeSignature2023", "", nil), "signature suite is not defined")
+
+		err := r.Register("OtherSignature2023", "", &fakeSuite{proofType: "FakeSignature2023"})
+		require.EqualError(t, err, "signature suite does not accept proof type OtherSignature2023")
+
+		err = r.Register("FakeSignature2023", "fake-2023", &fakeSuite{proofType: "FakeSignature2023"})
+		require.EqualError(t, err, "signature suite does not support cryptosuite fake-2023")
+
+		err = r.Register("DataIntegrityProof", "other-2023",
+			&fakeKeySuite{fakeSuite{proofType: "DataIntegrityProof", cryptosuite: "fake-2023"}})
+		require.EqualError(t, err, "signature suite cryptosuite fake-2023 does not match other-2023")
+	})
+}
+
+func TestRegistry_VerifierDispatch(t *testing.T) {
+	r := NewRegistry()
+
+	fake := &fakeSuite{proofType: "FakeSignature2023"}
+	require.NoError(t, r.Register("FakeSignature2023", "", fake))
+
+	v, err := sigverifier.NewWithRegistry(&fakeKeyResolver{}, r)
+	require.NoError(t, err)
+
+	require.NoError(t, v.Verify([]byte(fakeSignedDoc)))
+	require.Equal(t, 1, fake.verified)
+
+	fake.verifyErr = errors.New("invalid signature")
+	require.EqualError(t, v.Verify([]byte(fakeSignedDoc)), "invalid signature")
+
+	v, err = sigverifier.NewWithRegistry(&fakeKeyResolver{}, NewRegistry())
+	require.NoError(t, err)
+	require.EqualError(t, v.Verify([]byte(fakeSignedDoc)), "signature type FakeSignature2023 not supported")
+
+	_, err = sigverifier.NewWithRegistry(&fakeKeyResolver{}, nil)
+	require.EqualError(t, err, "signature suite registry must be provided")
+}
+
+type fakeKeyResolver struct{}
+
+func (r *fakeKeyResolver) Resolve(string) (*sigverifier.PublicKey, error) {
+	return &sigverifier.PublicKey{Type: "FakeVerificationKey2023", Value: []byte("public key")}, nil
+}
+
+type fakeSuite struct {
+	proofType   string
+	cryptosuite string
+	verifyErr   error
+	verified    int
+}
+
+func (s *fakeSuite) GetCanonicalDocument(map[string]interface{}, ...jsonld.ProcessorOpts) ([]byte, error) {
+	return []byte("canonical document"), nil
+}
+
+func (s *fakeSuite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+func (s *fakeSuite) Verify(*sigverifier.PublicKey, []byte, []byte) error {
+	if s.verifyErr != nil {
+		return s.verifyErr
+	}
+
+	s.verified++
+
+	return nil
+}
+
+func (s *fakeSuite) Accept(t string) bool {
+	return t == s.proofType
+}
+
+func (s *fakeSuite) CompactProof() bool {
+	return false
+}
+
+// fakeKeySuite is a Data Integrity suite supporting certain public keys.
+type fakeKeySuite struct {
+	fakeSuite
+}
+
+func (s *fakeKeySuite) Cryptosuite() string {
+	return s.cryptosuite
+}
+
+func (s *fakeKeySuite) ProofPurpose() string {
+	return "assertionMethod"
+}
+
+func (s *fakeKeySuite) CheckPublicKey(*sigverifier.PublicKey) error {
+	return nil
+}
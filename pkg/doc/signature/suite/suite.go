@@ -14,17 +14,23 @@ import (
 
 // SignatureSuite defines general signature suite structure.
 type SignatureSuite struct {
-	Signer         signer
-	Verifier       verifier
+	Signer         Signer
+	Verifier       Verifier
 	CompactedProof bool
+	// ExpectedProofPurpose is the proof purpose checked by the suites which verify it (e.g. Data Integrity suites).
+	ExpectedProofPurpose string
 }
 
-type signer interface {
+// Signer is the SPI the signature suites sign with. CryptoSigner signs with a key handle of the Crypto service
+// (tinkcrypto by default), other implementations may forward the signing to where the key is held (e.g. an HSM).
+type Signer interface {
 	// Sign will sign document and return signature
 	Sign(data []byte) ([]byte, error)
 }
 
-type verifier interface {
+// Verifier is the SPI the signature suites verify signatures with. CryptoVerifier verifies with the Crypto service
+// (tinkcrypto by default), other implementations may forward the verification (e.g. to an HSM).
+type Verifier interface {
 	// Verify will verify a signature.
 	Verify(pubKeyValue *sigverifier.PublicKey, doc, signature []byte) error
 }
@@ -33,14 +39,14 @@ type verifier interface {
 type Opt func(opts *SignatureSuite)
 
 // WithSigner defines a signer for the Signature Suite.
-func WithSigner(s signer) Opt {
+func WithSigner(s Signer) Opt {
 	return func(opts *SignatureSuite) {
 		opts.Signer = s
 	}
 }
 
 // WithVerifier defines a verifier for the Signature Suite.
-func WithVerifier(v verifier) Opt {
+func WithVerifier(v Verifier) Opt {
 	return func(opts *SignatureSuite) {
 		opts.Verifier = v
 	}
@@ -53,6 +59,14 @@ func WithCompactProof() Opt {
 	}
 }
 
+// WithExpectedProofPurpose defines the proof purpose a proof must have in order to be verified.
+// It is used by the suites which check proof purpose (e.g. Data Integrity suites), "assertionMethod" by default.
+func WithExpectedProofPurpose(purpose string) Opt {
+	return func(opts *SignatureSuite) {
+		opts.ExpectedProofPurpose = purpose
+	}
+}
+
 // InitSuiteOptions initializes signature suite with options.
 func InitSuiteOptions(suite *SignatureSuite, opts ...Opt) *SignatureSuite {
 	for _, opt := range opts {
@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifier
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"reflect"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// canonicalDocCache caches the canonical documents computed during a single verification, so the document
+// secured by the proofs of a proof set is canonicalized (URDNA2015) once and not for every proof.
+// It must not outlive the verification as the JSON-LD contexts of the document could change in between.
+type canonicalDocCache struct {
+	docs map[canonicalDocKey][]byte
+}
+
+// canonicalDocKey identifies a canonical document by the type of the suite which computed it
+// and the hash of the JSON document.
+type canonicalDocKey struct {
+	suiteType reflect.Type
+	docHash   [sha256.Size]byte
+}
+
+func newCanonicalDocCache() *canonicalDocCache {
+	return &canonicalDocCache{docs: make(map[canonicalDocKey][]byte)}
+}
+
+// wrap returns the suite which canonicalizes documents using the cache.
+func (c *canonicalDocCache) wrap(suite SignatureSuite) SignatureSuite {
+	return &cachingSuite{SignatureSuite: suite, cache: c}
+}
+
+type cachingSuite struct {
+	SignatureSuite
+	cache *canonicalDocCache
+}
+
+// GetCanonicalDocument returns the cached canonical document or computes it using the wrapped suite.
+func (s *cachingSuite) GetCanonicalDocument(doc map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return s.SignatureSuite.GetCanonicalDocument(doc, opts...)
+	}
+
+	key := canonicalDocKey{
+		suiteType: reflect.TypeOf(s.SignatureSuite),
+		docHash:   sha256.Sum256(docBytes),
+	}
+
+	if canonicalDoc, ok := s.cache.docs[key]; ok {
+		return append([]byte(nil), canonicalDoc...), nil
+	}
+
+	canonicalDoc, err := s.SignatureSuite.GetCanonicalDocument(doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.docs[key] = append([]byte(nil), canonicalDoc...)
+
+	return canonicalDoc, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const proofSetSize = 5
+
+func TestCanonicalDocCache(t *testing.T) {
+	suite := &canonicalizingSuite{}
+
+	v, err := New(&testKeyResolver{publicKey: &PublicKey{Type: kms.ED25519}}, suite)
+	require.NoError(t, err)
+
+	docBytes := newProofSetDoc(t, proofSetSize)
+
+	require.NoError(t, v.Verify(docBytes))
+	// the proof options of every proof and the document once
+	require.Equal(t, proofSetSize+1, suite.canonicalized)
+
+	// the cache is scoped to a single verification
+	require.NoError(t, v.Verify(docBytes))
+	require.Equal(t, 2*(proofSetSize+1), suite.canonicalized)
+}
+
+func BenchmarkVerifyProofSet(b *testing.B) {
+	v, err := New(&testKeyResolver{publicKey: &PublicKey{Type: kms.ED25519}}, &canonicalizingSuite{})
+	require.NoError(b, err)
+
+	proofSetDoc := newProofSetDoc(b, proofSetSize)
+
+	var singleProofDocs [][]byte
+
+	for i := 0; i < proofSetSize; i++ {
+		singleProofDocs = append(singleProofDocs, newProofSetDoc(b, 1))
+	}
+
+	b.Run("proof set verified at once", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, v.Verify(proofSetDoc))
+		}
+	})
+
+	b.Run("proofs verified one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, doc := range singleProofDocs {
+				require.NoError(b, v.Verify(doc))
+			}
+		}
+	})
+}
+
+func newProofSetDoc(t testing.TB, proofsCount int) []byte {
+	t.Helper()
+
+	doc := map[string]interface{}{
+		"@context": map[string]interface{}{
+			"@vocab": "https://example.com/vocab#",
+		},
+		"id":           "https://example.com/credentials/1872",
+		"issuer":       "did:example:issuer",
+		"issuanceDate": "2010-01-01T19:23:24Z",
+	}
+
+	var claims []interface{}
+
+	for i := 0; i < 50; i++ {
+		claims = append(claims, map[string]interface{}{
+			"name":  fmt.Sprintf("claim %d", i),
+			"value": fmt.Sprintf("value %d", i),
+		})
+	}
+
+	doc["credentialSubject"] = map[string]interface{}{
+		"id":     "did:example:subject",
+		"claims": claims,
+	}
+
+	var proofs []interface{}
+
+	for i := 0; i < proofsCount; i++ {
+		proofs = append(proofs, map[string]interface{}{
+			"type":               "Ed25519Signature2018",
+			"created":            fmt.Sprintf("2011-09-23T20:21:%02dZ", i),
+			"verificationMethod": "did:example:issuer#key1",
+			"proofValue":         "ABC",
+		})
+	}
+
+	doc["proof"] = proofs
+
+	docBytes, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	return docBytes
+}
+
+// canonicalizingSuite canonicalizes documents with URDNA2015 and accepts any signature.
+type canonicalizingSuite struct {
+	canonicalized int
+}
+
+func (s *canonicalizingSuite) GetCanonicalDocument(doc map[string]interface{},
+	opts ...jsonld.ProcessorOpts) ([]byte, error) {
+	s.canonicalized++
+
+	return jsonld.Default().GetCanonicalDocument(doc, opts...)
+}
+
+func (s *canonicalizingSuite) GetDigest(doc []byte) []byte {
+	return doc
+}
+
+func (s *canonicalizingSuite) Verify(*PublicKey, []byte, []byte) error {
+	return nil
+}
+
+func (s *canonicalizingSuite) Accept(string) bool {
+	return true
+}
+
+func (s *canonicalizingSuite) CompactProof() bool {
+	return false
+}
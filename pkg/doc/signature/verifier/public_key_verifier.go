@@ -438,29 +438,36 @@ func splitMessageIntoLines(msg string, transformBlankNodes bool) [][]byte {
 }
 
 func transformFromBlankNode(row string) string {
-	// transform from "urn:bnid:_:c14n0" to "_:c14n0"
+	// transform from "urn:bnid:_:c14n0" to "_:c14n0" (all blank nodes of the statement)
 	const (
 		emptyNodePlaceholder = "<urn:bnid:_:c14n"
 		emptyNodePrefixLen   = 10
 	)
 
-	prefixIndex := strings.Index(row, emptyNodePlaceholder)
-	if prefixIndex < 0 {
-		return row
-	}
+	var sb strings.Builder
 
-	sepIndex := strings.Index(row[prefixIndex:], ">")
-	if sepIndex < 0 {
-		return row
-	}
+	for {
+		prefixIndex := strings.Index(row, emptyNodePlaceholder)
+		if prefixIndex < 0 {
+			break
+		}
+
+		sepIndex := strings.Index(row[prefixIndex:], ">")
+		if sepIndex < 0 {
+			break
+		}
+
+		sepIndex += prefixIndex
 
-	sepIndex += prefixIndex
+		sb.WriteString(row[:prefixIndex])
+		sb.WriteString(row[prefixIndex+emptyNodePrefixLen : sepIndex])
+
+		row = row[sepIndex+1:]
+	}
 
-	prefix := row[:prefixIndex]
-	blankNode := row[prefixIndex+emptyNodePrefixLen : sepIndex]
-	suffix := row[sepIndex+1:]
+	sb.WriteString(row)
 
-	return fmt.Sprintf("%s%s%s", prefix, blankNode, suffix)
+	return sb.String()
 }
 
 type ellipticCurve struct {
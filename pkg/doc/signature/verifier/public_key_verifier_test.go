@@ -386,6 +386,8 @@ func TestTransformFromBlankNodes(t *testing.T) {
 		fe = "abcd _:c14n efgh"
 		g  = ""
 		ge = ""
+		h  = "<urn:bnid:_:c14n1> <https://example.org/examples#degree> <urn:bnid:_:c14n0> ."
+		he = "_:c14n1 <https://example.org/examples#degree> _:c14n0 ."
 	)
 
 	at := transformFromBlankNode(a)
@@ -408,6 +410,9 @@ func TestTransformFromBlankNodes(t *testing.T) {
 
 	gt := transformFromBlankNode(g)
 	require.Equal(t, ge, gt)
+
+	ht := transformFromBlankNode(h)
+	require.Equal(t, he, ht)
 }
 
 //nolint:lll,goconst
@@ -9,12 +9,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 )
 
+// defaultProofPurpose is the purpose of the proofs not defining it.
+const defaultProofPurpose = "assertionMethod"
+
 // SignatureSuite encapsulates signature suite methods required for signature verification.
 type SignatureSuite interface {
 
@@ -34,11 +39,33 @@ type SignatureSuite interface {
 	CompactProof() bool
 }
 
+// dataIntegritySuite is implemented by Data Integrity signature suites which share
+// "DataIntegrityProof" proof type and are distinguished by the cryptosuite.
+type dataIntegritySuite interface {
+	// Cryptosuite returns the cryptosuite identifier (e.g. "eddsa-rdfc-2022")
+	Cryptosuite() string
+
+	// ProofPurpose returns the purpose a proof must have to be verified by the suite
+	ProofPurpose() string
+}
+
+// publicKeySuite is implemented by signature suites which support only certain public keys
+// (e.g. ECDSA Data Integrity suite which digest algorithm is bound to the curve of the key).
+type publicKeySuite interface {
+	// CheckPublicKey returns an error if the public key cannot be used with the suite
+	CheckPublicKey(pubKey *PublicKey) error
+}
+
 // PublicKey contains a result of public key resolution.
 type PublicKey struct {
 	Type  string
 	Value []byte
 	JWK   *jose.JWK
+
+	// ProofPurposes are the proof purposes the key is authorized for, i.e. the verification relationships
+	// (e.g. assertionMethod) the key is listed under in the DID document of its controller.
+	// If not nil, the purpose of the proof verified with the key must be one of them.
+	ProofPurposes []string
 }
 
 // keyResolver encapsulates key resolution.
@@ -48,9 +75,16 @@ type keyResolver interface {
 	Resolve(id string) (*PublicKey, error)
 }
 
+// SuiteRegistry looks up signature suites by the proof type and cryptosuite (of Data Integrity proofs) they verify.
+type SuiteRegistry interface {
+	// Suites returns the signature suites registered for proofs of the given type and cryptosuite
+	Suites(proofType, cryptosuite string) []SignatureSuite
+}
+
 // DocumentVerifier implements JSON LD document proof verification.
 type DocumentVerifier struct {
 	signatureSuites []SignatureSuite
+	suiteRegistry   SuiteRegistry
 	pkResolver      keyResolver
 }
 
@@ -66,8 +100,97 @@ func New(resolver keyResolver, suites ...SignatureSuite) (*DocumentVerifier, err
 	}, nil
 }
 
-// Verify will verify document proofs.
+// NewWithRegistry returns new instance of document verifier which looks up the signature suites
+// in the registry by the type and cryptosuite of the verified proofs.
+func NewWithRegistry(resolver keyResolver, registry SuiteRegistry) (*DocumentVerifier, error) {
+	if registry == nil {
+		return nil, errors.New("signature suite registry must be provided")
+	}
+
+	return &DocumentVerifier{
+		suiteRegistry: registry,
+		pkResolver:    resolver,
+	}, nil
+}
+
+// Verify will verify document proofs. All proofs of the document must be valid.
 func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...jsonld.ProcessorOpts) error {
+	return dv.VerifyProofSet(jsonLdDoc, 0, opts...)
+}
+
+// VerifyProofSet will verify document proofs (proof set and/or proof chain) requiring at least minValid
+// of them to be valid. If minValid is not positive, all proofs must be valid.
+// A proof of a proof chain is valid only if the previous proofs it refers to are valid as well.
+func (dv *DocumentVerifier) VerifyProofSet(jsonLdDoc []byte, minValid int, opts ...jsonld.ProcessorOpts) error {
+	return dv.VerifyWithOpts(jsonLdDoc, minValid, WithProcessorOpts(opts...))
+}
+
+// VerifyOpt is the option of the document proofs verification.
+type VerifyOpt func(opts *verifyOpts)
+
+type verifyOpts struct {
+	challenge     string
+	domain        string
+	clock         clock.Clock
+	processorOpts []jsonld.ProcessorOpts
+	proofResults  *[]ProofResult
+}
+
+// ProofResult is the verification result of a single proof of the document.
+type ProofResult struct {
+	// Type is the type of the proof (e.g. "Ed25519Signature2018").
+	Type string
+	// VerificationMethod is the ID of the public key the proof is verified with.
+	VerificationMethod string
+	// Err is the reason why the proof is invalid, it is nil for a valid proof.
+	Err error
+}
+
+// WithChallenge requires the verified proofs to be created for the challenge, preventing their replay.
+func WithChallenge(challenge string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.challenge = challenge
+	}
+}
+
+// WithDomain requires the verified proofs to be created for the domain, preventing their replay.
+func WithDomain(domain string) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.domain = domain
+	}
+}
+
+// WithClock defines the clock the expiry of the proofs is checked against (the system clock by default).
+func WithClock(c clock.Clock) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.clock = c
+	}
+}
+
+// WithProcessorOpts defines the JSON-LD processor options used to canonicalize the document.
+func WithProcessorOpts(opts ...jsonld.ProcessorOpts) VerifyOpt {
+	return func(verifyOpts *verifyOpts) {
+		verifyOpts.processorOpts = append(verifyOpts.processorOpts, opts...)
+	}
+}
+
+// WithProofResults collects the verification result of every proof of the document into results, whether the
+// verification of the document succeeds or not, telling which of its proofs are invalid and why.
+func WithProofResults(results *[]ProofResult) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.proofResults = results
+	}
+}
+
+// VerifyWithOpts will verify document proofs like VerifyProofSet, also checking the proofs against the options,
+// e.g. the challenge and domain they must be created for.
+func (dv *DocumentVerifier) VerifyWithOpts(jsonLdDoc []byte, minValid int, opts ...VerifyOpt) error {
+	vOpts := &verifyOpts{}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
 	var jsonLdObject map[string]interface{}
 
 	err := json.Unmarshal(jsonLdDoc, &jsonLdObject)
@@ -75,60 +198,289 @@ func (dv *DocumentVerifier) Verify(jsonLdDoc []byte, opts ...jsonld.ProcessorOpt
 		return fmt.Errorf("failed to unmarshal json ld document: %w", err)
 	}
 
-	return dv.verifyObject(jsonLdObject, opts...)
+	return dv.verifyObject(jsonLdObject, minValid, vOpts)
 }
 
 // verifyObject will verify document proofs for JSON LD object.
-func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, opts ...jsonld.ProcessorOpts) error {
+func (dv *DocumentVerifier) verifyObject(jsonLdObject map[string]interface{}, minValid int,
+	opts *verifyOpts) error {
 	proofs, err := proof.GetProofs(jsonLdObject)
 	if err != nil {
 		return err
 	}
 
+	psv := newProofSetVerifier(dv, jsonLdObject, proofs, opts)
+
+	if minValid <= 0 {
+		err = psv.verifyAll(proofs)
+	} else {
+		err = psv.verifyMinValid(proofs, minValid)
+	}
+
+	if opts.proofResults != nil {
+		*opts.proofResults = psv.proofResults(proofs)
+	}
+
+	return err
+}
+
+// proofSetVerifier verifies proofs of a proof set, following previousProof references of a proof chain.
+type proofSetVerifier struct {
+	dv           *DocumentVerifier
+	jsonLdObject map[string]interface{}
+	opts         *verifyOpts
+	cache        *canonicalDocCache
+
+	byID    map[string]*proof.Proof
+	results map[*proof.Proof]error
+	pending map[*proof.Proof]bool
+}
+
+func newProofSetVerifier(dv *DocumentVerifier, jsonLdObject map[string]interface{}, proofs []*proof.Proof,
+	opts *verifyOpts) *proofSetVerifier {
+	byID := make(map[string]*proof.Proof)
+
 	for _, p := range proofs {
-		publicKeyID, err := p.PublicKeyID()
-		if err != nil {
-			return err
+		if p.ID != "" {
+			byID[p.ID] = p
 		}
+	}
 
-		publicKey, err := dv.pkResolver.Resolve(publicKeyID)
-		if err != nil {
+	return &proofSetVerifier{
+		dv:           dv,
+		jsonLdObject: jsonLdObject,
+		opts:         opts,
+		cache:        newCanonicalDocCache(),
+		byID:         byID,
+		results:      make(map[*proof.Proof]error),
+		pending:      make(map[*proof.Proof]bool),
+	}
+}
+
+// verifyAll verifies that all the proofs are valid.
+func (psv *proofSetVerifier) verifyAll(proofs []*proof.Proof) error {
+	for _, p := range proofs {
+		if err := psv.verify(p); err != nil {
 			return err
 		}
+	}
 
-		suite, err := dv.getSignatureSuite(p.Type)
-		if err != nil {
-			return err
+	return nil
+}
+
+// proofResults returns the verification results of the proofs, the proofs not verified yet are verified.
+func (psv *proofSetVerifier) proofResults(proofs []*proof.Proof) []ProofResult {
+	results := make([]ProofResult, len(proofs))
+
+	for i, p := range proofs {
+		results[i] = ProofResult{
+			Type:               p.Type,
+			VerificationMethod: p.VerificationMethod,
+			Err:                psv.verify(p),
 		}
 
-		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
-		if err != nil {
-			return err
+		if results[i].VerificationMethod == "" {
+			results[i].VerificationMethod = p.Creator
 		}
+	}
 
-		signature, err := getProofVerifyValue(p)
-		if err != nil {
-			return err
+	return results
+}
+
+// verifyMinValid verifies that at least minValid of the proofs are valid.
+func (psv *proofSetVerifier) verifyMinValid(proofs []*proof.Proof, minValid int) error {
+	var (
+		valid    int
+		firstErr error
+	)
+
+	for _, p := range proofs {
+		if err := psv.verify(p); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			continue
 		}
 
-		err = suite.Verify(publicKey, message, signature)
-		if err != nil {
-			return err
+		valid++
+	}
+
+	if valid >= minValid {
+		return nil
+	}
+
+	if firstErr == nil {
+		return fmt.Errorf("%d of %d proofs are valid while at least %d required", valid, len(proofs), minValid)
+	}
+
+	return fmt.Errorf("%d of %d proofs are valid while at least %d required: %w",
+		valid, len(proofs), minValid, firstErr)
+}
+
+// verify verifies the proof and (recursively) the previous proofs it refers to. Results are memoized.
+func (psv *proofSetVerifier) verify(p *proof.Proof) error {
+	if err, ok := psv.results[p]; ok {
+		return err
+	}
+
+	if psv.pending[p] {
+		return fmt.Errorf("proof chain cycle detected at proof %s", p.ID)
+	}
+
+	psv.pending[p] = true
+
+	err := psv.verifyChain(p)
+
+	delete(psv.pending, p)
+	psv.results[p] = err
+
+	return err
+}
+
+func (psv *proofSetVerifier) verifyChain(p *proof.Proof) error {
+	for _, id := range p.PreviousProof {
+		previous, ok := psv.byID[id]
+		if !ok {
+			return fmt.Errorf("previous proof %s not found", id)
+		}
+
+		if err := psv.verify(previous); err != nil {
+			return fmt.Errorf("previous proof %s: %w", id, err)
 		}
 	}
 
+	return psv.dv.verifyProof(psv.jsonLdObject, p, psv.opts, psv.cache)
+}
+
+// verifyProof will verify single proof of JSON LD object.
+func (dv *DocumentVerifier) verifyProof(jsonLdObject map[string]interface{}, p *proof.Proof,
+	opts *verifyOpts, cache *canonicalDocCache) error {
+	if err := checkProofOptions(p, opts); err != nil {
+		return err
+	}
+
+	publicKeyID, err := p.PublicKeyID()
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := dv.pkResolver.Resolve(publicKeyID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkKeyProofPurpose(p, publicKeyID, publicKey); err != nil {
+		return err
+	}
+
+	suite, err := dv.getSignatureSuite(p, publicKey)
+	if err != nil {
+		return err
+	}
+
+	if diSuite, ok := suite.(dataIntegritySuite); ok && p.ProofPurpose != diSuite.ProofPurpose() {
+		return fmt.Errorf("proof purpose %q does not match expected %q", p.ProofPurpose, diSuite.ProofPurpose())
+	}
+
+	message, err := proof.CreateVerifyData(cache.wrap(suite), jsonLdObject, p, opts.processorOpts...)
+	if err != nil {
+		return err
+	}
+
+	signature, err := getProofVerifyValue(p)
+	if err != nil {
+		return err
+	}
+
+	return suite.Verify(publicKey, message, signature)
+}
+
+// checkProofOptions checks that the proof has not expired and was created for the expected challenge and domain.
+func checkProofOptions(p *proof.Proof, opts *verifyOpts) error {
+	if p.Expires != nil && !clock.OrSystem(opts.clock).Now().Before(p.Expires.Time) {
+		return fmt.Errorf("proof expired at %s", p.Expires.UTC().Format(time.RFC3339))
+	}
+
+	if opts.challenge != "" && p.Challenge != opts.challenge {
+		if p.Challenge == "" {
+			return errors.New("proof challenge is missing")
+		}
+
+		return fmt.Errorf("proof challenge %q does not match expected %q", p.Challenge, opts.challenge)
+	}
+
+	if opts.domain != "" && p.Domain != opts.domain {
+		if p.Domain == "" {
+			return errors.New("proof domain is missing")
+		}
+
+		return fmt.Errorf("proof domain %q does not match expected %q", p.Domain, opts.domain)
+	}
+
 	return nil
 }
 
-// getSignatureSuite returns signature suite based on signature type.
-func (dv *DocumentVerifier) getSignatureSuite(signatureType string) (SignatureSuite, error) {
-	for _, s := range dv.signatureSuites {
-		if s.Accept(signatureType) {
-			return s, nil
+// checkKeyProofPurpose checks that the key is authorized for the purpose of the proof. A proof without the purpose
+// is an assertion proof (the default purpose of the signer).
+func checkKeyProofPurpose(p *proof.Proof, publicKeyID string, publicKey *PublicKey) error {
+	if publicKey.ProofPurposes == nil {
+		return nil
+	}
+
+	proofPurpose := p.ProofPurpose
+	if proofPurpose == "" {
+		proofPurpose = defaultProofPurpose
+	}
+
+	for _, purpose := range publicKey.ProofPurposes {
+		if purpose == proofPurpose {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("verification method %s is not authorized for proof purpose %s", publicKeyID, proofPurpose)
+}
+
+// getSignatureSuite returns signature suite based on signature type (and cryptosuite of Data Integrity proof)
+// which supports the public key.
+func (dv *DocumentVerifier) getSignatureSuite(p *proof.Proof, publicKey *PublicKey) (SignatureSuite, error) {
+	var pubKeyErr error
+
+	suites := dv.signatureSuites
+	if dv.suiteRegistry != nil {
+		suites = dv.suiteRegistry.Suites(p.Type, p.Cryptosuite)
+	}
+
+	for _, s := range suites {
+		if !s.Accept(p.Type) {
+			continue
 		}
+
+		if diSuite, ok := s.(dataIntegritySuite); ok && diSuite.Cryptosuite() != p.Cryptosuite {
+			continue
+		}
+
+		if pkSuite, ok := s.(publicKeySuite); ok {
+			if err := pkSuite.CheckPublicKey(publicKey); err != nil {
+				pubKeyErr = err
+
+				continue
+			}
+		}
+
+		return s, nil
+	}
+
+	if p.Cryptosuite != "" {
+		if pubKeyErr != nil {
+			return nil, fmt.Errorf("signature type %s with cryptosuite %s: %w", p.Type, p.Cryptosuite, pubKeyErr)
+		}
+
+		return nil, fmt.Errorf("signature type %s with cryptosuite %s not supported", p.Type, p.Cryptosuite)
 	}
 
-	return nil, fmt.Errorf("signature type %s not supported", signatureType)
+	return nil, fmt.Errorf("signature type %s not supported", p.Type)
 }
 
 func getProofVerifyValue(p *proof.Proof) ([]byte, error) {
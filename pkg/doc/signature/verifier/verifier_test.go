@@ -12,12 +12,14 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 )
 
 //go:embed testdata/valid_doc.jsonld
@@ -75,6 +77,31 @@ func TestVerify(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, "public key is not resolved")
 
+	// public key is authorized for other proof purposes
+	v, err = New(&testKeyResolver{
+		publicKey: &PublicKey{
+			Type:          kms.ED25519,
+			Value:         []byte("signature"),
+			ProofPurposes: []string{"authentication", "keyAgreement"},
+		},
+	}, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+
+	err = v.Verify([]byte(validDoc))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not authorized for proof purpose assertionMethod")
+
+	// public key is authorized for the proof purpose
+	v, err = New(&testKeyResolver{
+		publicKey: &PublicKey{
+			Type:          kms.ED25519,
+			Value:         []byte("signature"),
+			ProofPurposes: []string{"authentication", "assertionMethod"},
+		},
+	}, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+	require.NoError(t, v.Verify([]byte(validDoc)))
+
 	// signature suite is not found
 	v, err = New(okKeyResolver, &testSignatureSuite{accept: false})
 	require.NoError(t, err)
@@ -112,6 +139,229 @@ func TestVerify(t *testing.T) {
 	require.Nil(t, v)
 }
 
+func TestVerifyProofSet(t *testing.T) {
+	keyResolver := &testKeyResolver{
+		publicKey: &PublicKey{
+			Type:  kms.ED25519,
+			Value: []byte("signature"),
+		},
+		invalidKeyID: "did:example:123456#invalid",
+	}
+
+	v, err := New(keyResolver, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+
+	newDoc := func(t *testing.T, proofs ...map[string]interface{}) []byte {
+		t.Helper()
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &doc))
+
+		p, ok := doc["proof"].(map[string]interface{})
+		require.True(t, ok)
+
+		proofSet := make([]interface{}, len(proofs))
+
+		for i, fields := range proofs {
+			pCopy := make(map[string]interface{})
+
+			for k, v := range p {
+				pCopy[k] = v
+			}
+
+			for k, v := range fields {
+				pCopy[k] = v
+			}
+
+			proofSet[i] = pCopy
+		}
+
+		doc["proof"] = proofSet
+
+		docBytes, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		return docBytes
+	}
+
+	t.Run("proof set", func(t *testing.T) {
+		docBytes := newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1"},
+			map[string]interface{}{"id": "urn:uuid:2", "verificationMethod": "did:example:123456#invalid"})
+
+		err := v.Verify(docBytes)
+		require.EqualError(t, err, "invalid public key")
+
+		err = v.VerifyProofSet(docBytes, 1)
+		require.NoError(t, err)
+
+		err = v.VerifyProofSet(docBytes, 2)
+		require.EqualError(t, err, "1 of 2 proofs are valid while at least 2 required: invalid public key")
+
+		err = v.VerifyProofSet(newDoc(t, map[string]interface{}{}), 2)
+		require.EqualError(t, err, "1 of 1 proofs are valid while at least 2 required")
+	})
+
+	t.Run("proof results", func(t *testing.T) {
+		docBytes := newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1", "verificationMethod": "did:example:123456#invalid"},
+			map[string]interface{}{"id": "urn:uuid:2"})
+
+		var results []ProofResult
+
+		err := v.VerifyWithOpts(docBytes, 0, WithProofResults(&results))
+		require.EqualError(t, err, "invalid public key")
+		require.Equal(t, []ProofResult{
+			{
+				Type:               "Ed25519Signature2018",
+				VerificationMethod: "did:example:123456#invalid",
+				Err:                errors.New("invalid public key"),
+			},
+			{
+				Type:               "Ed25519Signature2018",
+				VerificationMethod: "did:example:123456#key1",
+			},
+		}, results)
+
+		err = v.VerifyWithOpts(docBytes, 1, WithProofResults(&results))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.EqualError(t, results[0].Err, "invalid public key")
+		require.NoError(t, results[1].Err)
+	})
+
+	t.Run("proof chain", func(t *testing.T) {
+		err := v.Verify(newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1"},
+			map[string]interface{}{"id": "urn:uuid:2", "previousProof": "urn:uuid:1"}))
+		require.NoError(t, err)
+
+		docBytes := newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1", "verificationMethod": "did:example:123456#invalid"},
+			map[string]interface{}{"id": "urn:uuid:2", "previousProof": "urn:uuid:1"})
+
+		err = v.VerifyProofSet(docBytes, 1)
+		require.EqualError(t, err,
+			"0 of 2 proofs are valid while at least 1 required: invalid public key")
+
+		// the proof of the chain is invalid if the previous proof is invalid
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(docBytes, &doc))
+
+		proofs, err := proof.GetProofs(doc)
+		require.NoError(t, err)
+
+		err = newProofSetVerifier(v, doc, proofs, &verifyOpts{}).verify(proofs[1])
+		require.EqualError(t, err, "previous proof urn:uuid:1: invalid public key")
+	})
+
+	t.Run("previous proof not found", func(t *testing.T) {
+		err := v.Verify(newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1"},
+			map[string]interface{}{"id": "urn:uuid:2", "previousProof": "urn:uuid:3"}))
+		require.EqualError(t, err, "previous proof urn:uuid:3 not found")
+	})
+
+	t.Run("proof chain cycle", func(t *testing.T) {
+		err := v.Verify(newDoc(t,
+			map[string]interface{}{"id": "urn:uuid:1", "previousProof": "urn:uuid:2"},
+			map[string]interface{}{"id": "urn:uuid:2", "previousProof": "urn:uuid:1"}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof chain cycle detected at proof urn:uuid:1")
+	})
+}
+
+func TestVerifyWithOpts(t *testing.T) {
+	v, err := New(&testKeyResolver{
+		publicKey: &PublicKey{
+			Type:  kms.ED25519,
+			Value: []byte("signature"),
+		},
+	}, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+
+	newDoc := func(t *testing.T, fields map[string]interface{}) []byte {
+		t.Helper()
+
+		var doc map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validDoc), &doc))
+
+		p, ok := doc["proof"].(map[string]interface{})
+		require.True(t, ok)
+
+		for k, v := range fields {
+			p[k] = v
+		}
+
+		docBytes, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		return docBytes
+	}
+
+	doc := newDoc(t, map[string]interface{}{"challenge": "challenge", "domain": "example.com"})
+
+	t.Run("matching challenge and domain", func(t *testing.T) {
+		require.NoError(t, v.VerifyWithOpts(doc, 0, WithChallenge("challenge"), WithDomain("example.com")))
+		require.NoError(t, v.VerifyWithOpts(doc, 0))
+	})
+
+	t.Run("mismatching challenge or domain", func(t *testing.T) {
+		err := v.VerifyWithOpts(doc, 0, WithChallenge("other"), WithDomain("example.com"))
+		require.EqualError(t, err, `proof challenge "challenge" does not match expected "other"`)
+
+		err = v.VerifyWithOpts(doc, 0, WithChallenge("challenge"), WithDomain("other.com"))
+		require.EqualError(t, err, `proof domain "example.com" does not match expected "other.com"`)
+	})
+
+	t.Run("missing challenge or domain", func(t *testing.T) {
+		err := v.VerifyWithOpts([]byte(validDoc), 0, WithChallenge("challenge"))
+		require.EqualError(t, err, "proof challenge is missing")
+
+		err = v.VerifyWithOpts([]byte(validDoc), 0, WithDomain("example.com"))
+		require.EqualError(t, err, "proof domain is missing")
+	})
+
+	t.Run("checked for each proof of the proof set", func(t *testing.T) {
+		var docMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(doc, &docMap))
+
+		docMap["proof"] = []interface{}{docMap["proof"], map[string]interface{}{
+			"type":               "Ed25519Signature2018",
+			"verificationMethod": "did:example:123456#key2",
+			"created":            "2011-09-23T20:21:34Z",
+			"proofValue":         "ABC",
+		}}
+
+		proofSetDoc, err := json.Marshal(docMap)
+		require.NoError(t, err)
+
+		err = v.VerifyWithOpts(proofSetDoc, 0, WithChallenge("challenge"))
+		require.EqualError(t, err, "proof challenge is missing")
+
+		require.NoError(t, v.VerifyWithOpts(proofSetDoc, 1, WithChallenge("challenge"),
+			WithProcessorOpts(jsonld.WithValidateRDF())))
+	})
+
+	t.Run("proof expiry", func(t *testing.T) {
+		expiringDoc := newDoc(t, map[string]interface{}{"expires": "2030-01-01T00:00:00Z"})
+
+		require.NoError(t, v.VerifyWithOpts(expiringDoc, 0,
+			WithClock(mockclock.New(time.Date(2029, 12, 31, 23, 59, 59, 0, time.UTC)))))
+
+		err := v.VerifyWithOpts(expiringDoc, 0,
+			WithClock(mockclock.New(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))))
+		require.EqualError(t, err, "proof expired at 2030-01-01T00:00:00Z")
+
+		err = v.VerifyWithOpts(newDoc(t, map[string]interface{}{"expires": "2011-09-24T20:21:34Z"}), 0)
+		require.EqualError(t, err, "proof expired at 2011-09-24T20:21:34Z")
+	})
+}
+
 func Test_getProofVerifyValue(t *testing.T) {
 	jwsSignature := base64.RawURLEncoding.EncodeToString([]byte("signature"))
 
@@ -140,11 +390,16 @@ func Test_getProofVerifyValue(t *testing.T) {
 }
 
 type testKeyResolver struct {
-	publicKey *PublicKey
-	err       error
+	publicKey    *PublicKey
+	err          error
+	invalidKeyID string
 }
 
-func (r *testKeyResolver) Resolve(string) (*PublicKey, error) {
+func (r *testKeyResolver) Resolve(id string) (*PublicKey, error) {
+	if r.invalidKeyID != "" && id == r.invalidKeyID {
+		return nil, errors.New("invalid public key")
+	}
+
 	return r.publicKey, r.err
 }
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
@@ -29,7 +30,7 @@ import (
 var errInvalidKeyType = errors.New("key type is not supported")
 
 // CreateKID creates a KID value based on the marshalled keyBytes of type kt. This function should be called for
-// asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2).
+// asymmetric public keys only (ECDSA DER or IEEE-P1363, secp256k1, ED25519, X25519, BLS12381G2).
 // returns:
 //  - base64 raw (no padding) URL encoded KID
 //  - error in case of error
@@ -60,6 +61,13 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return bbsKID, nil
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363: // secp256k1 is not supported by go jose.
+		secp256k1KID, err := createSecp256K1KID(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("createKID: %w", err)
+		}
+
+		return secp256k1KID, nil
 	}
 
 	jwk, err := BuildJWK(keyBytes, kt)
@@ -249,6 +257,29 @@ func createBLS12381G2KID(keyBytes []byte) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
 }
 
+func createSecp256K1KID(keyBytes []byte) (string, error) {
+	const (
+		secp256k1ThumbprintTemplate = `{"crv":"secp256k1","kty":"EC","x":"%s","y":"%s"}`
+		secp256k1CoordinateSize     = 32
+	)
+
+	pubKey, err := btcec.ParsePubKey(keyBytes, btcec.S256())
+	if err != nil {
+		return "", fmt.Errorf("createSecp256K1KID: invalid secp256k1 key: %w", err)
+	}
+
+	x := make([]byte, secp256k1CoordinateSize)
+	y := make([]byte, secp256k1CoordinateSize)
+
+	jwk := fmt.Sprintf(secp256k1ThumbprintTemplate,
+		base64.RawURLEncoding.EncodeToString(pubKey.X.FillBytes(x)),
+		base64.RawURLEncoding.EncodeToString(pubKey.Y.FillBytes(y)))
+
+	thumbprint := sha256Sum(jwk)
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
 func sha256Sum(jwk string) []byte {
 	h := crypto.SHA256.New()
 	_, _ = h.Write([]byte(jwk)) // SHA256 digest returns empty error on Write()
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	"github.com/stretchr/testify/require"
 
@@ -226,3 +227,35 @@ func TestCreateBLS12381G2KID(t *testing.T) {
 	_, err = CreateKID(append(pubKeyBytes, []byte("larger key")...), kms.BLS12381G2Type)
 	require.EqualError(t, err, "createKID: invalid BBS+ key")
 }
+
+func TestCreateSecp256K1KID(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	kid, err := CreateKID(privKey.PubKey().SerializeUncompressed(), kms.ECDSASecp256k1TypeIEEEP1363)
+	require.NoError(t, err)
+	require.NotEmpty(t, kid)
+
+	// compressed and uncompressed forms of the same key have the same KID.
+	compressedKID, err := CreateKID(privKey.PubKey().SerializeCompressed(), kms.ECDSASecp256k1TypeDER)
+	require.NoError(t, err)
+	require.Equal(t, kid, compressedKID)
+
+	// the KID is the JWK thumbprint of the key.
+	jwk, err := jose.JWKFromKey(privKey.PubKey().ToECDSA())
+	require.NoError(t, err)
+
+	mJWK, err := jwk.MarshalJSON()
+	require.NoError(t, err)
+
+	jwkMap := map[string]string{}
+	require.NoError(t, json.Unmarshal(mJWK, &jwkMap))
+
+	thumbprint := sha256Sum(fmt.Sprintf(`{"crv":"%s","kty":"%s","x":"%s","y":"%s"}`,
+		jwkMap["crv"], jwkMap["kty"], jwkMap["x"], jwkMap["y"]))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(thumbprint), kid)
+
+	_, err = CreateKID([]byte("not a key"), kms.ECDSASecp256k1TypeDER)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "createKID: createSecp256K1KID: invalid secp256k1 key")
+}
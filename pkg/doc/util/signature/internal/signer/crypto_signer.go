@@ -13,8 +13,12 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -23,16 +27,35 @@ type CryptoSigner struct {
 	PubKeyBytes []byte
 	PubKey      interface{}
 
-	crypto cryptoapi.Crypto
-	kh     interface{}
-	kid    string
+	crypto  cryptoapi.Crypto
+	kh      interface{}
+	kid     string
+	keyType kmsapi.KeyType
 }
 
 // Sign will sign document and return signature.
+// BBS+ keys sign each non blank line of the document as a separate message.
 func (s *CryptoSigner) Sign(msg []byte) ([]byte, error) {
+	if s.keyType == kmsapi.BLS12381G2Type {
+		return s.crypto.SignMulti(textToLines(string(msg)), s.kh)
+	}
+
 	return s.crypto.Sign(msg, s.kh)
 }
 
+func textToLines(txt string) [][]byte {
+	lines := strings.Split(txt, "\n")
+	linesBytes := make([][]byte, 0, len(lines))
+
+	for i := range lines {
+		if strings.TrimSpace(lines[i]) != "" {
+			linesBytes = append(linesBytes, []byte(lines[i]))
+		}
+	}
+
+	return linesBytes
+}
+
 // PublicKey returns a public key object (e.g. ed25519.VerificationMethod or *ecdsa.PublicKey).
 func (s *CryptoSigner) PublicKey() interface{} {
 	return s.PubKey
@@ -69,12 +92,13 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 		kid:         kid,
 		crypto:      crypto,
 		kh:          kh,
+		keyType:     keyType,
 		PubKey:      pubKey,
 		PubKeyBytes: pubKeyBytes,
 	}, nil
 }
 
-func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, error) {
+func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, error) { //nolint:gocyclo
 	switch keyType {
 	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP521TypeDER:
 		pubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
@@ -116,9 +140,25 @@ func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, erro
 			Y:     y,
 		}, nil
 
+	case kmsapi.ECDSASecp256k1TypeDER, kmsapi.ECDSASecp256k1TypeIEEEP1363:
+		pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("parse secp256k1 public key: %w", err)
+		}
+
+		return pubKey.ToECDSA(), nil
+
 	case kmsapi.ED25519Type:
 		return ed25519.PublicKey(pubKeyBytes), nil
 
+	case kmsapi.BLS12381G2Type:
+		pubKey, err := bbs12381g2pub.UnmarshalPublicKey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse BBS+ public key: %w", err)
+		}
+
+		return pubKey, nil
+
 	default:
 		return nil, errors.New("unsupported key type")
 	}
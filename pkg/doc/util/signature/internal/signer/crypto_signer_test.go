@@ -17,6 +17,7 @@ import (
 	"github.com/google/tink/go/keyset"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	kmsapi "github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
@@ -43,6 +44,8 @@ func TestNewCryptoSigner(t *testing.T) {
 		{kmsapi.ECDSAP256TypeIEEEP1363, &ecdsa.PublicKey{}},
 		{kmsapi.ECDSAP384TypeIEEEP1363, &ecdsa.PublicKey{}},
 		{kmsapi.ECDSAP521TypeIEEEP1363, &ecdsa.PublicKey{}},
+		{kmsapi.ECDSASecp256k1TypeDER, &ecdsa.PublicKey{}},
+		{kmsapi.ECDSASecp256k1TypeIEEEP1363, &ecdsa.PublicKey{}},
 	}
 
 	for _, test := range tests {
@@ -67,6 +70,32 @@ func TestNewCryptoSigner(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	t.Run("BBS+ signer", func(t *testing.T) {
+		signer, err := NewCryptoSigner(tinkCrypto, localKMS, kmsapi.BLS12381G2Type)
+		require.NoError(t, err)
+		require.IsType(t, &bbs12381g2pub.PublicKey{}, signer.PublicKey())
+		require.Len(t, signer.PublicKeyBytes(), 96)
+
+		msg := []byte("first message\n\nsecond message\n")
+		sigMsg, err := signer.Sign(msg)
+		require.NoError(t, err)
+
+		keyHandle, ok := signer.kh.(*keyset.Handle)
+		require.True(t, ok)
+
+		publicKeyHandle, err := keyHandle.Public()
+		require.NoError(t, err)
+
+		err = tinkCrypto.VerifyMulti([][]byte{[]byte("first message"), []byte("second message")}, sigMsg,
+			publicKeyHandle)
+		require.NoError(t, err)
+
+		// the signature also verifies with the exported public key bytes, as done by the BBS+ suite verifier.
+		err = bbs12381g2pub.New().Verify([][]byte{[]byte("first message"), []byte("second message")}, sigMsg,
+			signer.PublicKeyBytes())
+		require.NoError(t, err)
+	})
+
 	t.Run("error corner cases", func(t *testing.T) {
 		kms := &mockkms.KeyManager{
 			CreateKeyErr: errors.New("key creation error"),
@@ -104,6 +133,19 @@ func TestNewCryptoSigner(t *testing.T) {
 		require.EqualError(t, err, "unexpected type of ecdsa public key")
 		require.Nil(t, signer)
 
+		kms = &mockkms.KeyManager{
+			ExportPubKeyBytesValue: []byte("not a public key"),
+		}
+		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.BLS12381G2Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse BBS+ public key")
+		require.Nil(t, signer)
+
+		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.ECDSASecp256k1TypeIEEEP1363)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse secp256k1 public key")
+		require.Nil(t, signer)
+
 		kms = &mockkms.KeyManager{}
 		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.ChaCha20Poly1305Type)
 		require.Error(t, err)
@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// BitstringStatusListEntryType is the credentialStatus type of a credential which status is published in a
+	// BitstringStatusListCredential.
+	// Reference: https://www.w3.org/TR/vc-bitstring-status-list/#bitstringstatuslistentry
+	BitstringStatusListEntryType = "BitstringStatusListEntry"
+
+	// BitstringStatusListCredentialType is the type of bitstring status list credential.
+	BitstringStatusListCredentialType = "BitstringStatusListCredential"
+
+	statusSizeField    = "statusSize"
+	statusMessageField = "statusMessage"
+
+	// multibase prefix of base64url encoding with no padding.
+	multibaseBase64URLPrefix = "u"
+
+	// status values are read into uint64.
+	maxStatusSize = 64
+)
+
+// StatusMessage maps a status value of a BitstringStatusListEntry to a message.
+type StatusMessage struct {
+	// Status is the hexadecimal status value (e.g. "0x2").
+	Status string `json:"status"`
+
+	// Message describes the status.
+	Message string `json:"message"`
+}
+
+func parseBitstringStatusListEntry(status *TypedID) (*statusEntry, error) {
+	entry, err := parseStatusEntry(status)
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.purpose {
+	case StatusPurposeRevocation, StatusPurposeSuspension, StatusPurposeRefresh, StatusPurposeMessage:
+	default:
+		return nil, fmt.Errorf("unsupported %s: %s", statusPurposeField, entry.purpose)
+	}
+
+	entry.listType = BitstringStatusListCredentialType
+
+	entry.size, err = parseStatusSize(status.CustomFields[statusSizeField])
+	if err != nil {
+		return nil, err
+	}
+
+	if rawMessages, ok := status.CustomFields[statusMessageField]; ok {
+		messagesBytes, e := json.Marshal(rawMessages)
+		if e != nil {
+			return nil, fmt.Errorf("marshal %s: %w", statusMessageField, e)
+		}
+
+		if e = json.Unmarshal(messagesBytes, &entry.messages); e != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", statusMessageField, e)
+		}
+	}
+
+	if entry.size > 1 && len(entry.messages) == 0 {
+		return nil, fmt.Errorf("%s is required for %s greater than 1", statusMessageField, statusSizeField)
+	}
+
+	return entry, nil
+}
+
+// parseStatusSize parses the optional statusSize of a BitstringStatusListEntry, which defaults to 1.
+func parseStatusSize(v interface{}) (int, error) {
+	var size int
+
+	switch s := v.(type) {
+	case nil:
+		return 1, nil
+	case float64:
+		size = int(s)
+
+		if float64(size) != s {
+			return 0, fmt.Errorf("invalid %s: %v", statusSizeField, v)
+		}
+	case int:
+		size = s
+	default:
+		return 0, fmt.Errorf("invalid %s: %v", statusSizeField, v)
+	}
+
+	if size < 1 || size > maxStatusSize {
+		return 0, fmt.Errorf("invalid %s: %v", statusSizeField, v)
+	}
+
+	return size, nil
+}
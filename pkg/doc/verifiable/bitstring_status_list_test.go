@@ -0,0 +1,215 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	bitstringStatusListCredentialTemplate = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    {
+      "@vocab": "https://www.w3.org/ns/credentials/status#"
+    }
+  ],
+  "id": "https://example.com/credentials/status/3",
+  "type": ["VerifiableCredential", "BitstringStatusListCredential"],
+  "issuer": "did:example:12345",
+  "issuanceDate": "2021-04-05T14:27:40Z",
+  "credentialSubject": {
+    "id": "https://example.com/status/3#list",
+    "type": "BitstringStatusList",
+    "statusPurpose": %s,
+    "encodedList": "%s"
+  }
+}`
+
+	bitstringStatusMessages = `[
+  {"status": "0x0", "message": "pending_review"},
+  {"status": "0x1", "message": "accepted"},
+  {"status": "0x2", "message": "rejected"},
+  {"status": "0x3", "message": "undefined"}
+]`
+)
+
+func TestStatusChecker_CheckBitstringStatusList(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	createList := func(purpose string, size int, values map[int]uint64) []byte {
+		return signStatusListCredential(t, signer, []byte(fmt.Sprintf(bitstringStatusListCredentialTemplate,
+			purpose, multibaseBase64URLPrefix+encodeStatusValues(t, size, values))))
+	}
+
+	newChecker := func(statusList []byte) *StatusChecker {
+		return NewStatusChecker(
+			WithStatusListFetcher(func(url string) ([]byte, error) {
+				require.Equal(t, statusListURL, url)
+
+				return statusList, nil
+			}),
+			WithStatusListCredentialOpts(
+				WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))))
+	}
+
+	t.Run("1-bit status size", func(t *testing.T) {
+		checker := newChecker(createList(`"revocation"`, 1, map[int]uint64{7: 1}))
+
+		result, err := checker.Check(newBitstringStatusVC(t, `"statusPurpose": "revocation", "statusListIndex": "7"`))
+		require.NoError(t, err)
+		require.Equal(t, &StatusResult{Purpose: StatusPurposeRevocation, Set: true, Value: 1}, result)
+		require.True(t, result.Revoked())
+
+		result, err = checker.Check(newBitstringStatusVC(t,
+			`"statusPurpose": "revocation", "statusListIndex": "8", "statusSize": 1`))
+		require.NoError(t, err)
+		require.False(t, result.Set)
+		require.False(t, result.Revoked())
+	})
+
+	t.Run("2-bit status size", func(t *testing.T) {
+		checker := newChecker(createList(`"message"`, 2, map[int]uint64{3: 3, 5: 2, 7: 1}))
+
+		for index, expected := range map[string]*StatusResult{
+			"3": {Purpose: StatusPurposeMessage, Set: true, Value: 3, Message: "undefined"},
+			"4": {Purpose: StatusPurposeMessage, Set: false, Value: 0, Message: "pending_review"},
+			"5": {Purpose: StatusPurposeMessage, Set: true, Value: 2, Message: "rejected"},
+			"7": {Purpose: StatusPurposeMessage, Set: true, Value: 1, Message: "accepted"},
+		} {
+			result, err := checker.Check(newBitstringStatusVC(t, fmt.Sprintf(
+				`"statusPurpose": "message", "statusListIndex": "%s", "statusSize": 2, "statusMessage": %s`,
+				index, bitstringStatusMessages)))
+			require.NoError(t, err)
+			require.Equal(t, expected, result, "status list index %s", index)
+			require.False(t, result.Revoked())
+			require.False(t, result.Suspended())
+		}
+	})
+
+	t.Run("status list with several purposes", func(t *testing.T) {
+		checker := newChecker(createList(`["revocation", "suspension"]`, 1, map[int]uint64{7: 1}))
+
+		result, err := checker.Check(newBitstringStatusVC(t, `"statusPurpose": "suspension", "statusListIndex": "7"`))
+		require.NoError(t, err)
+		require.True(t, result.Suspended())
+
+		_, err = checker.Check(newBitstringStatusVC(t, `"statusPurpose": "refresh", "statusListIndex": "7"`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `does not match credential status purpose "refresh"`)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		checker := newChecker(createList(`"message"`, 2, nil))
+
+		_, err := checker.Check(newBitstringStatusVC(t, fmt.Sprintf(
+			`"statusPurpose": "message", "statusListIndex": "%d", "statusSize": 2, "statusMessage": %s`,
+			statusListSize/2, bitstringStatusMessages)))
+		require.Error(t, err)
+
+		var rangeErr *StatusListIndexOutOfRangeError
+
+		require.True(t, errors.As(err, &rangeErr))
+		require.Equal(t, statusListSize/2, rangeErr.Index)
+		require.Equal(t, statusListSize/2, rangeErr.Size)
+	})
+
+	t.Run("status list of another type", func(t *testing.T) {
+		checker := newChecker(createStatusListCredential(t, signer, StatusPurposeRevocation, 7))
+
+		_, err := checker.Check(newBitstringStatusVC(t, `"statusPurpose": "revocation", "statusListIndex": "7"`))
+		require.EqualError(t, err, "status list credential is not of BitstringStatusListCredential type")
+	})
+
+	t.Run("invalid status entry", func(t *testing.T) {
+		checker := newChecker(nil)
+
+		for fields, expected := range map[string]string{
+			`"statusPurpose": "other", "statusListIndex": "7"`:                      "unsupported statusPurpose: other",
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": 2`:   "statusMessage is required for statusSize greater than 1", //nolint:lll
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": 0`:   "invalid statusSize: 0",
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": 65`:  "invalid statusSize: 65",
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": 1.5`: "invalid statusSize: 1.5",
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": "2"`: "invalid statusSize: 2",
+		} {
+			_, err := checker.Check(newBitstringStatusVC(t, fields))
+			require.EqualError(t, err, expected)
+		}
+
+		_, err := checker.Check(newBitstringStatusVC(t,
+			`"statusPurpose": "message", "statusListIndex": "7", "statusSize": 2, "statusMessage": "invalid"`))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal statusMessage")
+	})
+}
+
+func TestReadStatus(t *testing.T) {
+	bitstring := []byte{0b10011100, 0b00000001}
+
+	for _, tc := range []struct {
+		index, size int
+		value       uint64
+	}{
+		{index: 0, size: 1, value: 1},
+		{index: 1, size: 1, value: 0},
+		{index: 0, size: 2, value: 2},
+		{index: 1, size: 2, value: 1},
+		{index: 2, size: 2, value: 3},
+		{index: 1, size: 4, value: 0b1100},
+		{index: 0, size: 16, value: 0b1001110000000001},
+	} {
+		value, err := readStatus(bitstring, tc.index, tc.size)
+		require.NoError(t, err)
+		require.Equal(t, tc.value, value, "index %d size %d", tc.index, tc.size)
+	}
+
+	_, err := readStatus(bitstring, 2, 8)
+	require.EqualError(t, err, "status list index 2 is out of range of the status list of 2 entries")
+}
+
+func newBitstringStatusVC(t *testing.T, fields string) *Credential {
+	t.Helper()
+
+	status := &TypedID{}
+
+	err := json.Unmarshal([]byte(fmt.Sprintf(
+		`{"id": "%s#1", "type": "BitstringStatusListEntry", "statusListCredential": "%s", %s}`,
+		statusListURL, statusListURL, fields)), status)
+	require.NoError(t, err)
+
+	return &Credential{Status: status}
+}
+
+// encodeStatusValues encodes a status list of statusListSize bits with status values of size bits at the given
+// indexes.
+func encodeStatusValues(t *testing.T, size int, values map[int]uint64) string {
+	t.Helper()
+
+	bitstring := make([]byte, statusListSize/bitsPerByte)
+
+	for index, value := range values {
+		for i := 0; i < size; i++ {
+			if value&(1<<(size-1-i)) == 0 {
+				continue
+			}
+
+			pos := index*size + i
+			bitstring[pos/bitsPerByte] |= 1 << (bitsPerByte - 1 - pos%bitsPerByte)
+		}
+	}
+
+	return compressStatusList(t, bitstring)
+}
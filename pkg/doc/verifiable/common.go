@@ -22,12 +22,11 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
-// TODO https://github.com/square/go-jose/issues/263 support ES256K
-
 // JWSAlgorithm defines JWT signature algorithms of Verifiable Credential.
 type JWSAlgorithm int
 
@@ -37,6 +36,15 @@ const (
 
 	// EdDSA JWT Algorithm.
 	EdDSA
+
+	// ES256 JWT Algorithm (ECDSA using P-256 and SHA-256).
+	ES256
+
+	// ES384 JWT Algorithm (ECDSA using P-384 and SHA-384).
+	ES384
+
+	// ES256K JWT Algorithm (ECDSA using secp256k1 and SHA-256).
+	ES256K
 )
 
 // name return the name of the signature algorithm.
@@ -46,6 +54,12 @@ func (ja JWSAlgorithm) name() (string, error) {
 		return "RS256", nil
 	case EdDSA:
 		return "EdDSA", nil
+	case ES256:
+		return "ES256", nil
+	case ES384:
+		return "ES384", nil
+	case ES256K:
+		return "ES256K", nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
@@ -72,9 +86,21 @@ func SingleKey(pubKey []byte, pubKeyType string) PublicKeyFetcher {
 	}
 }
 
+// proofPurposes are the proof purposes of DID verification relationships.
+//nolint:gochecknoglobals
+var proofPurposes = map[did.VerificationRelationship]string{
+	did.Authentication:       "authentication",
+	did.AssertionMethod:      "assertionMethod",
+	did.CapabilityDelegation: "capabilityDelegation",
+	did.CapabilityInvocation: "capabilityInvocation",
+	did.KeyAgreement:         "keyAgreement",
+}
+
 // VDRKeyResolver resolves DID in order to find public keys for VC verification using vdr.Registry.
 // A source of DID could be issuer of VC or holder of VP. It can be also obtained from
 // JWS "issuer" claim or "verificationMethod" of Linked Data Proof.
+// The resolved keys are authorized only for the proof purposes of the verification relationships
+// they are listed under in the DID document, e.g. a key listed only under keyAgreement can't verify any proof.
 type VDRKeyResolver struct {
 	vdr vdrapi.Registry
 }
@@ -90,19 +116,50 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 		return nil, fmt.Errorf("resolve DID %s: %w", issuerDID, err)
 	}
 
-	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
+	var pubKey *verifier.PublicKey
+
+	verificationMethods := docResolution.DIDDocument.VerificationMethods()
+
+	for _, verifications := range verificationMethods {
 		for _, verification := range verifications {
 			if strings.Contains(verification.VerificationMethod.ID, keyID) {
-				return &verifier.PublicKey{
+				pubKey = &verifier.PublicKey{
 					Type:  verification.VerificationMethod.Type,
 					Value: verification.VerificationMethod.Value,
 					JWK:   verification.VerificationMethod.JSONWebKey(),
-				}, nil
+				}
+
+				keyID = verification.VerificationMethod.ID
+
+				break
 			}
 		}
+
+		if pubKey != nil {
+			break
+		}
 	}
 
-	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	if pubKey == nil {
+		return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
+	}
+
+	// the key is authorized for the proof purposes of the verification relationships it's listed under
+	pubKey.ProofPurposes = []string{}
+
+	for _, relationship := range []did.VerificationRelationship{
+		did.Authentication, did.AssertionMethod, did.CapabilityDelegation, did.CapabilityInvocation, did.KeyAgreement,
+	} {
+		for _, verification := range verificationMethods[relationship] {
+			if verification.VerificationMethod.ID == keyID {
+				pubKey.ProofPurposes = append(pubKey.ProofPurposes, proofPurposes[relationship])
+
+				break
+			}
+		}
+	}
+
+	return pubKey, nil
 }
 
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
@@ -118,7 +175,8 @@ type Proof map[string]interface{}
 type CustomFields map[string]interface{}
 
 // TypedID defines a flexible structure with id and name fields and arbitrary extra fields
-// kept in CustomFields.
+// kept in CustomFields. Type holds a single type, several types (e.g. of a termsOfUse entry)
+// are kept as is in CustomFields["type"].
 type TypedID struct {
 	ID   string `json:"id,omitempty"`
 	Type string `json:"type,omitempty"`
@@ -150,6 +208,25 @@ func (tid *TypedID) UnmarshalJSON(data []byte) error {
 
 	tid.CustomFields = make(CustomFields)
 
+	var multiType struct {
+		Type []interface{} `json:"type"`
+	}
+
+	if json.Unmarshal(data, &multiType) == nil && multiType.Type != nil {
+		// only the ID is mapped, the types are left in the custom fields.
+		var idOnly struct {
+			ID string `json:"id,omitempty"`
+		}
+
+		if err := unmarshalWithCustomFields(data, &idOnly, tid.CustomFields); err != nil {
+			return fmt.Errorf("unmarshal TypedID: %w", err)
+		}
+
+		tid.ID, tid.Type = idOnly.ID, ""
+
+		return nil
+	}
+
 	err := unmarshalWithCustomFields(data, alias, tid.CustomFields)
 	if err != nil {
 		return fmt.Errorf("unmarshal TypedID: %w", err)
@@ -27,6 +27,18 @@ func TestJwtAlgorithm_Name(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "EdDSA", alg)
 
+	alg, err = ES256.name()
+	require.NoError(t, err)
+	require.Equal(t, "ES256", alg)
+
+	alg, err = ES384.name()
+	require.NoError(t, err)
+	require.Equal(t, "ES384", alg)
+
+	alg, err = ES256K.name()
+	require.NoError(t, err)
+	require.Equal(t, "ES256K", alg)
+
 	// not supported alg
 	sa, err := JWSAlgorithm(-1).name()
 	require.Error(t, err)
@@ -260,11 +272,13 @@ func TestDIDKeyResolver_Resolve(t *testing.T) {
 	r.Equal("Ed25519VerificationKey2018", authPubKey.Type)
 	r.NotNil(authPubKey.JWK)
 	r.Equal(authPubKey.JWK.Algorithm, "EdDSA")
+	r.Equal([]string{"authentication"}, authPubKey.ProofPurposes)
 
 	assertMethPubKey, err := resolver.PublicKeyFetcher()(didDoc.ID, assertionMethod.VerificationMethod.ID)
 	r.NoError(err)
 	r.Equal(assertionMethod.VerificationMethod.Value, assertMethPubKey.Value)
 	r.Equal("Ed25519VerificationKey2018", assertMethPubKey.Type)
+	r.Equal([]string{"assertionMethod"}, assertMethPubKey.ProofPurposes)
 
 	pubKey, err = resolver.PublicKeyFetcher()(didDoc.ID, "invalid key")
 	r.Error(err)
@@ -18,6 +18,7 @@ import (
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
@@ -32,22 +33,61 @@ const DefaultSchema = `{
     "@context",
     "type",
     "credentialSubject",
-    "issuer",
-    "issuanceDate"
+    "issuer"
   ],
+  "if": {
+    "properties": {
+      "@context": {
+        "anyOf": [
+          {
+            "type": "string",
+            "const": "https://www.w3.org/2018/credentials/v1"
+          },
+          {
+            "type": "array",
+            "items": [
+              {
+                "type": "string",
+                "const": "https://www.w3.org/2018/credentials/v1"
+              }
+            ]
+          }
+        ]
+      }
+    }
+  },
+  "then": {
+    "required": [
+      "issuanceDate"
+    ]
+  },
   "properties": {
     "@context": {
       "oneOf": [
         {
           "type": "string",
-          "const": "https://www.w3.org/2018/credentials/v1"
+          "anyOf": [
+            {
+              "const": "https://www.w3.org/2018/credentials/v1"
+            },
+            {
+              "const": "https://www.w3.org/ns/credentials/v2"
+            }
+          ]
         },
         {
           "type": "array",
           "items": [
             {
               "type": "string",
-              "const": "https://www.w3.org/2018/credentials/v1"
+              "anyOf": [
+                {
+                  "const": "https://www.w3.org/2018/credentials/v1"
+                },
+                {
+                  "const": "https://www.w3.org/ns/credentials/v2"
+                }
+              ]
             }
           ],
           "uniqueItems": true,
@@ -121,6 +161,14 @@ const DefaultSchema = `{
       "type": "string",
       "format": "date-time"
     },
+    "validFrom": {
+      "type": "string",
+      "format": "date-time"
+    },
+    "validUntil": {
+      "type": "string",
+      "format": "date-time"
+    },
     "proof": {
       "anyOf": [
         {
@@ -225,6 +273,9 @@ const DefaultSchema = `{
 // https://www.w3.org/TR/vc-data-model/#data-schemas
 const jsonSchema2018Type = "JsonSchemaValidator2018"
 
+// https://www.w3.org/TR/vc-json-schema/#jsonschema
+const jsonSchemaType = "JsonSchema"
+
 const (
 	// https://www.w3.org/TR/vc-data-model/#base-context
 	baseContext = "https://www.w3.org/2018/credentials/v1"
@@ -291,15 +342,20 @@ type cache interface {
 type ExpirableSchemaCache struct {
 	cache      cache
 	expiration time.Duration
+	clock      clock.Clock
 }
 
 // CredentialSchemaLoader defines expirable cache.
 type CredentialSchemaLoader struct {
 	schemaDownloadClient *http.Client
+	schemaFetcher        SchemaFetcher
 	cache                SchemaCache
 	jsonLoader           gojsonschema.JSONLoader
 }
 
+// SchemaFetcher fetches the credential schema document located at the given URL.
+type SchemaFetcher func(url string) ([]byte, error)
+
 // CredentialSchemaLoaderBuilder defines a builder of CredentialSchemaLoader.
 type CredentialSchemaLoaderBuilder struct {
 	loader *CredentialSchemaLoader
@@ -318,6 +374,12 @@ func (b *CredentialSchemaLoaderBuilder) SetSchemaDownloadClient(client *http.Cli
 	return b
 }
 
+// SetSchemaFetcher sets the fetcher of credential schemas. It takes precedence over the schema download client.
+func (b *CredentialSchemaLoaderBuilder) SetSchemaFetcher(fetcher SchemaFetcher) *CredentialSchemaLoaderBuilder {
+	b.loader.schemaFetcher = fetcher
+	return b
+}
+
 // SetCache defines SchemaCache.
 func (b *CredentialSchemaLoaderBuilder) SetCache(cache SchemaCache) *CredentialSchemaLoaderBuilder {
 	b.loader.cache = cache
@@ -346,9 +408,16 @@ func (b *CredentialSchemaLoaderBuilder) Build() *CredentialSchemaLoader {
 	return l
 }
 
+// SetClock sets the clock the expiry of the cached elements is checked with, clock.SystemClock by default.
+func (sc *ExpirableSchemaCache) SetClock(c clock.Clock) *ExpirableSchemaCache {
+	sc.clock = c
+
+	return sc
+}
+
 // Put element to the cache. It also adds a mark of when the element will expire.
 func (sc *ExpirableSchemaCache) Put(k string, v []byte) {
-	expires := time.Now().Add(sc.expiration).Unix()
+	expires := clock.OrSystem(sc.clock).Now().Add(sc.expiration).Unix()
 
 	const numBytesTime = 8
 
@@ -373,7 +442,7 @@ func (sc *ExpirableSchemaCache) Get(k string) ([]byte, bool) {
 	const numBytesTime = 8
 
 	expires := int64(binary.LittleEndian.Uint64(b[:numBytesTime]))
-	if expires < time.Now().Unix() {
+	if expires < clock.OrSystem(sc.clock).Now().Unix() {
 		// cache expires
 		sc.cache.Del([]byte(k))
 		return nil, false
@@ -492,6 +561,8 @@ func (s *Subject) UnmarshalJSON(bytes []byte) error {
 }
 
 // Credential Verifiable Credential definition.
+// Issued and Expired hold issuanceDate and expirationDate of a VC 1.1 credential,
+// or validFrom and validUntil of a VC 2.0 credential.
 type Credential struct {
 	Context       []string
 	CustomContext []interface{}
@@ -509,6 +580,10 @@ type Credential struct {
 	TermsOfUse     []TypedID
 	RefreshService []TypedID
 
+	// JWT is the serialized JWT (JWS or unsecured JWT) the credential was parsed from, if any.
+	// It is kept as is, so the credential can be passed on without being re-signed.
+	JWT string
+
 	CustomFields CustomFields
 }
 
@@ -520,6 +595,8 @@ type rawCredential struct {
 	Subject        json.RawMessage                `json:"credentialSubject,omitempty"`
 	Issued         *util.TimeWithTrailingZeroMsec `json:"issuanceDate,omitempty"`
 	Expired        *util.TimeWithTrailingZeroMsec `json:"expirationDate,omitempty"`
+	ValidFrom      *util.TimeWithTrailingZeroMsec `json:"validFrom,omitempty"`
+	ValidUntil     *util.TimeWithTrailingZeroMsec `json:"validUntil,omitempty"`
 	Proof          json.RawMessage                `json:"proof,omitempty"`
 	Status         *TypedID                       `json:"credentialStatus,omitempty"`
 	Issuer         json.RawMessage                `json:"issuer,omitempty"`
@@ -569,11 +646,17 @@ type credentialOpts struct {
 	disabledCustomSchema  bool
 	schemaLoader          *CredentialSchemaLoader
 	modelValidationMode   vcModelValidationMode
+	subjectSchemaCheck    bool
 	allowedCustomContexts map[string]bool
 	allowedCustomTypes    map[string]bool
 	disabledProofCheck    bool
 	strictValidation      bool
+	strictJSONLD          bool
+	validityClock         clock.Clock
 	ldpSuites             []verifier.SignatureSuite
+	suiteRegistry         verifier.SuiteRegistry
+	minValidProofs        int
+	proofResults          *[]verifier.ProofResult
 
 	jsonldCredentialOpts
 }
@@ -596,6 +679,16 @@ func WithNoCustomSchemaCheck() CredentialOpt {
 	}
 }
 
+// WithCredentialSchemaValidation option enables validation of credentialSubject against the credential schemas
+// of JsonSchema type. The schemas are fetched using the credential schema loader (see WithCredentialSchemaLoader).
+// It does not change the validation of the whole credential: JsonSchemaValidator2018 schemas still validate it
+// unless WithNoCustomSchemaCheck is set.
+func WithCredentialSchemaValidation() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.subjectSchemaCheck = true
+	}
+}
+
 // WithPublicKeyFetcher set public key fetcher used when decoding from JWS.
 func WithPublicKeyFetcher(fetcher PublicKeyFetcher) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -668,6 +761,25 @@ func WithStrictValidation() CredentialOpt {
 	}
 }
 
+// WithStrictJSONLD option enables the check that every property and type of VC is defined by its JSON-LD
+// contexts, so no claim is silently dropped on JSON-LD processing (e.g. when verifying linked data proofs).
+// The contexts are loaded using the JSON-LD document loader of VC (see WithJSONLDDocumentLoader).
+// The check is made regardless of the VC model validation mode.
+func WithStrictJSONLD() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.strictJSONLD = true
+	}
+}
+
+// WithValidityPeriodCheck option enables the check of the validity period of VC: the current time read from
+// the clock (clock.SystemClock if nil) must not be before its issuanceDate (validFrom of VC 2.0 credentials,
+// "nbf" of JWT credentials) nor after its expirationDate (validUntil, "exp").
+func WithValidityPeriodCheck(c clock.Clock) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.validityClock = clock.OrSystem(c)
+	}
+}
+
 // WithExternalJSONLDContext defines external JSON-LD contexts to be used in JSON-LD validation and
 // Linked Data Signatures verification.
 func WithExternalJSONLDContext(context ...string) CredentialOpt {
@@ -691,6 +803,32 @@ func WithEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) CredentialOp
 	}
 }
 
+// WithSignatureSuiteRegistry defines the registry of the suites which are used to check embedded linked data
+// proof of VC. The suites are looked up by the type and cryptosuite of the proof, this allows checking proofs
+// of custom signature suites.
+func WithSignatureSuiteRegistry(registry verifier.SuiteRegistry) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.suiteRegistry = registry
+	}
+}
+
+// WithMinValidProofs defines that at least n of the embedded linked data proofs of VC (proof set and/or
+// proof chain) must be valid. By default, all proofs must be valid.
+func WithMinValidProofs(n int) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.minValidProofs = n
+	}
+}
+
+// WithProofResults collects the verification result of each embedded linked data proof of VC into results:
+// its type, verification method and the reason why it is invalid, if so. The results are collected whether
+// the proof check succeeds or not, telling e.g. which proof of a proof set failed the check.
+func WithProofResults(results *[]verifier.ProofResult) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofResults = results
+	}
+}
+
 // parseIssuer parses raw issuer.
 //
 // Issuer can be defined by:
@@ -812,9 +950,46 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		return nil, err
 	}
 
+	if vcOpts.strictJSONLD {
+		err = checkUndefinedJSONLDTerms(vcDataDecoded, &vcOpts.jsonldCredentialOpts)
+		if err != nil {
+			return nil, fmt.Errorf("strict JSON-LD check: %w", err)
+		}
+	}
+
+	if vcOpts.validityClock != nil {
+		err = checkValidityPeriod(vc, vcOpts.validityClock.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.subjectSchemaCheck {
+		err = validateSubjectUsingCredentialSchemas(vcDataDecoded, vc.Schemas, vcOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if vcStr := string(vcData); jwt.IsJWS(vcStr) || jwt.IsJWTUnsecured(vcStr) {
+		vc.JWT = vcStr
+	}
+
 	return vc, nil
 }
 
+func checkValidityPeriod(vc *Credential, now time.Time) error {
+	if vc.Issued != nil && now.Before(vc.Issued.Time) {
+		return fmt.Errorf("credential is not valid before %s", vc.Issued.UTC().Format(time.RFC3339))
+	}
+
+	if vc.Expired != nil && !now.Before(vc.Expired.Time) {
+		return fmt.Errorf("credential expired at %s", vc.Expired.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
@@ -849,7 +1024,7 @@ func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts
 		return errors.New("violated type constraint: not base only type defined")
 	}
 
-	if len(vc.Context) > 1 || vc.Context[0] != baseContext {
+	if len(vc.Context) > 1 || (vc.Context[0] != baseContext && vc.Context[0] != ContextV2URI) {
 		return errors.New("violated @context constraint: not base only @context defined")
 	}
 
@@ -963,6 +1138,16 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		return nil, fmt.Errorf("fill credential subject from raw: %w", err)
 	}
 
+	issued, expired := raw.Issued, raw.Expired
+
+	if issued == nil {
+		issued = raw.ValidFrom
+	}
+
+	if expired == nil {
+		expired = raw.ValidUntil
+	}
+
 	return &Credential{
 		Context:        context,
 		CustomContext:  customContext,
@@ -970,8 +1155,8 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Types:          types,
 		Subject:        subjects,
 		Issuer:         issuer,
-		Issued:         raw.Issued,
-		Expired:        raw.Expired,
+		Issued:         issued,
+		Expired:        expired,
 		Proofs:         proofs,
 		Status:         raw.Status,
 		Schemas:        schemas,
@@ -1038,6 +1223,9 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 		publicKeyFetcher:     vcOpts.publicKeyFetcher,
 		disabledProofCheck:   vcOpts.disabledProofCheck,
 		ldpSuites:            vcOpts.ldpSuites,
+		suiteRegistry:        vcOpts.suiteRegistry,
+		minValidProofs:       vcOpts.minValidProofs,
+		proofResults:         vcOpts.proofResults,
 		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
 	}
 }
@@ -1209,7 +1397,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	cache := loader.cache
 
 	if cache == nil {
-		return loadJSONSchema(url, loader.schemaDownloadClient)
+		return loader.fetch(url)
 	}
 
 	// Check the cache first.
@@ -1217,7 +1405,7 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 		return cachedBytes, nil
 	}
 
-	schemaBytes, err := loadJSONSchema(url, loader.schemaDownloadClient)
+	schemaBytes, err := loader.fetch(url)
 	if err != nil {
 		return nil, err
 	}
@@ -1228,6 +1416,14 @@ func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
 	return schemaBytes, nil
 }
 
+func (l *CredentialSchemaLoader) fetch(url string) ([]byte, error) {
+	if l.schemaFetcher != nil {
+		return l.schemaFetcher(url)
+	}
+
+	return loadJSONSchema(url, l.schemaDownloadClient)
+}
+
 func loadJSONSchema(url string, client *http.Client) ([]byte, error) {
 	resp, err := client.Get(url)
 	if err != nil {
@@ -1328,9 +1524,14 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		return nil, err
 	}
 
-	rawTermsOfUse, err := typedIDsToRaw(vc.TermsOfUse)
-	if err != nil {
-		return nil, err
+	// termsOfUse is always serialized as an array, so a single entry given in an array is not turned into an object.
+	var rawTermsOfUse json.RawMessage
+
+	if len(vc.TermsOfUse) > 0 {
+		rawTermsOfUse, err = json.Marshal(vc.TermsOfUse)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	proof, err := proofsToRaw(vc.Proofs)
@@ -1365,14 +1566,23 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		Evidence:       vc.Evidence,
 		RefreshService: rawRefreshService,
 		TermsOfUse:     rawTermsOfUse,
-		Issued:         vc.Issued,
-		Expired:        vc.Expired,
 		CustomFields:   vc.CustomFields,
 	}
 
+	if isContextV2(vc.Context) {
+		r.ValidFrom, r.ValidUntil = vc.Issued, vc.Expired
+	} else {
+		r.Issued, r.Expired = vc.Issued, vc.Expired
+	}
+
 	return r, nil
 }
 
+// isContextV2 checks if the base context is the one of VC Data Model 2.0.
+func isContextV2(context []string) bool {
+	return len(context) > 0 && context[0] == ContextV2URI
+}
+
 func typesToRaw(types []string) interface{} {
 	if len(types) == 1 {
 		// as string
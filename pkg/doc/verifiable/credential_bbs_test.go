@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"crypto/sha256"
+	_ "embed"
 	"encoding/base64"
 	"encoding/json"
 	"testing"
@@ -22,6 +23,16 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
+//nolint:gochecknoglobals
+var (
+	//go:embed testdata/nested_credential.jsonld
+	nestedCredential string
+	//go:embed testdata/nested_reveal_doc.jsonld
+	nestedRevealDoc string
+	//go:embed testdata/array_element_reveal_doc.jsonld
+	arrayElementRevealDoc string
+)
+
 //nolint:lll
 func TestCredential_GenerateBBSSelectiveDisclosure(t *testing.T) {
 	s := "uBlesrb_p6VIl-DrJ4Kj7DJ2S45uDqq6cJSgwdw_tVXWazl1XnjQxKsIzrY1RqffBqqT1oFTPi5Nwb_3IGMTWvXeGU7xwZOP8K1jybjknN0ADhp3i8JjTDeuUWH_sixv8ydcx4Qpqq-mMOX7nEm7Dg"
@@ -196,6 +207,71 @@ func TestCredential_GenerateBBSSelectiveDisclosure(t *testing.T) {
 	})
 }
 
+func TestCredential_GenerateBBSSelectiveDisclosure_Nested(t *testing.T) {
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(nestedCredential))
+	require.NoError(t, err)
+
+	signVCWithBBS(t, privKey, pubKeyBytes, vc)
+
+	nonce := []byte("nonce")
+
+	vcOptions := []CredentialOpt{
+		WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")),
+	}
+
+	deriveAndVerify := func(t *testing.T, revealJSON string) string {
+		t.Helper()
+
+		revealDoc, err := toMap(revealJSON)
+		require.NoError(t, err)
+
+		vcWithSelectiveDisclosure, err := vc.GenerateBBSSelectiveDisclosure(revealDoc, nonce, vcOptions...)
+		require.NoError(t, err)
+		require.Len(t, vcWithSelectiveDisclosure.Proofs, 1)
+
+		vcSelectiveDisclosureBytes, err := json.Marshal(vcWithSelectiveDisclosure)
+		require.NoError(t, err)
+
+		sigSuite := bbsblssignatureproof2020.New(
+			suite.WithCompactProof(),
+			suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce)))
+
+		_, err = parseTestCredential(t, vcSelectiveDisclosureBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")))
+		require.NoError(t, err)
+
+		return string(vcSelectiveDisclosureBytes)
+	}
+
+	t.Run("reveal field of nested object", func(t *testing.T) {
+		vcSelectiveDisclosure := deriveAndVerify(t, nestedRevealDoc)
+
+		require.Contains(t, vcSelectiveDisclosure, "BachelorDegree")
+		require.NotContains(t, vcSelectiveDisclosure, "Bachelor of Science and Arts")
+		require.NotContains(t, vcSelectiveDisclosure, "Example University")
+		require.NotContains(t, vcSelectiveDisclosure, "Jayden")
+		require.NotContains(t, vcSelectiveDisclosure, "Master of Computer Science")
+	})
+
+	t.Run("reveal one element of array", func(t *testing.T) {
+		vcSelectiveDisclosure := deriveAndVerify(t, arrayElementRevealDoc)
+
+		require.Contains(t, vcSelectiveDisclosure, "MasterDegree")
+		require.Contains(t, vcSelectiveDisclosure, "Master of Computer Science")
+		require.NotContains(t, vcSelectiveDisclosure, "Another Example University")
+		require.NotContains(t, vcSelectiveDisclosure, "BachelorDegree")
+		require.NotContains(t, vcSelectiveDisclosure, "Bachelor of Science and Arts")
+	})
+}
+
 func signVCWithBBS(t *testing.T, privKey *bbs12381g2pub.PrivateKey, pubKeyBytes []byte, vc *Credential) {
 	t.Helper()
 
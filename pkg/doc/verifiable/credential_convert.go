@@ -0,0 +1,52 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+)
+
+// ToJWT converts the Verifiable Credential into a JWT VC signed by the signer. The "iss", "sub", "nbf", "jti"
+// and "exp" claims are set from the credential and the rest of the credential is put into the "vc" claim.
+// The linked data proofs of the credential are dropped, the returned credential is secured by the JWS only.
+func (vc *Credential) ToJWT(signer Signer, signatureAlg JWSAlgorithm, keyID string) (*Credential, error) {
+	vcCopy := vc.withoutProof()
+
+	claims, err := vcCopy.JWTClaims(true)
+	if err != nil {
+		return nil, fmt.Errorf("convert VC to JWT: %w", err)
+	}
+
+	vcCopy.JWT, err = claims.MarshalJWS(signatureAlg, signer, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("convert VC to JWT: %w", err)
+	}
+
+	return vcCopy, nil
+}
+
+// ToLD converts the Verifiable Credential into a JSON-LD VC secured by a linked data proof created with the
+// signature suite of the context. The JWT the credential was parsed from, if any, and its previous linked data
+// proofs are dropped.
+func (vc *Credential) ToLD(context *LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) (*Credential, error) {
+	vcCopy := vc.withoutProof()
+
+	if err := vcCopy.AddLinkedDataProof(context, jsonldOpts...); err != nil {
+		return nil, fmt.Errorf("convert VC to JSON-LD: %w", err)
+	}
+
+	return vcCopy, nil
+}
+
+func (vc *Credential) withoutProof() *Credential {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+	vcCopy.JWT = ""
+
+	return &vcCopy
+}
@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+func TestCredential_ToJWT(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	t.Run("converts JSON-LD VC into JWT VC", func(t *testing.T) {
+		ldVC, pubKeyFetcher := createVCWithLinkedDataProof(t)
+
+		jwtVC, err := ldVC.ToJWT(signer, EdDSA, "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1")
+		require.NoError(t, err)
+		require.NotEmpty(t, jwtVC.JWT)
+		require.Empty(t, jwtVC.Proofs)
+		require.Len(t, ldVC.Proofs, 1)
+
+		claims, err := unmarshalJWSClaims(jwtVC.JWT, false, nil)
+		require.NoError(t, err)
+		require.Equal(t, ldVC.Issuer.ID, claims.Issuer)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", claims.Subject)
+		require.Equal(t, ldVC.ID, claims.ID)
+		require.Equal(t, ldVC.Issued.Unix(), claims.NotBefore.Time().Unix())
+		require.Equal(t, ldVC.Expired.Unix(), claims.Expiry.Time().Unix())
+		require.NotContains(t, claims.VC, "proof")
+		require.NotContains(t, claims.VC, "id")
+
+		parsedVC, err := ParseCredential([]byte(jwtVC.JWT),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Equal(t, ldVC.Subject, parsedVC.Subject)
+		require.Equal(t, ldVC.Issuer, parsedVC.Issuer)
+
+		// the original credential is not changed.
+		_, err = ParseCredential(ldVC.byteJSON(t), WithPublicKeyFetcher(pubKeyFetcher),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+	})
+
+	t.Run("several subjects", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Subject = []Subject{{ID: "did:example:1"}, {ID: "did:example:2"}}
+
+		_, err = vc.ToJWT(signer, EdDSA, "any")
+		require.EqualError(t, err, "convert VC to JWT: get VC subject id: more than one subject is defined")
+	})
+}
+
+func TestCredential_ToLD(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtVC, err := vc.ToJWT(signer, EdDSA, "any")
+	require.NoError(t, err)
+
+	parsedJWTVC, err := ParseCredential([]byte(jwtVC.JWT),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	t.Run("converts JWT VC into JSON-LD VC", func(t *testing.T) {
+		ldVC, err := parsedJWTVC.ToLD(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Empty(t, ldVC.JWT)
+		require.Len(t, ldVC.Proofs, 1)
+		require.NotEmpty(t, parsedJWTVC.JWT)
+
+		parsedLDVC, err := ParseCredential(ldVC.byteJSON(t),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Equal(t, vc.Subject, parsedLDVC.Subject)
+		require.Equal(t, vc.ID, parsedLDVC.ID)
+		require.Equal(t, vc.Issuer, parsedLDVC.Issuer)
+		require.Equal(t, vc.Issued.Unix(), parsedLDVC.Issued.Unix())
+		require.Equal(t, vc.Expired.Unix(), parsedLDVC.Expired.Unix())
+	})
+
+	t.Run("round trip preserves the credential subject", func(t *testing.T) {
+		ldVC, err := parsedJWTVC.ToLD(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		jwtVC, err := ldVC.ToJWT(signer, EdDSA, "any")
+		require.NoError(t, err)
+
+		roundTripVC, err := ParseCredential([]byte(jwtVC.JWT),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Equal(t, vc.Subject, roundTripVC.Subject)
+	})
+
+	t.Run("unsupported signature type", func(t *testing.T) {
+		_, err := parsedJWTVC.ToLD(&LinkedDataProofContext{
+			SignatureType:           "UnknownSignature",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "convert VC to JSON-LD")
+	})
+}
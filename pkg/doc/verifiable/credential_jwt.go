@@ -20,6 +20,8 @@ const (
 	vcIssuanceDateField   = "issuanceDate"
 	vcIDField             = "id"
 	vcExpirationDateField = "expirationDate"
+	vcValidFromField      = "validFrom"
+	vcValidUntilField     = "validUntil"
 	vcIssuerField         = "issuer"
 	vcIssuerIDField       = "id"
 )
@@ -108,13 +110,19 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	vcMap := jcc.VC
 	claims := jcc.Claims
 
+	issuedField, expiredField := vcIssuanceDateField, vcExpirationDateField
+
+	if vcContext, _, err := decodeContext(vcMap["@context"]); err == nil && isContextV2(vcContext) {
+		issuedField, expiredField = vcValidFromField, vcValidUntilField
+	}
+
 	if iss := claims.Issuer; iss != "" {
 		refineVCIssuerFromJWTClaims(vcMap, iss)
 	}
 
 	if nbf := claims.NotBefore; nbf != nil {
 		nbfTime := nbf.Time().UTC()
-		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
+		vcMap[issuedField] = nbfTime.Format(time.RFC3339)
 	}
 
 	if jti := claims.ID; jti != "" {
@@ -123,12 +131,12 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 
 	if iat := claims.IssuedAt; iat != nil {
 		iatTime := iat.Time().UTC()
-		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
+		vcMap[issuedField] = iatTime.Format(time.RFC3339)
 	}
 
 	if exp := claims.Expiry; exp != nil {
 		expTime := exp.Time().UTC()
-		vcMap[vcExpirationDateField] = expTime.Format(time.RFC3339)
+		vcMap[expiredField] = expTime.Format(time.RFC3339)
 	}
 }
 
@@ -7,14 +7,17 @@ package verifiable
 
 import (
 	"crypto/ed25519"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
@@ -57,28 +60,32 @@ func TestParseCredentialFromJWS(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("Decoding credential from JWS", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t,
-			createEdDSAJWS(t, testCred, ed25519Signer, false),
-			WithPublicKeyFetcher(ed25519KeyFetcher))
+		vcJWS := createEdDSAJWS(t, testCred, ed25519Signer, false)
+
+		vcFromJWT, err := parseTestCredential(t, vcJWS, WithPublicKeyFetcher(ed25519KeyFetcher))
 
 		require.NoError(t, err)
+		require.Equal(t, string(vcJWS), vcFromJWT.JWT)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
+		vc.JWT = vcFromJWT.JWT
 		require.Equal(t, vc, vcFromJWT)
 	})
 
 	t.Run("Decoding credential from JWS with minimized fields of \"vc\" claim", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t,
-			createEdDSAJWS(t, testCred, ed25519Signer, true),
-			WithPublicKeyFetcher(ed25519KeyFetcher))
+		vcJWS := createEdDSAJWS(t, testCred, ed25519Signer, true)
+
+		vcFromJWT, err := parseTestCredential(t, vcJWS, WithPublicKeyFetcher(ed25519KeyFetcher))
 
 		require.NoError(t, err)
+		require.Equal(t, string(vcJWS), vcFromJWT.JWT)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
+		vc.JWT = vcFromJWT.JWT
 		require.Equal(t, vc, vcFromJWT)
 	})
 
@@ -138,33 +145,155 @@ func TestParseCredentialFromJWS_EdDSA(t *testing.T) {
 		vcJWSStr,
 		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
 	require.NoError(t, err)
+	require.Equal(t, string(vcJWSStr), vcFromJWS.JWT)
 
 	// unmarshalled credential must be the same as original one
+	vc.JWT = vcFromJWS.JWT
 	require.Equal(t, vc, vcFromJWS)
 }
 
+// es256TestCredentialJWT is jwtTestCredential signed with ES256 by es256TestIssuerJWK
+// (kid "did:example:76e12ec712ebc6f1c221ebfeb1f#keys-1").
+const es256TestCredentialJWT = "eyJhbGciOiJFUzI1NiIsImtpZCI6ImRpZDpleGFtcGxlOjc2ZTEyZWM3MTJlYmM2ZjFjMjIxZWJmZWIxZiNrZXlzLTEiLCJ0eXAiOiJKV1QifQ.eyJleHAiOjE1Nzc5MDY2MDQsImlhdCI6MTI2MjM3MzgwNCwiaXNzIjoiZGlkOmV4YW1wbGU6NzZlMTJlYzcxMmViYzZmMWMyMjFlYmZlYjFmIiwibmJmIjoxMjYyMzczODA0LCJzdWIiOiJkaWQ6ZXhhbXBsZTplYmZlYjFmNzEyZWJjNmYxYzI3NmUxMmVjMjEiLCJ2YyI6eyJAY29udGV4dCI6WyJodHRwczovL3d3dy53My5vcmcvMjAxOC9jcmVkZW50aWFscy92MSIsImh0dHBzOi8vd3d3LnczLm9yZy8yMDE4L2NyZWRlbnRpYWxzL2V4YW1wbGVzL3YxIl0sImNyZWRlbnRpYWxTdWJqZWN0Ijp7ImRlZ3JlZSI6eyJ0eXBlIjoiQmFjaGVsb3JEZWdyZWUiLCJ1bml2ZXJzaXR5IjoiTUlUIn0sImlkIjoiZGlkOmV4YW1wbGU6ZWJmZWIxZjcxMmViYzZmMWMyNzZlMTJlYzIxIn0sImlzc3VlciI6eyJuYW1lIjoiRXhhbXBsZSBVbml2ZXJzaXR5In0sInR5cGUiOlsiVmVyaWZpYWJsZUNyZWRlbnRpYWwiLCJVbml2ZXJzaXR5RGVncmVlQ3JlZGVudGlhbCJdfX0.ekTdwQPA6Y_RfxTM0YaQBjFs_RpCXXA3QO5PLH2ZPQrhGrawhDRNGQ7BmlyNe9Xz-Lib0Y6AgMCi8c1-WKrWpg" //nolint:lll
+
+const es256TestIssuerJWK = `{
+  "kty": "EC",
+  "crv": "P-256",
+  "x": "oBolRIpGo0utmnbvxaAlsp6JX5nRyrDm8OIHyL8owNE",
+  "y": "7lqCsCjoAkosNh3QoHlCNBT1txAcxXeaxREItiMq6uE"
+}`
+
+func TestParseCredentialFromJWS_ES256(t *testing.T) {
+	const issuer = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	jwk := &jose.JWK{}
+	require.NoError(t, jwk.UnmarshalJSON([]byte(es256TestIssuerJWK)))
+
+	vm, err := did.NewVerificationMethodFromJWK(issuer+"#keys-1", "JsonWebKey2020", issuer, jwk)
+	require.NoError(t, err)
+
+	resolver := NewVDRKeyResolver(&mockvdr.MockVDRegistry{
+		ResolveValue: &did.Doc{
+			Context:            []string{did.ContextV1},
+			ID:                 issuer,
+			VerificationMethod: []did.VerificationMethod{*vm},
+		},
+	})
+
+	t.Run("decode and verify VC JWT of known issuer", func(t *testing.T) {
+		vcFromJWT, err := parseTestCredential(t, []byte(es256TestCredentialJWT),
+			WithPublicKeyFetcher(resolver.PublicKeyFetcher()))
+		require.NoError(t, err)
+		require.Equal(t, es256TestCredentialJWT, vcFromJWT.JWT)
+
+		vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+		require.NoError(t, err)
+
+		vc.JWT = es256TestCredentialJWT
+		require.Equal(t, vc, vcFromJWT)
+	})
+
+	t.Run("tampered VC JWT", func(t *testing.T) {
+		parts := strings.Split(es256TestCredentialJWT, ".")
+
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+
+		// swap r and s.
+		sig = append(sig[32:], sig[:32]...)
+		tampered := parts[0] + "." + parts[1] + "." + base64.RawURLEncoding.EncodeToString(sig)
+
+		vc, err := parseTestCredential(t, []byte(tampered), WithPublicKeyFetcher(resolver.PublicKeyFetcher()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWS decoding: unmarshal VC JWT claims")
+		require.Nil(t, vc)
+	})
+}
+
+func TestParseCredentialFromJWS_ECDSA(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		alg     JWSAlgorithm
+		pkType  string
+	}{
+		{
+			name:    "ES256",
+			keyType: kms.ECDSAP256TypeIEEEP1363,
+			alg:     ES256,
+			pkType:  "JsonWebKey2020",
+		},
+		{
+			name:    "ES384",
+			keyType: kms.ECDSAP384TypeIEEEP1363,
+			alg:     ES384,
+			pkType:  "JsonWebKey2020",
+		},
+		{
+			name:    "ES256K",
+			keyType: kms.ECDSASecp256k1TypeIEEEP1363,
+			alg:     ES256K,
+			pkType:  "EcdsaSecp256k1VerificationKey2019",
+		},
+	}
+
+	vcBytes := []byte(jwtTestCredential)
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			vc, err := parseTestCredential(t, vcBytes)
+			require.NoError(t, err)
+
+			jwtClaims, err := vc.JWTClaims(true)
+			require.NoError(t, err)
+
+			vcJWS, err := jwtClaims.MarshalJWS(tc.alg, signer, vc.Issuer.ID+"#keys-"+keyID)
+			require.NoError(t, err)
+
+			vcFromJWS, err := parseTestCredential(t, []byte(vcJWS),
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), tc.pkType)))
+			require.NoError(t, err)
+
+			vc.JWT = vcJWS
+			require.Equal(t, vc, vcFromJWS)
+		})
+	}
+}
+
 func TestParseCredentialFromUnsecuredJWT(t *testing.T) {
 	testCred := []byte(jwtTestCredential)
 
 	t.Run("Unsecured JWT decoding with no fields minimization", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, false))
+		vcJWT := createUnsecuredJWT(t, testCred, false)
+
+		vcFromJWT, err := parseTestCredential(t, vcJWT)
 
 		require.NoError(t, err)
+		require.Equal(t, string(vcJWT), vcFromJWT.JWT)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
+		vc.JWT = vcFromJWT.JWT
 		require.Equal(t, vc, vcFromJWT)
 	})
 
 	t.Run("Unsecured JWT decoding with minimized fields", func(t *testing.T) {
-		vcFromJWT, err := parseTestCredential(t, createUnsecuredJWT(t, testCred, true))
+		vcJWT := createUnsecuredJWT(t, testCred, true)
+
+		vcFromJWT, err := parseTestCredential(t, vcJWT)
 
 		require.NoError(t, err)
+		require.Equal(t, string(vcJWT), vcFromJWT.JWT)
 
 		vc, err := parseTestCredential(t, testCred)
 		require.NoError(t, err)
 
+		vc.JWT = vcFromJWT.JWT
 		require.Equal(t, vc, vcFromJWT)
 	})
 }
@@ -6,14 +6,18 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -21,18 +25,24 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	jld "github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/signature"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 )
 
 func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
@@ -68,6 +78,143 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_CreatedAndExpires(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	addProof := func(created, expires time.Time) []byte {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      "did:example:123456#key1",
+			Created:                 &created,
+			Expires:                 &expires,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+		r.Equal(created.Format(time.RFC3339), vc.Proofs[0]["created"])
+		r.Equal(expires.Format(time.RFC3339), vc.Proofs[0]["expires"])
+
+		vcBytes, err := json.Marshal(vc)
+		r.NoError(err)
+
+		return vcBytes
+	}
+
+	t.Run("proof not expired", func(t *testing.T) {
+		created := time.Now().UTC().Truncate(time.Second)
+
+		_, err := parseTestCredential(t, addProof(created, created.Add(time.Hour)),
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.NoError(err)
+	})
+
+	t.Run("proof expired", func(t *testing.T) {
+		created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := parseTestCredential(t, addProof(created, created.Add(time.Hour)),
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		r.Error(err)
+		r.Contains(err.Error(), "proof expired at 2020-01-01T01:00:00Z")
+	})
+}
+
+func TestParseCredentialFromLinkedDataProof_KeyProofPurpose(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	const (
+		issuerDID = "did:example:123456"
+		keyID     = issuerDID + "#key1"
+	)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      keyID,
+		Purpose:                 "assertionMethod",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	vm := did.NewVerificationMethodFromBytes(keyID, "Ed25519VerificationKey2018", issuerDID, signer.PublicKeyBytes())
+
+	parseWithIssuerDoc := func(didDoc *did.Doc) (*Credential, error) {
+		resolver := NewVDRKeyResolver(&mockvdr.MockVDRegistry{ResolveValue: didDoc})
+
+		return parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(resolver.PublicKeyFetcher()))
+	}
+
+	t.Run("key listed under assertionMethod", func(t *testing.T) {
+		vcWithLdp, err := parseWithIssuerDoc(&did.Doc{
+			ID:                 issuerDID,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, vc, vcWithLdp)
+	})
+
+	t.Run("key listed under keyAgreement only", func(t *testing.T) {
+		vcWithLdp, err := parseWithIssuerDoc(&did.Doc{
+			ID:           issuerDID,
+			KeyAgreement: []did.Verification{*did.NewEmbeddedVerification(vm, did.KeyAgreement)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"verification method did:example:123456#key1 is not authorized for proof purpose assertionMethod")
+		require.Nil(t, vcWithLdp)
+	})
+
+	t.Run("key listed under authentication only", func(t *testing.T) {
+		vcWithLdp, err := parseWithIssuerDoc(&did.Doc{
+			ID:                 issuerDID,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"verification method did:example:123456#key1 is not authorized for proof purpose assertionMethod")
+		require.Nil(t, vcWithLdp)
+	})
+
+	t.Run("key not listed under any verification relationship", func(t *testing.T) {
+		vcWithLdp, err := parseWithIssuerDoc(&did.Doc{
+			ID:                 issuerDID,
+			VerificationMethod: []did.VerificationMethod{*vm},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not authorized for proof purpose assertionMethod")
+		require.Nil(t, vcWithLdp)
+	})
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
@@ -449,27 +596,7 @@ func TestExtraContextWithLDP(t *testing.T) {
 	r.NotNil(vcWithLdp)
 }
 
-func TestParseCredentialFromLinkedDataProof_BbsBlsSignature2020(t *testing.T) {
-	r := require.New(t)
-
-	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
-	r.NoError(err)
-
-	bbsSigner, err := newBBSSigner(privKey)
-	r.NoError(err)
-
-	sigSuite := bbsblssignature2020.New(
-		suite.WithSigner(bbsSigner),
-		suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))
-
-	ldpContext := &LinkedDataProofContext{
-		SignatureType:           "BbsBlsSignature2020",
-		SignatureRepresentation: SignatureProofValue,
-		Suite:                   sigSuite,
-		VerificationMethod:      "did:example:123456#key1",
-	}
-
-	vcJSON := `
+const permanentResidentCardCredential = `
 	{
 	 "@context": [
 	   "https://www.w3.org/2018/credentials/v1",
@@ -505,9 +632,29 @@ func TestParseCredentialFromLinkedDataProof_BbsBlsSignature2020(t *testing.T) {
 	   "birthDate": "1958-07-17"
 	 }
 	}
-	`
+`
 
-	vc, err := parseTestCredential(t, []byte(vcJSON))
+func TestParseCredentialFromLinkedDataProof_BbsBlsSignature2020(t *testing.T) {
+	r := require.New(t)
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	r.NoError(err)
+
+	bbsSigner, err := newBBSSigner(privKey)
+	r.NoError(err)
+
+	sigSuite := bbsblssignature2020.New(
+		suite.WithSigner(bbsSigner),
+		suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "BbsBlsSignature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	vc, err := parseTestCredential(t, []byte(permanentResidentCardCredential))
 	r.NoError(err)
 	r.Len(vc.Proofs, 0)
 
@@ -728,6 +875,315 @@ func TestParseCredentialFromLinkedDataProof_EcdsaSecp256k1Signature2019(t *testi
 	r.Equal(vc, vcWithLdp)
 }
 
+const ed25519Signature2020Credential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/security/suites/ed25519-2020/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21"
+  }
+}`
+
+func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(ed25519Signature2020Credential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2020.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, "Ed25519Signature2020", vc.Proofs[0]["type"])
+	require.True(t, strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	// public key as resolved from "publicKeyMultibase" of Ed25519VerificationKey2020 verification method
+	keyFetcher := SingleKey(append([]byte{0xed, 0x01}, signer.PublicKeyBytes()...), "Ed25519VerificationKey2020")
+
+	t.Run("verify with default suites", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(keyFetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("verify with raw public key", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519VerificationKey2018")))
+		require.NoError(t, err)
+	})
+
+	t.Run("tampered credential", func(t *testing.T) {
+		tamperedBytes := []byte(strings.Replace(string(vcBytes), "ebfeb1f712ebc6f1c276e12ec21", "tampered", 1))
+
+		_, err := parseTestCredential(t, tamperedBytes, WithPublicKeyFetcher(keyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+	})
+}
+
+const dataIntegrityCredential = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "validFrom": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "university": "MIT"
+    }
+  }
+}`
+
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProof(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := eddsardfc2022.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier()))
+
+	// Multikey as resolved from "publicKeyMultibase" of DID verification method.
+	multikeyFetcher := SingleKey(append([]byte{0xed, 0x01}, signer.PublicKeyBytes()...), "Multikey")
+
+	addProof := func(t *testing.T, purpose string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(dataIntegrityCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+			Purpose:                 purpose,
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "DataIntegrityProof", vc.Proofs[0]["type"])
+		require.Equal(t, "eddsa-rdfc-2022", vc.Proofs[0]["cryptosuite"])
+		require.True(t, strings.HasPrefix(vc.Proofs[0]["proofValue"].(string), "z"))
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	vcBytes := addProof(t, "")
+
+	t.Run("verify with default suites", func(t *testing.T) {
+		vc, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(multikeyFetcher))
+		require.NoError(t, err)
+		require.Equal(t, "assertionMethod", vc.Proofs[0]["proofPurpose"])
+	})
+
+	t.Run("verify with raw public key", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519VerificationKey2020")))
+		require.NoError(t, err)
+	})
+
+	t.Run("tampered credential", func(t *testing.T) {
+		tamperedBytes := []byte(strings.Replace(string(vcBytes), "MIT", "Harvard", 1))
+
+		_, err := parseTestCredential(t, tamperedBytes, WithPublicKeyFetcher(multikeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+	})
+
+	t.Run("unexpected proof purpose", func(t *testing.T) {
+		_, err := parseTestCredential(t, addProof(t, "authentication"), WithPublicKeyFetcher(multikeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `proof purpose "authentication" does not match expected "assertionMethod"`)
+
+		_, err = parseTestCredential(t, addProof(t, "authentication"),
+			WithEmbeddedSignatureSuites(eddsardfc2022.New(
+				suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier()),
+				suite.WithExpectedProofPurpose("authentication"))),
+			WithPublicKeyFetcher(multikeyFetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported cryptosuite", func(t *testing.T) {
+		unsupportedBytes := []byte(strings.Replace(string(vcBytes), "eddsa-rdfc-2022", "ecdsa-sd-2023", 1))
+
+		_, err := parseTestCredential(t, unsupportedBytes, WithPublicKeyFetcher(multikeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported cryptosuite: ecdsa-sd-2023")
+
+		_, err = parseTestCredential(t, unsupportedBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(multikeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signature type DataIntegrityProof with cryptosuite ecdsa-sd-2023 not supported")
+	})
+}
+
+func TestParseCredentialFromLinkedDataProof_DataIntegrityProofECDSA(t *testing.T) {
+	addProof := func(t *testing.T, sigSuite *ecdsardfc2019.Suite) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(dataIntegrityCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "DataIntegrityProof",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "ecdsa-rdfc-2019", vc.Proofs[0]["cryptosuite"])
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	// Multikey as resolved from "publicKeyMultibase" of DID verification method.
+	multikey := func(t *testing.T, signer signature.Signer, multicodecHeader []byte) []byte {
+		t.Helper()
+
+		pubKey, ok := signer.PublicKey().(*ecdsa.PublicKey)
+		require.True(t, ok)
+
+		return append(multicodecHeader, elliptic.MarshalCompressed(pubKey.Curve, pubKey.X, pubKey.Y)...)
+	}
+
+	tests := []struct {
+		name             string
+		keyType          kms.KeyType
+		multicodecHeader []byte
+		newSuite         func(opts ...suite.Opt) *ecdsardfc2019.Suite
+	}{
+		{
+			name:             "P-256",
+			keyType:          kms.ECDSAP256TypeIEEEP1363,
+			multicodecHeader: []byte{0x80, 0x24},
+			newSuite:         ecdsardfc2019.New,
+		},
+		{
+			name:             "P-384",
+			keyType:          kms.ECDSAP384TypeIEEEP1363,
+			multicodecHeader: []byte{0x81, 0x24},
+			newSuite:         ecdsardfc2019.NewP384,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			vcBytes := addProof(t, tc.newSuite(suite.WithSigner(signer)))
+
+			// default suites
+			_, err = parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(SingleKey(multikey(t, signer, tc.multicodecHeader), "Multikey")))
+			require.NoError(t, err)
+
+			_, err = parseTestCredential(t, []byte(strings.Replace(string(vcBytes), "MIT", "Harvard", 1)),
+				WithPublicKeyFetcher(SingleKey(multikey(t, signer, tc.multicodecHeader), "Multikey")))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "ecdsa: invalid signature")
+		})
+	}
+
+	t.Run("P-384 key is rejected by SHA-256 suite", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ECDSAP384TypeIEEEP1363)
+		require.NoError(t, err)
+
+		vcBytes := addProof(t, ecdsardfc2019.NewP384(suite.WithSigner(signer)))
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ecdsardfc2019.New(suite.WithVerifier(ecdsardfc2019.NewPublicKeyVerifier()))),
+			WithPublicKeyFetcher(SingleKey(multikey(t, signer, []byte{0x81, 0x24}), "Multikey")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "P-384 public key cannot be used with SHA-256 digest of P-256 suite")
+	})
+}
+
+//nolint:lll
+func TestParseCredentialFromLinkedDataProof_EcdsaSecp256k1Signature2019_ExternalCredential(t *testing.T) {
+	// Credential signed by an external implementation of EcdsaSecp256k1Signature2019.
+	vcJSON := `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "givenName": "John"
+  },
+  "proof": {
+    "type": "EcdsaSecp256k1Signature2019",
+    "created": "2021-08-25T10:00:00Z",
+    "verificationMethod": "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+    "proofPurpose": "assertionMethod",
+    "jws": "eyJhbGciOiJFUzI1NksiLCJiNjQiOmZhbHNlLCJjcml0IjpbImI2NCJdfQ.._R-yOIrAOAIA_PwxXeLVA7ftjcpY_Ad3w0iNPQ8qmK5wFks-ZJe6zgx4Htb7zyHAIpvZKLgQwIrFr4BS_5jqLw"
+  }
+}`
+
+	pubKeyBytes, err := hex.DecodeString("0428e4b07b59ef00d777190ee89256b6be7758201ca92bbed7b497fa9528a377376e7039a4fe623c852e88b316afd05c70127285e23f66900e709558a780b8fdf7")
+	require.NoError(t, err)
+
+	sigSuite := ecdsasecp256k1signature2019.New(
+		suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier()))
+
+	pubKeyFetcher := SingleKey(pubKeyBytes, "EcdsaSecp256k1VerificationKey2019")
+
+	t.Run("valid signature", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(vcJSON),
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(pubKeyFetcher))
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, "EcdsaSecp256k1Signature2019", vc.Proofs[0]["type"])
+	})
+
+	t.Run("tampered credential", func(t *testing.T) {
+		tamperedJSON := strings.Replace(vcJSON, `"givenName": "John"`, `"givenName": "Jane"`, 1)
+
+		_, err := parseTestCredential(t, []byte(tamperedJSON),
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(pubKeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+	})
+}
+
 //nolint:lll
 func TestParseCredential_JSONLiteralsNotSupported(t *testing.T) {
 	cmtrJSONLD := `
@@ -1100,6 +1556,205 @@ func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialWithLinkedDataProofSet(t *testing.T) {
+	ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ed25519SigSuite := ed25519signature2018.New(
+		suite.WithSigner(ed25519Signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	bbsPubKey, bbsPrivKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	bbsPubKeyBytes, err := bbsPubKey.Marshal()
+	require.NoError(t, err)
+
+	bbsSigner, err := newBBSSigner(bbsPrivKey)
+	require.NoError(t, err)
+
+	bbsSigSuite := bbsblssignature2020.New(
+		suite.WithSigner(bbsSigner),
+		suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(permanentResidentCardCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519SigSuite,
+		VerificationMethod:      "did:example:489398593#key1",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "BbsBlsSignature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   bbsSigSuite,
+		VerificationMethod:      "did:example:489398593#key2",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+	require.Len(t, vc.Proofs, 2)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	keys := map[string]*sigverifier.PublicKey{
+		"#key1": {Type: "Ed25519Signature2018", Value: ed25519Signer.PublicKeyBytes()},
+		"#key2": {Type: "Bls12381G2Key2020", Value: bbsPubKeyBytes},
+	}
+
+	fetcher := func(revoked string) PublicKeyFetcher {
+		return func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+			if pubKey, ok := keys[keyID]; ok && keyID != revoked {
+				return pubKey, nil
+			}
+
+			return nil, fmt.Errorf("public key %s not found", keyID)
+		}
+	}
+
+	t.Run("all proofs of the set are valid", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("")))
+		require.NoError(t, err)
+		require.Equal(t, vc, vcWithLdp)
+	})
+
+	t.Run("all proofs of the set must be valid by default", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("#key2")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key #key2 not found")
+	})
+
+	t.Run("at least N of M proofs must be valid", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("#key2")),
+			WithMinValidProofs(1))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("#key1")),
+			WithMinValidProofs(2))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "1 of 2 proofs are valid while at least 2 required")
+	})
+
+	t.Run("proof results pinpoint the invalid proof", func(t *testing.T) {
+		var results []sigverifier.ProofResult
+
+		_, err := parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("#key1")),
+			WithProofResults(&results))
+		require.Error(t, err)
+		require.Len(t, results, 2)
+
+		require.Equal(t, "Ed25519Signature2018", results[0].Type)
+		require.Equal(t, "did:example:489398593#key1", results[0].VerificationMethod)
+		require.Error(t, results[0].Err)
+		require.Contains(t, results[0].Err.Error(), "public key #key1 not found")
+
+		require.Equal(t, "BbsBlsSignature2020", results[1].Type)
+		require.Equal(t, "did:example:489398593#key2", results[1].VerificationMethod)
+		require.NoError(t, results[1].Err)
+
+		// the results are collected when the proof check succeeds too
+		results = nil
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(ed25519SigSuite, bbsSigSuite),
+			WithPublicKeyFetcher(fetcher("#key2")),
+			WithMinValidProofs(1),
+			WithProofResults(&results))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.NoError(t, results[0].Err)
+		require.Error(t, results[1].Err)
+	})
+}
+
+func TestParseCredentialWithLinkedDataProofChain(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := eddsardfc2022.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(dataIntegrityCredential))
+	require.NoError(t, err)
+
+	for _, ldpContext := range []*LinkedDataProofContext{
+		{ProofID: "urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7"},
+		{
+			ProofID:       "urn:uuid:26329423-bec9-4b2e-88cb-a7c7d9dc4544",
+			PreviousProof: []string{"urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7"},
+		},
+	} {
+		ldpContext.SignatureType = "DataIntegrityProof"
+		ldpContext.SignatureRepresentation = SignatureProofValue
+		ldpContext.Suite = sigSuite
+		ldpContext.VerificationMethod = "did:example:76e12ec712ebc6f1c221ebfeb1f#key1"
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+	}
+
+	require.Len(t, vc.Proofs, 2)
+	require.Equal(t, "urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7", vc.Proofs[0]["id"])
+	require.Equal(t, "urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7", vc.Proofs[1]["previousProof"])
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	fetcher := SingleKey(append([]byte{0xed, 0x01}, signer.PublicKeyBytes()...), "Multikey")
+
+	t.Run("valid proof chain", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes, WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, vc, vcWithLdp)
+	})
+
+	t.Run("chained proof secures the previous proof", func(t *testing.T) {
+		// replace proofValue of the previous proof with the one of the chained proof
+		tamperedBytes := []byte(strings.Replace(string(vcBytes),
+			vc.Proofs[0]["proofValue"].(string), vc.Proofs[1]["proofValue"].(string), 1))
+
+		_, err := parseTestCredential(t, tamperedBytes, WithPublicKeyFetcher(fetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "ed25519: invalid signature")
+
+		_, err = parseTestCredential(t, tamperedBytes, WithPublicKeyFetcher(fetcher), WithMinValidProofs(1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "0 of 2 proofs are valid while at least 1 required")
+	})
+
+	t.Run("previous proof is missing", func(t *testing.T) {
+		var raw map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcBytes, &raw))
+
+		proofs, ok := raw["proof"].([]interface{})
+		require.True(t, ok)
+
+		raw["proof"] = proofs[1]
+
+		missingBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, missingBytes, WithPublicKeyFetcher(fetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "previous proof urn:uuid:60102d04-b51e-11ed-acfe-2fcd717666a7 not found")
+	})
+}
+
 func createLocalCrypto() (*LocalCrypto, error) {
 	lKMS, err := createKMS()
 	if err != nil {
@@ -0,0 +1,151 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	credentialSubjectField = "credentialSubject"
+
+	// gojsonschema names the validated document root this way.
+	schemaRootField = "(root)"
+)
+
+// SchemaFieldError describes a field of credentialSubject which does not conform to the credential schema.
+type SchemaFieldError struct {
+	// Field is the path of the field, e.g. "credentialSubject.degree.type".
+	Field string
+
+	// Description describes the violation.
+	Description string
+}
+
+// CredentialSchemaError is returned when credentialSubject does not conform to a credential schema.
+type CredentialSchemaError struct {
+	// SchemaID is the ID (URL) of the credential schema.
+	SchemaID string
+
+	// Fields lists the fields which do not conform to the schema.
+	Fields []SchemaFieldError
+}
+
+func (e *CredentialSchemaError) Error() string {
+	fields := make([]string, len(e.Fields))
+
+	for i, f := range e.Fields {
+		fields[i] = fmt.Sprintf("%s: %s", f.Field, f.Description)
+	}
+
+	return fmt.Sprintf("credentialSubject does not conform to credential schema %s: %s",
+		e.SchemaID, strings.Join(fields, "; "))
+}
+
+// validateSubjectUsingCredentialSchemas validates credentialSubject of the credential against its JsonSchema
+// credential schemas. JsonSchemaValidator2018 schemas validate the whole credential, see getSchemaLoader.
+func validateSubjectUsingCredentialSchemas(vcBytes []byte, schemas []TypedID, opts *credentialOpts) error {
+	var subjects []json.RawMessage
+
+	for _, schema := range schemas {
+		if schema.Type == jsonSchema2018Type {
+			continue
+		}
+
+		if schema.Type != jsonSchemaType {
+			logger.Warnf("unsupported credential schema: %s. Skipping credentialSubject validation", schema.Type)
+
+			continue
+		}
+
+		if subjects == nil {
+			var err error
+
+			subjects, err = credentialSubjects(vcBytes)
+			if err != nil {
+				return err
+			}
+		}
+
+		schemaBytes, err := getJSONSchema(schema.ID, opts)
+		if err != nil {
+			return fmt.Errorf("load of credential schema from %s: %w", schema.ID, err)
+		}
+
+		compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schemaBytes))
+		if err != nil {
+			return fmt.Errorf("compile credential schema from %s: %w", schema.ID, err)
+		}
+
+		if err := validateSubjects(compiled, schema.ID, subjects); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateSubjects(schema *gojsonschema.Schema, schemaID string, subjects []json.RawMessage) error {
+	schemaErr := &CredentialSchemaError{SchemaID: schemaID}
+
+	for i, subject := range subjects {
+		result, err := schema.Validate(gojsonschema.NewBytesLoader(subject))
+		if err != nil {
+			return fmt.Errorf("validate credentialSubject against credential schema %s: %w", schemaID, err)
+		}
+
+		prefix := credentialSubjectField
+		if len(subjects) > 1 {
+			prefix = fmt.Sprintf("%s.%d", credentialSubjectField, i)
+		}
+
+		for _, resultErr := range result.Errors() {
+			field := prefix
+			if f := resultErr.Field(); f != schemaRootField {
+				field += "." + f
+			}
+
+			schemaErr.Fields = append(schemaErr.Fields, SchemaFieldError{
+				Field:       field,
+				Description: resultErr.Description(),
+			})
+		}
+	}
+
+	if len(schemaErr.Fields) > 0 {
+		return schemaErr
+	}
+
+	return nil
+}
+
+// credentialSubjects extracts the subjects of the credential as JSON documents.
+func credentialSubjects(vcBytes []byte) ([]json.RawMessage, error) {
+	var vcMap map[string]json.RawMessage
+
+	if err := json.Unmarshal(vcBytes, &vcMap); err != nil {
+		return nil, fmt.Errorf("unmarshal credential: %w", err)
+	}
+
+	subject, ok := vcMap[credentialSubjectField]
+	if !ok {
+		return nil, fmt.Errorf("credential has no %s", credentialSubjectField)
+	}
+
+	var subjects []json.RawMessage
+
+	if err := json.Unmarshal(subject, &subjects); err != nil {
+		// single subject
+		return []json.RawMessage{subject}, nil
+	}
+
+	return subjects, nil
+}
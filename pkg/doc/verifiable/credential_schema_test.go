@@ -0,0 +1,224 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	degreeSchemaURL = "https://example.com/schemas/degree.json"
+
+	degreeSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["id", "degree"],
+  "properties": {
+    "id": {
+      "type": "string"
+    },
+    "degree": {
+      "type": "object",
+      "required": ["type", "name"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "enum": ["BachelorDegree", "MasterDegree"]
+        },
+        "name": {
+          "type": "string"
+        }
+      }
+    }
+  }
+}`
+)
+
+func TestParseCredential_CredentialSchemaValidation(t *testing.T) {
+	fetcher := func(url string) ([]byte, error) {
+		require.Equal(t, degreeSchemaURL, url)
+
+		return []byte(degreeSchema), nil
+	}
+
+	parse := func(vcBytes []byte, f SchemaFetcher) (*Credential, error) {
+		return parseTestCredential(t, vcBytes, WithCredentialSchemaValidation(),
+			WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().SetSchemaFetcher(f).Build()))
+	}
+
+	t.Run("valid credentialSubject", func(t *testing.T) {
+		vc, err := parse(newSchemaTestCredential(t, jsonSchemaType, singleCredentialSubject), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, jsonSchemaType, vc.Schemas[0].Type)
+	})
+
+	t.Run("JsonSchemaValidator2018 schema still validates the whole credential", func(t *testing.T) {
+		vcBytes := newSchemaTestCredential(t, jsonSchema2018Type, singleCredentialSubject)
+
+		// the subject schema does not match the credential, with and without the option
+		_, err := parse(vcBytes, fetcher)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verifiable credential is not valid")
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().SetSchemaFetcher(fetcher).Build()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verifiable credential is not valid")
+
+		// the credential is not validated against its 2018 schema if custom schemas are disabled
+		_, err = parseTestCredential(t, vcBytes, WithCredentialSchemaValidation(), WithNoCustomSchemaCheck(),
+			WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().SetSchemaFetcher(fetcher).Build()))
+		require.NoError(t, err)
+
+		// a credential schema of the whole credential is not applied to credentialSubject
+		_, err = parse(vcBytes, func(string) ([]byte, error) {
+			return []byte(DefaultSchema), nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid credentialSubject", func(t *testing.T) {
+		subject := `{
+  "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+  "degree": {
+    "type": "DoctorDegree"
+  }
+}`
+
+		_, err := parse(newSchemaTestCredential(t, jsonSchemaType, subject), fetcher)
+		require.Error(t, err)
+
+		var schemaErr *CredentialSchemaError
+
+		require.True(t, errors.As(err, &schemaErr))
+		require.Equal(t, degreeSchemaURL, schemaErr.SchemaID)
+		require.Len(t, schemaErr.Fields, 2)
+
+		fields := map[string]bool{}
+		for _, f := range schemaErr.Fields {
+			fields[f.Field] = true
+		}
+
+		require.True(t, fields["credentialSubject.degree"])
+		require.True(t, fields["credentialSubject.degree.type"])
+		require.Contains(t, err.Error(), "credentialSubject does not conform to credential schema "+degreeSchemaURL)
+	})
+
+	t.Run("invalid subject of multiple subjects", func(t *testing.T) {
+		subjects := `[{
+  "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+  "degree": {
+    "type": "BachelorDegree",
+    "name": "Bachelor of Science and Arts"
+  }
+}, {
+  "id": "did:example:c276e12ec21ebfeb1f712ebc6f1"
+}]`
+
+		_, err := parse(newSchemaTestCredential(t, jsonSchemaType, subjects), fetcher)
+		require.Error(t, err)
+
+		var schemaErr *CredentialSchemaError
+
+		require.True(t, errors.As(err, &schemaErr))
+		require.Len(t, schemaErr.Fields, 1)
+		require.Equal(t, "credentialSubject.1", schemaErr.Fields[0].Field)
+		require.Equal(t, "degree is required", schemaErr.Fields[0].Description)
+	})
+
+	t.Run("validation is opt-in", func(t *testing.T) {
+		_, err := parseTestCredential(t, newSchemaTestCredential(t, jsonSchemaType, `{"id": "did:example:123"}`),
+			WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().SetSchemaFetcher(fetcher).Build()))
+		require.NoError(t, err)
+	})
+
+	t.Run("unsupported schema type is skipped", func(t *testing.T) {
+		_, err := parse(newSchemaTestCredential(t, "ZkpExampleSchema2018", `{"id": "did:example:123"}`),
+			func(string) ([]byte, error) {
+				return nil, errors.New("must not be called")
+			})
+		require.NoError(t, err)
+	})
+
+	t.Run("schema fetch error", func(t *testing.T) {
+		_, err := parse(newSchemaTestCredential(t, jsonSchemaType, singleCredentialSubject),
+			func(string) ([]byte, error) {
+				return nil, errors.New("fetch error")
+			})
+		require.EqualError(t, err, "load of credential schema from "+degreeSchemaURL+": fetch error")
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		_, err := parse(newSchemaTestCredential(t, jsonSchemaType, singleCredentialSubject),
+			func(string) ([]byte, error) {
+				return []byte(`{"type": 1}`), nil
+			})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "compile credential schema from "+degreeSchemaURL)
+	})
+
+	t.Run("schema is fetched once when cache is defined", func(t *testing.T) {
+		fetches := 0
+
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			fetches++
+
+			_, err := res.Write([]byte(degreeSchema))
+			require.NoError(t, err)
+		}))
+		defer testServer.Close()
+
+		loader := NewCredentialSchemaLoaderBuilder().
+			SetCache(NewExpirableSchemaCache(32*1024*1024, time.Hour)).
+			Build()
+
+		var raw rawCredential
+
+		require.NoError(t, json.Unmarshal(newSchemaTestCredential(t, jsonSchemaType, singleCredentialSubject), &raw))
+		raw.Schema = &TypedID{ID: testServer.URL, Type: jsonSchemaType}
+
+		vcBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = parseTestCredential(t, vcBytes, WithCredentialSchemaValidation(), WithCredentialSchemaLoader(loader))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 1, fetches)
+	})
+}
+
+func newSchemaTestCredential(t *testing.T, schemaType, subject string) []byte {
+	t.Helper()
+
+	var raw rawCredential
+
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
+
+	contexts, ok := raw.Context.([]interface{})
+	require.True(t, ok)
+
+	// JsonSchema type is defined by VC 2.0 context only.
+	raw.Context = append(contexts, map[string]interface{}{
+		jsonSchemaType: "https://www.w3.org/2018/credentials#JsonSchema",
+	})
+	raw.Subject = json.RawMessage(subject)
+	raw.Schema = &TypedID{ID: degreeSchemaURL, Type: schemaType}
+
+	vcBytes, err := json.Marshal(raw)
+	require.NoError(t, err)
+
+	return vcBytes
+}
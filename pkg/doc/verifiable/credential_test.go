@@ -21,7 +21,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 )
 
 const singleCredentialSubject = `
@@ -521,6 +523,124 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 	}
 }
 
+func TestParseCredentialV2(t *testing.T) {
+	const v2Credential = `{
+  "@context": [
+    "https://www.w3.org/ns/credentials/v2"
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "validFrom": "2010-01-01T19:23:24Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "university": "MIT"
+    }
+  }
+}`
+
+	t.Run("credential with only validFrom", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(v2Credential))
+		require.NoError(t, err)
+		require.Equal(t, ContextV2URI, vc.Context[0])
+		require.NotNil(t, vc.Issued)
+		require.Equal(t, time.Date(2010, 1, 1, 19, 23, 24, 0, time.UTC), vc.Issued.Time)
+		require.Nil(t, vc.Expired)
+		require.NotContains(t, vc.CustomFields, "validFrom")
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+		require.Equal(t, "2010-01-01T19:23:24Z", vcMap["validFrom"])
+		require.NotContains(t, vcMap, "issuanceDate")
+		require.NotContains(t, vcMap, "expirationDate")
+		require.NotContains(t, vcMap, "validUntil")
+
+		vcCopy, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, vc, vcCopy)
+	})
+
+	t.Run("credential with validUntil", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		vcMap["validUntil"] = "2030-01-01T19:23:24Z"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.NotNil(t, vc.Expired)
+		require.Equal(t, time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC), vc.Expired.Time)
+
+		vcBytes, err = vc.MarshalJSON()
+		require.NoError(t, err)
+		require.Contains(t, string(vcBytes), `"validUntil":"2030-01-01T19:23:24Z"`)
+		require.NotContains(t, string(vcBytes), "expirationDate")
+	})
+
+	t.Run("credential without dates", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		delete(vcMap, "validFrom")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Nil(t, vc.Issued)
+	})
+
+	t.Run("invalid validFrom", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(v2Credential), &vcMap))
+		vcMap["validFrom"] = "not a valid date time"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		err = validateCredentialUsingJSONSchema(vcBytes, nil, &credentialOpts{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "validFrom: Does not match format 'date-time'")
+	})
+
+	t.Run("JWT claims use validFrom and validUntil", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(v2Credential))
+		require.NoError(t, err)
+
+		vc.Expired = util.NewTime(time.Date(2030, 1, 1, 19, 23, 24, 0, time.UTC))
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+		require.NotContains(t, jwtClaims.VC, "validFrom")
+		require.Equal(t, vc.Expired.Time, jwtClaims.Expiry.Time().UTC())
+
+		sJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		vcBytes, err := decodeCredJWTUnsecured(sJWT)
+		require.NoError(t, err)
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(vcBytes, &vcMap))
+		require.Equal(t, "2010-01-01T19:23:24Z", vcMap["validFrom"])
+		require.Equal(t, "2030-01-01T19:23:24Z", vcMap["validUntil"])
+		require.NotContains(t, vcMap, "issuanceDate")
+		require.NotContains(t, vcMap, "expirationDate")
+	})
+}
+
 func TestValidateVerCredStatus(t *testing.T) {
 	t.Run("test verifiable credential with empty credential status", func(t *testing.T) {
 		var raw rawCredential
@@ -713,6 +833,67 @@ func TestCredential_MarshalJSON(t *testing.T) {
 		require.Equal(t, vc.stringJSON(t), cred2.stringJSON(t))
 	})
 
+	t.Run("round trip conversion of credential with evidence and terms of use", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(credentialWithEvidenceAndTermsOfUse))
+		require.NoError(t, err)
+		require.Len(t, vc.TermsOfUse, 1)
+		require.Equal(t, "http://example.com/policies/credential/4", vc.TermsOfUse[0].ID)
+		require.Equal(t, []interface{}{"IssuerPolicy", "KYCPolicy"}, vc.TermsOfUse[0].CustomFields["type"])
+
+		byteCred, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, credentialWithEvidenceAndTermsOfUse, string(byteCred))
+
+		// the fields survive signing, verification and the serialization of the signed credential.
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+		}, jsonld.WithDocumentLoader(createTestDocumentLoader(t))))
+
+		signedCred, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		verified, err := ParseCredential(signedCred,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		verified.Proofs = nil
+
+		byteCred, err = verified.MarshalJSON()
+		require.NoError(t, err)
+		require.JSONEq(t, credentialWithEvidenceAndTermsOfUse, string(byteCred))
+	})
+
+	t.Run("terms of use given as an object", func(t *testing.T) {
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(credentialWithEvidenceAndTermsOfUse), &vcMap))
+
+		termsOfUse := map[string]interface{}{"type": "IssuerPolicy", "profile": "http://example.com/profiles/credential"}
+		vcMap["termsOfUse"] = termsOfUse
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, []TypedID{{Type: "IssuerPolicy", CustomFields: CustomFields{
+			"profile": "http://example.com/profiles/credential",
+		}}}, vc.TermsOfUse)
+
+		byteCred, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		require.NoError(t, json.Unmarshal(byteCred, &vcMap))
+		require.Equal(t, []interface{}{termsOfUse}, vcMap["termsOfUse"])
+	})
+
 	t.Run("Failure in VC marshalling", func(t *testing.T) {
 		vc, err := parseTestCredential(t, []byte(validCredential))
 		require.NoError(t, err)
@@ -763,6 +944,22 @@ func TestWithDisabledProofCheck(t *testing.T) {
 	require.True(t, opts.disabledProofCheck)
 }
 
+func TestExpirableSchemaCache_SetClock(t *testing.T) {
+	c := mockclock.New(time.Now())
+	cache := NewExpirableSchemaCache(32*1024*1024, time.Minute).SetClock(c)
+
+	cache.Put("schema", []byte("custom schema"))
+
+	schema, ok := cache.Get("schema")
+	require.True(t, ok)
+	require.Equal(t, []byte("custom schema"), schema)
+
+	c.Add(2 * time.Minute)
+
+	_, ok = cache.Get("schema")
+	require.False(t, ok)
+}
+
 func TestWithCredentialSchemaLoader(t *testing.T) {
 	httpClient := &http.Client{}
 	jsonSchemaLoader := gojsonschema.NewStringLoader(DefaultSchema)
@@ -862,6 +1059,64 @@ func TestWithStrictValidation(t *testing.T) {
 	require.True(t, opts.strictValidation)
 }
 
+func TestWithStrictJSONLD(t *testing.T) {
+	credentialOpt := WithStrictJSONLD()
+	require.NotNil(t, credentialOpt)
+
+	opts := &credentialOpts{}
+	credentialOpt(opts)
+	require.True(t, opts.strictJSONLD)
+}
+
+func TestParseCredentialWithStrictJSONLD(t *testing.T) {
+	vcMap, err := toMap(validCredential)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithStrictJSONLD())
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	vcMap["credentialSubject"].(map[string]interface{})["favoriteFood"] = "Papaya"
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	// the undefined claim is dropped on JSON-LD processing, so it's accepted unless the strict check is enabled
+	vc, err = parseTestCredential(t, vcBytes)
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	vc, err = parseTestCredential(t, vcBytes, WithStrictJSONLD())
+	require.EqualError(t, err, "strict JSON-LD check: JSON-LD term favoriteFood is not defined in the context")
+	require.Nil(t, vc)
+}
+
+func TestParseCredentialWithValidityPeriodCheck(t *testing.T) {
+	// validCredential is issued at 2010-01-01T19:23:24Z and expires at 2020-01-01T19:23:24Z
+	clock := mockclock.New(time.Date(2010, 1, 1, 19, 23, 23, 0, time.UTC))
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithValidityPeriodCheck(clock))
+	require.EqualError(t, err, "credential is not valid before 2010-01-01T19:23:24Z")
+	require.Nil(t, vc)
+
+	clock.Add(time.Second)
+
+	vc, err = parseTestCredential(t, []byte(validCredential), WithValidityPeriodCheck(clock))
+	require.NoError(t, err)
+	require.NotNil(t, vc)
+
+	clock.Set(time.Date(2020, 1, 1, 19, 23, 24, 0, time.UTC))
+
+	vc, err = parseTestCredential(t, []byte(validCredential), WithValidityPeriodCheck(clock))
+	require.EqualError(t, err, "credential expired at 2020-01-01T19:23:24Z")
+	require.Nil(t, vc)
+
+	// the system clock is used by default
+	vc, err = parseTestCredential(t, []byte(validCredential), WithValidityPeriodCheck(nil))
+	require.EqualError(t, err, "credential expired at 2020-01-01T19:23:24Z")
+	require.Nil(t, vc)
+}
+
 func TestWithEmbeddedSignatureSuites(t *testing.T) {
 	ss := ed25519signature2018.New()
 
@@ -1857,7 +2112,17 @@ func TestParseUnverifiedCredential(t *testing.T) {
 			WithDisabledProofCheck())
 		require.NoError(t, err)
 		require.NotNil(t, vcUnverified)
+		require.Equal(t, jws, vcUnverified.JWT)
+
+		vc.JWT = jws
 		require.Equal(t, vc, vcUnverified)
+
+		// the kept JWT serializes the credential again as is
+		vcReparsed, err := ParseCredential([]byte(vcUnverified.JWT),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vcUnverified, vcReparsed)
 	})
 
 	t.Run("ParseUnverifiedCredential() for Linked Data proof", func(t *testing.T) {
@@ -0,0 +1,95 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// CredentialValidationError is returned by Credential.Validate, it lists all the problems found in the credential.
+type CredentialValidationError struct {
+	Errors []error
+}
+
+func (e *CredentialValidationError) Error() string {
+	errs := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		errs[i] = err.Error()
+	}
+
+	return "invalid credential: " + strings.Join(errs, "; ")
+}
+
+// Validate checks that the credential would produce a verifiable result once signed, without signing it. It runs
+// the structural validation against the VC data model JSON schema, the JSON-LD expansion (so the contexts must
+// resolve with the document loader set by WithJSONLDDocumentLoader) and, if the credential has credential schemas,
+// validates credentialSubject against them (see WithCredentialSchemaValidation).
+// All the problems found are returned at once as *CredentialValidationError.
+func (vc *Credential) Validate(opts ...CredentialOpt) error {
+	vcOpts := getCredentialOpts(opts)
+
+	vcBytes, err := vc.validationJSON()
+	if err != nil {
+		return fmt.Errorf("validate credential: %w", err)
+	}
+
+	var errs []error
+
+	result, err := gojsonschema.Validate(defaultSchemaLoader(), gojsonschema.NewBytesLoader(vcBytes))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("validation of verifiable credential: %w", err))
+	} else {
+		for _, desc := range result.Errors() {
+			// the errors of the conditional subschema are reported on their own.
+			if desc.Type() == "condition_then" || desc.Type() == "condition_else" {
+				continue
+			}
+
+			errs = append(errs, errors.New(desc.String()))
+		}
+	}
+
+	if err = vc.validateJSONLD(vcBytes, vcOpts); err != nil {
+		errs = append(errs, fmt.Errorf("JSON-LD expansion: %w", err))
+	}
+
+	if vcOpts.strictJSONLD {
+		if err = checkUndefinedJSONLDTerms(vcBytes, &vcOpts.jsonldCredentialOpts); err != nil {
+			errs = append(errs, fmt.Errorf("strict JSON-LD check: %w", err))
+		}
+	}
+
+	if err = validateSubjectUsingCredentialSchemas(vcBytes, vc.Schemas, vcOpts); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &CredentialValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+// validationJSON marshals the credential without its proofs, an issuer without ID is left out.
+func (vc *Credential) validationJSON() ([]byte, error) {
+	raw, err := vc.withoutProof().raw()
+	if err != nil {
+		return nil, err
+	}
+
+	if vc.Issuer.ID == "" && len(vc.Issuer.CustomFields) == 0 {
+		raw.Issuer = nil
+	}
+
+	return json.Marshal(raw)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_Validate(t *testing.T) {
+	t.Run("valid credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		require.NoError(t, vc.Validate(WithJSONLDDocumentLoader(createTestDocumentLoader(t))))
+	})
+
+	t.Run("missing issuer", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer = Issuer{}
+
+		err = vc.Validate(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.EqualError(t, err, "invalid credential: (root): issuer is required")
+
+		var validationErr *CredentialValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Len(t, validationErr.Errors, 1)
+	})
+
+	t.Run("all errors are reported", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer = Issuer{}
+		vc.Issued = nil
+		vc.Context = append(vc.Context, "https://example.com/unknown/context/v1")
+
+		err = vc.Validate(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+
+		var validationErr *CredentialValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Len(t, validationErr.Errors, 3)
+		require.ElementsMatch(t, []string{"(root): issuer is required", "(root): issuanceDate is required"},
+			[]string{validationErr.Errors[0].Error(), validationErr.Errors[1].Error()})
+		require.Contains(t, validationErr.Errors[2].Error(), "JSON-LD expansion")
+	})
+
+	t.Run("credentialSubject does not conform to the credential schema", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newSchemaTestCredential(t, jsonSchemaType,
+			`{"id": "did:example:123", "degree": {"type": "BachelorDegree"}}`))
+		require.NoError(t, err)
+
+		err = vc.Validate(WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithCredentialSchemaLoader(NewCredentialSchemaLoaderBuilder().SetSchemaFetcher(
+				func(string) ([]byte, error) {
+					return []byte(degreeSchema), nil
+				}).Build()))
+		require.Error(t, err)
+
+		var validationErr *CredentialValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Len(t, validationErr.Errors, 1)
+
+		var schemaErr *CredentialSchemaError
+
+		require.True(t, errors.As(validationErr.Errors[0], &schemaErr))
+		require.Equal(t, degreeSchemaURL, schemaErr.SchemaID)
+		require.Equal(t, []SchemaFieldError{{Field: "credentialSubject.degree", Description: "name is required"}},
+			schemaErr.Fields)
+	})
+
+	t.Run("signed credential", func(t *testing.T) {
+		vc, _ := createVCWithLinkedDataProof(t)
+
+		require.NoError(t, vc.Validate(WithJSONLDDocumentLoader(createTestDocumentLoader(t))))
+	})
+}
@@ -15,18 +15,23 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/bbsblssignatureproof2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsardfc2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ecdsasecp256k1signature2019"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/eddsardfc2022"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 )
 
 const (
 	ed25519Signature2018        = "Ed25519Signature2018"
+	ed25519Signature2020        = "Ed25519Signature2020"
 	jsonWebSignature2020        = "JsonWebSignature2020"
 	ecdsaSecp256k1Signature2019 = "EcdsaSecp256k1Signature2019"
 	bbsBlsSignature2020         = "BbsBlsSignature2020"
 	bbsBlsSignatureProof2020    = "BbsBlsSignatureProof2020"
+	dataIntegrityProof          = "DataIntegrityProof"
 )
 
 func getProofType(proofMap map[string]interface{}) (string, error) {
@@ -37,8 +42,8 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 
 	proofTypeStr := safeStringValue(proofType)
 	switch proofTypeStr {
-	case ed25519Signature2018, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
-		bbsBlsSignature2020, bbsBlsSignatureProof2020:
+	case ed25519Signature2018, ed25519Signature2020, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
+		bbsBlsSignature2020, bbsBlsSignatureProof2020, dataIntegrityProof:
 		return proofTypeStr, nil
 	default:
 		return "", fmt.Errorf("unsupported proof type: %s", proofType)
@@ -49,7 +54,12 @@ type embeddedProofCheckOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 
-	ldpSuites []verifier.SignatureSuite
+	ldpSuites      []verifier.SignatureSuite
+	suiteRegistry  verifier.SuiteRegistry
+	minValidProofs int
+	proofResults   *[]verifier.ProofResult
+	challenge      string
+	domain         string
 
 	jsonldCredentialOpts
 }
@@ -76,9 +86,14 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 		return nil, fmt.Errorf("check embedded proof: %w", err)
 	}
 
-	ldpSuites, err := getSuites(proofs, opts)
-	if err != nil {
-		return nil, err
+	var ldpSuites []verifier.SignatureSuite
+
+	// the suites of the registry are looked up by the verifier
+	if opts.suiteRegistry == nil {
+		ldpSuites, err = getSuites(proofs, opts)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if opts.publicKeyFetcher == nil {
@@ -93,7 +108,7 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) ([]byte,
 		checkedDoc, _ = json.Marshal(jsonldDoc) //nolint:errcheck
 	}
 
-	err = checkLinkedDataProof(checkedDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
+	err = checkLinkedDataProof(checkedDoc, ldpSuites, opts)
 	if err != nil {
 		return nil, fmt.Errorf("check embedded proof: %w", err)
 	}
@@ -110,35 +125,78 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 			return nil, fmt.Errorf("check embedded proof: %w", err)
 		}
 
-		if len(opts.ldpSuites) == 0 {
-			switch t {
-			case ed25519Signature2018:
-				ldpSuites = append(ldpSuites, ed25519signature2018.New(
-					suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier())))
-			case jsonWebSignature2020:
-				ldpSuites = append(ldpSuites, jsonwebsignature2020.New(
-					suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier())))
-			case ecdsaSecp256k1Signature2019:
-				ldpSuites = append(ldpSuites, ecdsasecp256k1signature2019.New(
-					suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier())))
-			case bbsBlsSignature2020:
-				ldpSuites = append(ldpSuites, bbsblssignature2020.New(
-					suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier())))
-			case bbsBlsSignatureProof2020:
-				nonce, err := getNonce(proofs[i])
-				if err != nil {
-					return nil, err
-				}
-
-				ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
-					suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+		if len(opts.ldpSuites) > 0 {
+			continue
+		}
+
+		// the suite of BBS+ signature proofs verifies the nonce of the proof, thus it is not in the registry
+		if t == bbsBlsSignatureProof2020 {
+			nonce, err := getNonce(proofs[i])
+			if err != nil {
+				return nil, err
 			}
+
+			ldpSuites = append(ldpSuites, bbsblssignatureproof2020.New(
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier(nonce))))
+
+			continue
 		}
+
+		cryptosuite := safeStringValue(proofs[i]["cryptosuite"])
+
+		suites := defaultSuiteRegistry.Suites(t, cryptosuite)
+		if len(suites) == 0 {
+			return nil, fmt.Errorf("check embedded proof: unsupported cryptosuite: %s", cryptosuite)
+		}
+
+		ldpSuites = append(ldpSuites, suites...)
 	}
 
 	return ldpSuites, nil
 }
 
+// defaultSuiteRegistry holds the built-in signature suites used if no suites or registry are defined by the options.
+// nolint:gochecknoglobals
+var defaultSuiteRegistry = NewSuiteRegistry()
+
+// NewSuiteRegistry returns a signature suite registry seeded with the built-in suites which verify embedded linked
+// data proofs (except BBS+ signature proofs, whose suite depends on the nonce of the proof). Suites of custom proof
+// types can be registered in it, see WithSignatureSuiteRegistry.
+func NewSuiteRegistry() *suite.Registry {
+	r := suite.NewRegistry()
+
+	builtIn := []struct {
+		proofType   string
+		cryptosuite string
+		suite       verifier.SignatureSuite
+	}{
+		{ed25519Signature2018, "", ed25519signature2018.New(
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))},
+		{ed25519Signature2020, "", ed25519signature2020.New(
+			suite.WithVerifier(ed25519signature2020.NewPublicKeyVerifier()))},
+		{jsonWebSignature2020, "", jsonwebsignature2020.New(
+			suite.WithVerifier(jsonwebsignature2020.NewPublicKeyVerifier()))},
+		{ecdsaSecp256k1Signature2019, "", ecdsasecp256k1signature2019.New(
+			suite.WithVerifier(ecdsasecp256k1signature2019.NewPublicKeyVerifier()))},
+		{bbsBlsSignature2020, "", bbsblssignature2020.New(
+			suite.WithVerifier(bbsblssignature2020.NewG2PublicKeyVerifier()))},
+		{dataIntegrityProof, eddsardfc2022.CryptosuiteID, eddsardfc2022.New(
+			suite.WithVerifier(eddsardfc2022.NewPublicKeyVerifier()))},
+		// the ECDSA suite is picked by the curve of the public key
+		{dataIntegrityProof, ecdsardfc2019.CryptosuiteID, ecdsardfc2019.New(
+			suite.WithVerifier(ecdsardfc2019.NewPublicKeyVerifier()))},
+		{dataIntegrityProof, ecdsardfc2019.CryptosuiteID, ecdsardfc2019.NewP384(
+			suite.WithVerifier(ecdsardfc2019.NewPublicKeyVerifier()))},
+	}
+
+	for _, b := range builtIn {
+		// the built-in suites accept their proof types and never conflict
+		_ = r.Register(b.proofType, b.cryptosuite, b.suite) //nolint:errcheck
+	}
+
+	return r
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
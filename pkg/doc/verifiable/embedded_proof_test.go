@@ -219,3 +219,21 @@ func Test_getSuites(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, suites, 4)
 }
+
+func TestNewSuiteRegistry(t *testing.T) {
+	r := NewSuiteRegistry()
+
+	for _, proofType := range []string{
+		ed25519Signature2018, ed25519Signature2020, jsonWebSignature2020, ecdsaSecp256k1Signature2019,
+		bbsBlsSignature2020,
+	} {
+		suites := r.Suites(proofType, "")
+		require.Len(t, suites, 1, proofType)
+		require.True(t, suites[0].Accept(proofType))
+	}
+
+	require.Len(t, r.Suites(dataIntegrityProof, "eddsa-rdfc-2022"), 1)
+	require.Len(t, r.Suites(dataIntegrityProof, "ecdsa-rdfc-2019"), 2)
+	require.Empty(t, r.Suites(dataIntegrityProof, ""))
+	require.Empty(t, r.Suites(bbsBlsSignatureProof2020, ""))
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 )
@@ -16,12 +17,18 @@ import (
 const (
 	// ContextURI is the required JSON-LD context for VCs and VPs.
 	ContextURI = "https://www.w3.org/2018/credentials/v1"
+	// ContextV2URI is the base JSON-LD context of VC Data Model 2.0 credentials and presentations.
+	ContextV2URI = "https://www.w3.org/ns/credentials/v2"
 	// VCType is the required Type for Verifiable Credentials.
 	VCType = "VerifiableCredential"
 	// VPType is the required Type for Verifiable Credentials.
 	VPType = "VerifiablePresentation"
 )
 
+// undefinedTermVocab is the vocabulary the terms not defined by the contexts of a JSON-LD document
+// are expanded to when checking the document for undefined terms.
+const undefinedTermVocab = "urn:aries-framework-go:undefined-term:"
+
 func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 	docMap, err := toMap(doc)
 	if err != nil {
@@ -44,6 +51,96 @@ func compactJSONLD(doc string, opts *jsonldCredentialOpts, strict bool) error {
 	return nil
 }
 
+// checkUndefinedJSONLDTerms fails if a property or a type of the JSON-LD document is not defined by its contexts,
+// i.e. it would be silently dropped on expansion. Nothing is dropped if the contexts define a default vocabulary.
+func checkUndefinedJSONLDTerms(doc []byte, opts *jsonldCredentialOpts) error {
+	docMap, err := toMap(doc)
+	if err != nil {
+		return fmt.Errorf("convert JSON-LD doc to map: %w", err)
+	}
+
+	jsonldProc := jsonld.Default()
+	ldOpts := []jsonld.ProcessorOpts{
+		jsonld.WithDocumentLoader(opts.jsonldDocumentLoader),
+		jsonld.WithExternalContext(opts.externalContext...),
+	}
+
+	vocabProbe := map[string]interface{}{
+		"@context":                    docMap["@context"],
+		"undefinedTermVocabProbeTerm": "",
+	}
+
+	expandedProbe, err := jsonldProc.Expand(vocabProbe, ldOpts...)
+	if err != nil {
+		return fmt.Errorf("expand JSON-LD document: %w", err)
+	}
+
+	if len(expandedProbe) > 0 {
+		return nil
+	}
+
+	docMap["@context"] = append(jsonld.AppendExternalContexts(docMap["@context"], opts.externalContext...),
+		map[string]interface{}{"@vocab": undefinedTermVocab})
+
+	expandedDoc, err := jsonldProc.Expand(docMap, jsonld.WithDocumentLoader(opts.jsonldDocumentLoader))
+	if err != nil {
+		return fmt.Errorf("expand JSON-LD document: %w", err)
+	}
+
+	if term, found := findUndefinedJSONLDTerm(expandedDoc); found {
+		return fmt.Errorf("JSON-LD term %s is not defined in the context", term)
+	}
+
+	return nil
+}
+
+func findUndefinedJSONLDTerm(v interface{}) (string, bool) {
+	switch vv := v.(type) {
+	case []interface{}:
+		for _, item := range vv {
+			if term, found := findUndefinedJSONLDTerm(item); found {
+				return term, true
+			}
+		}
+
+	case map[string]interface{}:
+		for k, item := range vv {
+			if strings.HasPrefix(k, undefinedTermVocab) {
+				return strings.TrimPrefix(k, undefinedTermVocab), true
+			}
+
+			if k == "@type" {
+				if term, found := findUndefinedJSONLDType(item); found {
+					return term, true
+				}
+
+				continue
+			}
+
+			if term, found := findUndefinedJSONLDTerm(item); found {
+				return term, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func findUndefinedJSONLDType(v interface{}) (string, bool) {
+	types, ok := v.([]interface{})
+	if !ok {
+		types = []interface{}{v}
+	}
+
+	for _, t := range types {
+		if tStr, ok := t.(string); ok && strings.HasPrefix(tStr, undefinedTermVocab) {
+			return strings.TrimPrefix(tStr, undefinedTermVocab), true
+		}
+	}
+
+	return "", false
+}
+
 func mapsHaveSameStructure(originalMap, compactedMap map[string]interface{}) bool {
 	original := compactMap(originalMap)
 	compacted := compactMap(compactedMap)
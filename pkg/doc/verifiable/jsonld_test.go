@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -497,3 +498,86 @@ func Benchmark_compactJSONLD(b *testing.B) {
 		})
 	})
 }
+
+func Test_checkUndefinedJSONLDTerms(t *testing.T) {
+	contextURL := "http://127.0.0.1?context=6"
+
+	loader := createTestDocumentLoader(t, jld.ContextDocument{
+		URL:     contextURL,
+		Content: context6,
+	})
+
+	opts := &jsonldCredentialOpts{jsonldDocumentLoader: loader}
+
+	vcJSONTemplate := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "%s"
+  ],
+  "id": "http://example.com/credentials/4643",
+  "type": %s,
+  "issuer": "https://example.com/issuers/14",
+  "issuanceDate": "2018-02-24T05:28:04Z",
+  "referenceNumber": 83294847,
+  "credentialSubject": %s
+}
+`
+
+	t.Run("all terms are defined", func(t *testing.T) {
+		vcJSON := fmt.Sprintf(vcJSONTemplate, contextURL, `["VerifiableCredential"]`,
+			`{"id": "did:example:abcdef1234567"}`)
+
+		require.NoError(t, checkUndefinedJSONLDTerms([]byte(vcJSON), opts))
+	})
+
+	t.Run("undefined property", func(t *testing.T) {
+		vcJSON := fmt.Sprintf(vcJSONTemplate, contextURL, `["VerifiableCredential"]`,
+			`[{"id": "did:example:abcdef1234567", "favoriteFood": "Papaya"}]`)
+
+		err := checkUndefinedJSONLDTerms([]byte(vcJSON), opts)
+		require.EqualError(t, err, "JSON-LD term favoriteFood is not defined in the context")
+	})
+
+	t.Run("undefined type", func(t *testing.T) {
+		vcJSON := fmt.Sprintf(vcJSONTemplate, contextURL, `["VerifiableCredential", "CustomExt12"]`,
+			`{"id": "did:example:abcdef1234567"}`)
+
+		err := checkUndefinedJSONLDTerms([]byte(vcJSON), opts)
+		require.EqualError(t, err, "JSON-LD term CustomExt12 is not defined in the context")
+	})
+
+	t.Run("default vocabulary defines all terms", func(t *testing.T) {
+		vcJSON := fmt.Sprintf(vcJSONTemplate, contextURL, `["VerifiableCredential", "CustomExt12"]`,
+			`{"id": "did:example:abcdef1234567", "favoriteFood": "Papaya"}`)
+
+		vcMap, err := toMap(vcJSON)
+		require.NoError(t, err)
+
+		vcMap["@context"] = append(vcMap["@context"].([]interface{}),
+			map[string]interface{}{"@vocab": "https://example.com/vocab#"})
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		require.NoError(t, checkUndefinedJSONLDTerms(vcBytes, opts))
+	})
+
+	t.Run("undefined external context", func(t *testing.T) {
+		vcJSON := fmt.Sprintf(vcJSONTemplate, contextURL, `["VerifiableCredential"]`,
+			`{"id": "did:example:abcdef1234567"}`)
+
+		err := checkUndefinedJSONLDTerms([]byte(vcJSON), &jsonldCredentialOpts{
+			jsonldDocumentLoader: loader,
+			externalContext:      []string{"http://127.0.0.1?context=undefined"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expand JSON-LD document")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		err := checkUndefinedJSONLDTerms([]byte("not JSON"), opts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "convert JSON-LD doc to map")
+	})
+}
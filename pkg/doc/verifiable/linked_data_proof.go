@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/proof"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
@@ -54,28 +55,53 @@ const (
 
 // LinkedDataProofContext holds options needed to build a Linked Data Proof.
 type LinkedDataProofContext struct {
+	ProofID                 string                  // optional
 	SignatureType           string                  // required
 	Suite                   signer.SignatureSuite   // required
 	SignatureRepresentation SignatureRepresentation // required
-	Created                 *time.Time              // optional
+	Created                 *time.Time              // optional, the time of signing if not set
+	Expires                 *time.Time              // optional
 	VerificationMethod      string                  // optional
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// PreviousProof holds IDs of the proofs which are secured by the new proof in a proof chain.
+	PreviousProof []string
+	// Clock reads the time of signing if Created is not set, clock.SystemClock by default.
+	Clock clock.Clock
 }
 
-func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite,
-	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
-	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
+func checkLinkedDataProof(jsonldBytes []byte, suites []verifier.SignatureSuite, opts *embeddedProofCheckOpts) error {
+	var (
+		documentVerifier *verifier.DocumentVerifier
+		err              error
+	)
+
+	if opts.suiteRegistry != nil {
+		documentVerifier, err = verifier.NewWithRegistry(&keyResolverAdapter{opts.publicKeyFetcher}, opts.suiteRegistry)
+	} else {
+		documentVerifier, err = verifier.New(&keyResolverAdapter{opts.publicKeyFetcher}, suites...)
+	}
+
 	if err != nil {
 		return fmt.Errorf("create new signature verifier: %w", err)
 	}
 
-	processorOpts := mapJSONLDProcessorOpts(jsonldOpts)
+	processorOpts := mapJSONLDProcessorOpts(&opts.jsonldCredentialOpts)
+
+	verifyOpts := []verifier.VerifyOpt{
+		verifier.WithProcessorOpts(processorOpts...),
+		verifier.WithChallenge(opts.challenge),
+		verifier.WithDomain(opts.domain),
+	}
+
+	if opts.proofResults != nil {
+		verifyOpts = append(verifyOpts, verifier.WithProofResults(opts.proofResults))
+	}
 
-	err = documentVerifier.Verify(jsonldBytes, processorOpts...)
+	err = documentVerifier.VerifyWithOpts(jsonldBytes, opts.minValidProofs, verifyOpts...)
 	if err != nil {
 		return fmt.Errorf("check linked data proof: %w", err)
 	}
@@ -132,13 +158,17 @@ func addLinkedDataProof(context *LinkedDataProofContext, jsonldBytes []byte,
 
 func mapContext(context *LinkedDataProofContext) *signer.Context {
 	return &signer.Context{
+		ID:                      context.ProofID,
 		SignatureType:           context.SignatureType,
 		SignatureRepresentation: proof.SignatureRepresentation(context.SignatureRepresentation),
 		Created:                 context.Created,
+		Clock:                   context.Clock,
+		Expires:                 context.Expires,
 		VerificationMethod:      context.VerificationMethod,
 		Challenge:               context.Challenge,
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		PreviousProof:           context.PreviousProof,
 	}
 }
@@ -338,9 +338,14 @@ type presentationOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
 	ldpSuites          []verifier.SignatureSuite
+	suiteRegistry      verifier.SuiteRegistry
 	strictValidation   bool
 	requireVC          bool
 	requireProof       bool
+	holderBinding      bool
+	credProofCheck     bool
+	challenge          string
+	domain             string
 
 	jsonldCredentialOpts
 }
@@ -363,6 +368,14 @@ func WithPresEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) Presenta
 	}
 }
 
+// WithPresSignatureSuiteRegistry defines the registry of the suites which are used to check embedded linked data
+// proof of VP. The suites are looked up by the type and cryptosuite of the proof.
+func WithPresSignatureSuiteRegistry(registry verifier.SuiteRegistry) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.suiteRegistry = registry
+	}
+}
+
 // WithPresDisabledProofCheck option for disabling of proof check.
 func WithPresDisabledProofCheck() PresentationOpt {
 	return func(opts *presentationOpts) {
@@ -387,6 +400,38 @@ func WithPresJSONLDDocumentLoader(documentLoader ld.DocumentLoader) Presentation
 	}
 }
 
+// WithPresHolderBinding checks that the linked data proofs of the VP are made with keys of the VP holder,
+// i.e. that the verification method of each proof belongs to the holder DID.
+func WithPresHolderBinding() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.holderBinding = true
+	}
+}
+
+// WithPresCredentialProofCheck checks the embedded linked data proof of each credential of the VP
+// using the public key fetcher and signature suites of the VP.
+func WithPresCredentialProofCheck() PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.credProofCheck = true
+	}
+}
+
+// WithPresChallenge checks that the linked data proofs of the VP have the given challenge,
+// e.g. the one of the present-proof request the VP answers. It's checked along with the proofs.
+func WithPresChallenge(challenge string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.challenge = challenge
+	}
+}
+
+// WithPresDomain checks that the linked data proofs of the VP have the given domain,
+// e.g. the one of the present-proof request the VP answers. It's checked along with the proofs.
+func WithPresDomain(domain string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.domain = domain
+	}
+}
+
 // ParsePresentation creates an instance of Verifiable Presentation by reading a JSON document from bytes.
 // It also applies miscellaneous options like custom decoders or settings of schema validation.
 func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, error) {
@@ -411,6 +456,11 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, fmt.Errorf("verifiableCredential is required")
 	}
 
+	err = checkPresentationProofs(p, vpOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	return p, nil
 }
 
@@ -523,6 +573,7 @@ func mapOpts(vpOpts *presentationOpts) *credentialOpts {
 		publicKeyFetcher:   vpOpts.publicKeyFetcher,
 		disabledProofCheck: vpOpts.disabledProofCheck,
 		ldpSuites:          vpOpts.ldpSuites,
+		suiteRegistry:      vpOpts.suiteRegistry,
 	}
 }
 
@@ -576,6 +627,9 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		publicKeyFetcher:     vpOpts.publicKeyFetcher,
 		disabledProofCheck:   vpOpts.disabledProofCheck,
 		ldpSuites:            vpOpts.ldpSuites,
+		suiteRegistry:        vpOpts.suiteRegistry,
+		challenge:            vpOpts.challenge,
+		domain:               vpOpts.domain,
 		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
 	}
 
@@ -17,6 +17,9 @@ type JWTPresClaims struct {
 	*jwt.Claims
 
 	Presentation *rawPresentation `json:"vp,omitempty"`
+
+	// Nonce binds the presentation to the verifier's request (e.g. an OpenID4VP authorization request).
+	Nonce string `json:"nonce,omitempty"`
 }
 
 func (jpc *JWTPresClaims) refineFromJWTClaims() {
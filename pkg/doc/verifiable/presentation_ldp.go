@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 )
@@ -27,3 +30,95 @@ func (vp *Presentation) AddLinkedDataProof(context *LinkedDataProofContext, json
 
 	return nil
 }
+
+// checkPresentationProofs makes the holder binding and credential proof checks requested by the options. The challenge
+// and domain are checked while verifying the proofs, here it's only checked that there are proofs to check them with.
+func checkPresentationProofs(vp *Presentation, opts *presentationOpts) error {
+	if (opts.challenge != "" || opts.domain != "") && opts.disabledProofCheck {
+		return errors.New("check presentation proofs: challenge and domain can't be checked with the proof check disabled")
+	}
+
+	if opts.holderBinding || opts.challenge != "" || opts.domain != "" {
+		if len(vp.Proofs) == 0 {
+			return errors.New("check presentation proofs: linked data proof is missing")
+		}
+
+		for _, proof := range vp.Proofs {
+			if err := checkPresentationProof(vp.Holder, proof, opts); err != nil {
+				return fmt.Errorf("check presentation proofs: %w", err)
+			}
+		}
+	}
+
+	if opts.credProofCheck {
+		if err := checkCredentialProofs(vp, opts); err != nil {
+			return fmt.Errorf("check presentation proofs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func checkPresentationProof(holder string, proof Proof, opts *presentationOpts) error {
+	if opts.holderBinding {
+		verificationMethod := safeStringValue(proof["verificationMethod"])
+
+		if holder == "" || strings.Split(verificationMethod, "#")[0] != holder {
+			return fmt.Errorf("verification method %q does not belong to holder %q", verificationMethod, holder)
+		}
+	}
+
+	return nil
+}
+
+func checkCredentialProofs(vp *Presentation, opts *presentationOpts) error {
+	checkOpts := &embeddedProofCheckOpts{
+		publicKeyFetcher:     opts.publicKeyFetcher,
+		ldpSuites:            opts.ldpSuites,
+		suiteRegistry:        opts.suiteRegistry,
+		jsonldCredentialOpts: opts.jsonldCredentialOpts,
+	}
+
+	for i, cred := range vp.credentials {
+		var (
+			credBytes []byte
+			err       error
+		)
+
+		switch c := cred.(type) {
+		case map[string]interface{}:
+			credBytes, err = json.Marshal(c)
+		case *Credential:
+			if c.JWT != "" {
+				continue
+			}
+
+			credBytes, err = c.MarshalJSON()
+		default:
+			// credentials decoded from JWT are checked while decoding the presentation
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("marshal credential %d: %w", i, err)
+		}
+
+		if !hasProof(credBytes) {
+			return fmt.Errorf("credential %d: embedded proof is missing", i)
+		}
+
+		if _, err = checkEmbeddedProof(credBytes, checkOpts); err != nil {
+			return fmt.Errorf("credential %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func hasProof(docBytes []byte) bool {
+	var doc struct {
+		Proof json.RawMessage `json:"proof,omitempty"`
+	}
+
+	return json.Unmarshal(docBytes, &doc) == nil && len(doc.Proof) > 0 && string(doc.Proof) != "null"
+}
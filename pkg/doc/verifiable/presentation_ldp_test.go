@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -96,3 +97,128 @@ func TestPresentation_AddLinkedDataProof(t *testing.T) {
 		r.Equal("Ed25519Signature2018", newVPProof["type"])
 	})
 }
+
+func TestParsePresentation_HolderBinding(t *testing.T) {
+	r := require.New(t)
+
+	const (
+		issuer    = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+		holder    = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+		challenge = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		domain    = "verifier.example.com"
+	)
+
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	loader := createTestDocumentLoader(t)
+
+	newCredential := func(signed bool) *Credential {
+		vc, e := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+		r.NoError(e)
+
+		if signed {
+			r.NoError(vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				Suite:                   ed25519signature2018.New(suite.WithSigner(issuerSigner)),
+				SignatureRepresentation: SignatureJWS,
+				VerificationMethod:      issuer + "#key1",
+			}, jsonld.WithDocumentLoader(loader)))
+		}
+
+		return vc
+	}
+
+	newSignedPresentation := func(verificationMethod string, vcs ...*Credential) []byte {
+		vp, e := NewPresentation(WithCredentials(vcs...))
+		r.NoError(e)
+
+		vp.Holder = holder
+
+		r.NoError(vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			Suite:                   ed25519signature2018.New(suite.WithSigner(holderSigner)),
+			SignatureRepresentation: SignatureJWS,
+			VerificationMethod:      verificationMethod,
+			Challenge:               challenge,
+			Domain:                  domain,
+			Purpose:                 "authentication",
+		}, jsonld.WithDocumentLoader(loader)))
+
+		vpBytes, e := json.Marshal(vp)
+		r.NoError(e)
+
+		return vpBytes
+	}
+
+	keyFetcher := WithPresPublicKeyFetcher(func(issuerID, _ string) (*verifier.PublicKey, error) {
+		if issuerID == issuer {
+			return &verifier.PublicKey{Type: kms.ED25519, Value: issuerSigner.PublicKeyBytes()}, nil
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: holderSigner.PublicKeyBytes()}, nil
+	})
+
+	t.Run("VP over two VCs bound to its holder", func(t *testing.T) {
+		vpBytes := newSignedPresentation(holder+"#key1", newCredential(true), newCredential(true))
+
+		vp, err := newTestPresentation(t, vpBytes, keyFetcher,
+			WithPresHolderBinding(),
+			WithPresCredentialProofCheck(),
+			WithPresChallenge(challenge),
+			WithPresDomain(domain))
+		r.NoError(err)
+		r.Equal(holder, vp.Holder)
+		r.Len(vp.Credentials(), 2)
+	})
+
+	t.Run("proof made with a key of another DID", func(t *testing.T) {
+		vpBytes := newSignedPresentation("did:example:other#key1", newCredential(true), newCredential(true))
+
+		_, err := newTestPresentation(t, vpBytes, keyFetcher)
+		r.NoError(err)
+
+		_, err = newTestPresentation(t, vpBytes, keyFetcher, WithPresHolderBinding())
+		r.EqualError(err, `check presentation proofs: verification method "did:example:other#key1" `+
+			`does not belong to holder "`+holder+`"`)
+	})
+
+	t.Run("challenge or domain mismatch", func(t *testing.T) {
+		vpBytes := newSignedPresentation(holder+"#key1", newCredential(true))
+
+		_, err := newTestPresentation(t, vpBytes, keyFetcher, WithPresChallenge("other challenge"))
+		r.EqualError(err, `check embedded proof: check linked data proof: proof challenge "`+challenge+
+			`" does not match expected "other challenge"`)
+
+		_, err = newTestPresentation(t, vpBytes, keyFetcher, WithPresDomain("other.example.com"))
+		r.EqualError(err, `check embedded proof: check linked data proof: proof domain "`+domain+
+			`" does not match expected "other.example.com"`)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresDisabledProofCheck(), WithPresChallenge(challenge))
+		r.EqualError(err, "check presentation proofs: challenge and domain can't be checked with the proof check disabled")
+	})
+
+	t.Run("unsigned VC", func(t *testing.T) {
+		vpBytes := newSignedPresentation(holder+"#key1", newCredential(true), newCredential(false))
+
+		_, err := newTestPresentation(t, vpBytes, keyFetcher)
+		r.NoError(err)
+
+		_, err = newTestPresentation(t, vpBytes, keyFetcher, WithPresCredentialProofCheck())
+		r.EqualError(err, "check presentation proofs: credential 1: embedded proof is missing")
+	})
+
+	t.Run("VP without linked data proof", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(newCredential(true)))
+		r.NoError(err)
+
+		vpBytes, err := json.Marshal(vp)
+		r.NoError(err)
+
+		_, err = newTestPresentation(t, vpBytes, WithPresHolderBinding())
+		r.EqualError(err, "check presentation proofs: linked data proof is missing")
+	})
+}
@@ -0,0 +1,432 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+)
+
+const (
+	// StatusList2021EntryType is the credentialStatus type of a credential which status is published in a
+	// StatusList2021Credential.
+	// Reference: https://w3c-ccg.github.io/vc-status-list-2021/#statuslist2021entry
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	// StatusList2021CredentialType is the type of status list credential.
+	StatusList2021CredentialType = "StatusList2021Credential"
+
+	// StatusPurposeRevocation is the status purpose of a revocation status list.
+	StatusPurposeRevocation = "revocation"
+
+	// StatusPurposeSuspension is the status purpose of a suspension status list.
+	StatusPurposeSuspension = "suspension"
+
+	// StatusPurposeRefresh is the status purpose of a refresh status list.
+	StatusPurposeRefresh = "refresh"
+
+	// StatusPurposeMessage is the status purpose of a status list with arbitrary status messages.
+	StatusPurposeMessage = "message"
+
+	statusPurposeField        = "statusPurpose"
+	statusListIndexField      = "statusListIndex"
+	statusListCredentialField = "statusListCredential"
+	encodedListField          = "encodedList"
+
+	bitsPerByte = 8
+)
+
+// StatusListIndexOutOfRangeError is returned when the statusListIndex of a credential is outside of
+// the bitstring of the status list credential.
+type StatusListIndexOutOfRangeError struct {
+	Index int
+	Size  int
+}
+
+func (e *StatusListIndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("status list index %d is out of range of the status list of %d entries", e.Index, e.Size)
+}
+
+// StatusListFetcher fetches a status list credential from the given URL.
+type StatusListFetcher func(url string) ([]byte, error)
+
+// StatusListCache defines a cache of status list credentials, keyed by URL.
+// ExpirableSchemaCache can be used as StatusListCache.
+type StatusListCache interface {
+
+	// Put element to the cache.
+	Put(k string, v []byte)
+
+	// Get element from the cache, returns false at second return value if element is not present.
+	Get(k string) ([]byte, bool)
+}
+
+// StatusResult is the status of a credential read from its status list.
+type StatusResult struct {
+	// Purpose of the status list (StatusPurposeRevocation or StatusPurposeSuspension).
+	Purpose string
+
+	// Set is true if the credential's status value in the status list is not zero.
+	Set bool
+
+	// Value is the status value of the credential. It is either 0 or 1 unless the status entry has a statusSize
+	// greater than 1.
+	Value uint64
+
+	// Message is the statusMessage of the status entry matching Value, if any.
+	Message string
+}
+
+// Revoked returns true if the credential is revoked.
+func (r *StatusResult) Revoked() bool {
+	return r.Set && r.Purpose == StatusPurposeRevocation
+}
+
+// Suspended returns true if the credential is suspended.
+func (r *StatusResult) Suspended() bool {
+	return r.Set && r.Purpose == StatusPurposeSuspension
+}
+
+// StatusChecker checks credential status published in StatusList2021 or BitstringStatusList credentials.
+type StatusChecker struct {
+	fetcher    StatusListFetcher
+	httpClient *http.Client
+	cache      StatusListCache
+	vcOpts     []CredentialOpt
+}
+
+// StatusCheckerOpt is the StatusChecker functional option.
+type StatusCheckerOpt func(c *StatusChecker)
+
+// WithStatusListFetcher sets the fetcher of status list credentials. Status list credentials are downloaded
+// using HTTP GET by default.
+func WithStatusListFetcher(fetcher StatusListFetcher) StatusCheckerOpt {
+	return func(c *StatusChecker) {
+		c.fetcher = fetcher
+	}
+}
+
+// WithStatusListHTTPClient sets the HTTP client status list credentials are downloaded with, e.g. to pin the
+// TLS certificates of the status list endpoints. It is ignored if WithStatusListFetcher is used.
+func WithStatusListHTTPClient(client *http.Client) StatusCheckerOpt {
+	return func(c *StatusChecker) {
+		c.httpClient = client
+	}
+}
+
+// WithStatusListCache sets the cache of fetched status list credentials.
+func WithStatusListCache(cache StatusListCache) StatusCheckerOpt {
+	return func(c *StatusChecker) {
+		c.cache = cache
+	}
+}
+
+// WithStatusListCredentialOpts sets the options used to parse and verify status list credentials
+// (e.g. WithPublicKeyFetcher or WithJSONLDDocumentLoader).
+func WithStatusListCredentialOpts(opts ...CredentialOpt) StatusCheckerOpt {
+	return func(c *StatusChecker) {
+		c.vcOpts = append(c.vcOpts, opts...)
+	}
+}
+
+// NewStatusChecker creates a new StatusChecker.
+func NewStatusChecker(opts ...StatusCheckerOpt) *StatusChecker {
+	c := &StatusChecker{}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{}
+	}
+
+	if c.fetcher == nil {
+		c.fetcher = HTTPStatusListFetcher(c.httpClient)
+	}
+
+	return c
+}
+
+// HTTPStatusListFetcher creates a StatusListFetcher downloading status list credentials using the given client.
+func HTTPStatusListFetcher(client *http.Client) StatusListFetcher {
+	return func(url string) ([]byte, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("load status list credential: %w", err)
+		}
+
+		defer func() {
+			e := resp.Body.Close()
+			if e != nil {
+				logger.Errorf("closing response body failed [%v]", e)
+			}
+		}()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("status list credential endpoint HTTP failure [%v]", resp.StatusCode)
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("status list credential: read response body: %w", err)
+		}
+
+		return body, nil
+	}
+}
+
+// Check reads the status of vc from the status list credential referenced by its StatusList2021Entry or
+// BitstringStatusListEntry credentialStatus. The status list credential must be secured by a proof, which is verified.
+func (c *StatusChecker) Check(vc *Credential) (*StatusResult, error) {
+	if vc.Status == nil {
+		return nil, errors.New("credential has no credentialStatus")
+	}
+
+	var (
+		entry *statusEntry
+		err   error
+	)
+
+	switch vc.Status.Type {
+	case StatusList2021EntryType:
+		entry, err = parseStatusList2021Entry(vc.Status)
+	case BitstringStatusListEntryType:
+		entry, err = parseBitstringStatusListEntry(vc.Status)
+	default:
+		return nil, fmt.Errorf("unsupported credentialStatus type: %s", vc.Status.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	bitstring, err := c.bitstring(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := readStatus(bitstring, entry.index, entry.size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatusResult{
+		Purpose: entry.purpose,
+		Set:     value != 0,
+		Value:   value,
+		Message: entry.message(value),
+	}, nil
+}
+
+// statusEntry is a credentialStatus entry referencing a status list credential.
+type statusEntry struct {
+	purpose  string
+	index    int
+	listURL  string
+	listType string
+	size     int
+	messages []StatusMessage
+}
+
+// message returns the status message of value, if any.
+func (e *statusEntry) message(value uint64) string {
+	for _, m := range e.messages {
+		status, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(m.Status), "0x"), 16, 64)
+		if err == nil && status == value {
+			return m.Message
+		}
+	}
+
+	return ""
+}
+
+func parseStatusList2021Entry(status *TypedID) (*statusEntry, error) {
+	entry, err := parseStatusEntry(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.purpose != StatusPurposeRevocation && entry.purpose != StatusPurposeSuspension {
+		return nil, fmt.Errorf("unsupported %s: %s", statusPurposeField, entry.purpose)
+	}
+
+	entry.listType = StatusList2021CredentialType
+	entry.size = 1
+
+	return entry, nil
+}
+
+// parseStatusEntry parses the fields common to StatusList2021Entry and BitstringStatusListEntry.
+func parseStatusEntry(status *TypedID) (*statusEntry, error) {
+	purpose, ok := status.CustomFields[statusPurposeField].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is missing or not a string", statusPurposeField)
+	}
+
+	indexStr, ok := status.CustomFields[statusListIndexField].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s is missing or not a string", statusListIndexField)
+	}
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil || index < 0 {
+		return nil, fmt.Errorf("invalid %s: %s", statusListIndexField, indexStr)
+	}
+
+	statusListURL, ok := status.CustomFields[statusListCredentialField].(string)
+	if !ok || statusListURL == "" {
+		return nil, fmt.Errorf("%s is missing or not a string", statusListCredentialField)
+	}
+
+	return &statusEntry{
+		purpose: purpose,
+		index:   index,
+		listURL: statusListURL,
+	}, nil
+}
+
+// readStatus reads the status value of size bits at index of bitstring. The first index is the left-most bit
+// of the first byte.
+func readStatus(bitstring []byte, index, size int) (uint64, error) {
+	listSize := len(bitstring) * bitsPerByte / size
+	if index >= listSize {
+		return 0, &StatusListIndexOutOfRangeError{Index: index, Size: listSize}
+	}
+
+	var value uint64
+
+	for i := index * size; i < (index+1)*size; i++ {
+		value <<= 1
+
+		if bitstring[i/bitsPerByte]&(1<<(bitsPerByte-1-i%bitsPerByte)) != 0 {
+			value |= 1
+		}
+	}
+
+	return value, nil
+}
+
+// bitstring fetches and verifies the status list credential of entry and returns its inflated bitstring.
+func (c *StatusChecker) bitstring(entry *statusEntry) ([]byte, error) {
+	vcBytes, err := c.fetch(entry.listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	statusListVC, err := ParseCredential(vcBytes, c.vcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list credential: %w", err)
+	}
+
+	if len(statusListVC.Proofs) == 0 && !jwt.IsJWS(statusListVC.JWT) {
+		return nil, errors.New("status list credential is not secured by a proof")
+	}
+
+	if !containsType(statusListVC.Types, entry.listType) {
+		return nil, fmt.Errorf("status list credential is not of %s type", entry.listType)
+	}
+
+	subject, ok := statusListVC.Subject.([]Subject)
+	if !ok || len(subject) != 1 {
+		return nil, errors.New("status list credential must have a single subject")
+	}
+
+	// a BitstringStatusList can be used for several purposes.
+	listPurpose := subject[0].CustomFields[statusPurposeField]
+	if !hasStatusPurpose(listPurpose, entry.purpose) {
+		return nil, fmt.Errorf("status list purpose %q does not match credential status purpose %q",
+			listPurpose, entry.purpose)
+	}
+
+	encodedList, ok := subject[0].CustomFields[encodedListField].(string)
+	if !ok {
+		return nil, fmt.Errorf("status list credential %s is missing or not a string", encodedListField)
+	}
+
+	return decodeStatusList(encodedList)
+}
+
+func (c *StatusChecker) fetch(url string) ([]byte, error) {
+	if c.cache != nil {
+		if vcBytes, ok := c.cache.Get(url); ok {
+			return vcBytes, nil
+		}
+	}
+
+	vcBytes, err := c.fetcher(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list credential: %w", err)
+	}
+
+	if c.cache != nil {
+		c.cache.Put(url, vcBytes)
+	}
+
+	return vcBytes, nil
+}
+
+// decodeStatusList decodes the base64url (or base64) encoded and GZIP-compressed bitstring of a status list.
+// The multibase prefix of base64url used by BitstringStatusList is accepted.
+func decodeStatusList(encodedList string) ([]byte, error) {
+	// GZIP data encoded in base64 always starts with "H4sI", so the prefix is not part of the data.
+	encodedList = strings.TrimPrefix(encodedList, multibaseBase64URLPrefix)
+
+	compressed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedList, "="))
+	if err != nil {
+		compressed, err = base64.StdEncoding.DecodeString(encodedList)
+		if err != nil {
+			return nil, fmt.Errorf("decode status list: %w", err)
+		}
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	bitstring, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	return bitstring, nil
+}
+
+func hasStatusPurpose(listPurpose interface{}, purpose string) bool {
+	switch p := listPurpose.(type) {
+	case string:
+		return p == purpose
+	case []interface{}:
+		for _, v := range p {
+			if v == purpose {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func containsType(types []string, t string) bool {
+	for _, vcType := range types {
+		if vcType == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,332 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	statusListURL = "https://example.com/credentials/status/3"
+
+	// minimum bitstring size of a status list (16KB).
+	statusListSize = 131072
+
+	statusListCredentialTemplate = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://w3id.org/vc/status-list/2021/v1"
+  ],
+  "id": "https://example.com/credentials/status/3",
+  "type": ["VerifiableCredential", "StatusList2021Credential"],
+  "issuer": "did:example:12345",
+  "issuanceDate": "2021-04-05T14:27:40Z",
+  "credentialSubject": {
+    "id": "https://example.com/status/3#list",
+    "type": "StatusList2021",
+    "statusPurpose": "%s",
+    "encodedList": "%s"
+  }
+}`
+)
+
+type mapStatusListCache map[string][]byte
+
+func (c mapStatusListCache) Put(k string, v []byte) {
+	c[k] = v
+}
+
+func (c mapStatusListCache) Get(k string) ([]byte, bool) {
+	v, ok := c[k]
+
+	return v, ok
+}
+
+func TestStatusChecker_Check(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	revocationList := createStatusListCredential(t, signer, StatusPurposeRevocation, 94567)
+	suspensionList := createStatusListCredential(t, signer, StatusPurposeSuspension, 42)
+
+	newChecker := func(statusList []byte, opts ...StatusCheckerOpt) *StatusChecker {
+		return NewStatusChecker(append([]StatusCheckerOpt{
+			WithStatusListFetcher(func(url string) ([]byte, error) {
+				require.Equal(t, statusListURL, url)
+
+				return statusList, nil
+			}),
+			WithStatusListCredentialOpts(
+				WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+				WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))),
+		}, opts...)...)
+	}
+
+	t.Run("revoked credential", func(t *testing.T) {
+		result, err := newChecker(revocationList).Check(newStatusListVC(StatusPurposeRevocation, "94567"))
+		require.NoError(t, err)
+		require.Equal(t, &StatusResult{Purpose: StatusPurposeRevocation, Set: true, Value: 1}, result)
+		require.True(t, result.Revoked())
+		require.False(t, result.Suspended())
+	})
+
+	t.Run("not revoked credential", func(t *testing.T) {
+		result, err := newChecker(revocationList).Check(newStatusListVC(StatusPurposeRevocation, "94566"))
+		require.NoError(t, err)
+		require.False(t, result.Set)
+		require.False(t, result.Revoked())
+	})
+
+	t.Run("suspended credential", func(t *testing.T) {
+		result, err := newChecker(suspensionList).Check(newStatusListVC(StatusPurposeSuspension, "42"))
+		require.NoError(t, err)
+		require.True(t, result.Suspended())
+		require.False(t, result.Revoked())
+	})
+
+	t.Run("status purpose mismatch", func(t *testing.T) {
+		_, err := newChecker(suspensionList).Check(newStatusListVC(StatusPurposeRevocation, "42"))
+		require.EqualError(t, err,
+			`status list purpose "suspension" does not match credential status purpose "revocation"`)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := newChecker(revocationList).Check(
+			newStatusListVC(StatusPurposeRevocation, fmt.Sprint(statusListSize)))
+		require.Error(t, err)
+
+		var rangeErr *StatusListIndexOutOfRangeError
+
+		require.True(t, errors.As(err, &rangeErr))
+		require.Equal(t, statusListSize, rangeErr.Index)
+		require.Equal(t, statusListSize, rangeErr.Size)
+	})
+
+	t.Run("status list credential is cached", func(t *testing.T) {
+		fetches := 0
+		cache := make(mapStatusListCache)
+
+		checker := newChecker(nil, WithStatusListCache(cache), WithStatusListFetcher(func(string) ([]byte, error) {
+			fetches++
+
+			return revocationList, nil
+		}))
+
+		for i := 0; i < 2; i++ {
+			result, err := checker.Check(newStatusListVC(StatusPurposeRevocation, "94567"))
+			require.NoError(t, err)
+			require.True(t, result.Revoked())
+		}
+
+		require.Equal(t, 1, fetches)
+		require.Contains(t, cache, statusListURL)
+	})
+
+	t.Run("status list credential from HTTP", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/status/3" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, err := w.Write(revocationList)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		checker := NewStatusChecker(WithStatusListCredentialOpts(
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))))
+
+		vc := newStatusListVC(StatusPurposeRevocation, "94567")
+		vc.Status.CustomFields[statusListCredentialField] = srv.URL + "/status/3"
+
+		result, err := checker.Check(vc)
+		require.NoError(t, err)
+		require.True(t, result.Revoked())
+
+		vc.Status.CustomFields[statusListCredentialField] = srv.URL + "/status/4"
+
+		_, err = checker.Check(vc)
+		require.EqualError(t, err,
+			"fetch status list credential: status list credential endpoint HTTP failure [404]")
+	})
+
+	t.Run("status list credential from HTTPS with the given client", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(revocationList)
+			require.NoError(t, err)
+		}))
+		defer srv.Close()
+
+		vc := newStatusListVC(StatusPurposeRevocation, "94567")
+		vc.Status.CustomFields[statusListCredentialField] = srv.URL + "/status/3"
+
+		credentialOpts := WithStatusListCredentialOpts(
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+
+		result, err := NewStatusChecker(credentialOpts, WithStatusListHTTPClient(srv.Client())).Check(vc)
+		require.NoError(t, err)
+		require.True(t, result.Revoked())
+
+		// the certificate of the test server is not trusted by the default client.
+		_, err = NewStatusChecker(credentialOpts).Check(vc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "certificate")
+	})
+
+	t.Run("status list credential signed by another key", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		checker := newChecker(revocationList, WithStatusListCredentialOpts(
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519))))
+
+		_, err = checker.Check(newStatusListVC(StatusPurposeRevocation, "94567"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse status list credential")
+	})
+
+	t.Run("status list credential without proof", func(t *testing.T) {
+		unsigned := []byte(fmt.Sprintf(statusListCredentialTemplate, StatusPurposeRevocation,
+			encodeStatusList(t, 94567)))
+
+		_, err := newChecker(unsigned).Check(newStatusListVC(StatusPurposeRevocation, "94567"))
+		require.EqualError(t, err, "status list credential is not secured by a proof")
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		checker := newChecker(nil, WithStatusListFetcher(func(string) ([]byte, error) {
+			return nil, errors.New("fetch error")
+		}))
+
+		_, err := checker.Check(newStatusListVC(StatusPurposeRevocation, "94567"))
+		require.EqualError(t, err, "fetch status list credential: fetch error")
+	})
+
+	t.Run("invalid credential status", func(t *testing.T) {
+		checker := newChecker(revocationList)
+
+		_, err := checker.Check(&Credential{})
+		require.EqualError(t, err, "credential has no credentialStatus")
+
+		_, err = checker.Check(&Credential{Status: &TypedID{Type: "RevocationList2020Status"}})
+		require.EqualError(t, err, "unsupported credentialStatus type: RevocationList2020Status")
+
+		vc := newStatusListVC("refresh", "1")
+		_, err = checker.Check(vc)
+		require.EqualError(t, err, "unsupported statusPurpose: refresh")
+
+		vc = newStatusListVC(StatusPurposeRevocation, "-1")
+		_, err = checker.Check(vc)
+		require.EqualError(t, err, "invalid statusListIndex: -1")
+
+		vc = newStatusListVC(StatusPurposeRevocation, "1")
+		delete(vc.Status.CustomFields, statusListCredentialField)
+		_, err = checker.Check(vc)
+		require.EqualError(t, err, "statusListCredential is missing or not a string")
+	})
+}
+
+func TestDecodeStatusList(t *testing.T) {
+	bitstring, err := decodeStatusList(encodeStatusList(t, 0, 9))
+	require.NoError(t, err)
+	require.Len(t, bitstring, statusListSize/bitsPerByte)
+	require.Equal(t, []byte{0x80, 0x40}, bitstring[:2])
+
+	_, err = decodeStatusList("not base64!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decode status list")
+
+	_, err = decodeStatusList(base64.RawURLEncoding.EncodeToString([]byte("not gzip")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decompress status list")
+}
+
+func newStatusListVC(purpose, index string) *Credential {
+	return &Credential{
+		Status: &TypedID{
+			ID:   statusListURL + "#" + index,
+			Type: StatusList2021EntryType,
+			CustomFields: CustomFields{
+				statusPurposeField:        purpose,
+				statusListIndexField:      index,
+				statusListCredentialField: statusListURL,
+			},
+		},
+	}
+}
+
+func encodeStatusList(t *testing.T, setIndexes ...int) string {
+	t.Helper()
+
+	bitstring := make([]byte, statusListSize/bitsPerByte)
+
+	for _, i := range setIndexes {
+		bitstring[i/bitsPerByte] |= 1 << (bitsPerByte - 1 - i%bitsPerByte)
+	}
+
+	return compressStatusList(t, bitstring)
+}
+
+func compressStatusList(t *testing.T, bitstring []byte) string {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+
+	w := gzip.NewWriter(buf)
+
+	_, err := w.Write(bitstring)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+func createStatusListCredential(t *testing.T, signer Signer, purpose string, setIndexes ...int) []byte {
+	t.Helper()
+
+	return signStatusListCredential(t, signer,
+		[]byte(fmt.Sprintf(statusListCredentialTemplate, purpose, encodeStatusList(t, setIndexes...))))
+}
+
+func signStatusListCredential(t *testing.T, signer Signer, statusListVC []byte) []byte {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, statusListVC)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		VerificationMethod:      "did:example:12345#key1",
+	}, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	return vcBytes
+}
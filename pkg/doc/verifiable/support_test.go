@@ -31,6 +31,9 @@ import (
 //go:embed testdata/valid_credential.jsonld
 var validCredential string //nolint:gochecknoglobals
 
+//go:embed testdata/credential_with_evidence.jsonld
+var credentialWithEvidenceAndTermsOfUse string //nolint:gochecknoglobals
+
 func (rc *rawCredential) stringJSON(t *testing.T) string {
 	bytes, err := json.Marshal(rc)
 	require.NoError(t, err)
@@ -10,7 +10,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
@@ -19,6 +22,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
 	legacy "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/authcrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didrotate"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/introduce"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/mediator"
@@ -26,10 +31,13 @@ import (
 	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
 	mdpresentproof "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofband"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/revocationnotification"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	arieshttp "github.com/hyperledger/aries-framework-go/pkg/didcomm/transport/http"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -71,8 +79,9 @@ func defFrameworkOpts(frameworkOpts *Aries) error {
 	// - OutOfBand depends on DIDExchange
 	// - Introduce depends on OutOfBand
 	frameworkOpts.protocolSvcCreators = append(frameworkOpts.protocolSvcCreators,
-		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(),
-		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc())
+		newMessagePickupSvc(), newRouteSvc(), newExchangeSvc(), newOutOfBandSvc(), newOutOfBandV2Svc(),
+		newIntroduceSvc(), newIssueCredentialSvc(), newPresentProofSvc(), newDIDRotateSvc(),
+		newDiscoverFeaturesSvc(frameworkOpts.discoverFeaturesTimeout), newRevocationNotificationSvc())
 
 	if frameworkOpts.secretLock == nil && frameworkOpts.kmsCreator == nil {
 		err = createDefSecretLock(frameworkOpts)
@@ -152,6 +161,79 @@ func newOutOfBandSvc() api.ProtocolSvcCreator {
 	}
 }
 
+func newOutOfBandV2Svc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return outofbandv2.New(prv)
+	}
+}
+
+func newDIDRotateSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return didrotate.New(prv)
+	}
+}
+
+func newDiscoverFeaturesSvc(queryTimeout time.Duration) api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		var opts []discoverfeatures.Opt
+
+		if queryTimeout > 0 {
+			opts = append(opts, discoverfeatures.WithQueryTimeout(queryTimeout))
+		}
+
+		return discoverfeatures.New(prv, opts...)
+	}
+}
+
+// protocolPIURIs are the PIURIs of the protocols of the built-in services, by service name.
+// nolint:gochecknoglobals
+var protocolPIURIs = map[string][]string{
+	didexchange.DIDExchange:     {didexchange.PIURI},
+	introduce.Introduce:         {piuri(introduce.IntroduceSpec)},
+	issuecredential.Name:        {piuri(issuecredential.Spec), piuri(issuecredential.SpecV3)},
+	presentproof.Name:           {piuri(presentproof.Spec), piuri(presentproof.SpecV3)},
+	mediator.Coordination:       {piuri(mediator.CoordinationSpec), piuri(mediator.CoordinationSpecV2)},
+	messagepickup.MessagePickup: {piuri(messagepickup.Spec), piuri(messagepickup.SpecV2)},
+	outofband.Name:              {outofband.PIURI},
+	outofbandv2.Name:            {outofbandv2.PIURI},
+	didrotate.Name:              {didrotate.PIURI},
+	revocationnotification.Name: {revocationnotification.PIURI},
+}
+
+// piuri returns the PIURI of the protocol of the message type prefix (spec).
+func piuri(spec string) string {
+	return strings.TrimSuffix(spec, "/")
+}
+
+// registerFeatures registers the protocols of the loaded built-in services with the discover features service, so
+// that they are disclosed to the other agents. The services of other protocols (see WithProtocols) register their
+// protocols themselves.
+func registerFeatures(services []dispatcher.ProtocolService) {
+	var features *discoverfeatures.Service
+
+	for _, svc := range services {
+		if s, ok := svc.(*discoverfeatures.Service); ok {
+			features = s
+		}
+	}
+
+	if features == nil {
+		return
+	}
+
+	for _, svc := range services {
+		for _, piuri := range protocolPIURIs[svc.Name()] {
+			features.RegisterProtocol(piuri)
+		}
+	}
+}
+
+func newRevocationNotificationSvc() api.ProtocolSvcCreator {
+	return func(prv api.Provider) (dispatcher.ProtocolService, error) {
+		return revocationnotification.New(prv)
+	}
+}
+
 func setDefaultKMSCryptOpts(frameworkOpts *Aries) error {
 	if frameworkOpts.kmsCreator == nil {
 		frameworkOpts.kmsCreator = func(provider kms.Provider) (kms.KeyManager, error) {
@@ -182,10 +264,22 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 		frameworkOpts.keyType = kms.ED25519Type
 	}
 
+	if frameworkOpts.signatureSuiteRegistry == nil {
+		frameworkOpts.signatureSuiteRegistry = docverifiable.NewSuiteRegistry()
+	}
+
 	if frameworkOpts.keyAgreementType == "" {
 		frameworkOpts.keyAgreementType = kms.X25519ECDHKWType
 	}
 
+	if frameworkOpts.contentEncryption == "" {
+		frameworkOpts.contentEncryption = jose.A256GCM
+	}
+
+	if frameworkOpts.clock == nil {
+		frameworkOpts.clock = clock.SystemClock{}
+	}
+
 	if frameworkOpts.packerCreator == nil {
 		frameworkOpts.packerCreator = func(provider packer.Provider) (packer.Packer, error) {
 			return legacy.New(provider), nil
@@ -199,13 +293,17 @@ func setAdditionalDefaultOpts(frameworkOpts *Aries) error {
 				return authcrypt.New(provider, jose.A128CBCHS256)
 			},
 			func(provider packer.Provider) (packer.Packer, error) {
-				return anoncrypt.New(provider, jose.A256GCM)
+				return anoncrypt.New(provider, frameworkOpts.contentEncryption)
 			},
 		}
 	}
 
 	if frameworkOpts.packagerCreator == nil {
 		frameworkOpts.packagerCreator = func(prov packager.Provider) (transport.Packager, error) {
+			if frameworkOpts.replayStore != nil {
+				return packager.New(prov, packager.WithReplayStore(frameworkOpts.replayStore))
+			}
+
 			return packager.New(prov)
 		}
 	}
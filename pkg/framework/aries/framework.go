@@ -8,11 +8,14 @@ package aries
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
@@ -21,7 +24,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
@@ -30,8 +36,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -63,6 +71,9 @@ type Aries struct {
 	packers                    []packer.Packer
 	vdrRegistry                vdrapi.Registry
 	vdr                        []vdrapi.VDR
+	fallbackVDR                []vdrapi.VDR
+	vdrResolveCacheSize        int
+	vdrResolveCacheTTL         time.Duration
 	verifiableStore            verifiable.Store
 	didConnectionStore         did.ConnectionStore
 	jsonldDocumentLoader       ld.DocumentLoader
@@ -70,6 +81,14 @@ type Aries struct {
 	id                         string
 	keyType                    kms.KeyType
 	keyAgreementType           kms.KeyType
+	contentEncryption          jose.EncAlg
+	clock                      clock.Clock
+	httpClient                 *http.Client
+	remoteJSONLDContexts       bool
+	statusChecker              *docverifiable.StatusChecker
+	signatureSuiteRegistry     *suite.Registry
+	replayStore                packager.ReplayStore
+	discoverFeaturesTimeout    time.Duration
 }
 
 // Option configures the framework.
@@ -119,6 +138,9 @@ func initializeServices(frameworkOpts *Aries) (*Aries, error) {
 		return nil, e
 	}
 
+	// Create status checker (must be done after vdr)
+	createStatusChecker(frameworkOpts)
+
 	// create packers and packager (must be done after KMS and connection store)
 	if err := createPackersAndPackager(frameworkOpts); err != nil {
 		return nil, err
@@ -249,6 +271,27 @@ func WithVDR(v vdrapi.VDR) Option {
 	}
 }
 
+// WithFallbackVDR injects a VDR service to the Aries framework, consulted only for DID methods not supported by the
+// default VDRs nor by the ones injected with WithVDR (eg: a Universal Resolver httpbinding VDR).
+func WithFallbackVDR(v vdrapi.VDR) Option {
+	return func(opts *Aries) error {
+		opts.fallbackVDR = append(opts.fallbackVDR, v)
+		return nil
+	}
+}
+
+// WithVDRResolveCache enables an LRU cache of up to size DID resolutions in front of the VDR registry created by the
+// framework, see vdr.WithResolveCache. Cached resolutions expire after ttl (never if ttl is 0). There is no cache by
+// default.
+func WithVDRResolveCache(size int, ttl time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.vdrResolveCacheSize = size
+		opts.vdrResolveCacheTTL = ttl
+
+		return nil
+	}
+}
+
 // WithMessageServiceProvider injects a message service provider to the Aries framework.
 // Message service provider returns list of message services which can be used to provide custom handle
 // functionality based on incoming messages type and purpose.
@@ -295,6 +338,16 @@ func WithJSONLDDocumentLoader(loader ld.DocumentLoader) Option {
 	}
 }
 
+// WithSignatureSuiteRegistry injects the registry of the signature suites which verify the embedded linked data
+// proofs of credentials, e.g. with suites of custom proof types registered. A registry seeded with the built-in
+// suites (see verifiable.NewSuiteRegistry) is used by default.
+func WithSignatureSuiteRegistry(registry *suite.Registry) Option {
+	return func(opts *Aries) error {
+		opts.signatureSuiteRegistry = registry
+		return nil
+	}
+}
+
 // WithKeyType injects a default signing key type.
 func WithKeyType(keyType kms.KeyType) Option {
 	return func(opts *Aries) error {
@@ -311,6 +364,66 @@ func WithKeyAgreementType(keyAgreementType kms.KeyType) Option {
 	}
 }
 
+// WithContentEncryption sets the content encryption algorithm of the default Anoncrypt packer, e.g. jose.A128GCM
+// where AES-128 is mandated. jose.A256GCM is used by default.
+func WithContentEncryption(encAlg jose.EncAlg) Option {
+	return func(opts *Aries) error {
+		opts.contentEncryption = encAlg
+		return nil
+	}
+}
+
+// WithReplayStore enables the replay protection of the inbound envelopes unpacked by the default packager: the
+// replayed envelopes recorded in the store are rejected, e.g. with packager.NewMemReplayStore(window) within a
+// sliding window. There is no replay protection by default.
+func WithReplayStore(store packager.ReplayStore) Option {
+	return func(opts *Aries) error {
+		opts.replayStore = store
+		return nil
+	}
+}
+
+// WithHTTPClient sets the HTTP client the framework fetches remote resources with, so TLS certificate pinning,
+// proxies and timeouts can be configured in one place. It is used to resolve did:web DIDs, to download status list
+// credentials (see context.Provider.StatusChecker()) and to fetch remote JSON-LD contexts if enabled with
+// WithRemoteJSONLDContexts. It is also available to the clients through context.Provider.HTTPClient().
+// DIDComm messages are sent with the outbound transports (see WithOutboundTransports), not with this client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *Aries) error {
+		opts.httpClient = client
+		return nil
+	}
+}
+
+// WithRemoteJSONLDContexts enables the default JSON-LD document loader to fetch the contexts missing from its storage
+// from the network, with the client of WithHTTPClient. Only the embedded and stored contexts are loaded by default.
+func WithRemoteJSONLDContexts() Option {
+	return func(opts *Aries) error {
+		opts.remoteJSONLDContexts = true
+		return nil
+	}
+}
+
+// WithClock injects the clock the time-based checks of the framework (the ~timing of inbound messages and the expiry
+// of remote JSON-LD contexts) read the current time from, tests can inject a fixed or fast-forwarded clock. The system
+// clock is used by default. The clock is available to the services from the context, e.g. to be passed to the
+// validity period checks of JWTs and credentials.
+func WithClock(c clock.Clock) Option {
+	return func(opts *Aries) error {
+		opts.clock = c
+		return nil
+	}
+}
+
+// WithDiscoverFeaturesQueryTimeout sets the time the discover features queries wait for the disclose of the other
+// party (discoverfeatures.DefaultQueryTimeout by default).
+func WithDiscoverFeaturesQueryTimeout(timeout time.Duration) Option {
+	return func(opts *Aries) error {
+		opts.discoverFeaturesTimeout = timeout
+		return nil
+	}
+}
+
 // Context provides a handle to the framework context.
 func (a *Aries) Context() (*context.Provider, error) {
 	return context.New(
@@ -336,6 +449,10 @@ func (a *Aries) Context() (*context.Provider, error) {
 		context.WithJSONLDDocumentLoader(a.jsonldDocumentLoader),
 		context.WithKeyType(a.keyType),
 		context.WithKeyAgreementType(a.keyAgreementType),
+		context.WithClock(a.clock),
+		context.WithHTTPClient(a.httpClient),
+		context.WithStatusChecker(a.statusChecker),
+		context.WithSignatureSuiteRegistry(a.signatureSuiteRegistry),
 	)
 }
 
@@ -367,9 +484,26 @@ func (a *Aries) Close() error {
 		}
 	}
 
+	if err := a.stopOutboundTransports(); err != nil {
+		return err
+	}
+
 	return a.closeVDR()
 }
 
+// stopOutboundTransports stops the outbound transports keeping connections open (e.g. websocket).
+func (a *Aries) stopOutboundTransports() error {
+	for _, outbound := range a.outboundTransports {
+		if stopper, ok := outbound.(interface{ Stop() error }); ok {
+			if err := stopper.Stop(); err != nil {
+				return fmt.Errorf("outbound transport close failed: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *Aries) closeVDR() error {
 	if a.vdrRegistry != nil {
 		if err := a.vdrRegistry.Close(); err != nil {
@@ -425,7 +559,16 @@ func createVDR(frameworkOpts *Aries) error {
 	)
 
 	k := key.New()
-	opts = append(opts, vdr.WithVDR(k))
+	w := web.New(web.WithHTTPClient(frameworkOpts.httpClient))
+	opts = append(opts, vdr.WithVDR(k), vdr.WithVDR(w), vdr.WithVDR(jwk.New()))
+
+	for _, v := range frameworkOpts.fallbackVDR {
+		opts = append(opts, vdr.WithFallbackVDR(v))
+	}
+
+	if frameworkOpts.vdrResolveCacheSize > 0 {
+		opts = append(opts, vdr.WithResolveCache(frameworkOpts.vdrResolveCacheSize, frameworkOpts.vdrResolveCacheTTL))
+	}
 
 	frameworkOpts.vdrRegistry = vdr.New(opts...)
 
@@ -491,12 +634,30 @@ func createDIDConnectionStore(frameworkOpts *Aries) error {
 	return err
 }
 
+func createStatusChecker(frameworkOpts *Aries) {
+	frameworkOpts.statusChecker = docverifiable.NewStatusChecker(
+		docverifiable.WithStatusListHTTPClient(frameworkOpts.httpClient),
+		docverifiable.WithStatusListCredentialOpts(
+			docverifiable.WithPublicKeyFetcher(
+				docverifiable.NewVDRKeyResolver(frameworkOpts.vdrRegistry).PublicKeyFetcher()),
+			docverifiable.WithJSONLDDocumentLoader(frameworkOpts.jsonldDocumentLoader),
+			docverifiable.WithSignatureSuiteRegistry(frameworkOpts.signatureSuiteRegistry),
+		),
+	)
+}
+
 func createJSONLDDocumentLoader(frameworkOpts *Aries) error {
 	if frameworkOpts.jsonldDocumentLoader != nil {
 		return nil
 	}
 
-	l, err := jsonld.NewDocumentLoader(frameworkOpts.storeProvider)
+	opts := []jsonld.DocumentLoaderOpts{jsonld.WithClock(frameworkOpts.clock)}
+
+	if frameworkOpts.remoteJSONLDContexts {
+		opts = append(opts, jsonld.WithRemoteDocumentLoader(ld.NewDefaultDocumentLoader(frameworkOpts.httpClient)))
+	}
+
+	l, err := jsonld.NewDocumentLoader(frameworkOpts.storeProvider, opts...)
 	if err != nil {
 		return fmt.Errorf("document loader creation failed: %w", err)
 	}
@@ -553,6 +714,9 @@ func loadServices(frameworkOpts *Aries) error {
 		context.WithDIDConnectionStore(frameworkOpts.didConnectionStore),
 		context.WithMessageServiceProvider(frameworkOpts.msgSvcProvider),
 		context.WithJSONLDDocumentLoader(frameworkOpts.jsonldDocumentLoader),
+		context.WithHTTPClient(frameworkOpts.httpClient),
+		context.WithStatusChecker(frameworkOpts.statusChecker),
+		context.WithSignatureSuiteRegistry(frameworkOpts.signatureSuiteRegistry),
 	)
 	if err != nil {
 		return fmt.Errorf("create context failed: %w", err)
@@ -572,6 +736,8 @@ func loadServices(frameworkOpts *Aries) error {
 		}
 	}
 
+	registerFeatures(frameworkOpts.services)
+
 	return nil
 }
 
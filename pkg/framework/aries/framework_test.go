@@ -15,22 +15,33 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packager"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/discoverfeatures"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	mocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
@@ -38,6 +49,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/msghandler"
@@ -51,7 +63,9 @@ import (
 	locallock "github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/web"
 )
 
 //nolint:lll
@@ -141,6 +155,93 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - with user provided fallback", func(t *testing.T) {
+		fallback := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}
+
+		aries, err := New(WithFallbackVDR(fallback), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		require.Equal(t, []vdrapi.VDR{fallback}, aries.fallbackVDR)
+
+		docResolution, err := aries.vdrRegistry.Resolve("did:ion:123")
+		require.NoError(t, err)
+		require.Equal(t, "did:ion:123", docResolution.DIDDocument.ID)
+
+		// did:key is still handled by the default VDR.
+		docResolution, err = aries.vdrRegistry.Resolve("did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH")
+		require.NoError(t, err)
+		require.Equal(t, "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH", docResolution.DIDDocument.ID)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test vdr - with resolve cache", func(t *testing.T) {
+		reads := 0
+
+		v := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			},
+		}
+
+		aries, err := New(WithVDR(v), WithVDRResolveCache(10, time.Minute),
+			WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			docResolution, e := aries.vdrRegistry.Resolve("did:example:123")
+			require.NoError(t, e)
+			require.Equal(t, "did:example:123", docResolution.DIDDocument.ID)
+		}
+
+		require.Equal(t, 1, reads)
+
+		registry, ok := aries.vdrRegistry.(*vdr.Registry)
+		require.True(t, ok)
+
+		registry.InvalidateCache("did:example:123")
+
+		_, err = aries.vdrRegistry.Resolve("did:example:123")
+		require.NoError(t, err)
+		require.Equal(t, 2, reads)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test vdr - no resolve cache by default", func(t *testing.T) {
+		reads := 0
+
+		v := &mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				reads++
+
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}
+
+		aries, err := New(WithVDR(v), WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = aries.vdrRegistry.Resolve("did:example:123")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, reads)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test error create vdr", func(t *testing.T) {
 		sp := storage.NewMockStoreProvider()
 		sp.FailNamespace = peer.StoreNamespace
@@ -190,6 +291,43 @@ func TestFramework(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("test vdr - resolve did:web with default vdr", func(t *testing.T) {
+		const webDoc = `{"@context": ["https://w3id.org/did/v1"], "id": "did:web:example.com"}`
+
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, e := w.Write([]byte(webDoc))
+			require.NoError(t, e)
+		}))
+		defer s.Close()
+
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		webDID := "did:web:" + url.QueryEscape(strings.TrimPrefix(s.URL, "https://"))
+
+		docResolution, err := aries.vdrRegistry.Resolve(webDID, vdrapi.WithOption(web.HTTPClientOpt, s.Client()))
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com", docResolution.DIDDocument.ID)
+
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test vdr - resolve did:jwk with default vdr", func(t *testing.T) {
+		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
+		require.NoError(t, err)
+		require.NotEmpty(t, aries)
+
+		//nolint:lll
+		jwkDID := "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9"
+
+		docResolution, err := aries.vdrRegistry.Resolve(jwkDID)
+		require.NoError(t, err)
+		require.Equal(t, jwkDID, docResolution.DIDDocument.ID)
+
+		require.NoError(t, aries.Close())
+	})
+
 	t.Run("test protocol svc - with default protocol", func(t *testing.T) {
 		aries, err := New(WithInboundTransport(&mockInboundTransport{}))
 		require.NoError(t, err)
@@ -637,6 +775,214 @@ func TestFramework(t *testing.T) {
 		require.Equal(t, kms.BLS12381G2Type, aries.keyType)
 		require.Equal(t, kms.NISTP384ECDHKWType, aries.keyAgreementType)
 	})
+
+	t.Run("test content encryption option", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.Equal(t, jose.A256GCM, aries.contentEncryption)
+
+		aries, err = New(WithContentEncryption(jose.A128GCM))
+		require.NoError(t, err)
+		require.Equal(t, jose.A128GCM, aries.contentEncryption)
+
+		_, recipientKey, err := aries.kms.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		var anoncryptPacker *anoncrypt.Packer
+
+		for _, p := range aries.packers {
+			if ap, ok := p.(*anoncrypt.Packer); ok {
+				anoncryptPacker = ap
+			}
+		}
+
+		require.NotNil(t, anoncryptPacker)
+
+		packed, err := anoncryptPacker.Pack(transport.MediaTypeV1PlaintextPayload, []byte("{}"), nil,
+			[][]byte{recipientKey})
+		require.NoError(t, err)
+
+		jwe, err := jose.Deserialize(string(packed))
+		require.NoError(t, err)
+
+		encAlg, ok := jwe.ProtectedHeaders.Encryption()
+		require.True(t, ok)
+		require.Equal(t, string(jose.A128GCM), encAlg)
+	})
+
+	t.Run("test replay store option", func(t *testing.T) {
+		aries, err := New(WithReplayStore(packager.NewMemReplayStore(time.Minute)))
+		require.NoError(t, err)
+
+		_, recipientKey, err := aries.kms.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		var anoncryptPacker *anoncrypt.Packer
+
+		for _, p := range aries.packers {
+			if ap, ok := p.(*anoncrypt.Packer); ok {
+				anoncryptPacker = ap
+			}
+		}
+
+		require.NotNil(t, anoncryptPacker)
+
+		packed, err := anoncryptPacker.Pack(transport.MediaTypeV1PlaintextPayload, []byte(`{"@id":"1"}`), nil,
+			[][]byte{recipientKey})
+		require.NoError(t, err)
+
+		_, err = aries.packager.UnpackMessage(packed)
+		require.NoError(t, err)
+
+		_, err = aries.packager.UnpackMessage(packed)
+		require.True(t, errors.Is(err, packager.ErrReplayedMessage))
+	})
+
+	t.Run("test built-in protocols are disclosed", func(t *testing.T) {
+		aries, err := New(WithDiscoverFeaturesQueryTimeout(time.Second))
+		require.NoError(t, err)
+		require.Equal(t, time.Second, aries.discoverFeaturesTimeout)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+
+		svc, err := ctx.Service(discoverfeatures.Name)
+		require.NoError(t, err)
+
+		var disclosed []string
+
+		for _, feature := range svc.(*discoverfeatures.Service).Disclose(discoverfeatures.Query{
+			FeatureType: discoverfeatures.FeatureTypeProtocol,
+			Match:       "*",
+		}) {
+			disclosed = append(disclosed, feature.ID)
+		}
+
+		require.Subset(t, disclosed, []string{
+			discoverfeatures.PIURI,
+			didexchange.PIURI,
+			"https://didcomm.org/issue-credential/2.0",
+			"https://didcomm.org/issue-credential/3.0",
+			"https://didcomm.org/present-proof/3.0",
+			"https://didcomm.org/coordinate-mediation/2.0",
+			"https://didcomm.org/out-of-band/2.0",
+		})
+	})
+
+	t.Run("test signature suite registry", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.NotNil(t, ctx.SignatureSuiteRegistry())
+		require.Len(t, ctx.SignatureSuiteRegistry().Suites("Ed25519Signature2018", ""), 1)
+		require.Len(t, ctx.SignatureSuiteRegistry().Suites("DataIntegrityProof", "ecdsa-rdfc-2019"), 2)
+		require.NoError(t, aries.Close())
+
+		registry := suite.NewRegistry()
+
+		aries, err = New(WithSignatureSuiteRegistry(registry))
+		require.NoError(t, err)
+
+		ctx, err = aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, registry, ctx.SignatureSuiteRegistry())
+		require.NoError(t, aries.Close())
+	})
+
+	t.Run("test HTTP client option", func(t *testing.T) {
+		requests := 0
+
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			w.Header().Set("Content-Type", "application/ld+json")
+
+			_, err := w.Write([]byte(`{"@context": ["https://w3id.org/did/v1"], "id": "did:web:example.com"}`))
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		didWeb := "did:web:" + url.QueryEscape(strings.TrimPrefix(server.URL, "https://"))
+
+		// the certificate of the test server is only trusted by the client of the server.
+		aries, err := New(WithHTTPClient(server.Client()))
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, aries.Close())
+		}()
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, server.Client(), ctx.HTTPClient())
+
+		docResolution, err := ctx.VDRegistry().Resolve(didWeb)
+		require.NoError(t, err)
+		require.Equal(t, "did:web:example.com", docResolution.DIDDocument.ID)
+		require.Equal(t, 1, requests)
+
+		// remote JSON-LD contexts are not fetched unless enabled
+		_, err = ctx.JSONLDDocumentLoader().LoadDocument(server.URL + "/context")
+		require.Error(t, err)
+		require.Equal(t, 1, requests)
+
+		// status lists are downloaded with the client, the response is not a status list credential
+		_, err = ctx.StatusChecker().Check(&docverifiable.Credential{Status: &docverifiable.TypedID{
+			Type: docverifiable.StatusList2021EntryType,
+			CustomFields: map[string]interface{}{
+				"statusPurpose":        docverifiable.StatusPurposeRevocation,
+				"statusListIndex":      "1",
+				"statusListCredential": server.URL + "/status",
+			},
+		}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse status list credential")
+		require.Equal(t, 2, requests)
+
+		remoteAries, err := New(WithHTTPClient(server.Client()), WithRemoteJSONLDContexts())
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, remoteAries.Close())
+		}()
+
+		_, err = remoteAries.jsonldDocumentLoader.LoadDocument(server.URL + "/context")
+		require.NoError(t, err)
+		require.Equal(t, 3, requests)
+
+		defaultAries, err := New()
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, defaultAries.Close())
+		}()
+
+		_, err = defaultAries.vdrRegistry.Resolve(didWeb)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "certificate")
+		require.Equal(t, 3, requests)
+	})
+
+	t.Run("test clock option", func(t *testing.T) {
+		aries, err := New()
+		require.NoError(t, err)
+		require.Equal(t, clock.SystemClock{}, aries.clock)
+
+		ctx, err := aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, clock.SystemClock{}, ctx.Clock())
+
+		fixed := mockclock.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		aries, err = New(WithClock(fixed))
+		require.NoError(t, err)
+
+		ctx, err = aries.Context()
+		require.NoError(t, err)
+		require.Equal(t, fixed, ctx.Clock())
+	})
 }
 
 func Test_Packager(t *testing.T) {
@@ -7,18 +7,29 @@ SPDX-License-Identifier: Apache-2.0
 package context
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/pkg/common/clock"
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/dispatcher"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
@@ -28,6 +39,11 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
+// expiresTimeV2 is the DIDComm V2 message header holding the expiry time, in seconds since the epoch.
+const expiresTimeV2 = "expires_time"
+
+var logger = log.New("aries-framework/context")
+
 // package context creates a framework Provider context to add optional (non default) framework services and provides
 // simple accessor methods to those same services.
 
@@ -56,6 +72,10 @@ type Provider struct {
 	frameworkID                string
 	keyType                    kms.KeyType
 	keyAgreementType           kms.KeyType
+	clock                      clock.Clock
+	httpClient                 *http.Client
+	statusChecker              *docverifiable.StatusChecker
+	signatureSuiteRegistry     *suite.Registry
 }
 
 type inboundHandler struct {
@@ -170,7 +190,7 @@ func (p *Provider) tryToHandle(
 // InboundMessageHandler return an inbound message handler.
 func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 	return func(envelope *transport.Envelope) error {
-		msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+		msg, props, err := p.parseInbound(envelope)
 		if err != nil {
 			return err
 		}
@@ -180,9 +200,13 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 			if svc.Accept(msg.Type()) {
 				var myDID, theirDID string
 
-				switch svc.Name() {
-				// perf: DID exchange doesn't require myDID and theirDID
-				case didexchange.DIDExchange:
+				switch {
+				// perf: DID exchange doesn't require myDID and theirDID, except to check the sender of problem reports
+				case svc.Name() == didexchange.DIDExchange && msg.Type() != didexchange.ProblemReportMsgType:
+				case svc.Name() == didexchange.DIDExchange:
+					props[didexchange.SenderKeyPropKey] = base58.Encode(envelope.FromKey)
+
+					fallthrough
 				default:
 					myDID, theirDID, err = p.getDIDs(envelope)
 					if err != nil {
@@ -190,9 +214,19 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 					}
 				}
 
-				_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, nil))
+				// a presentation of a connection-less request must be sent to the service of the request
+				if svc.Name() == presentproof.Name {
+					props[presentproof.RecipientKeyPropKey] = base58.Encode(envelope.ToKey)
+				}
 
-				return err
+				_, err = svc.HandleInbound(msg, service.NewDIDCommContext(myDID, theirDID, props))
+				if err != nil {
+					return err
+				}
+
+				p.ackReceipt(envelope, msg)
+
+				return nil
 			}
 		}
 
@@ -214,7 +248,14 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 					return fmt.Errorf("inbound message handler: %w", err)
 				}
 
-				return p.tryToHandle(svc, msg, service.NewDIDCommContext(myDID, theirDID, nil))
+				err = p.tryToHandle(svc, msg, service.NewDIDCommContext(myDID, theirDID, props))
+				if err != nil {
+					return err
+				}
+
+				p.ackReceipt(envelope, msg)
+
+				return nil
 			}
 		}
 
@@ -222,6 +263,127 @@ func (p *Provider) InboundMessageHandler() transport.InboundMessageHandler {
 	}
 }
 
+// parseInbound parses the message of envelope and returns it with the properties of its DIDCommContext.
+// Expired messages are rejected.
+func (p *Provider) parseInbound(envelope *transport.Envelope) (service.DIDCommMsgMap, map[string]interface{}, error) {
+	msg, err := service.ParseDIDCommMsgMap(envelope.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	props := make(map[string]interface{})
+
+	if !hasTiming(msg) {
+		return msg, props, nil
+	}
+
+	timing, err := getTiming(msg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("inbound message handler: %w", err)
+	}
+
+	if timing.Expired(p.Clock().Now()) {
+		return nil, nil, p.rejectExpired(envelope, msg, timing)
+	}
+
+	props[decorator.TimingPropKey] = timing
+
+	return msg, props, nil
+}
+
+func hasTiming(msg service.DIDCommMsgMap) bool {
+	if msg.IsDIDCommV2() {
+		_, ok := msg[expiresTimeV2]
+
+		return ok
+	}
+
+	_, ok := msg[decorator.TimingPropKey]
+
+	return ok
+}
+
+// getTiming returns the timing of msg: the ~timing decorator of DIDComm V1 messages or the expires_time header of
+// DIDComm V2 messages.
+func getTiming(msg service.DIDCommMsgMap) (*decorator.Timing, error) {
+	if msg.IsDIDCommV2() {
+		expiresTime, ok := msg[expiresTimeV2].(float64)
+		if !ok {
+			return nil, errors.New("expires_time is not a number")
+		}
+
+		return &decorator.Timing{ExpiresTime: time.Unix(int64(expiresTime), 0)}, nil
+	}
+
+	timingBytes, err := json.Marshal(msg[decorator.TimingPropKey])
+	if err != nil {
+		return nil, fmt.Errorf("marshal ~timing: %w", err)
+	}
+
+	timing := &decorator.Timing{}
+
+	err = json.Unmarshal(timingBytes, timing)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal ~timing: %w", err)
+	}
+
+	return timing, nil
+}
+
+// rejectExpired replies to an expired message with a problem report, if the sender is known, instead of handling it.
+func (p *Provider) rejectExpired(envelope *transport.Envelope, msg service.DIDCommMsgMap,
+	timing *decorator.Timing) error {
+	expiredErr := fmt.Errorf("inbound message handler: message %s of type %s expired at %s",
+		msg.ID(), msg.Type(), timing.ExpiresTime.Format(time.RFC3339))
+
+	myDID, theirDID, err := p.getDIDs(envelope)
+	if err != nil || myDID == "" || theirDID == "" || p.messenger == nil {
+		return expiredErr
+	}
+
+	report := service.NewDIDCommMsgMap(&model.ProblemReport{
+		Type:        model.ProblemReportMsgType,
+		ID:          uuid.New().String(),
+		Description: model.Code{Code: model.MessageExpiredCode},
+		Comment:     fmt.Sprintf("message expired at %s", timing.ExpiresTime.Format(time.RFC3339)),
+	})
+
+	if msg.IsDIDCommV2() {
+		report = service.NewDIDCommMsgMap(&model.ProblemReportV2{
+			ID:   uuid.New().String(),
+			Type: model.ProblemReportV2MsgType,
+			Body: model.ProblemReportV2Body{Code: model.MessageExpiredCode},
+		})
+	}
+
+	err = p.messenger.ReplyToMsg(msg, report, myDID, theirDID)
+	if err != nil {
+		logger.Warnf("failed to send problem report for expired message %s: %v", msg.ID(), err)
+	}
+
+	return expiredErr
+}
+
+// ackReceipt sends an ack to the sender of msg if it requests one on receipt (~please_ack). It is called once msg
+// was handled successfully, so that messages failing to be handled are not acked.
+func (p *Provider) ackReceipt(envelope *transport.Envelope, msg service.DIDCommMsgMap) {
+	if p.messenger == nil || !model.RequestsAck(msg, decorator.AckOnReceipt) {
+		return
+	}
+
+	myDID, theirDID, err := p.getDIDs(envelope)
+	if err != nil || myDID == "" || theirDID == "" {
+		logger.Warnf("cannot ack receipt of message %s: sender is unknown", msg.ID())
+
+		return
+	}
+
+	err = model.SendAck(p.messenger, msg, myDID, theirDID, model.AckStatusOK)
+	if err != nil {
+		logger.Warnf("failed to ack receipt of message %s: %v", msg.ID(), err)
+	}
+}
+
 func (p *Provider) getDIDs(envelope *transport.Envelope) (string, string, error) {
 	myDID, err := p.didConnectionStore.GetDID(base58.Encode(envelope.ToKey))
 	if errors.Is(err, did.ErrNotFound) {
@@ -298,6 +460,50 @@ func (p *Provider) KeyAgreementType() kms.KeyType {
 	return p.keyAgreementType
 }
 
+// Clock returns the clock the time-based checks read the current time from, clock.SystemClock by default.
+func (p *Provider) Clock() clock.Clock {
+	return clock.OrSystem(p.clock)
+}
+
+// HTTPClient returns the HTTP client to fetch remote resources with (e.g. status list credentials),
+// a default client if none was injected.
+func (p *Provider) HTTPClient() *http.Client {
+	if p.httpClient == nil {
+		return &http.Client{}
+	}
+
+	return p.httpClient
+}
+
+// StatusChecker returns the checker of the status of credentials published in status lists, downloaded with the
+// HTTP client of the context and verified with its VDR and JSON-LD document loader if none was injected.
+func (p *Provider) StatusChecker() *docverifiable.StatusChecker {
+	if p.statusChecker != nil {
+		return p.statusChecker
+	}
+
+	opts := []docverifiable.CredentialOpt{docverifiable.WithJSONLDDocumentLoader(p.jsonldDocumentLoader)}
+
+	if p.signatureSuiteRegistry != nil {
+		opts = append(opts, docverifiable.WithSignatureSuiteRegistry(p.signatureSuiteRegistry))
+	}
+
+	if p.vdr != nil {
+		opts = append(opts, docverifiable.WithPublicKeyFetcher(docverifiable.NewVDRKeyResolver(p.vdr).PublicKeyFetcher()))
+	}
+
+	return docverifiable.NewStatusChecker(
+		docverifiable.WithStatusListHTTPClient(p.HTTPClient()),
+		docverifiable.WithStatusListCredentialOpts(opts...),
+	)
+}
+
+// SignatureSuiteRegistry returns the registry of the signature suites which verify the embedded linked data proofs
+// of credentials, seeded with the built-in suites by the framework. Custom suites can be registered in it.
+func (p *Provider) SignatureSuiteRegistry() *suite.Registry {
+	return p.signatureSuiteRegistry
+}
+
 // ProviderOption configures the framework.
 type ProviderOption func(opts *Provider) error
 
@@ -493,3 +699,36 @@ func WithKeyAgreementType(keyAgreementType kms.KeyType) ProviderOption {
 		}
 	}
 }
+
+// WithClock injects the clock the time-based checks read the current time from into the context.
+func WithClock(c clock.Clock) ProviderOption {
+	return func(opts *Provider) error {
+		opts.clock = c
+		return nil
+	}
+}
+
+// WithHTTPClient injects the HTTP client to fetch remote resources with into the context.
+func WithHTTPClient(client *http.Client) ProviderOption {
+	return func(opts *Provider) error {
+		opts.httpClient = client
+		return nil
+	}
+}
+
+// WithStatusChecker injects the checker of the status of credentials into the context.
+func WithStatusChecker(checker *docverifiable.StatusChecker) ProviderOption {
+	return func(opts *Provider) error {
+		opts.statusChecker = checker
+		return nil
+	}
+}
+
+// WithSignatureSuiteRegistry injects the registry of the signature suites which verify the embedded linked data
+// proofs of credentials into the context.
+func WithSignatureSuiteRegistry(registry *suite.Registry) ProviderOption {
+	return func(opts *Provider) error {
+		opts.signatureSuiteRegistry = registry
+		return nil
+	}
+}
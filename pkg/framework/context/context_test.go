@@ -9,6 +9,7 @@ package context
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -17,14 +18,19 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/model"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/didexchange"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	docverifiable "github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
 	serviceMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/didcomm/common/service"
 	didStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/did"
 	verifiableStoreMocks "github.com/hyperledger/aries-framework-go/pkg/internal/gomocks/store/verifiable"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockclock "github.com/hyperledger/aries-framework-go/pkg/mock/clock"
 	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
 	mockdidcomm "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm"
 	mockdispatcher "github.com/hyperledger/aries-framework-go/pkg/mock/didcomm/dispatcher"
@@ -189,6 +195,51 @@ func TestNewProvider(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("inbound message handler for didexchange problem report gets the DIDs", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:my", nil)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("", did.ErrNotFound)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: didexchange.DIDExchange,
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc:   func(msg service.DIDCommMsg) (string, error) { return uuid.New().String(), nil },
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{
+			"@id": "5678876542345",
+			"@type": "` + didexchange.ProblemReportMsgType + `"
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.NoError(t, err)
+	})
+
+	t.Run("inbound message handler for present-proof passes the recipient key", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("", did.ErrNotFound)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("", did.ErrNotFound)
+
+		received := make(chan service.DIDCommContext, 1)
+
+		ctx, err := New(WithProtocolServices(&contextRecorderSvc{
+			MockDIDExchangeSvc: &mockdidexchange.MockDIDExchangeSvc{
+				ProtocolName: presentproof.Name,
+				AcceptFunc:   func(msgType string) bool { return true },
+			},
+			contexts: received,
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`{
+			"@id": "5678876542345",
+			"@type": "` + presentproof.PresentationMsgType + `"
+		}`), FromKey: []byte("fromKey"), ToKey: []byte("toKey")})
+		require.NoError(t, err)
+		require.Equal(t, base58.Encode([]byte("toKey")), (<-received).All()[presentproof.RecipientKeyPropKey])
+	})
+
 	t.Run("inbound message handler: DID not found is ok", func(t *testing.T) {
 		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
 		messengerHandler.EXPECT().
@@ -371,6 +422,277 @@ func TestNewProvider(t *testing.T) {
 		require.EqualError(t, errors.Unwrap(err), errTest.Error())
 	})
 
+	t.Run("expired message is rejected with a problem report", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:me", nil)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:them", nil)
+
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().
+			ReplyToMsg(gomock.Any(), gomock.Any(), "did:example:me", "did:example:them").
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, "request-1", in.ID())
+				require.Equal(t, model.ProblemReportMsgType, out.Type())
+
+				details, err := model.ParseProblemReport(out)
+				require.NoError(t, err)
+				require.Equal(t, model.MessageExpiredCode, details.Code)
+
+				return nil
+			})
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				return "", errors.New("expired message must not be handled")
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(fmt.Sprintf(`
+		{
+			"@id": "request-1",
+			"@type": "https://didcomm.org/present-proof/2.0/request-presentation",
+			"~timing": {"expires_time": %q}
+		}`, time.Now().Add(-time.Minute).Format(time.RFC3339))),
+			ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message request-1 of type "+
+			"https://didcomm.org/present-proof/2.0/request-presentation expired at")
+	})
+
+	t.Run("expired DIDComm V2 message from unknown sender is dropped", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", did.ErrNotFound).AnyTimes()
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				return "", errors.New("expired message must not be handled")
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(serviceMocks.NewMockMessengerHandler(ctrl)),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(fmt.Sprintf(`
+		{
+			"id": "request-2",
+			"type": "https://didcomm.org/present-proof/3.0/request-presentation",
+			"expires_time": %d
+		}`, time.Now().Add(-time.Minute).Unix())),
+			ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message request-2 of type")
+	})
+
+	t.Run("message expiry is checked against the injected clock", func(t *testing.T) {
+		expiresTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := mockclock.New(expiresTime.Add(-time.Minute))
+
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:me", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:them", nil).AnyTimes()
+
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().ReplyToMsg(gomock.Any(), gomock.Any(), "did:example:me", "did:example:them").
+			Return(nil)
+
+		handled := 0
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled++
+
+				return "", nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore),
+			WithClock(clock))
+		require.NoError(t, err)
+		require.Equal(t, clock, ctx.Clock())
+
+		envelope := &transport.Envelope{Message: []byte(fmt.Sprintf(`
+		{
+			"@id": "request-3",
+			"@type": "https://didcomm.org/present-proof/2.0/request-presentation",
+			"~timing": {"expires_time": %q}
+		}`, expiresTime.Format(time.RFC3339))),
+			ToKey: []byte("toKey"), FromKey: []byte("fromKey")}
+
+		require.NoError(t, ctx.InboundMessageHandler()(envelope))
+		require.Equal(t, 1, handled)
+
+		clock.Add(2 * time.Minute)
+
+		err = ctx.InboundMessageHandler()(envelope)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "message request-3 of type")
+		require.Equal(t, 1, handled)
+	})
+
+	t.Run("timing is passed to handlers", func(t *testing.T) {
+		staleTime := time.Now().Add(time.Minute).UTC().Truncate(time.Second)
+
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().
+			HandleInbound(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ service.DIDCommMsgMap, ctx service.DIDCommContext) error {
+				timing, ok := ctx.All()[decorator.TimingPropKey].(*decorator.Timing)
+				require.True(t, ok)
+				require.True(t, staleTime.Equal(*timing.StaleTime))
+				require.False(t, timing.Expired(time.Now()))
+
+				return nil
+			})
+
+		mockMsgHandler := msghandler.NewMockMsgServiceProvider()
+		require.NoError(t, mockMsgHandler.Register(&generic.MockMessageSvc{}))
+
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("", nil).AnyTimes()
+
+		ctx, err := New(WithMessageServiceProvider(mockMsgHandler),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(fmt.Sprintf(`
+		{
+			"@type": "valid-message-type",
+			"~timing": {"expires_time": %q, "stale_time": %q}
+		}`, time.Now().Add(time.Hour).Format(time.RFC3339), staleTime.Format(time.RFC3339))),
+			ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid timing", func(t *testing.T) {
+		ctx, err := New(WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@type": "valid-message-type",
+			"~timing": {"expires_time": "yesterday"}
+		}`)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal ~timing")
+	})
+
+	t.Run("message requesting an ack on receipt is acked", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:me", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:them", nil).AnyTimes()
+
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().
+			ReplyToMsg(gomock.Any(), gomock.Any(), "did:example:me", "did:example:them").
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, "offer-1", in.ID())
+				require.Equal(t, model.AckMsgType, out.Type())
+
+				ack := &model.Ack{}
+				require.NoError(t, out.Decode(ack))
+				require.Equal(t, model.AckStatusOK, ack.Status)
+
+				return nil
+			})
+
+		handled := false
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				handled = true
+
+				return uuid.New().String(), nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "offer-1",
+			"@type": "https://didcomm.org/issue-credential/2.0/offer-credential",
+			"~please_ack": {"on": ["RECEIPT"]}
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+		require.True(t, handled)
+	})
+
+	t.Run("DIDComm V2 message requesting an ack on receipt is acked with a V2 ack", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("toKey"))).Return("did:example:me", nil).AnyTimes()
+		connectionStore.EXPECT().GetDID(base58.Encode([]byte("fromKey"))).Return("did:example:them", nil).AnyTimes()
+
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+		messengerHandler.EXPECT().
+			ReplyToMsg(gomock.Any(), gomock.Any(), "did:example:me", "did:example:them").
+			DoAndReturn(func(in, out service.DIDCommMsgMap, _, _ string) error {
+				require.Equal(t, "offer-2", in.ID())
+				require.True(t, out.IsDIDCommV2())
+				require.Equal(t, model.AckMsgTypeV2, out.Type())
+
+				return nil
+			})
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				return uuid.New().String(), nil
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"id": "offer-2",
+			"type": "https://didcomm.org/issue-credential/3.0/offer-credential",
+			"~please_ack": {"on": ["RECEIPT"]}
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.NoError(t, err)
+	})
+
+	t.Run("message failing to be handled is not acked", func(t *testing.T) {
+		connectionStore := didStoreMocks.NewMockConnectionStore(ctrl)
+		connectionStore.EXPECT().GetDID(gomock.Any()).Return("did:example:me", nil).AnyTimes()
+
+		// no ReplyToMsg call is expected
+		messengerHandler := serviceMocks.NewMockMessengerHandler(ctrl)
+
+		ctx, err := New(WithProtocolServices(&mockdidexchange.MockDIDExchangeSvc{
+			ProtocolName: "mockProtocolSvc",
+			AcceptFunc:   func(msgType string) bool { return true },
+			HandleFunc: func(msg service.DIDCommMsg) (string, error) {
+				return "", errors.New("handle error")
+			},
+		}), WithMessageServiceProvider(msghandler.NewMockMsgServiceProvider()),
+			WithMessengerHandler(messengerHandler),
+			WithDIDConnectionStore(connectionStore))
+		require.NoError(t, err)
+
+		err = ctx.InboundMessageHandler()(&transport.Envelope{Message: []byte(`
+		{
+			"@id": "offer-3",
+			"@type": "https://didcomm.org/issue-credential/2.0/offer-credential",
+			"~please_ack": {"on": ["RECEIPT"]}
+		}`), ToKey: []byte("toKey"), FromKey: []byte("fromKey")})
+		require.EqualError(t, err, "handle error")
+	})
+
 	t.Run("InboundDIDCommMsgHandler", func(t *testing.T) {
 		expected := service.NewDIDCommMsgMap(&didexchange.Request{
 			Type: "test-type",
@@ -588,6 +910,43 @@ func TestNewProvider(t *testing.T) {
 		require.Equal(t, loader, prov.JSONLDDocumentLoader())
 	})
 
+	t.Run("test new with HTTP client", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.NotNil(t, prov.HTTPClient())
+
+		client := &http.Client{Timeout: time.Second}
+
+		prov, err = New(WithHTTPClient(client))
+		require.NoError(t, err)
+		require.Equal(t, client, prov.HTTPClient())
+	})
+
+	t.Run("test new with status checker", func(t *testing.T) {
+		prov, err := New(WithVDRegistry(&mockvdr.MockVDRegistry{}))
+		require.NoError(t, err)
+		require.NotNil(t, prov.StatusChecker())
+
+		checker := docverifiable.NewStatusChecker()
+
+		prov, err = New(WithStatusChecker(checker))
+		require.NoError(t, err)
+		require.Equal(t, checker, prov.StatusChecker())
+	})
+
+	t.Run("test new with signature suite registry", func(t *testing.T) {
+		prov, err := New()
+		require.NoError(t, err)
+		require.Nil(t, prov.SignatureSuiteRegistry())
+
+		registry := docverifiable.NewSuiteRegistry()
+
+		prov, err = New(WithSignatureSuiteRegistry(registry), WithVDRegistry(&mockvdr.MockVDRegistry{}))
+		require.NoError(t, err)
+		require.Equal(t, registry, prov.SignatureSuiteRegistry())
+		require.NotNil(t, prov.StatusChecker())
+	})
+
 	t.Run("test new with bad (fake) option", func(t *testing.T) {
 		prov, err := New(func(opts *Provider) error {
 			return fmt.Errorf("bad option")
@@ -621,3 +980,15 @@ func TestNewProvider(t *testing.T) {
 		require.EqualError(t, err, "option failed: invalid KeyAgreement key type: XChaCha20Poly1305")
 	})
 }
+
+// contextRecorderSvc records the contexts of the inbound messages it handles.
+type contextRecorderSvc struct {
+	*mockdidexchange.MockDIDExchangeSvc
+	contexts chan service.DIDCommContext
+}
+
+func (s *contextRecorderSvc) HandleInbound(_ service.DIDCommMsg, ctx service.DIDCommContext) (string, error) {
+	s.contexts <- ctx
+
+	return "", nil
+}
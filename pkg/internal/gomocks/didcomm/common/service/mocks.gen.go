@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service (interfaces: DIDComm,Event,Messenger,MessengerHandler)
+// Source: github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service (interfaces: DIDComm,DestinationMessenger,Event,Messenger,MessengerHandler)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -120,6 +120,43 @@ func (mr *MockDIDCommMockRecorder) UnregisterMsgEvent(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterMsgEvent", reflect.TypeOf((*MockDIDComm)(nil).UnregisterMsgEvent), arg0)
 }
 
+// MockDestinationMessenger is a mock of DestinationMessenger interface.
+type MockDestinationMessenger struct {
+	ctrl     *gomock.Controller
+	recorder *MockDestinationMessengerMockRecorder
+}
+
+// MockDestinationMessengerMockRecorder is the mock recorder for MockDestinationMessenger.
+type MockDestinationMessengerMockRecorder struct {
+	mock *MockDestinationMessenger
+}
+
+// NewMockDestinationMessenger creates a new mock instance.
+func NewMockDestinationMessenger(ctrl *gomock.Controller) *MockDestinationMessenger {
+	mock := &MockDestinationMessenger{ctrl: ctrl}
+	mock.recorder = &MockDestinationMessengerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestinationMessenger) EXPECT() *MockDestinationMessengerMockRecorder {
+	return m.recorder
+}
+
+// ReplyToMsgToDestination mocks base method.
+func (m *MockDestinationMessenger) ReplyToMsgToDestination(arg0, arg1 service.DIDCommMsgMap, arg2 string, arg3 *service.Destination) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyToMsgToDestination", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplyToMsgToDestination indicates an expected call of ReplyToMsgToDestination.
+func (mr *MockDestinationMessengerMockRecorder) ReplyToMsgToDestination(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyToMsgToDestination", reflect.TypeOf((*MockDestinationMessenger)(nil).ReplyToMsgToDestination), arg0, arg1, arg2, arg3)
+}
+
 // MockEvent is a mock of Event interface.
 type MockEvent struct {
 	ctrl     *gomock.Controller
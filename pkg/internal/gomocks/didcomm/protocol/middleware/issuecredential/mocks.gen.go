@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential (interfaces: Provider,Metadata)
+// Source: github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential (interfaces: Provider,Metadata,IssuerProvider)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -8,9 +8,11 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	crypto "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	service "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 	issuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
 	vdr "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	kms "github.com/hyperledger/aries-framework-go/pkg/kms"
 	verifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
 	ld "github.com/piprate/json-gold/ld"
 )
@@ -131,6 +133,20 @@ func (mr *MockMetadataMockRecorder) IssueCredential() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCredential", reflect.TypeOf((*MockMetadata)(nil).IssueCredential))
 }
 
+// IssueCredentialV3 mocks base method.
+func (m *MockMetadata) IssueCredentialV3() *issuecredential.IssueCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.IssueCredentialV3)
+	return ret0
+}
+
+// IssueCredentialV3 indicates an expected call of IssueCredentialV3.
+func (mr *MockMetadataMockRecorder) IssueCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueCredentialV3", reflect.TypeOf((*MockMetadata)(nil).IssueCredentialV3))
+}
+
 // Message mocks base method.
 func (m *MockMetadata) Message() service.DIDCommMsg {
 	m.ctrl.T.Helper()
@@ -159,6 +175,20 @@ func (mr *MockMetadataMockRecorder) OfferCredential() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCredential", reflect.TypeOf((*MockMetadata)(nil).OfferCredential))
 }
 
+// OfferCredentialV3 mocks base method.
+func (m *MockMetadata) OfferCredentialV3() *issuecredential.OfferCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.OfferCredentialV3)
+	return ret0
+}
+
+// OfferCredentialV3 indicates an expected call of OfferCredentialV3.
+func (mr *MockMetadataMockRecorder) OfferCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCredentialV3", reflect.TypeOf((*MockMetadata)(nil).OfferCredentialV3))
+}
+
 // Properties mocks base method.
 func (m *MockMetadata) Properties() map[string]interface{} {
 	m.ctrl.T.Helper()
@@ -187,6 +217,20 @@ func (mr *MockMetadataMockRecorder) ProposeCredential() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeCredential", reflect.TypeOf((*MockMetadata)(nil).ProposeCredential))
 }
 
+// ProposeCredentialV3 mocks base method.
+func (m *MockMetadata) ProposeCredentialV3() *issuecredential.ProposeCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProposeCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.ProposeCredentialV3)
+	return ret0
+}
+
+// ProposeCredentialV3 indicates an expected call of ProposeCredentialV3.
+func (mr *MockMetadataMockRecorder) ProposeCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposeCredentialV3", reflect.TypeOf((*MockMetadata)(nil).ProposeCredentialV3))
+}
+
 // RequestCredential mocks base method.
 func (m *MockMetadata) RequestCredential() *issuecredential.RequestCredential {
 	m.ctrl.T.Helper()
@@ -201,6 +245,20 @@ func (mr *MockMetadataMockRecorder) RequestCredential() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredential", reflect.TypeOf((*MockMetadata)(nil).RequestCredential))
 }
 
+// RequestCredentialV3 mocks base method.
+func (m *MockMetadata) RequestCredentialV3() *issuecredential.RequestCredentialV3 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCredentialV3")
+	ret0, _ := ret[0].(*issuecredential.RequestCredentialV3)
+	return ret0
+}
+
+// RequestCredentialV3 indicates an expected call of RequestCredentialV3.
+func (mr *MockMetadataMockRecorder) RequestCredentialV3() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCredentialV3", reflect.TypeOf((*MockMetadata)(nil).RequestCredentialV3))
+}
+
 // StateName mocks base method.
 func (m *MockMetadata) StateName() string {
 	m.ctrl.T.Helper()
@@ -214,3 +272,82 @@ func (mr *MockMetadataMockRecorder) StateName() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateName", reflect.TypeOf((*MockMetadata)(nil).StateName))
 }
+
+// MockIssuerProvider is a mock of IssuerProvider interface.
+type MockIssuerProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIssuerProviderMockRecorder
+}
+
+// MockIssuerProviderMockRecorder is the mock recorder for MockIssuerProvider.
+type MockIssuerProviderMockRecorder struct {
+	mock *MockIssuerProvider
+}
+
+// NewMockIssuerProvider creates a new mock instance.
+func NewMockIssuerProvider(ctrl *gomock.Controller) *MockIssuerProvider {
+	mock := &MockIssuerProvider{ctrl: ctrl}
+	mock.recorder = &MockIssuerProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIssuerProvider) EXPECT() *MockIssuerProviderMockRecorder {
+	return m.recorder
+}
+
+// Crypto mocks base method.
+func (m *MockIssuerProvider) Crypto() crypto.Crypto {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Crypto")
+	ret0, _ := ret[0].(crypto.Crypto)
+	return ret0
+}
+
+// Crypto indicates an expected call of Crypto.
+func (mr *MockIssuerProviderMockRecorder) Crypto() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Crypto", reflect.TypeOf((*MockIssuerProvider)(nil).Crypto))
+}
+
+// JSONLDDocumentLoader mocks base method.
+func (m *MockIssuerProvider) JSONLDDocumentLoader() ld.DocumentLoader {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JSONLDDocumentLoader")
+	ret0, _ := ret[0].(ld.DocumentLoader)
+	return ret0
+}
+
+// JSONLDDocumentLoader indicates an expected call of JSONLDDocumentLoader.
+func (mr *MockIssuerProviderMockRecorder) JSONLDDocumentLoader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JSONLDDocumentLoader", reflect.TypeOf((*MockIssuerProvider)(nil).JSONLDDocumentLoader))
+}
+
+// KMS mocks base method.
+func (m *MockIssuerProvider) KMS() kms.KeyManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KMS")
+	ret0, _ := ret[0].(kms.KeyManager)
+	return ret0
+}
+
+// KMS indicates an expected call of KMS.
+func (mr *MockIssuerProviderMockRecorder) KMS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KMS", reflect.TypeOf((*MockIssuerProvider)(nil).KMS))
+}
+
+// VDRegistry mocks base method.
+func (m *MockIssuerProvider) VDRegistry() vdr.Registry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VDRegistry")
+	ret0, _ := ret[0].(vdr.Registry)
+	return ret0
+}
+
+// VDRegistry indicates an expected call of VDRegistry.
+func (mr *MockIssuerProviderMockRecorder) VDRegistry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VDRegistry", reflect.TypeOf((*MockIssuerProvider)(nil).VDRegistry))
+}
@@ -57,8 +57,9 @@ type KeyManager interface {
 	PubKeyBytesToHandle(pubKey []byte, kt KeyType) (interface{}, error)
 	// ImportPrivateKey will import privKey into the KMS storage for the given keyType then returns the new key id and
 	// the newly persisted Handle.
-	// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
-	// 'kt' possible types are signing key types only (ECDSA keys or Ed25519)
+	// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey, implementations may also accept []byte
+	// holding a private JWK or a DER encoded private key.
+	// 'kt' possible types are signing key types only (ECDSA keys or Ed25519), it must match the key material
 	// 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
 	// then an error is returned.
 	// Returns:
@@ -66,6 +67,14 @@ type KeyManager interface {
 	//  - handle instance (to private key)
 	//  - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
+	// ExportPrivateKey will fetch the key referenced by keyID and export its private key as a marshalled JWK, e.g. to
+	// back up an agent's keys or to migrate them to another KMS. Implementations must refuse to export private keys
+	// unless explicitly configured to allow it.
+	// Returns:
+	//  - marshalled private JWK
+	//  - key type of the exported key, to be used when importing it with ImportPrivateKey
+	//  - error if export is not allowed, the key is not found or is not an exportable signing key
+	ExportPrivateKey(keyID string) ([]byte, KeyType, error)
 }
 
 // Provider for KeyManager builder/constructor.
@@ -100,6 +109,8 @@ const (
 	ECDSAP384IEEEP1363 = "ECDSAP384IEEEP1363"
 	// ECDSAP521IEEEP1363 key type value.
 	ECDSAP521IEEEP1363 = "ECDSAP521IEEEP1363"
+	// ECDSASecp256k1DER key type value.
+	ECDSASecp256k1DER = "ECDSASecp256k1DER"
 	// ECDSASecp256k1IEEEP1363 key type value.
 	ECDSASecp256k1IEEEP1363 = "ECDSASecp256k1IEEEP1363"
 	// ED25519 key type value.
@@ -148,6 +159,8 @@ const (
 	ECDSAP384TypeIEEEP1363 = KeyType(ECDSAP384IEEEP1363)
 	// ECDSAP521TypeIEEEP1363 key type value.
 	ECDSAP521TypeIEEEP1363 = KeyType(ECDSAP521IEEEP1363)
+	// ECDSASecp256k1TypeDER key type value.
+	ECDSASecp256k1TypeDER = KeyType(ECDSASecp256k1DER)
 	// ECDSASecp256k1TypeIEEEP1363 key type value.
 	ECDSASecp256k1TypeIEEEP1363 = KeyType(ECDSASecp256k1IEEEP1363)
 	// ED25519Type key type value.
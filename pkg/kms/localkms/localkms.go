@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/aead"
@@ -27,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/secp256k1"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
@@ -55,6 +57,30 @@ type LocalKMS struct {
 	primaryKeyURI     string
 	store             storage.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+	allowExport       bool
+	masterSeed        []byte
+	seedLock          sync.Mutex
+}
+
+// Opt is a LocalKMS construction option.
+type Opt func(l *LocalKMS)
+
+// WithPrivateKeyExport allows exporting private keys with ExportPrivateKey, e.g. to back up or migrate an agent's
+// keys. LocalKMS instances built without this option refuse to export private keys.
+func WithPrivateKeyExport() Opt {
+	return func(l *LocalKMS) {
+		l.allowExport = true
+	}
+}
+
+// WithMasterSeed makes Create derive keys deterministically from seed instead of generating random keys, so that a
+// LocalKMS created with the same seed on an empty store reproduces the same keys, in the same order, with the same
+// key IDs. The seed must be at least 32 bytes long, see deriveKeySet for the derivation scheme and the supported key
+// types.
+func WithMasterSeed(seed []byte) Opt {
+	return func(l *LocalKMS) {
+		l.masterSeed = seed
+	}
 }
 
 func newKeyIDWrapperStore(provider storage.Provider, storePrefix string) (storage.Store, error) {
@@ -67,12 +93,12 @@ func newKeyIDWrapperStore(provider storage.Provider, storePrefix string) (storag
 }
 
 // New will create a new (local) KMS service.
-func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
-	return NewWithPrefix(primaryKeyURI, p, "")
+func New(primaryKeyURI string, p kms.Provider, opts ...Opt) (*LocalKMS, error) {
+	return NewWithPrefix(primaryKeyURI, p, "", opts...)
 }
 
 // NewWithPrefix will create a new (local) KMS service using a store name prefixed with storePrefix.
-func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string) (*LocalKMS, error) {
+func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string, opts ...Opt) (*LocalKMS, error) {
 	store, err := newKeyIDWrapperStore(p.StorageProvider(), storePrefix)
 	if err != nil {
 		return nil, fmt.Errorf("new: failed to ceate local kms: %w", err)
@@ -88,13 +114,22 @@ func NewWithPrefix(primaryKeyURI string, p kms.Provider, storePrefix string) (*L
 	// create a KMSEnvelopeAEAD instance to wrap/unwrap keys managed by LocalKMS
 	keyEnvelopeAEAD := aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw)
 
-	return &LocalKMS{
-			store:             store,
-			secretLock:        secretLock,
-			primaryKeyURI:     primaryKeyURI,
-			primaryKeyEnvAEAD: keyEnvelopeAEAD,
-		},
-		nil
+	l := &LocalKMS{
+		store:             store,
+		secretLock:        secretLock,
+		primaryKeyURI:     primaryKeyURI,
+		primaryKeyEnvAEAD: keyEnvelopeAEAD,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	if l.masterSeed != nil && len(l.masterSeed) < minSeedSize {
+		return nil, fmt.Errorf("new: master seed must be at least %d bytes long", minSeedSize)
+	}
+
+	return l, nil
 }
 
 // Create a new key/keyset/key handle for the type kt
@@ -107,6 +142,10 @@ func (l *LocalKMS) Create(kt kms.KeyType) (string, interface{}, error) {
 		return "", nil, fmt.Errorf("failed to create new key, missing key type")
 	}
 
+	if l.masterSeed != nil {
+		return l.createFromSeed(kt)
+	}
+
 	keyTemplate, err := getKeyTemplate(kt)
 	if err != nil {
 		return "", nil, fmt.Errorf("create: failed to getKeyTemplate: %w", err)
@@ -140,6 +179,11 @@ func (l *LocalKMS) Get(keyID string) (interface{}, error) {
 //  - handle instance (to private key)
 //  - error if failure
 func (l *LocalKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
+	if l.masterSeed != nil {
+		// a rotated keyset holds a random key, it could not be recovered from the seed.
+		return "", nil, errors.New("rotate: not supported for keys derived from a master seed")
+	}
+
 	kh, err := l.getKeySet(keyID)
 	if err != nil {
 		return "", nil, fmt.Errorf("rotate: failed to getKeySet: %w", err)
@@ -202,6 +246,10 @@ func getKeyTemplate(keyType kms.KeyType) (*tinkpb.KeyTemplate, error) {
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA384, commonpb.EllipticCurveType_NIST_P384), nil
 	case kms.ECDSAP521TypeIEEEP1363:
 		return createECDSAIEEE1363KeyTemplate(commonpb.HashType_SHA512, commonpb.EllipticCurveType_NIST_P521), nil
+	case kms.ECDSASecp256k1TypeDER:
+		return secp256k1.DERKeyTemplate(), nil
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		return secp256k1.IEEEP1363KeyTemplate(), nil
 	case kms.ED25519Type:
 		return signature.ED25519KeyWithoutPrefixTemplate(), nil
 	case kms.HMACSHA256Tag256Type:
@@ -380,8 +428,10 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interface
 
 // ImportPrivateKey will import privKey into the KMS storage for the given keyType then returns the new key id and
 // the newly persisted Handle.
-// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
-// 'keyType' possible types are signing key types only (ECDSA keys or Ed25519)
+// 'privKey' possible types are: *ecdsa.PrivateKey, ed25519.PrivateKey, *bbs12381g2pub.PrivateKey or []byte holding
+// either a private JWK (as returned by ExportPrivateKey) or a DER encoded (PKCS#8 or SEC 1) private key.
+// 'keyType' possible types are signing key types only (ECDSA keys, Ed25519 or BLS12381G2), it must match the key
+// material.
 // 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
 // then an error is returned.
 // Returns:
@@ -397,6 +447,8 @@ func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 		return l.importEd25519Key(pk, kt, opts...)
 	case *bbs12381g2pub.PrivateKey:
 		return l.importBBSKey(pk, kt, opts...)
+	case []byte:
+		return l.importKeyBytes(pk, kt, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private key does not support this key type or key is public")
 	}
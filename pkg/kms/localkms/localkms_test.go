@@ -21,11 +21,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/subtle/random"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms/internal/keywrapper"
 	mocksecretlock "github.com/hyperledger/aries-framework-go/pkg/mock/secretlock"
@@ -498,6 +500,77 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) {
 	}
 }
 
+func TestLocalKMS_CreateBLS12381G2Key(t *testing.T) {
+	kmsService := createKMS(t)
+
+	keyID, kh, err := kmsService.Create(kms.BLS12381G2Type)
+	require.NoError(t, err)
+	require.NotEmpty(t, keyID)
+	require.NotEmpty(t, kh)
+
+	pubKeyBytes, err := kmsService.ExportPubKeyBytes(keyID)
+	require.NoError(t, err)
+	// BLS12-381 G2 public keys are exported in their 96 bytes compressed form.
+	require.Len(t, pubKeyBytes, 96)
+
+	pubKey, err := bbs12381g2pub.UnmarshalPublicKey(pubKeyBytes)
+	require.NoError(t, err)
+
+	mPubKey, err := pubKey.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, pubKeyBytes, mPubKey)
+}
+
+func TestLocalKMS_Secp256k1Keys(t *testing.T) {
+	kmsService := createKMS(t)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	for _, kt := range []kms.KeyType{kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363} {
+		keyType := kt
+		t.Run(string(keyType), func(t *testing.T) {
+			keyID, kh, err := kmsService.Create(keyType)
+			require.NoError(t, err)
+			require.NotEmpty(t, keyID)
+
+			// the keys are exported in the uncompressed form whatever their signature encoding is.
+			pubKeyBytes, err := kmsService.ExportPubKeyBytes(keyID)
+			require.NoError(t, err)
+			require.Len(t, pubKeyBytes, 65)
+			require.Equal(t, byte(0x04), pubKeyBytes[0])
+
+			pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+			require.NoError(t, err)
+
+			msg := []byte("test message")
+
+			sig, err := tinkCrypto.Sign(msg, kh)
+			require.NoError(t, err)
+
+			// the compressed form of the key is accepted too.
+			for _, keyBytes := range [][]byte{pubKeyBytes, pubKey.SerializeCompressed()} {
+				kid, err := CreateKID(keyBytes, keyType)
+				require.NoError(t, err)
+				require.Equal(t, kid, keyID)
+
+				pubKH, err := kmsService.PubKeyBytesToHandle(keyBytes, keyType)
+				require.NoError(t, err)
+
+				err = tinkCrypto.Verify(sig, msg, pubKH)
+				require.NoError(t, err)
+
+				err = tinkCrypto.Verify(sig, []byte("other message"), pubKH)
+				require.Error(t, err)
+			}
+		})
+	}
+
+	_, err = kmsService.PubKeyBytesToHandle([]byte("not a key"), kms.ECDSASecp256k1TypeDER)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse secp256k1 public key")
+}
+
 func TestLocalKMS_getKeyTemplate(t *testing.T) {
 	keyTemplate, err := getKeyTemplate(kms.HMACSHA256Tag256Type)
 	require.NoError(t, err)
@@ -0,0 +1,186 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
+	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/subtle"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// ErrPrivateKeyExportNotAllowed is returned by ExportPrivateKey when the LocalKMS was not built with
+// WithPrivateKeyExport().
+var ErrPrivateKeyExportNotAllowed = errors.New("private key export is not allowed")
+
+// ExportPrivateKey will fetch the key referenced by keyID and export its primary private key as a JWK. Only signing
+// keys (ECDSA, Ed25519 and BLS12381G2) can be exported, and only if the LocalKMS was built with
+// WithPrivateKeyExport(). The exported key can be imported back with ImportPrivateKey using the returned key type.
+// Returns:
+//  - marshalled private JWK
+//  - key type of the exported key
+//  - error if export is not allowed or if it fails to export the private key
+func (l *LocalKMS) ExportPrivateKey(keyID string) ([]byte, kms.KeyType, error) {
+	if !l.allowExport {
+		return nil, "", fmt.Errorf("exportPrivateKey: %w", ErrPrivateKeyExportNotAllowed)
+	}
+
+	kh, err := l.getKeySet(keyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPrivateKey: failed to get keyset handle: %w", err)
+	}
+
+	privKey, kt, err := exportPrivateKey(kh)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPrivateKey: %w", err)
+	}
+
+	jwk, err := jose.JWKFromKey(privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPrivateKey: failed to create JWK: %w", err)
+	}
+
+	jwk.KeyID = keyID
+
+	jwkBytes, err := jwk.MarshalJSON()
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPrivateKey: failed to marshal JWK: %w", err)
+	}
+
+	return jwkBytes, kt, nil
+}
+
+func exportPrivateKey(kh *keyset.Handle) (interface{}, kms.KeyType, error) {
+	ks := insecurecleartextkeyset.KeysetMaterial(kh)
+
+	var primaryKey *tinkpb.Keyset_Key
+
+	for _, key := range ks.Key {
+		if key.KeyId == ks.PrimaryKeyId {
+			primaryKey = key
+
+			break
+		}
+	}
+
+	if primaryKey == nil || primaryKey.KeyData == nil {
+		return nil, "", errors.New("primary key not found in keyset")
+	}
+
+	switch primaryKey.KeyData.TypeUrl {
+	case ecdsaSignerTypeURL:
+		return exportECDSAKey(primaryKey.KeyData.Value)
+	case ed25519SignerTypeURL:
+		return exportEd25519Key(primaryKey.KeyData.Value)
+	case bbsSignerKeyTypeURL:
+		return exportBBSKey(primaryKey.KeyData.Value)
+	default:
+		return nil, "", fmt.Errorf("can't export key with keyURL:%s", primaryKey.KeyData.TypeUrl)
+	}
+}
+
+func exportECDSAKey(keyValue []byte) (interface{}, kms.KeyType, error) {
+	privKeyProto := new(ecdsapb.EcdsaPrivateKey)
+
+	err := proto.Unmarshal(keyValue, privKeyProto)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal ECDSA private key: %w", err)
+	}
+
+	if privKeyProto.PublicKey == nil || privKeyProto.PublicKey.Params == nil {
+		return nil, "", errors.New("ECDSA private key is missing its public key")
+	}
+
+	params := privKeyProto.PublicKey.Params
+
+	curve := subtle.GetCurve(params.Curve.String())
+	if curve == nil {
+		return nil, "", errors.New("undefined curve")
+	}
+
+	kt, err := ecdsaKeyType(params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(privKeyProto.PublicKey.X),
+			Y:     new(big.Int).SetBytes(privKeyProto.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(privKeyProto.KeyValue),
+	}, kt, nil
+}
+
+func ecdsaKeyType(params *ecdsapb.EcdsaParams) (kms.KeyType, error) {
+	keyTypes := map[commonpb.EllipticCurveType][2]kms.KeyType{
+		commonpb.EllipticCurveType_NIST_P256: {kms.ECDSAP256TypeDER, kms.ECDSAP256TypeIEEEP1363},
+		commonpb.EllipticCurveType_NIST_P384: {kms.ECDSAP384TypeDER, kms.ECDSAP384TypeIEEEP1363},
+		commonpb.EllipticCurveType_NIST_P521: {kms.ECDSAP521TypeDER, kms.ECDSAP521TypeIEEEP1363},
+	}
+
+	kts, ok := keyTypes[params.Curve]
+	if !ok {
+		return "", fmt.Errorf("unsupported ECDSA curve %s", params.Curve)
+	}
+
+	switch params.Encoding {
+	case ecdsapb.EcdsaSignatureEncoding_DER:
+		return kts[0], nil
+	case ecdsapb.EcdsaSignatureEncoding_IEEE_P1363:
+		return kts[1], nil
+	default:
+		return "", fmt.Errorf("unsupported ECDSA signature encoding %s", params.Encoding)
+	}
+}
+
+func exportEd25519Key(keyValue []byte) (interface{}, kms.KeyType, error) {
+	privKeyProto := new(ed25519pb.Ed25519PrivateKey)
+
+	err := proto.Unmarshal(keyValue, privKeyProto)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal ED25519 private key: %w", err)
+	}
+
+	if len(privKeyProto.KeyValue) != ed25519.SeedSize {
+		return nil, "", errors.New("invalid ED25519 private key seed")
+	}
+
+	return ed25519.NewKeyFromSeed(privKeyProto.KeyValue), kms.ED25519Type, nil
+}
+
+func exportBBSKey(keyValue []byte) (interface{}, kms.KeyType, error) {
+	privKeyProto := new(bbspb.BBSPrivateKey)
+
+	err := proto.Unmarshal(keyValue, privKeyProto)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal BBS+ private key: %w", err)
+	}
+
+	privKey, err := bbs12381g2pub.UnmarshalPrivateKey(privKeyProto.KeyValue)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal BBS+ private key value: %w", err)
+	}
+
+	return privKey, kms.BLS12381G2Type, nil
+}
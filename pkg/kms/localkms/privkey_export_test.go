@@ -0,0 +1,194 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+)
+
+func TestLocalKMS_ExportPrivateKey(t *testing.T) {
+	keyTypes := []kms.KeyType{
+		kms.ECDSAP256TypeDER,
+		kms.ECDSAP384TypeDER,
+		kms.ECDSAP521TypeDER,
+		kms.ECDSAP256TypeIEEEP1363,
+		kms.ECDSAP384TypeIEEEP1363,
+		kms.ECDSAP521TypeIEEEP1363,
+		kms.ED25519Type,
+		kms.BLS12381G2Type,
+	}
+
+	source := createExportKMS(t, WithPrivateKeyExport())
+	destination := createExportKMS(t)
+
+	for _, keyType := range keyTypes {
+		kt := keyType
+		t.Run("export and import "+string(kt)+" key", func(t *testing.T) {
+			kid, pubKey, err := source.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			privKeyJWK, exportedKT, err := source.ExportPrivateKey(kid)
+			require.NoError(t, err)
+			require.Equal(t, kt, exportedKT)
+
+			jwk := &jose.JWK{}
+			require.NoError(t, jwk.UnmarshalJSON(privKeyJWK))
+			require.Equal(t, kid, jwk.KeyID)
+			require.False(t, jwk.IsPublic())
+
+			importedKID, kh, err := destination.ImportPrivateKey(privKeyJWK, exportedKT, kms.WithKeyID(jwk.KeyID))
+			require.NoError(t, err)
+			require.NotNil(t, kh)
+			require.Equal(t, kid, importedKID)
+
+			importedPubKey, err := destination.ExportPubKeyBytes(importedKID)
+			require.NoError(t, err)
+			require.Equal(t, pubKey, importedPubKey)
+		})
+	}
+
+	t.Run("export refused without WithPrivateKeyExport", func(t *testing.T) {
+		kid, _, err := destination.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, _, err = destination.ExportPrivateKey(kid)
+		require.True(t, errors.Is(err, ErrPrivateKeyExportNotAllowed))
+	})
+
+	t.Run("export unknown key", func(t *testing.T) {
+		_, _, err := source.ExportPrivateKey("unknown")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportPrivateKey: failed to get keyset handle")
+	})
+
+	t.Run("export non signing key", func(t *testing.T) {
+		kid, _, err := source.Create(kms.AES256GCMType)
+		require.NoError(t, err)
+
+		_, _, err = source.ExportPrivateKey(kid)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exportPrivateKey: can't export key with keyURL")
+	})
+}
+
+func TestLocalKMS_ImportPrivateKeyBytes(t *testing.T) {
+	k := createExportKMS(t)
+
+	ecPrivKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("import PKCS#8 DER keys", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(ecPrivKey)
+		require.NoError(t, err)
+
+		kid, _, err := k.ImportPrivateKey(der, kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		pubKey, err := k.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(elliptic.P256(), ecPrivKey.X, ecPrivKey.Y), pubKey)
+
+		der, err = x509.MarshalPKCS8PrivateKey(edPrivKey)
+		require.NoError(t, err)
+
+		kid, _, err = k.ImportPrivateKey(der, kms.ED25519Type)
+		require.NoError(t, err)
+
+		pubKey, err = k.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+		require.EqualValues(t, edPrivKey.Public(), pubKey)
+	})
+
+	t.Run("import SEC 1 DER key", func(t *testing.T) {
+		der, err := x509.MarshalECPrivateKey(ecPrivKey)
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(der, kms.ECDSAP256TypeDER)
+		require.NoError(t, err)
+	})
+
+	t.Run("key type does not match the key material", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(ecPrivKey)
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(der, kms.ECDSAP384TypeDER)
+		require.EqualError(t, err, "import private EC key failed: key curve does not match key type "+
+			string(kms.ECDSAP384TypeDER))
+
+		jwk, err := jose.JWKFromKey(edPrivKey)
+		require.NoError(t, err)
+
+		jwkBytes, err := jwk.MarshalJSON()
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(jwkBytes, kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "import private ED25519 key failed: invalid key type")
+	})
+
+	t.Run("import public JWK", func(t *testing.T) {
+		jwk, err := jose.JWKFromKey(&ecPrivKey.PublicKey)
+		require.NoError(t, err)
+
+		jwkBytes, err := jwk.MarshalJSON()
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(jwkBytes, kms.ECDSAP256TypeDER)
+		require.EqualError(t, err, "import private key does not support this key type or key is public")
+	})
+
+	t.Run("import X25519 JWK", func(t *testing.T) {
+		jwk, err := jose.JWKFromX25519Key(make([]byte, 32))
+		require.NoError(t, err)
+
+		jwkBytes, err := jwk.MarshalJSON()
+		require.NoError(t, err)
+
+		_, _, err = k.ImportPrivateKey(jwkBytes, kms.X25519ECDHKWType)
+		require.EqualError(t, err, "import private key bytes failed: JWK is not a private signing key")
+	})
+
+	t.Run("import invalid key bytes", func(t *testing.T) {
+		_, _, err := k.ImportPrivateKey([]byte{}, kms.ED25519Type)
+		require.EqualError(t, err, "import private key bytes failed: private key is empty")
+
+		_, _, err = k.ImportPrivateKey([]byte("not a key"), kms.ED25519Type)
+		require.EqualError(t, err, "import private key bytes failed: private key is neither a JWK nor a DER "+
+			"encoded key")
+
+		_, _, err = k.ImportPrivateKey([]byte(`{"kty":"EC"}`), kms.ECDSAP256TypeDER)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "import private key bytes failed: unable to read jose JWK")
+	})
+}
+
+func createExportKMS(t *testing.T, opts ...Opt) *LocalKMS {
+	t.Helper()
+
+	p := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+
+	k, err := New(testMasterKeyURI, p, opts...)
+	require.NoError(t, err)
+
+	return k
+}
@@ -10,17 +10,24 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/keyset"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/subtle"
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -28,15 +35,35 @@ const (
 	ecdsaSignerTypeURL   = "type.googleapis.com/google.crypto.tink.EcdsaPrivateKey"
 	ed25519SignerTypeURL = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
 	bbsSignerKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
+
+	secp256k1SignerTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PrivateKey"
 )
 
 func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	ks, err := newECKeySet(privKey, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
+	}
+
+	return l.importKeySet(ks, opts...)
+}
+
+func newECKeySet(privKey *ecdsa.PrivateKey, kt kms.KeyType) (*tinkpb.Keyset, error) {
+	switch kt {
+	case kms.ECDSASecp256k1TypeDER, kms.ECDSASecp256k1TypeIEEEP1363:
+		return newSecp256K1KeySet(privKey, kt)
+	default:
+		return newECDSAKeySet(privKey, kt)
+	}
+}
+
+func newECDSAKeySet(privKey *ecdsa.PrivateKey, kt kms.KeyType) (*tinkpb.Keyset, error) {
 	var params *ecdsapb.EcdsaParams
 
 	err := validECPrivateKey(privKey)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
+		return nil, err
 	}
 
 	switch kt {
@@ -77,17 +104,99 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 			HashType: commonpb.HashType_SHA512,
 		}
 	default:
-		return "", nil, fmt.Errorf("import private EC key failed: invalid ECDSA key type")
+		return nil, fmt.Errorf("invalid ECDSA key type")
+	}
+
+	if privKey.Curve != subtle.GetCurve(params.Curve.String()) {
+		return nil, fmt.Errorf("key curve does not match key type %s", kt)
 	}
 
 	mKeyValue, err := getMarshalledECDSAPrivateKey(privKey, params)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private EC key failed: %w", err)
+		return nil, err
 	}
 
-	ks := newKeySet(ecdsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+	return newKeySet(ecdsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE), nil
+}
 
-	return l.importKeySet(ks, opts...)
+func newSecp256K1KeySet(privKey *ecdsa.PrivateKey, kt kms.KeyType) (*tinkpb.Keyset, error) {
+	err := validECPrivateKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_IEEE_P1363
+	if kt == kms.ECDSASecp256k1TypeDER {
+		encoding = secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER
+	}
+
+	if privKey.Curve != btcec.S256() {
+		return nil, fmt.Errorf("key curve does not match key type %s", kt)
+	}
+
+	mKeyValue, err := proto.Marshal(&secp256k1pb.Secp256K1PrivateKey{
+		Version: 0,
+		PublicKey: &secp256k1pb.Secp256K1PublicKey{
+			Version: 0,
+			Params: &secp256k1pb.Secp256K1Params{
+				HashType: commonpb.HashType_SHA256,
+				Curve:    secp256k1pb.BitcoinCurveType_SECP256K1,
+				Encoding: encoding,
+			},
+			X: privKey.X.Bytes(),
+			Y: privKey.Y.Bytes(),
+		},
+		KeyValue: privKey.D.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeySet(secp256k1SignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE), nil
+}
+
+func (l *LocalKMS) importKeyBytes(privKey []byte, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, interface{}, error) {
+	key, err := parsePrivateKey(privKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key bytes failed: %w", err)
+	}
+
+	return l.ImportPrivateKey(key, kt, opts...)
+}
+
+// parsePrivateKey parses a private JWK or a DER encoded (PKCS#8 or SEC 1) private key.
+func parsePrivateKey(privKey []byte) (interface{}, error) {
+	if len(privKey) == 0 {
+		return nil, errors.New("private key is empty")
+	}
+
+	if json.Valid(privKey) {
+		jwk := &jose.JWK{}
+
+		err := jwk.UnmarshalJSON(privKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := jwk.Key.([]byte); ok {
+			return nil, errors.New("JWK is not a private signing key")
+		}
+
+		return jwk.Key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(privKey)
+	if err == nil {
+		return key, nil
+	}
+
+	key, err = x509.ParseECPrivateKey(privKey)
+	if err != nil {
+		return nil, errors.New("private key is neither a JWK nor a DER encoded key")
+	}
+
+	return key, nil
 }
 
 func (l *LocalKMS) importKeySet(ks *tinkpb.Keyset, opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
@@ -111,52 +220,66 @@ func getMarshalledECDSAPrivateKey(privKey *ecdsa.PrivateKey, params *ecdsapb.Ecd
 
 func (l *LocalKMS) importEd25519Key(privKey ed25519.PrivateKey, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	ks, err := newEd25519KeySet(privKey, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private ED25519 key failed: %w", err)
+	}
+
+	return l.importKeySet(ks, opts...)
+}
+
+func newEd25519KeySet(privKey ed25519.PrivateKey, kt kms.KeyType) (*tinkpb.Keyset, error) {
 	if privKey == nil {
-		return "", nil, fmt.Errorf("import private ED25519 key failed: private key is nil")
+		return nil, fmt.Errorf("private key is nil")
 	}
 
 	if kt != kms.ED25519Type {
-		return "", nil, fmt.Errorf("import private ED25519 key failed: invalid key type")
+		return nil, fmt.Errorf("invalid key type")
 	}
 
 	privKeyProto, err := newProtoEd25519PrivateKey(privKey)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private ED25519 key failed: %w", err)
+		return nil, err
 	}
 
 	mKeyValue, err := proto.Marshal(privKeyProto)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private ED25519 key failed: %w", err)
+		return nil, err
 	}
 
-	ks := newKeySet(ed25519SignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
-
-	return l.importKeySet(ks, opts...)
+	return newKeySet(ed25519SignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE), nil
 }
 
 func (l *LocalKMS) importBBSKey(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyType,
 	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	ks, err := newBBSKeySet(privKey, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private BBS+ key failed: %w", err)
+	}
+
+	return l.importKeySet(ks, opts...)
+}
+
+func newBBSKeySet(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyType) (*tinkpb.Keyset, error) {
 	if privKey == nil {
-		return "", nil, fmt.Errorf("import private BBS+ key failed: private key is nil")
+		return nil, fmt.Errorf("private key is nil")
 	}
 
 	if kt != kms.BLS12381G2Type {
-		return "", nil, fmt.Errorf("import private BBS+ key failed: invalid key type")
+		return nil, fmt.Errorf("invalid key type")
 	}
 
 	privKeyProto, err := newProtoBBSPrivateKey(privKey, kt)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private BBS+ key failed: %w", err)
+		return nil, err
 	}
 
 	mKeyValue, err := proto.Marshal(privKeyProto)
 	if err != nil {
-		return "", nil, fmt.Errorf("import private BBS+ key failed: %w", err)
+		return nil, err
 	}
 
-	ks := newKeySet(bbsSignerKeyTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
-
-	return l.importKeySet(ks, opts...)
+	return newKeySet(bbsSignerKeyTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE), nil
 }
 
 func validECPrivateKey(privateKey *ecdsa.PrivateKey) error {
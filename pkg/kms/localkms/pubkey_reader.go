@@ -12,6 +12,7 @@ import (
 	"crypto/x509"
 	"fmt"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
@@ -22,6 +23,7 @@ import (
 	"github.com/google/tink/go/subtle"
 
 	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 )
 
@@ -142,6 +144,20 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType) ([]byte, stri
 		if err != nil {
 			return nil, "", err
 		}
+	case kms.ECDSASecp256k1TypeDER:
+		tURL = secp256k1VerifierTypeURL
+
+		keyValue, err = getMarshalledSecp256K1Key(pubKey, secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER)
+		if err != nil {
+			return nil, "", err
+		}
+	case kms.ECDSASecp256k1TypeIEEEP1363:
+		tURL = secp256k1VerifierTypeURL
+
+		keyValue, err = getMarshalledSecp256K1Key(pubKey, secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_IEEE_P1363)
+		if err != nil {
+			return nil, "", err
+		}
 	case kms.ED25519Type:
 		tURL = ed25519VerifierTypeURL
 		pubKeyProto := new(ed25519pb.Ed25519PublicKey)
@@ -232,3 +248,23 @@ func newProtoECDSAPublicKey(ecPubKey *ecdsa.PublicKey, params *ecdsapb.EcdsaPara
 		Params:  params,
 	}
 }
+
+// getMarshalledSecp256K1Key accepts both the compressed and the uncompressed forms of the secp256k1 public key.
+func getMarshalledSecp256K1Key(marshaledPubKey []byte,
+	encoding secp256k1pb.Secp256K1SignatureEncoding) ([]byte, error) {
+	pubKey, err := btcec.ParsePubKey(marshaledPubKey, btcec.S256())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secp256k1 public key: %w", err)
+	}
+
+	return proto.Marshal(&secp256k1pb.Secp256K1PublicKey{
+		Version: 0,
+		Params: &secp256k1pb.Secp256K1Params{
+			HashType: commonpb.HashType_SHA256,
+			Curve:    secp256k1pb.BitcoinCurveType_SECP256K1,
+			Encoding: encoding,
+		},
+		X: pubKey.X.Bytes(),
+		Y: pubKey.Y.Bytes(),
+	})
+}
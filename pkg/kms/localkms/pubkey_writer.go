@@ -14,6 +14,7 @@ import (
 	"io"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/golang/protobuf/proto"
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
 	bbspb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
+	secp256k1pb "github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
 )
 
 const (
@@ -31,6 +33,7 @@ const (
 	nistPECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPublicKey"
 	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
 	bbsVerifierKeyTypeURL        = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
+	secp256k1VerifierTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.Secp256k1PublicKey"
 )
 
 // PubKeyWriter will write the raw bytes of a Tink KeySet's primary public key
@@ -68,7 +71,7 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 	for _, key := range ks {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
-			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL:
+			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, secp256k1VerifierTypeURL:
 				created, err = writePubKey(w, key)
 				if err != nil {
 					return err
@@ -97,11 +100,11 @@ func write(w io.Writer, msg *tinkpb.Keyset) error {
 	return nil
 }
 
-func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
+func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) { //nolint:gocyclo
 	var marshaledRawPubKey []byte
 
 	// TODO add other key types than the ones below and other than nistPECDHKWPublicKeyTypeURL and
-	// TODO x25519ECDHKWPublicKeyTypeURL(eg: secp256k1 when introduced in KMS).
+	// TODO x25519ECDHKWPublicKeyTypeURL.
 	switch key.KeyData.TypeUrl {
 	case ecdsaVerifierTypeURL:
 		pubKeyProto := new(ecdsapb.EcdsaPublicKey)
@@ -135,6 +138,18 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 
 		marshaledRawPubKey = make([]byte, len(pubKeyProto.KeyValue))
 		copy(marshaledRawPubKey, pubKeyProto.KeyValue)
+	case secp256k1VerifierTypeURL:
+		pubKeyProto := new(secp256k1pb.Secp256K1PublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, err
+		}
+
+		marshaledRawPubKey, err = getMarshalledSecp256K1KeyValueFromProto(pubKeyProto)
+		if err != nil {
+			return false, err
+		}
 	default:
 		return false, fmt.Errorf("can't export key with keyURL:%s", key.KeyData.TypeUrl)
 	}
@@ -147,6 +162,22 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, error) {
 	return n > 0, nil
 }
 
+// getMarshalledSecp256K1KeyValueFromProto returns the uncompressed public key point, whatever the signature encoding
+// of the key is. PubKeyBytesToHandle accepts the compressed form too.
+func getMarshalledSecp256K1KeyValueFromProto(pubKeyProto *secp256k1pb.Secp256K1PublicKey) ([]byte, error) {
+	if pubKeyProto.Params == nil {
+		return nil, fmt.Errorf("undefined secp256k1 key params")
+	}
+
+	pubKey := &btcec.PublicKey{
+		Curve: btcec.S256(),
+		X:     new(big.Int).SetBytes(pubKeyProto.X),
+		Y:     new(big.Int).SetBytes(pubKeyProto.Y),
+	}
+
+	return pubKey.SerializeUncompressed(), nil
+}
+
 func getMarshalledECDSAKeyValueFromProto(pubKeyProto *ecdsapb.EcdsaPublicKey) ([]byte, error) {
 	var (
 		marshaledRawPubKey []byte
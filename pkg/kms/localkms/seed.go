@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	minSeedSize = 32
+
+	// seedDerivationSalt is the HKDF salt used to derive keys from the master seed.
+	seedDerivationSalt = "aries-framework-go/localkms/seed"
+	// seedIndexPrefix prefixes the store entries holding the next derivation index of each key type.
+	seedIndexPrefix = "seedindex_"
+
+	// extra bytes read when deriving an EC private key to make the modulo bias negligible (FIPS 186-4 B.4.1).
+	ecExtraBytes = 8
+)
+
+// seedCurves are the curves of the EC key types that can be derived from a master seed.
+// nolint:gochecknoglobals
+var seedCurves = map[kms.KeyType]elliptic.Curve{
+	kms.ECDSAP256TypeDER:            elliptic.P256(),
+	kms.ECDSAP384TypeDER:            elliptic.P384(),
+	kms.ECDSAP521TypeDER:            elliptic.P521(),
+	kms.ECDSAP256TypeIEEEP1363:      elliptic.P256(),
+	kms.ECDSAP384TypeIEEEP1363:      elliptic.P384(),
+	kms.ECDSAP521TypeIEEEP1363:      elliptic.P521(),
+	kms.ECDSASecp256k1TypeDER:       btcec.S256(),
+	kms.ECDSASecp256k1TypeIEEEP1363: btcec.S256(),
+}
+
+// createFromSeed derives the next key of type kt from the master seed and stores it with its public key's thumbprint
+// as key ID. The derivation index of each key type starts at 0 and is incremented after each Create.
+func (l *LocalKMS) createFromSeed(kt kms.KeyType) (string, *keyset.Handle, error) {
+	l.seedLock.Lock()
+	defer l.seedLock.Unlock()
+
+	index, err := l.nextSeedIndex(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to get seed derivation index: %w", err)
+	}
+
+	ks, err := deriveKeySet(l.masterSeed, kt, index)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to derive key from seed: %w", err)
+	}
+
+	kh, err := insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: ks})
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to read derived keyset: %w", err)
+	}
+
+	kid, err := l.generateKID(kh, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to generate kid: %w", err)
+	}
+
+	kid, err = l.writeImportedKey(ks, kms.WithKeyID(kid))
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to store derived keyset: %w", err)
+	}
+
+	err = l.store.Put(seedIndexPrefix+string(kt), []byte(strconv.FormatUint(index+1, 10)))
+	if err != nil {
+		return "", nil, fmt.Errorf("create: failed to store seed derivation index: %w", err)
+	}
+
+	return kid, kh, nil
+}
+
+func (l *LocalKMS) nextSeedIndex(kt kms.KeyType) (uint64, error) {
+	index, err := l.store.Get(seedIndexPrefix + string(kt))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseUint(string(index), 10, 64)
+}
+
+// deriveKeySet derives the key of type kt at the given index from seed. The key material is read from
+// HKDF-SHA256(ikm: seed, salt: "aries-framework-go/localkms/seed", info: "<kt>/<index>"), e.g. info is
+// "ED25519/0" for the first Ed25519 key. Symmetric and ECDH key types can't be derived, the others use the material as
+// follows:
+//  - ED25519: the first 32 bytes are the Ed25519 private key seed (RFC 8032).
+//  - ECDSA keys (NIST P-256, P-384, P-521 and secp256k1, DER or IEEE-P1363): c is read from the first
+//    (curve bit size / 8 + 8) bytes, and the private scalar is d = (c mod (n - 1)) + 1 (FIPS 186-4 B.4.1).
+//  - BLS12381G2: the first 32 bytes are the input keying material of the BBS+ key generation.
+func deriveKeySet(seed []byte, kt kms.KeyType, index uint64) (*tinkpb.Keyset, error) {
+	material := hkdf.New(sha256.New, seed, []byte(seedDerivationSalt), []byte(fmt.Sprintf("%s/%d", kt, index)))
+
+	if curve, ok := seedCurves[kt]; ok {
+		privKey, err := deriveECDSAKey(curve, material)
+		if err != nil {
+			return nil, err
+		}
+
+		return newECKeySet(privKey, kt)
+	}
+
+	switch kt {
+	case kms.ED25519Type:
+		privKeySeed := make([]byte, ed25519.SeedSize)
+
+		if _, err := io.ReadFull(material, privKeySeed); err != nil {
+			return nil, err
+		}
+
+		return newEd25519KeySet(ed25519.NewKeyFromSeed(privKeySeed), kt)
+	case kms.BLS12381G2Type:
+		ikm := make([]byte, minSeedSize)
+
+		if _, err := io.ReadFull(material, ikm); err != nil {
+			return nil, err
+		}
+
+		_, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, ikm)
+		if err != nil {
+			return nil, err
+		}
+
+		return newBBSKeySet(privKey, kt)
+	default:
+		return nil, fmt.Errorf("key type '%s' can't be derived from a seed", kt)
+	}
+}
+
+func deriveECDSAKey(curve elliptic.Curve, material io.Reader) (*ecdsa.PrivateKey, error) {
+	params := curve.Params()
+
+	c := make([]byte, params.BitSize/8+ecExtraBytes)
+
+	if _, err := io.ReadFull(material, c); err != nil {
+		return nil, err
+	}
+
+	one := big.NewInt(1)
+	n := new(big.Int).Sub(params.N, one)
+
+	d := new(big.Int).SetBytes(c)
+	d.Mod(d, n)
+	d.Add(d, one)
+
+	privKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve},
+		D:         d,
+	}
+
+	privKey.X, privKey.Y = curve.ScalarBaseMult(d.Bytes())
+
+	return privKey, nil
+}
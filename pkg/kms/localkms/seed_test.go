@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+var seedKeyTypes = []kms.KeyType{ // nolint:gochecknoglobals
+	kms.ED25519Type,
+	kms.ECDSAP256TypeDER,
+	kms.ECDSAP384TypeDER,
+	kms.ECDSAP521TypeDER,
+	kms.ECDSAP256TypeIEEEP1363,
+	kms.ECDSAP384TypeIEEEP1363,
+	kms.ECDSAP521TypeIEEEP1363,
+	kms.ECDSASecp256k1TypeDER,
+	kms.ECDSASecp256k1TypeIEEEP1363,
+	kms.BLS12381G2Type,
+	kms.ED25519Type,
+	kms.ECDSAP256TypeIEEEP1363,
+}
+
+func TestLocalKMS_CreateFromSeed(t *testing.T) {
+	seed := bytes.Repeat([]byte{1}, 32)
+
+	t.Run("KMS instances with the same seed derive the same keys", func(t *testing.T) {
+		first := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+		second := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+
+		kids := map[string]bool{}
+
+		for _, kt := range seedKeyTypes {
+			kid, pubKey, err := first.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			kid2, pubKey2, err := second.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			require.Equal(t, kid, kid2)
+			require.Equal(t, pubKey, pubKey2)
+
+			// keys of the same type are derived at the next index.
+			require.False(t, kids[kid])
+			kids[kid] = true
+		}
+	})
+
+	t.Run("different seeds derive different keys", func(t *testing.T) {
+		first := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+		second := createSeedKMS(t, mockstorage.NewMockStoreProvider(), bytes.Repeat([]byte{2}, 32))
+
+		for _, kt := range seedKeyTypes {
+			kid, pubKey, err := first.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			kid2, pubKey2, err := second.CreateAndExportPubKeyBytes(kt)
+			require.NoError(t, err)
+
+			require.NotEqual(t, kid, kid2)
+			require.NotEqual(t, pubKey, pubKey2)
+		}
+	})
+
+	t.Run("derivation index is persisted in the store", func(t *testing.T) {
+		storeProvider := mockstorage.NewMockStoreProvider()
+
+		kid, _, err := createSeedKMS(t, storeProvider, seed).Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		kid2, _, err := createSeedKMS(t, storeProvider, seed).Create(kms.ED25519Type)
+		require.NoError(t, err)
+		require.NotEqual(t, kid, kid2)
+
+		recovered := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+
+		for _, expectedKID := range []string{kid, kid2} {
+			recoveredKID, _, err := recovered.Create(kms.ED25519Type)
+			require.NoError(t, err)
+			require.Equal(t, expectedKID, recoveredKID)
+		}
+	})
+
+	t.Run("derived keys sign", func(t *testing.T) {
+		k := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+
+		tinkCrypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		for _, kt := range []kms.KeyType{
+			kms.ED25519Type, kms.ECDSAP256TypeDER, kms.ECDSAP521TypeIEEEP1363, kms.ECDSASecp256k1TypeIEEEP1363,
+		} {
+			kid, kh, err := k.Create(kt)
+			require.NoError(t, err)
+
+			sig, err := tinkCrypto.Sign([]byte("test message"), kh)
+			require.NoError(t, err)
+
+			pubKey, err := k.ExportPubKeyBytes(kid)
+			require.NoError(t, err)
+
+			pubKH, err := k.PubKeyBytesToHandle(pubKey, kt)
+			require.NoError(t, err)
+
+			require.NoError(t, tinkCrypto.Verify(sig, []byte("test message"), pubKH))
+		}
+
+		kid, kh, err := k.Create(kms.BLS12381G2Type)
+		require.NoError(t, err)
+
+		sig, err := tinkCrypto.SignMulti([][]byte{[]byte("test message")}, kh)
+		require.NoError(t, err)
+
+		pubKey, err := k.ExportPubKeyBytes(kid)
+		require.NoError(t, err)
+
+		pubKH, err := k.PubKeyBytesToHandle(pubKey, kms.BLS12381G2Type)
+		require.NoError(t, err)
+
+		require.NoError(t, tinkCrypto.VerifyMulti([][]byte{[]byte("test message")}, sig, pubKH))
+	})
+
+	t.Run("unsupported key types and rotation", func(t *testing.T) {
+		k := createSeedKMS(t, mockstorage.NewMockStoreProvider(), seed)
+
+		_, _, err := k.Create(kms.AES256GCMType)
+		require.EqualError(t, err, "create: failed to derive key from seed: key type 'AES256GCM' can't be derived "+
+			"from a seed")
+
+		kid, _, err := k.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, _, err = k.Rotate(kms.ED25519Type, kid)
+		require.EqualError(t, err, "rotate: not supported for keys derived from a master seed")
+	})
+
+	t.Run("seed too short", func(t *testing.T) {
+		p := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+
+		_, err := New(testMasterKeyURI, p, WithMasterSeed([]byte("short seed")))
+		require.EqualError(t, err, "new: master seed must be at least 32 bytes long")
+	})
+
+	t.Run("invalid derivation index", func(t *testing.T) {
+		storeProvider := mockstorage.NewMockStoreProvider()
+		k := createSeedKMS(t, storeProvider, seed)
+
+		require.NoError(t, k.store.Put(seedIndexPrefix+string(kms.ED25519Type), []byte("not an index")))
+
+		_, _, err := k.Create(kms.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "create: failed to get seed derivation index")
+	})
+}
+
+func createSeedKMS(t *testing.T, storeProvider storage.Provider, seed []byte) *LocalKMS {
+	t.Helper()
+
+	p := mockkms.NewProviderForKMS(storeProvider, &noop.NoLock{})
+
+	k, err := New(testMasterKeyURI, p, WithMasterSeed(seed))
+	require.NoError(t, err)
+
+	return k
+}
@@ -77,6 +77,14 @@ type importKeyResp struct {
 	Location string `json:"location,omitempty"`
 }
 
+type rotateKeyReq struct {
+	KeyType string `json:"keyType,omitempty"`
+}
+
+type rotateKeyResp struct {
+	Location string `json:"location,omitempty"`
+}
+
 type marshalFunc func(interface{}) ([]byte, error)
 
 type unmarshalFunc func([]byte, interface{}) error
@@ -328,7 +336,75 @@ func (r *RemoteKMS) buildKIDURL(keyID string) string {
 //  - handle instance (to private key)
 //  - error if failure
 func (r *RemoteKMS) Rotate(kt kms.KeyType, keyID string) (string, interface{}, error) {
-	return "", nil, errors.New("function Rotate is not implemented in remoteKMS")
+	startRotate := time.Now()
+
+	destination := r.buildKIDURL(keyID) + "/rotate"
+
+	httpReqJSON := &rotateKeyReq{
+		KeyType: string(kt),
+	}
+
+	marshaledReq, err := r.marshalFunc(httpReqJSON)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal Rotate key request [%s, %w]", destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, marshaledReq)
+	if err != nil {
+		return "", nil, fmt.Errorf("posting Rotate key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "Rotate")
+
+	keyURL, err := r.rotatedKeyURL(resp, destination)
+	if err != nil {
+		return "", nil, err
+	}
+
+	kid := keyURL[strings.LastIndex(keyURL, "/")+1:]
+
+	logger.Infof("overall Rotate key duration: %s", time.Since(startRotate))
+
+	return kid, keyURL, nil
+}
+
+// rotatedKeyURL reads the URL of the rotated key from the Rotate response.
+func (r *RemoteKMS) rotatedKeyURL(resp *http.Response, destination string) (string, error) {
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("read key response for Rotate failed [%s, %w]", destination, err)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errAPI errMessage
+
+		err = r.unmarshalFunc(respBody, &errAPI)
+		if err != nil {
+			return "", fmt.Errorf("unmarshal error for Rotate failed [%s, %w]", destination, err)
+		}
+
+		return "", fmt.Errorf("rotate key failed [%s, %s]", destination, errAPI.Error)
+	}
+
+	keyURL := resp.Header.Get(LocationHeader)
+
+	if keyURL == "" {
+		var httpResp rotateKeyResp
+
+		err = r.unmarshalFunc(respBody, &httpResp)
+		if err != nil {
+			return "", fmt.Errorf("unmarshal key for Rotate failed [%s, %w]", destination, err)
+		}
+
+		keyURL = httpResp.Location
+	}
+
+	if keyURL == "" {
+		return "", fmt.Errorf("no key location in Rotate response [%s]", destination)
+	}
+
+	return keyURL, nil
 }
 
 // ExportPubKeyBytes will remotely fetch a key referenced by id then gets its public key in raw bytes and returns it.
@@ -398,6 +474,11 @@ func (r *RemoteKMS) PubKeyBytesToHandle(pubKey []byte, kt kms.KeyType) (interfac
 	return nil, errors.New("function PubKeyBytesToHandle is not implemented in remoteKMS")
 }
 
+// ExportPrivateKey is not implemented in remoteKMS, private keys never leave the remote key server.
+func (r *RemoteKMS) ExportPrivateKey(keyID string) ([]byte, kms.KeyType, error) {
+	return nil, "", errors.New("function ExportPrivateKey is not implemented in remoteKMS")
+}
+
 // ImportPrivateKey will import privKey into the KMS storage for the given KeyType then returns the new key id and
 // the newly persisted Handle.
 // 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
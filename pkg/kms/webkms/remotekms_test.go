@@ -38,6 +38,7 @@ const (
 	controller        = "did:example:123456789"
 	defaultKeyStoreID = "12345"
 	defaultKID        = "99999"
+	defaultRotatedKID = "88888"
 )
 
 const xRootCapabilityHeaderValue = "DUMMY"
@@ -199,11 +200,16 @@ func TestRemoteKeyStore(t *testing.T) {
 			require.Contains(t, err.Error(), "posting GET ExportPubKeyBytes key failed")
 		})
 
-		_, _, err = remoteKMS.Rotate(kms.AES128GCMType, "")
-		require.EqualError(t, err, "function Rotate is not implemented in remoteKMS")
+		rotatedKID, rotatedKH, err := remoteKMS.Rotate(kms.ED25519Type, kid)
+		require.NoError(t, err)
+		require.Equal(t, defaultRotatedKID, rotatedKID)
+		require.Contains(t, rotatedKH, fmt.Sprintf("%s/keys/%s", defaultKeystoreURL, defaultRotatedKID))
 
 		_, err = remoteKMS.PubKeyBytesToHandle(nil, kms.AES128GCMType)
 		require.EqualError(t, err, "function PubKeyBytesToHandle is not implemented in remoteKMS")
+
+		_, _, err = remoteKMS.ExportPrivateKey(kid)
+		require.EqualError(t, err, "function ExportPrivateKey is not implemented in remoteKMS")
 	})
 }
 
@@ -346,6 +352,122 @@ func TestImportPrivateKey(t *testing.T) {
 	remoteKMS.unmarshalFunc = json.Unmarshal
 }
 
+func TestRotate(t *testing.T) {
+	const authToken = "Bearer token"
+
+	keystoreURL := func(url string) string {
+		return fmt.Sprintf("%s/%s", strings.ReplaceAll(KeystoreEndpoint, "{serverEndpoint}", url), defaultKeyStoreID)
+	}
+
+	withAuth := WithHeaders(func(req *http.Request) (*http.Header, error) {
+		req.Header.Set("Authorization", authToken)
+
+		return &req.Header, nil
+	})
+
+	t.Run("success with the key location in the response body", func(t *testing.T) {
+		hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, authToken, r.Header.Get("Authorization"))
+			require.True(t, strings.HasSuffix(r.URL.Path, "/keys/"+defaultKID+"/rotate"))
+
+			var req rotateKeyReq
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, string(kms.ECDSAP256TypeIEEEP1363), req.KeyType)
+
+			mResp, err := json.Marshal(&rotateKeyResp{
+				Location: fmt.Sprintf("https://%s/kms/keystores/%s/keys/%s", r.Host, defaultKeyStoreID, defaultRotatedKID),
+			})
+			require.NoError(t, err)
+
+			_, err = w.Write(mResp)
+			require.NoError(t, err)
+		})
+
+		server, url, client := CreateMockHTTPServerAndClient(t, hf)
+
+		defer func() { require.NoError(t, server.Close()) }()
+
+		remoteKMS := New(keystoreURL(url), client, withAuth)
+
+		kid, kh, err := remoteKMS.Rotate(kms.ECDSAP256TypeIEEEP1363, defaultKID)
+		require.NoError(t, err)
+		require.Equal(t, defaultRotatedKID, kid)
+		require.True(t, strings.HasSuffix(kh.(string), "/keys/"+defaultRotatedKID))
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, err := w.Write([]byte(`{"errMessage": "key not found"}`))
+			require.NoError(t, err)
+		})
+
+		server, url, client := CreateMockHTTPServerAndClient(t, hf)
+
+		defer func() { require.NoError(t, server.Close()) }()
+
+		_, _, err := New(keystoreURL(url), client).Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rotate key failed")
+		require.Contains(t, err.Error(), "key not found")
+	})
+
+	t.Run("invalid API error", func(t *testing.T) {
+		hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		server, url, client := CreateMockHTTPServerAndClient(t, hf)
+
+		defer func() { require.NoError(t, server.Close()) }()
+
+		_, _, err := New(keystoreURL(url), client).Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal error for Rotate failed")
+	})
+
+	t.Run("no key location in the response", func(t *testing.T) {
+		hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(`{}`))
+			require.NoError(t, err)
+		})
+
+		server, url, client := CreateMockHTTPServerAndClient(t, hf)
+
+		defer func() { require.NoError(t, server.Close()) }()
+
+		remoteKMS := New(keystoreURL(url), client)
+
+		_, _, err := remoteKMS.Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no key location in Rotate response")
+
+		remoteKMS.unmarshalFunc = failingUnmarshal
+
+		_, _, err = remoteKMS.Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal key for Rotate failed")
+	})
+
+	t.Run("request failures", func(t *testing.T) {
+		remoteKMS := New(keystoreURL("https://localhost"), &http.Client{})
+
+		remoteKMS.marshalFunc = failingMarshal
+
+		_, _, err := remoteKMS.Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to marshal Rotate key request")
+
+		remoteKMS.marshalFunc = json.Marshal
+		remoteKMS.keystoreURL = "``#$%"
+
+		_, _, err = remoteKMS.Rotate(kms.ED25519Type, defaultKID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "posting Rotate key failed")
+	})
+}
+
 func TestCloseResponseBody(t *testing.T) {
 	closeResponseBody(&errFailingCloser{}, logger, "testing close fail should log: errFailingCloser always fails")
 }
@@ -369,6 +491,13 @@ func processPOSTRequest(w http.ResponseWriter, r *http.Request, keysetID, kid, d
 		return processExportKeyRequest(w, defaultExportPubKey)
 	}
 
+	if strings.LastIndex(r.URL.Path, "/rotate") == len(r.URL.Path)-len("/rotate") {
+		w.Header().Add(LocationHeader,
+			fmt.Sprintf("https://%s/kms/keystores/%s/keys/%s", r.Host, keysetID, defaultRotatedKID))
+
+		return nil
+	}
+
 	w.Header().Add(LocationHeader, fmt.Sprintf("https://%s/kms/keystores/%s", r.Host, keysetID))
 
 	return nil
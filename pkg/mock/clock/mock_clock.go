@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// MockClock is a clock returning a fixed time, which can be fast-forwarded.
+type MockClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// New returns a mock clock set to the given time.
+func New(now time.Time) *MockClock {
+	return &MockClock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *MockClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.now
+}
+
+// Set sets the time of the clock.
+func (c *MockClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Add fast-forwards the clock by the given duration.
+func (c *MockClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
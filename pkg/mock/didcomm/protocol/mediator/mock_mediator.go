@@ -30,6 +30,8 @@ type MockMediatorSvc struct {
 	Connections        []string
 	GetConnectionsErr  error
 	AddKeyFunc         func(string) error
+	Keylist            *mediator.Keylist
+	KeylistQueryErr    error
 }
 
 // HandleInbound msg.
@@ -117,3 +119,12 @@ func (m *MockMediatorSvc) GetConnections() ([]string, error) {
 
 	return m.Connections, nil
 }
+
+// KeylistQuery returns the recipient keys registered with the router.
+func (m *MockMediatorSvc) KeylistQuery(connID string, p *mediator.Paginate) (*mediator.Keylist, error) {
+	if m.KeylistQueryErr != nil {
+		return nil, m.KeylistQueryErr
+	}
+
+	return m.Keylist, nil
+}
@@ -15,18 +15,22 @@ import (
 // MockMessagePickupSvc mock messagepickup service.
 type MockMessagePickupSvc struct {
 	service.DIDComm
-	ProtocolName       string
-	StatusRequestErr   error
-	StatusRequestFunc  func(connectionID string) (*messagepickup.Status, error)
-	BatchPickupErr     error
-	BatchPickupFunc    func(connectionID string, size int) (int, error)
-	HandleInboundFunc  func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
-	HandleOutboundFunc func(_ service.DIDCommMsg, _, _ string) (string, error)
-	AddMessageFunc     func(message *model.Envelope, theirDID string) error
-	AddMessageErr      error
-	AcceptFunc         func(msgType string) bool
-	NoopErr            error
-	NoopFunc           func(connectionID string) error
+	ProtocolName          string
+	StatusRequestErr      error
+	StatusRequestFunc     func(connectionID string) (*messagepickup.Status, error)
+	BatchPickupErr        error
+	BatchPickupFunc       func(connectionID string, size int) (int, error)
+	HandleInboundFunc     func(msg service.DIDCommMsg, ctx service.DIDCommContext) (string, error)
+	HandleOutboundFunc    func(_ service.DIDCommMsg, _, _ string) (string, error)
+	AddMessageFunc        func(message *model.Envelope, theirDID string) error
+	AddMessageErr         error
+	AcceptFunc            func(msgType string) bool
+	NoopErr               error
+	NoopFunc              func(connectionID string) error
+	StatusRequestV2Err    error
+	DeliveryRequestErr    error
+	DeliveryRequestFunc   func(connectionID string, limit int) (int, error)
+	LiveDeliveryChangeErr error
 }
 
 // Name return service name.
@@ -107,3 +111,30 @@ func (m *MockMessagePickupSvc) Noop(connectionID string) error {
 
 	return nil
 }
+
+// StatusRequestV2 perform StatusRequestV2.
+func (m *MockMessagePickupSvc) StatusRequestV2(connectionID string) (*messagepickup.StatusV2, error) {
+	if m.StatusRequestV2Err != nil {
+		return nil, m.StatusRequestV2Err
+	}
+
+	return &messagepickup.StatusV2{}, nil
+}
+
+// DeliveryRequest perform DeliveryRequest.
+func (m *MockMessagePickupSvc) DeliveryRequest(connectionID string, limit int) (int, error) {
+	if m.DeliveryRequestErr != nil {
+		return 0, m.DeliveryRequestErr
+	}
+
+	if m.DeliveryRequestFunc != nil {
+		return m.DeliveryRequestFunc(connectionID, limit)
+	}
+
+	return 0, nil
+}
+
+// LiveDeliveryChange perform LiveDeliveryChange.
+func (m *MockMessagePickupSvc) LiveDeliveryChange(connectionID string, liveDelivery bool) error {
+	return m.LiveDeliveryChangeErr
+}
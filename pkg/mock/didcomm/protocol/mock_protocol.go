@@ -42,6 +42,7 @@ type MockProvider struct {
 	InboundDIDCommMsgHandlerFunc func() service.InboundHandler
 	KeyTypeValue                 kms.KeyType
 	KeyAgreementTypeValue        kms.KeyType
+	ServiceEndpointValue         string
 }
 
 // OutboundDispatcher is mock outbound dispatcher for DID exchange service.
@@ -146,6 +147,11 @@ func (p *MockProvider) KeyAgreementType() kms.KeyType {
 	return p.KeyAgreementTypeValue
 }
 
+// ServiceEndpoint returns a mocked service endpoint.
+func (p *MockProvider) ServiceEndpoint() string {
+	return p.ServiceEndpointValue
+}
+
 type mockConnectionStore struct{}
 
 // GetDID returns DID associated with key.
@@ -12,6 +12,7 @@ import "github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
 type MockMessenger struct {
 	ErrReplyTo           error
 	ReplyToMsgFunc       func(service.DIDCommMsgMap, service.DIDCommMsgMap, string, string) error
+	ReplyToMsgToDestFunc func(service.DIDCommMsgMap, service.DIDCommMsgMap, string, *service.Destination) error
 	ErrReplyToNested     error
 	ErrSend              error
 	ErrSendToDestination error
@@ -35,6 +36,16 @@ func (m *MockMessenger) ReplyToMsg(in, out service.DIDCommMsgMap, myDID, theirDI
 	return nil
 }
 
+// ReplyToMsgToDestination mock messenger reply to msg to destination.
+func (m *MockMessenger) ReplyToMsgToDestination(in, out service.DIDCommMsgMap, sender string,
+	destination *service.Destination) error {
+	if m.ReplyToMsgToDestFunc != nil {
+		return m.ReplyToMsgToDestFunc(in, out, sender, destination)
+	}
+
+	return nil
+}
+
 // Send mock messenger Send.
 func (m *MockMessenger) Send(msg service.DIDCommMsgMap, myDID, theirDID string) error {
 	if m.ErrSend != nil {
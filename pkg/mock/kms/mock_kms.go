@@ -41,6 +41,9 @@ type KeyManager struct {
 	ImportPrivateKeyErr      error
 	ImportPrivateKeyID       string
 	ImportPrivateKeyValue    *keyset.Handle
+	ExportPrivateKeyValue    []byte
+	ExportPrivateKeyType     kmsservice.KeyType
+	ExportPrivateKeyErr      error
 }
 
 // Create a new mock ey/keyset/key handle for the type kt.
@@ -111,6 +114,15 @@ func (k *KeyManager) ImportPrivateKey(privKey interface{}, keyType kmsservice.Ke
 	return k.ImportPrivateKeyID, k.ImportPrivateKeyValue, nil
 }
 
+// ExportPrivateKey will return a mocked private JWK and its key type.
+func (k *KeyManager) ExportPrivateKey(keyID string) ([]byte, kmsservice.KeyType, error) {
+	if k.ExportPrivateKeyErr != nil {
+		return nil, "", k.ExportPrivateKeyErr
+	}
+
+	return k.ExportPrivateKeyValue, k.ExportPrivateKeyType, nil
+}
+
 func createMockKeyHandle(ks *tinkpb.Keyset) (*keyset.Handle, error) {
 	primaryKey := ks.Key[0]
 
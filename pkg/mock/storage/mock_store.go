@@ -144,9 +144,17 @@ func (s *MockStore) Get(k string) ([]byte, error) {
 	return entry.Value, s.ErrGet
 }
 
-// GetTags is not implemented.
+// GetTags fetches the tags associated with the given key.
 func (s *MockStore) GetTags(key string) ([]storage.Tag, error) {
-	panic("implement me")
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	entry, ok := s.Store[key]
+	if !ok {
+		return nil, storage.ErrDataNotFound
+	}
+
+	return entry.Tags, nil
 }
 
 // GetBulk is not implemented.
@@ -15,6 +15,7 @@ import (
 // to be used only for unit tests.
 type MockVDR struct {
 	AcceptValue    bool
+	AcceptFunc     func(method string) bool
 	StoreErr       error
 	ReadFunc       func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 	CreateFunc     func(did *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
@@ -61,6 +62,10 @@ func (m *MockVDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error
 
 // Accept did.
 func (m *MockVDR) Accept(method string) bool {
+	if m.AcceptFunc != nil {
+		return m.AcceptFunc(method)
+	}
+
 	return m.AcceptValue
 }
 
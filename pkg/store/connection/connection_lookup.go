@@ -10,6 +10,7 @@
 package connection
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +29,11 @@ const (
 	invKeyPrefix        = "inv"
 	eventDataKeyPrefix  = "connevent"
 	didConnMapKeyPrefix = "didconn"
+	connMetaKeyPrefix   = "connmeta"
+	transientTagName    = "transientconn"
+	inFlightKeyPrefix   = "conninflight"
+	inFlightEventPrefix = "conninflightevent"
+	pthIDTagName        = "connpthid"
 	keySeparator        = "_"
 	stateIDEmptyErr     = "stateID can't be empty"
 )
@@ -42,6 +48,16 @@ type provider interface {
 	StorageProvider() storage.Provider
 }
 
+// DIDCommVersion is the DIDComm messaging version a connection was established with.
+type DIDCommVersion string
+
+const (
+	// DIDCommV1 is the DIDComm V1 (Aries RFC) version.
+	DIDCommV1 DIDCommVersion = "v1"
+	// DIDCommV2 is the DIDComm V2 (DIF DIDComm Messaging) version.
+	DIDCommV2 DIDCommVersion = "v2"
+)
+
 // Record contain info about did exchange connection.
 type Record struct {
 	ConnectionID      string
@@ -59,6 +75,10 @@ type Record struct {
 	Implicit          bool
 	Namespace         string
 	MediaTypeProfiles []string
+	DIDCommVersion    DIDCommVersion
+	// Transient connections (e.g. of one-shot out-of-band exchanges) are kept in the protocol state store only,
+	// they are never written to the permanent store and are removed once their time to live has elapsed.
+	Transient bool
 }
 
 // NewLookup returns new connection lookup instance.
@@ -69,7 +89,9 @@ func NewLookup(p provider) (*Lookup, error) {
 		return nil, fmt.Errorf("failed to open permanent store to create new connection recorder: %w", err)
 	}
 
-	err = p.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix}})
+	err = p.StorageProvider().SetStoreConfig(Namespace, storage.StoreConfiguration{
+		TagNames: []string{connIDKeyPrefix, inFlightKeyPrefix, pthIDTagName},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in permanent store: %w", err)
 	}
@@ -80,7 +102,7 @@ func NewLookup(p provider) (*Lookup, error) {
 	}
 
 	err = p.ProtocolStateStorageProvider().SetStoreConfig(Namespace,
-		storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix, connStateKeyPrefix}})
+		storage.StoreConfiguration{TagNames: []string{connIDKeyPrefix, connStateKeyPrefix, transientTagName}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set store config in protocol state store: %w", err)
 	}
@@ -113,10 +135,70 @@ func (c *Lookup) GetConnectionRecord(connectionID string) (*Record, error) {
 	return &rec, nil
 }
 
-// QueryConnectionRecords returns connection records found in underlying store
-// for given query criteria.
+// QueryOpt sets a criterion the connection records returned by QueryConnectionRecordsWithOpts must match.
+type QueryOpt func(*queryFilter)
+
+// WithConnectionID matches the connection record with the given connection ID.
+func WithConnectionID(connectionID string) QueryOpt {
+	return func(f *queryFilter) {
+		f.connectionID = connectionID
+	}
+}
+
+// WithMyDID matches the connection records with the given DID of the agent.
+func WithMyDID(myDID string) QueryOpt {
+	return func(f *queryFilter) {
+		f.myDID = myDID
+	}
+}
+
+// WithTheirDID matches the connection records with the given DID of the other party.
+func WithTheirDID(theirDID string) QueryOpt {
+	return func(f *queryFilter) {
+		f.theirDID = theirDID
+	}
+}
+
+// WithState matches the connection records in the given state.
+func WithState(state string) QueryOpt {
+	return func(f *queryFilter) {
+		f.state = state
+	}
+}
+
+// queryFilter holds the criteria of a query, empty criteria match all the records.
+type queryFilter struct {
+	connectionID string
+	myDID        string
+	theirDID     string
+	state        string
+}
+
+func (f *queryFilter) matches(record *Record) bool {
+	return (f.connectionID == "" || f.connectionID == record.ConnectionID) &&
+		(f.myDID == "" || f.myDID == record.MyDID) &&
+		(f.theirDID == "" || f.theirDID == record.TheirDID) &&
+		(f.state == "" || f.state == record.State)
+}
+
+// QueryConnectionRecords returns connection records found in underlying store.
 func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
-	// TODO https://github.com/hyperledger/aries-framework-go/issues/655 query criteria to be added as part of issue
+	return c.QueryConnectionRecordsWithOpts()
+}
+
+// QueryConnectionRecordsWithOpts returns connection records found in underlying store
+// matching all the given criteria.
+func (c *Lookup) QueryConnectionRecordsWithOpts(opts ...QueryOpt) ([]*Record, error) {
+	filter := &queryFilter{}
+
+	for _, opt := range opts {
+		opt(filter)
+	}
+
+	if filter.connectionID != "" {
+		return c.queryConnectionRecordByID(filter)
+	}
+
 	searchKey := getConnectionKeyPrefix()("")
 
 	persistentStoreRecords, persistentStoreKeys, err := c.getDataFromPersistentStore(searchKey)
@@ -130,7 +212,33 @@ func (c *Lookup) QueryConnectionRecords() ([]*Record, error) {
 			"from the protocol state store: %w", err)
 	}
 
-	return allRecords, nil
+	var records []*Record
+
+	for _, record := range allRecords {
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// queryConnectionRecordByID gets the record of filter.connectionID instead of going through all the records.
+func (c *Lookup) queryConnectionRecordByID(filter *queryFilter) ([]*Record, error) {
+	record, err := c.GetConnectionRecord(filter.connectionID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection record: %w", err)
+	}
+
+	if !filter.matches(record) {
+		return nil, nil
+	}
+
+	return []*Record{record}, nil
 }
 
 func (c *Lookup) addDataFromProtocolStateStoreToRecords(searchKey string, keys map[string]struct{},
@@ -155,6 +263,55 @@ func (c *Lookup) addDataFromProtocolStateStoreToRecords(searchKey string, keys m
 	return records, nil
 }
 
+// GetConnectionRecordsByParentThreadID returns the completed connection records with the given parent thread ID,
+// e.g. the connections established by accepting the out-of-band invitation with this ID. Transient connection
+// records are not returned.
+func (c *Lookup) GetConnectionRecordsByParentThreadID(pthID string) ([]*Record, error) {
+	if pthID == "" {
+		return nil, fmt.Errorf(errMsgInvalidKey)
+	}
+
+	tag := parentThreadIDTag(pthID)
+
+	itr, err := c.store.Query(tag.Name + ":" + tag.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query permanent store: %w", err)
+	}
+
+	defer func() {
+		errClose := itr.Close()
+		if errClose != nil {
+			logger.Errorf("failed to close records iterator from permanent storage: %s", errClose.Error())
+		}
+	}()
+
+	var records []*Record
+
+	more, err := itr.Next()
+
+	for ; more && err == nil; more, err = itr.Next() {
+		value, errValue := itr.Value()
+		if errValue != nil {
+			return nil, fmt.Errorf("failed to get value from iterator: %w", errValue)
+		}
+
+		var record Record
+
+		errValue = json.Unmarshal(value, &record)
+		if errValue != nil {
+			return nil, fmt.Errorf("failed to unmarshal connection record: %w", errValue)
+		}
+
+		records = append(records, &record)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next set of data from permanent storage iterator: %w", err)
+	}
+
+	return records, nil
+}
+
 // GetConnectionRecordAtState return connection record based on the connection ID and state.
 func (c *Lookup) GetConnectionRecordAtState(connectionID, stateID string) (*Record, error) {
 	if stateID == "" {
@@ -191,6 +348,11 @@ func (c *Lookup) GetConnectionRecordByNSThreadID(nsThreadID string) (*Record, er
 // GetConnectionIDByDIDs return connection id based on dids (my or their did) metadata.
 func (c *Lookup) GetConnectionIDByDIDs(myDID, theirDID string) (string, error) {
 	connectionIDBytes, err := c.store.Get(getDIDConnMapKeyPrefix()(myDID, theirDID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		// the DIDs of transient connections are mapped in the protocol state store
+		connectionIDBytes, err = c.protocolStateStore.Get(getDIDConnMapKeyPrefix()(myDID, theirDID))
+	}
+
 	if err != nil {
 		return "", fmt.Errorf("get did-connection map : %w", err)
 	}
@@ -198,6 +360,22 @@ func (c *Lookup) GetConnectionIDByDIDs(myDID, theirDID string) (string, error) {
 	return string(connectionIDBytes), nil
 }
 
+// GetConnectionMetadata returns the application-specific metadata saved for the connection with the given ID.
+func (c *Lookup) GetConnectionMetadata(connectionID string) (map[string]interface{}, error) {
+	if connectionID == "" {
+		return nil, fmt.Errorf(errMsgInvalidKey)
+	}
+
+	var meta map[string]interface{}
+
+	err := getAndUnmarshal(getConnectionMetadataKeyPrefix()(connectionID), &meta, c.store)
+	if err != nil {
+		return nil, fmt.Errorf("get connection metadata: connectionid=%s err=%w", connectionID, err)
+	}
+
+	return meta, nil
+}
+
 // GetInvitation finds and parses stored invitation to target type.
 // TODO should avoid using target of type `interface{}` [Issue #1030].
 func (c *Lookup) GetInvitation(id string, target interface{}) error {
@@ -285,6 +463,12 @@ func getAndUnmarshal(key string, target interface{}, store storage.Store) error
 	return nil
 }
 
+// parentThreadIDTag returns the tag indexing completed connection records by parent thread ID. Its value is encoded
+// since tag values can't contain ':'.
+func parentThreadIDTag(pthID string) storage.Tag {
+	return storage.Tag{Name: pthIDTagName, Value: base64.RawURLEncoding.EncodeToString([]byte(pthID))}
+}
+
 // getConnectionKeyPrefix key prefix for connection record persisted.
 func getConnectionKeyPrefix() KeyPrefix {
 	return func(key ...string) string {
@@ -320,6 +504,20 @@ func getEventDataKeyPrefix() KeyPrefix {
 	}
 }
 
+// getInFlightKeyPrefix key prefix for checkpointing in-flight connection records.
+func getInFlightKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, inFlightKeyPrefix, strings.Join(key, keySeparator))
+	}
+}
+
+// getInFlightEventKeyPrefix key prefix for checkpointing the event data of in-flight connections.
+func getInFlightEventKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, inFlightEventPrefix, strings.Join(key, keySeparator))
+	}
+}
+
 // getDIDConnMapKeyPrefix key prefix for saving mapping between DID and ConnectionID.
 func getDIDConnMapKeyPrefix() KeyPrefix {
 	return func(key ...string) string {
@@ -327,6 +525,13 @@ func getDIDConnMapKeyPrefix() KeyPrefix {
 	}
 }
 
+// getConnectionMetadataKeyPrefix key prefix for saving connection metadata.
+func getConnectionMetadataKeyPrefix() KeyPrefix {
+	return func(key ...string) string {
+		return fmt.Sprintf(keyPattern, connMetaKeyPrefix, strings.Join(key, keySeparator))
+	}
+}
+
 // CreateNamespaceKey creates key prefix for namespace related data.
 func CreateNamespaceKey(prefix, thID string) (string, error) {
 	key, err := computeHash([]byte(thID))
@@ -351,6 +351,130 @@ func TestConnectionRecorder_QueryConnectionRecord(t *testing.T) {
 	})
 }
 
+func TestConnectionRecorder_QueryConnectionRecordsWithOpts(t *testing.T) {
+	recorder, err := NewRecorder(&mockProvider{})
+	require.NoError(t, err)
+
+	records := []*Record{
+		{ConnectionID: "conn1", ThreadID: "thread1", MyDID: "did:example:alice", TheirDID: "did:example:bob",
+			State: StateNameCompleted},
+		{ConnectionID: "conn2", ThreadID: "thread2", MyDID: "did:example:alice", TheirDID: "did:example:carol",
+			State: StateNameCompleted},
+		{ConnectionID: "conn3", ThreadID: "thread3", MyDID: "did:example:dave", TheirDID: "did:example:bob",
+			State: "requested"},
+		{ConnectionID: "conn4", ThreadID: "thread4", State: "invited"},
+	}
+
+	for _, record := range records {
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+	}
+
+	tests := []struct {
+		name     string
+		opts     []QueryOpt
+		expected []string
+	}{
+		{name: "no criteria", expected: []string{"conn1", "conn2", "conn3", "conn4"}},
+		{name: "connection ID", opts: []QueryOpt{WithConnectionID("conn3")}, expected: []string{"conn3"}},
+		{name: "unknown connection ID", opts: []QueryOpt{WithConnectionID("conn5")}},
+		{name: "my DID", opts: []QueryOpt{WithMyDID("did:example:alice")}, expected: []string{"conn1", "conn2"}},
+		{name: "their DID", opts: []QueryOpt{WithTheirDID("did:example:bob")}, expected: []string{"conn1", "conn3"}},
+		{name: "state", opts: []QueryOpt{WithState(StateNameCompleted)}, expected: []string{"conn1", "conn2"}},
+		{
+			name:     "their DID and state",
+			opts:     []QueryOpt{WithTheirDID("did:example:bob"), WithState("requested")},
+			expected: []string{"conn3"},
+		},
+		{
+			name: "my DID, their DID and state",
+			opts: []QueryOpt{
+				WithMyDID("did:example:alice"), WithTheirDID("did:example:carol"), WithState(StateNameCompleted),
+			},
+			expected: []string{"conn2"},
+		},
+		{
+			name: "connection ID and not matching state",
+			opts: []QueryOpt{WithConnectionID("conn1"), WithState("requested")},
+		},
+		{
+			name: "no record matches all the criteria",
+			opts: []QueryOpt{WithMyDID("did:example:dave"), WithState(StateNameCompleted)},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := recorder.QueryConnectionRecordsWithOpts(tc.opts...)
+			require.NoError(t, err)
+
+			connectionIDs := make([]string, len(result))
+			for i, record := range result {
+				connectionIDs[i] = record.ConnectionID
+			}
+
+			require.ElementsMatch(t, tc.expected, connectionIDs)
+		})
+	}
+
+	t.Run("connection ID - error scenario", func(t *testing.T) {
+		lookup, err := NewLookup(&mockProvider{store: &mockstorage.MockStore{
+			ErrGet: fmt.Errorf(sampleErrMsg),
+			Store:  make(map[string]mockstorage.DBEntry),
+		}})
+		require.NoError(t, err)
+
+		result, err := lookup.QueryConnectionRecordsWithOpts(WithConnectionID("conn1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+		require.Empty(t, result)
+	})
+}
+
+func TestLookup_GetConnectionRecordsByParentThreadID(t *testing.T) {
+	recorder, err := NewRecorder(&mockProvider{})
+	require.NoError(t, err)
+
+	const pthID = "did:example:inviter#invitation-1"
+
+	records := []*Record{
+		{ConnectionID: "conn1", ThreadID: "thread1", ParentThreadID: pthID, State: StateNameCompleted},
+		{ConnectionID: "conn2", ThreadID: "thread2", ParentThreadID: "other", State: StateNameCompleted},
+		{ConnectionID: "conn3", ThreadID: "thread3", ParentThreadID: pthID, State: "requested"},
+		{ConnectionID: "conn4", ThreadID: "thread4", ParentThreadID: pthID, State: StateNameCompleted,
+			Transient: true},
+	}
+
+	for _, record := range records {
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+	}
+
+	result, err := recorder.GetConnectionRecordsByParentThreadID(pthID)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "conn1", result[0].ConnectionID)
+
+	result, err = recorder.GetConnectionRecordsByParentThreadID("unknown")
+	require.NoError(t, err)
+	require.Empty(t, result)
+
+	_, err = recorder.GetConnectionRecordsByParentThreadID("")
+	require.EqualError(t, err, errMsgInvalidKey)
+
+	t.Run("query error", func(t *testing.T) {
+		lookup, err := NewLookup(&mockProvider{store: &mockstorage.MockStore{
+			ErrQuery: fmt.Errorf(sampleErrMsg),
+			Store:    make(map[string]mockstorage.DBEntry),
+		}})
+		require.NoError(t, err)
+
+		_, err = lookup.GetConnectionRecordsByParentThreadID(pthID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+}
+
 func TestGetConnectionIDByDIDs(t *testing.T) {
 	myDID := "did:mydid:123"
 	theirDID := "did:theirdid:789"
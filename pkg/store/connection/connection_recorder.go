@@ -14,6 +14,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -21,6 +23,8 @@ import (
 const (
 	// StateNameCompleted completed state.
 	StateNameCompleted = "completed"
+	// stateNameAbandoned abandoned state, like the completed state it ends the exchange.
+	stateNameAbandoned = "abandoned"
 	// MyNSPrefix namespace val my.
 	MyNSPrefix = "my"
 	// TheirNSPrefix namespace val their
@@ -28,23 +32,42 @@ const (
 	//  will need to be figured with verification key
 	TheirNSPrefix    = "their"
 	errMsgInvalidKey = "invalid key"
+	// DefaultTransientTTL is the default time to live of transient connections.
+	DefaultTransientTTL = time.Hour
 )
 
+// RecorderOption configures the connection recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransientTTL sets the time to live of transient connections, after which they are garbage-collected.
+func WithTransientTTL(ttl time.Duration) RecorderOption {
+	return func(r *Recorder) {
+		r.transientTTL = ttl
+	}
+}
+
 // NewRecorder returns new connection recorder.
 // Recorder is read-write connection store which provides
 // write features on top query features from Lookup.
-func NewRecorder(p provider) (*Recorder, error) {
+func NewRecorder(p provider, opts ...RecorderOption) (*Recorder, error) {
 	lookup, err := NewLookup(p)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new connection recorder : %w", err)
 	}
 
-	return &Recorder{lookup}, nil
+	recorder := &Recorder{Lookup: lookup, transientTTL: DefaultTransientTTL}
+
+	for _, opt := range opts {
+		opt(recorder)
+	}
+
+	return recorder, nil
 }
 
 // Recorder is read-write connection store.
 type Recorder struct {
 	*Lookup
+	transientTTL time.Duration
 }
 
 // SaveInvitation saves invitation in permanent store for given key.
@@ -58,12 +81,71 @@ func (c *Recorder) SaveInvitation(id string, invitation interface{}) error {
 }
 
 // SaveConnectionRecord saves given connection records in underlying store.
+// Transient connection records are saved in the protocol state store only, the ones which have expired are
+// garbage-collected along the way.
 func (c *Recorder) SaveConnectionRecord(record *Record) error {
-	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-		record, c.protocolStateStore, storage.Tag{
+	tags := []storage.Tag{{
+		Name:  getConnectionKeyPrefix()(""),
+		Value: getConnectionKeyPrefix()(record.ConnectionID),
+	}}
+
+	if record.Transient {
+		if err := c.RemoveExpiredTransientConnections(); err != nil {
+			return fmt.Errorf("remove expired transient connections: %w", err)
+		}
+
+		tags = append(tags, storage.Tag{
+			Name:  transientTagName,
+			Value: strconv.FormatInt(time.Now().Add(c.transientTTL).UnixNano(), 10),
+		})
+	}
+
+	if err := c.saveProtocolState(record, tags...); err != nil {
+		return err
+	}
+
+	if !record.Transient {
+		if err := c.checkpointConnection(record); err != nil {
+			return fmt.Errorf("checkpoint connection record in permanent store: %w", err)
+		}
+	}
+
+	if record.State == StateNameCompleted && record.Transient {
+		// create map between DIDs and ConnectionID
+		if err := c.protocolStateStore.Put(getDIDConnMapKeyPrefix()(record.MyDID, record.TheirDID),
+			[]byte(record.ConnectionID)); err != nil {
+			return fmt.Errorf("save did and connection map in protocol state store: %w", err)
+		}
+	}
+
+	if record.State == StateNameCompleted && !record.Transient {
+		completedTags := []storage.Tag{{
 			Name:  getConnectionKeyPrefix()(""),
 			Value: getConnectionKeyPrefix()(record.ConnectionID),
-		}); err != nil {
+		}}
+
+		if record.ParentThreadID != "" {
+			completedTags = append(completedTags, parentThreadIDTag(record.ParentThreadID))
+		}
+
+		if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
+			record, c.store, completedTags...); err != nil {
+			return fmt.Errorf("save connection record in permanent store: %w", err)
+		}
+
+		// create map between DIDs and ConnectionID
+		if err := c.store.Put(getDIDConnMapKeyPrefix()(record.MyDID, record.TheirDID),
+			[]byte(record.ConnectionID)); err != nil {
+			return fmt.Errorf("save did and connection map in store: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Recorder) saveProtocolState(record *Record, tags ...storage.Tag) error {
+	if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
+		record, c.protocolStateStore, tags...); err != nil {
 		return fmt.Errorf("save connection record in protocol state store: %w", err)
 	}
 
@@ -78,25 +160,119 @@ func (c *Recorder) SaveConnectionRecord(record *Record) error {
 		}
 	}
 
-	if record.State == StateNameCompleted {
-		if err := marshalAndSave(getConnectionKeyPrefix()(record.ConnectionID),
-			record, c.store, storage.Tag{
-				Name:  getConnectionKeyPrefix()(""),
-				Value: getConnectionKeyPrefix()(record.ConnectionID),
-			}); err != nil {
-			return fmt.Errorf("save connection record in permanent store: %w", err)
+	return nil
+}
+
+// checkpointConnection keeps a copy of the in-flight connection record in the permanent store, so that the exchange
+// can be resumed after a restart (see RestoreInFlightConnections). The copy is removed once the exchange has ended.
+func (c *Recorder) checkpointConnection(record *Record) error {
+	if record.State == StateNameCompleted || record.State == stateNameAbandoned {
+		return c.removeCheckpoint(record.ConnectionID)
+	}
+
+	return marshalAndSave(getInFlightKeyPrefix()(record.ConnectionID), record, c.store,
+		storage.Tag{Name: inFlightKeyPrefix})
+}
+
+func (c *Recorder) removeCheckpoint(connectionID string) error {
+	for _, key := range []string{
+		getInFlightKeyPrefix()(connectionID),
+		getInFlightEventKeyPrefix()(connectionID),
+	} {
+		_, err := c.store.Get(key)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			continue
 		}
 
-		// create map between DIDs and ConnectionID
-		if err := c.store.Put(getDIDConnMapKeyPrefix()(record.MyDID, record.TheirDID),
-			[]byte(record.ConnectionID)); err != nil {
-			return fmt.Errorf("save did and connection map in store: %w", err)
+		if err != nil {
+			return fmt.Errorf("get in-flight connection checkpoint: %w", err)
+		}
+
+		if err = c.store.Delete(key); err != nil {
+			return fmt.Errorf("delete in-flight connection checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RestoreInFlightConnections restores the in-flight connections checkpointed in the permanent store (i.e. the
+// exchanges which were neither completed nor abandoned) into the protocol state store, along with their namespaced
+// thread ID mappings and event data, so that the exchanges interrupted by a restart can be resumed.
+// The connections already in the protocol state store are left as they are.
+func (c *Recorder) RestoreInFlightConnections() error {
+	itr, err := c.store.Query(inFlightKeyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to query permanent store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	more, err := itr.Next()
+	if err != nil {
+		return fmt.Errorf("failed to get next set of data from iterator: %w", err)
+	}
+
+	for more {
+		var record Record
+
+		if err = getValueAndUnmarshal(itr, &record); err != nil {
+			return err
+		}
+
+		if err = c.restoreConnection(&record); err != nil {
+			return fmt.Errorf("restore in-flight connection: connectionid=%s err=%w", record.ConnectionID, err)
+		}
+
+		more, err = itr.Next()
+		if err != nil {
+			return fmt.Errorf("failed to get next set of data from iterator: %w", err)
 		}
 	}
 
 	return nil
 }
 
+func (c *Recorder) restoreConnection(record *Record) error {
+	_, err := c.protocolStateStore.Get(getConnectionKeyPrefix()(record.ConnectionID))
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, storage.ErrDataNotFound) {
+		return fmt.Errorf("get connection record from protocol state store: %w", err)
+	}
+
+	err = c.saveProtocolState(record, storage.Tag{
+		Name:  getConnectionKeyPrefix()(""),
+		Value: getConnectionKeyPrefix()(record.ConnectionID),
+	})
+	if err != nil {
+		return err
+	}
+
+	if isValidConnection(record) == nil {
+		if err = c.SaveNamespaceThreadID(record.ThreadID, record.Namespace, record.ConnectionID); err != nil {
+			return fmt.Errorf("save namespaced thread ID mapping: %w", err)
+		}
+	}
+
+	event, err := c.store.Get(getInFlightEventKeyPrefix()(record.ConnectionID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get in-flight connection event data: %w", err)
+	}
+
+	if err = c.protocolStateStore.Put(getEventDataKeyPrefix()(record.ConnectionID), event); err != nil {
+		return fmt.Errorf("save event data in protocol state store: %w", err)
+	}
+
+	return nil
+}
+
 // SaveConnectionRecordWithMappings saves newly created connection record against the connection id in the store
 // and it creates mapping from namespaced ThreadID to connection ID.
 func (c *Recorder) SaveConnectionRecordWithMappings(record *Record) error {
@@ -118,10 +294,39 @@ func (c *Recorder) SaveConnectionRecordWithMappings(record *Record) error {
 	return nil
 }
 
-// SaveEvent saves event related data for given connection ID
-// TODO connection event data shouldn't be transient [Issues #1029].
+// SaveConnectionMetadata saves application-specific metadata (e.g. a user account ID or labels) of the connection
+// with the given ID, replacing the metadata saved before. The metadata is kept apart from the connection record,
+// it is not affected by the connection state transitions.
+func (c *Recorder) SaveConnectionMetadata(connectionID string, meta map[string]interface{}) error {
+	if connectionID == "" {
+		return fmt.Errorf(errMsgInvalidKey)
+	}
+
+	if _, err := c.GetConnectionRecord(connectionID); err != nil {
+		return fmt.Errorf("unable to get connection record: connectionid=%s err=%w", connectionID, err)
+	}
+
+	return marshalAndSave(getConnectionMetadataKeyPrefix()(connectionID), meta, c.store)
+}
+
+// SaveEvent saves event related data for given connection ID, the data is checkpointed in the permanent store
+// along with the in-flight connection record. Nothing is written to the permanent store for the connections which
+// are not checkpointed, i.e. transient connections and ended exchanges.
 func (c *Recorder) SaveEvent(connectionID string, data []byte) error {
-	return c.protocolStateStore.Put(getEventDataKeyPrefix()(connectionID), data)
+	if err := c.protocolStateStore.Put(getEventDataKeyPrefix()(connectionID), data); err != nil {
+		return err
+	}
+
+	_, err := c.store.Get(getInFlightKeyPrefix()(connectionID))
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("get in-flight connection checkpoint: %w", err)
+	}
+
+	return c.store.Put(getInFlightEventKeyPrefix()(connectionID), data)
 }
 
 // SaveNamespaceThreadID saves given namespace, threadID and connection ID mapping in protocol state store.
@@ -172,12 +377,130 @@ func (c *Recorder) RemoveConnection(connectionID string) error {
 			connectionID, err)
 	}
 
+	if record.Transient {
+		err = c.protocolStateStore.Delete(getDIDConnMapKeyPrefix()(record.MyDID, record.TheirDID))
+		if err != nil {
+			return fmt.Errorf("unable to delete did mapping connection record from the protocol state store: "+
+				"connectionid=%s err=%w", connectionID, err)
+		}
+	}
+
+	if err = c.removeCheckpoint(connectionID); err != nil {
+		return fmt.Errorf("unable to delete in-flight connection checkpoint from the store: connectionid=%s err=%w",
+			connectionID, err)
+	}
+
 	// remove namespace, threadID and connection ID mapping from protocol state store
 	err = removeMappings(c, record)
 	if err != nil {
 		return fmt.Errorf("unable to delete connection record with namespace mappings: %w", err)
 	}
 
+	err = c.store.Delete(getConnectionMetadataKeyPrefix()(connectionID))
+	if err != nil {
+		return fmt.Errorf("unable to delete connection metadata from the store: connectionid=%s err=%w",
+			connectionID, err)
+	}
+
+	return nil
+}
+
+// RemoveDIDMapping removes the mapping of the given DIDs to their connection, e.g. once a party of the connection
+// has rotated away from one of them: the connection is not looked up by these DIDs anymore.
+func (c *Recorder) RemoveDIDMapping(myDID, theirDID string) error {
+	err := c.store.Delete(getDIDConnMapKeyPrefix()(myDID, theirDID))
+	if err != nil {
+		return fmt.Errorf("unable to delete did mapping from the store: %w", err)
+	}
+
+	// the DIDs of transient connections are mapped in the protocol state store
+	err = c.protocolStateStore.Delete(getDIDConnMapKeyPrefix()(myDID, theirDID))
+	if err != nil {
+		return fmt.Errorf("unable to delete did mapping from the protocol state store: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveExpiredTransientConnections removes the transient connections whose time to live has elapsed.
+func (c *Recorder) RemoveExpiredTransientConnections() error {
+	expired, err := c.expiredTransientConnections()
+	if err != nil {
+		return err
+	}
+
+	for _, connectionID := range expired {
+		if err = c.RemoveConnection(connectionID); err != nil {
+			return fmt.Errorf("remove expired transient connection: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Recorder) expiredTransientConnections() ([]string, error) {
+	itr, err := c.protocolStateStore.Query(transientTagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query protocol state store: %w", err)
+	}
+
+	defer storage.Close(itr, logger)
+
+	now := time.Now().UnixNano()
+
+	var expired []string
+
+	more, err := itr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next set of data from iterator: %w", err)
+	}
+
+	for more {
+		tags, err := itr.Tags()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tags from iterator: %w", err)
+		}
+
+		for _, tag := range tags {
+			if tag.Name != transientTagName {
+				continue
+			}
+
+			expiry, err := strconv.ParseInt(tag.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid transient connection expiry: %w", err)
+			}
+
+			if expiry <= now {
+				var record Record
+
+				if err = getValueAndUnmarshal(itr, &record); err != nil {
+					return nil, err
+				}
+
+				expired = append(expired, record.ConnectionID)
+			}
+		}
+
+		more, err = itr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next set of data from iterator: %w", err)
+		}
+	}
+
+	return expired, nil
+}
+
+func getValueAndUnmarshal(itr storage.Iterator, target interface{}) error {
+	value, err := itr.Value()
+	if err != nil {
+		return fmt.Errorf("failed to get value from iterator: %w", err)
+	}
+
+	if err = json.Unmarshal(value, target); err != nil {
+		return fmt.Errorf("failed to unmarshal connection record: %w", err)
+	}
+
 	return nil
 }
 
@@ -10,8 +10,11 @@
 package connection
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -600,6 +603,422 @@ func TestConnectionRecorder_RemoveConnection(t *testing.T) {
 	})
 }
 
+func TestConnectionRecorder_RemoveDIDMapping(t *testing.T) {
+	t.Run("the connection is not looked up by the DIDs anymore", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		for _, transient := range []bool{false, true} {
+			record := &Record{
+				ThreadID:     threadIDValue,
+				ConnectionID: uuid.New().String(),
+				State:        StateNameCompleted,
+				Namespace:    TheirNSPrefix,
+				MyDID:        "did:mydid:123",
+				TheirDID:     "did:theirdid:" + uuid.New().String(),
+				Transient:    transient,
+			}
+			require.NoError(t, recorder.SaveConnectionRecord(record))
+
+			connID, err := recorder.GetConnectionIDByDIDs(record.MyDID, record.TheirDID)
+			require.NoError(t, err)
+			require.Equal(t, record.ConnectionID, connID)
+
+			require.NoError(t, recorder.RemoveDIDMapping(record.MyDID, record.TheirDID))
+
+			_, err = recorder.GetConnectionIDByDIDs(record.MyDID, record.TheirDID)
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+			// the connection record is kept
+			_, err = recorder.GetConnectionRecord(record.ConnectionID)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("store errors", func(t *testing.T) {
+		expected := errors.New("delete error")
+
+		recorder, err := NewRecorder(&mockProvider{
+			store: &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry), ErrDelete: expected},
+		})
+		require.NoError(t, err)
+
+		err = recorder.RemoveDIDMapping("did:mydid:123", "did:theirdid:123")
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "from the store")
+
+		recorder, err = NewRecorder(&mockProvider{
+			protocolStateStore: &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry), ErrDelete: expected},
+		})
+		require.NoError(t, err)
+
+		err = recorder.RemoveDIDMapping("did:mydid:123", "did:theirdid:123")
+		require.True(t, errors.Is(err, expected))
+		require.Contains(t, err.Error(), "from the protocol state store")
+	})
+}
+
+func TestConnectionRecorder_TransientConnection(t *testing.T) {
+	newRecord := func() *Record {
+		return &Record{
+			ThreadID:     threadIDValue,
+			ConnectionID: uuid.New().String(),
+			State:        StateNameCompleted,
+			Namespace:    TheirNSPrefix,
+			MyDID:        "did:mydid:" + uuid.New().String(),
+			TheirDID:     "did:theirdid:" + uuid.New().String(),
+			Transient:    true,
+		}
+	}
+
+	t.Run("transient connection is not saved in the permanent store", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		record := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		_, err = recorder.store.Get(getConnectionKeyPrefix()(record.ConnectionID))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = recorder.store.Get(getDIDConnMapKeyPrefix()(record.MyDID, record.TheirDID))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		recordFound, err := recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, record, recordFound)
+
+		connID, err := recorder.GetConnectionIDByDIDs(record.MyDID, record.TheirDID)
+		require.NoError(t, err)
+		require.Equal(t, record.ConnectionID, connID)
+
+		records, err := recorder.QueryConnectionRecordsWithOpts(WithTheirDID(record.TheirDID))
+		require.NoError(t, err)
+		require.Equal(t, []*Record{record}, records)
+
+		require.NoError(t, recorder.RemoveExpiredTransientConnections())
+
+		_, err = recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+	})
+
+	t.Run("expired transient connections are garbage-collected", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{}, WithTransientTTL(time.Millisecond))
+		require.NoError(t, err)
+
+		expired := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecord(expired))
+
+		time.Sleep(2 * time.Millisecond)
+
+		require.NoError(t, recorder.RemoveExpiredTransientConnections())
+
+		_, err = recorder.GetConnectionRecord(expired.ConnectionID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = recorder.GetConnectionIDByDIDs(expired.MyDID, expired.TheirDID)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = recorder.GetConnectionRecordAtState(expired.ConnectionID, StateNameCompleted)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		// saving another transient connection removes the expired ones too
+		require.NoError(t, recorder.SaveConnectionRecord(newRecord()))
+		time.Sleep(2 * time.Millisecond)
+
+		active := newRecord()
+		require.NoError(t, (&Recorder{Lookup: recorder.Lookup, transientTTL: time.Hour}).SaveConnectionRecord(active))
+
+		records, err := recorder.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Equal(t, []*Record{active}, records)
+	})
+
+	t.Run("permanent connections are not garbage-collected", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{}, WithTransientTTL(0))
+		require.NoError(t, err)
+
+		record := newRecord()
+		record.Transient = false
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+		require.NoError(t, recorder.SaveConnectionRecord(newRecord()))
+		require.NoError(t, recorder.RemoveExpiredTransientConnections())
+
+		records, err := recorder.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Equal(t, []*Record{record}, records)
+	})
+
+	t.Run("remove expired transient connections - query error", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{protocolStateStore: &mockstorage.MockStore{
+			Store:    make(map[string]mockstorage.DBEntry),
+			ErrQuery: errors.New(sampleErrMsg),
+		}})
+		require.NoError(t, err)
+
+		err = recorder.SaveConnectionRecord(newRecord())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+}
+
+func TestConnectionRecorder_RestoreInFlightConnections(t *testing.T) {
+	newRecord := func() *Record {
+		return &Record{
+			ThreadID:     uuid.New().String(),
+			ConnectionID: uuid.New().String(),
+			State:        "requested",
+			Namespace:    MyNSPrefix,
+			MyDID:        "did:mydid:123",
+			TheirDID:     "did:theirdid:123",
+		}
+	}
+
+	// restart keeps the permanent store and loses the protocol state store
+	restart := func(store storage.Store) *Recorder {
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		require.NoError(t, recorder.RestoreInFlightConnections())
+
+		return recorder
+	}
+
+	t.Run("in-flight connection is restored", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		record := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(record))
+		require.NoError(t, recorder.SaveEvent(record.ConnectionID, []byte("event")))
+
+		recorder = restart(store)
+
+		nsThID, err := CreateNamespaceKey(MyNSPrefix, record.ThreadID)
+		require.NoError(t, err)
+
+		recordFound, err := recorder.GetConnectionRecordByNSThreadID(nsThID)
+		require.NoError(t, err)
+		require.Equal(t, record, recordFound)
+
+		recordFound, err = recorder.GetConnectionRecordAtState(record.ConnectionID, record.State)
+		require.NoError(t, err)
+		require.Equal(t, record, recordFound)
+
+		event, err := recorder.GetEvent(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, []byte("event"), event)
+
+		// the exchange is resumed up to its completion
+		record.State = StateNameCompleted
+		require.NoError(t, recorder.SaveConnectionRecord(record))
+
+		_, err = store.Get(getInFlightKeyPrefix()(record.ConnectionID))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		_, err = store.Get(getInFlightEventKeyPrefix()(record.ConnectionID))
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		recorder = restart(store)
+
+		_, err = recorder.GetConnectionRecordByNSThreadID(nsThID)
+		require.Error(t, err)
+
+		recordFound, err = recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, record, recordFound)
+	})
+
+	t.Run("abandoned and removed connections are not restored", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		abandoned := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(abandoned))
+
+		abandoned.State = "abandoned"
+		require.NoError(t, recorder.SaveConnectionRecord(abandoned))
+
+		removed := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(removed))
+		require.NoError(t, recorder.SaveEvent(removed.ConnectionID, []byte("event")))
+		require.NoError(t, recorder.RemoveConnection(removed.ConnectionID))
+
+		recorder = restart(store)
+
+		records, err := recorder.QueryConnectionRecords()
+		require.NoError(t, err)
+		require.Empty(t, records)
+	})
+
+	t.Run("events of transient and ended connections are not checkpointed", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		transient := newRecord()
+		transient.Transient = true
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(transient))
+		require.NoError(t, recorder.SaveEvent(transient.ConnectionID, []byte("event")))
+
+		completed := newRecord()
+		completed.State = StateNameCompleted
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(completed))
+		require.NoError(t, recorder.SaveEvent(completed.ConnectionID, []byte("event")))
+
+		unknownConnID := uuid.New().String()
+		require.NoError(t, recorder.SaveEvent(unknownConnID, []byte("event")))
+
+		for _, connID := range []string{transient.ConnectionID, completed.ConnectionID, unknownConnID} {
+			event, err := recorder.GetEvent(connID)
+			require.NoError(t, err)
+			require.Equal(t, []byte("event"), event)
+
+			_, err = store.Get(getInFlightEventKeyPrefix()(connID))
+			require.True(t, errors.Is(err, storage.ErrDataNotFound))
+		}
+	})
+
+	t.Run("save event error", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		store.ErrGet = errors.New(sampleErrMsg)
+
+		err = recorder.SaveEvent(uuid.New().String(), []byte("event"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+
+	t.Run("connection in the protocol state store is left as it is", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+		protocolStateStore := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store, protocolStateStore: protocolStateStore})
+		require.NoError(t, err)
+
+		record := newRecord()
+		require.NoError(t, recorder.SaveConnectionRecordWithMappings(record))
+
+		recordBytes, err := json.Marshal(&Record{ConnectionID: record.ConnectionID, State: "responded"})
+		require.NoError(t, err)
+
+		protocolStateStore.Store[getConnectionKeyPrefix()(record.ConnectionID)] = mockstorage.DBEntry{
+			Value: recordBytes,
+		}
+
+		require.NoError(t, recorder.RestoreInFlightConnections())
+
+		recordFound, err := recorder.GetConnectionRecord(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, "responded", recordFound.State)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{store: &mockstorage.MockStore{
+			Store:    make(map[string]mockstorage.DBEntry),
+			ErrQuery: errors.New(sampleErrMsg),
+		}})
+		require.NoError(t, err)
+
+		err = recorder.RestoreInFlightConnections()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+
+	t.Run("protocol state store error", func(t *testing.T) {
+		store := &mockstorage.MockStore{Store: make(map[string]mockstorage.DBEntry)}
+
+		recorder, err := NewRecorder(&mockProvider{store: store})
+		require.NoError(t, err)
+
+		require.NoError(t, recorder.SaveConnectionRecord(newRecord()))
+
+		recorder, err = NewRecorder(&mockProvider{store: store, protocolStateStore: &mockstorage.MockStore{
+			Store:  make(map[string]mockstorage.DBEntry),
+			ErrPut: errors.New(sampleErrMsg),
+		}})
+		require.NoError(t, err)
+
+		err = recorder.RestoreInFlightConnections()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), sampleErrMsg)
+	})
+}
+
+func TestConnectionRecorder_ConnectionMetadata(t *testing.T) {
+	t.Run("save and get connection metadata after a state change - success", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		record := &Record{
+			ThreadID:     threadIDValue,
+			ConnectionID: uuid.New().String(),
+			State:        stateNameInvited,
+			Namespace:    TheirNSPrefix,
+		}
+		err = recorder.SaveConnectionRecord(record)
+		require.NoError(t, err)
+
+		meta := map[string]interface{}{
+			"accountID": "account-1",
+			"labels":    []interface{}{"verifier", "kiosk"},
+		}
+		err = recorder.SaveConnectionMetadata(record.ConnectionID, meta)
+		require.NoError(t, err)
+
+		record.State = StateNameCompleted
+		record.MyDID = "did:mydid:123"
+		record.TheirDID = "did:theirdid:123"
+		err = recorder.SaveConnectionRecord(record)
+		require.NoError(t, err)
+
+		metaFound, err := recorder.GetConnectionMetadata(record.ConnectionID)
+		require.NoError(t, err)
+		require.Equal(t, meta, metaFound)
+
+		err = recorder.RemoveConnection(record.ConnectionID)
+		require.NoError(t, err)
+
+		_, err = recorder.GetConnectionMetadata(record.ConnectionID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("get connection metadata - not found scenario", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		_, err = recorder.GetConnectionMetadata(sampleConnID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("save connection metadata - unknown connection scenario", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		err = recorder.SaveConnectionMetadata(sampleConnID, map[string]interface{}{"accountID": "account-1"})
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+
+	t.Run("save and get connection metadata - invalid key scenario", func(t *testing.T) {
+		recorder, err := NewRecorder(&mockProvider{})
+		require.NoError(t, err)
+
+		err = recorder.SaveConnectionMetadata("", map[string]interface{}{})
+		require.EqualError(t, err, errMsgInvalidKey)
+
+		_, err = recorder.GetConnectionMetadata("")
+		require.EqualError(t, err, errMsgInvalidKey)
+	})
+}
+
 func TestConnectionRecorder_ConnectionRecordMappings(t *testing.T) {
 	t.Run("get connection record by namespace threadID in my namespace", func(t *testing.T) {
 		recorder, err := NewRecorder(&mockProvider{})
@@ -17,4 +17,6 @@ type Record struct {
 	// of issuing a credential or presentation.
 	MyDID    string `json:"my_did,omitempty"`
 	TheirDID string `json:"their_did,omitempty"`
+	// Revoked is set once the issuer notified the holder that the credential was revoked.
+	Revoked bool `json:"revoked,omitempty"`
 }
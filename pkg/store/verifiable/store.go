@@ -67,6 +67,12 @@ type Store interface {
 	RemovePresentationByName(name string) error
 }
 
+// RevocationStore is implemented by the stores which can flag a stored credential as revoked (e.g. once the issuer
+// notified the holder of the revocation).
+type RevocationStore interface {
+	MarkCredentialRevoked(id string) error
+}
+
 // StoreImplementation stores vc.
 type StoreImplementation struct {
 	store          storage.Store
@@ -317,6 +323,45 @@ func (s *StoreImplementation) RemovePresentationByName(name string) error {
 	return nil
 }
 
+// MarkCredentialRevoked flags the records of the verifiable credential with the given ID as revoked.
+func (s *StoreImplementation) MarkCredentialRevoked(id string) error {
+	if id == "" {
+		return errors.New("credential id is mandatory")
+	}
+
+	records, err := s.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("get credential records: %w", err)
+	}
+
+	found := false
+
+	for _, r := range records {
+		if r.ID != id {
+			continue
+		}
+
+		found = true
+		r.Revoked = true
+
+		recordBytes, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("failed to marshal record: %w", err)
+		}
+
+		err = s.store.Put(credentialNameDataKey(r.Name), recordBytes, storage.Tag{Name: credentialNameKey})
+		if err != nil {
+			return fmt.Errorf("failed to put record: %w", err)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("credential %s: %w", id, storage.ErrDataNotFound)
+	}
+
+	return nil
+}
+
 func (s *StoreImplementation) remove(id, recordKey string) error {
 	err := s.store.Delete(id)
 	if err != nil {
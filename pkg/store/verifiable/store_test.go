@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/internal/jsonldtest"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockstore "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
 const (
@@ -483,6 +484,64 @@ func TestGetCredentials(t *testing.T) {
 	})
 }
 
+func TestMarkCredentialRevoked(t *testing.T) {
+	t.Run("test mark credential revoked - success", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: sampleCredentialID}))
+		require.NoError(t, s.SaveCredential(sampleCredentialName+"1", &verifiable.Credential{ID: sampleCredentialID + "1"}))
+
+		require.NoError(t, s.MarkCredentialRevoked(sampleCredentialID))
+
+		records, err := s.GetCredentials()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		for _, r := range records {
+			require.Equal(t, r.ID == sampleCredentialID, r.Revoked)
+		}
+
+		id, err := s.GetCredentialIDByName(sampleCredentialName)
+		require.NoError(t, err)
+		require.Equal(t, sampleCredentialID, id)
+	})
+	t.Run("test mark credential revoked - empty id", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		require.EqualError(t, s.MarkCredentialRevoked(""), "credential id is mandatory")
+	})
+	t.Run("test mark credential revoked - credential not found", func(t *testing.T) {
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewMockStoreProvider(),
+		})
+		require.NoError(t, err)
+
+		err = s.MarkCredentialRevoked(sampleCredentialID)
+		require.ErrorIs(t, err, storage.ErrDataNotFound)
+	})
+	t.Run("test mark credential revoked - error from store put", func(t *testing.T) {
+		store := &mockstore.MockStore{Store: make(map[string]mockstore.DBEntry)}
+		s, err := New(&mockprovider.Provider{
+			StorageProviderValue: mockstore.NewCustomMockStoreProvider(store),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, s.SaveCredential(sampleCredentialName, &verifiable.Credential{ID: sampleCredentialID}))
+
+		store.ErrPut = fmt.Errorf("error put")
+
+		err = s.MarkCredentialRevoked(sampleCredentialID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to put record")
+	})
+}
+
 func TestSaveVP(t *testing.T) {
 	t.Run("test save vp - success", func(t *testing.T) {
 		s, err := New(&mockprovider.Provider{
@@ -0,0 +1,269 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package encrypted offers a storage.Provider wrapper that encrypts the data stored in the wrapped provider.
+//
+// Values are encrypted with an AEAD key managed by the KMS. Keys, tag names and tag values are replaced by their MAC
+// so that they can still be looked up and queried without being readable in the wrapped provider. The MAC of the key
+// is the additional data of the value encryption, so a value copied under another key in the wrapped provider fails
+// to decrypt.
+package encrypted
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/formattedstore"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// NewProvider wraps provider in a storage.Provider that encrypts values with aeadKH (e.g. a kms.AES256GCMType key
+// handle) and replaces keys and tags with their MAC computed with macKH (e.g. a kms.HMACSHA256Tag256Type key handle).
+// Both key handles must come from a KMS compatible with c, such as localkms with tinkcrypto.
+func NewProvider(provider storage.Provider, c crypto.Crypto, aeadKH, macKH interface{}) storage.Provider {
+	return formattedstore.NewProvider(&keyedProvider{Provider: provider}, NewFormatter(c, aeadKH, macKH))
+}
+
+// Formatter is a formattedstore.Formatter that encrypts values and MACs keys and tags.
+// Its Deformat method expects the values as read from the wrapped provider by NewProvider, i.e. along with the key
+// they are stored under.
+type Formatter struct {
+	crypto crypto.Crypto
+	aeadKH interface{}
+	macKH  interface{}
+}
+
+// NewFormatter returns a new Formatter using c with aeadKH to encrypt values and with macKH to MAC keys and tags.
+func NewFormatter(c crypto.Crypto, aeadKH, macKH interface{}) *Formatter {
+	return &Formatter{
+		crypto: c,
+		aeadKH: aeadKH,
+		macKH:  macKH,
+	}
+}
+
+// encryptedValue is the value stored in the wrapped provider.
+type encryptedValue struct {
+	Ciphertext []byte `json:"ciphertext"`
+	Nonce      []byte `json:"nonce"`
+}
+
+// storedValue is a value read from the wrapped provider along with the (formatted) key it is stored under, as
+// passed to Deformat.
+type storedValue struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// content is the encrypted content of an encryptedValue. Key and tags are kept alongside the value since they can't
+// be recovered from their MAC.
+type content struct {
+	Key   string        `json:"key"`
+	Value []byte        `json:"value"`
+	Tags  []storage.Tag `json:"tags,omitempty"`
+}
+
+// Format returns the MAC of key and tags, and value encrypted along with key and tags, with the MAC of key as
+// additional data. An empty key, tag value or a nil value is returned as is.
+func (f *Formatter) Format(key string, value []byte, tags ...storage.Tag) (string, []byte, []storage.Tag, error) {
+	formattedKey, err := f.mac(key)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to compute key MAC: %w", err)
+	}
+
+	formattedTags, err := f.formatTags(tags)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if value == nil {
+		return formattedKey, nil, formattedTags, nil
+	}
+
+	formattedValue, err := f.encrypt(&content{Key: key, Value: value, Tags: tags}, formattedKey)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return formattedKey, formattedValue, formattedTags, nil
+}
+
+// Deformat decrypts the stored value in formattedValue, checking that it is stored under the key it was encrypted
+// for, and returns the key, value and tags it holds. formattedKey, if set, must be the key of the stored value.
+func (f *Formatter) Deformat(formattedKey string, formattedValue []byte, _ ...storage.Tag) (string, []byte,
+	[]storage.Tag, error) {
+	if formattedValue == nil {
+		return "", nil, nil, fmt.Errorf("encrypted formatter requires the formatted value to deformat data")
+	}
+
+	var sv storedValue
+
+	err := json.Unmarshal(formattedValue, &sv)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to unmarshal stored value: %w", err)
+	}
+
+	if formattedKey != "" && formattedKey != sv.Key {
+		return "", nil, nil, errors.New("formatted value is not stored under the formatted key")
+	}
+
+	var ev encryptedValue
+
+	err = json.Unmarshal(sv.Value, &ev)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to unmarshal encrypted value: %w", err)
+	}
+
+	// the key MAC is the additional data: decryption fails if the value was moved under another key.
+	plaintext, err := f.crypto.Decrypt(ev.Ciphertext, []byte(sv.Key), ev.Nonce, f.aeadKH)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	var c content
+
+	err = json.Unmarshal(plaintext, &c)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to unmarshal decrypted value: %w", err)
+	}
+
+	return c.Key, c.Value, c.Tags, nil
+}
+
+// UsesDeterministicKeyFormatting returns true since the formatted key is the MAC of the key.
+func (f *Formatter) UsesDeterministicKeyFormatting() bool {
+	return true
+}
+
+func (f *Formatter) formatTags(tags []storage.Tag) ([]storage.Tag, error) {
+	formattedTags := make([]storage.Tag, len(tags))
+
+	for i, tag := range tags {
+		name, err := f.mac(tag.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute tag name MAC: %w", err)
+		}
+
+		value, err := f.mac(tag.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute tag value MAC: %w", err)
+		}
+
+		formattedTags[i] = storage.Tag{Name: name, Value: value}
+	}
+
+	return formattedTags, nil
+}
+
+func (f *Formatter) encrypt(c *content, formattedKey string) ([]byte, error) {
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	ciphertext, nonce, err := f.crypto.Encrypt(plaintext, []byte(formattedKey), f.aeadKH)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt value: %w", err)
+	}
+
+	return json.Marshal(&encryptedValue{Ciphertext: ciphertext, Nonce: nonce})
+}
+
+// mac returns the base64url encoded MAC of data, it contains no ':' so it can be used in query expressions.
+func (f *Formatter) mac(data string) (string, error) {
+	if data == "" {
+		return "", nil
+	}
+
+	m, err := f.crypto.ComputeMAC([]byte(data), f.macKH)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(m), nil
+}
+
+// keyedProvider wraps the stores of the wrapped provider so that the values they return hold the key they are
+// stored under, which formattedstore does not pass to Deformat when getting values.
+type keyedProvider struct {
+	storage.Provider
+}
+
+func (p *keyedProvider) OpenStore(name string) (storage.Store, error) {
+	store, err := p.Provider.OpenStore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyedStore{Store: store}, nil
+}
+
+type keyedStore struct {
+	storage.Store
+}
+
+func (s *keyedStore) Get(key string) ([]byte, error) {
+	value, err := s.Store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return withKey(key, value)
+}
+
+func (s *keyedStore) GetBulk(keys ...string) ([][]byte, error) {
+	values, err := s.Store.GetBulk(keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+
+		values[i], err = withKey(keys[i], value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func (s *keyedStore) Query(expression string, options ...storage.QueryOption) (storage.Iterator, error) {
+	iterator, err := s.Store.Query(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyedIterator{Iterator: iterator}, nil
+}
+
+type keyedIterator struct {
+	storage.Iterator
+}
+
+func (i *keyedIterator) Value() ([]byte, error) {
+	key, err := i.Iterator.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := i.Iterator.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	return withKey(key, value)
+}
+
+func withKey(key string, value []byte) ([]byte, error) {
+	return json.Marshal(&storedValue{Key: key, Value: value})
+}
@@ -0,0 +1,241 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package encrypted
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockcrypto "github.com/hyperledger/aries-framework-go/pkg/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	testStoreName = "credentials"
+	testKey       = "did:example:credential"
+	testValue     = `{"credentialSubject":{"name":"Alice Doe"}}`
+)
+
+func TestProvider(t *testing.T) {
+	underlyingProvider := mem.NewProvider()
+
+	c, aeadKH, macKH := newTestCrypto(t)
+
+	provider := NewProvider(underlyingProvider, c, aeadKH, macKH)
+
+	store, err := provider.OpenStore(testStoreName)
+	require.NoError(t, err)
+
+	err = provider.SetStoreConfig(testStoreName, storage.StoreConfiguration{TagNames: []string{"type"}})
+	require.NoError(t, err)
+
+	err = store.Put(testKey, []byte(testValue), storage.Tag{Name: "type", Value: "UniversityDegree"})
+	require.NoError(t, err)
+
+	t.Run("get and query decrypted data", func(t *testing.T) {
+		value, err := store.Get(testKey)
+		require.NoError(t, err)
+		require.Equal(t, testValue, string(value))
+
+		tags, err := store.GetTags(testKey)
+		require.NoError(t, err)
+		require.Equal(t, []storage.Tag{{Name: "type", Value: "UniversityDegree"}}, tags)
+
+		itr, err := store.Query("type:UniversityDegree")
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.Equal(t, testKey, key)
+
+		value, err = itr.Value()
+		require.NoError(t, err)
+		require.Equal(t, testValue, string(value))
+
+		more, err = itr.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+	})
+
+	t.Run("underlying store holds no plaintext", func(t *testing.T) {
+		underlyingStore, err := underlyingProvider.OpenStore(testStoreName)
+		require.NoError(t, err)
+
+		_, err = underlyingStore.Get(testKey)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+
+		itr, err := underlyingStore.Query("type:UniversityDegree")
+		require.NoError(t, err)
+
+		more, err := itr.Next()
+		require.NoError(t, err)
+		require.False(t, more)
+
+		config, err := underlyingProvider.GetStoreConfig(testStoreName)
+		require.NoError(t, err)
+		require.Len(t, config.TagNames, 1)
+		require.NotEqual(t, "type", config.TagNames[0])
+
+		itr, err = underlyingStore.Query(config.TagNames[0])
+		require.NoError(t, err)
+
+		more, err = itr.Next()
+		require.NoError(t, err)
+		require.True(t, more)
+
+		key, err := itr.Key()
+		require.NoError(t, err)
+		require.NotContains(t, key, "credential")
+
+		value, err := itr.Value()
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(value, []byte("Alice")))
+		require.False(t, bytes.Contains(value, []byte(testKey)))
+
+		tags, err := itr.Tags()
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		require.NotEqual(t, "UniversityDegree", tags[0].Value)
+	})
+
+	t.Run("value moved under another key", func(t *testing.T) {
+		const otherKey = "did:example:other"
+
+		require.NoError(t, store.Put(otherKey, []byte(`{}`)))
+
+		underlyingStore, err := underlyingProvider.OpenStore(testStoreName)
+		require.NoError(t, err)
+
+		formatter := NewFormatter(c, aeadKH, macKH)
+
+		formattedKey, _, _, err := formatter.Format(testKey, nil)
+		require.NoError(t, err)
+
+		formattedOtherKey, _, _, err := formatter.Format(otherKey, nil)
+		require.NoError(t, err)
+
+		value, err := underlyingStore.Get(formattedKey)
+		require.NoError(t, err)
+
+		require.NoError(t, underlyingStore.Put(formattedOtherKey, value))
+
+		_, err = store.Get(otherKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt value")
+
+		values, err := store.GetBulk(testKey, otherKey)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to decrypt value")
+		require.Nil(t, values)
+
+		require.NoError(t, store.Delete(otherKey))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(testKey))
+
+		_, err := store.Get(testKey)
+		require.True(t, errors.Is(err, storage.ErrDataNotFound))
+	})
+}
+
+func TestFormatter(t *testing.T) {
+	t.Run("blank key, tag values and nil value are kept as is", func(t *testing.T) {
+		c, aeadKH, macKH := newTestCrypto(t)
+
+		key, value, tags, err := NewFormatter(c, aeadKH, macKH).Format("", nil, storage.Tag{Name: "type"})
+		require.NoError(t, err)
+		require.Empty(t, key)
+		require.Nil(t, value)
+		require.Len(t, tags, 1)
+		require.NotEmpty(t, tags[0].Name)
+		require.Empty(t, tags[0].Value)
+	})
+
+	t.Run("MAC errors", func(t *testing.T) {
+		f := NewFormatter(&mockcrypto.Crypto{ComputeMACErr: errors.New("mac error")}, nil, nil)
+
+		_, _, _, err := f.Format(testKey, []byte(testValue))
+		require.EqualError(t, err, "failed to compute key MAC: mac error")
+
+		_, _, _, err = f.Format("", nil, storage.Tag{Name: "type"})
+		require.EqualError(t, err, "failed to compute tag name MAC: mac error")
+	})
+
+	t.Run("encrypt error", func(t *testing.T) {
+		f := NewFormatter(&mockcrypto.Crypto{EncryptErr: errors.New("encrypt error")}, nil, nil)
+
+		_, _, _, err := f.Format("", []byte(testValue))
+		require.EqualError(t, err, "failed to encrypt value: encrypt error")
+	})
+
+	t.Run("deformat errors", func(t *testing.T) {
+		f := NewFormatter(&mockcrypto.Crypto{DecryptErr: errors.New("decrypt error")}, nil, nil)
+
+		_, _, _, err := f.Deformat("", nil)
+		require.EqualError(t, err, "encrypted formatter requires the formatted value to deformat data")
+
+		_, _, _, err = f.Deformat("", []byte("not JSON"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal stored value")
+
+		_, _, _, err = f.Deformat("other", []byte(`{"key":"key","value":""}`))
+		require.EqualError(t, err, "formatted value is not stored under the formatted key")
+
+		notJSON, err := withKey("key", []byte("not JSON"))
+		require.NoError(t, err)
+
+		_, _, _, err = f.Deformat("key", notJSON)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal encrypted value")
+
+		encrypted, err := withKey("key", []byte(`{"ciphertext":"","nonce":""}`))
+		require.NoError(t, err)
+
+		_, _, _, err = f.Deformat("", encrypted)
+		require.EqualError(t, err, "failed to decrypt value: decrypt error")
+
+		f = NewFormatter(&mockcrypto.Crypto{DecryptValue: []byte("not JSON")}, nil, nil)
+
+		_, _, _, err = f.Deformat("", encrypted)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal decrypted value")
+	})
+}
+
+func newTestCrypto(t *testing.T) (*tinkcrypto.Crypto, interface{}, interface{}) {
+	t.Helper()
+
+	k, err := localkms.New("local-lock://test/master/key/",
+		mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{}))
+	require.NoError(t, err)
+
+	_, aeadKH, err := k.Create(kms.AES256GCMType)
+	require.NoError(t, err)
+
+	_, macKH, err := k.Create(kms.HMACSHA256Tag256Type)
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	return c, aeadKH, macKH
+}
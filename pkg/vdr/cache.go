@@ -0,0 +1,179 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/hyperledger/aries-framework-go/pkg/common/log"
+	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+// cacheKeySeparator separates the did from the resolve options in cache keys, it is not allowed in dids.
+const cacheKeySeparator = " "
+
+var logger = log.New("aries-framework/vdr")
+
+// resolveCache is an LRU cache of did resolutions coalescing concurrent resolutions of the same did. Resolutions are
+// cached by did and resolve options, and stored serialized so that every caller gets its own copy.
+type resolveCache struct {
+	cache gcache.Cache
+	ttl   time.Duration
+
+	mutex    sync.Mutex
+	inflight map[string]*resolveCall
+}
+
+type resolveCall struct {
+	wg  sync.WaitGroup
+	res []byte
+	err error
+}
+
+func newResolveCache(size int, ttl time.Duration) *resolveCache {
+	return &resolveCache{
+		cache:    gcache.New(size).LRU().Build(),
+		ttl:      ttl,
+		inflight: make(map[string]*resolveCall),
+	}
+}
+
+// resolve returns a copy of the cached resolution of did with opts, or resolves it with resolveFn. Concurrent
+// resolutions of the same did with the same opts share a single resolveFn call. Failed resolutions are not cached,
+// and neither are resolutions with opts that cannot be serialized into a cache key or resolutions that cannot be
+// copied.
+func (c *resolveCache) resolve(did string, opts []vdrapi.DIDMethodOption,
+	resolveFn func() (*diddoc.DocResolution, error)) (*diddoc.DocResolution, error) {
+	key, err := cacheKey(did, opts)
+	if err != nil {
+		return resolveFn()
+	}
+
+	if cached, err := c.cache.Get(key); err == nil {
+		if b, ok := cached.([]byte); ok {
+			return copyResolution(b, resolveFn)
+		}
+	}
+
+	c.mutex.Lock()
+
+	if call, ok := c.inflight[key]; ok {
+		c.mutex.Unlock()
+		call.wg.Wait()
+
+		if call.err != nil {
+			return nil, call.err
+		}
+
+		return copyResolution(call.res, resolveFn)
+	}
+
+	call := &resolveCall{}
+	call.wg.Add(1)
+	c.inflight[key] = call
+
+	c.mutex.Unlock()
+
+	res, err := resolveFn()
+
+	call.err = err
+	if err == nil && res != nil && res.DIDDocument != nil {
+		if b, e := res.JSONBytes(); e == nil {
+			call.res = b
+			c.set(did, key, b)
+		}
+	}
+
+	c.mutex.Lock()
+	delete(c.inflight, key)
+	c.mutex.Unlock()
+
+	call.wg.Done()
+
+	return res, err
+}
+
+// copyResolution parses a copy of the serialized resolution b, falling back to resolveFn if there is none.
+func copyResolution(b []byte, resolveFn func() (*diddoc.DocResolution, error)) (*diddoc.DocResolution, error) {
+	if b != nil {
+		if res, err := diddoc.ParseDocumentResolution(b); err == nil {
+			return res, nil
+		}
+	}
+
+	return resolveFn()
+}
+
+// cacheKey returns the cache key of the resolution of did with opts.
+func cacheKey(did string, opts []vdrapi.DIDMethodOption) (string, error) {
+	if len(opts) == 0 {
+		return did, nil
+	}
+
+	didMethodOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
+
+	for _, opt := range opts {
+		opt(didMethodOpts)
+	}
+
+	b, err := json.Marshal(didMethodOpts.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return did + cacheKeySeparator + string(b), nil
+}
+
+func (c *resolveCache) set(did, key string, res []byte) {
+	method, err := GetDidMethod(did)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case isImmutableDIDMethod(method):
+		err = c.cache.Set(key, res)
+	case c.ttl > 0:
+		err = c.cache.SetWithExpire(key, res, c.ttl)
+	case method == peer.DIDMethod:
+		// peer dids can rotate, they are cached only if resolutions expire.
+		return
+	default:
+		err = c.cache.Set(key, res)
+	}
+
+	if err != nil {
+		logger.Warnf("failed to cache resolution of did %s: %s", did, err)
+	}
+}
+
+// isImmutableDIDMethod returns true for did methods whose documents never change, their resolutions are cached
+// without expiry.
+func isImmutableDIDMethod(method string) bool {
+	switch method {
+	case "key", "jwk":
+		return true
+	default:
+		return false
+	}
+}
+
+// invalidate removes the resolutions of did with any resolve options from the cache.
+func (c *resolveCache) invalidate(did string) {
+	for _, key := range c.cache.Keys(false) {
+		if k, ok := key.(string); ok && (k == did || strings.HasPrefix(k, did+cacheKeySeparator)) {
+			c.cache.Remove(k)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package vdr
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+)
+
+func TestRegistry_ResolveCache(t *testing.T) {
+	const (
+		webDID = "did:web:example.com"
+		keyDID = "did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH"
+	)
+
+	newRegistry := func(calls *int32, opts ...Option) *Registry {
+		return New(append(opts, WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				atomic.AddInt32(calls, 1)
+
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			},
+		}))...)
+	}
+
+	t.Run("test cache hit", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, time.Minute))
+
+		for i := 0; i < 3; i++ {
+			d, err := registry.Resolve(webDID)
+			require.NoError(t, err)
+			require.Equal(t, webDID, d.DIDDocument.ID)
+		}
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test no cache", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls)
+
+		for i := 0; i < 3; i++ {
+			_, err := registry.Resolve(webDID)
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+
+		registry.InvalidateCache(webDID)
+	})
+
+	t.Run("test ttl expiry", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 10*time.Millisecond))
+
+		_, err := registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		_, err = registry.Resolve(keyDID)
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, err = registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		// did:key documents are immutable, they do not expire.
+		_, err = registry.Resolve(keyDID)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test lru eviction", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(1, 0))
+
+		for _, didID := range []string{webDID, keyDID, webDID} {
+			_, err := registry.Resolve(didID)
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test invalidate cache", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		_, err := registry.Resolve(keyDID)
+		require.NoError(t, err)
+
+		registry.InvalidateCache(keyDID)
+
+		_, err = registry.Resolve(keyDID)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test update and deactivate invalidate cache", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		_, err := registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Update(&did.Doc{ID: webDID}))
+
+		_, err = registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		require.NoError(t, registry.Deactivate(webDID))
+
+		_, err = registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test cache key includes resolve options", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(webDID, vdrapi.WithOption("versionId", "1"))
+			require.NoError(t, err)
+
+			_, err = registry.Resolve(webDID, vdrapi.WithOption("versionId", "2"))
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+		registry.InvalidateCache(webDID)
+
+		_, err := registry.Resolve(webDID, vdrapi.WithOption("versionId", "1"))
+		require.NoError(t, err)
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test resolve options that cannot be a cache key are not cached", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(webDID, vdrapi.WithOption("callback", func() {}))
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test cached resolutions are copies", func(t *testing.T) {
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		d, err := registry.Resolve(webDID)
+		require.NoError(t, err)
+
+		d.DIDDocument.ID = "did:web:mutated"
+
+		for i := 0; i < 2; i++ {
+			d, err = registry.Resolve(webDID)
+			require.NoError(t, err)
+			require.Equal(t, webDID, d.DIDDocument.ID)
+
+			d.DIDDocument.ID = "did:web:mutated"
+		}
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test peer dids are cached only with ttl", func(t *testing.T) {
+		const peerDID = "did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa"
+
+		var calls int32
+
+		registry := newRegistry(&calls, WithResolveCache(10, 0))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(peerDID)
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+		calls = 0
+		registry = newRegistry(&calls, WithResolveCache(10, 10*time.Millisecond))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(peerDID)
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, err := registry.Resolve(peerDID)
+		require.NoError(t, err)
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test errors not cached", func(t *testing.T) {
+		var calls int32
+
+		registry := New(WithResolveCache(10, 0), WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				atomic.AddInt32(&calls, 1)
+
+				return nil, fmt.Errorf("read error")
+			},
+		}))
+
+		for i := 0; i < 2; i++ {
+			_, err := registry.Resolve(webDID)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "read error")
+		}
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("test concurrent resolves coalesce", func(t *testing.T) {
+		var calls int32
+
+		release := make(chan struct{})
+
+		registry := New(WithResolveCache(10, time.Minute), WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true,
+			ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+
+				return &did.DocResolution{DIDDocument: &did.Doc{Context: []string{did.ContextV1}, ID: didID}}, nil
+			},
+		}))
+
+		const resolves = 10
+
+		var wg sync.WaitGroup
+
+		wg.Add(resolves)
+
+		for i := 0; i < resolves; i++ {
+			go func() {
+				defer wg.Done()
+
+				d, err := registry.Resolve(webDID)
+				require.NoError(t, err)
+				require.Equal(t, webDID, d.DIDDocument.ID)
+			}()
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
+}
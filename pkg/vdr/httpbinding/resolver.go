@@ -20,6 +20,8 @@ import (
 
 const (
 	didLDJson = "application/did+ld+json"
+	// didResolutionLDJson is the content type of DID resolution results returned by Universal Resolvers.
+	didResolutionLDJson = "application/ld+json"
 )
 
 // resolveDID makes DID resolution via HTTP.
@@ -49,14 +51,17 @@ func (v *VDR) resolveDID(uri string) ([]byte, error) {
 		return nil, fmt.Errorf("reading response body failed: %w", err)
 	}
 
-	if resp.StatusCode == http.StatusOK && strings.Contains(resp.Header.Get("Content-type"), didLDJson) {
+	contentType := resp.Header.Get("Content-type")
+
+	if resp.StatusCode == http.StatusOK &&
+		(strings.Contains(contentType, didLDJson) || strings.Contains(contentType, didResolutionLDJson)) {
 		return gotBody, nil
 	} else if resp.StatusCode == http.StatusNotFound {
 		return nil, fmt.Errorf("DID does not exist for request: %s", uri)
 	}
 
 	return nil, fmt.Errorf("unsupported response from DID resolver [%v] header [%s] body [%s]",
-		resp.StatusCode, resp.Header.Get("Content-type"), gotBody)
+		resp.StatusCode, contentType, gotBody)
 }
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
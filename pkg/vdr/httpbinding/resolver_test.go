@@ -135,6 +135,25 @@ func TestRead_DIDDoc(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, didDoc.ID, gotDocument.DIDDocument.ID)
 	})
+	t.Run("test success return universal resolver did resolution", func(t *testing.T) {
+		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			require.Equal(t, "/1.0/identifiers/did:example:334455", req.URL.String())
+			res.Header().Add("Content-type", `application/ld+json;profile="https://w3id.org/did-resolution"`)
+			res.WriteHeader(http.StatusOK)
+			_, err := res.Write([]byte(didResolutionData))
+			require.NoError(t, err)
+		}))
+
+		defer func() { testServer.Close() }()
+
+		resolver, err := New(testServer.URL + "/1.0/identifiers")
+		require.NoError(t, err)
+		gotDocument, err := resolver.Read("did:example:334455")
+		require.NoError(t, err)
+		didDoc, err := did.ParseDocument([]byte(doc))
+		require.NoError(t, err)
+		require.Equal(t, didDoc.ID, gotDocument.DIDDocument.ID)
+	})
 	t.Run("test empty doc", func(t *testing.T) {
 		testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 			require.Equal(t, "/did:example:334455", req.URL.String())
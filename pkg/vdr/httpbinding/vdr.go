@@ -73,7 +73,7 @@ func (v *VDR) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
 
 // Deactivate did doc.
 func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	return fmt.Errorf("deactivation not supported")
 }
 
 // Option configures the peer vdr.
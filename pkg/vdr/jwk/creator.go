@@ -0,0 +1,148 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	schemaResV1    = "https://w3id.org/did-resolution/v1"
+	schemaDIDV1    = "https://www.w3.org/ns/did/v1"
+	schemaJWS2020  = "https://w3id.org/security/suites/jws-2020/v1"
+	jsonWebKey2020 = "JsonWebKey2020"
+
+	ecKty      = "EC"
+	okpKty     = "OKP"
+	p256Crv    = "P-256"
+	ed25519Crv = "Ed25519"
+	x25519Crv  = "X25519"
+
+	sigUse = "sig"
+	encUse = "enc"
+)
+
+// Create new did:jwk DID document for didDoc. The first entry of didDoc.VerificationMethod must hold the public JWK
+// (Ed25519, P-256 or X25519) the did:jwk identifier is derived from.
+func (v *VDR) Create(didDoc *did.Doc, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if didDoc == nil || len(didDoc.VerificationMethod) == 0 {
+		return nil, fmt.Errorf("verification method is empty")
+	}
+
+	jwk := didDoc.VerificationMethod[0].JSONWebKey()
+	if jwk == nil {
+		return nil, fmt.Errorf("verification method is not a JSON web key")
+	}
+
+	pubJWK, err := publicJWK(jwk)
+	if err != nil {
+		return nil, err
+	}
+
+	didJWK, err := didFromJWK(pubJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := createDoc(didJWK, pubJWK)
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// CreateDID returns the did:jwk identifier of the given public JWK.
+func CreateDID(jwk *jose.JWK) (string, error) {
+	pubJWK, err := publicJWK(jwk)
+	if err != nil {
+		return "", err
+	}
+
+	return didFromJWK(pubJWK)
+}
+
+func didFromJWK(pubJWK *jose.JWK) (string, error) {
+	jwkBytes, err := pubJWK.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("marshal jwk: %w", err)
+	}
+
+	return fmt.Sprintf("did:%s:%s", DIDMethod, base64.RawURLEncoding.EncodeToString(jwkBytes)), nil
+}
+
+// publicJWK validates jwk is a supported public key and returns a copy of it free of any private key material.
+func publicJWK(jwk *jose.JWK) (*jose.JWK, error) {
+	// marshal/unmarshal to get Kty and Crv set regardless of how jwk was built.
+	jwkBytes, err := jwk.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("marshal jwk: %w", err)
+	}
+
+	key := &jose.JWK{}
+
+	err = key.UnmarshalJSON(jwkBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal jwk: %w", err)
+	}
+
+	switch {
+	case isKey(key, okpKty, x25519Crv):
+		// X25519 JWKs only hold the public key bytes.
+		return key, nil
+	case isKey(key, okpKty, ed25519Crv), isKey(key, ecKty, p256Crv):
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("jwk is not a public key")
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("not supported jwk: kty '%s', crv '%s'", key.Kty, key.Crv)
+	}
+}
+
+func isKey(jwk *jose.JWK, kty, crv string) bool {
+	return strings.EqualFold(jwk.Kty, kty) && strings.EqualFold(jwk.Crv, crv)
+}
+
+func createDoc(didJWK string, jwk *jose.JWK) (*did.Doc, error) {
+	vm, err := did.NewVerificationMethodFromJWK(didJWK+"#0", jsonWebKey2020, didJWK, jwk)
+	if err != nil {
+		return nil, fmt.Errorf("error creating verification method %w", err)
+	}
+
+	doc := &did.Doc{
+		Context:            []string{schemaDIDV1, schemaJWS2020},
+		ID:                 didJWK,
+		VerificationMethod: []did.VerificationMethod{*vm},
+	}
+
+	use := jwk.Use
+	// X25519 keys can only be used for key agreement.
+	if isKey(jwk, okpKty, x25519Crv) {
+		use = encUse
+	}
+
+	if use != encUse {
+		doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+		doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+		doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+		doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+	}
+
+	if use != sigUse {
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+)
+
+func TestCreateAndResolve(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	edJWK, err := jose.JWKFromKey(edPub)
+	require.NoError(t, err)
+
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	p256JWK, err := jose.JWKFromKey(&p256Key.PublicKey)
+	require.NoError(t, err)
+
+	x25519Pub := make([]byte, 32)
+	_, err = rand.Read(x25519Pub)
+	require.NoError(t, err)
+
+	x25519JWK, err := jose.JWKFromX25519Key(x25519Pub)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		jwk          *jose.JWK
+		keyAgreement bool
+		signing      bool
+	}{
+		{name: "Ed25519", jwk: edJWK, keyAgreement: true, signing: true},
+		{name: "P-256", jwk: p256JWK, keyAgreement: true, signing: true},
+		{name: "X25519", jwk: x25519JWK, keyAgreement: true, signing: false},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			v := New()
+
+			created, err := v.Create(createDIDDoc(t, tc.jwk))
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(created.DIDDocument.ID, "did:jwk:"))
+
+			resolved, err := v.Read(created.DIDDocument.ID)
+			require.NoError(t, err)
+
+			for _, doc := range []*did.Doc{created.DIDDocument, resolved.DIDDocument} {
+				require.Equal(t, created.DIDDocument.ID, doc.ID)
+				require.Len(t, doc.VerificationMethod, 1)
+				require.Equal(t, doc.ID+"#0", doc.VerificationMethod[0].ID)
+				require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+				require.Equal(t, doc.ID, doc.VerificationMethod[0].Controller)
+
+				expectedValue, err := tc.jwk.PublicKeyBytes()
+				require.NoError(t, err)
+				require.Equal(t, expectedValue, doc.VerificationMethod[0].Value)
+
+				require.Equal(t, tc.keyAgreement, len(doc.KeyAgreement) == 1)
+				require.Equal(t, tc.signing, len(doc.Authentication) == 1)
+				require.Equal(t, tc.signing, len(doc.AssertionMethod) == 1)
+			}
+
+			didJWK, err := CreateDID(tc.jwk)
+			require.NoError(t, err)
+			require.Equal(t, created.DIDDocument.ID, didJWK)
+		})
+	}
+
+	t.Run("key use", func(t *testing.T) {
+		sigJWK, err := jose.JWKFromKey(edPub)
+		require.NoError(t, err)
+
+		sigJWK.Use = "sig"
+
+		sigDoc, err := New().Create(createDIDDoc(t, sigJWK))
+		require.NoError(t, err)
+		require.Empty(t, sigDoc.DIDDocument.KeyAgreement)
+		require.Len(t, sigDoc.DIDDocument.Authentication, 1)
+
+		encJWK, err := jose.JWKFromKey(&p256Key.PublicKey)
+		require.NoError(t, err)
+
+		encJWK.Use = "enc"
+
+		encDoc, err := New().Create(createDIDDoc(t, encJWK))
+		require.NoError(t, err)
+		require.Len(t, encDoc.DIDDocument.KeyAgreement, 1)
+		require.Empty(t, encDoc.DIDDocument.Authentication)
+		require.Empty(t, encDoc.DIDDocument.AssertionMethod)
+	})
+}
+
+func TestCreateError(t *testing.T) {
+	t.Run("empty verification method", func(t *testing.T) {
+		_, err := New().Create(&did.Doc{})
+		require.EqualError(t, err, "verification method is empty")
+	})
+
+	t.Run("verification method without JWK", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-1", "Ed25519VerificationKey2018", "", []byte("key"))
+
+		_, err := New().Create(&did.Doc{VerificationMethod: []did.VerificationMethod{*vm}})
+		require.EqualError(t, err, "verification method is not a JSON web key")
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := jose.JWKFromKey(edPriv)
+		require.NoError(t, err)
+
+		_, err = CreateDID(jwk)
+		require.EqualError(t, err, "jwk is not a public key")
+	})
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk, err := jose.JWKFromKey(&p384Key.PublicKey)
+		require.NoError(t, err)
+
+		_, err = New().Create(createDIDDoc(t, jwk))
+		require.EqualError(t, err, "not supported jwk: kty 'EC', crv 'P-384'")
+	})
+}
+
+func createDIDDoc(t *testing.T, jwk *jose.JWK) *did.Doc {
+	t.Helper()
+
+	vm, err := did.NewVerificationMethodFromJWK("#key-1", jsonWebKey2020, "", jwk)
+	require.NoError(t, err)
+
+	return &did.Doc{VerificationMethod: []did.VerificationMethod{*vm}}
+}
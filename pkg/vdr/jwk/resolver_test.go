@@ -0,0 +1,70 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// nolint:lll
+const specP256DID = "did:jwk:eyJjcnYiOiJQLTI1NiIsImt0eSI6IkVDIiwieCI6ImFjYklRaXVNczNpOF91c3pFakoydHBUdFJNNEVVM3l6OTFQSDZDZEgyVjAiLCJ5IjoiX0tjeUxqOXZXTXB0bm1LdG00NkdxRHo4d2Y3NEk1TEtncmwyR3pIM25TRSJ9"
+
+func TestRead(t *testing.T) {
+	t.Run("resolve P-256 did:jwk", func(t *testing.T) {
+		docResolution, err := New().Read(specP256DID)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, specP256DID, doc.ID)
+		require.Equal(t, []string{schemaDIDV1, schemaJWS2020}, doc.Context)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, specP256DID+"#0", doc.VerificationMethod[0].ID)
+
+		jwk := doc.VerificationMethod[0].JSONWebKey()
+		require.NotNil(t, jwk)
+		require.Equal(t, "EC", jwk.Kty)
+		require.Equal(t, "P-256", jwk.Crv)
+
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+	})
+
+	t.Run("invalid did", func(t *testing.T) {
+		_, err := New().Read("did:jwk")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID document")
+	})
+
+	t.Run("invalid method", func(t *testing.T) {
+		_, err := New().Read("did:key:z6MkpTHR8VNsBxYAAWHut2Geadd9jSwuBV8xRoAnwWsdvktH")
+		require.EqualError(t, err, "jwk vdr Read: invalid did:jwk method: key")
+	})
+
+	t.Run("invalid method ID encoding", func(t *testing.T) {
+		_, err := New().Read("did:jwk:e30.")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:jwk method ID")
+	})
+
+	t.Run("invalid JWK", func(t *testing.T) {
+		_, err := New().Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(`{"kty":"EC"}`)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "jwk vdr Read")
+	})
+
+	t.Run("unsupported JWK", func(t *testing.T) {
+		_, err := New().Read("did:jwk:" + base64.RawURLEncoding.EncodeToString([]byte(
+			`{"kty":"oct","k":"GawgguFyGrWKav7AX4VKUg"}`)))
+		require.EqualError(t, err, "jwk vdr Read: not supported jwk: kty 'oct', crv ''")
+	})
+}
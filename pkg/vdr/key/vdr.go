@@ -47,5 +47,5 @@ func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 
 // Deactivate did doc.
 func (v *VDR) Deactivate(didID string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	return fmt.Errorf("deactivation not supported")
 }
@@ -0,0 +1,254 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	// Reference: https://identity.foundation/peer-did-method-spec/#generation-method (numalgo 2).
+	numAlgo2Prefix = peerPrefix + "2"
+
+	purposeAssertion            = 'A'
+	purposeEncryption           = 'E'
+	purposeVerification         = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+
+	didCommMessagingType     = "DIDCommMessaging"
+	didCommMessagingTypeAbbr = "dm"
+)
+
+// abbreviatedService is the service block encoding of numalgo 2 peer DIDs.
+type abbreviatedService struct {
+	Type            string   `json:"t"`
+	ServiceEndpoint string   `json:"s"`
+	RoutingKeys     []string `json:"r,omitempty"`
+	Accept          []string `json:"a,omitempty"`
+}
+
+// IsNumAlgo2DID returns true if didID is a numalgo 2 peer DID (eg: did:peer:2.Ez6LS...Vz6Mk...).
+func IsNumAlgo2DID(didID string) bool {
+	return strings.HasPrefix(didID, numAlgo2Prefix+".")
+}
+
+// NewNumAlgo2DID creates a numalgo 2 peer DID encoding inline the given keys and services. Each key's purpose code
+// is derived from its verification relationship. Ed25519VerificationKey2018 and X25519KeyAgreementKey2019 keys are
+// supported.
+// Reference: https://identity.foundation/peer-did-method-spec/#generation-method
+func NewNumAlgo2DID(keys []did.Verification, services []did.Service) (string, error) {
+	if len(keys) == 0 {
+		return "", errors.New("numalgo 2 peer DID must include at least one key")
+	}
+
+	elements := []string{numAlgo2Prefix}
+
+	for i := range keys {
+		purpose, err := purposeCode(keys[i].Relationship)
+		if err != nil {
+			return "", err
+		}
+
+		code, err := keyCode(keys[i].VerificationMethod.Type)
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements,
+			string(purpose)+fingerprint.KeyFingerprint(code, keys[i].VerificationMethod.Value))
+	}
+
+	for i := range services {
+		encoded, err := encodeService(&services[i])
+		if err != nil {
+			return "", err
+		}
+
+		elements = append(elements, string(purposeService)+encoded)
+	}
+
+	return strings.Join(elements, "."), nil
+}
+
+// resolveNumAlgo2DID expands a numalgo 2 peer DID to its DID document.
+// Reference: https://identity.foundation/peer-did-method-spec/#resolving-a-didpeer2
+func resolveNumAlgo2DID(didID string) (*did.Doc, error) {
+	if !IsNumAlgo2DID(didID) {
+		return nil, fmt.Errorf("not a numalgo 2 peer DID: %s", didID)
+	}
+
+	doc := &did.Doc{
+		Context: []string{did.ContextV1},
+		ID:      didID,
+	}
+
+	for _, element := range strings.Split(strings.TrimPrefix(didID, numAlgo2Prefix+"."), ".") {
+		if element == "" {
+			return nil, errors.New("empty numalgo 2 peer DID element")
+		}
+
+		if element[0] == purposeService {
+			svc, err := decodeService(element[1:])
+			if err != nil {
+				return nil, err
+			}
+
+			svc.ID = serviceID(didID, len(doc.Service))
+			doc.Service = append(doc.Service, *svc)
+
+			continue
+		}
+
+		err := addKey(doc, element[0], element[1:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+func addKey(doc *did.Doc, purpose byte, multibaseKey string) error {
+	pubKey, code, err := fingerprint.PubKeyFromFingerprint(multibaseKey)
+	if err != nil {
+		return fmt.Errorf("decode numalgo 2 peer DID key: %w", err)
+	}
+
+	var keyType string
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		keyType = ed25519VerificationKey2018
+	case fingerprint.X25519PubKeyMultiCodec:
+		keyType = x25519KeyAgreementKey2019
+	default:
+		return fmt.Errorf("not supported numalgo 2 peer DID key multicodec code [0x%x]", code)
+	}
+
+	vm := did.NewVerificationMethodFromBytes(fmt.Sprintf("%s#key-%d", doc.ID, len(doc.VerificationMethod)+1),
+		keyType, doc.ID, pubKey)
+
+	switch purpose {
+	case purposeAssertion:
+		doc.AssertionMethod = append(doc.AssertionMethod, *did.NewReferencedVerification(vm, did.AssertionMethod))
+	case purposeEncryption:
+		doc.KeyAgreement = append(doc.KeyAgreement, *did.NewReferencedVerification(vm, did.KeyAgreement))
+	case purposeVerification:
+		doc.Authentication = append(doc.Authentication, *did.NewReferencedVerification(vm, did.Authentication))
+	case purposeCapabilityInvocation:
+		doc.CapabilityInvocation = append(doc.CapabilityInvocation,
+			*did.NewReferencedVerification(vm, did.CapabilityInvocation))
+	case purposeCapabilityDelegation:
+		doc.CapabilityDelegation = append(doc.CapabilityDelegation,
+			*did.NewReferencedVerification(vm, did.CapabilityDelegation))
+	default:
+		return fmt.Errorf("not supported numalgo 2 peer DID purpose code '%c'", purpose)
+	}
+
+	doc.VerificationMethod = append(doc.VerificationMethod, *vm)
+
+	return nil
+}
+
+func purposeCode(relationship did.VerificationRelationship) (byte, error) {
+	switch relationship {
+	case did.AssertionMethod:
+		return purposeAssertion, nil
+	case did.KeyAgreement:
+		return purposeEncryption, nil
+	case did.Authentication:
+		return purposeVerification, nil
+	case did.CapabilityInvocation:
+		return purposeCapabilityInvocation, nil
+	case did.CapabilityDelegation:
+		return purposeCapabilityDelegation, nil
+	default:
+		return 0, fmt.Errorf("not supported numalgo 2 peer DID verification relationship: %d", relationship)
+	}
+}
+
+func keyCode(keyType string) (uint64, error) {
+	switch keyType {
+	case ed25519VerificationKey2018:
+		return fingerprint.ED25519PubKeyMultiCodec, nil
+	case x25519KeyAgreementKey2019:
+		return fingerprint.X25519PubKeyMultiCodec, nil
+	default:
+		return 0, fmt.Errorf("not supported numalgo 2 peer DID key type: %s", keyType)
+	}
+}
+
+func serviceID(didID string, index int) string {
+	if index == 0 {
+		return didID + "#service"
+	}
+
+	return fmt.Sprintf("%s#service-%d", didID, index)
+}
+
+func encodeService(svc *did.Service) (string, error) {
+	abbr := &abbreviatedService{
+		Type:            svc.Type,
+		ServiceEndpoint: svc.ServiceEndpoint,
+		RoutingKeys:     svc.RoutingKeys,
+		Accept:          svc.Accept,
+	}
+
+	if abbr.Type == didCommMessagingType {
+		abbr.Type = didCommMessagingTypeAbbr
+	}
+
+	buf := new(bytes.Buffer)
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(abbr)
+	if err != nil {
+		return "", fmt.Errorf("encode numalgo 2 peer DID service: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+func decodeService(encoded string) (*did.Service, error) {
+	// accept padded and unpadded base64url service blocks.
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode numalgo 2 peer DID service: %w", err)
+	}
+
+	abbr := &abbreviatedService{}
+
+	err = json.Unmarshal(raw, abbr)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal numalgo 2 peer DID service: %w", err)
+	}
+
+	svc := &did.Service{
+		Type:            abbr.Type,
+		ServiceEndpoint: abbr.ServiceEndpoint,
+		RoutingKeys:     abbr.RoutingKeys,
+		Accept:          abbr.Accept,
+	}
+
+	if svc.Type == didCommMessagingTypeAbbr {
+		svc.Type = didCommMessagingType
+	}
+
+	return svc, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// numAlgo2DID is the numalgo 2 example of the peer DID method spec.
+// nolint:lll
+const numAlgo2DID = "did:peer:2.Ez6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.Vz6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg.SeyJ0IjoiZG0iLCJzIjoiaHR0cHM6Ly9leGFtcGxlLmNvbS9lbmRwb2ludCIsInIiOlsiZGlkOmV4YW1wbGU6c29tZW1lZGlhdG9yI3NvbWVrZXkiXSwiYSI6WyJkaWRjb21tL3YyIiwiZGlkY29tbS9haXAyO2Vudj1yZmM1ODciXX0"
+
+func TestResolveNumAlgo2DID(t *testing.T) {
+	t.Run("resolve spec example", func(t *testing.T) {
+		doc, err := resolveNumAlgo2DID(numAlgo2DID)
+		require.NoError(t, err)
+
+		require.Equal(t, numAlgo2DID, doc.ID)
+		require.Len(t, doc.VerificationMethod, 3)
+
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, numAlgo2DID+"#key-1", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+		require.Equal(t, "z6LSbysY2xFMRpGMhb7tFTLMpeuPRaqaWM1yECx2AtzE3KCc",
+			fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, doc.KeyAgreement[0].VerificationMethod.Value))
+
+		require.Len(t, doc.Authentication, 2)
+		require.Equal(t, numAlgo2DID+"#key-2", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, ed25519VerificationKey2018, doc.Authentication[0].VerificationMethod.Type)
+		require.Equal(t, numAlgo2DID, doc.Authentication[0].VerificationMethod.Controller)
+		require.Equal(t, numAlgo2DID+"#key-3", doc.Authentication[1].VerificationMethod.ID)
+		require.Equal(t, "z6MkgoLTnTypo3tDRwCkZXSccTPHRLhF4ZnjhueYAFpEX6vg",
+			fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, doc.Authentication[1].VerificationMethod.Value))
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, numAlgo2DID+"#service", doc.Service[0].ID)
+		require.Equal(t, "DIDCommMessaging", doc.Service[0].Type)
+		require.Equal(t, "https://example.com/endpoint", doc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, doc.Service[0].RoutingKeys)
+		require.Equal(t, []string{"didcomm/v2", "didcomm/aip2;env=rfc587"}, doc.Service[0].Accept)
+	})
+
+	t.Run("resolve with VDR", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(numAlgo2DID)
+		require.NoError(t, err)
+		require.Equal(t, numAlgo2DID, docResolution.DIDDocument.ID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 3)
+
+		_, err = v.Read("did:peer:2.Xz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve numalgo 2 peer DID")
+	})
+
+	t.Run("resolve errors", func(t *testing.T) {
+		_, err := resolveNumAlgo2DID("did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa")
+		require.EqualError(t, err, "not a numalgo 2 peer DID: did:peer:1zQmZMygzYqNwU6Uhmewx5Xepf2VLp5S4HLSwwgf2aiKZuwa")
+
+		_, err = resolveNumAlgo2DID("did:peer:2..Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V")
+		require.EqualError(t, err, "empty numalgo 2 peer DID element")
+
+		_, err = resolveNumAlgo2DID("did:peer:2.Xz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V")
+		require.EqualError(t, err, "not supported numalgo 2 peer DID purpose code 'X'")
+
+		_, err = resolveNumAlgo2DID("did:peer:2.V6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode numalgo 2 peer DID key")
+
+		p256Key := fingerprint.KeyFingerprint(fingerprint.P256PubKeyMultiCodec, make([]byte, 33))
+		_, err = resolveNumAlgo2DID("did:peer:2.V" + p256Key)
+		require.EqualError(t, err, "not supported numalgo 2 peer DID key multicodec code [0x1200]")
+
+		_, err = resolveNumAlgo2DID("did:peer:2.S!!!")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode numalgo 2 peer DID service")
+
+		_, err = resolveNumAlgo2DID("did:peer:2.S" + base64.RawURLEncoding.EncodeToString([]byte("[]")))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal numalgo 2 peer DID service")
+	})
+
+	t.Run("resolve padded service block", func(t *testing.T) {
+		padded := "did:peer:2.Vz6MkqRYqQiSgvZQdnBytw86Qbs2ZWUkGv22od935YF4s8M7V.S" +
+			base64.URLEncoding.EncodeToString([]byte(`{"t":"dm","s":"https://example.com"}`)) + "." +
+			"S" + base64.URLEncoding.EncodeToString([]byte(`{"t":"other","s":"https://example.org"}`))
+
+		doc, err := resolveNumAlgo2DID(padded)
+		require.NoError(t, err)
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, padded+"#service", doc.Service[0].ID)
+		require.Equal(t, "DIDCommMessaging", doc.Service[0].Type)
+		require.Equal(t, padded+"#service-1", doc.Service[1].ID)
+		require.Equal(t, "other", doc.Service[1].Type)
+	})
+}
+
+func TestNewNumAlgo2DID(t *testing.T) {
+	t.Run("round trip spec example", func(t *testing.T) {
+		doc, err := resolveNumAlgo2DID(numAlgo2DID)
+		require.NoError(t, err)
+
+		keys := []did.Verification{doc.KeyAgreement[0], doc.Authentication[0], doc.Authentication[1]}
+
+		didID, err := NewNumAlgo2DID(keys, doc.Service)
+		require.NoError(t, err)
+		require.Equal(t, numAlgo2DID, didID)
+		require.True(t, IsNumAlgo2DID(didID))
+	})
+
+	t.Run("all purposes", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key", ed25519VerificationKey2018, "", make([]byte, 32))
+
+		didID, err := NewNumAlgo2DID([]did.Verification{
+			*did.NewReferencedVerification(vm, did.AssertionMethod),
+			*did.NewReferencedVerification(vm, did.Authentication),
+			*did.NewReferencedVerification(vm, did.CapabilityInvocation),
+			*did.NewReferencedVerification(vm, did.CapabilityDelegation),
+		}, nil)
+		require.NoError(t, err)
+
+		doc, err := resolveNumAlgo2DID(didID)
+		require.NoError(t, err)
+		require.Len(t, doc.VerificationMethod, 4)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Empty(t, doc.KeyAgreement)
+		require.Empty(t, doc.Service)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewNumAlgo2DID(nil, nil)
+		require.EqualError(t, err, "numalgo 2 peer DID must include at least one key")
+
+		vm := did.NewVerificationMethodFromBytes("#key", ed25519VerificationKey2018, "", make([]byte, 32))
+
+		_, err = NewNumAlgo2DID([]did.Verification{
+			*did.NewReferencedVerification(vm, did.VerificationRelationshipGeneral),
+		}, nil)
+		require.EqualError(t, err, "not supported numalgo 2 peer DID verification relationship: 0")
+
+		vm.Type = jsonWebKey2020
+
+		_, err = NewNumAlgo2DID([]did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}, nil)
+		require.EqualError(t, err, "not supported numalgo 2 peer DID key type: JsonWebKey2020")
+	})
+}
@@ -15,8 +15,18 @@ import (
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
 func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	// numalgo 2 peer DIDs embed their keys and services, no lookup is needed.
+	if IsNumAlgo2DID(didID) {
+		doc, err := resolveNumAlgo2DID(didID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID: %w", err)
+		}
+
+		return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	}
+
 	// get the document from the store
-	doc, err := v.Get(didID)
+	doc, deactivated, err := v.get(didID)
 	if err != nil {
 		return nil, fmt.Errorf("fetching data from store failed: %w", err)
 	}
@@ -25,5 +35,11 @@ func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolutio
 		return nil, vdrapi.ErrNotFound
 	}
 
-	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+	docResolution := &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}
+
+	if deactivated {
+		docResolution.DocumentMetadata = &did.DocumentMetadata{Deactivated: true}
+	}
+
+	return docResolution, nil
 }
@@ -25,9 +25,10 @@ type modifiedBy struct {
 }
 
 type docDelta struct {
-	Change     string        `json:"change,omitempty"`
-	ModifiedBy *[]modifiedBy `json:"by,omitempty"`
-	ModifiedAt time.Time     `json:"when,omitempty"`
+	Change      string        `json:"change,omitempty"`
+	ModifiedBy  *[]modifiedBy `json:"by,omitempty"`
+	ModifiedAt  time.Time     `json:"when,omitempty"`
+	Deactivated bool          `json:"deactivated,omitempty"`
 }
 
 // storeDID saves Peer DID Document along with user key/signature.
@@ -60,31 +61,77 @@ func (v *VDR) storeDID(doc *did.Doc, by *[]modifiedBy) error { //nolint: unparam
 	return v.store.Put(doc.ID, val)
 }
 
+// deactivateDID appends a deactivation delta to the stored Peer DID Document.
+func (v *VDR) deactivateDID(id string, by *[]modifiedBy) error {
+	if id == "" {
+		return errors.New("ID is mandatory")
+	}
+
+	deltas, err := v.getDeltas(id)
+	if err != nil {
+		return fmt.Errorf("delta data fetch from store for did [%s] failed: %w", id, err)
+	}
+
+	if isDeactivated(deltas) {
+		return fmt.Errorf("did [%s] is already deactivated", id)
+	}
+
+	deltas = append(deltas, docDelta{
+		ModifiedBy:  by,
+		ModifiedAt:  time.Now(),
+		Deactivated: true,
+	})
+
+	val, err := json.Marshal(deltas)
+	if err != nil {
+		return fmt.Errorf("JSON marshalling of document deltas failed: %w", err)
+	}
+
+	return v.store.Put(id, val)
+}
+
 // Get returns Peer DID Document.
 func (v *VDR) Get(id string) (*did.Doc, error) {
+	doc, _, err := v.get(id)
+
+	return doc, err
+}
+
+// get returns Peer DID Document along with its deactivated flag.
+func (v *VDR) get(id string) (*did.Doc, bool, error) {
 	if id == "" {
-		return nil, errors.New("ID is mandatory")
+		return nil, false, errors.New("ID is mandatory")
 	}
 
 	deltas, err := v.getDeltas(id)
 	if err != nil {
-		return nil, fmt.Errorf("delta data fetch from store for did [%s] failed: %w", id, err)
+		return nil, false, fmt.Errorf("delta data fetch from store for did [%s] failed: %w", id, err)
 	}
 
-	// For now, assume storage contains only one delta(genesis document)
+	// For now, assume the first delta is the genesis document and following ones are deactivation deltas only.
 	delta := deltas[0]
 
 	doc, err := base64.URLEncoding.DecodeString(delta.Change)
 	if err != nil {
-		return nil, fmt.Errorf("decoding of document delta failed: %w", err)
+		return nil, false, fmt.Errorf("decoding of document delta failed: %w", err)
 	}
 
 	document, err := did.ParseDocument(doc)
 	if err != nil {
-		return nil, fmt.Errorf("document ParseDocument() failed: %w", err)
+		return nil, false, fmt.Errorf("document ParseDocument() failed: %w", err)
+	}
+
+	return document, isDeactivated(deltas), nil
+}
+
+func isDeactivated(deltas []docDelta) bool {
+	for _, delta := range deltas {
+		if delta.Deactivated {
+			return true
+		}
 	}
 
-	return document, nil
+	return false
 }
 
 // Close frees resources being maintained by vdr.
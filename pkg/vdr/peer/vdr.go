@@ -43,9 +43,13 @@ func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	return fmt.Errorf("not supported")
 }
 
-// Deactivate did doc.
+// Deactivate did doc. The document remains resolvable, flagged as deactivated in its metadata.
 func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if err := v.deactivateDID(did, nil); err != nil {
+		return fmt.Errorf("deactivate did: %w", err)
+	}
+
+	return nil
 }
 
 // Accept did method.
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
 )
 
@@ -27,11 +30,44 @@ func TestUpdate(t *testing.T) {
 
 func TestDeactivate(t *testing.T) {
 	t.Run("test deactivate", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		err = v.storeDID(&did.Doc{Context: []string{"https://w3id.org/did/v1"}, ID: peerDID}, nil)
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(peerDID)
+		require.NoError(t, err)
+		require.Nil(t, docResolution.DocumentMetadata)
+
+		require.NoError(t, v.Deactivate(peerDID))
+
+		docResolution, err = v.Read(peerDID)
+		require.NoError(t, err)
+		require.Equal(t, peerDID, docResolution.DIDDocument.ID)
+		require.NotNil(t, docResolution.DocumentMetadata)
+		require.True(t, docResolution.DocumentMetadata.Deactivated)
+
+		err = v.Deactivate(peerDID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already deactivated")
+	})
+
+	t.Run("test deactivate empty did", func(t *testing.T) {
 		v, err := New(&storage.MockStoreProvider{})
 		require.NoError(t, err)
 
 		err = v.Deactivate("")
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "not supported")
+		require.Contains(t, err.Error(), "ID is mandatory")
+	})
+
+	t.Run("test deactivate did not found", func(t *testing.T) {
+		v, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		err = v.Deactivate(peerDID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
 	})
 }
@@ -10,20 +10,28 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
 )
 
+const (
+	didJSONContentType   = "application/did+json"
+	didLDJSONContentType = "application/did+ld+json"
+)
+
 // Option is a vdr instance option.
 type Option func(opts *Registry)
 
 // Registry vdr registry.
 type Registry struct {
 	vdr                []vdrapi.VDR
+	fallbackVDR        []vdrapi.VDR
 	defServiceEndpoint string
 	defServiceType     string
+	cache              *resolveCache
 }
 
 // New return new instance of vdr.
@@ -39,7 +47,27 @@ func New(opts ...Option) *Registry {
 }
 
 // Resolve did document.
+// If the registry is created with WithResolveCache, resolutions are served from the cache and concurrent resolutions
+// of the same did with the same opts share a single call to the did method.
 func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
+	if r.cache != nil {
+		return r.cache.resolve(did, opts, func() (*diddoc.DocResolution, error) {
+			return r.resolve(did, opts...)
+		})
+	}
+
+	return r.resolve(did, opts...)
+}
+
+// InvalidateCache removes did from the resolution cache, if any. The next Resolve call fetches the did document from
+// the did method.
+func (r *Registry) InvalidateCache(did string) {
+	if r.cache != nil {
+		r.cache.invalidate(did)
+	}
+}
+
+func (r *Registry) resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.DocResolution, error) {
 	didMethod, err := GetDidMethod(did)
 	if err != nil {
 		return nil, err
@@ -61,9 +89,59 @@ func (r *Registry) Resolve(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.
 		return nil, fmt.Errorf("did method read failed failed: %w", err)
 	}
 
+	populateResolutionMetadata(didDocResolution)
+
 	return didDocResolution, nil
 }
 
+// ResolveDocument resolves did and returns the did document only, without any resolution metadata.
+func (r *Registry) ResolveDocument(did string, opts ...vdrapi.DIDMethodOption) (*diddoc.Doc, error) {
+	didDocResolution, err := r.Resolve(did, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if didDocResolution == nil || didDocResolution.DIDDocument == nil {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return didDocResolution.DIDDocument, nil
+}
+
+// populateResolutionMetadata sets the resolution metadata and the document metadata missing from the did method's
+// resolution result.
+func populateResolutionMetadata(didDocResolution *diddoc.DocResolution) {
+	if didDocResolution == nil || didDocResolution.DIDDocument == nil {
+		return
+	}
+
+	doc := didDocResolution.DIDDocument
+
+	if didDocResolution.ResolutionMetadata == nil {
+		didDocResolution.ResolutionMetadata = &diddoc.ResolutionMetadata{}
+	}
+
+	if didDocResolution.ResolutionMetadata.ContentType == "" {
+		didDocResolution.ResolutionMetadata.ContentType = didJSONContentType
+
+		if len(doc.Context) > 0 {
+			didDocResolution.ResolutionMetadata.ContentType = didLDJSONContentType
+		}
+	}
+
+	if didDocResolution.DocumentMetadata == nil {
+		didDocResolution.DocumentMetadata = &diddoc.DocumentMetadata{}
+	}
+
+	if didDocResolution.DocumentMetadata.Created == nil {
+		didDocResolution.DocumentMetadata.Created = doc.Created
+	}
+
+	if didDocResolution.DocumentMetadata.Updated == nil {
+		didDocResolution.DocumentMetadata.Updated = doc.Updated
+	}
+}
+
 // Update did document.
 func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
 	didMethod, err := GetDidMethod(didDoc.ID)
@@ -77,7 +155,11 @@ func (r *Registry) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) er
 		return err
 	}
 
-	return method.Update(didDoc, opts...)
+	err = method.Update(didDoc, opts...)
+
+	r.InvalidateCache(didDoc.ID)
+
+	return err
 }
 
 // Deactivate did document.
@@ -93,7 +175,11 @@ func (r *Registry) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error
 		return err
 	}
 
-	return method.Deactivate(did, opts...)
+	err = method.Deactivate(did, opts...)
+
+	r.InvalidateCache(did)
+
+	return err
 }
 
 // Create a new DID Document and store it in this registry.
@@ -140,6 +226,12 @@ func (r *Registry) Close() error {
 		}
 	}
 
+	for _, v := range r.fallbackVDR {
+		if err := v.Close(); err != nil {
+			return fmt.Errorf("close fallback vdr: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -150,6 +242,12 @@ func (r *Registry) resolveVDR(method string) (vdrapi.VDR, error) {
 		}
 	}
 
+	for _, v := range r.fallbackVDR {
+		if v.Accept(method) {
+			return v, nil
+		}
+	}
+
 	return nil, fmt.Errorf("did method %s not supported for vdr", method)
 }
 
@@ -160,6 +258,24 @@ func WithVDR(method vdrapi.VDR) Option {
 	}
 }
 
+// WithFallbackVDR adds did method implementation consulted only for did methods not accepted by any of the VDRs
+// added with WithVDR (eg: an httpbinding VDR delegating resolution to a Universal Resolver).
+func WithFallbackVDR(method vdrapi.VDR) Option {
+	return func(opts *Registry) {
+		opts.fallbackVDR = append(opts.fallbackVDR, method)
+	}
+}
+
+// WithResolveCache enables an LRU cache of up to size did resolutions in front of Resolve, keyed by did and resolve
+// options. Cached resolutions expire after ttl (never if ttl is 0), except for immutable did methods (did:key, did:jwk)
+// which never expire. did:peer resolutions are cached only if ttl is set, as peer dids can rotate.
+// Use InvalidateCache to evict a did known to have changed.
+func WithResolveCache(size int, ttl time.Duration) Option {
+	return func(opts *Registry) {
+		opts.cache = newResolveCache(size, ttl)
+	}
+}
+
 // WithDefaultServiceType is default service type for this creator.
 func WithDefaultServiceType(serviceType string) Option {
 	return func(opts *Registry) {
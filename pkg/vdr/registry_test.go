@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package vdr
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -108,6 +110,141 @@ func TestRegistry_Resolve(t *testing.T) {
 		_, err := registry.Resolve("1:id:123")
 		require.NoError(t, err)
 	})
+
+	t.Run("test resolution metadata populated", func(t *testing.T) {
+		created := time.Now().Add(-time.Hour)
+		updated := time.Now()
+
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{
+					DIDDocument: &did.Doc{
+						Context: []string{did.ContextV1},
+						ID:      didID,
+						Created: &created,
+						Updated: &updated,
+					},
+				}, nil
+			},
+		}))
+
+		d, err := registry.Resolve("1:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "application/did+ld+json", d.ResolutionMetadata.ContentType)
+		require.Equal(t, &created, d.DocumentMetadata.Created)
+		require.Equal(t, &updated, d.DocumentMetadata.Updated)
+		require.False(t, d.DocumentMetadata.Deactivated)
+	})
+
+	t.Run("test resolution metadata from did method kept", func(t *testing.T) {
+		created := time.Now()
+
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{
+					DIDDocument:        &did.Doc{ID: didID},
+					ResolutionMetadata: &did.ResolutionMetadata{ContentType: "application/json"},
+					DocumentMetadata: &did.DocumentMetadata{
+						Created:     &created,
+						VersionID:   "3",
+						Deactivated: true,
+					},
+				}, nil
+			},
+		}))
+
+		d, err := registry.Resolve("1:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "application/json", d.ResolutionMetadata.ContentType)
+		require.Equal(t, &created, d.DocumentMetadata.Created)
+		require.Nil(t, d.DocumentMetadata.Updated)
+		require.Equal(t, "3", d.DocumentMetadata.VersionID)
+		require.True(t, d.DocumentMetadata.Deactivated)
+	})
+
+	t.Run("test resolution metadata for doc without context", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}))
+
+		d, err := registry.Resolve("1:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "application/did+json", d.ResolutionMetadata.ContentType)
+	})
+}
+
+func TestRegistry_FallbackVDR(t *testing.T) {
+	localRead := func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		return &did.DocResolution{DIDDocument: &did.Doc{ID: "local"}}, nil
+	}
+
+	fallbackRead := func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+		return &did.DocResolution{DIDDocument: &did.Doc{ID: "fallback"}}, nil
+	}
+
+	registry := New(
+		WithFallbackVDR(&mockvdr.MockVDR{AcceptValue: true, ReadFunc: fallbackRead}),
+		WithVDR(&mockvdr.MockVDR{
+			AcceptFunc: func(method string) bool { return method == "local" },
+			ReadFunc:   localRead,
+		}),
+	)
+
+	t.Run("test local vdr takes precedence", func(t *testing.T) {
+		d, err := registry.Resolve("did:local:123")
+		require.NoError(t, err)
+		require.Equal(t, "local", d.DIDDocument.ID)
+	})
+
+	t.Run("test fallback vdr for unsupported method", func(t *testing.T) {
+		d, err := registry.Resolve("did:ion:123")
+		require.NoError(t, err)
+		require.Equal(t, "fallback", d.DIDDocument.ID)
+	})
+
+	t.Run("test fallback vdr close error", func(t *testing.T) {
+		r := New(WithFallbackVDR(&mockvdr.MockVDR{CloseErr: fmt.Errorf("close error")}))
+		err := r.Close()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "close fallback vdr: close error")
+	})
+}
+
+func TestRegistry_ResolveDocument(t *testing.T) {
+	t.Run("test success", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return &did.DocResolution{DIDDocument: &did.Doc{ID: didID}}, nil
+			},
+		}))
+
+		doc, err := registry.ResolveDocument("1:id:123")
+		require.NoError(t, err)
+		require.Equal(t, "1:id:123", doc.ID)
+	})
+
+	t.Run("test error from resolve did", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{
+			AcceptValue: true, ReadFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return nil, fmt.Errorf("read error")
+			},
+		}))
+
+		doc, err := registry.ResolveDocument("1:id:123")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "read error")
+		require.Nil(t, doc)
+	})
+
+	t.Run("test no did document", func(t *testing.T) {
+		registry := New(WithVDR(&mockvdr.MockVDR{AcceptValue: true}))
+
+		doc, err := registry.ResolveDocument("1:id:123")
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+		require.Nil(t, doc)
+	})
 }
 
 func TestRegistry_Update(t *testing.T) {
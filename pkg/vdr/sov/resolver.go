@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+)
+
+const (
+	schemaResV1                = "https://w3id.org/did-resolution/v1"
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	x25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+
+	// an Indy DID is the first 16 bytes of the Ed25519 verkey of the DID.
+	indyDIDSize = 16
+	verkeySize  = 32
+	// abbreviatedVerkeyPrefix prefixes the verkey written without the part which is the Indy DID.
+	abbreviatedVerkeyPrefix = "~"
+)
+
+// endpointAttrib is the value of the endpoint ATTRIB.
+type endpointAttrib struct {
+	Endpoint json.RawMessage `json:"endpoint"`
+}
+
+// endpoint is the DIDComm endpoint of the endpoint ATTRIB, older ledgers hold the endpoint URL only.
+type endpoint struct {
+	Endpoint    string   `json:"endpoint"`
+	RoutingKeys []string `json:"routingKeys,omitempty"`
+}
+
+// Read resolves a did:sov DID by reading its NYM (and the endpoint ATTRIB, if any) from the ledger.
+func (v *VDR) Read(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	parsed, err := did.Parse(didID)
+	if err != nil {
+		return nil, fmt.Errorf("did:sov vdr Read: failed to parse DID: %w", err)
+	}
+
+	if parsed.Method != namespace {
+		return nil, fmt.Errorf("did:sov vdr Read: invalid did:sov method: %s", parsed.Method)
+	}
+
+	indyDID := parsed.MethodSpecificID
+
+	if len(base58.Decode(indyDID)) != indyDIDSize {
+		return nil, fmt.Errorf("did:sov vdr Read: invalid did:sov method ID: %s", indyDID)
+	}
+
+	nym, err := v.ledger.GetNym(indyDID)
+	if err != nil {
+		return nil, fmt.Errorf("did:sov vdr Read: failed to get NYM: %w", err)
+	}
+
+	verkey, err := expandVerkey(indyDID, nym.Verkey)
+	if err != nil {
+		return nil, fmt.Errorf("did:sov vdr Read: %w", err)
+	}
+
+	ep, err := v.readEndpoint(indyDID)
+	if err != nil {
+		return nil, fmt.Errorf("did:sov vdr Read: %w", err)
+	}
+
+	didDoc, err := createDIDDoc(didID, verkey, ep)
+	if err != nil {
+		return nil, fmt.Errorf("did:sov vdr Read: %w", err)
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
+}
+
+func (v *VDR) readEndpoint(indyDID string) (*endpoint, error) {
+	raw, err := v.ledger.GetAttrib(indyDID, EndpointAttrib)
+	if errors.Is(err, vdrapi.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint ATTRIB: %w", err)
+	}
+
+	var attrib endpointAttrib
+
+	err = json.Unmarshal([]byte(raw), &attrib)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal endpoint ATTRIB: %w", err)
+	}
+
+	ep := &endpoint{}
+
+	// the endpoint is either the endpoint URL or the endpoint object
+	if err = json.Unmarshal(attrib.Endpoint, &ep.Endpoint); err != nil {
+		if err = json.Unmarshal(attrib.Endpoint, ep); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal endpoint: %w", err)
+		}
+	}
+
+	if ep.Endpoint == "" {
+		return nil, errors.New("endpoint ATTRIB has no endpoint")
+	}
+
+	return ep, nil
+}
+
+// expandVerkey returns the full verkey of the Indy DID, the verkey written to the ledger could be abbreviated.
+func expandVerkey(indyDID, verkey string) ([]byte, error) {
+	var verkeyBytes []byte
+
+	if strings.HasPrefix(verkey, abbreviatedVerkeyPrefix) {
+		verkeyBytes = append(base58.Decode(indyDID), base58.Decode(strings.TrimPrefix(verkey,
+			abbreviatedVerkeyPrefix))...)
+	} else {
+		verkeyBytes = base58.Decode(verkey)
+	}
+
+	if len(verkeyBytes) != verkeySize {
+		return nil, fmt.Errorf("invalid NYM verkey: %s", verkey)
+	}
+
+	return verkeyBytes, nil
+}
+
+func createDIDDoc(didID string, verkey []byte, ep *endpoint) (*did.Doc, error) {
+	pubKey := did.NewVerificationMethodFromBytes(didID+"#key-1", ed25519VerificationKey2018, didID, verkey)
+
+	curve25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(verkey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert verkey to key agreement key: %w", err)
+	}
+
+	keyAgreement := did.NewVerificationMethodFromBytes(didID+"#key-agreement-1", x25519KeyAgreementKey2019,
+		didID, curve25519PubKey)
+
+	didDoc := &did.Doc{
+		Context:            []string{did.ContextV1},
+		ID:                 didID,
+		VerificationMethod: []did.VerificationMethod{*pubKey},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(pubKey, did.Authentication)},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(pubKey, did.AssertionMethod)},
+		KeyAgreement:       []did.Verification{*did.NewEmbeddedVerification(keyAgreement, did.KeyAgreement)},
+	}
+
+	if ep != nil {
+		didDoc.Service = []did.Service{{
+			ID:              didID + "#did-communication",
+			Type:            vdrapi.DIDCommServiceType,
+			ServiceEndpoint: ep.Endpoint,
+			RecipientKeys:   []string{base58.Encode(verkey)},
+			RoutingKeys:     ep.RoutingKeys,
+		}}
+	}
+
+	return didDoc, nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+func TestRead(t *testing.T) {
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	indyDID := base58.Encode(pubKey[:indyDIDSize])
+	didID := "did:sov:" + indyDID
+
+	t.Run("test resolve NYM", func(t *testing.T) {
+		v, err := New(&fakeLedger{nyms: map[string]*Nym{
+			indyDID: {Dest: indyDID, Verkey: base58.Encode(pubKey)},
+		}})
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(didID)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didID, doc.ID)
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, didID+"#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+		require.Empty(t, doc.Service)
+
+		// the resolved DID doc is valid
+		docBytes, err := doc.JSONBytes()
+		require.NoError(t, err)
+
+		_, err = did.ParseDocument(docBytes)
+		require.NoError(t, err)
+	})
+
+	t.Run("test resolve NYM with abbreviated verkey and endpoint", func(t *testing.T) {
+		v, err := New(&fakeLedger{
+			nyms: map[string]*Nym{
+				indyDID: {Dest: indyDID, Verkey: "~" + base58.Encode(pubKey[indyDIDSize:])},
+			},
+			attribs: map[string]string{
+				indyDID: `{"endpoint":{"endpoint":"https://agent.example.com","routingKeys":["routing-key"]}}`,
+			},
+		})
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(didID)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, []byte(pubKey), doc.VerificationMethod[0].Value)
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, vdrapi.DIDCommServiceType, doc.Service[0].Type)
+		require.Equal(t, "https://agent.example.com", doc.Service[0].ServiceEndpoint)
+		require.Equal(t, []string{base58.Encode(pubKey)}, doc.Service[0].RecipientKeys)
+		require.Equal(t, []string{"routing-key"}, doc.Service[0].RoutingKeys)
+	})
+
+	t.Run("test resolve NYM with endpoint URL", func(t *testing.T) {
+		v, err := New(&fakeLedger{
+			nyms:    map[string]*Nym{indyDID: {Dest: indyDID, Verkey: base58.Encode(pubKey)}},
+			attribs: map[string]string{indyDID: `{"endpoint":"https://agent.example.com"}`},
+		})
+		require.NoError(t, err)
+
+		docResolution, err := v.Read(didID)
+		require.NoError(t, err)
+		require.Equal(t, "https://agent.example.com", docResolution.DIDDocument.Service[0].ServiceEndpoint)
+		require.Empty(t, docResolution.DIDDocument.Service[0].RoutingKeys)
+	})
+
+	t.Run("test DID not found", func(t *testing.T) {
+		v, err := New(&fakeLedger{})
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, vdrapi.ErrNotFound))
+	})
+
+	t.Run("test invalid DID", func(t *testing.T) {
+		v, err := New(&fakeLedger{})
+		require.NoError(t, err)
+
+		_, err = v.Read("invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to parse DID")
+
+		_, err = v.Read("did:key:" + indyDID)
+		require.EqualError(t, err, "did:sov vdr Read: invalid did:sov method: key")
+
+		_, err = v.Read("did:sov:" + base58.Encode(pubKey))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid did:sov method ID")
+	})
+
+	t.Run("test invalid ledger data", func(t *testing.T) {
+		v, err := New(&fakeLedger{nyms: map[string]*Nym{indyDID: {Dest: indyDID, Verkey: "~invalid"}}})
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.EqualError(t, err, "did:sov vdr Read: invalid NYM verkey: ~invalid")
+
+		v, err = New(&fakeLedger{
+			nyms:    map[string]*Nym{indyDID: {Dest: indyDID, Verkey: base58.Encode(pubKey)}},
+			attribs: map[string]string{indyDID: `{"endpoint":{}}`},
+		})
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.EqualError(t, err, "did:sov vdr Read: endpoint ATTRIB has no endpoint")
+
+		v, err = New(&fakeLedger{
+			nyms:    map[string]*Nym{indyDID: {Dest: indyDID, Verkey: base58.Encode(pubKey)}},
+			attribs: map[string]string{indyDID: `{"endpoint":1}`},
+		})
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to unmarshal endpoint")
+
+		v, err = New(&fakeLedger{
+			nyms:      map[string]*Nym{indyDID: {Dest: indyDID, Verkey: base58.Encode(pubKey)}},
+			attribErr: errors.New("pool timeout"),
+		})
+		require.NoError(t, err)
+
+		_, err = v.Read(didID)
+		require.EqualError(t, err, "did:sov vdr Read: failed to get endpoint ATTRIB: pool timeout")
+	})
+}
+
+// fakeLedger is the ledger client reading the transactions of a fake Indy pool.
+type fakeLedger struct {
+	nyms      map[string]*Nym
+	attribs   map[string]string
+	attribErr error
+}
+
+func (l *fakeLedger) GetNym(indyDID string) (*Nym, error) {
+	nym, ok := l.nyms[indyDID]
+	if !ok {
+		return nil, vdrapi.ErrNotFound
+	}
+
+	return nym, nil
+}
+
+func (l *fakeLedger) GetAttrib(indyDID, name string) (string, error) {
+	if l.attribErr != nil {
+		return "", l.attribErr
+	}
+
+	attrib, ok := l.attribs[indyDID]
+	if !ok || name != EndpointAttrib {
+		return "", vdrapi.ErrNotFound
+	}
+
+	return attrib, nil
+}
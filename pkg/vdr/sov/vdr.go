@@ -0,0 +1,78 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+)
+
+const (
+	namespace = "sov"
+
+	// EndpointAttrib is the name of the ATTRIB holding the DIDComm endpoint of the Indy DID.
+	EndpointAttrib = "endpoint"
+)
+
+// LedgerClient reads the transactions of Indy DIDs from the ledger, e.g. using an Indy pool connector.
+type LedgerClient interface {
+	// GetNym returns the NYM transaction data of the Indy DID (the method specific ID of the did:sov DID).
+	// It returns vdrapi.ErrNotFound if the DID is not written to the ledger.
+	GetNym(did string) (*Nym, error)
+	// GetAttrib returns the raw value of the named ATTRIB of the Indy DID.
+	// It returns vdrapi.ErrNotFound if the DID has no such attribute.
+	GetAttrib(did, name string) (string, error)
+}
+
+// Nym is the data of a NYM transaction.
+type Nym struct {
+	Dest   string `json:"dest"`
+	Verkey string `json:"verkey"`
+	Role   string `json:"role,omitempty"`
+}
+
+// VDR resolves did:sov DIDs from an Indy ledger.
+type VDR struct {
+	ledger LedgerClient
+}
+
+// New creates a new did:sov VDR reading the DIDs using the ledger client.
+func New(ledger LedgerClient) (*VDR, error) {
+	if ledger == nil {
+		return nil, errors.New("ledger client is required")
+	}
+
+	return &VDR{ledger: ledger}, nil
+}
+
+// Accept method of the VDR interface.
+func (v *VDR) Accept(method string) bool {
+	return method == namespace
+}
+
+// Create did doc.
+func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return nil, fmt.Errorf("build not supported in did:sov vdr")
+}
+
+// Update did doc.
+func (v *VDR) Update(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("not supported")
+}
+
+// Deactivate did doc.
+func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
+	return fmt.Errorf("deactivation not supported")
+}
+
+// Close method of the VDR interface.
+func (v *VDR) Close() error {
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVDRMethods(t *testing.T) {
+	t.Run("test base vdr methods", func(t *testing.T) {
+		v, err := New(&fakeLedger{})
+		require.NoError(t, err)
+
+		require.True(t, v.Accept("sov"))
+		require.False(t, v.Accept("key"))
+		require.NoError(t, v.Close())
+	})
+
+	t.Run("test ledger client is required", func(t *testing.T) {
+		v, err := New(nil)
+		require.EqualError(t, err, "ledger client is required")
+		require.Nil(t, v)
+	})
+}
+
+func TestCreateDID(t *testing.T) {
+	v, err := New(&fakeLedger{})
+	require.NoError(t, err)
+
+	d, err := v.Create(nil)
+	require.Nil(t, d)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "build not supported")
+}
+
+func TestUpdate(t *testing.T) {
+	v, err := New(&fakeLedger{})
+	require.NoError(t, err)
+
+	err = v.Update(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+}
+
+func TestDeactivate(t *testing.T) {
+	v, err := New(&fakeLedger{})
+	require.NoError(t, err)
+
+	err = v.Deactivate("")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not supported")
+}
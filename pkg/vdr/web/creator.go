@@ -13,7 +13,17 @@ import (
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
-// Create creates a did:web diddoc (unsupported at the moment).
+// Create publishes the did:web diddoc (supported only if the publisher is configured, see WithPublisher).
+// The ID of the diddoc is the did:web DID, it defines the location the diddoc is published to.
 func (v *VDR) Create(didDoc *did.Doc, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
+	if v.publisher == nil {
+		return nil, fmt.Errorf("error building did:web did doc --> build not supported in http binding vdr")
+	}
+
+	err := v.publish(didDoc)
+	if err != nil {
+		return nil, fmt.Errorf("error building did:web did doc --> %w", err)
+	}
+
+	return &did.DocResolution{DIDDocument: didDoc}, nil
 }
@@ -7,9 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	didapi "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestCreateDID(t *testing.T) {
@@ -20,4 +28,97 @@ func TestCreateDID(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "build not supported in http binding vdr")
 	})
+
+	t.Run("test create then resolve did", func(t *testing.T) {
+		publisher := newMemPublisher()
+		v := New(WithPublisher(publisher))
+
+		didDoc := newTestDoc(t, validDIDWithPath)
+
+		docResolution, err := v.Create(didDoc)
+		require.NoError(t, err)
+		require.Equal(t, didDoc, docResolution.DIDDocument)
+		require.Contains(t, publisher.docs, "https://"+validURLWithPath+documentPath)
+
+		docResolution, err = v.Read(validDIDWithPath,
+			vdrapi.WithOption(HTTPClientOpt, &http.Client{Transport: publisher}))
+		require.NoError(t, err)
+		require.Equal(t, validDIDWithPath, docResolution.DIDDocument.ID)
+		require.Len(t, docResolution.DIDDocument.VerificationMethod, 1)
+		require.Equal(t, didDoc.VerificationMethod[0].Value, docResolution.DIDDocument.VerificationMethod[0].Value)
+	})
+
+	t.Run("test create did publish failure", func(t *testing.T) {
+		publisher := newMemPublisher()
+		publisher.err = errors.New("bucket not found")
+
+		v := New(WithPublisher(publisher))
+
+		d, err := v.Create(newTestDoc(t, validDID))
+		require.Nil(t, d)
+		require.EqualError(t, err, "error building did:web did doc --> failed to publish did doc to "+
+			"https://"+validURL+defaultPath+" --> bucket not found")
+	})
+
+	t.Run("test create did with invalid did doc", func(t *testing.T) {
+		v := New(WithPublisher(newMemPublisher()))
+
+		d, err := v.Create(&didapi.Doc{})
+		require.Nil(t, d)
+		require.EqualError(t, err, "error building did:web did doc --> did doc ID is required")
+
+		d, err = v.Create(&didapi.Doc{ID: invalidDIDNoPrefix})
+		require.Nil(t, d)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "could not parse did:web did")
+	})
+}
+
+func newTestDoc(t *testing.T, didID string) *didapi.Doc {
+	t.Helper()
+
+	vm := didapi.NewVerificationMethodFromBytes(didID+"#key-1", "Ed25519VerificationKey2018", didID,
+		[]byte("public key bytes"))
+
+	return &didapi.Doc{
+		Context:            []string{didapi.ContextV1},
+		ID:                 didID,
+		VerificationMethod: []didapi.VerificationMethod{*vm},
+	}
+}
+
+// memPublisher publishes DID documents in memory and serves them as HTTP transport.
+type memPublisher struct {
+	mu   sync.RWMutex
+	docs map[string][]byte
+	err  error
+}
+
+func newMemPublisher() *memPublisher {
+	return &memPublisher{docs: make(map[string][]byte)}
+}
+
+func (p *memPublisher) Publish(docURL string, doc []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.docs[docURL] = doc
+
+	return nil
+}
+
+func (p *memPublisher) RoundTrip(req *http.Request) (*http.Response, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	doc, ok := p.docs[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(&bytes.Buffer{})}, nil
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(doc))}, nil
 }
@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
@@ -20,13 +22,18 @@ import (
 const (
 	// HTTPClientOpt http client opt.
 	HTTPClientOpt = "httpClient"
+	// TimeoutOpt http request timeout opt (time.Duration).
+	TimeoutOpt = "timeout"
 )
 
 var logger = log.New("aries-framework/pkg/vdr/web")
 
 // Read resolves a did:web did.
 func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
-	httpClient := &http.Client{}
+	httpClient := v.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
 
 	didOpts := &vdrapi.DIDMethodOpts{Values: make(map[string]interface{})}
 	// Apply options
@@ -43,12 +50,32 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 		}
 	}
 
+	ctx := context.Background()
+
+	t, ok := didOpts.Values[TimeoutOpt]
+	if ok {
+		timeout, isDuration := t.(time.Duration)
+		if !isDuration {
+			return nil, fmt.Errorf("failed to cast timeout opt to time duration")
+		}
+
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	address, _, err := parseDIDWeb(didID)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving did:web did --> could not parse did:web did --> %w", err)
 	}
 
-	resp, err := httpClient.Get(address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving did:web did --> failed to create http request --> %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error resolving did:web did --> http request unsuccessful --> %w", err)
 	}
@@ -56,7 +83,8 @@ func (v *VDR) Read(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolu
 	defer closeResponseBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http server returned status code [%d]", resp.StatusCode)
+		return nil, fmt.Errorf("error resolving did:web did --> http server returned status code [%d] for %s",
+			resp.StatusCode, address)
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
@@ -14,6 +14,7 @@ import (
 	urlapi "net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -116,6 +117,23 @@ func TestResolveDID(t *testing.T) {
 		require.Nil(t, err)
 		require.Equal(t, expectedDoc, docResolution.DIDDocument)
 	})
+	t.Run("test resolve did with the http client of the vdr", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte(validDoc))
+			require.NoError(t, err)
+		}))
+		defer s.Close()
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+		v := New(WithHTTPClient(s.Client()))
+		docResolution, err := v.Read(did)
+		require.Nil(t, err)
+		require.Equal(t, "did:web:www.example.org", docResolution.DIDDocument.ID)
+
+		// the client of the resolution options takes precedence.
+		_, err = v.Read(did, vdrapi.WithOption(HTTPClientOpt, &http.Client{}))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "http request unsuccessful")
+	})
 	t.Run("test resolve did with path success", func(t *testing.T) {
 		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write([]byte(validDoc))
@@ -141,6 +159,31 @@ func TestResolveDID(t *testing.T) {
 		v := New()
 		_, err := v.Read(did, vdrapi.WithOption(HTTPClientOpt, s.Client()))
 		require.Error(t, err)
+		require.Contains(t, err.Error(), "http server returned status code [404]")
+	})
+	t.Run("test resolve did with timeout", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}))
+		defer s.Close()
+
+		did := fmt.Sprintf("did:web:%s", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+
+		v := New()
+		doc, err := v.Read(did, vdrapi.WithOption(HTTPClientOpt, s.Client()),
+			vdrapi.WithOption(TimeoutOpt, 10*time.Millisecond))
+		require.Nil(t, doc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "context deadline exceeded")
+	})
+	t.Run("test resolve did with invalid timeout opt", func(t *testing.T) {
+		v := New()
+		doc, err := v.Read(validDID, vdrapi.WithOption(TimeoutOpt, "1s"))
+		require.Nil(t, doc)
+		require.EqualError(t, err, "failed to cast timeout opt to time duration")
 	})
 }
 
@@ -8,6 +8,7 @@ package web
 
 import (
 	"fmt"
+	"net/http"
 
 	diddoc "github.com/hyperledger/aries-framework-go/pkg/doc/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
@@ -17,12 +18,48 @@ const (
 	namespace = "web"
 )
 
+// Publisher publishes the DID documents of did:web DIDs, e.g. to an object store or the filesystem
+// served by the web server of the DID domain.
+type Publisher interface {
+	// Publish writes the DID document to the location the document is resolved from,
+	// docURL is the URL of the document (e.g. https://example.com/.well-known/did.json).
+	Publish(docURL string, doc []byte) error
+}
+
 // VDR implements the VDR interface.
-type VDR struct{}
+type VDR struct {
+	publisher  Publisher
+	httpClient *http.Client
+}
+
+// Option configures the did:web vdr.
+type Option func(v *VDR)
 
 // New creates a new VDR struct.
-func New() *VDR {
-	return &VDR{}
+func New(opts ...Option) *VDR {
+	v := &VDR{}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// WithPublisher option is for publishing the created and updated DID documents using the publisher.
+// Without the publisher did:web DID documents can't be created nor updated.
+func WithPublisher(publisher Publisher) Option {
+	return func(v *VDR) {
+		v.publisher = publisher
+	}
+}
+
+// WithHTTPClient option is for resolving did:web DIDs with the given HTTP client (e.g. to pin the TLS certificates
+// of the DID domains or to go through a proxy). The HTTPClientOpt resolution option takes precedence over it.
+func WithHTTPClient(client *http.Client) Option {
+	return func(v *VDR) {
+		v.httpClient = client
+	}
 }
 
 // Accept method of the VDR interface.
@@ -30,14 +67,46 @@ func (v *VDR) Accept(method string) bool {
 	return method == namespace
 }
 
-// Update did doc.
+// Update publishes the updated did doc.
 func (v *VDR) Update(didDoc *diddoc.Doc, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	if v.publisher == nil {
+		return fmt.Errorf("not supported")
+	}
+
+	err := v.publish(didDoc)
+	if err != nil {
+		return fmt.Errorf("error updating did:web did doc --> %w", err)
+	}
+
+	return nil
 }
 
 // Deactivate did doc.
 func (v *VDR) Deactivate(did string, opts ...vdrapi.DIDMethodOption) error {
-	return fmt.Errorf("not supported")
+	return fmt.Errorf("deactivation not supported")
+}
+
+func (v *VDR) publish(didDoc *diddoc.Doc) error {
+	if didDoc == nil || didDoc.ID == "" {
+		return fmt.Errorf("did doc ID is required")
+	}
+
+	address, _, err := parseDIDWeb(didDoc.ID)
+	if err != nil {
+		return fmt.Errorf("could not parse did:web did --> %w", err)
+	}
+
+	docBytes, err := didDoc.JSONBytes()
+	if err != nil {
+		return fmt.Errorf("failed to marshal did doc --> %w", err)
+	}
+
+	err = v.publisher.Publish(address, docBytes)
+	if err != nil {
+		return fmt.Errorf("failed to publish did doc to %s --> %w", address, err)
+	}
+
+	return nil
 }
 
 // Close method of the VDR interface.
@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package web
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	didapi "github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 )
 
 func TestVDRMethods(t *testing.T) {
@@ -29,6 +33,32 @@ func TestUpdate(t *testing.T) {
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "not supported")
 	})
+
+	t.Run("test update publishes did doc", func(t *testing.T) {
+		publisher := newMemPublisher()
+		v := New(WithPublisher(publisher))
+
+		didDoc := newTestDoc(t, validDID)
+
+		_, err := v.Create(didDoc)
+		require.NoError(t, err)
+
+		didDoc.Service = []didapi.Service{{
+			ID:              validDID + "#did-communication",
+			Type:            vdrapi.DIDCommServiceType,
+			ServiceEndpoint: "https://www.example.org/didcomm",
+		}}
+
+		require.NoError(t, v.Update(didDoc))
+
+		docResolution, err := v.Read(validDID, vdrapi.WithOption(HTTPClientOpt, &http.Client{Transport: publisher}))
+		require.NoError(t, err)
+		require.Len(t, docResolution.DIDDocument.Service, 1)
+		require.Equal(t, "https://www.example.org/didcomm", docResolution.DIDDocument.Service[0].ServiceEndpoint)
+
+		err = v.Update(&didapi.Doc{})
+		require.EqualError(t, err, "error updating did:web did doc --> did doc ID is required")
+	})
 }
 
 func TestDeactivate(t *testing.T) {
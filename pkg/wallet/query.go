@@ -41,7 +41,7 @@ const (
 
 // Name returns name of the query.
 func (q QueryType) Name() string {
-	return []string{"", "QueryByExample", "QueryByFrame", "PresentationExchange"}[q]
+	return []string{"", "QueryByExample", "QueryByFrame", "PresentationExchange", "DIDAuth"}[q]
 }
 
 // GetQueryType returns QueryType instance for given string query type.
@@ -56,8 +56,8 @@ func GetQueryType(name string) (QueryType, error) {
 	case "didauth":
 		return DIDAuth, nil
 	default:
-		return 0, fmt.Errorf("unsupported query type, supported types - (%s, %s, %s)",
-			QueryByExample.Name(), QueryByFrame.Name(), PresentationExchange.Name())
+		return 0, fmt.Errorf("unsupported query type, supported types - (%s, %s, %s, %s)",
+			QueryByExample.Name(), QueryByFrame.Name(), PresentationExchange.Name(), DIDAuth.Name())
 	}
 }
 
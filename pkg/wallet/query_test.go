@@ -221,6 +221,7 @@ func TestGetQueryType(t *testing.T) {
 				for _, str := range tc.typeStr {
 					qType, err := GetQueryType(str)
 					require.Equal(t, qType, tc.expected)
+					require.Equal(t, tc.expectedName, qType.Name())
 					if tc.error != "" {
 						require.Error(t, err)
 						require.Contains(t, err.Error(), tc.error)
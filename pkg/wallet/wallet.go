@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/piprate/json-gold/ld"
 
@@ -678,6 +679,25 @@ func (c *Wallet) verifyPresentation(authToken string, presentation json.RawMessa
 	return true, nil
 }
 
+// jwsSigner returns the signer with the JWS algorithm of the key of the verification method, which the
+// JsonWebSignature2020 suite puts in the header of its proofs.
+func (c *Wallet) jwsSigner(authToken string, s suite.Signer, verificationMethod string) (suite.Signer, error) {
+	vmSplit := strings.Split(verificationMethod, "#")
+
+	pubKey, err := verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).
+		PublicKeyFetcher()(vmSplit[0], "#"+vmSplit[vmSectionCount-1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve verification method: %w", err)
+	}
+
+	alg, err := jsonwebsignature2020.VerificationMethodJWSAlgorithm(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get JWS algorithm of the verification method: %w", err)
+	}
+
+	return jsonwebsignature2020.NewSignerWithJWSAlgorithm(s, alg), nil
+}
+
 func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOptions,
 	relationship did.VerificationRelationship) error {
 	s, err := newKMSSigner(authToken, c.walletCrypto, opts)
@@ -691,7 +711,12 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 	case Ed25519Signature2018:
 		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
 	case JSONWebSignature2020:
-		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+		jwsSigner, e := c.jwsSigner(authToken, s, opts.VerificationMethod)
+		if e != nil {
+			return e
+		}
+
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(jwsSigner))
 	case BbsBlsSignature2020:
 		addContext(p, bbsContext)
 
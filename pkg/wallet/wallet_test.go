@@ -1309,6 +1309,11 @@ func TestWallet_Issue(t *testing.T) {
 		require.Equal(t, result.Proofs[0]["proofPurpose"], "assertionMethod")
 		require.Equal(t, result.Proofs[0]["type"], JSONWebSignature2020)
 		require.Equal(t, result.Proofs[0]["verificationMethod"], vm)
+
+		// the JWS alg is derived from the Ed25519 key of the verification method
+		jwsHeader, err := base64.RawURLEncoding.DecodeString(strings.Split(result.Proofs[0]["jws"].(string), ".")[0])
+		require.NoError(t, err)
+		require.Contains(t, string(jwsHeader), `"alg":"EdDSA"`)
 	})
 
 	t.Run("Test VC wallet issue using BBS - success", func(t *testing.T) {
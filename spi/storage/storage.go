@@ -189,6 +189,21 @@ type Store interface {
 	Close() error
 }
 
+// PaginatedStore is implemented by Stores that can return query results one page at a time.
+// Callers can check whether a Store supports it with a type assertion.
+type PaginatedStore interface {
+	// QueryWithPagination returns the data that satisfies the expression one page at a time. The expression format is
+	// the same as in Store.Query. Results are ordered by key, a page is read from the entry following the bookmark
+	// without going through the whole store. The returned Iterator holds at most pageSize entries, while its
+	// TotalItems method counts all the entries matched by the expression when it is called.
+	// Pass a blank bookmark to get the first page. To get the following page, pass the returned nextBookmark.
+	// nextBookmark is blank when there are no more pages. Bookmarks are opaque. A bookmark stays valid when entries
+	// are added or deleted between calls.
+	// If pageSize is not positive or the bookmark is invalid, then an error will be returned.
+	QueryWithPagination(expression string, pageSize int, bookmark string) (results Iterator, nextBookmark string,
+		err error)
+}
+
 // Iterator allows for iteration over a collection of entries in a store.
 type Iterator interface {
 	// Next moves the pointer to the next entry in the iterator.